@@ -0,0 +1,39 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// AddFrame draws the plot at the given size as the next frame
+// of the animation a. A live plot may so be added as a frame
+// after each call of its Update method.
+func (p *Plot) AddFrame(a *vgimg.Animation, w, h vg.Length) error {
+	c := vgimg.New(w, h)
+	if err := p.DrawLimited(draw.New(c)); err != nil {
+		return err
+	}
+	return a.Add(c)
+}
+
+// Animate adds n frames of the given size to the animation a,
+// drawing the plot returned by frame(i) as the frame i. The
+// returned plots may be built for each frame, or be the same
+// plot changed by frame.
+func Animate(a *vgimg.Animation, w, h vg.Length, n int, frame func(i int) (*Plot, error)) error {
+	for i := 0; i < n; i++ {
+		p, err := frame(i)
+		if err != nil {
+			return err
+		}
+		if err := p.AddFrame(a, w, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import "math"
+
+// A Ranger returns the range of an axis fitting
+// the range of the data drawn along it.
+type Ranger interface {
+	// Range returns the range of an axis
+	// given the range of its data.
+	Range(min, max float64) (float64, float64)
+}
+
+// fit extends the range of the axis to fit the range
+// of the data from min to max, as set by AutoRange.
+func (a *Axis) fit(min, max float64) {
+	if min > max {
+		// There are no data.
+		return
+	}
+	if a.AutoRange != nil {
+		min, max = a.AutoRange.Range(min, max)
+	}
+	a.Min = math.Min(a.Min, min)
+	a.Max = math.Max(a.Max, max)
+}
+
+// ExactRange is suitable for the AutoRange field of an
+// Axis, it returns the range of the data, as a nil
+// AutoRange does.
+type ExactRange struct{}
+
+var _ Ranger = ExactRange{}
+
+// Range returns min and max.
+func (ExactRange) Range(min, max float64) (float64, float64) {
+	return min, max
+}
+
+// PaddedRange is suitable for the AutoRange field of an
+// Axis, it returns the range of the data padded on each
+// side by a fraction of its width, so that the data are
+// not drawn at the edges of the plot.
+type PaddedRange struct {
+	// Fraction is the fraction of the width
+	// of the range padded on each side.
+	Fraction float64
+}
+
+var _ Ranger = PaddedRange{}
+
+// Range returns the range from min to max padded by
+// the fraction of its width.
+func (r PaddedRange) Range(min, max float64) (float64, float64) {
+	pad := (max - min) * r.Fraction
+	return min - pad, max + pad
+}
+
+// NiceRange is suitable for the AutoRange field of an
+// Axis, it returns the range of the data rounded out to
+// the nearest major tick marks of DefaultTicks, so that
+// the axis starts and ends at round numbers.
+type NiceRange struct{}
+
+var _ Ranger = NiceRange{}
+
+// Range returns the range from min to max rounded out
+// to multiples of the distance between the major tick
+// marks returned by DefaultTicks.
+func (NiceRange) Range(min, max float64) (float64, float64) {
+	if min == max || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return min, max
+	}
+	delta, _ := majorTickDelta(min, max)
+	return math.Floor(min/delta) * delta, math.Ceil(max/delta) * delta
+}
+
+// IncludeZero is suitable for the AutoRange field of an
+// Axis, it extends the range of the data to include zero,
+// so that bars and magnitudes are drawn from zero, before
+// passing it to Then. IncludeZero must not be used on an
+// axis with a log scale.
+type IncludeZero struct {
+	// Then, if not nil, returns the range of
+	// the axis given the extended range.
+	Then Ranger
+}
+
+var _ Ranger = IncludeZero{}
+
+// Range returns the range from min to max including
+// zero, as returned by r.Then if it is not nil.
+func (r IncludeZero) Range(min, max float64) (float64, float64) {
+	return subRange(r.Then, math.Min(min, 0), math.Max(max, 0))
+}
+
+// SymmetricRange is suitable for the AutoRange field of
+// an Axis, it extends the range of the data to be
+// symmetric about zero, so that deviations on either side
+// of zero are drawn alike, before passing it to Then.
+// SymmetricRange must not be used on an axis with a log
+// scale.
+type SymmetricRange struct {
+	// Then, if not nil, returns the range of
+	// the axis given the extended range.
+	Then Ranger
+}
+
+var _ Ranger = SymmetricRange{}
+
+// Range returns the range from min to max extended to be
+// symmetric about zero, as returned by r.Then if it is not
+// nil.
+func (r SymmetricRange) Range(min, max float64) (float64, float64) {
+	m := math.Max(math.Abs(min), math.Abs(max))
+	return subRange(r.Then, -m, m)
+}
+
+// subRange returns the range from min to max as
+// returned by r, or unchanged if r is nil.
+func subRange(r Ranger, min, max float64) (float64, float64) {
+	if r == nil {
+		return min, max
+	}
+	return r.Range(min, max)
+}
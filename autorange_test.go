@@ -0,0 +1,73 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"testing"
+
+	"github.com/gonum/plot/vg/draw"
+)
+
+func TestRanger(t *testing.T) {
+	for _, test := range []struct {
+		r                Ranger
+		min, max         float64
+		wantMin, wantMax float64
+	}{
+		{r: ExactRange{}, min: 0.3, max: 9.2, wantMin: 0.3, wantMax: 9.2},
+		{r: PaddedRange{Fraction: 0.1}, min: 10, max: 20, wantMin: 9, wantMax: 21},
+		{r: PaddedRange{Fraction: 0.1}, min: 5, max: 5, wantMin: 5, wantMax: 5},
+		{r: NiceRange{}, min: 0.3, max: 9.2, wantMin: 0, wantMax: 10},
+		{r: NiceRange{}, min: 103, max: 147, wantMin: 100, wantMax: 150},
+		{r: NiceRange{}, min: -0.42, max: 0.77, wantMin: -0.6, wantMax: 0.9},
+		{r: IncludeZero{}, min: 3, max: 7, wantMin: 0, wantMax: 7},
+		{r: IncludeZero{}, min: -7, max: -3, wantMin: -7, wantMax: 0},
+		{r: IncludeZero{Then: NiceRange{}}, min: 3, max: 97, wantMin: 0, wantMax: 120},
+		{r: SymmetricRange{}, min: -2, max: 5, wantMin: -5, wantMax: 5},
+		{r: SymmetricRange{Then: PaddedRange{Fraction: 0.1}}, min: -2, max: 5, wantMin: -6, wantMax: 6},
+	} {
+		min, max := test.r.Range(test.min, test.max)
+		if !approxEqual(min, test.wantMin) || !approxEqual(max, test.wantMax) {
+			t.Errorf("unexpected range of %#v for [%g, %g]: got:[%g, %g] want:[%g, %g]",
+				test.r, test.min, test.max, min, max, test.wantMin, test.wantMax)
+		}
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	const tol = 1e-12
+	return a-b < tol && b-a < tol
+}
+
+// rangeData is a plotter of the given data range.
+type rangeData struct {
+	xmin, xmax, ymin, ymax float64
+}
+
+func (rangeData) Plot(draw.Canvas, *Plot) {}
+
+func (d rangeData) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return d.xmin, d.xmax, d.ymin, d.ymax
+}
+
+func TestAutoRange(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.AutoRange = PaddedRange{Fraction: 0.1}
+	p.Y.AutoRange = IncludeZero{}
+	p.Add(rangeData{10, 20, 3, 7})
+	if p.X.Min != 9 || p.X.Max != 21 || p.Y.Min != 0 || p.Y.Max != 7 {
+		t.Errorf("unexpected axis ranges: got:[%g, %g] [%g, %g]", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+
+	// The ranges are fitted to all of the data
+	// of the plotters, rather than padded again.
+	p.Add(rangeData{15, 30, 4, 8})
+	if p.X.Min != 8 || p.X.Max != 32 || p.Y.Min != 0 || p.Y.Max != 8 {
+		t.Errorf("unexpected axis ranges: got:[%g, %g] [%g, %g]", p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+}
@@ -7,7 +7,9 @@ package plot
 import (
 	"image/color"
 	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gonum/floats"
@@ -48,6 +50,10 @@ type Axis struct {
 		// counterclockwise will be added to the label
 		// text before drawing.
 		draw.TextStyle
+
+		// Padding is the distance between the axis
+		// label and the tick labels.
+		Padding vg.Length
 	}
 
 	// LineStyle is the style of the axis line.
@@ -58,30 +64,125 @@ type Axis struct {
 	// on the axis, thus making it easier to see.
 	Padding vg.Length
 
+	// Tick holds the style of the tick marks and their
+	// labels, and the functions returning them. The labels
+	// of the major tick marks returned by Marker may be
+	// replaced by those of a formatter: FormatAll if it
+	// is set, or else FormatIndex, or else Format. Tick
+	// marks whose label is empty, as returned by Marker
+	// or by any of the formatters, are minor tick marks.
 	Tick struct {
 		// Label is the TextStyle on the tick labels.
 		Label draw.TextStyle
 
+		// LabelPadding is the distance between the tick
+		// labels and the tick marks. If LabelPadding is
+		// zero, the tick labels of a vertical axis are
+		// separated from the tick marks by the width of
+		// a space, and those of a horizontal axis are
+		// not separated.
+		LabelPadding vg.Length
+
 		// LineStyle is the LineStyle of the tick lines.
 		draw.LineStyle
 
 		// Length is the length of a major tick mark.
-		// Minor tick marks are half of the length of major
-		// tick marks.
 		Length vg.Length
 
+		// MinorLineStyle is the LineStyle of the minor
+		// tick lines. If its Width is zero, LineStyle is
+		// used.
+		MinorLineStyle draw.LineStyle
+
+		// MinorLength is the length of a minor tick mark.
+		// If MinorLength is zero, minor tick marks are half
+		// of the length of major tick marks.
+		MinorLength vg.Length
+
 		// Marker returns the tick marks.  Any tick marks
 		// returned by the Marker function that are not in
 		// range of the axis are not drawn.
 		Marker Ticker
+
+		// Format, if not nil, returns the label of each
+		// major tick mark returned by Marker, given its
+		// value.
+		Format func(v float64) string
+
+		// FormatIndex, if not nil, returns the label of
+		// each major tick mark returned by Marker, given
+		// its index among the major tick marks, its value
+		// and the range of the axis.
+		FormatIndex func(i int, v, min, max float64) string
+
+		// FormatAll, if not nil, returns the labels of
+		// all of the major tick marks returned by Marker
+		// at once, given their values in order and the
+		// range of the axis, so that the labels may share
+		// an exponent or a number of decimal places, or
+		// omit the parts repeated from their neighbors.
+		// It must return a label for each value.
+		// EqualDecimals, SharedExponent and ConciseTimes
+		// return such labels.
+		FormatAll func(values []float64, min, max float64) []string
+
+		// Overlap specifies how tick labels that would
+		// overlap along the axis are resolved. By default
+		// they are drawn as they are.
+		Overlap TickOverlap
 	}
 
 	// Scale transforms a value given in the data coordinate system
 	// to the normalized coordinate system of the axis—its distance
 	// along the axis as a fraction of the axis range.
 	Scale Normalizer
+
+	// AutoRange, if not nil, returns the range of the
+	// axis fitting the range of the data of the plotters
+	// added to the plot, such as a range padded around
+	// the data or rounded to the tick marks. If it is nil,
+	// the range of the axis is the range of the data.
+	// AutoRange must be set before the plotters are added.
+	AutoRange Ranger
+
+	// Arrow is the length of the arrowhead drawn at the
+	// maximum end of the axis line. If Arrow is zero, no
+	// arrowhead is drawn.
+	Arrow vg.Length
 }
 
+// TickOverlap specifies how the overlapping tick
+// labels of an axis are resolved.
+type TickOverlap int
+
+const (
+	// OverlapAllowed draws overlapping
+	// tick labels as they are.
+	OverlapAllowed TickOverlap = iota
+
+	// ThinTickLabels removes the labels of all but every
+	// k-th labeled tick mark, for the smallest k such that
+	// the remaining labels do not overlap. The tick marks
+	// whose labels are removed are drawn as minor tick marks.
+	ThinTickLabels
+
+	// RotateTickLabels rotates the tick labels of a
+	// horizontal axis by 90°, to read upwards, and thins
+	// them if they still overlap. The tick labels of a
+	// vertical axis are thinned.
+	RotateTickLabels
+
+	// ShrinkTickLabels shrinks the font of the tick labels
+	// by steps of a tenth of its size, to no less than 60%
+	// of its size, and thins them if they still overlap.
+	ShrinkTickLabels
+)
+
+// tickLabelScales are the fractions of the size of
+// their font to which ShrinkTickLabels shrinks tick
+// labels, in turn.
+var tickLabelScales = []vg.Length{0.9, 0.8, 0.7, 0.6}
+
 // makeAxis returns a default Axis.
 //
 // The default range is (∞, ­∞), and thus any finite
@@ -175,6 +276,21 @@ func (LogScale) Normalize(min, max, x float64) float64 {
 	return (log(x) - logMin) / (log(max) - logMin)
 }
 
+// LogitScale can be used as the value of an Axis.Scale function to
+// set the axis to a logit scale, log(x/(1-x)), which spreads out
+// probabilities near 0 and 1. The range of the axis must be within
+// the open interval (0, 1).
+type LogitScale struct{}
+
+var _ Normalizer = LogitScale{}
+
+// Normalize returns the fractional logit distance of
+// x between min and max.
+func (LogitScale) Normalize(min, max, x float64) float64 {
+	logitMin := logit(min)
+	return (logit(x) - logitMin) / (logit(max) - logitMin)
+}
+
 // Norm returns the value of x, given in the data coordinate
 // system, normalized to its distance as a fraction of the
 // range of this axis.  For example, if x is a.Min then the return
@@ -183,15 +299,171 @@ func (a *Axis) Norm(x float64) float64 {
 	return a.Scale.Normalize(a.Min, a.Max, x)
 }
 
+// Value returns the value, in the data coordinate system, at the
+// normalized distance f along the axis, the inverse of Norm. Scales
+// other than LinearScale, LogScale, LogitScale and TransformScale
+// are taken to be linear.
+func (a *Axis) Value(f float64) float64 {
+	t := scaleTransform(a.Scale)
+	min, max := t.Forward(a.Min), t.Forward(a.Max)
+	return t.Inverse(min + f*(max-min))
+}
+
+// ticks returns the tick marks of the axis, with the
+// labels of the major tick marks formatted by the
+// Tick.Format, Tick.FormatIndex or Tick.FormatAll
+// functions, if set.
+func (a *Axis) ticks() []Tick {
+	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if a.Tick.Format == nil && a.Tick.FormatIndex == nil && a.Tick.FormatAll == nil {
+		return marks
+	}
+	// Copy the tick marks, since a Ticker such as
+	// ConstantTicks may return its own slice.
+	marks = append([]Tick(nil), marks...)
+	if a.Tick.FormatAll != nil {
+		var values []float64
+		for _, t := range marks {
+			if !t.IsMinor() {
+				values = append(values, t.Value)
+			}
+		}
+		labels := a.Tick.FormatAll(values, a.Min, a.Max)
+		if len(labels) != len(values) {
+			panic("plot: wrong number of tick labels")
+		}
+		i := 0
+		for j, t := range marks {
+			if t.IsMinor() {
+				continue
+			}
+			marks[j].Label = labels[i]
+			i++
+		}
+		return marks
+	}
+	i := 0
+	for j, t := range marks {
+		if t.IsMinor() {
+			continue
+		}
+		if a.Tick.FormatIndex != nil {
+			marks[j].Label = a.Tick.FormatIndex(i, t.Value, a.Min, a.Max)
+		} else {
+			marks[j].Label = a.Tick.Format(t.Value)
+		}
+		i++
+	}
+	return marks
+}
+
+// fitTicks returns the tick marks of the axis and the style
+// of their labels, with the overlaps of the labels along an
+// axis of the given length resolved as set by Tick.Overlap.
+// If the length is zero, the overlaps are not resolved.
+func (a *Axis) fitTicks(length vg.Length, horizontal bool) ([]Tick, draw.TextStyle) {
+	marks, sty := a.ticks(), a.Tick.Label
+	if a.Tick.Overlap == OverlapAllowed || length == 0 || !a.labelsOverlap(marks, sty, length, horizontal) {
+		return marks, sty
+	}
+	switch a.Tick.Overlap {
+	case RotateTickLabels:
+		if horizontal {
+			sty.Rotation = math.Pi / 2
+			sty.XAlign, sty.YAlign = draw.XRight, draw.YCenter
+		}
+	case ShrinkTickLabels:
+		size := sty.Font.Size
+		for _, scale := range tickLabelScales {
+			sty.Font.Size = size * scale
+			if !a.labelsOverlap(marks, sty, length, horizontal) {
+				return marks, sty
+			}
+		}
+	}
+	thinned := marks
+	for k := 2; k <= len(marks) && a.labelsOverlap(thinned, sty, length, horizontal); k++ {
+		thinned = thinTicks(marks, k)
+	}
+	return thinned, sty
+}
+
+// labelsOverlap returns whether the labels of the major tick
+// marks within the range of the axis, drawn with the style sty
+// along an axis of the given length, are not separated by at
+// least a quarter of the size of their font.
+func (a *Axis) labelsOverlap(marks []Tick, sty draw.TextStyle, length vg.Length, horizontal bool) bool {
+	gap := sty.Font.Size / 4
+	var prev vg.Length
+	first := true
+	for _, t := range marks {
+		if t.IsMinor() || t.Value < a.Min || t.Value > a.Max {
+			continue
+		}
+		pos := length * vg.Length(a.Norm(t.Value))
+		r := sty.Rectangle(t.Label)
+		min, max := pos+r.Min.X, pos+r.Max.X
+		if !horizontal {
+			min, max = pos+r.Min.Y, pos+r.Max.Y
+		}
+		if !first && min < prev+gap {
+			return true
+		}
+		prev, first = max, false
+	}
+	return false
+}
+
+// thinTicks returns a copy of marks with the labels of all
+// but every k-th labeled tick mark, from the first, removed.
+func thinTicks(marks []Tick, k int) []Tick {
+	thinned := append([]Tick(nil), marks...)
+	i := 0
+	for j, t := range thinned {
+		if t.IsMinor() {
+			continue
+		}
+		if i%k != 0 {
+			thinned[j].Label = ""
+		}
+		i++
+	}
+	return thinned
+}
+
 // drawTicks returns true if the tick marks should be drawn.
 func (a *Axis) drawTicks() bool {
 	return a.Tick.Width > 0 && a.Tick.Length > 0
 }
 
+// tickStyle returns the LineStyle and the length of the
+// line of the tick mark t.
+func (a *Axis) tickStyle(t Tick) (draw.LineStyle, vg.Length) {
+	if !t.IsMinor() {
+		return a.Tick.LineStyle, a.Tick.Length
+	}
+	sty, len := a.Tick.MinorLineStyle, a.Tick.MinorLength
+	if sty.Width == 0 {
+		sty = a.Tick.LineStyle
+	}
+	if len == 0 {
+		len = a.Tick.Length / 2
+	}
+	return sty, len
+}
+
 // A horizontalAxis draws horizontally across the bottom
 // of a plot.
 type horizontalAxis struct {
 	Axis
+
+	// length is the length of the axis, along which
+	// the overlaps of its tick labels are resolved.
+	length vg.Length
+
+	// noLine specifies that the axis line is not
+	// drawn, as for NoSpines.
+	noLine bool
 }
 
 // size returns the height of the axis.
@@ -199,12 +471,16 @@ func (a *horizontalAxis) size() (h vg.Length) {
 	if a.Label.Text != "" {
 		h -= a.Label.Font.Extents().Descent
 		h += a.Label.Height(a.Label.Text)
+		h += a.Label.Padding
 	}
-	if marks := a.Tick.Marker.Ticks(a.Min, a.Max); len(marks) > 0 {
+	if marks, sty := a.fitTicks(a.length, true); len(marks) > 0 {
 		if a.drawTicks() {
 			h += a.Tick.Length
 		}
-		h += tickLabelHeight(a.Tick.Label, marks)
+		if lheight := tickLabelHeight(sty, marks); lheight > 0 {
+			h += lheight
+			h += a.Tick.LabelPadding
+		}
 	}
 	h += a.Width / 2
 	h += a.Padding
@@ -218,20 +494,24 @@ func (a *horizontalAxis) draw(c draw.Canvas) {
 		y -= a.Label.Font.Extents().Descent
 		c.FillText(a.Label.TextStyle, vg.Point{X: c.Center().X, Y: y}, a.Label.Text)
 		y += a.Label.Height(a.Label.Text)
+		y += a.Label.Padding
 	}
 
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
-	ticklabelheight := tickLabelHeight(a.Tick.Label, marks)
+	marks, sty := a.fitTicks(a.length, true)
+	ticklabelheight := tickLabelHeight(sty, marks)
 	for _, t := range marks {
 		x := c.X(a.Norm(t.Value))
 		if !c.ContainsX(x) || t.IsMinor() {
 			continue
 		}
-		c.FillText(a.Tick.Label, vg.Point{X: x, Y: y + ticklabelheight}, t.Label)
+		c.FillText(sty, vg.Point{X: x, Y: y + ticklabelheight}, t.Label)
 	}
 
 	if len(marks) > 0 {
 		y += ticklabelheight
+		if ticklabelheight > 0 {
+			y += a.Tick.LabelPadding
+		}
 	} else {
 		y += a.Width / 2
 	}
@@ -243,18 +523,21 @@ func (a *horizontalAxis) draw(c draw.Canvas) {
 			if !c.ContainsX(x) {
 				continue
 			}
-			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x, y+start, x, y+len)
+			sty, tlen := a.tickStyle(t)
+			c.StrokeLine2(sty, x, y+len-tlen, x, y+len)
 		}
 		y += len
 	}
 
-	c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+	if !a.noLine {
+		c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+		a.drawArrow(c, vg.Point{X: c.Max.X, Y: y}, vg.Point{X: 1})
+	}
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels.
 func (a *horizontalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
-	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+	for _, t := range a.ticks() {
 		if t.IsMinor() {
 			continue
 		}
@@ -274,6 +557,14 @@ func (a *horizontalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
 // A verticalAxis is drawn vertically up the left side of a plot.
 type verticalAxis struct {
 	Axis
+
+	// length is the length of the axis, along which
+	// the overlaps of its tick labels are resolved.
+	length vg.Length
+
+	// noLine specifies that the axis line is not
+	// drawn, as for NoSpines.
+	noLine bool
 }
 
 // size returns the width of the axis.
@@ -281,11 +572,16 @@ func (a *verticalAxis) size() (w vg.Length) {
 	if a.Label.Text != "" {
 		w -= a.Label.Font.Extents().Descent
 		w += a.Label.Height(a.Label.Text)
+		w += a.Label.Padding
 	}
-	if marks := a.Tick.Marker.Ticks(a.Min, a.Max); len(marks) > 0 {
-		if lwidth := tickLabelWidth(a.Tick.Label, marks); lwidth > 0 {
+	if marks, sty := a.fitTicks(a.length, false); len(marks) > 0 {
+		if lwidth := tickLabelWidth(sty, marks); lwidth > 0 {
 			w += lwidth
-			w += a.Label.Width(" ")
+			if a.Tick.LabelPadding != 0 {
+				w += a.Tick.LabelPadding
+			} else {
+				w += a.Label.Width(" ")
+			}
 		}
 		if a.drawTicks() {
 			w += a.Tick.Length
@@ -305,9 +601,10 @@ func (a *verticalAxis) draw(c draw.Canvas) {
 		x += a.Label.Height(a.Label.Text)
 		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
 		x += -a.Label.Font.Extents().Descent
+		x += a.Label.Padding
 	}
-	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
-	if w := tickLabelWidth(a.Tick.Label, marks); len(marks) > 0 && w > 0 {
+	marks, sty := a.fitTicks(a.length, false)
+	if w := tickLabelWidth(sty, marks); len(marks) > 0 && w > 0 {
 		x += w
 	}
 	major := false
@@ -316,11 +613,15 @@ func (a *verticalAxis) draw(c draw.Canvas) {
 		if !c.ContainsY(y) || t.IsMinor() {
 			continue
 		}
-		c.FillText(a.Tick.Label, vg.Point{X: x, Y: y}, t.Label)
+		c.FillText(sty, vg.Point{X: x, Y: y}, t.Label)
 		major = true
 	}
 	if major {
-		x += a.Tick.Label.Width(" ")
+		if a.Tick.LabelPadding != 0 {
+			x += a.Tick.LabelPadding
+		} else {
+			x += a.Tick.Label.Width(" ")
+		}
 	}
 	if a.drawTicks() && len(marks) > 0 {
 		len := a.Tick.Length
@@ -329,17 +630,20 @@ func (a *verticalAxis) draw(c draw.Canvas) {
 			if !c.ContainsY(y) {
 				continue
 			}
-			start := t.lengthOffset(len)
-			c.StrokeLine2(a.Tick.LineStyle, x+start, y, x+len, y)
+			sty, tlen := a.tickStyle(t)
+			c.StrokeLine2(sty, x+len-tlen, y, x+len, y)
 		}
 		x += len
 	}
-	c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
+	if !a.noLine {
+		c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
+		a.drawArrow(c, vg.Point{X: x, Y: c.Max.Y}, vg.Point{Y: 1})
+	}
 }
 
 // GlyphBoxes returns the GlyphBoxes for the tick labels
 func (a *verticalAxis) GlyphBoxes(*Plot) (boxes []GlyphBox) {
-	for _, t := range a.Tick.Marker.Ticks(a.Min, a.Max) {
+	for _, t := range a.ticks() {
 		if t.IsMinor() {
 			continue
 		}
@@ -364,25 +668,10 @@ var _ Ticker = DefaultTicks{}
 
 // Ticks returns Ticks in a specified range
 func (DefaultTicks) Ticks(min, max float64) (ticks []Tick) {
-	const SuggestedTicks = 3
 	if max < min {
 		panic("illegal range")
 	}
-	tens := math.Pow10(int(math.Floor(math.Log10(max - min))))
-	n := (max - min) / tens
-	for n < SuggestedTicks {
-		tens /= 10
-		n = (max - min) / tens
-	}
-
-	majorMult := int(n / SuggestedTicks)
-	switch majorMult {
-	case 7:
-		majorMult = 6
-	case 9:
-		majorMult = 8
-	}
-	majorDelta := float64(majorMult) * tens
+	majorDelta, majorMult := majorTickDelta(min, max)
 	val := math.Floor(min/majorDelta) * majorDelta
 	prec := precisionOf(majorDelta)
 	for val <= max {
@@ -422,6 +711,28 @@ func (DefaultTicks) Ticks(min, max float64) (ticks []Tick) {
 	return
 }
 
+// majorTickDelta returns the distance between the major tick
+// marks returned by DefaultTicks in the range from min to max,
+// and its leading digit.
+func majorTickDelta(min, max float64) (delta float64, mult int) {
+	const SuggestedTicks = 3
+	tens := math.Pow10(int(math.Floor(math.Log10(max - min))))
+	n := (max - min) / tens
+	for n < SuggestedTicks {
+		tens /= 10
+		n = (max - min) / tens
+	}
+
+	mult = int(n / SuggestedTicks)
+	switch mult {
+	case 7:
+		mult = 6
+	case 9:
+		mult = 8
+	}
+	return float64(mult) * tens, mult
+}
+
 // LogTicks is suitable for the Tick.Marker field of an Axis,
 // it returns tick marks suitable for a log-scale axis.
 type LogTicks struct{}
@@ -451,6 +762,53 @@ func (LogTicks) Ticks(min, max float64) []Tick {
 	return ticks
 }
 
+// LogitTicks is suitable for the Tick.Marker field of an Axis,
+// it returns tick marks suitable for a logit-scale axis: major
+// tick marks at 0.5 and at powers of ten and their complements,
+// such as 0.01, 0.1, 0.9 and 0.99, and minor tick marks between
+// them.
+type LogitTicks struct{}
+
+var _ Ticker = LogitTicks{}
+
+// Ticks returns Ticks in a specified range
+func (LogitTicks) Ticks(min, max float64) []Tick {
+	if min <= 0 || max >= 1 {
+		panic("Values must be between 0 and 1 for a logit scale.")
+	}
+	var ticks []Tick
+	add := func(v float64, label string) {
+		if v >= min && v <= max {
+			ticks = append(ticks, Tick{Value: v, Label: label})
+		}
+	}
+	decades := int(math.Ceil(-math.Log10(math.Min(min, 1-max))))
+	if decades < 1 {
+		decades = 1
+	}
+	// The tick marks below 0.5, by decade
+	// from the smallest, and their
+	// complements above 0.5 in reverse.
+	for k := decades; k >= 1; k-- {
+		tens := math.Pow10(-k)
+		add(tens, formatFloatTick(tens, precisionOf(tens)))
+		for i := 2; i < 10 && (k > 1 || i < 5); i++ {
+			add(float64(i)*tens, "")
+		}
+	}
+	add(0.5, "0.5")
+	for k := 1; k <= decades; k++ {
+		tens := math.Pow10(-k)
+		for i := 9; i >= 2; i-- {
+			if k > 1 || i < 5 {
+				add(1-float64(i)*tens, "")
+			}
+		}
+		add(1-tens, "0."+strings.Repeat("9", k))
+	}
+	return ticks
+}
+
 // ConstantTicks is suitable for the Tick.Marker field of an Axis.
 // This function returns the given set of ticks.
 type ConstantTicks []Tick
@@ -462,6 +820,80 @@ func (ts ConstantTicks) Ticks(float64, float64) []Tick {
 	return ts
 }
 
+// MinorTicks is suitable for the Tick.Marker field of an Axis.
+// It returns the major tick marks of Ticker, with N-1 evenly
+// spaced minor tick marks in each interval between adjacent
+// major tick marks in place of the minor tick marks of Ticker.
+// Minor tick marks are also added below the first and above
+// the last major tick mark, with the same spacing as in the
+// nearest interval.
+type MinorTicks struct {
+	// Ticker is used to generate the major tick marks.
+	// If nil, DefaultTicks will be used.
+	Ticker Ticker
+
+	// N is the number of intervals between
+	// minor tick marks in each interval between
+	// major tick marks. If N is less than two,
+	// no minor tick marks are returned.
+	N int
+}
+
+var _ Ticker = MinorTicks{}
+
+// Ticks implements plot.Ticker.
+func (t MinorTicks) Ticks(min, max float64) []Tick {
+	if t.Ticker == nil {
+		t.Ticker = DefaultTicks{}
+	}
+
+	var ticks []Tick
+	for _, tick := range t.Ticker.Ticks(min, max) {
+		if !tick.IsMinor() {
+			ticks = append(ticks, tick)
+		}
+	}
+	if t.N < 2 || len(ticks) < 2 {
+		return ticks
+	}
+	sort.Sort(byTickValue(ticks))
+
+	major := len(ticks)
+	for i := -1; i < major; i++ {
+		// Intervals beyond the first and last major
+		// tick marks take the spacing of their
+		// neighboring interval.
+		j := i
+		if j < 0 {
+			j = 0
+		} else if j == major-1 {
+			j = major - 2
+		}
+		delta := (ticks[j+1].Value - ticks[j].Value) / float64(t.N)
+
+		var start float64
+		if i < 0 {
+			start = ticks[0].Value - float64(t.N)*delta
+		} else {
+			start = ticks[i].Value
+		}
+		for k := 1; k < t.N; k++ {
+			val := start + float64(k)*delta
+			if val >= min && val <= max {
+				ticks = append(ticks, Tick{Value: val})
+			}
+		}
+	}
+	return ticks
+}
+
+// byTickValue sorts tick marks by value.
+type byTickValue []Tick
+
+func (t byTickValue) Len() int           { return len(t) }
+func (t byTickValue) Less(i, j int) bool { return t[i].Value < t[j].Value }
+func (t byTickValue) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+
 // UnixTimeIn returns a time conversion function for the given location.
 func UnixTimeIn(loc *time.Location) func(t float64) time.Time {
 	return func(t float64) time.Time {
@@ -528,17 +960,6 @@ func (t Tick) IsMinor() bool {
 	return t.Label == ""
 }
 
-// lengthOffset returns an offset that should be added to the
-// tick mark's line to accout for its length.  I.e., the start of
-// the line for a minor tick mark must be shifted by half of
-// the length.
-func (t Tick) lengthOffset(len vg.Length) vg.Length {
-	if t.IsMinor() {
-		return len / 2
-	}
-	return 0
-}
-
 // tickLabelHeight returns height of the tick mark labels.
 func tickLabelHeight(sty draw.TextStyle, ticks []Tick) vg.Length {
 	maxHeight := vg.Length(0)
@@ -578,6 +999,13 @@ func log(x float64) float64 {
 	return math.Log(x)
 }
 
+func logit(x float64) float64 {
+	if x <= 0 || x >= 1 {
+		panic("Values must be between 0 and 1 for a logit scale.")
+	}
+	return math.Log(x / (1 - x))
+}
+
 // formatFloatTick returns a g-formated string representation of v
 // to the specified precision.
 func formatFloatTick(v float64, prec int) string {
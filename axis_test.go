@@ -5,9 +5,15 @@
 package plot
 
 import (
+	"fmt"
+	"image/color"
 	"math"
 	"reflect"
+	"strconv"
 	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
 )
 
 func TestAxisSmallTick(t *testing.T) {
@@ -59,3 +65,230 @@ func labelsOf(ticks []Tick) []string {
 	}
 	return labels
 }
+
+func TestAxisTickFormat(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 1
+	marks := ConstantTicks{{0, "0"}, {0.25, ""}, {0.5, "0.5"}, {1, "1"}}
+	a.Tick.Marker = marks
+
+	a.Tick.Format = func(v float64) string {
+		return strconv.FormatFloat(100*v, 'f', -1, 64) + "%"
+	}
+	if got, want := labelsOf(a.ticks()), []string{"0%", "50%", "100%"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels with Format: got:%q want:%q", got, want)
+	}
+	if got, want := labelsOf(marks), []string{"0", "0.5", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Format modified the Marker tick marks: got:%q want:%q", got, want)
+	}
+
+	a.Tick.FormatIndex = func(i int, v, min, max float64) string {
+		if i%2 != 0 {
+			return ""
+		}
+		return fmt.Sprintf("%d:%g/%g", i, v, max-min)
+	}
+	got := a.ticks()
+	if want := []string{"0:0/1", "2:1/1"}; !reflect.DeepEqual(labelsOf(got), want) {
+		t.Errorf("unexpected labels with FormatIndex: got:%q want:%q", labelsOf(got), want)
+	}
+	if len(got) != len(marks) {
+		t.Errorf("unexpected number of tick marks: got:%d want:%d", len(got), len(marks))
+	}
+
+	a.Tick.FormatAll = func(values []float64, min, max float64) []string {
+		labels := make([]string, len(values))
+		for i, v := range values {
+			labels[i] = fmt.Sprintf("%d/%d:%g", i, len(values), v)
+		}
+		return labels
+	}
+	if got, want := labelsOf(a.ticks()), []string{"0/3:0", "1/3:0.5", "2/3:1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels with FormatAll: got:%q want:%q", got, want)
+	}
+	if got, want := labelsOf(marks), []string{"0", "0.5", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FormatAll modified the Marker tick marks: got:%q want:%q", got, want)
+	}
+}
+
+func TestMinorTicks(t *testing.T) {
+	for _, test := range []struct {
+		ticker   Ticker
+		n        int
+		min, max float64
+		want     []float64
+	}{
+		{
+			ticker: ConstantTicks{{0, "0"}, {0.5, ""}, {1, "1"}, {2, "2"}},
+			n:      4,
+			min:    -0.5, max: 2.3,
+			want: []float64{0, 1, 2, -0.5, -0.25, 0.25, 0.5, 0.75, 1.25, 1.5, 1.75, 2.25},
+		},
+		{
+			ticker: ConstantTicks{{2, "2"}, {0, "0"}},
+			n:      2,
+			min:    0, max: 2,
+			want: []float64{0, 2, 1},
+		},
+		{
+			ticker: ConstantTicks{{0, "0"}, {0.5, ""}, {1, "1"}},
+			n:      1,
+			min:    0, max: 1,
+			want: []float64{0, 1},
+		},
+	} {
+		ticks := MinorTicks{Ticker: test.ticker, N: test.n}.Ticks(test.min, test.max)
+		var got []float64
+		for _, tk := range ticks {
+			got = append(got, tk.Value)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected tick values for N=%d: got:%v want:%v", test.n, got, test.want)
+		}
+	}
+}
+
+func TestAxisTickStyle(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	major, minor := Tick{Value: 0, Label: "0"}, Tick{Value: 0.5}
+
+	if sty, len := a.tickStyle(major); !reflect.DeepEqual(sty, a.Tick.LineStyle) || len != a.Tick.Length {
+		t.Errorf("unexpected major tick style: got:%v %v", sty, len)
+	}
+	if sty, len := a.tickStyle(minor); !reflect.DeepEqual(sty, a.Tick.LineStyle) || len != a.Tick.Length/2 {
+		t.Errorf("unexpected default minor tick style: got:%v %v", sty, len)
+	}
+
+	a.Tick.MinorLineStyle = draw.LineStyle{Color: color.Gray{128}, Width: vg.Points(0.25)}
+	a.Tick.MinorLength = vg.Points(3)
+	if sty, len := a.tickStyle(minor); !reflect.DeepEqual(sty, a.Tick.MinorLineStyle) || len != vg.Points(3) {
+		t.Errorf("unexpected minor tick style: got:%v %v", sty, len)
+	}
+}
+
+func TestAxisTickOverlap(t *testing.T) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 10
+	var marks ConstantTicks
+	for v := 0; v <= 10; v++ {
+		marks = append(marks, Tick{Value: float64(v), Label: strconv.Itoa(1000000 + v)})
+	}
+	a.Tick.Marker = marks
+	all := labelsOf(marks)
+
+	for _, test := range []struct {
+		overlap    TickOverlap
+		horizontal bool
+		length     vg.Length
+		want       []string
+		rotated    bool
+		shrunk     bool
+	}{
+		{overlap: OverlapAllowed, horizontal: true, length: vg.Points(100), want: all},
+		{overlap: ThinTickLabels, horizontal: true, length: vg.Points(1000), want: all},
+		{overlap: ThinTickLabels, horizontal: false, length: vg.Points(1000), want: all},
+		{
+			overlap: ThinTickLabels, horizontal: true, length: vg.Points(200),
+			want: []string{"1000000", "1000002", "1000004", "1000006", "1000008", "1000010"},
+		},
+		{
+			overlap: ThinTickLabels, horizontal: true, length: vg.Points(10),
+			want: []string{"1000000"},
+		},
+		{overlap: RotateTickLabels, horizontal: true, length: vg.Points(200), want: all, rotated: true},
+		{
+			overlap: RotateTickLabels, horizontal: false, length: vg.Points(50),
+			want: []string{"1000000", "1000003", "1000006", "1000009"},
+		},
+		{overlap: ShrinkTickLabels, horizontal: true, length: vg.Points(300), want: all, shrunk: true},
+		{
+			overlap: ShrinkTickLabels, horizontal: true, length: vg.Points(100),
+			want: []string{"1000000", "1000003", "1000006", "1000009"}, shrunk: true,
+		},
+	} {
+		a.Tick.Overlap = test.overlap
+		got, sty := a.fitTicks(test.length, test.horizontal)
+		if labels := labelsOf(got); !reflect.DeepEqual(labels, test.want) {
+			t.Errorf("unexpected labels for overlap %d along %v: got:%q want:%q", test.overlap, test.length, labels, test.want)
+		}
+		if len(got) != len(marks) {
+			t.Errorf("unexpected number of tick marks for overlap %d: got:%d want:%d", test.overlap, len(got), len(marks))
+		}
+		if rotated := sty.Rotation != 0; rotated != test.rotated {
+			t.Errorf("unexpected rotation for overlap %d along %v: got:%v", test.overlap, test.length, sty.Rotation)
+		}
+		if shrunk := sty.Font.Size < a.Tick.Label.Font.Size; shrunk != test.shrunk {
+			t.Errorf("unexpected font size for overlap %d along %v: got:%v", test.overlap, test.length, sty.Font.Size)
+		}
+		if test.overlap != OverlapAllowed && a.labelsOverlap(got, sty, test.length, test.horizontal) {
+			t.Errorf("labels overlap for overlap %d along %v", test.overlap, test.length)
+		}
+	}
+	if labels := labelsOf(marks); !reflect.DeepEqual(labels, all) {
+		t.Errorf("tick marks of the Marker modified: got:%q", labels)
+	}
+}
+
+func TestLogitTicks(t *testing.T) {
+	for _, test := range []struct {
+		min, max  float64
+		want      []string
+		wantMinor int
+	}{
+		{
+			min:       0.001,
+			max:       0.999,
+			want:      []string{"0.001", "0.01", "0.1", "0.5", "0.9", "0.99", "0.999"},
+			wantMinor: 2 * (8 + 8 + 3),
+		},
+		{
+			min:       0.05,
+			max:       0.5,
+			want:      []string{"0.1", "0.5"},
+			wantMinor: 5 + 3,
+		},
+		{
+			min:       0.2,
+			max:       0.8,
+			want:      []string{"0.5"},
+			wantMinor: 6,
+		},
+	} {
+		ticks := LogitTicks{}.Ticks(test.min, test.max)
+		if got := labelsOf(ticks); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("tick labels mismatch for [%g, %g]:\ngot: %q\nwant:%q", test.min, test.max, got, test.want)
+		}
+		if minor := len(ticks) - len(test.want); minor != test.wantMinor {
+			t.Errorf("unexpected number of minor ticks for [%g, %g]: got:%d want:%d", test.min, test.max, minor, test.wantMinor)
+		}
+		for i := 1; i < len(ticks); i++ {
+			if ticks[i].Value <= ticks[i-1].Value {
+				t.Errorf("ticks out of order for [%g, %g]: %v", test.min, test.max, ticks)
+				break
+			}
+		}
+	}
+
+	s := LogitScale{}
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{x: 0.01, want: 0},
+		{x: 0.1, want: (math.Log(1.0/9) - math.Log(1.0/99)) / (2 * math.Log(99))},
+		{x: 0.5, want: 0.5},
+		{x: 0.99, want: 1},
+	} {
+		if got := s.Normalize(0.01, 0.99, test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected normalized value of %g: got:%g want:%g", test.x, got, test.want)
+		}
+	}
+}
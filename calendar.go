@@ -0,0 +1,208 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxCalendarLabels is the largest number of labeled
+// tick marks returned by the calendar tickers.
+const maxCalendarLabels = 12
+
+// FiscalQuarter returns the fiscal year and the quarter,
+// from 1 to 4, of t, for fiscal years starting on the first
+// day of the given month. A fiscal year is named after the
+// calendar year in which it ends, so the fiscal year 2017
+// starting in October begins on 1 October 2016. A zero
+// start is January, making fiscal years calendar years.
+func FiscalQuarter(t time.Time, start time.Month) (year, quarter int) {
+	if start == 0 {
+		start = time.January
+	}
+	m := (int(t.Month()) - int(start) + 12) % 12
+	year = t.Year()
+	if t.Month() < start {
+		year--
+	}
+	if start != time.January {
+		year++
+	}
+	return year, m/3 + 1
+}
+
+// quarterStart returns the start of the fiscal quarter containing t.
+func quarterStart(t time.Time, start time.Month) time.Time {
+	if start == 0 {
+		start = time.January
+	}
+	m := (int(t.Month()) - int(start) + 12) % 12
+	return time.Date(t.Year(), t.Month()-time.Month(m%3), 1, 0, 0, 0, 0, t.Location())
+}
+
+// quarterTicks returns tick marks at the starts of the fiscal
+// quarters between min and max, in Unix seconds converted to
+// times by conv. Every step quarters from the first quarter
+// of a fiscal year, label is called to label the tick mark;
+// the other tick marks are minor.
+func quarterTicks(min, max float64, conv func(float64) time.Time, start time.Month, step int, label func(year, quarter int) string) []Tick {
+	var ticks []Tick
+	for q := quarterStart(conv(min), start); float64(q.Unix()) <= max; q = q.AddDate(0, 3, 0) {
+		v := float64(q.Unix())
+		if v < min {
+			continue
+		}
+		tick := Tick{Value: v}
+		year, quarter := FiscalQuarter(q, start)
+		if (year*4+quarter-1)%step == 0 {
+			tick.Label = label(year, quarter)
+		}
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
+
+// fiscalYearName returns the name of a fiscal year.
+func fiscalYearName(year int, start time.Month) string {
+	if start == 0 || start == time.January {
+		return fmt.Sprint(year)
+	}
+	return fmt.Sprintf("FY%d", year)
+}
+
+// QuarterTicks is suitable for axes representing time values
+// in seconds since the Unix epoch. It returns a tick mark at the
+// start of each fiscal quarter, labeled with the quarter and its
+// fiscal year, such as "Q1 2016", or "Q1 FY2017" for fiscal years
+// that do not start in January. When the axis spans many quarters,
+// some of them are marked by minor tick marks.
+type QuarterTicks struct {
+	// FiscalStart is the month in which fiscal years
+	// start. If zero, fiscal years are calendar years.
+	FiscalStart time.Month
+
+	// Time takes a float64 value and converts it into a time.Time,
+	// whose location is used to find the starts of the quarters.
+	// If nil, UTCUnixTime is used.
+	Time func(t float64) time.Time
+}
+
+var _ Ticker = QuarterTicks{}
+
+// Ticks implements plot.Ticker.
+func (t QuarterTicks) Ticks(min, max float64) []Tick {
+	if t.Time == nil {
+		t.Time = UTCUnixTime
+	}
+	label := func(year, quarter int) string {
+		return fmt.Sprintf("Q%d %s", quarter, fiscalYearName(year, t.FiscalStart))
+	}
+	step := 1
+	for _, s := range []int{1, 2, 4} {
+		step = s
+		if n := (max - min) / (float64(s) * 91 * 24 * 3600); n <= maxCalendarLabels {
+			break
+		}
+	}
+	return quarterTicks(min, max, t.Time, t.FiscalStart, step, label)
+}
+
+// FiscalYearTicks is suitable for axes representing time values
+// in seconds since the Unix epoch. It returns a tick mark at the
+// start of each fiscal year, labeled with its name, such as "2016",
+// or "FY2017" for fiscal years that do not start in January, and
+// minor tick marks at the starts of the other quarters. When the
+// axis spans many years, only some of them are labeled.
+type FiscalYearTicks struct {
+	// FiscalStart is the month in which fiscal years
+	// start. If zero, fiscal years are calendar years.
+	FiscalStart time.Month
+
+	// Time takes a float64 value and converts it into a time.Time,
+	// whose location is used to find the starts of the years.
+	// If nil, UTCUnixTime is used.
+	Time func(t float64) time.Time
+}
+
+var _ Ticker = FiscalYearTicks{}
+
+// Ticks implements plot.Ticker.
+func (t FiscalYearTicks) Ticks(min, max float64) []Tick {
+	if t.Time == nil {
+		t.Time = UTCUnixTime
+	}
+	years := 1
+	for _, y := range []int{1, 2, 5, 10, 20, 50, 100} {
+		years = y
+		if n := (max - min) / (float64(y) * 365 * 24 * 3600); n <= maxCalendarLabels {
+			break
+		}
+	}
+	label := func(year, _ int) string {
+		if year%years != 0 {
+			return ""
+		}
+		return fiscalYearName(year, t.FiscalStart)
+	}
+	ticks := quarterTicks(min, max, t.Time, t.FiscalStart, 4, label)
+	if years > 2 {
+		// Quarters are too close together to be marked.
+		var marks []Tick
+		for _, tick := range ticks {
+			if _, q := FiscalQuarter(t.Time(tick.Value), t.FiscalStart); q == 1 {
+				marks = append(marks, tick)
+			}
+		}
+		ticks = marks
+	}
+	return ticks
+}
+
+// WeekTicks is suitable for axes representing time values in
+// seconds since the Unix epoch. It returns a tick mark at the
+// start of each ISO 8601 week, on Monday, labeled with the ISO
+// year and week number, such as "2016-W05". When the axis spans
+// many weeks, some of them are marked by minor tick marks.
+type WeekTicks struct {
+	// Time takes a float64 value and converts it into a time.Time,
+	// whose location is used to find the starts of the weeks.
+	// If nil, UTCUnixTime is used.
+	Time func(t float64) time.Time
+}
+
+var _ Ticker = WeekTicks{}
+
+// Ticks implements plot.Ticker.
+func (t WeekTicks) Ticks(min, max float64) []Tick {
+	if t.Time == nil {
+		t.Time = UTCUnixTime
+	}
+	step := 1
+	for _, s := range []int{1, 2, 4, 13, 26} {
+		step = s
+		if n := (max - min) / (float64(s) * 7 * 24 * 3600); n <= maxCalendarLabels {
+			break
+		}
+	}
+
+	first := t.Time(min)
+	days := (int(first.Weekday()) + 6) % 7 // Days since Monday.
+	week := time.Date(first.Year(), first.Month(), first.Day()-days, 0, 0, 0, 0, first.Location())
+	var ticks []Tick
+	for ; float64(week.Unix()) <= max; week = week.AddDate(0, 0, 7) {
+		v := float64(week.Unix())
+		if v < min {
+			continue
+		}
+		tick := Tick{Value: v}
+		year, n := week.ISOWeek()
+		if (n-1)%step == 0 && (step == 1 || n < 53) {
+			tick.Label = fmt.Sprintf("%d-W%02d", year, n)
+		}
+		ticks = append(ticks, tick)
+	}
+	return ticks
+}
@@ -0,0 +1,99 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func unix(year int, month time.Month, day int) float64 {
+	return float64(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix())
+}
+
+func TestFiscalQuarter(t *testing.T) {
+	for _, test := range []struct {
+		t           time.Time
+		start       time.Month
+		year, quart int
+	}{
+		{t: time.Date(2016, time.February, 10, 0, 0, 0, 0, time.UTC), start: 0, year: 2016, quart: 1},
+		{t: time.Date(2016, time.December, 31, 0, 0, 0, 0, time.UTC), start: time.January, year: 2016, quart: 4},
+		{t: time.Date(2016, time.October, 1, 0, 0, 0, 0, time.UTC), start: time.October, year: 2017, quart: 1},
+		{t: time.Date(2016, time.September, 30, 0, 0, 0, 0, time.UTC), start: time.October, year: 2016, quart: 4},
+		{t: time.Date(2016, time.May, 15, 0, 0, 0, 0, time.UTC), start: time.April, year: 2017, quart: 1},
+		{t: time.Date(2016, time.March, 15, 0, 0, 0, 0, time.UTC), start: time.April, year: 2016, quart: 4},
+	} {
+		year, quart := FiscalQuarter(test.t, test.start)
+		if year != test.year || quart != test.quart {
+			t.Errorf("unexpected fiscal quarter of %v starting in %v: got:%d Q%d want:%d Q%d",
+				test.t, test.start, year, quart, test.year, test.quart)
+		}
+	}
+}
+
+func TestQuarterTicks(t *testing.T) {
+	ticks := QuarterTicks{}.Ticks(unix(2015, time.December, 1), unix(2016, time.December, 1))
+	if got, want := labelsOf(ticks), []string{"Q1 2016", "Q2 2016", "Q3 2016", "Q4 2016"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels: got:%q want:%q", got, want)
+	}
+	if ticks[0].Value != unix(2016, time.January, 1) {
+		t.Errorf("unexpected first tick: got:%v want:%v", ticks[0].Value, unix(2016, time.January, 1))
+	}
+
+	ticks = QuarterTicks{FiscalStart: time.October}.Ticks(unix(2016, time.September, 1), unix(2017, time.February, 1))
+	if got, want := labelsOf(ticks), []string{"Q1 FY2017", "Q2 FY2017"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected fiscal labels: got:%q want:%q", got, want)
+	}
+
+	// Over many years, only the first quarters are labeled.
+	ticks = QuarterTicks{}.Ticks(unix(2010, time.January, 1), unix(2016, time.December, 31))
+	if len(ticks) != 28 {
+		t.Errorf("unexpected number of tick marks: got:%d want:28", len(ticks))
+	}
+	for _, l := range labelsOf(ticks) {
+		if l[:2] != "Q1" {
+			t.Errorf("unexpected label: %q", l)
+		}
+	}
+}
+
+func TestFiscalYearTicks(t *testing.T) {
+	ticks := FiscalYearTicks{FiscalStart: time.April}.Ticks(unix(2014, time.January, 1), unix(2016, time.June, 1))
+	if got, want := labelsOf(ticks), []string{"FY2015", "FY2016", "FY2017"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels: got:%q want:%q", got, want)
+	}
+	if ticks[0].Value != unix(2014, time.January, 1) || ticks[0].Label != "" {
+		t.Errorf("unexpected first tick: got:%v", ticks[0])
+	}
+
+	ticks = FiscalYearTicks{}.Ticks(unix(1950, time.January, 1), unix(2016, time.January, 1))
+	if got, want := labelsOf(ticks), []string{"1950", "1960", "1970", "1980", "1990", "2000", "2010"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels over decades: got:%q want:%q", got, want)
+	}
+	if len(ticks) != 67 {
+		t.Errorf("unexpected number of tick marks: got:%d want:67", len(ticks))
+	}
+}
+
+func TestWeekTicks(t *testing.T) {
+	// 1 January 2016 is a Friday, in week 53 of 2015.
+	ticks := WeekTicks{}.Ticks(unix(2015, time.December, 26), unix(2016, time.January, 20))
+	if got, want := labelsOf(ticks), []string{"2015-W53", "2016-W01", "2016-W02", "2016-W03"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels: got:%q want:%q", got, want)
+	}
+	if ticks[0].Value != unix(2015, time.December, 28) {
+		t.Errorf("unexpected first tick: got:%v want:%v", ticks[0].Value, unix(2015, time.December, 28))
+	}
+
+	ticks = WeekTicks{}.Ticks(unix(2016, time.January, 1), unix(2016, time.December, 31))
+	if got, want := labelsOf(ticks), []string{"2016-W01", "2016-W14", "2016-W27", "2016-W40"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels over a year: got:%q want:%q", got, want)
+	}
+	if len(ticks) != 52 {
+		t.Errorf("unexpected number of tick marks: got:%d want:52", len(ticks))
+	}
+}
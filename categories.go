@@ -0,0 +1,137 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import "sort"
+
+// Categories is an ordered set of named categories
+// placed along a categorical, or nominal, axis. The
+// category at index i of the set is located at i along
+// the axis, so that adjacent categories are evenly
+// spaced, one data unit apart.
+//
+// Categories implements the Ticker interface, marking
+// each category with a labelled tick, and the Ranger
+// interface, extending the range of the axis by half a
+// unit at each end so that the first and last categories
+// are spaced from the ends as they are from each other.
+type Categories struct {
+	names []string
+	index map[string]int
+}
+
+var (
+	_ Ticker = (*Categories)(nil)
+	_ Ranger = (*Categories)(nil)
+)
+
+// NewCategories returns Categories holding the given names
+// in order. Repeated names are held once, at the index at
+// which they first appear.
+func NewCategories(names ...string) *Categories {
+	c := &Categories{index: make(map[string]int)}
+	for _, name := range names {
+		c.Add(name)
+	}
+	return c
+}
+
+// Add adds the named category at the end of the set if it
+// is not already held, and returns the index of the category.
+func (c *Categories) Add(name string) int {
+	if i, ok := c.index[name]; ok {
+		return i
+	}
+	if c.index == nil {
+		c.index = make(map[string]int)
+	}
+	c.index[name] = len(c.names)
+	c.names = append(c.names, name)
+	return len(c.names) - 1
+}
+
+// Len returns the number of categories in the set.
+func (c *Categories) Len() int {
+	return len(c.names)
+}
+
+// Names returns the names of the categories in order.
+func (c *Categories) Names() []string {
+	return append([]string(nil), c.names...)
+}
+
+// Location returns the location along the axis of the
+// named category. The returned bool is false if the
+// category is not held.
+func (c *Categories) Location(name string) (float64, bool) {
+	i, ok := c.index[name]
+	return float64(i), ok
+}
+
+// Sort orders the categories lexically by name.
+func (c *Categories) Sort() {
+	c.SortBy(func(a, b string) bool { return a < b })
+}
+
+// SortBy orders the categories by the given less function,
+// keeping equal categories in their current order. Since
+// the locations of the categories change, SortBy must be
+// called before the locations are used to place data.
+func (c *Categories) SortBy(less func(a, b string) bool) {
+	sort.Stable(byCategory{names: c.names, less: less})
+	for i, name := range c.names {
+		c.index[name] = i
+	}
+}
+
+// byCategory sorts category names by a less function.
+type byCategory struct {
+	names []string
+	less  func(a, b string) bool
+}
+
+func (c byCategory) Len() int           { return len(c.names) }
+func (c byCategory) Swap(i, j int)      { c.names[i], c.names[j] = c.names[j], c.names[i] }
+func (c byCategory) Less(i, j int) bool { return c.less(c.names[i], c.names[j]) }
+
+// Ticks returns a labelled tick at the location of each
+// category.
+func (c *Categories) Ticks(min, max float64) []Tick {
+	ticks := make([]Tick, len(c.names))
+	for i, name := range c.names {
+		ticks[i] = Tick{Value: float64(i), Label: name}
+	}
+	return ticks
+}
+
+// Range returns the range from half a unit below the first
+// category to half a unit above the last, extended to
+// include min and max.
+func (c *Categories) Range(min, max float64) (float64, float64) {
+	if len(c.names) == 0 {
+		return min, max
+	}
+	lo, hi := -0.5, float64(len(c.names))-0.5
+	if min < lo {
+		lo = min
+	}
+	if max > hi {
+		hi = max
+	}
+	return lo, hi
+}
+
+// Categorize makes the axis a categorical axis of the
+// given categories. The categories are marked by the tick
+// labels of the axis, and the range of the axis is fitted
+// to the categories and to the data of the plotters
+// subsequently added to the plot. The tick marks are
+// hidden, since the labels alone identify the categories.
+func (a *Axis) Categorize(c *Categories) {
+	a.Tick.Marker = c
+	a.Tick.Length = 0
+	a.AutoRange = c
+	a.Min, a.Max = c.Range(a.Min, a.Max)
+}
@@ -0,0 +1,52 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategories(t *testing.T) {
+	c := NewCategories("pear", "apple", "pear", "fig")
+	if got, want := c.Names(), []string{"pear", "apple", "fig"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected names: got:%q want:%q", got, want)
+	}
+	if i := c.Add("kiwi"); i != 3 {
+		t.Errorf("unexpected index of added category: got:%d want:3", i)
+	}
+	if loc, ok := c.Location("fig"); !ok || loc != 2 {
+		t.Errorf("unexpected location of fig: got:%v,%t want:2,true", loc, ok)
+	}
+	if _, ok := c.Location("plum"); ok {
+		t.Error("unexpected location of missing category")
+	}
+
+	c.Sort()
+	if got, want := c.Names(), []string{"apple", "fig", "kiwi", "pear"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sorted names: got:%q want:%q", got, want)
+	}
+	if loc, _ := c.Location("pear"); loc != 3 {
+		t.Errorf("unexpected location of pear after sorting: got:%v want:3", loc)
+	}
+
+	wantTicks := []Tick{{0, "apple"}, {1, "fig"}, {2, "kiwi"}, {3, "pear"}}
+	if got := c.Ticks(0, 1); !reflect.DeepEqual(got, wantTicks) {
+		t.Errorf("unexpected ticks: got:%v want:%v", got, wantTicks)
+	}
+
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Categorize(c)
+	if p.X.Min != -0.5 || p.X.Max != 3.5 {
+		t.Errorf("unexpected axis range: got:[%v, %v] want:[-0.5, 3.5]", p.X.Min, p.X.Max)
+	}
+	p.Add(rangeData{xmin: 0, xmax: 5})
+	if p.X.Min != -0.5 || p.X.Max != 5 {
+		t.Errorf("unexpected axis range after adding data: got:[%v, %v] want:[-0.5, 5]", p.X.Min, p.X.Max)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package columnar adapts columns of columnar data, such as
+// the arrays of Apache Arrow record batches and of Parquet files
+// read through Arrow, as the data of plotters, without copying
+// the values of the columns.
+//
+// The adapters read the values of any Column, an interface that
+// the *array.Float64 arrays of the Arrow Go library satisfy, so
+// that the column i of a record batch rec is adapted by
+//
+//	col := rec.Column(i).(*array.Float64)
+//
+// without this package depending on Arrow. Plotters that copy
+// their data, such as lines and scatters, copy the values of the
+// adapters too. Large columns should be reduced first, such as
+// by plotter.LTTB, which reads the points of an XYer in turn,
+// or drawn by plotters that keep their data, such as heat maps.
+//
+// Null values are read as NaN, which most plotters reject, so
+// points should be read from columns with no null values.
+package columnar
+
+import (
+	"errors"
+	"math"
+)
+
+// Column is a column of float64 values
+// that may hold null values.
+type Column interface {
+	// Len returns the number of
+	// values of the column.
+	Len() int
+
+	// Value returns the value i.
+	Value(i int) float64
+
+	// IsNull returns whether the value i is null.
+	IsNull(i int) bool
+}
+
+// value returns the value i of c,
+// or NaN if it is null.
+func value(c Column, i int) float64 {
+	if c.IsNull(i) {
+		return math.NaN()
+	}
+	return c.Value(i)
+}
+
+// Values implements the plotter.Valuer interface,
+// reading the values of a column, with NaN for
+// the null values.
+type Values struct {
+	Column Column
+}
+
+// Len implements the Len method of the plotter.Valuer interface.
+func (vs Values) Len() int { return vs.Column.Len() }
+
+// Value implements the Value method of the plotter.Valuer interface.
+func (vs Values) Value(i int) float64 { return value(vs.Column, i) }
+
+// XYs implements the plotter.XYer interface, reading the
+// X and Y coordinates of points from two columns of equal
+// length, with NaN for the null values.
+type XYs struct {
+	X, Y Column
+}
+
+// NewXYs returns the points of the columns x and y,
+// or an error if their lengths differ.
+func NewXYs(x, y Column) (XYs, error) {
+	if x.Len() != y.Len() {
+		return XYs{}, errors.New("X/Y length mismatch")
+	}
+	return XYs{X: x, Y: y}, nil
+}
+
+// Len implements the Len method of the plotter.XYer interface.
+func (xys XYs) Len() int { return xys.X.Len() }
+
+// XY implements the XY method of the plotter.XYer interface.
+func (xys XYs) XY(i int) (x, y float64) {
+	return value(xys.X, i), value(xys.Y, i)
+}
+
+// XYZs implements the plotter.XYZer interface, reading the
+// X, Y and Z coordinates of points from three columns of
+// equal length, with NaN for the null values.
+type XYZs struct {
+	X, Y, Z Column
+}
+
+// NewXYZs returns the points of the columns x, y
+// and z, or an error if their lengths differ.
+func NewXYZs(x, y, z Column) (XYZs, error) {
+	if x.Len() != y.Len() || x.Len() != z.Len() {
+		return XYZs{}, errors.New("X/Y/Z length mismatch")
+	}
+	return XYZs{X: x, Y: y, Z: z}, nil
+}
+
+// Len implements the Len method of the plotter.XYZer interface.
+func (xyzs XYZs) Len() int { return xyzs.X.Len() }
+
+// XYZ implements the XYZ method of the plotter.XYZer interface.
+func (xyzs XYZs) XYZ(i int) (x, y, z float64) {
+	return value(xyzs.X, i), value(xyzs.Y, i), value(xyzs.Z, i)
+}
+
+// XY returns the X and Y coordinates of point i.
+func (xyzs XYZs) XY(i int) (x, y float64) {
+	return value(xyzs.X, i), value(xyzs.Y, i)
+}
+
+// Grid implements the plotter.GridXYZ interface, reading the
+// values of a grid by row, from the first row, from a column,
+// with NaN for the null values, such as a raster stored as a
+// column of a table.
+type Grid struct {
+	// Values holds the values of the grid.
+	Values Column
+
+	// Cols is the number of columns of the grid.
+	Cols int
+
+	// XCoord and YCoord hold the coordinates of the
+	// columns and the rows of the grid. If either is
+	// nil, the coordinates are the indices.
+	XCoord, YCoord Column
+}
+
+// NewGrid returns the grid of the given number of columns of
+// the values of z, with the coordinates x and y of its columns
+// and rows, which may be nil. An error is returned if the
+// length of z is not a multiple of cols, or if the lengths of
+// x or y do not match the dimensions of the grid.
+func NewGrid(z Column, cols int, x, y Column) (*Grid, error) {
+	if cols <= 0 || z.Len()%cols != 0 {
+		return nil, errors.New("Grid values do not fill the columns")
+	}
+	if x != nil && x.Len() != cols {
+		return nil, errors.New("Number of X coordinates does not match the number of columns")
+	}
+	if y != nil && y.Len() != z.Len()/cols {
+		return nil, errors.New("Number of Y coordinates does not match the number of rows")
+	}
+	return &Grid{Values: z, Cols: cols, XCoord: x, YCoord: y}, nil
+}
+
+// Dims implements the Dims method of the plotter.GridXYZ interface.
+func (g *Grid) Dims() (c, r int) { return g.Cols, g.Values.Len() / g.Cols }
+
+// Z implements the Z method of the plotter.GridXYZ interface.
+func (g *Grid) Z(c, r int) float64 { return value(g.Values, r*g.Cols+c) }
+
+// X implements the X method of the plotter.GridXYZ interface.
+func (g *Grid) X(c int) float64 {
+	if g.XCoord == nil {
+		return float64(c)
+	}
+	return value(g.XCoord, c)
+}
+
+// Y implements the Y method of the plotter.GridXYZ interface.
+func (g *Grid) Y(r int) float64 {
+	if g.YCoord == nil {
+		return float64(r)
+	}
+	return value(g.YCoord, r)
+}
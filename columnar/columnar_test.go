@@ -0,0 +1,121 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package columnar
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// column is a Column with the API of the
+// Float64 arrays of Apache Arrow, whose null
+// values are given by a validity mask.
+type column struct {
+	values []float64
+	valid  []bool
+}
+
+func (c column) Len() int            { return len(c.values) }
+func (c column) Value(i int) float64 { return c.values[i] }
+func (c column) IsNull(i int) bool   { return c.valid != nil && !c.valid[i] }
+
+var (
+	_ plotter.Valuer  = Values{}
+	_ plotter.XYer    = XYs{}
+	_ plotter.XYZer   = XYZs{}
+	_ plotter.GridXYZ = (*Grid)(nil)
+)
+
+func TestXYs(t *testing.T) {
+	x := column{values: []float64{1, 2, 3, 4}}
+	y := column{values: []float64{10, 20, 30, 40}, valid: []bool{true, false, true, true}}
+	xys, err := NewXYs(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if xys.Len() != 4 {
+		t.Errorf("unexpected length: got:%d want:4", xys.Len())
+	}
+	if px, py := xys.XY(2); px != 3 || py != 30 {
+		t.Errorf("unexpected point: got:(%v, %v) want:(3, 30)", px, py)
+	}
+	if _, py := xys.XY(1); !math.IsNaN(py) {
+		t.Errorf("unexpected null value: got:%v want:NaN", py)
+	}
+
+	// The points are read from the columns, not copied.
+	x.values[0] = 5
+	if px, _ := xys.XY(0); px != 5 {
+		t.Errorf("points copied from the column: got:%v want:5", px)
+	}
+
+	// Large columns are reduced without copies.
+	pts, err := plotter.LTTB(XYs{X: x, Y: x}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pts) != 3 {
+		t.Errorf("unexpected number of points: got:%d want:3", len(pts))
+	}
+
+	if _, err := NewXYs(x, column{values: []float64{1}}); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+	if _, err := NewXYZs(x, y, column{values: []float64{1}}); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+	xyzs, err := NewXYZs(x, x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if px, py, pz := xyzs.XYZ(3); px != 4 || py != 4 || pz != 40 {
+		t.Errorf("unexpected point: got:(%v, %v, %v) want:(4, 4, 40)", px, py, pz)
+	}
+
+	vs, err := plotter.CopyValues(Values{Column: x})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (plotter.Values{5, 2, 3, 4}); !reflect.DeepEqual(vs, want) {
+		t.Errorf("unexpected values: got:%v want:%v", vs, want)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	z := column{values: []float64{1, 2, 3, 4, 5, 6}, valid: []bool{true, true, true, true, false, true}}
+	g, err := NewGrid(z, 3, column{values: []float64{0.5, 1.5, 2.5}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c, r := g.Dims(); c != 3 || r != 2 {
+		t.Errorf("unexpected dimensions: got:(%d, %d) want:(3, 2)", c, r)
+	}
+	if v := g.Z(2, 0); v != 3 {
+		t.Errorf("unexpected value: got:%v want:3", v)
+	}
+	if v := g.Z(1, 1); !math.IsNaN(v) {
+		t.Errorf("unexpected null value: got:%v want:NaN", v)
+	}
+	if x, y := g.X(1), g.Y(1); x != 1.5 || y != 1 {
+		t.Errorf("unexpected coordinates: got:(%v, %v) want:(1.5, 1)", x, y)
+	}
+
+	for _, test := range []struct {
+		cols int
+		x, y Column
+	}{
+		{cols: 4},
+		{cols: 0},
+		{cols: 3, x: column{values: []float64{1}}},
+		{cols: 3, y: column{values: []float64{1}}},
+	} {
+		if _, err := NewGrid(z, test.cols, test.x, test.y); err == nil {
+			t.Errorf("expected error for %d columns", test.cols)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package csvio reads tables of comma separated values as the
+// data of plotters, selecting their columns by name, so that
+// the data of a CSV file can be plotted without glue code.
+//
+// The fields of a table are numbers or times. Times are read as
+// seconds since the Unix epoch, as plot.TimeTicks expects of the
+// coordinates of a time axis. Empty fields and fields holding
+// "NA" or "NaN" are missing values, which are NaN in the columns
+// of a table and skipped in the data of plotters.
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// Table is a table of values read from CSV.
+type Table struct {
+	// Header holds the names of the columns.
+	Header []string
+
+	// Columns holds the values of each
+	// column, with NaN for missing values.
+	Columns [][]float64
+}
+
+// ReadCSV reads a table from r, with the names of the columns
+// on the first line. Fields that are not numbers are parsed as
+// times with each of the given layouts, as by time.Parse, in
+// turn. An error is returned if a field is neither a number, a
+// time nor missing.
+func ReadCSV(r io.Reader, layouts ...string) (*Table, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	return ReadTable(cr, layouts...)
+}
+
+// ReadTable reads a table from the records of r, which may be
+// configured with another separator or comment character, with
+// the names of the columns in the first record. The fields are
+// parsed as by ReadCSV.
+func ReadTable(r *csv.Reader, layouts ...string) (*Table, error) {
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("No table header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{
+		Header:  append([]string(nil), header...),
+		Columns: make([][]float64, len(header)),
+	}
+	for n := 2; ; n++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return t, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) != len(header) {
+			return nil, fmt.Errorf("Wrong number of fields in record %d", n)
+		}
+		for i, f := range rec {
+			v, err := parseField(strings.TrimSpace(f), layouts)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid value %q of column %q in record %d", f, t.Header[i], n)
+			}
+			t.Columns[i] = append(t.Columns[i], v)
+		}
+	}
+}
+
+// parseField returns the value of the field f, NaN if it is
+// missing, parsing it as a number or as a time with one of
+// the layouts.
+func parseField(f string, layouts []string) (float64, error) {
+	switch f {
+	case "", "NA", "NaN":
+		return math.NaN(), nil
+	}
+	v, err := strconv.ParseFloat(f, 64)
+	if err == nil {
+		return v, nil
+	}
+	for _, l := range layouts {
+		if t, terr := time.Parse(l, f); terr == nil {
+			return float64(t.UnixNano()) / 1e9, nil
+		}
+	}
+	return 0, err
+}
+
+// Column returns the values of the named
+// column, or an error if there is none.
+func (t *Table) Column(name string) ([]float64, error) {
+	for i, h := range t.Header {
+		if h == name {
+			return t.Columns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("No column %q", name)
+}
+
+// columns returns the named columns.
+func (t *Table) columns(names ...string) ([][]float64, error) {
+	cols := make([][]float64, len(names))
+	for i, name := range names {
+		var err error
+		if cols[i], err = t.Column(name); err != nil {
+			return nil, err
+		}
+	}
+	return cols, nil
+}
+
+// rows returns the indices of the rows with no
+// missing or infinite value in any of cols.
+func rows(cols [][]float64) []int {
+	var rows []int
+	for r := range cols[0] {
+		ok := true
+		for _, c := range cols {
+			if math.IsNaN(c[r]) || math.IsInf(c[r], 0) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// Values returns the values of the named column,
+// skipping missing values.
+func (t *Table) Values(name string) (plotter.Values, error) {
+	cols, err := t.columns(name)
+	if err != nil {
+		return nil, err
+	}
+	rows := rows(cols)
+	vs := make(plotter.Values, len(rows))
+	for i, r := range rows {
+		vs[i] = cols[0][r]
+	}
+	return vs, nil
+}
+
+// XYs returns the points with the X and Y coordinates of
+// the named columns, skipping rows missing either.
+func (t *Table) XYs(x, y string) (plotter.XYs, error) {
+	cols, err := t.columns(x, y)
+	if err != nil {
+		return nil, err
+	}
+	rows := rows(cols)
+	xys := make(plotter.XYs, len(rows))
+	for i, r := range rows {
+		xys[i].X, xys[i].Y = cols[0][r], cols[1][r]
+	}
+	return xys, nil
+}
+
+// XYZs returns the points with the X, Y and Z coordinates
+// of the named columns, skipping rows missing any of them.
+func (t *Table) XYZs(x, y, z string) (plotter.XYZs, error) {
+	cols, err := t.columns(x, y, z)
+	if err != nil {
+		return nil, err
+	}
+	rows := rows(cols)
+	xyzs := make(plotter.XYZs, len(rows))
+	for i, r := range rows {
+		xyzs[i].X, xyzs[i].Y, xyzs[i].Z = cols[0][r], cols[1][r], cols[2][r]
+	}
+	return xyzs, nil
+}
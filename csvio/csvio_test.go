@@ -0,0 +1,101 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package csvio
+
+import (
+	"encoding/csv"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gonum/plot/plotter"
+)
+
+const table = `time, temp, rain, wind
+2016-03-01, 5.5, 0, 3
+2016-03-02, 7, NA, 4
+2016-03-03, , 2.5, 5
+2016-03-04, 8.25, 1, NaN
+`
+
+func TestReadCSV(t *testing.T) {
+	tab, err := ReadCSV(strings.NewReader(table), "2006-01-02")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"time", "temp", "rain", "wind"}; !reflect.DeepEqual(tab.Header, want) {
+		t.Errorf("unexpected header: got:%q want:%q", tab.Header, want)
+	}
+	day := float64(time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC).Unix())
+
+	xys, err := tab.XYs("time", "temp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := plotter.XYs{{X: day, Y: 5.5}, {X: day + 86400, Y: 7}, {X: day + 3*86400, Y: 8.25}}
+	if !reflect.DeepEqual(xys, want) {
+		t.Errorf("unexpected points: got:%v want:%v", xys, want)
+	}
+
+	xyzs, err := tab.XYZs("temp", "rain", "wind")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (plotter.XYZs{{X: 5.5, Y: 0, Z: 3}}); !reflect.DeepEqual(xyzs, want) {
+		t.Errorf("unexpected points: got:%v want:%v", xyzs, want)
+	}
+
+	vs, err := tab.Values("rain")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (plotter.Values{0, 2.5, 1}); !reflect.DeepEqual(vs, want) {
+		t.Errorf("unexpected values: got:%v want:%v", vs, want)
+	}
+
+	col, err := tab.Column("wind")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(col) != 4 || col[2] != 5 || !math.IsNaN(col[3]) {
+		t.Errorf("unexpected column: got:%v want:[3 4 5 NaN]", col)
+	}
+	if _, err := tab.XYs("time", "snow"); err == nil {
+		t.Error("expected error for missing column")
+	}
+
+	for _, bad := range []string{
+		"",
+		"a,b\n1,x\n",
+		"a,b\n1,2\n3\n",
+	} {
+		if _, err := ReadCSV(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+	// Times are not parsed without layouts.
+	if _, err := ReadCSV(strings.NewReader(table)); err == nil {
+		t.Error("expected error for times with no layout")
+	}
+}
+
+func TestReadTable(t *testing.T) {
+	r := csv.NewReader(strings.NewReader("# comment\nx;y\n1;2\n"))
+	r.Comma = ';'
+	r.Comment = '#'
+	tab, err := ReadTable(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xys, err := tab.XYs("x", "y")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (plotter.XYs{{X: 1, Y: 2}}); !reflect.DeepEqual(xys, want) {
+		t.Errorf("unexpected points: got:%v want:%v", xys, want)
+	}
+}
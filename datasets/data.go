@@ -0,0 +1,249 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file was generated by generate_data.go. Do not edit.
+
+package datasets
+
+var irisData = Flowers{
+	{"setosa", 4.6, 3.4, 1.4, 0.5},
+	{"setosa", 5.1, 3.7, 1.5, 0.4},
+	{"setosa", 4.8, 3.7, 1.7, 0.3},
+	{"setosa", 5.5, 3.6, 1.6, 0.1},
+	{"setosa", 5.3, 3.6, 1.6, 0.1},
+	{"setosa", 4.9, 4.1, 1.6, 0.1},
+	{"setosa", 5.4, 3.2, 1.2, 0.1},
+	{"setosa", 5.1, 3.6, 1.3, 0.2},
+	{"setosa", 5.1, 2.9, 1.5, 0.1},
+	{"setosa", 5.3, 3.6, 1.3, 0.2},
+	{"setosa", 5.1, 4.1, 1.3, 0.3},
+	{"setosa", 5.3, 2.9, 1.6, 0.2},
+	{"setosa", 5.2, 3.3, 1.5, 0.2},
+	{"setosa", 5.1, 3.6, 1.2, 0.3},
+	{"setosa", 5.4, 3.4, 1.3, 0.1},
+	{"setosa", 4.7, 3.7, 1.7, 0.2},
+	{"setosa", 4.9, 3.4, 1.4, 0.3},
+	{"setosa", 5.6, 3.3, 1.4, 0.4},
+	{"setosa", 4.6, 3.2, 1.3, 0.1},
+	{"setosa", 4.3, 3.2, 1.8, 0.3},
+	{"setosa", 5.0, 3.4, 1.7, 0.1},
+	{"setosa", 4.7, 3.5, 1.2, 0.1},
+	{"setosa", 5.4, 3.5, 1.1, 0.3},
+	{"setosa", 5.1, 3.1, 1.5, 0.3},
+	{"setosa", 5.9, 3.6, 1.2, 0.1},
+	{"setosa", 4.9, 3.1, 1.5, 0.1},
+	{"setosa", 5.8, 4.0, 1.5, 0.3},
+	{"setosa", 5.0, 3.4, 1.4, 0.1},
+	{"setosa", 4.8, 3.2, 1.9, 0.1},
+	{"setosa", 5.0, 3.2, 1.6, 0.2},
+	{"setosa", 5.3, 3.6, 1.3, 0.2},
+	{"setosa", 4.7, 3.5, 1.1, 0.2},
+	{"setosa", 4.6, 3.9, 1.3, 0.3},
+	{"setosa", 5.4, 3.3, 1.6, 0.1},
+	{"setosa", 5.7, 3.4, 1.2, 0.2},
+	{"setosa", 4.5, 2.6, 1.6, 0.2},
+	{"setosa", 4.7, 3.5, 1.3, 0.5},
+	{"setosa", 5.2, 3.7, 1.3, 0.1},
+	{"setosa", 5.1, 2.5, 1.5, 0.3},
+	{"setosa", 5.2, 3.7, 1.6, 0.3},
+	{"setosa", 4.7, 3.2, 1.2, 0.1},
+	{"setosa", 4.8, 2.9, 1.2, 0.1},
+	{"setosa", 4.2, 3.4, 1.5, 0.3},
+	{"setosa", 5.5, 3.2, 1.6, 0.4},
+	{"setosa", 5.2, 3.6, 1.3, 0.4},
+	{"setosa", 4.6, 3.6, 1.7, 0.1},
+	{"setosa", 4.6, 3.5, 1.5, 0.3},
+	{"setosa", 5.3, 3.1, 1.5, 0.3},
+	{"setosa", 4.8, 3.8, 1.4, 0.2},
+	{"setosa", 5.4, 3.5, 1.4, 0.3},
+	{"versicolor", 5.7, 3.0, 5.8, 1.5},
+	{"versicolor", 6.1, 2.6, 5.0, 1.3},
+	{"versicolor", 5.9, 3.0, 4.3, 1.0},
+	{"versicolor", 6.9, 2.9, 4.0, 1.4},
+	{"versicolor", 5.7, 2.8, 3.8, 1.1},
+	{"versicolor", 4.8, 2.8, 3.8, 1.3},
+	{"versicolor", 5.9, 2.5, 4.1, 1.4},
+	{"versicolor", 5.8, 2.9, 4.0, 1.1},
+	{"versicolor", 5.7, 2.5, 4.4, 1.3},
+	{"versicolor", 5.6, 2.8, 4.3, 1.1},
+	{"versicolor", 5.9, 2.7, 4.7, 1.1},
+	{"versicolor", 5.9, 3.3, 4.9, 1.3},
+	{"versicolor", 5.5, 3.0, 4.0, 1.6},
+	{"versicolor", 5.7, 3.1, 4.0, 1.3},
+	{"versicolor", 6.0, 2.5, 4.9, 1.3},
+	{"versicolor", 6.8, 2.7, 4.0, 1.4},
+	{"versicolor", 5.6, 3.1, 4.3, 1.4},
+	{"versicolor", 6.4, 2.6, 4.5, 1.3},
+	{"versicolor", 5.8, 3.3, 4.4, 1.0},
+	{"versicolor", 5.7, 2.6, 4.8, 1.5},
+	{"versicolor", 6.3, 2.7, 5.3, 1.0},
+	{"versicolor", 4.8, 3.0, 4.5, 1.3},
+	{"versicolor", 6.0, 3.0, 4.6, 1.6},
+	{"versicolor", 6.5, 3.2, 4.2, 0.9},
+	{"versicolor", 6.3, 2.7, 4.4, 1.3},
+	{"versicolor", 6.3, 2.7, 4.0, 1.3},
+	{"versicolor", 5.6, 3.0, 4.2, 1.2},
+	{"versicolor", 5.6, 2.9, 4.8, 1.3},
+	{"versicolor", 5.8, 3.4, 4.3, 1.4},
+	{"versicolor", 6.2, 2.7, 4.0, 1.0},
+	{"versicolor", 5.3, 2.6, 4.7, 0.9},
+	{"versicolor", 6.9, 2.5, 4.1, 1.0},
+	{"versicolor", 4.9, 3.2, 4.3, 1.4},
+	{"versicolor", 5.9, 3.2, 4.8, 1.5},
+	{"versicolor", 6.1, 2.6, 4.3, 1.5},
+	{"versicolor", 6.2, 2.6, 4.6, 1.3},
+	{"versicolor", 5.9, 2.8, 3.7, 1.5},
+	{"versicolor", 5.7, 2.4, 4.6, 1.6},
+	{"versicolor", 6.2, 2.5, 4.3, 1.2},
+	{"versicolor", 6.3, 2.8, 4.2, 1.3},
+	{"versicolor", 5.9, 2.8, 4.0, 1.4},
+	{"versicolor", 6.4, 3.1, 4.7, 1.4},
+	{"versicolor", 5.2, 3.1, 4.1, 1.3},
+	{"versicolor", 5.8, 2.9, 3.5, 1.1},
+	{"versicolor", 6.5, 3.2, 4.9, 1.3},
+	{"versicolor", 6.9, 3.2, 4.3, 1.2},
+	{"versicolor", 5.7, 2.5, 4.8, 1.1},
+	{"versicolor", 6.4, 2.7, 4.4, 1.0},
+	{"versicolor", 5.6, 3.0, 5.1, 1.2},
+	{"versicolor", 6.2, 2.5, 3.7, 1.4},
+	{"virginica", 7.1, 3.1, 5.5, 1.5},
+	{"virginica", 7.0, 2.9, 4.7, 2.3},
+	{"virginica", 6.2, 2.8, 5.2, 2.4},
+	{"virginica", 6.4, 2.9, 6.1, 2.3},
+	{"virginica", 6.3, 3.5, 5.2, 1.8},
+	{"virginica", 5.7, 2.5, 6.2, 2.5},
+	{"virginica", 5.7, 3.1, 6.0, 1.5},
+	{"virginica", 7.0, 3.0, 5.2, 1.7},
+	{"virginica", 7.4, 2.2, 5.7, 1.7},
+	{"virginica", 5.9, 3.4, 5.2, 2.1},
+	{"virginica", 6.1, 2.6, 5.7, 1.5},
+	{"virginica", 6.5, 3.4, 5.1, 1.7},
+	{"virginica", 6.3, 2.6, 5.7, 2.1},
+	{"virginica", 7.4, 3.8, 5.1, 1.8},
+	{"virginica", 7.3, 2.7, 5.6, 2.1},
+	{"virginica", 6.3, 3.1, 5.7, 2.1},
+	{"virginica", 5.9, 3.3, 6.0, 2.2},
+	{"virginica", 6.4, 2.6, 6.1, 2.0},
+	{"virginica", 6.0, 3.2, 5.6, 2.2},
+	{"virginica", 5.9, 2.6, 5.2, 2.2},
+	{"virginica", 6.0, 3.8, 6.0, 2.3},
+	{"virginica", 7.1, 3.0, 5.7, 2.0},
+	{"virginica", 6.4, 3.4, 6.0, 1.8},
+	{"virginica", 7.2, 3.3, 5.7, 1.9},
+	{"virginica", 5.7, 3.1, 5.3, 2.3},
+	{"virginica", 6.0, 3.1, 6.1, 2.0},
+	{"virginica", 6.5, 3.0, 6.1, 2.3},
+	{"virginica", 7.9, 3.1, 6.3, 1.9},
+	{"virginica", 6.0, 3.0, 5.6, 2.2},
+	{"virginica", 5.5, 3.4, 5.8, 1.9},
+	{"virginica", 6.8, 3.1, 5.7, 2.2},
+	{"virginica", 7.7, 3.1, 5.1, 1.9},
+	{"virginica", 7.2, 2.5, 6.0, 2.1},
+	{"virginica", 4.6, 3.5, 5.7, 2.5},
+	{"virginica", 7.7, 2.8, 5.1, 2.2},
+	{"virginica", 7.1, 3.2, 5.9, 2.1},
+	{"virginica", 7.1, 3.0, 5.3, 1.9},
+	{"virginica", 7.1, 2.9, 5.0, 2.4},
+	{"virginica", 7.0, 2.6, 5.6, 2.1},
+	{"virginica", 6.9, 3.5, 5.4, 2.2},
+	{"virginica", 5.8, 3.4, 5.4, 2.1},
+	{"virginica", 6.2, 3.0, 5.7, 2.0},
+	{"virginica", 6.8, 2.3, 5.9, 2.2},
+	{"virginica", 7.2, 2.9, 5.0, 2.0},
+	{"virginica", 6.9, 3.4, 4.7, 2.2},
+	{"virginica", 6.1, 3.1, 5.5, 2.4},
+	{"virginica", 6.5, 2.4, 5.4, 1.9},
+	{"virginica", 6.7, 2.7, 5.3, 2.2},
+	{"virginica", 6.1, 2.5, 5.0, 2.2},
+	{"virginica", 6.7, 2.3, 5.0, 2.2},
+}
+
+const (
+	terrainCols = 48
+	terrainRows = 32
+)
+
+var terrainData = [terrainRows * terrainCols]int16{
+	-612, -666, -718, -740, -724, -696, -682, -640, -576, -506, -409, -319, -260, -184, -102, -24, 17, 53, 117, 163, 164, 151, 148, 106, 49, -14, -117, -243, -354, -491, -600, -740, -859, -974, -1046, -1118, -1176, -1235, -1260, -1273, -1269, -1241, -1224, -1171, -1116, -1076, -1005, -924,
+	-663, -746, -809, -837, -838, -827, -759, -727, -641, -573, -457, -367, -279, -172, -91, -47, 28, 90, 155, 171, 216, 197, 174, 148, 59, -34, -113, -257, -381, -534, -677, -801, -919, -1044, -1146, -1231, -1318, -1354, -1403, -1398, -1422, -1392, -1366, -1306, -1252, -1172, -1117, -1024,
+	-730, -793, -861, -905, -907, -892, -825, -780, -678, -581, -512, -403, -268, -182, -84, 6, 86, 157, 186, 225, 261, 274, 241, 178, 137, 8, -107, -236, -398, -552, -688, -873, -1001, -1109, -1253, -1325, -1440, -1491, -1511, -1531, -1553, -1521, -1467, -1434, -1372, -1295, -1228, -1110,
+	-773, -853, -921, -964, -959, -939, -889, -813, -704, -607, -495, -403, -283, -153, -66, 31, 117, 226, 275, 316, 355, 328, 339, 246, 196, 66, -77, -205, -404, -547, -730, -889, -1049, -1176, -1317, -1425, -1511, -1592, -1652, -1649, -1660, -1642, -1621, -1548, -1495, -1409, -1335, -1199,
+	-785, -868, -918, -980, -967, -950, -885, -828, -727, -619, -490, -348, -244, -91, 4, 91, 196, 309, 368, 401, 461, 436, 413, 355, 263, 116, -14, -200, -355, -563, -738, -891, -1093, -1249, -1356, -1501, -1587, -1676, -1717, -1746, -1767, -1740, -1719, -1666, -1606, -1507, -1391, -1312,
+	-781, -838, -922, -948, -935, -926, -874, -794, -684, -548, -437, -281, -161, -24, 70, 213, 298, 411, 471, 512, 575, 556, 512, 448, 364, 207, 30, -140, -307, -524, -723, -885, -1090, -1256, -1383, -1522, -1651, -1750, -1789, -1823, -1850, -1831, -1829, -1745, -1696, -1599, -1481, -1354,
+	-709, -816, -865, -888, -914, -879, -802, -716, -598, -477, -349, -181, -54, 68, 185, 323, 428, 532, 599, 636, 673, 684, 649, 554, 466, 316, 135, -49, -256, -468, -660, -892, -1073, -1238, -1417, -1558, -1658, -1748, -1828, -1898, -1905, -1927, -1893, -1837, -1746, -1660, -1548, -1384,
+	-651, -718, -799, -820, -819, -765, -680, -610, -498, -339, -216, -51, 62, 201, 329, 430, 572, 637, 737, 793, 827, 797, 746, 698, 566, 381, 236, 13, -189, -401, -633, -816, -1008, -1210, -1361, -1517, -1676, -1783, -1858, -1912, -1936, -1953, -1926, -1847, -1799, -1678, -1582, -1420,
+	-543, -622, -666, -706, -688, -637, -563, -441, -350, -196, -71, 76, 232, 367, 503, 603, 689, 816, 862, 930, 940, 941, 867, 777, 644, 486, 305, 119, -131, -350, -546, -756, -949, -1136, -1321, -1490, -1644, -1763, -1858, -1904, -1951, -1949, -1926, -1897, -1813, -1710, -1585, -1446,
+	-445, -494, -529, -570, -543, -478, -373, -263, -130, -7, 123, 271, 415, 547, 650, 758, 868, 961, 1030, 1056, 1054, 1048, 1009, 879, 781, 608, 386, 181, -12, -248, -474, -661, -867, -1065, -1233, -1414, -1570, -1677, -1786, -1886, -1934, -1921, -1926, -1878, -1777, -1664, -1566, -1415,
+	-322, -369, -393, -394, -342, -301, -188, -63, 49, 198, 376, 497, 632, 764, 874, 967, 1040, 1113, 1165, 1170, 1192, 1154, 1081, 999, 844, 670, 482, 305, 81, -151, -360, -584, -754, -946, -1143, -1324, -1487, -1618, -1726, -1812, -1832, -1865, -1840, -1825, -1735, -1637, -1532, -1363,
+	-215, -247, -242, -234, -164, -66, 40, 154, 301, 456, 603, 740, 886, 970, 1096, 1147, 1215, 1265, 1286, 1320, 1284, 1255, 1167, 1047, 923, 776, 577, 398, 185, -42, -251, -432, -641, -828, -1017, -1175, -1350, -1491, -1614, -1702, -1748, -1787, -1776, -1728, -1680, -1585, -1473, -1307,
+	-76, -115, -69, -59, 25, 146, 275, 391, 555, 725, 886, 999, 1124, 1225, 1324, 1355, 1396, 1416, 1425, 1406, 1388, 1300, 1243, 1130, 1000, 834, 644, 480, 278, 87, -126, -317, -507, -685, -852, -1029, -1209, -1337, -1451, -1571, -1628, -1650, -1656, -1624, -1580, -1471, -1384, -1236,
+	4, 28, 88, 118, 236, 342, 501, 662, 846, 999, 1152, 1296, 1415, 1488, 1531, 1581, 1604, 1581, 1542, 1523, 1447, 1389, 1296, 1193, 1035, 919, 751, 584, 412, 205, 10, -140, -350, -499, -684, -847, -1034, -1185, -1292, -1383, -1455, -1504, -1542, -1503, -1470, -1378, -1270, -1176,
+	137, 166, 197, 321, 434, 564, 722, 899, 1083, 1288, 1433, 1588, 1661, 1736, 1787, 1785, 1760, 1731, 1682, 1589, 1516, 1436, 1355, 1214, 1121, 969, 806, 689, 520, 342, 188, 23, -174, -345, -518, -679, -836, -957, -1096, -1205, -1284, -1364, -1359, -1373, -1303, -1267, -1173, -1044,
+	237, 275, 364, 450, 585, 766, 956, 1170, 1348, 1539, 1709, 1840, 1940, 2001, 2007, 1995, 1954, 1854, 1793, 1676, 1611, 1478, 1390, 1290, 1162, 1057, 897, 780, 643, 505, 353, 195, 9, -158, -293, -447, -613, -784, -898, -1015, -1106, -1151, -1201, -1206, -1180, -1104, -1020, -931,
+	304, 353, 454, 603, 752, 958, 1161, 1367, 1583, 1794, 1942, 2081, 2185, 2204, 2231, 2190, 2117, 2000, 1894, 1769, 1669, 1533, 1453, 1338, 1230, 1100, 997, 879, 775, 647, 507, 369, 212, 47, -101, -270, -407, -565, -704, -820, -897, -956, -1016, -1011, -1009, -970, -918, -844,
+	350, 475, 581, 717, 897, 1129, 1332, 1553, 1781, 1978, 2189, 2288, 2363, 2394, 2381, 2323, 2249, 2112, 2003, 1838, 1713, 1590, 1479, 1396, 1274, 1180, 1113, 1010, 891, 777, 666, 533, 417, 247, 115, -29, -190, -329, -487, -597, -670, -756, -812, -839, -815, -814, -779, -718,
+	398, 533, 655, 821, 1034, 1221, 1473, 1722, 1931, 2171, 2346, 2482, 2519, 2567, 2527, 2447, 2339, 2189, 2047, 1899, 1788, 1670, 1543, 1442, 1382, 1281, 1205, 1135, 1055, 954, 839, 705, 616, 456, 295, 147, 9, -133, -247, -385, -453, -568, -612, -662, -638, -631, -636, -572,
+	447, 567, 721, 894, 1095, 1348, 1590, 1822, 2064, 2279, 2452, 2565, 2634, 2665, 2605, 2514, 2396, 2247, 2089, 1955, 1835, 1683, 1592, 1500, 1451, 1352, 1322, 1244, 1179, 1078, 997, 877, 786, 656, 522, 350, 221, 97, -34, -167, -286, -350, -407, -455, -497, -494, -497, -465,
+	469, 612, 738, 935, 1137, 1369, 1619, 1876, 2092, 2328, 2499, 2611, 2674, 2653, 2616, 2542, 2405, 2254, 2101, 1968, 1845, 1745, 1643, 1564, 1485, 1435, 1423, 1366, 1303, 1241, 1157, 1030, 936, 819, 693, 532, 416, 277, 142, 34, -79, -169, -236, -272, -311, -356, -372, -352,
+	475, 585, 771, 966, 1166, 1406, 1617, 1872, 2114, 2311, 2472, 2560, 2638, 2626, 2587, 2504, 2385, 2252, 2099, 1980, 1830, 1761, 1678, 1587, 1544, 1543, 1499, 1444, 1394, 1335, 1288, 1185, 1091, 966, 826, 700, 594, 431, 324, 207, 111, 4, -57, -137, -177, -209, -252, -254,
+	474, 609, 769, 937, 1145, 1362, 1575, 1830, 2032, 2229, 2362, 2460, 2537, 2548, 2488, 2396, 2284, 2163, 2050, 1933, 1839, 1727, 1670, 1617, 1611, 1581, 1566, 1543, 1493, 1447, 1396, 1314, 1216, 1107, 977, 853, 724, 614, 481, 359, 246, 143, 69, -2, -41, -90, -122, -144,
+	470, 580, 728, 902, 1094, 1297, 1529, 1722, 1932, 2082, 2210, 2323, 2391, 2382, 2324, 2251, 2151, 2050, 1946, 1857, 1787, 1716, 1676, 1616, 1637, 1624, 1614, 1607, 1576, 1541, 1453, 1389, 1300, 1205, 1106, 950, 842, 733, 601, 503, 368, 282, 221, 125, 42, 22, -19, -50,
+	447, 561, 669, 849, 1034, 1193, 1419, 1603, 1753, 1918, 2039, 2119, 2155, 2163, 2147, 2065, 2010, 1914, 1819, 1749, 1686, 1647, 1625, 1609, 1620, 1622, 1640, 1628, 1595, 1553, 1534, 1434, 1385, 1270, 1174, 1038, 935, 814, 691, 584, 474, 390, 319, 244, 166, 89, 33, 19,
+	409, 525, 638, 790, 929, 1099, 1275, 1419, 1599, 1742, 1838, 1897, 1929, 1963, 1922, 1886, 1818, 1753, 1683, 1652, 1600, 1561, 1548, 1566, 1580, 1617, 1614, 1610, 1619, 1594, 1542, 1477, 1423, 1321, 1204, 1133, 1017, 908, 792, 663, 553, 474, 381, 309, 218, 180, 117, 54,
+	359, 466, 588, 689, 850, 962, 1129, 1274, 1393, 1523, 1620, 1685, 1687, 1718, 1701, 1678, 1633, 1554, 1525, 1513, 1465, 1465, 1484, 1503, 1535, 1569, 1608, 1608, 1599, 1588, 1546, 1507, 1407, 1342, 1266, 1150, 1040, 952, 811, 716, 617, 522, 451, 378, 286, 215, 175, 130,
+	327, 413, 524, 621, 720, 869, 953, 1099, 1202, 1283, 1393, 1427, 1468, 1487, 1483, 1462, 1421, 1399, 1374, 1349, 1342, 1373, 1407, 1421, 1470, 1503, 1535, 1561, 1560, 1563, 1520, 1454, 1423, 1331, 1267, 1133, 1035, 939, 847, 749, 641, 581, 461, 409, 336, 245, 205, 149,
+	300, 386, 442, 535, 640, 725, 807, 939, 998, 1109, 1143, 1200, 1239, 1236, 1236, 1253, 1236, 1211, 1215, 1198, 1231, 1235, 1282, 1351, 1364, 1420, 1446, 1478, 1513, 1496, 1469, 1420, 1381, 1325, 1208, 1147, 1033, 932, 861, 745, 648, 591, 492, 423, 348, 297, 233, 169,
+	283, 333, 390, 437, 538, 620, 686, 767, 826, 895, 942, 998, 1033, 1036, 1051, 1053, 1049, 1060, 1063, 1070, 1088, 1131, 1186, 1238, 1271, 1309, 1378, 1382, 1419, 1396, 1415, 1348, 1336, 1263, 1172, 1099, 1017, 915, 823, 728, 665, 552, 494, 414, 345, 286, 244, 200,
+	250, 293, 337, 383, 443, 504, 550, 630, 683, 733, 770, 804, 860, 870, 867, 887, 876, 898, 902, 925, 980, 1008, 1058, 1126, 1171, 1208, 1262, 1296, 1301, 1335, 1312, 1281, 1255, 1183, 1126, 1056, 989, 901, 784, 718, 642, 548, 492, 417, 330, 303, 244, 234,
+	245, 267, 295, 309, 379, 393, 460, 527, 564, 610, 640, 663, 714, 702, 738, 740, 764, 783, 789, 808, 837, 893, 923, 1002, 1038, 1096, 1144, 1173, 1208, 1213, 1220, 1184, 1175, 1138, 1046, 983, 907, 850, 759, 664, 592, 505, 441, 386, 353, 295, 235, 206,
+}
+
+var temperatureData = [365]float64{
+	0.6, -2.4, -1.4, -2.7, -1.9, 0.9, 4.5, 0.9,
+	4.3, 7.1, 7.8, 5.2, 3.0, 5.2, 3.9, 6.3,
+	8.4, 8.6, 5.6, 4.2, 1.9, 0.8, -0.2, 0.7,
+	-0.1, 2.3, -0.3, -1.3, 0.2, -1.6, 1.3, -0.1,
+	-1.0, -0.5, -1.2, -1.0, 1.3, 1.3, 3.7, 1.1,
+	1.8, 0.9, 2.6, 3.6, 3.5, 2.7, 1.4, 4.1,
+	2.3, 3.5, 6.4, 3.8, 2.0, 2.6, 1.2, 0.7,
+	-1.3, 1.3, -3.3, -3.7, -3.8, 0.8, 2.5, 5.3,
+	2.3, 4.2, 8.0, 8.2, 5.3, 5.6, 10.8, 8.2,
+	4.0, 3.7, 5.3, 2.9, 4.1, 3.7, 4.3, 2.7,
+	6.8, 10.3, 9.1, 4.4, 4.9, 2.8, 7.1, 6.9,
+	5.4, 5.6, 11.1, 10.6, 9.0, 7.1, 5.2, 6.7,
+	8.7, 9.1, 8.9, 8.8, 7.3, 7.5, 2.6, 4.0,
+	5.0, 7.4, 9.9, 9.0, 9.5, 10.2, 12.2, 10.7,
+	13.7, 15.9, 11.2, 10.3, 13.8, 14.2, 14.4, 11.9,
+	10.9, 9.4, 11.6, 15.6, 13.5, 14.9, 15.4, 17.3,
+	19.0, 18.1, 20.1, 17.5, 17.9, 16.9, 16.7, 16.0,
+	13.8, 15.5, 16.1, 16.0, 17.6, 17.0, 20.0, 16.3,
+	19.2, 19.4, 19.2, 18.2, 16.3, 18.3, 19.5, 15.0,
+	17.0, 17.6, 18.3, 16.7, 16.3, 15.7, 18.0, 18.5,
+	17.4, 21.3, 20.1, 18.8, 22.3, 22.6, 21.8, 21.4,
+	19.7, 22.2, 18.1, 20.5, 22.0, 22.1, 18.7, 20.5,
+	18.6, 19.5, 19.0, 16.2, 19.7, 20.6, 22.9, 19.9,
+	18.8, 17.9, 17.1, 22.0, 22.8, 23.3, 27.5, 26.4,
+	24.9, 23.6, 22.8, 18.9, 18.6, 16.5, 17.7, 18.6,
+	17.3, 17.7, 22.2, 20.1, 22.0, 21.9, 19.9, 20.8,
+	18.6, 17.4, 16.6, 14.4, 15.9, 21.5, 24.1, 23.0,
+	22.6, 20.4, 20.1, 21.5, 20.6, 19.8, 18.9, 19.1,
+	16.3, 16.2, 15.3, 13.1, 13.6, 16.8, 15.0, 16.2,
+	19.5, 20.2, 19.0, 19.4, 19.4, 20.4, 19.2, 18.0,
+	16.4, 12.6, 16.1, 14.8, 17.2, 17.0, 11.2, 10.0,
+	8.2, 11.9, 14.5, 16.3, 14.4, 15.4, 18.9, 12.9,
+	11.9, 11.2, 13.2, 11.8, 10.4, 12.5, 13.1, 15.3,
+	19.3, 16.0, 16.6, 14.9, 12.1, 9.3, 10.5, 13.1,
+	12.2, 9.7, 11.7, 11.6, 13.3, 13.9, 14.5, 16.6,
+	15.6, 13.5, 9.8, 7.7, 10.6, 14.8, 13.6, 15.1,
+	10.8, 13.4, 13.5, 12.8, 12.9, 8.8, 7.7, 5.0,
+	4.9, 7.0, 6.6, 11.1, 9.3, 6.8, 4.3, 4.2,
+	4.5, 2.4, 2.8, 4.7, 5.2, 9.4, 9.2, 10.5,
+	8.9, 10.5, 10.3, 6.8, 7.3, 9.7, 11.0, 9.0,
+	6.1, 7.7, 7.4, 7.0, 6.6, 6.5, 7.6, 6.6,
+	5.4, 3.5, 8.0, 8.3, 3.8, 2.7, 0.1, 2.8,
+	2.6, 1.7, 3.6, 4.6, 3.4, -0.7, -1.4, 1.2,
+	2.3, -0.6, 3.5, 0.8, 2.2, 2.9, 2.7, 1.3,
+	4.5, 1.4, -0.5, 2.2, 6.5, 3.1, 2.0, 0.7,
+	2.4, -0.6, 1.3, -2.0, -2.0,
+}
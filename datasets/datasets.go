@@ -0,0 +1,229 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package datasets provides small data sets for examples
+// and tests, returned as the data types of the plotter
+// package so that they can be plotted directly.
+//
+// The data sets are compiled into the package, so they
+// need no files at run time. The iris, terrain and
+// temperature data are synthetic, drawn from fixed
+// pseudo-random sources by generate_data.go.
+package datasets
+
+import (
+	"time"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// Flower holds the measurements of a flower,
+// in centimetres.
+type Flower struct {
+	Species string
+
+	SepalLength, SepalWidth float64
+	PetalLength, PetalWidth float64
+}
+
+// Measure is one of the measurements of a Flower.
+type Measure int
+
+const (
+	SepalLength Measure = iota
+	SepalWidth
+	PetalLength
+	PetalWidth
+)
+
+// String returns the name of the measurement.
+func (m Measure) String() string {
+	switch m {
+	case SepalLength:
+		return "Sepal length"
+	case SepalWidth:
+		return "Sepal width"
+	case PetalLength:
+		return "Petal length"
+	case PetalWidth:
+		return "Petal width"
+	}
+	panic("datasets: unknown measure")
+}
+
+// of returns the measurement of the flower.
+func (m Measure) of(f Flower) float64 {
+	switch m {
+	case SepalLength:
+		return f.SepalLength
+	case SepalWidth:
+		return f.SepalWidth
+	case PetalLength:
+		return f.PetalLength
+	case PetalWidth:
+		return f.PetalWidth
+	}
+	panic("datasets: unknown measure")
+}
+
+// Flowers is a table of flower measurements.
+type Flowers []Flower
+
+// Iris returns a table of 150 flowers, 50 of each of the
+// species setosa, versicolor and virginica, in the manner
+// of Fisher's iris data: the measurements are drawn from
+// normal distributions with the means and standard deviations
+// of each species in the original data.
+func Iris() Flowers {
+	return append(Flowers(nil), irisData...)
+}
+
+// Species returns the names of the species of
+// the flowers, in order of first appearance.
+func (fs Flowers) Species() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, f := range fs {
+		if !seen[f.Species] {
+			seen[f.Species] = true
+			names = append(names, f.Species)
+		}
+	}
+	return names
+}
+
+// Of returns the flowers of the given species.
+func (fs Flowers) Of(species string) Flowers {
+	var sub Flowers
+	for _, f := range fs {
+		if f.Species == species {
+			sub = append(sub, f)
+		}
+	}
+	return sub
+}
+
+// Values returns the given measurement of the flowers.
+func (fs Flowers) Values(m Measure) plotter.Values {
+	vs := make(plotter.Values, len(fs))
+	for i, f := range fs {
+		vs[i] = m.of(f)
+	}
+	return vs
+}
+
+// XYs returns two measurements of the flowers as points.
+func (fs Flowers) XYs(x, y Measure) plotter.XYs {
+	xys := make(plotter.XYs, len(fs))
+	for i, f := range fs {
+		xys[i].X = x.of(f)
+		xys[i].Y = y.of(f)
+	}
+	return xys
+}
+
+// Classes returns the species of each flower as an index
+// into the names of the species, as used by NewEmbedding.
+func (fs Flowers) Classes() (classes []int, names []string) {
+	names = fs.Species()
+	index := make(map[string]int, len(names))
+	for i, n := range names {
+		index[n] = i
+	}
+	classes = make([]int, len(fs))
+	for i, f := range fs {
+		classes[i] = index[f.Species]
+	}
+	return classes, names
+}
+
+// terrain is a grid of elevations.
+type terrain struct{}
+
+// Terrain returns a grid of 48×32 elevations in metres, with
+// hills and a sea, such as could be drawn by a HeatMap with
+// the Hypsometric color map. The cells of the grid are one
+// kilometre apart, with X and Y giving their centers in
+// kilometres.
+func Terrain() plotter.GridXYZ {
+	return terrain{}
+}
+
+// Dims implements the plotter.GridXYZ interface.
+func (terrain) Dims() (c, r int) { return terrainCols, terrainRows }
+
+// Z implements the plotter.GridXYZ interface.
+func (terrain) Z(c, r int) float64 {
+	if c < 0 || c >= terrainCols || r < 0 || r >= terrainRows {
+		panic("datasets: index out of range")
+	}
+	return float64(terrainData[r*terrainCols+c])
+}
+
+// X implements the plotter.GridXYZ interface.
+func (terrain) X(c int) float64 {
+	if c < 0 || c >= terrainCols {
+		panic("datasets: index out of range")
+	}
+	return float64(c)
+}
+
+// Y implements the plotter.GridXYZ interface.
+func (terrain) Y(r int) float64 {
+	if r < 0 || r >= terrainRows {
+		panic("datasets: index out of range")
+	}
+	return float64(r)
+}
+
+// Temperatures returns the daily mean temperatures, in
+// degrees Celsius, of a temperate city over the year 2015.
+// The X values are the times of noon of each day, in
+// seconds since the Unix epoch, as used by plot.TimeTicks.
+func Temperatures() plotter.XYs {
+	start := time.Date(2015, time.January, 1, 12, 0, 0, 0, time.UTC)
+	xys := make(plotter.XYs, len(temperatureData))
+	for i, t := range temperatureData {
+		xys[i].X = float64(start.AddDate(0, 0, i).Unix())
+		xys[i].Y = t
+	}
+	return xys
+}
+
+// EnergyFlows returns a graph of the flows of energy, in
+// terawatt hours, from primary sources through conversion
+// to the sectors in which it is used, as the flows of a
+// Sankey diagram. The sources are in category 0, the
+// carriers in category 1 and the uses in category 2.
+func EnergyFlows() []plotter.Flow {
+	flows := []plotter.Flow{
+		{SourceLabel: "Coal", ReceptorLabel: "Electricity", Value: 90},
+		{SourceLabel: "Coal", ReceptorLabel: "Heat", Value: 20},
+		{SourceLabel: "Gas", ReceptorLabel: "Electricity", Value: 60},
+		{SourceLabel: "Gas", ReceptorLabel: "Heat", Value: 110},
+		{SourceLabel: "Nuclear", ReceptorLabel: "Electricity", Value: 80},
+		{SourceLabel: "Wind", ReceptorLabel: "Electricity", Value: 45},
+		{SourceLabel: "Solar", ReceptorLabel: "Electricity", Value: 15},
+		{SourceLabel: "Oil", ReceptorLabel: "Fuel", Value: 140},
+		{SourceLabel: "Electricity", ReceptorLabel: "Homes", Value: 95},
+		{SourceLabel: "Electricity", ReceptorLabel: "Industry", Value: 105},
+		{SourceLabel: "Electricity", ReceptorLabel: "Transport", Value: 10},
+		{SourceLabel: "Electricity", ReceptorLabel: "Losses", Value: 80},
+		{SourceLabel: "Heat", ReceptorLabel: "Homes", Value: 85},
+		{SourceLabel: "Heat", ReceptorLabel: "Industry", Value: 35},
+		{SourceLabel: "Heat", ReceptorLabel: "Losses", Value: 10},
+		{SourceLabel: "Fuel", ReceptorLabel: "Transport", Value: 110},
+		{SourceLabel: "Fuel", ReceptorLabel: "Industry", Value: 20},
+		{SourceLabel: "Fuel", ReceptorLabel: "Losses", Value: 10},
+	}
+	for i := range flows {
+		switch flows[i].SourceLabel {
+		case "Electricity", "Heat", "Fuel":
+			flows[i].SourceCategory, flows[i].ReceptorCategory = 1, 2
+		default:
+			flows[i].SourceCategory, flows[i].ReceptorCategory = 0, 1
+		}
+	}
+	return flows
+}
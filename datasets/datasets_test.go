@@ -0,0 +1,178 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datasets
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gonum/plot/plotter"
+)
+
+func TestIris(t *testing.T) {
+	fs := Iris()
+	if len(fs) != 150 {
+		t.Fatalf("unexpected number of flowers: got:%d want:150", len(fs))
+	}
+	names := fs.Species()
+	if want := []string{"setosa", "versicolor", "virginica"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("unexpected species: got:%v want:%v", names, want)
+	}
+	for _, n := range names {
+		if got := len(fs.Of(n)); got != 50 {
+			t.Errorf("unexpected number of %s flowers: got:%d want:50", n, got)
+		}
+	}
+	classes, _ := fs.Classes()
+	if classes[0] != 0 || classes[149] != 2 {
+		t.Errorf("unexpected classes: first:%d last:%d", classes[0], classes[149])
+	}
+
+	// The petals of setosa are much shorter
+	// than those of the other species.
+	mean := func(vs plotter.Values) float64 {
+		var sum float64
+		for _, v := range vs {
+			sum += v
+		}
+		return sum / float64(len(vs))
+	}
+	setosa := mean(fs.Of("setosa").Values(PetalLength))
+	virginica := mean(fs.Of("virginica").Values(PetalLength))
+	if setosa > 2 || virginica < 5 {
+		t.Errorf("unexpected mean petal lengths: setosa:%v virginica:%v", setosa, virginica)
+	}
+	xys := fs.XYs(SepalLength, PetalWidth)
+	if xys[0].X != fs[0].SepalLength || xys[0].Y != fs[0].PetalWidth {
+		t.Errorf("unexpected point: got:%v want:{%v %v}", xys[0], fs[0].SepalLength, fs[0].PetalWidth)
+	}
+
+	// Iris returns a copy of the data.
+	fs[0].SepalLength = -1
+	if Iris()[0].SepalLength == -1 {
+		t.Error("iris data modified through returned table")
+	}
+}
+
+func TestTerrain(t *testing.T) {
+	g := Terrain()
+	c, r := g.Dims()
+	if c != 48 || r != 32 {
+		t.Fatalf("unexpected dimensions: got:%dx%d want:48x32", c, r)
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			min = math.Min(min, g.Z(i, j))
+			max = math.Max(max, g.Z(i, j))
+		}
+	}
+	if min >= 0 || max <= 1000 {
+		t.Errorf("terrain has no sea or hills: range [%v, %v]", min, max)
+	}
+	if g.X(c-1) != float64(c-1) || g.Y(r-1) != float64(r-1) {
+		t.Errorf("unexpected coordinates of last cell: got:(%v, %v)", g.X(c-1), g.Y(r-1))
+	}
+}
+
+func TestTemperatures(t *testing.T) {
+	xys := Temperatures()
+	if len(xys) != 365 {
+		t.Fatalf("unexpected number of days: got:%d want:365", len(xys))
+	}
+	last := time.Unix(int64(xys[364].X), 0).UTC()
+	if want := time.Date(2015, time.December, 31, 12, 0, 0, 0, time.UTC); !last.Equal(want) {
+		t.Errorf("unexpected last day: got:%v want:%v", last, want)
+	}
+	// Summer is warmer than winter.
+	if xys[15].Y > xys[196].Y {
+		t.Errorf("January warmer than July: %v > %v", xys[15].Y, xys[196].Y)
+	}
+}
+
+func TestEnergyFlows(t *testing.T) {
+	flows := EnergyFlows()
+	if _, err := plotter.NewSankey(flows...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The energy into each carrier is used.
+	in := make(map[string]float64)
+	out := make(map[string]float64)
+	for _, f := range flows {
+		if f.ReceptorCategory == 1 {
+			in[f.ReceptorLabel] += f.Value
+		}
+		if f.SourceCategory == 1 {
+			out[f.SourceLabel] += f.Value
+		}
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("unbalanced flows: in:%v out:%v", in, out)
+	}
+}
+
+// gridRange returns the range of the values of g.
+func gridRange(g plotter.GridXYZ) (min, max float64) {
+	c, r := g.Dims()
+	min, max = math.Inf(1), math.Inf(-1)
+	for i := 0; i < c; i++ {
+		for j := 0; j < r; j++ {
+			min = math.Min(min, g.Z(i, j))
+			max = math.Max(max, g.Z(i, j))
+		}
+	}
+	return min, max
+}
+
+func TestFields(t *testing.T) {
+	g := Gaussians(11, 21, Bump{X: 0.5, Y: 0.5, Sigma: 0.1, Height: 2}, Bump{X: 0, Y: 1, Sigma: 0.2, Height: -1})
+	if c, r := g.Dims(); c != 11 || r != 21 {
+		t.Fatalf("unexpected dimensions: got:%dx%d want:11x21", c, r)
+	}
+	if g.X(5) != 0.5 || g.Y(10) != 0.5 || g.X(10) != 1 {
+		t.Errorf("unexpected coordinates: got:(%v, %v) and %v", g.X(5), g.Y(10), g.X(10))
+	}
+	if z := g.Z(5, 10); math.Abs(z-2) > 1e-2 {
+		t.Errorf("unexpected height of bump: got:%v want:2", z)
+	}
+	if z := g.Z(0, 20); math.Abs(z+1) > 1e-2 {
+		t.Errorf("unexpected depth of dip: got:%v want:-1", z)
+	}
+
+	min, max := gridRange(Peaks(61, 61))
+	if math.Abs(min+6.5) > 0.1 || math.Abs(max-8.1) > 0.1 {
+		t.Errorf("unexpected range of peaks: got:[%v, %v] want:[-6.5, 8.1]", min, max)
+	}
+
+	m := Mandelbrot(36, 26, 50)
+	// The point near -0.5 on the real axis is inside
+	// of the set, and the corner far outside of it.
+	if m.X(20) != -0.5 || m.Y(0) != -1.25 {
+		t.Fatalf("unexpected coordinates: got:(%v, %v)", m.X(20), m.Y(0))
+	}
+	if z := m.Z(20, 13); z != 50 {
+		t.Errorf("unexpected count inside of set: got:%v want:50", z)
+	}
+	if z := m.Z(0, 0); z != 1 {
+		t.Errorf("unexpected count outside of set: got:%v want:1", z)
+	}
+
+	p := Perlin(41, 41, 4, 1)
+	if z := p.Z(10, 30); z != 0 {
+		t.Errorf("unexpected noise at lattice vertex: got:%v want:0", z)
+	}
+	min, max = gridRange(p)
+	if min < -1 || max > 1 || max-min < 0.5 {
+		t.Errorf("unexpected range of noise: got:[%v, %v]", min, max)
+	}
+	q := Perlin(41, 41, 4, 1)
+	for i := 0; i < 41; i++ {
+		if p.Z(i, 7) != q.Z(i, 7) {
+			t.Fatalf("noise differs for the same seed at column %d", i)
+		}
+	}
+}
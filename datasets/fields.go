@@ -0,0 +1,173 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package datasets
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// sampled is a scalar field sampled on a regular grid,
+// spanning a rectangle with its first and last rows
+// and columns on the edges of the rectangle.
+type sampled struct {
+	cols, rows             int
+	xmin, xmax, ymin, ymax float64
+	z                      []float64
+}
+
+// sample returns the field f sampled on a grid of the given
+// dimensions spanning [xmin, xmax]×[ymin, ymax].
+func sample(cols, rows int, xmin, xmax, ymin, ymax float64, f func(x, y float64) float64) sampled {
+	if cols < 1 || rows < 1 {
+		panic("datasets: grid dimensions must be positive")
+	}
+	s := sampled{cols: cols, rows: rows, xmin: xmin, xmax: xmax, ymin: ymin, ymax: ymax, z: make([]float64, cols*rows)}
+	for r := 0; r < rows; r++ {
+		y := s.Y(r)
+		for c := 0; c < cols; c++ {
+			s.z[r*cols+c] = f(s.X(c), y)
+		}
+	}
+	return s
+}
+
+// Dims implements the plotter.GridXYZ interface.
+func (s sampled) Dims() (c, r int) { return s.cols, s.rows }
+
+// Z implements the plotter.GridXYZ interface.
+func (s sampled) Z(c, r int) float64 {
+	if c < 0 || c >= s.cols || r < 0 || r >= s.rows {
+		panic("datasets: index out of range")
+	}
+	return s.z[r*s.cols+c]
+}
+
+// X implements the plotter.GridXYZ interface.
+func (s sampled) X(c int) float64 {
+	if c < 0 || c >= s.cols {
+		panic("datasets: index out of range")
+	}
+	return at(s.xmin, s.xmax, c, s.cols)
+}
+
+// Y implements the plotter.GridXYZ interface.
+func (s sampled) Y(r int) float64 {
+	if r < 0 || r >= s.rows {
+		panic("datasets: index out of range")
+	}
+	return at(s.ymin, s.ymax, r, s.rows)
+}
+
+// at returns the coordinate of sample i of n
+// spanning [min, max].
+func at(min, max float64, i, n int) float64 {
+	if n == 1 {
+		return (min + max) / 2
+	}
+	return min + (max-min)*float64(i)/float64(n-1)
+}
+
+// Bump is a Gaussian bump of a field returned by Gaussians.
+type Bump struct {
+	// X and Y are the center of the bump.
+	X, Y float64
+
+	// Sigma is the standard deviation
+	// of the bump in both directions.
+	Sigma float64
+
+	// Height is the value of the bump
+	// at its center, which may be
+	// negative for a dip.
+	Height float64
+}
+
+// Gaussians returns the sum of the Gaussian bumps, sampled on
+// a grid of the given dimensions spanning the unit square.
+func Gaussians(cols, rows int, bumps ...Bump) plotter.GridXYZ {
+	return sample(cols, rows, 0, 1, 0, 1, func(x, y float64) float64 {
+		var z float64
+		for _, b := range bumps {
+			d2 := (x-b.X)*(x-b.X) + (y-b.Y)*(y-b.Y)
+			z += b.Height * math.Exp(-d2/(2*b.Sigma*b.Sigma))
+		}
+		return z
+	})
+}
+
+// Peaks returns the peaks function of MATLAB, a sum of
+// scaled and translated Gaussians with three peaks and
+// three dips between about -6.5 and 8.1, sampled on a grid
+// of the given dimensions spanning [-3, 3]×[-3, 3].
+func Peaks(cols, rows int) plotter.GridXYZ {
+	return sample(cols, rows, -3, 3, -3, 3, func(x, y float64) float64 {
+		return 3*(1-x)*(1-x)*math.Exp(-x*x-(y+1)*(y+1)) -
+			10*(x/5-x*x*x-math.Pow(y, 5))*math.Exp(-x*x-y*y) -
+			math.Exp(-(x+1)*(x+1)-y*y)/3
+	})
+}
+
+// Mandelbrot returns the escape counts of the Mandelbrot set,
+// the number of iterations of z² + c after which |z| exceeds
+// 2, up to the given number of iterations for the points of
+// the set, sampled on a grid of the given dimensions spanning
+// [-2.5, 1]×[-1.25, 1.25] of the complex plane.
+func Mandelbrot(cols, rows, iterations int) plotter.GridXYZ {
+	return sample(cols, rows, -2.5, 1, -1.25, 1.25, func(x, y float64) float64 {
+		var re, im float64
+		for n := 0; n < iterations; n++ {
+			re, im = re*re-im*im+x, 2*re*im+y
+			if re*re+im*im > 4 {
+				return float64(n + 1)
+			}
+		}
+		return float64(iterations)
+	})
+}
+
+// Perlin returns the gradient noise of Perlin, between about
+// -1 and 1, sampled on a grid of the given dimensions spanning
+// the unit square, with the given number of noise lattice cells
+// along each side of the square. The noise is zero at the
+// vertices of the lattice. The gradients at the vertices are
+// drawn from a pseudo-random source with the given seed, so
+// that the same seed gives the same noise.
+func Perlin(cols, rows, cells int, seed int64) plotter.GridXYZ {
+	if cells < 1 {
+		panic("datasets: number of noise cells must be positive")
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	n := cells + 1
+	grads := make([][2]float64, n*n)
+	for i := range grads {
+		grads[i][1], grads[i][0] = math.Sincos(2 * math.Pi * rnd.Float64())
+	}
+	return sample(cols, rows, 0, 1, 0, 1, func(x, y float64) float64 {
+		x *= float64(cells)
+		y *= float64(cells)
+		i := math.Min(math.Floor(x), float64(cells-1))
+		j := math.Min(math.Floor(y), float64(cells-1))
+		fx, fy := x-i, y-j
+
+		// dot returns the contribution of the
+		// vertex at offset (di, dj) of the cell.
+		dot := func(di, dj int) float64 {
+			g := grads[(int(j)+dj)*n+int(i)+di]
+			return g[0]*(fx-float64(di)) + g[1]*(fy-float64(dj))
+		}
+		// fade is the quintic interpolant of Perlin's
+		// improved noise, flat at both ends.
+		fade := func(t float64) float64 { return t * t * t * (t*(t*6-15) + 10) }
+		u, v := fade(fx), fade(fy)
+		bottom := dot(0, 0) + u*(dot(1, 0)-dot(0, 0))
+		top := dot(0, 1) + u*(dot(1, 1)-dot(0, 1))
+		// The noise of two dimensions is at most
+		// √2/2 in magnitude.
+		return math.Sqrt2 * (bottom + v*(top-bottom))
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build ignore
+
+// This program generates the data.go source file holding the
+// synthetic iris, terrain and temperature data sets. The data
+// are drawn from fixed pseudo-random sources, so the output
+// does not change between runs.
+//
+// Run the program:
+// go run generate_data.go > data.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+)
+
+// species holds the means and standard deviations of the
+// sepal length and width and the petal length and width of
+// the species of Fisher's iris data.
+var species = []struct {
+	name      string
+	mean, std [4]float64
+}{
+	{name: "setosa", mean: [4]float64{5.01, 3.43, 1.46, 0.25}, std: [4]float64{0.35, 0.38, 0.17, 0.11}},
+	{name: "versicolor", mean: [4]float64{5.94, 2.77, 4.26, 1.33}, std: [4]float64{0.52, 0.31, 0.47, 0.20}},
+	{name: "virginica", mean: [4]float64{6.59, 2.97, 5.55, 2.03}, std: [4]float64{0.64, 0.32, 0.55, 0.27}},
+}
+
+// peaks are the hills and basins of the terrain.
+var peaks = []struct{ x, y, height, width float64 }{
+	{x: 12, y: 20, height: 2400, width: 6},
+	{x: 30, y: 24, height: 1600, width: 8},
+	{x: 22, y: 10, height: 900, width: 5},
+	{x: 40, y: 8, height: -2200, width: 9},
+	{x: 4, y: 4, height: -1200, width: 6},
+}
+
+const (
+	terrainCols = 48
+	terrainRows = 32
+)
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString(`// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file was generated by generate_data.go. Do not edit.
+
+package datasets
+
+`)
+
+	rnd := rand.New(rand.NewSource(1))
+	buf.WriteString("var irisData = Flowers{\n")
+	for _, s := range species {
+		for i := 0; i < 50; i++ {
+			var m [4]float64
+			for j := range m {
+				m[j] = math.Max(0.1, round(s.mean[j]+s.std[j]*rnd.NormFloat64(), 1))
+			}
+			fmt.Fprintf(&buf, "\t{%q, %.1f, %.1f, %.1f, %.1f},\n", s.name, m[0], m[1], m[2], m[3])
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	rnd = rand.New(rand.NewSource(2))
+	fmt.Fprintf(&buf, "const (\n\tterrainCols = %d\n\tterrainRows = %d\n)\n\n", terrainCols, terrainRows)
+	buf.WriteString("var terrainData = [terrainRows * terrainCols]int16{\n")
+	for r := 0; r < terrainRows; r++ {
+		buf.WriteString("\t")
+		for c := 0; c < terrainCols; c++ {
+			x, y := float64(c), float64(r)
+			z := 150 + 60*math.Sin(x/3)*math.Cos(y/4) + 40*rnd.Float64()
+			for _, p := range peaks {
+				d2 := (x-p.x)*(x-p.x) + (y-p.y)*(y-p.y)
+				z += p.height * math.Exp(-d2/(2*p.width*p.width))
+			}
+			if c > 0 {
+				buf.WriteString(" ")
+			}
+			fmt.Fprintf(&buf, "%d,", int(round(z, 0)))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n\n")
+
+	rnd = rand.New(rand.NewSource(3))
+	buf.WriteString("var temperatureData = [365]float64{\n")
+	var weather float64
+	for d := 0; d < 365; d++ {
+		// The weather follows the season, with
+		// spells of warmer or colder days.
+		weather = 0.7*weather + 2*rnd.NormFloat64()
+		t := 11 - 9*math.Cos(2*math.Pi*(float64(d)-15)/365) + weather
+		if d%8 == 0 {
+			buf.WriteString("\t")
+		} else {
+			buf.WriteString(" ")
+		}
+		fmt.Fprintf(&buf, "%.1f,", round(t, 1))
+		if d%8 == 7 || d == 364 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("}\n")
+
+	buf.WriteTo(os.Stdout)
+}
+
+// round returns x rounded to the given number of decimals.
+func round(x float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	v := math.Floor(x*p + 0.5)
+	if v == 0 {
+		// Avoid writing negative zero.
+		return 0
+	}
+	return v / p
+}
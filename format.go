@@ -0,0 +1,177 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gonum/floats"
+)
+
+// maxDecimals is the largest number of decimal
+// places given to tick labels by EqualDecimals.
+const maxDecimals = 15
+
+// EqualDecimals is suitable for the Tick.FormatAll field of
+// an Axis. It returns labels of the values with the same number
+// of decimal places, the fewest that show each value exactly to
+// within a billionth of the range of the axis, so that the
+// decimal points of the labels line up.
+func EqualDecimals(values []float64, min, max float64) []string {
+	d := decimals(values, 1e-9*math.Max(math.Abs(max-min), math.SmallestNonzeroFloat64))
+	labels := make([]string, len(values))
+	for i, v := range values {
+		labels[i] = formatDecimals(v, d)
+	}
+	return labels
+}
+
+// decimals returns the fewest decimal places
+// that show each value to within tol.
+func decimals(values []float64, tol float64) int {
+	for d := 0; d < maxDecimals; d++ {
+		exact := true
+		for _, v := range values {
+			if math.Abs(floats.Round(v, d)-v) > tol {
+				exact = false
+				break
+			}
+		}
+		if exact {
+			return d
+		}
+	}
+	return maxDecimals
+}
+
+// formatDecimals returns v with d decimal places,
+// without the sign of negative zero.
+func formatDecimals(v float64, d int) string {
+	return strconv.FormatFloat(floats.Round(v, d)+0, 'f', d, 64)
+}
+
+// SharedExponent is suitable for the Tick.FormatAll field of
+// an Axis. It returns labels of the values in e notation with
+// the exponent of the largest magnitude, shared by all of the
+// labels, and mantissas with the same number of decimal places,
+// as for axes of very large or very small values. Zero is
+// labeled as 0, and the exponent is omitted when it is zero.
+func SharedExponent(values []float64, min, max float64) []string {
+	var largest float64
+	for _, v := range values {
+		largest = math.Max(largest, math.Abs(v))
+	}
+	exp := 0
+	if largest != 0 && !math.IsInf(largest, 0) {
+		exp = int(math.Floor(math.Log10(largest)))
+	}
+	scale := math.Pow(10, float64(-exp))
+
+	mantissas := make([]float64, len(values))
+	for i, v := range values {
+		mantissas[i] = v * scale
+	}
+	d := decimals(mantissas, 1e-9*math.Max(math.Abs(max-min)*scale, math.SmallestNonzeroFloat64))
+
+	labels := make([]string, len(values))
+	for i, m := range mantissas {
+		switch {
+		case floats.Round(m, d) == 0:
+			labels[i] = "0"
+		case exp == 0:
+			labels[i] = formatDecimals(m, d)
+		default:
+			labels[i] = formatDecimals(m, d) + "e" + strconv.Itoa(exp)
+		}
+	}
+	return labels
+}
+
+// ConciseTimes returns a function suitable for the
+// Tick.FormatAll field of an Axis, labeling times converted
+// from the values by conv, or by UTCUnixTime if conv is nil.
+//
+// Each label holds the time of day, and the date or the year
+// only where they differ from those of the previous label.
+// The time of day is omitted when all of the times are at
+// midnight, the day of the month when they are all on the
+// first of a month, and the month when they are all on the
+// first of January, so that for example ticks every six hours
+// are labeled "Jan 2 2016 18:00", "Jan 3 00:00", "06:00" and so
+// on.
+func ConciseTimes(conv func(float64) time.Time) func(values []float64, min, max float64) []string {
+	if conv == nil {
+		conv = UTCUnixTime
+	}
+	return func(values []float64, min, max float64) []string {
+		times := make([]time.Time, len(values))
+		midnight, firsts, januaries, seconds := true, true, true, false
+		for i, v := range values {
+			t := conv(v)
+			times[i] = t
+			if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 {
+				midnight = false
+			}
+			if t.Second() != 0 {
+				seconds = true
+			}
+			if t.Day() != 1 {
+				firsts = false
+			}
+			if t.Month() != time.January {
+				januaries = false
+			}
+		}
+		firsts = firsts && midnight
+		clock, day := "15:04", "Jan 2"
+		if seconds {
+			clock = "15:04:05"
+		}
+		switch {
+		case firsts && januaries:
+			day = ""
+		case firsts:
+			day = "Jan"
+		}
+
+		labels := make([]string, len(times))
+		for i, t := range times {
+			var newDay, newYear bool
+			if i == 0 {
+				newDay, newYear = true, true
+			} else {
+				p := times[i-1]
+				newYear = t.Year() != p.Year()
+				newDay = newYear || t.YearDay() != p.YearDay()
+			}
+			var label string
+			if newDay && day != "" {
+				label = t.Format(day)
+				if firsts && t.Month() == time.January && newYear && i > 0 {
+					label = ""
+				}
+			}
+			if newYear {
+				label = joinLabel(label, t.Format("2006"))
+			}
+			if !midnight {
+				label = joinLabel(label, t.Format(clock))
+			}
+			labels[i] = label
+		}
+		return labels
+	}
+}
+
+// joinLabel returns the two parts of a
+// label separated by a space.
+func joinLabel(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + " " + b
+}
@@ -0,0 +1,127 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEqualDecimals(t *testing.T) {
+	for _, test := range []struct {
+		values   []float64
+		min, max float64
+		want     []string
+	}{
+		{
+			values: []float64{0, 0.5, 1, 1.5, 2},
+			min:    0, max: 2,
+			want: []string{"0.0", "0.5", "1.0", "1.5", "2.0"},
+		},
+		{
+			values: []float64{0.1, 0.2, 0.30000000000000004, 0.4},
+			min:    0.1, max: 0.4,
+			want: []string{"0.1", "0.2", "0.3", "0.4"},
+		},
+		{
+			values: []float64{-0.25, 0, 0.25},
+			min:    -0.3, max: 0.3,
+			want: []string{"-0.25", "0.00", "0.25"},
+		},
+		{
+			values: []float64{10, 20, 30},
+			min:    5, max: 35,
+			want: []string{"10", "20", "30"},
+		},
+	} {
+		if got := EqualDecimals(test.values, test.min, test.max); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels for %v: got:%q want:%q", test.values, got, test.want)
+		}
+	}
+}
+
+func TestSharedExponent(t *testing.T) {
+	for _, test := range []struct {
+		values   []float64
+		min, max float64
+		want     []string
+	}{
+		{
+			values: []float64{0, 5e5, 1e6, 1.5e6},
+			min:    0, max: 1.6e6,
+			want: []string{"0", "0.5e6", "1.0e6", "1.5e6"},
+		},
+		{
+			values: []float64{2e-4, 4e-4, 6e-4},
+			min:    1e-4, max: 7e-4,
+			want: []string{"2e-4", "4e-4", "6e-4"},
+		},
+		{
+			values: []float64{-2, 0, 2.5},
+			min:    -3, max: 3,
+			want: []string{"-2.0", "0", "2.5"},
+		},
+	} {
+		if got := SharedExponent(test.values, test.min, test.max); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels for %v: got:%q want:%q", test.values, got, test.want)
+		}
+	}
+}
+
+func TestConciseTimes(t *testing.T) {
+	unix := func(year int, month time.Month, day, hour, min, sec int) float64 {
+		return float64(time.Date(year, month, day, hour, min, sec, 0, time.UTC).Unix())
+	}
+	format := ConciseTimes(nil)
+	for _, test := range []struct {
+		values []float64
+		want   []string
+	}{
+		{
+			values: []float64{
+				unix(2016, 1, 2, 18, 0, 0),
+				unix(2016, 1, 3, 0, 0, 0),
+				unix(2016, 1, 3, 6, 0, 0),
+			},
+			want: []string{"Jan 2 2016 18:00", "Jan 3 00:00", "06:00"},
+		},
+		{
+			values: []float64{
+				unix(2016, 12, 30, 0, 0, 0),
+				unix(2016, 12, 31, 0, 0, 0),
+				unix(2017, 1, 1, 0, 0, 0),
+			},
+			want: []string{"Dec 30 2016", "Dec 31", "Jan 1 2017"},
+		},
+		{
+			values: []float64{
+				unix(2016, 11, 1, 0, 0, 0),
+				unix(2016, 12, 1, 0, 0, 0),
+				unix(2017, 1, 1, 0, 0, 0),
+				unix(2017, 2, 1, 0, 0, 0),
+			},
+			want: []string{"Nov 2016", "Dec", "2017", "Feb"},
+		},
+		{
+			values: []float64{
+				unix(2015, 1, 1, 0, 0, 0),
+				unix(2016, 1, 1, 0, 0, 0),
+			},
+			want: []string{"2015", "2016"},
+		},
+		{
+			values: []float64{
+				unix(2016, 3, 1, 12, 0, 0),
+				unix(2016, 3, 1, 12, 0, 30),
+			},
+			want: []string{"Mar 1 2016 12:00:00", "12:00:30"},
+		},
+	} {
+		if got := format(test.values, test.values[0], test.values[len(test.values)-1]); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected labels: got:%q want:%q", got, test.want)
+		}
+	}
+}
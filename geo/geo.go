@@ -0,0 +1,182 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package geo draws maps of geographic features, such as the
+// regions of a choropleth map filled by the values of some data,
+// read from GeoJSON and drawn in a map projection of the gridio
+// package.
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Point is a geographic position, the
+// longitude and latitude in degrees.
+type Point struct {
+	Lon, Lat float64
+}
+
+// Polygon is a polygon of a geographic feature, given by
+// rings of points. The first ring is the outer boundary of
+// the polygon, and the rest are the boundaries of its holes.
+// The first and last points of each ring may be the same.
+type Polygon [][]Point
+
+// Feature is a geographic feature, such as a country
+// or a river, with the polygons of its area or the lines
+// of its course.
+type Feature struct {
+	// Properties holds the properties of the feature,
+	// such as its name and the data about it.
+	Properties map[string]interface{}
+
+	// Polygons holds the polygons of the area
+	// of the feature.
+	Polygons []Polygon
+
+	// Lines holds the lines of the feature.
+	Lines [][]Point
+}
+
+// geoJSON is a GeoJSON object of any type.
+type geoJSON struct {
+	Type        string                 `json:"type"`
+	Features    []geoJSON              `json:"features"`
+	Geometry    *geoJSON               `json:"geometry"`
+	Geometries  []geoJSON              `json:"geometries"`
+	Properties  map[string]interface{} `json:"properties"`
+	Coordinates json.RawMessage        `json:"coordinates"`
+}
+
+// ReadGeoJSON reads the features of a GeoJSON FeatureCollection,
+// Feature or geometry. A bare geometry is read as a Feature with
+// no properties. The coordinates of Polygons and LineStrings, and
+// of their Multi and GeometryCollection forms, are read, and those
+// of Points, which have neither area nor course, are skipped.
+func ReadGeoJSON(r io.Reader) ([]Feature, error) {
+	var obj geoJSON
+	if err := json.NewDecoder(r).Decode(&obj); err != nil {
+		return nil, err
+	}
+	switch obj.Type {
+	case "FeatureCollection":
+		features := make([]Feature, len(obj.Features))
+		for i, o := range obj.Features {
+			if o.Type != "Feature" {
+				return nil, fmt.Errorf("Invalid GeoJSON feature type %q", o.Type)
+			}
+			if err := features[i].read(o); err != nil {
+				return nil, err
+			}
+		}
+		return features, nil
+	case "Feature":
+		var f Feature
+		if err := f.read(obj); err != nil {
+			return nil, err
+		}
+		return []Feature{f}, nil
+	}
+	var f Feature
+	if err := f.addGeometry(obj); err != nil {
+		return nil, err
+	}
+	return []Feature{f}, nil
+}
+
+// read reads the GeoJSON Feature obj into f.
+func (f *Feature) read(obj geoJSON) error {
+	f.Properties = obj.Properties
+	if obj.Geometry == nil {
+		return nil
+	}
+	return f.addGeometry(*obj.Geometry)
+}
+
+// addGeometry adds the coordinates of the
+// GeoJSON geometry obj to the feature.
+func (f *Feature) addGeometry(obj geoJSON) error {
+	var err error
+	switch obj.Type {
+	case "Point", "MultiPoint":
+	case "LineString":
+		var line [][]float64
+		if err = json.Unmarshal(obj.Coordinates, &line); err == nil {
+			f.Lines = append(f.Lines, points(line))
+		}
+	case "MultiLineString":
+		var lines [][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &lines); err == nil {
+			for _, l := range lines {
+				f.Lines = append(f.Lines, points(l))
+			}
+		}
+	case "Polygon":
+		var rings [][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &rings); err == nil {
+			f.Polygons = append(f.Polygons, polygon(rings))
+		}
+	case "MultiPolygon":
+		var polys [][][][]float64
+		if err = json.Unmarshal(obj.Coordinates, &polys); err == nil {
+			for _, p := range polys {
+				f.Polygons = append(f.Polygons, polygon(p))
+			}
+		}
+	case "GeometryCollection":
+		for _, g := range obj.Geometries {
+			if err := f.addGeometry(g); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("Invalid GeoJSON geometry type %q", obj.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid GeoJSON %s coordinates: %v", obj.Type, err)
+	}
+	return nil
+}
+
+// points returns the points of GeoJSON positions,
+// ignoring their altitudes, if any.
+func points(pos [][]float64) []Point {
+	ps := make([]Point, 0, len(pos))
+	for _, p := range pos {
+		if len(p) < 2 {
+			continue
+		}
+		ps = append(ps, Point{Lon: p[0], Lat: p[1]})
+	}
+	return ps
+}
+
+// polygon returns the polygon of GeoJSON rings.
+func polygon(rings [][][]float64) Polygon {
+	p := make(Polygon, len(rings))
+	for i, r := range rings {
+		p[i] = points(r)
+	}
+	return p
+}
+
+// Values returns the values of the named numeric property
+// of the features, such as for the Values of Polygons. The
+// value of a feature without the property, or with a value
+// that is not a number, is NaN.
+func Values(features []Feature, property string) []float64 {
+	vs := make([]float64, len(features))
+	for i, f := range features {
+		v, ok := f.Properties[property].(float64)
+		if !ok {
+			v = math.NaN()
+		}
+		vs[i] = v
+	}
+	return vs
+}
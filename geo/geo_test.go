@@ -0,0 +1,188 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/gridio"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+const testRegions = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"name": "West", "rate": 3.5},
+			"geometry": {"type": "Polygon", "coordinates": [
+				[[-110, 30], [-100, 30], [-100, 45], [-110, 45], [-110, 30]],
+				[[-107, 35], [-103, 35], [-103, 40], [-107, 40], [-107, 35]]
+			]}
+		},
+		{
+			"type": "Feature",
+			"properties": {"name": "East", "rate": 7},
+			"geometry": {"type": "MultiPolygon", "coordinates": [
+				[[[-100, 30], [-85, 30], [-85, 45], [-100, 45], [-100, 30]]],
+				[[[-80, 25, 0], [-78, 25, 0], [-78, 27, 0], [-80, 25, 0]]]
+			]}
+		},
+		{
+			"type": "Feature",
+			"properties": {"name": "River"},
+			"geometry": {"type": "LineString", "coordinates": [[-95, 47], [-90, 38], [-89, 29]]}
+		}
+	]
+}`
+
+// ExamplePolygons draws a choropleth map of
+// regions in the Albers projection.
+func ExamplePolygons() {
+	features, err := ReadGeoJSON(strings.NewReader(testRegions))
+	if err != nil {
+		panic(err)
+	}
+	proj := gridio.Albers{Lon0: -96, Lat0: 23, Lat1: 29.5, Lat2: 45.5}
+
+	cm := palette.Linear(palette.Heat(16, 1))
+	cm.SetMin(0)
+	cm.SetMax(10)
+	regions := NewPolygons(features, proj)
+	regions.Values = Values(features, "rate")
+	regions.ColorMap = cm
+	rivers := NewPolylines(features, proj)
+	rivers.Color = color.RGBA{B: 255, A: 255}
+
+	p, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	p.Title.Text = "Rates by region"
+	p.HideAxes()
+	p.Add(regions, rivers)
+	p.Save(4*vg.Inch, 3*vg.Inch, "choropleth.png")
+}
+
+func TestReadGeoJSON(t *testing.T) {
+	features, err := ReadGeoJSON(strings.NewReader(testRegions))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(features) != 3 {
+		t.Fatalf("unexpected number of features: got:%d want:3", len(features))
+	}
+	if n := len(features[0].Polygons); n != 1 || len(features[0].Polygons[0]) != 2 {
+		t.Errorf("unexpected polygons of the first feature: got:%v", features[0].Polygons)
+	}
+	if n := len(features[1].Polygons); n != 2 {
+		t.Errorf("unexpected number of polygons of the second feature: got:%d want:2", n)
+	}
+	if got, want := features[1].Polygons[1][0][1], (Point{Lon: -78, Lat: 25}); got != want {
+		t.Errorf("unexpected point: got:%v want:%v", got, want)
+	}
+	if want := [][]Point{{{-95, 47}, {-90, 38}, {-89, 29}}}; !reflect.DeepEqual(features[2].Lines, want) {
+		t.Errorf("unexpected lines: got:%v want:%v", features[2].Lines, want)
+	}
+
+	vs := Values(features, "rate")
+	if vs[0] != 3.5 || vs[1] != 7 || !math.IsNaN(vs[2]) {
+		t.Errorf("unexpected values: got:%v", vs)
+	}
+
+	features, err = ReadGeoJSON(strings.NewReader(`{"type": "GeometryCollection", "geometries": [
+		{"type": "Point", "coordinates": [1, 2]},
+		{"type": "MultiLineString", "coordinates": [[[0, 0], [1, 1]], [[2, 2], [3, 3]]]}
+	]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(features) != 1 || len(features[0].Lines) != 2 || features[0].Polygons != nil {
+		t.Errorf("unexpected geometry collection: got:%+v", features)
+	}
+
+	for _, bad := range []string{
+		`{"type": "Circle", "coordinates": [0, 0]}`,
+		`{"type": "Polygon", "coordinates": [0, 0]}`,
+		`{"type": "FeatureCollection", "features": [{"type": "Polygon"}]}`,
+		`not json`,
+	} {
+		if _, err := ReadGeoJSON(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestPolygons(t *testing.T) {
+	features, err := ReadGeoJSON(strings.NewReader(testRegions))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	regions := NewPolygons(features, nil)
+	xmin, xmax, ymin, ymax := regions.DataRange()
+	if xmin != -110 || xmax != -78 || ymin != 25 || ymax != 45 {
+		t.Errorf("unexpected data range: got:[%v %v]×[%v %v]", xmin, xmax, ymin, ymax)
+	}
+	rivers := NewPolylines(features, gridio.Mercator{})
+	if xmin, xmax, _, _ := rivers.DataRange(); xmin >= xmax || xmax >= 0 {
+		t.Errorf("unexpected projected data range: got:[%v %v]", xmin, xmax)
+	}
+
+	cm := palette.Linear(palette.Heat(16, 1))
+	cm.SetMin(0)
+	cm.SetMax(5)
+	regions.Values = Values(features, "rate")
+	regions.ColorMap = cm
+	want, _ := cm.At(3.5)
+	if got := regions.color(0); got != want {
+		t.Errorf("unexpected color of the first region: got:%v want:%v", got, want)
+	}
+	if got := regions.color(1); got != regions.Color {
+		t.Errorf("unexpected color of the region out of range: got:%v want:%v", got, regions.Color)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(regions)
+	var r recorder.Canvas
+	p.Draw(draw.NewCanvas(&r, 100, 100))
+	var fills int
+	for _, a := range r.Actions {
+		if f, ok := a.(*recorder.Fill); ok {
+			fills++
+			// The hole of the first polygon, given wound
+			// as its outer boundary, is wound against it.
+			if fills == 2 {
+				outer := area(pathPoints(f.Path[:5]))
+				hole := area(pathPoints(f.Path[6:11]))
+				if outer*hole >= 0 {
+					t.Errorf("hole wound with its boundary: areas %v and %v", outer, hole)
+				}
+			}
+		}
+	}
+	// The background and the three polygons are filled.
+	if fills != 4 {
+		t.Errorf("unexpected number of fills: got:%d want:4", fills)
+	}
+}
+
+func pathPoints(p vg.Path) []vg.Point {
+	pts := make([]vg.Point, len(p))
+	for i, c := range p {
+		pts[i] = c.Pos
+	}
+	return pts
+}
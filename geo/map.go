@@ -0,0 +1,268 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package geo
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/gridio"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Polygons implements the plot.Plotter interface, drawing the
+// polygons of geographic features in the map coordinates of a
+// projection. The polygons of each feature are filled with the
+// color of its value, as in a choropleth map.
+//
+// The X and Y axes of the plot are the map coordinates of the
+// projection, and should be given the same scale for the map to
+// keep its shape.
+type Polygons struct {
+	// Features holds the features.
+	Features []Feature
+
+	// Projection is the projection of the map.
+	// The longitudes and latitudes are drawn
+	// as they are if Projection is nil.
+	Projection gridio.Projection
+
+	// Values, if not nil, holds the value of each
+	// feature, by which its polygons are filled
+	// using ColorMap.
+	Values []float64
+
+	// ColorMap is the ColorMap of the Values.
+	ColorMap palette.ColorMap
+
+	// Color is the fill color of the polygons of the
+	// features whose values are missing, NaN or outside
+	// of the range of the ColorMap. If Color is nil,
+	// they are not filled.
+	Color color.Color
+
+	// LineStyle is the style of the borders of the
+	// polygons. If its Color is nil, the borders
+	// are not drawn.
+	LineStyle draw.LineStyle
+}
+
+// NewPolygons returns Polygons of the features drawn in the
+// given projection, filled in light gray with thin borders.
+func NewPolygons(features []Feature, proj gridio.Projection) *Polygons {
+	return &Polygons{
+		Features:   features,
+		Projection: proj,
+		Color:      color.Gray{Y: 220},
+		LineStyle:  draw.LineStyle{Color: color.Gray{Y: 64}, Width: vg.Points(0.5)},
+	}
+}
+
+// forward returns the map coordinates of
+// a point in the projection p, if any.
+func forward(p gridio.Projection, pt Point) (x, y float64) {
+	if p == nil {
+		return pt.Lon, pt.Lat
+	}
+	return p.Forward(pt.Lon, pt.Lat)
+}
+
+// path returns the path of the rings of a polygon in the
+// canvas coordinates of the map. The holes of the polygon
+// are wound in the opposite direction to its outer boundary,
+// so that they are not filled by either fill rule.
+func path(p gridio.Projection, poly Polygon, trX, trY func(float64) vg.Length) vg.Path {
+	var pa vg.Path
+	var outer float64
+	for i, ring := range poly {
+		if len(ring) < 3 {
+			continue
+		}
+		pts := make([]vg.Point, len(ring))
+		for j, pt := range ring {
+			x, y := forward(p, pt)
+			pts[j] = vg.Point{X: trX(x), Y: trY(y)}
+		}
+		a := area(pts)
+		if i == 0 {
+			outer = a
+		} else if a*outer > 0 {
+			for l, r := 0, len(pts)-1; l < r; l, r = l+1, r-1 {
+				pts[l], pts[r] = pts[r], pts[l]
+			}
+		}
+		pa.Move(pts[0])
+		for _, pt := range pts[1:] {
+			pa.Line(pt)
+		}
+		pa.Close()
+	}
+	return pa
+}
+
+// area returns the signed area of a ring,
+// positive if it is wound anticlockwise.
+func area(pts []vg.Point) float64 {
+	var a float64
+	for i, p := range pts {
+		q := pts[(i+1)%len(pts)]
+		a += float64(p.X*q.Y - q.X*p.Y)
+	}
+	return a / 2
+}
+
+// Plot implements the plot.Plotter interface.
+func (ps *Polygons) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, f := range ps.Features {
+		col := ps.color(i)
+		for _, poly := range f.Polygons {
+			pa := path(ps.Projection, poly, trX, trY)
+			if len(pa) == 0 {
+				continue
+			}
+			if col != nil {
+				c.SetColor(col)
+				c.Fill(pa)
+			}
+			if ps.LineStyle.Color != nil && ps.LineStyle.Width > 0 {
+				c.SetLineStyle(ps.LineStyle)
+				c.Stroke(pa)
+			}
+		}
+	}
+}
+
+// color returns the fill color of feature i.
+func (ps *Polygons) color(i int) color.Color {
+	if ps.Values == nil || ps.ColorMap == nil || i >= len(ps.Values) {
+		return ps.Color
+	}
+	c, err := ps.ColorMap.At(ps.Values[i])
+	if err != nil {
+		return ps.Color
+	}
+	return c
+}
+
+// DataRange implements the plot.DataRanger interface,
+// returning the extent of the polygons on the map.
+func (ps *Polygons) DataRange() (xmin, xmax, ymin, ymax float64) {
+	r := newExtent()
+	for _, f := range ps.Features {
+		for _, poly := range f.Polygons {
+			for _, ring := range poly {
+				r.add(ps.Projection, ring)
+			}
+		}
+	}
+	return r.xmin, r.xmax, r.ymin, r.ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (ps *Polygons) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	if ps.Color != nil {
+		c.FillPolygon(ps.Color, c.ClipPolygonY(pts))
+	}
+	if ps.LineStyle.Color != nil && ps.LineStyle.Width > 0 {
+		pts = append(pts, pts[0])
+		c.StrokeLines(ps.LineStyle, c.ClipLinesY(pts)...)
+	}
+}
+
+// Polylines implements the plot.Plotter interface, drawing
+// the lines of geographic features, such as rivers or roads,
+// in the map coordinates of a projection.
+type Polylines struct {
+	// Features holds the features.
+	Features []Feature
+
+	// Projection is the projection of the map.
+	// The longitudes and latitudes are drawn
+	// as they are if Projection is nil.
+	Projection gridio.Projection
+
+	// LineStyle is the style of the lines.
+	draw.LineStyle
+}
+
+// NewPolylines returns Polylines of the features drawn
+// in the given projection with the default line style.
+func NewPolylines(features []Feature, proj gridio.Projection) *Polylines {
+	return &Polylines{
+		Features:   features,
+		Projection: proj,
+		LineStyle:  plotter.DefaultLineStyle,
+	}
+}
+
+// Plot implements the plot.Plotter interface.
+func (pl *Polylines) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, f := range pl.Features {
+		for _, line := range f.Lines {
+			pts := make([]vg.Point, len(line))
+			for i, pt := range line {
+				x, y := forward(pl.Projection, pt)
+				pts[i] = vg.Point{X: trX(x), Y: trY(y)}
+			}
+			c.StrokeLines(pl.LineStyle, c.ClipLinesXY(pts)...)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface,
+// returning the extent of the lines on the map.
+func (pl *Polylines) DataRange() (xmin, xmax, ymin, ymax float64) {
+	r := newExtent()
+	for _, f := range pl.Features {
+		for _, line := range f.Lines {
+			r.add(pl.Projection, line)
+		}
+	}
+	return r.xmin, r.xmax, r.ymin, r.ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (pl *Polylines) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(pl.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// extent is the extent of points on a map.
+type extent struct {
+	xmin, xmax, ymin, ymax float64
+}
+
+func newExtent() *extent {
+	return &extent{
+		xmin: math.Inf(1), xmax: math.Inf(-1),
+		ymin: math.Inf(1), ymax: math.Inf(-1),
+	}
+}
+
+// add extends the extent to the map coordinates of
+// the points in the projection p, skipping those
+// outside of the map.
+func (e *extent) add(p gridio.Projection, pts []Point) {
+	for _, pt := range pts {
+		x, y := forward(p, pt)
+		if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+			continue
+		}
+		e.xmin, e.xmax = math.Min(e.xmin, x), math.Max(e.xmax, x)
+		e.ymin, e.ymax = math.Min(e.ymin, y), math.Max(e.ymax, y)
+	}
+}
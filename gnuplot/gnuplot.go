@@ -0,0 +1,235 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gnuplot exports plots as gnuplot scripts, with the
+// data of their plotters in separate data files, so that the
+// plots may be changed and drawn again with gnuplot.
+//
+// The titles, labels, ranges and scales of the axes, the
+// location of the legend, and the lines, scatters, grids and
+// heat maps of a plot are exported, with their styles. Other
+// plotters are noted in comments of the script, and not drawn.
+// The scripts use dash types, which need gnuplot 5.
+package gnuplot
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// DPI is the resolution of the PNG images
+// drawn by the scripts.
+const DPI = 96
+
+// pointTypes holds the gnuplot point types
+// of the shapes of glyphs.
+var pointTypes = []struct {
+	shape draw.GlyphDrawer
+	typ   int
+}{
+	{shape: draw.PlusGlyph{}, typ: 1},
+	{shape: draw.CrossGlyph{}, typ: 2},
+	{shape: draw.BoxGlyph{}, typ: 4},
+	{shape: draw.SquareGlyph{}, typ: 5},
+	{shape: draw.RingGlyph{}, typ: 6},
+	{shape: draw.CircleGlyph{}, typ: 7},
+	{shape: draw.PyramidGlyph{}, typ: 8},
+	{shape: draw.TriangleGlyph{}, typ: 9},
+}
+
+// Save writes a gnuplot script to the named file that draws
+// the plot p as a PNG image of the given size. The image is
+// written to the file of the same name with the extension
+// ".png", and the data of the plotters are written to the
+// files of the same name with the extensions ".1.dat", ".2.dat"
+// and so on, in the directory of the script. The script reads
+// and writes the files relative to the working directory, so
+// it should be run from its directory.
+func Save(p *plot.Plot, w, h vg.Length, file string) error {
+	dir := filepath.Dir(file)
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	script, data := export(p, w, h, base)
+	for i, d := range data {
+		name := fmt.Sprintf("%s.%d.dat", base, i+1)
+		if err := ioutil.WriteFile(filepath.Join(dir, name), d, 0666); err != nil {
+			return err
+		}
+	}
+	return ioutil.WriteFile(file, script, 0666)
+}
+
+// export returns the script drawing the plot p to the image
+// base.png, and the contents of its data files base.1.dat,
+// base.2.dat and so on.
+func export(p *plot.Plot, w, h vg.Length, base string) (script []byte, data [][]byte) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "set terminal pngcairo size %d,%d noenhanced\n", round(w.Dots(DPI)), round(h.Dots(DPI)))
+	fmt.Fprintf(&buf, "set output %s\n", quote(base+".png"))
+	if p.Title.Text != "" {
+		fmt.Fprintf(&buf, "set title %s\n", quote(p.Title.Text))
+	}
+	for _, a := range []struct {
+		name string
+		axis *plot.Axis
+	}{{"x", &p.X}, {"y", &p.Y}} {
+		if a.axis.Label.Text != "" {
+			fmt.Fprintf(&buf, "set %slabel %s\n", a.name, quote(a.axis.Label.Text))
+		}
+		fmt.Fprintf(&buf, "set %srange [%g:%g]\n", a.name, a.axis.Min, a.axis.Max)
+		if _, ok := a.axis.Scale.(plot.LogScale); ok {
+			fmt.Fprintf(&buf, "set logscale %s\n", a.name)
+		}
+	}
+	key := "bottom"
+	if p.Legend.Top {
+		key = "top"
+	}
+	if p.Legend.Left {
+		key += " left"
+	} else {
+		key += " right"
+	}
+	fmt.Fprintf(&buf, "set key %s\n", key)
+
+	var plots []string
+	for _, d := range p.Plotters() {
+		title := "notitle"
+		if t, ok := d.(plot.Thumbnailer); ok {
+			if text, ok := p.Legend.EntryText(t); ok {
+				title = "title " + quote(text)
+			}
+		}
+		file := quote(fmt.Sprintf("%s.%d.dat", base, len(data)+1))
+
+		switch d := d.(type) {
+		case *plotter.Line:
+			style := "lines " + lineStyle(d.LineStyle)
+			if d.Points != nil {
+				style = "linespoints " + lineStyle(d.LineStyle) + " " + pointStyle(*d.Points)
+			}
+			data = append(data, xyData(d.XYs))
+			plots = append(plots, fmt.Sprintf("%s using 1:2 with %s %s", file, style, title))
+
+		case *plotter.Scatter:
+			data = append(data, xyData(d.XYs))
+			plots = append(plots, fmt.Sprintf("%s using 1:2 with points %s %s", file, pointStyle(d.GlyphStyle), title))
+
+		case *plotter.Grid:
+			fmt.Fprintf(&buf, "set grid %s\n", lineStyle(d.Horizontal))
+
+		case *plotter.HeatMap:
+			// Gnuplot draws the heat maps of a plot
+			// with a single palette.
+			cs := d.Palette.Colors()
+			if len(cs) > 0 {
+				fmt.Fprint(&buf, "set palette defined (")
+				for i, c := range cs {
+					if i > 0 {
+						fmt.Fprint(&buf, ", ")
+					}
+					fmt.Fprintf(&buf, "%d %s", i, quote(rgb(c)))
+				}
+				fmt.Fprint(&buf, ")\n")
+			}
+			fmt.Fprintf(&buf, "set cbrange [%g:%g]\n", d.Min, d.Max)
+			data = append(data, gridData(d.GridXYZ))
+			plots = append(plots, fmt.Sprintf("%s using 1:2:3 with image %s", file, title))
+
+		default:
+			fmt.Fprintf(&buf, "# Plotter of type %T not exported.\n", d)
+		}
+	}
+	if len(plots) > 0 {
+		fmt.Fprintf(&buf, "plot %s\n", strings.Join(plots, ", \\\n     "))
+	}
+	return buf.Bytes(), data
+}
+
+// xyData returns the data file of the points xys.
+func xyData(xys plotter.XYer) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		fmt.Fprintf(&buf, "%g %g\n", x, y)
+	}
+	return buf.Bytes()
+}
+
+// gridData returns the data file of the grid g,
+// with a blank line after each row of cells, as
+// gnuplot expects of the data of images.
+func gridData(g plotter.GridXYZ) []byte {
+	var buf bytes.Buffer
+	c, r := g.Dims()
+	for j := 0; j < r; j++ {
+		for i := 0; i < c; i++ {
+			fmt.Fprintf(&buf, "%g %g %g\n", g.X(i), g.Y(j), g.Z(i, j))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// lineStyle returns the gnuplot style of the line style sty.
+func lineStyle(sty draw.LineStyle) string {
+	s := fmt.Sprintf("lw %g lc rgb %s", sty.Width.Points(), quote(rgb(sty.Color)))
+	if len(sty.Dashes) > 0 {
+		ds := make([]string, len(sty.Dashes))
+		for i, d := range sty.Dashes {
+			ds[i] = fmt.Sprintf("%g", d.Points())
+		}
+		s += " dt (" + strings.Join(ds, ",") + ")"
+	}
+	return s
+}
+
+// pointStyle returns the gnuplot style of the glyph style sty.
+// Glyphs of unknown shapes are drawn as filled circles, and
+// glyphs of the default radius are drawn at point size one.
+func pointStyle(sty draw.GlyphStyle) string {
+	typ := 7
+	for _, pt := range pointTypes {
+		if reflect.TypeOf(pt.shape) == reflect.TypeOf(sty.Shape) {
+			typ = pt.typ
+		}
+	}
+	size := sty.Radius / plotter.DefaultGlyphStyle.Radius
+	return fmt.Sprintf("pt %d ps %g lc rgb %s", typ, size, quote(rgb(sty.Color)))
+}
+
+// rgb returns the gnuplot color specification of c,
+// "#rrggbb" for an opaque color, or "#aarrggbb" with
+// the transparency, the complement of the alpha, for
+// a translucent color.
+func rgb(c color.Color) string {
+	if c == nil {
+		return "#ff000000"
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", 0xff-n.A, n.R, n.G, n.B)
+}
+
+// quote returns s as a double-quoted gnuplot string.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// round returns x rounded to the nearest integer.
+func round(x float64) int {
+	return int(math.Floor(x + 0.5))
+}
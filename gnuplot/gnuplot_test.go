@@ -0,0 +1,101 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gnuplot
+
+import (
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+type grid struct{ mat64.Matrix }
+
+func (g grid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g grid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g grid) X(c int) float64    { return float64(c) }
+func (g grid) Y(r int) float64    { return float64(r) }
+
+func TestSave(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = `Say "hi"`
+	p.X.Label.Text = "x"
+	p.Y.Scale = plot.LogScale{}
+	p.Legend.Top = true
+
+	h := plotter.NewHeatMap(grid{mat64.NewDense(2, 2, []float64{1, 2, 3, 4})}, palette.Heat(2, 1))
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 1}, {X: 1, Y: 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Color = color.NRGBA{R: 255, A: 128}
+	l.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	s, err := plotter.NewScatter(plotter.XYs{{X: 0.5, Y: 1.5}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Shape = draw.CircleGlyph{}
+	s.Radius = vg.Points(5)
+	p.Add(plotter.NewGrid(), h, l, s, plotter.NewFunction(func(x float64) float64 { return x }))
+	p.Legend.Add("line", l)
+	p.Y.Min, p.Y.Max = 0.5, 2
+
+	dir, err := ioutil.TempDir("", "gnuplot")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	err = Save(p, 4*vg.Inch, 3*vg.Inch, filepath.Join(dir, "fig.gp"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		file, want string
+	}{
+		{
+			file: "fig.gp",
+			want: `set terminal pngcairo size 384,288 noenhanced
+set output "fig.png"
+set title "Say \"hi\""
+set xlabel "x"
+set xrange [-0.5:1.5]
+set yrange [0.5:2]
+set logscale y
+set key top right
+set grid lw 0.25 lc rgb "#808080"
+set palette defined (0 "#ff0000", 1 "#ffff00")
+set cbrange [1:4]
+# Plotter of type *plotter.Function not exported.
+plot "fig.1.dat" using 1:2:3 with image notitle, \
+     "fig.2.dat" using 1:2 with lines lw 1 lc rgb "#7fff0000" dt (4,2) title "line", \
+     "fig.3.dat" using 1:2 with points pt 7 ps 2 lc rgb "#000000" notitle
+`,
+		},
+		{file: "fig.1.dat", want: "0 0 1\n1 0 2\n\n0 1 3\n1 1 4\n\n"},
+		{file: "fig.2.dat", want: "0 1\n1 2\n"},
+		{file: "fig.3.dat", want: "0.5 1.5\n"},
+	} {
+		got, err := ioutil.ReadFile(filepath.Join(dir, test.file))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("unexpected contents of %s:\ngot:\n%s\nwant:\n%s", test.file, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TIFF tags read by ReadGeoTIFF.
+const (
+	tagImageWidth      = 256
+	tagImageLength     = 257
+	tagBitsPerSample   = 258
+	tagCompression     = 259
+	tagStripOffsets    = 273
+	tagSamplesPerPixel = 277
+	tagRowsPerStrip    = 278
+	tagStripByteCounts = 279
+	tagPlanarConfig    = 284
+	tagTileWidth       = 322
+	tagSampleFormat    = 339
+	tagPixelScale      = 33550
+	tagTiepoint        = 33922
+	tagGeoKeys         = 34735
+	tagNoData          = 42113
+)
+
+// GeoTIFF keys read by ReadGeoTIFF, and the raster
+// type of rasters whose pixels are points.
+const (
+	keyRasterType      = 1025
+	keyGeographicType  = 2048
+	keyProjectedCSType = 3072
+
+	rasterPixelIsPoint = 2
+)
+
+// tiff is a decoded TIFF image file directory.
+type tiff struct {
+	order binary.ByteOrder
+
+	// tags holds the values of the integer and floating
+	// point tags, and ascii those of the text tags.
+	tags  map[uint16][]float64
+	ascii map[uint16]string
+}
+
+// ReadGeoTIFF reads a grid from a band, counted from zero,
+// of the first image of an uncompressed TIFF file, such as
+// a digital elevation model. The samples may be integers or
+// floating point numbers of any of the sizes allowed by TIFF,
+// stored in strips.
+//
+// If the file holds GeoTIFF tags, the coordinates of the cells
+// are the model coordinates of their centers, and the EPSG code
+// of the coordinate reference system is stored in the "epsg"
+// attribute of the grid. Otherwise the coordinates are the
+// column and row indices of the pixels, counted from the bottom
+// row of the image. Samples equal to the GDAL no data value are
+// cells with no data.
+func ReadGeoTIFF(r io.Reader, band int) (*Grid, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	t, err := readTIFF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, rows := t.int(tagImageWidth, 0), t.int(tagImageLength, 0)
+	if cols <= 0 || rows <= 0 {
+		return nil, errors.New("Invalid TIFF image size")
+	}
+	if c := t.int(tagCompression, 1); c != 1 {
+		return nil, fmt.Errorf("Unsupported TIFF compression %d", c)
+	}
+	if _, ok := t.tags[tagTileWidth]; ok {
+		return nil, errors.New("Unsupported tiled TIFF image")
+	}
+	spp := t.int(tagSamplesPerPixel, 1)
+	if band < 0 || band >= spp {
+		return nil, fmt.Errorf("No band %d in TIFF image with %d bands", band, spp)
+	}
+	bits := t.int(tagBitsPerSample, 1)
+	for _, b := range t.tags[tagBitsPerSample] {
+		if int(b) != bits {
+			return nil, errors.New("Unsupported TIFF bands of different sizes")
+		}
+	}
+	format := t.int(tagSampleFormat, 1)
+	sample, err := t.sampleReader(format, bits)
+	if err != nil {
+		return nil, err
+	}
+	size := bits / 8
+
+	// Locate each sample of the band in the strips.
+	offsets, counts := t.tags[tagStripOffsets], t.tags[tagStripByteCounts]
+	if len(offsets) == 0 || len(offsets) != len(counts) {
+		return nil, errors.New("Invalid TIFF strips")
+	}
+	perStrip := t.int(tagRowsPerStrip, rows)
+	if perStrip <= 0 {
+		return nil, errors.New("Invalid TIFF rows per strip")
+	}
+	planar := t.int(tagPlanarConfig, 1) == 2
+	samples := spp
+	if planar {
+		samples = 1
+	}
+	// The samples of the grid must fit in the file.
+	if len(data)/size/samples/cols < rows {
+		return nil, errors.New("Invalid TIFF image size")
+	}
+	stride, step, first := cols*spp*size, spp*size, 0
+	if planar {
+		stride, step = cols*size, size
+		stripsPerBand := (rows + perStrip - 1) / perStrip
+		first = band * stripsPerBand
+		band = 0
+	}
+
+	g := newGrid(cols, rows)
+	for row := 0; row < rows; row++ {
+		s := first + row/perStrip
+		if s >= len(offsets) {
+			return nil, errors.New("Invalid TIFF strips")
+		}
+		start := int(offsets[s]) + (row%perStrip)*stride + band*size
+		end := start + (cols-1)*step + size
+		if start < 0 || end > len(data) || end-int(offsets[s]) > int(counts[s]) {
+			return nil, errors.New("TIFF strip out of range")
+		}
+		for col := 0; col < cols; col++ {
+			g.Data[row*cols+col] = sample(data[start+col*step:])
+		}
+	}
+
+	if nodata, ok := t.ascii[tagNoData]; ok {
+		v, err := strconv.ParseFloat(strings.TrimSpace(nodata), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid GeoTIFF no data value %q", nodata)
+		}
+		for i, z := range g.Data {
+			if z == v {
+				g.Data[i] = math.NaN()
+			}
+		}
+		g.Attrs["nodata"] = strings.TrimSpace(nodata)
+	}
+
+	scale, tie := t.tags[tagPixelScale], t.tags[tagTiepoint]
+	if len(scale) >= 2 && len(tie) >= 6 {
+		// The tie point is at the corner of its
+		// pixel unless the raster is of points.
+		center := 0.5
+		keys := t.tags[tagGeoKeys]
+		for i := 4; i+3 < len(keys); i += 4 {
+			key, loc, val := keys[i], keys[i+1], keys[i+3]
+			if loc != 0 {
+				continue
+			}
+			switch key {
+			case keyGeographicType, keyProjectedCSType:
+				g.Attrs["epsg"] = strconv.Itoa(int(val))
+			case keyRasterType:
+				if val == rasterPixelIsPoint {
+					center = 0
+				}
+			}
+		}
+		for c := range g.XCoord.Values {
+			g.XCoord.Values[c] = tie[3] + (float64(c)+center-tie[0])*scale[0]
+		}
+		for r := range g.YCoord.Values {
+			g.YCoord.Values[r] = tie[4] - (float64(r)+center-tie[1])*scale[1]
+		}
+		return g, g.orient()
+	}
+	g.flipRows()
+	for r := range g.YCoord.Values {
+		g.YCoord.Values[r] = float64(r)
+	}
+	return g, nil
+}
+
+// readTIFF decodes the first image file directory of a TIFF file.
+func readTIFF(data []byte) (*tiff, error) {
+	if len(data) < 8 {
+		return nil, errors.New("Not a TIFF file")
+	}
+	t := &tiff{tags: make(map[uint16][]float64), ascii: make(map[uint16]string)}
+	switch string(data[:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil, errors.New("Not a TIFF file")
+	}
+	if t.order.Uint16(data[2:]) != 42 {
+		return nil, errors.New("Not a TIFF file")
+	}
+
+	ifd := int(t.order.Uint32(data[4:]))
+	if ifd < 8 || ifd+2 > len(data) {
+		return nil, errors.New("Invalid TIFF directory offset")
+	}
+	n := int(t.order.Uint16(data[ifd:]))
+	if ifd+2+12*n > len(data) {
+		return nil, errors.New("Invalid TIFF directory")
+	}
+	for i := 0; i < n; i++ {
+		e := data[ifd+2+12*i:]
+		tag, typ, count := t.order.Uint16(e), t.order.Uint16(e[2:]), int(t.order.Uint32(e[4:]))
+		size := map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}[typ]
+		if size == 0 {
+			// Skip tags of unknown types.
+			continue
+		}
+		val := e[8:12]
+		if count*size > 4 {
+			off := int(t.order.Uint32(val))
+			if count < 0 || off < 0 || off+count*size > len(data) {
+				return nil, fmt.Errorf("Invalid TIFF tag %d", tag)
+			}
+			val = data[off : off+count*size]
+		}
+		if typ == 2 {
+			t.ascii[tag] = strings.TrimRight(string(val[:count]), "\x00")
+			continue
+		}
+		vs := make([]float64, count)
+		for j := range vs {
+			v := val[j*size:]
+			switch typ {
+			case 1, 7:
+				vs[j] = float64(v[0])
+			case 6:
+				vs[j] = float64(int8(v[0]))
+			case 3:
+				vs[j] = float64(t.order.Uint16(v))
+			case 8:
+				vs[j] = float64(int16(t.order.Uint16(v)))
+			case 4:
+				vs[j] = float64(t.order.Uint32(v))
+			case 9:
+				vs[j] = float64(int32(t.order.Uint32(v)))
+			case 5:
+				vs[j] = float64(t.order.Uint32(v)) / float64(t.order.Uint32(v[4:]))
+			case 10:
+				vs[j] = float64(int32(t.order.Uint32(v))) / float64(int32(t.order.Uint32(v[4:])))
+			case 11:
+				vs[j] = float64(math.Float32frombits(t.order.Uint32(v)))
+			case 12:
+				vs[j] = math.Float64frombits(t.order.Uint64(v))
+			}
+		}
+		t.tags[tag] = vs
+	}
+	return t, nil
+}
+
+// int returns the first value of an integer tag,
+// or def if the tag is absent.
+func (t *tiff) int(tag uint16, def int) int {
+	if vs := t.tags[tag]; len(vs) > 0 {
+		return int(vs[0])
+	}
+	return def
+}
+
+// sampleReader returns a function reading a sample of the
+// given TIFF sample format and number of bits.
+func (t *tiff) sampleReader(format, bits int) (func([]byte) float64, error) {
+	o := t.order
+	switch {
+	case format == 1 && bits == 8:
+		return func(b []byte) float64 { return float64(b[0]) }, nil
+	case format == 1 && bits == 16:
+		return func(b []byte) float64 { return float64(o.Uint16(b)) }, nil
+	case format == 1 && bits == 32:
+		return func(b []byte) float64 { return float64(o.Uint32(b)) }, nil
+	case format == 2 && bits == 8:
+		return func(b []byte) float64 { return float64(int8(b[0])) }, nil
+	case format == 2 && bits == 16:
+		return func(b []byte) float64 { return float64(int16(o.Uint16(b))) }, nil
+	case format == 2 && bits == 32:
+		return func(b []byte) float64 { return float64(int32(o.Uint32(b))) }, nil
+	case format == 3 && bits == 32:
+		return func(b []byte) float64 { return float64(math.Float32frombits(o.Uint32(b))) }, nil
+	case format == 3 && bits == 64:
+		return func(b []byte) float64 { return math.Float64frombits(o.Uint64(b)) }, nil
+	}
+	return nil, fmt.Errorf("Unsupported TIFF samples of format %d with %d bits", format, bits)
+}
@@ -0,0 +1,195 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// tiffEntry is a tag of a TIFF file written by tiffFile,
+// with a value of type []uint16, []uint32, []float64
+// or string.
+type tiffEntry struct {
+	tag  uint16
+	vals interface{}
+}
+
+// tiffFile returns a little-endian TIFF file holding
+// the image data and the tags, which must be given in
+// increasing order. The strip offset tag is set to the
+// offset of the image data.
+func tiffFile(image []byte, tags []tiffEntry) []byte {
+	le := binary.LittleEndian
+	ifd := 8 + len(image)
+	extra := ifd + 2 + 12*len(tags) + 4
+
+	var dir, ext bytes.Buffer
+	binary.Write(&dir, le, uint16(len(tags)))
+	for _, e := range tags {
+		var typ uint16
+		var val bytes.Buffer
+		var count int
+		switch v := e.vals.(type) {
+		case []uint16:
+			typ, count = 3, len(v)
+			binary.Write(&val, le, v)
+		case []uint32:
+			if e.tag == tagStripOffsets {
+				v = []uint32{8}
+			}
+			typ, count = 4, len(v)
+			binary.Write(&val, le, v)
+		case []float64:
+			typ, count = 12, len(v)
+			binary.Write(&val, le, v)
+		case string:
+			typ, count = 2, len(v)+1
+			val.WriteString(v + "\x00")
+		}
+		binary.Write(&dir, le, e.tag)
+		binary.Write(&dir, le, typ)
+		binary.Write(&dir, le, uint32(count))
+		if val.Len() <= 4 {
+			b := make([]byte, 4)
+			copy(b, val.Bytes())
+			dir.Write(b)
+			continue
+		}
+		binary.Write(&dir, le, uint32(extra+ext.Len()))
+		ext.Write(val.Bytes())
+	}
+	binary.Write(&dir, le, uint32(0))
+
+	var f bytes.Buffer
+	f.WriteString("II")
+	binary.Write(&f, le, uint16(42))
+	binary.Write(&f, le, uint32(ifd))
+	f.Write(image)
+	f.Write(dir.Bytes())
+	f.Write(ext.Bytes())
+	return f.Bytes()
+}
+
+func TestReadGeoTIFF(t *testing.T) {
+	// A 3×2 elevation model in float32, with the
+	// top left corner of the image at (100, 50)
+	// and 10×5 unit pixels.
+	var image bytes.Buffer
+	binary.Write(&image, binary.LittleEndian, []float32{
+		1, 2, 3,
+		4, -9999, 6,
+	})
+	tags := []tiffEntry{
+		{tagImageWidth, []uint16{3}},
+		{tagImageLength, []uint16{2}},
+		{tagBitsPerSample, []uint16{32}},
+		{tagCompression, []uint16{1}},
+		{tagStripOffsets, []uint32{0}},
+		{tagSamplesPerPixel, []uint16{1}},
+		{tagRowsPerStrip, []uint16{2}},
+		{tagStripByteCounts, []uint32{24}},
+		{tagSampleFormat, []uint16{3}},
+		{tagPixelScale, []float64{10, 5, 0}},
+		{tagTiepoint, []float64{0, 0, 0, 100, 50, 0}},
+		{tagGeoKeys, []uint16{1, 1, 0, 1, keyProjectedCSType, 0, 1, 32631}},
+		{tagNoData, "-9999"},
+	}
+	g, err := ReadGeoTIFF(bytes.NewReader(tiffFile(image.Bytes(), tags)), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c, r := g.Dims(); c != 3 || r != 2 {
+		t.Fatalf("unexpected dimensions: got:%dx%d want:3x2", c, r)
+	}
+	if want := []float64{105, 115, 125}; !reflect.DeepEqual(g.XCoord.Values, want) {
+		t.Errorf("unexpected X coordinates: got:%v want:%v", g.XCoord.Values, want)
+	}
+	if want := []float64{42.5, 47.5}; !reflect.DeepEqual(g.YCoord.Values, want) {
+		t.Errorf("unexpected Y coordinates: got:%v want:%v", g.YCoord.Values, want)
+	}
+	// The top row of the image is the last row of the grid.
+	if g.Z(0, 1) != 1 || g.Z(2, 0) != 6 || !math.IsNaN(g.Z(1, 0)) {
+		t.Errorf("unexpected values: %v", g.Data)
+	}
+	if g.Attrs["epsg"] != "32631" {
+		t.Errorf("unexpected EPSG code: got:%q want:\"32631\"", g.Attrs["epsg"])
+	}
+
+	// Without geographic tags the coordinates are indices.
+	g, err = ReadGeoTIFF(bytes.NewReader(tiffFile(image.Bytes(), tags[:9])), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []float64{0, 1}; !reflect.DeepEqual(g.YCoord.Values, want) {
+		t.Errorf("unexpected Y coordinates: got:%v want:%v", g.YCoord.Values, want)
+	}
+	if g.Z(1, 0) != -9999 {
+		t.Errorf("unexpected value without no data tag: got:%v want:-9999", g.Z(1, 0))
+	}
+
+	if _, err := ReadGeoTIFF(bytes.NewReader(tiffFile(image.Bytes(), tags)), 1); err == nil {
+		t.Error("expected error for missing band")
+	}
+}
+
+func TestReadGeoTIFFBands(t *testing.T) {
+	// A 2×1 image of two interleaved uint16 bands.
+	var image bytes.Buffer
+	binary.Write(&image, binary.LittleEndian, []uint16{1, 10, 2, 20})
+	tags := []tiffEntry{
+		{tagImageWidth, []uint16{2}},
+		{tagImageLength, []uint16{1}},
+		{tagBitsPerSample, []uint16{16, 16}},
+		{tagStripOffsets, []uint32{0}},
+		{tagSamplesPerPixel, []uint16{2}},
+		{tagStripByteCounts, []uint32{8}},
+	}
+	g, err := ReadGeoTIFF(bytes.NewReader(tiffFile(image.Bytes(), tags)), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []float64{10, 20}; !reflect.DeepEqual(g.Data, want) {
+		t.Errorf("unexpected second band: got:%v want:%v", g.Data, want)
+	}
+	tags = append(tags, tiffEntry{tagSampleFormat, []uint16{3}})
+	if _, err := ReadGeoTIFF(bytes.NewReader(tiffFile(image.Bytes(), tags)), 1); err == nil {
+		t.Error("expected error for 16 bit floating point samples")
+	}
+}
+
+func TestReadGeoTIFFMalformed(t *testing.T) {
+	image := make([]byte, 4)
+	header := func(cols, rows uint32, perStrip uint16) []tiffEntry {
+		return []tiffEntry{
+			{tagImageWidth, []uint32{cols}},
+			{tagImageLength, []uint32{rows}},
+			{tagBitsPerSample, []uint16{8}},
+			{tagCompression, []uint16{1}},
+			{tagStripOffsets, []uint32{0}},
+			{tagSamplesPerPixel, []uint16{1}},
+			{tagRowsPerStrip, []uint16{perStrip}},
+			{tagStripByteCounts, []uint32{4}},
+		}
+	}
+	if _, err := ReadGeoTIFF(bytes.NewReader(tiffFile(image, header(2, 2, 2))), 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		name string
+		tags []tiffEntry
+	}{
+		{name: "zero rows per strip", tags: header(2, 2, 0)},
+		{name: "huge image", tags: header(0x80000000, 0x80000000, 1)},
+		{name: "image larger than its data", tags: header(3, 2, 2)},
+	} {
+		if _, err := ReadGeoTIFF(bytes.NewReader(tiffFile(image, test.tags)), 0); err == nil {
+			t.Errorf("expected error for %s", test.name)
+		}
+	}
+}
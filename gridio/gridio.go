@@ -0,0 +1,181 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gridio reads gridded scalar fields from raster
+// files, as Grids that implement the plotter.GridXYZ interface,
+// so that they can be drawn by heat maps and contour plots.
+//
+// Grids can be read from the variables of NetCDF classic and
+// 64-bit offset files, the bands of uncompressed GeoTIFF files,
+// PGM images and CSV tables. The coordinates of the cells and
+// the names and units of the values are read from the file when
+// it holds them.
+//
+// Grids from different sources can be compared on the same axes
+// by resampling them to common cells with Resample and Resize, and
+// by reprojecting them between map projections with Reproject.
+package gridio
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// Coordinate describes the coordinates of
+// the columns or rows of a Grid.
+type Coordinate struct {
+	// Name and Units are the name and the
+	// units of the coordinate, if known.
+	Name, Units string
+
+	// Values holds the coordinate of the center of
+	// each column or row, in increasing order.
+	Values []float64
+}
+
+// Grid is a grid of values read from a file. It implements
+// the plotter.GridXYZ interface, with the rows in increasing
+// order of their Y coordinates, so that the first row of an
+// image is the last row of its Grid. Cells with no data are
+// NaN.
+type Grid struct {
+	// Name and Units are the name and the
+	// units of the values, if known.
+	Name, Units string
+
+	// XCoord and YCoord are the coordinates
+	// of the columns and the rows.
+	XCoord, YCoord Coordinate
+
+	// Attrs holds other metadata read from the
+	// file, such as the attributes of a NetCDF
+	// variable or the EPSG code of the coordinate
+	// reference system of a GeoTIFF file.
+	Attrs map[string]string
+
+	// Data holds the values of the cells
+	// by row, from the first row.
+	Data []float64
+}
+
+var _ plotter.GridXYZ = (*Grid)(nil)
+
+// newGrid returns a Grid of the given dimensions with
+// its columns and rows at their indices.
+func newGrid(cols, rows int) *Grid {
+	g := &Grid{
+		XCoord: Coordinate{Values: make([]float64, cols)},
+		YCoord: Coordinate{Values: make([]float64, rows)},
+		Attrs:  make(map[string]string),
+		Data:   make([]float64, cols*rows),
+	}
+	for c := range g.XCoord.Values {
+		g.XCoord.Values[c] = float64(c)
+	}
+	for r := range g.YCoord.Values {
+		g.YCoord.Values[r] = float64(r)
+	}
+	return g
+}
+
+// Dims implements the plotter.GridXYZ interface.
+func (g *Grid) Dims() (c, r int) {
+	return len(g.XCoord.Values), len(g.YCoord.Values)
+}
+
+// Z implements the plotter.GridXYZ interface.
+func (g *Grid) Z(c, r int) float64 {
+	cols := len(g.XCoord.Values)
+	if c < 0 || c >= cols || r < 0 || r >= len(g.YCoord.Values) {
+		panic("gridio: index out of range")
+	}
+	return g.Data[r*cols+c]
+}
+
+// X implements the plotter.GridXYZ interface.
+func (g *Grid) X(c int) float64 {
+	return g.XCoord.Values[c]
+}
+
+// Y implements the plotter.GridXYZ interface.
+func (g *Grid) Y(r int) float64 {
+	return g.YCoord.Values[r]
+}
+
+// Min returns the smallest value of the
+// grid, ignoring cells with no data.
+func (g *Grid) Min() float64 {
+	min := math.Inf(1)
+	for _, v := range g.Data {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// Max returns the largest value of the
+// grid, ignoring cells with no data.
+func (g *Grid) Max() float64 {
+	max := math.Inf(-1)
+	for _, v := range g.Data {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// flipRows reverses the order of the rows of the grid.
+func (g *Grid) flipRows() {
+	cols, rows := g.Dims()
+	for r := 0; r < rows/2; r++ {
+		top, bottom := g.Data[r*cols:(r+1)*cols], g.Data[(rows-1-r)*cols:(rows-r)*cols]
+		for c := range top {
+			top[c], bottom[c] = bottom[c], top[c]
+		}
+		ys := g.YCoord.Values
+		ys[r], ys[rows-1-r] = ys[rows-1-r], ys[r]
+	}
+}
+
+// flipCols reverses the order of the columns of the grid.
+func (g *Grid) flipCols() {
+	cols, rows := g.Dims()
+	for r := 0; r < rows; r++ {
+		row := g.Data[r*cols : (r+1)*cols]
+		for c := 0; c < cols/2; c++ {
+			row[c], row[cols-1-c] = row[cols-1-c], row[c]
+		}
+	}
+	xs := g.XCoord.Values
+	for c := 0; c < cols/2; c++ {
+		xs[c], xs[cols-1-c] = xs[cols-1-c], xs[c]
+	}
+}
+
+// orient puts the columns and rows of the grid in
+// increasing order of their coordinates, returning
+// an error if the coordinates are not monotonic.
+func (g *Grid) orient() error {
+	for _, a := range []struct {
+		vs   []float64
+		flip func()
+	}{
+		{vs: g.XCoord.Values, flip: g.flipCols},
+		{vs: g.YCoord.Values, flip: g.flipRows},
+	} {
+		if len(a.vs) > 1 && a.vs[0] > a.vs[1] {
+			a.flip()
+		}
+		for i := 1; i < len(a.vs); i++ {
+			if !(a.vs[i-1] < a.vs[i]) {
+				return errors.New("Grid coordinates not monotonic")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+)
+
+func ExampleReadCSV() {
+	const field = `1, 2, 3
+4, 5, 6
+7, NaN, 9`
+	g, err := ReadCSV(strings.NewReader(field))
+	if err != nil {
+		panic(err)
+	}
+	h := plotter.NewHeatMap(g, palette.Heat(12, 1))
+	fmt.Println(g.Z(0, 0), g.Z(0, 2), h.Min, h.Max)
+
+	// Output:
+	// 7 1 1 9
+}
+
+func TestReadPGM(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+	}{
+		{name: "plain", data: "P2\n# A comment.\n3 2\n300\n1 2 3\n4 5 300\n"},
+		{name: "raw", data: "P5 3 2 300\n\x00\x01\x00\x02\x00\x03\x00\x04\x00\x05\x01\x2c"},
+	} {
+		g, err := ReadPGM(strings.NewReader(test.data))
+		if err != nil {
+			t.Errorf("unexpected error for %s image: %v", test.name, err)
+			continue
+		}
+		if want := []float64{4, 5, 300, 1, 2, 3}; !reflect.DeepEqual(g.Data, want) {
+			t.Errorf("unexpected values of %s image: got:%v want:%v", test.name, g.Data, want)
+		}
+		if want := []float64{0, 1}; !reflect.DeepEqual(g.YCoord.Values, want) {
+			t.Errorf("unexpected Y coordinates of %s image: got:%v want:%v", test.name, g.YCoord.Values, want)
+		}
+	}
+
+	raw := "P5 2 1 255\n\x07\x08"
+	g, err := ReadPGM(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []float64{7, 8}; !reflect.DeepEqual(g.Data, want) {
+		t.Errorf("unexpected values: got:%v want:%v", g.Data, want)
+	}
+	if _, err := ReadPGM(strings.NewReader(raw[:len(raw)-1])); err == nil {
+		t.Error("expected error for truncated image")
+	}
+	if _, err := ReadPGM(strings.NewReader("P6 2 1 255\n")); err == nil {
+		t.Error("expected error for PPM image")
+	}
+	if _, err := ReadPGM(strings.NewReader("P5 100000000 100000000 255\n\x00")); err == nil {
+		t.Error("expected error for image larger than its data")
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	g, err := ReadCSV(strings.NewReader("1,,3\n4,NA,6\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c, r := g.Dims(); c != 3 || r != 2 {
+		t.Fatalf("unexpected dimensions: got:%dx%d want:3x2", c, r)
+	}
+	if g.Z(0, 0) != 4 || g.Z(2, 1) != 3 || !math.IsNaN(g.Z(1, 0)) || !math.IsNaN(g.Z(1, 1)) {
+		t.Errorf("unexpected values: %v", g.Data)
+	}
+	if g.Min() != 1 || g.Max() != 6 {
+		t.Errorf("unexpected range: got:[%v, %v] want:[1, 6]", g.Min(), g.Max())
+	}
+	if _, err := ReadCSV(strings.NewReader("1,2\n3\n")); err == nil {
+		t.Error("expected error for ragged table")
+	}
+	if _, err := ReadCSV(strings.NewReader("1,x\n")); err == nil {
+		t.Error("expected error for invalid value")
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NetCDF header tags and data types.
+const (
+	ncDimension = 0x0a
+	ncVariable  = 0x0b
+	ncAttribute = 0x0c
+
+	ncByte   = 1
+	ncChar   = 2
+	ncShort  = 3
+	ncInt    = 4
+	ncFloat  = 5
+	ncDouble = 6
+)
+
+// ncSize holds the size of each NetCDF data type.
+var ncSize = [...]int{ncByte: 1, ncChar: 1, ncShort: 2, ncInt: 4, ncFloat: 4, ncDouble: 8}
+
+// ncFill holds the default fill value of each
+// numeric NetCDF data type, marking missing data.
+var ncFill = [...]float64{
+	ncByte:   -127,
+	ncShort:  -32767,
+	ncInt:    -2147483647,
+	ncFloat:  float64(float32(9.9692099683868690e+36)),
+	ncDouble: 9.9692099683868690e+36,
+}
+
+// ncAttr is an attribute of a NetCDF file or variable,
+// holding either text or numbers.
+type ncAttr struct {
+	text string
+	vals []float64
+}
+
+// String returns the text or the
+// numbers of the attribute.
+func (a ncAttr) String() string {
+	if a.vals == nil {
+		return a.text
+	}
+	s := make([]string, len(a.vals))
+	for i, v := range a.vals {
+		s[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return strings.Join(s, ", ")
+}
+
+// ncVar is a variable of a NetCDF file.
+type ncVar struct {
+	name  string
+	dims  []int
+	attrs map[string]ncAttr
+	typ   int
+	begin int
+}
+
+// netCDF is the header of a NetCDF file.
+type netCDF struct {
+	numrecs int
+	dims    []string
+	lens    []int
+	vars    []ncVar
+}
+
+// ncReader reads big-endian values from the header of a
+// NetCDF file, recording the first error that occurs.
+type ncReader struct {
+	data []byte
+	off  int
+	err  error
+}
+
+// next returns the next n bytes, or nil if they
+// are not in the header or an error has occurred.
+func (r *ncReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.data)-r.off {
+		r.err = errors.New("Invalid NetCDF header")
+		return nil
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *ncReader) int() int {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return int(int32(binary.BigEndian.Uint32(b)))
+}
+
+func (r *ncReader) int64() int {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return int(int64(binary.BigEndian.Uint64(b)))
+}
+
+// padded returns n bytes padded to a multiple of four.
+func (r *ncReader) padded(n int) []byte {
+	b := r.next(n)
+	r.next((4 - n%4) % 4)
+	return b
+}
+
+func (r *ncReader) name() string {
+	return string(r.padded(r.int()))
+}
+
+// list reads the tag and length of a list.
+func (r *ncReader) list(tag int) int {
+	t, n := r.int(), r.int()
+	if t != tag && (t != 0 || n != 0) {
+		r.err = errors.New("Invalid NetCDF header")
+	}
+	return n
+}
+
+// ncValues decodes n values of the given type.
+func ncValues(b []byte, typ, n int) []float64 {
+	vs := make([]float64, n)
+	for i := range vs {
+		switch typ {
+		case ncByte:
+			vs[i] = float64(int8(b[i]))
+		case ncShort:
+			vs[i] = float64(int16(binary.BigEndian.Uint16(b[2*i:])))
+		case ncInt:
+			vs[i] = float64(int32(binary.BigEndian.Uint32(b[4*i:])))
+		case ncFloat:
+			vs[i] = float64(math.Float32frombits(binary.BigEndian.Uint32(b[4*i:])))
+		case ncDouble:
+			vs[i] = math.Float64frombits(binary.BigEndian.Uint64(b[8*i:]))
+		}
+	}
+	return vs
+}
+
+func (r *ncReader) attrs() map[string]ncAttr {
+	attrs := make(map[string]ncAttr)
+	n := r.list(ncAttribute)
+	for i := 0; i < n && r.err == nil; i++ {
+		name := r.name()
+		typ, count := r.int(), r.int()
+		if typ < ncByte || typ > ncDouble || count < 0 {
+			r.err = errors.New("Invalid NetCDF attribute")
+			break
+		}
+		b := r.padded(count * ncSize[typ])
+		if r.err != nil {
+			break
+		}
+		if typ == ncChar {
+			attrs[name] = ncAttr{text: strings.TrimRight(string(b), "\x00")}
+		} else {
+			attrs[name] = ncAttr{vals: ncValues(b, typ, count)}
+		}
+	}
+	return attrs
+}
+
+// readNetCDF decodes the header of a NetCDF classic
+// or 64-bit offset file.
+func readNetCDF(data []byte) (*netCDF, error) {
+	r := &ncReader{data: data}
+	magic := r.next(4)
+	if r.err != nil || string(magic[:3]) != "CDF" || (magic[3] != 1 && magic[3] != 2) {
+		if len(data) >= 4 && string(data[1:4]) == "HDF" {
+			return nil, errors.New("Unsupported NetCDF-4 file")
+		}
+		return nil, errors.New("Not a NetCDF file")
+	}
+	offset64 := magic[3] == 2
+
+	f := &netCDF{numrecs: r.int()}
+	n := r.list(ncDimension)
+	for i := 0; i < n && r.err == nil; i++ {
+		f.dims = append(f.dims, r.name())
+		f.lens = append(f.lens, r.int())
+		if f.lens[i] < 0 {
+			r.err = errors.New("Invalid NetCDF dimension length")
+		}
+	}
+	r.attrs() // The global attributes are not used.
+	n = r.list(ncVariable)
+	for i := 0; i < n && r.err == nil; i++ {
+		v := ncVar{name: r.name()}
+		nd := r.int()
+		for j := 0; j < nd && r.err == nil; j++ {
+			d := r.int()
+			if d < 0 || d >= len(f.dims) {
+				r.err = errors.New("Invalid NetCDF dimension")
+			}
+			v.dims = append(v.dims, d)
+		}
+		v.attrs = r.attrs()
+		v.typ = r.int()
+		r.int() // vsize
+		if offset64 {
+			v.begin = r.int64()
+		} else {
+			v.begin = r.int()
+		}
+		if v.typ < ncByte || v.typ > ncDouble {
+			r.err = errors.New("Invalid NetCDF variable type")
+		}
+		f.vars = append(f.vars, v)
+	}
+	return f, r.err
+}
+
+// variable returns the named variable.
+func (f *netCDF) variable(name string) (ncVar, bool) {
+	for _, v := range f.vars {
+		if v.name == name {
+			return v, true
+		}
+	}
+	return ncVar{}, false
+}
+
+// ReadNetCDF reads a grid from the named variable of a NetCDF
+// classic or 64-bit offset file. NetCDF-4 files, which are HDF5
+// files, are not supported.
+//
+// The last two dimensions of the variable are the rows and the
+// columns of the grid. For variables of more dimensions, such as
+// time series of fields, the first field is read, at index zero
+// of the other dimensions. Values equal to the _FillValue or
+// missing_value attributes of the variable, or to the default
+// fill value of its type, are cells with no data, and the others
+// are scaled by its scale_factor and add_offset attributes.
+//
+// The coordinates of the cells are the values of the coordinate
+// variables of the dimensions, if any, or otherwise the indices
+// of the columns and rows. The name and units of the variable
+// and of its coordinates are taken from their names and units
+// attributes, and the other attributes of the variable are
+// stored in the Attrs of the grid.
+func ReadNetCDF(r io.Reader, variable string) (*Grid, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := readNetCDF(data)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := f.variable(variable)
+	if !ok {
+		return nil, fmt.Errorf("No NetCDF variable named %s", variable)
+	}
+	if v.typ == ncChar {
+		return nil, fmt.Errorf("NetCDF variable %s is not numeric", variable)
+	}
+	if len(v.dims) < 2 {
+		return nil, fmt.Errorf("NetCDF variable %s has fewer than two dimensions", variable)
+	}
+	if f.lens[v.dims[0]] == 0 && f.numrecs == 0 {
+		return nil, fmt.Errorf("NetCDF variable %s has no records", variable)
+	}
+	ydim, xdim := v.dims[len(v.dims)-2], v.dims[len(v.dims)-1]
+	cols, rows := f.lens[xdim], f.lens[ydim]
+	if cols == 0 || rows == 0 {
+		return nil, fmt.Errorf("Unsupported NetCDF grid over the record dimension in %s", variable)
+	}
+	if cols > len(data)/rows {
+		return nil, fmt.Errorf("NetCDF variable %s out of range", variable)
+	}
+	vals, err := f.values(data, v, cols*rows)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGrid(cols, rows)
+	g.Name = variable
+	var fill []float64
+	for _, name := range []string{"_FillValue", "missing_value"} {
+		fill = append(fill, v.attrs[name].vals...)
+	}
+	if fill == nil {
+		fill = []float64{ncFill[v.typ]}
+	}
+	scale, offset := 1.0, 0.0
+	if a, ok := v.attrs["scale_factor"]; ok && len(a.vals) > 0 {
+		scale = a.vals[0]
+	}
+	if a, ok := v.attrs["add_offset"]; ok && len(a.vals) > 0 {
+		offset = a.vals[0]
+	}
+	for i, z := range vals {
+		g.Data[i] = z*scale + offset
+		for _, fv := range fill {
+			if z == fv {
+				g.Data[i] = math.NaN()
+			}
+		}
+	}
+	for name, a := range v.attrs {
+		if name == "units" {
+			g.Units = a.String()
+			continue
+		}
+		g.Attrs[name] = a.String()
+	}
+
+	for _, c := range []struct {
+		dim   int
+		coord *Coordinate
+	}{
+		{dim: xdim, coord: &g.XCoord},
+		{dim: ydim, coord: &g.YCoord},
+	} {
+		c.coord.Name = f.dims[c.dim]
+		cv, ok := f.variable(f.dims[c.dim])
+		if !ok || len(cv.dims) != 1 || cv.dims[0] != c.dim || cv.typ == ncChar {
+			continue
+		}
+		vs, err := f.values(data, cv, len(c.coord.Values))
+		if err != nil {
+			return nil, err
+		}
+		copy(c.coord.Values, vs)
+		if a, ok := cv.attrs["units"]; ok {
+			c.coord.Units = a.String()
+		}
+	}
+	return g, g.orient()
+}
+
+// values returns the first n values of the variable.
+func (f *netCDF) values(data []byte, v ncVar, n int) ([]float64, error) {
+	if v.begin < 0 || v.begin > len(data) || n > (len(data)-v.begin)/ncSize[v.typ] {
+		return nil, fmt.Errorf("NetCDF variable %s out of range", v.name)
+	}
+	return ncValues(data[v.begin:], v.typ, n), nil
+}
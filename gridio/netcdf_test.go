@@ -0,0 +1,185 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ncTestVar is a variable of a NetCDF file written by ncFile.
+type ncTestVar struct {
+	name  string
+	dims  []int32
+	attrs []ncTestAttr
+	typ   int32
+	data  interface{}
+}
+
+// ncTestAttr is an attribute of a variable, with a
+// value of type string or a slice of numbers.
+type ncTestAttr struct {
+	name string
+	typ  int32
+	val  interface{}
+}
+
+// ncFile returns a NetCDF classic file with the given
+// dimensions, one record and the variables.
+func ncFile(dims []string, lens []int32, vars []ncTestVar) []byte {
+	be := binary.BigEndian
+	pad := func(b *bytes.Buffer) {
+		for b.Len()%4 != 0 {
+			b.WriteByte(0)
+		}
+	}
+	name := func(b *bytes.Buffer, s string) {
+		binary.Write(b, be, int32(len(s)))
+		b.WriteString(s)
+		pad(b)
+	}
+	header := func(begins []int32) []byte {
+		var b bytes.Buffer
+		b.WriteString("CDF\x01")
+		binary.Write(&b, be, int32(1))
+		binary.Write(&b, be, []int32{ncDimension, int32(len(dims))})
+		for i, d := range dims {
+			name(&b, d)
+			binary.Write(&b, be, lens[i])
+		}
+		binary.Write(&b, be, []int32{0, 0})
+		binary.Write(&b, be, []int32{ncVariable, int32(len(vars))})
+		for i, v := range vars {
+			name(&b, v.name)
+			binary.Write(&b, be, int32(len(v.dims)))
+			binary.Write(&b, be, v.dims)
+			if len(v.attrs) == 0 {
+				binary.Write(&b, be, []int32{0, 0})
+			} else {
+				binary.Write(&b, be, []int32{ncAttribute, int32(len(v.attrs))})
+			}
+			for _, a := range v.attrs {
+				name(&b, a.name)
+				binary.Write(&b, be, a.typ)
+				var val bytes.Buffer
+				if s, ok := a.val.(string); ok {
+					val.WriteString(s)
+				} else {
+					binary.Write(&val, be, a.val)
+				}
+				binary.Write(&b, be, int32(val.Len()/ncSize[a.typ]))
+				b.Write(val.Bytes())
+				pad(&b)
+			}
+			binary.Write(&b, be, v.typ)
+			binary.Write(&b, be, int32(binary.Size(v.data)))
+			binary.Write(&b, be, begins[i])
+		}
+		return b.Bytes()
+	}
+
+	begins := make([]int32, len(vars))
+	off := int32(len(header(begins)))
+	var data bytes.Buffer
+	for i, v := range vars {
+		begins[i] = off + int32(data.Len())
+		binary.Write(&data, be, v.data)
+		pad(&data)
+	}
+	return append(header(begins), data.Bytes()...)
+}
+
+func TestReadNetCDF(t *testing.T) {
+	// A temperature field over time, with
+	// latitudes in decreasing order.
+	f := ncFile([]string{"time", "lat", "lon"}, []int32{0, 2, 3}, []ncTestVar{
+		{
+			name:  "lat",
+			dims:  []int32{1},
+			attrs: []ncTestAttr{{"units", ncChar, "degrees_north"}},
+			typ:   ncDouble,
+			data:  []float64{20, 10},
+		},
+		{
+			name:  "lon",
+			dims:  []int32{2},
+			attrs: []ncTestAttr{{"units", ncChar, "degrees_east"}},
+			typ:   ncFloat,
+			data:  []float32{0, 5, 10},
+		},
+		{
+			name: "temp",
+			dims: []int32{0, 1, 2},
+			attrs: []ncTestAttr{
+				{"long_name", ncChar, "temperature"},
+				{"units", ncChar, "K"},
+				{"_FillValue", ncShort, []int16{-1}},
+				{"scale_factor", ncFloat, []float32{0.5}},
+				{"add_offset", ncDouble, []float64{273}},
+			},
+			typ:  ncShort,
+			data: []int16{0, 2, 4, -1, 8, 10},
+		},
+	})
+
+	g, err := ReadNetCDF(bytes.NewReader(f), "temp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c, r := g.Dims(); c != 3 || r != 2 {
+		t.Fatalf("unexpected dimensions: got:%dx%d want:3x2", c, r)
+	}
+	if g.Name != "temp" || g.Units != "K" || g.Attrs["long_name"] != "temperature" {
+		t.Errorf("unexpected metadata: name:%q units:%q attrs:%v", g.Name, g.Units, g.Attrs)
+	}
+	if want := (Coordinate{Name: "lon", Units: "degrees_east", Values: []float64{0, 5, 10}}); !reflect.DeepEqual(g.XCoord, want) {
+		t.Errorf("unexpected X coordinate: got:%+v want:%+v", g.XCoord, want)
+	}
+	if want := (Coordinate{Name: "lat", Units: "degrees_north", Values: []float64{10, 20}}); !reflect.DeepEqual(g.YCoord, want) {
+		t.Errorf("unexpected Y coordinate: got:%+v want:%+v", g.YCoord, want)
+	}
+	// The rows are ordered by increasing latitude.
+	if g.Z(0, 1) != 273 || g.Z(2, 0) != 278 || g.Z(1, 1) != 274 || !math.IsNaN(g.Z(0, 0)) {
+		t.Errorf("unexpected values: %v", g.Data)
+	}
+
+	if _, err := ReadNetCDF(bytes.NewReader(f), "lat"); err == nil {
+		t.Error("expected error for one dimensional variable")
+	}
+	if _, err := ReadNetCDF(bytes.NewReader(f), "pressure"); err == nil {
+		t.Error("expected error for missing variable")
+	}
+	if _, err := ReadNetCDF(bytes.NewReader([]byte("\x89HDF\r\n\x1a\n")), "temp"); err == nil {
+		t.Error("expected error for NetCDF-4 file")
+	}
+}
+
+func TestReadNetCDFMalformed(t *testing.T) {
+	grid := func(lens []int32) []byte {
+		return ncFile([]string{"y", "x"}, lens, []ncTestVar{
+			{name: "z", dims: []int32{0, 1}, typ: ncByte, data: []int8{1, 2, 3, 4}},
+		})
+	}
+	// A dimension name of length 0xFFFFFFFF.
+	name := []byte("CDF\x01\x00\x00\x00\x01\x00\x00\x00\x0a\x00\x00\x00\x01\xff\xff\xff\xff")
+
+	for _, test := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "truncated", data: grid([]int32{2, 2})[:30]},
+		{name: "huge name", data: name},
+		{name: "negative lengths", data: grid([]int32{-2, -2})},
+		{name: "negative length", data: grid([]int32{2, -2})},
+		{name: "huge lengths", data: grid([]int32{1 << 30, 1 << 30})},
+	} {
+		if _, err := ReadNetCDF(bytes.NewReader(test.data), "z"); err == nil {
+			t.Errorf("expected error for %s header", test.name)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// earthRadius is the radius in metres of the sphere
+// on which the projections are defined.
+const earthRadius = 6378137
+
+// maxMercatorLat is the latitude in degrees at which the
+// Mercator projection of the world is square.
+const maxMercatorLat = 85.05112877980659
+
+// Projection is a map projection, converting between
+// geographic coordinates, the longitude and latitude in
+// degrees, and the coordinates of a map.
+type Projection interface {
+	// Forward returns the map coordinates of
+	// a longitude and latitude.
+	Forward(lon, lat float64) (x, y float64)
+
+	// Inverse returns the longitude and latitude
+	// of map coordinates, or NaN if they are
+	// outside of the map.
+	Inverse(x, y float64) (lon, lat float64)
+}
+
+// Geographic is the projection in which the map coordinates
+// are the longitude and the latitude, as for EPSG:4326.
+type Geographic struct{}
+
+// Forward implements the Projection interface.
+func (Geographic) Forward(lon, lat float64) (x, y float64) { return lon, lat }
+
+// Inverse implements the Projection interface.
+func (Geographic) Inverse(x, y float64) (lon, lat float64) { return x, y }
+
+// Mercator is the spherical Mercator projection used by web
+// maps, as for EPSG:3857, with map coordinates in metres.
+// Latitudes beyond about 85° are clamped to the edges of the
+// square map of the world.
+type Mercator struct{}
+
+// Forward implements the Projection interface.
+func (Mercator) Forward(lon, lat float64) (x, y float64) {
+	lat = math.Max(-maxMercatorLat, math.Min(lat, maxMercatorLat))
+	phi := lat * math.Pi / 180
+	return earthRadius * lon * math.Pi / 180, earthRadius * math.Log(math.Tan(math.Pi/4+phi/2))
+}
+
+// Inverse implements the Projection interface.
+func (Mercator) Inverse(x, y float64) (lon, lat float64) {
+	lon = x / earthRadius * 180 / math.Pi
+	lat = (2*math.Atan(math.Exp(y/earthRadius)) - math.Pi/2) * 180 / math.Pi
+	return lon, lat
+}
+
+// Sinusoidal is the sinusoidal equal area projection, as used
+// by MODIS land products, with map coordinates in metres.
+type Sinusoidal struct{}
+
+// Forward implements the Projection interface.
+func (Sinusoidal) Forward(lon, lat float64) (x, y float64) {
+	phi := lat * math.Pi / 180
+	return earthRadius * lon * math.Pi / 180 * math.Cos(phi), earthRadius * phi
+}
+
+// Inverse implements the Projection interface.
+func (Sinusoidal) Inverse(x, y float64) (lon, lat float64) {
+	phi := y / earthRadius
+	lon = x / (earthRadius * math.Cos(phi)) * 180 / math.Pi
+	lat = phi * 180 / math.Pi
+	if math.Abs(lon) > 180 || math.Abs(lat) > 90 {
+		return math.NaN(), math.NaN()
+	}
+	return lon, lat
+}
+
+// Equirectangular is the equirectangular projection, with
+// map coordinates in metres, whose scale is true along the
+// parallels at the latitude StandardParallel, in degrees,
+// and along all of the meridians. It is the plate carrée,
+// as for EPSG:4087, if StandardParallel is zero.
+type Equirectangular struct {
+	StandardParallel float64
+}
+
+// Forward implements the Projection interface.
+func (p Equirectangular) Forward(lon, lat float64) (x, y float64) {
+	c := math.Cos(p.StandardParallel * math.Pi / 180)
+	return earthRadius * lon * math.Pi / 180 * c, earthRadius * lat * math.Pi / 180
+}
+
+// Inverse implements the Projection interface.
+func (p Equirectangular) Inverse(x, y float64) (lon, lat float64) {
+	c := math.Cos(p.StandardParallel * math.Pi / 180)
+	lon = x / (earthRadius * c) * 180 / math.Pi
+	lat = y / earthRadius * 180 / math.Pi
+	if math.Abs(lon) > 180 || math.Abs(lat) > 90 {
+		return math.NaN(), math.NaN()
+	}
+	return lon, lat
+}
+
+// Albers is the Albers equal area conic projection, with map
+// coordinates in metres, as used for maps of regions of mid
+// latitudes such as the conterminous United States. Its scale
+// is true along the standard parallels at the latitudes Lat1
+// and Lat2, in degrees, which must not be opposite. The origin
+// of the map coordinates is at the longitude Lon0 and the
+// latitude Lat0.
+type Albers struct {
+	Lon0, Lat0 float64
+	Lat1, Lat2 float64
+}
+
+// params returns the cone constant n, the constant C
+// and the radius rho0 of the parallel of the origin.
+func (p Albers) params() (n, c, rho0 float64) {
+	s1 := math.Sin(p.Lat1 * math.Pi / 180)
+	s2 := math.Sin(p.Lat2 * math.Pi / 180)
+	n = (s1 + s2) / 2
+	if n == 0 {
+		panic("gridio: opposite Albers standard parallels")
+	}
+	c = 1 - s1*s1 + 2*n*s1
+	rho0 = earthRadius * math.Sqrt(c-2*n*math.Sin(p.Lat0*math.Pi/180)) / n
+	return n, c, rho0
+}
+
+// Forward implements the Projection interface.
+func (p Albers) Forward(lon, lat float64) (x, y float64) {
+	n, c, rho0 := p.params()
+	rho := earthRadius * math.Sqrt(c-2*n*math.Sin(lat*math.Pi/180)) / n
+	theta := n * (lon - p.Lon0) * math.Pi / 180
+	return rho * math.Sin(theta), rho0 - rho*math.Cos(theta)
+}
+
+// Inverse implements the Projection interface.
+func (p Albers) Inverse(x, y float64) (lon, lat float64) {
+	n, c, rho0 := p.params()
+	dy := rho0 - y
+	if n < 0 {
+		x, dy = -x, -dy
+	}
+	rho := math.Hypot(x, dy)
+	theta := math.Atan2(x, dy)
+	s := (c - rho*rho*n*n/(earthRadius*earthRadius)) / (2 * n)
+	if math.Abs(s) > 1 {
+		return math.NaN(), math.NaN()
+	}
+	lon = p.Lon0 + theta/n*180/math.Pi
+	lat = math.Asin(s) * 180 / math.Pi
+	if math.Abs(lon-p.Lon0) > 180 {
+		return math.NaN(), math.NaN()
+	}
+	return lon, lat
+}
+
+// ProjectionOf returns the projection with the given EPSG
+// code, such as the "epsg" attribute of a Grid read from
+// a GeoTIFF file. The codes of the Geographic projection,
+// 4326, of the Mercator projection, 3857, and of the plate
+// carrée, 4087, are known.
+func ProjectionOf(epsg string) (Projection, error) {
+	switch epsg {
+	case "4326":
+		return Geographic{}, nil
+	case "3857", "3785", "900913":
+		return Mercator{}, nil
+	case "4087":
+		return Equirectangular{}, nil
+	}
+	return nil, fmt.Errorf("Unsupported projection EPSG:%s", epsg)
+}
+
+// Reproject returns the grid g, whose coordinates are the map
+// coordinates of the projection from, reprojected by the given
+// resampling method to a regular grid of cols×rows cells in the
+// map coordinates of the projection to, covering the area of g.
+// Cells outside of g have no data in the new grid, which is
+// empty if g is empty or either size is not positive.
+//
+// The cells of the new grid are mapped onto g through their
+// longitudes and latitudes. The AreaWeighted method averages the
+// cells of g overlapping the bounding box of each mapped cell.
+func Reproject(g plotter.GridXYZ, from, to Projection, cols, rows int, method Resampling) *Grid {
+	s := newSource(g)
+	if s.cols == 0 || s.rows == 0 || cols <= 0 || rows <= 0 {
+		n := header(g, nil, nil)
+		n.XCoord.Name, n.XCoord.Units = "", ""
+		n.YCoord.Name, n.YCoord.Units = "", ""
+		return n
+	}
+
+	// Find the extent of g in the new projection
+	// from points around and within its edges.
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	add := func(x, y float64) {
+		x, y = to.Forward(from.Inverse(x, y))
+		if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+			return
+		}
+		xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+		ymin, ymax = math.Min(ymin, y), math.Max(ymax, y)
+	}
+	for _, x := range s.xe {
+		for _, y := range s.ye {
+			add(x, y)
+		}
+	}
+
+	xs, ys := regular(xmin, xmax, cols), regular(ymin, ymax, rows)
+	n := header(g, xs, ys)
+	n.XCoord.Name, n.XCoord.Units = "", ""
+	n.YCoord.Name, n.YCoord.Units = "", ""
+	xe, ye := edges(xs), edges(ys)
+	for r, y := range ys {
+		for c, x := range xs {
+			sx, sy := from.Forward(to.Inverse(x, y))
+			if math.IsNaN(sx) || math.IsNaN(sy) {
+				n.Data[r*cols+c] = math.NaN()
+				continue
+			}
+			x0, y0 := math.Inf(1), math.Inf(1)
+			x1, y1 := math.Inf(-1), math.Inf(-1)
+			if method == AreaWeighted {
+				for _, cx := range []float64{xe[c], xe[c+1]} {
+					for _, cy := range []float64{ye[r], ye[r+1]} {
+						px, py := from.Forward(to.Inverse(cx, cy))
+						x0, x1 = math.Min(x0, px), math.Max(x1, px)
+						y0, y1 = math.Min(y0, py), math.Max(y1, py)
+					}
+				}
+			}
+			n.Data[r*cols+c] = s.at(sx, sy, x0, y0, x1, y1, method)
+		}
+	}
+	return n
+}
@@ -0,0 +1,87 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProjections(t *testing.T) {
+	usAlbers := Albers{Lon0: -96, Lat0: 23, Lat1: 29.5, Lat2: 45.5}
+	for _, p := range []Projection{
+		Geographic{}, Mercator{}, Sinusoidal{},
+		Equirectangular{}, Equirectangular{StandardParallel: 45},
+		usAlbers, Albers{Lon0: 25, Lat1: -20, Lat2: -40},
+	} {
+		for _, ll := range [][2]float64{{0, 0}, {-120, 45}, {179, -60}, {2.35, 48.85}} {
+			x, y := p.Forward(ll[0], ll[1])
+			lon, lat := p.Inverse(x, y)
+			if math.Abs(lon-ll[0]) > 1e-9 || math.Abs(lat-ll[1]) > 1e-9 {
+				t.Errorf("%T did not round trip %v: got:(%v, %v)", p, ll, lon, lat)
+			}
+		}
+	}
+	if x, y := (Mercator{}).Forward(180, 90); math.Abs(x-y) > 1e-6 {
+		t.Errorf("Mercator map of the world is not square: corner at (%v, %v)", x, y)
+	}
+	if lon, _ := (Sinusoidal{}).Inverse(2.1e7, 0); !math.IsNaN(lon) {
+		t.Errorf("expected NaN outside of sinusoidal map: got:%v", lon)
+	}
+
+	if x, y := (Equirectangular{StandardParallel: 60}).Forward(180, 90); math.Abs(x-y) > 1e-6 {
+		t.Errorf("equirectangular map of the world at 60° is not square: corner at (%v, %v)", x, y)
+	}
+	// The origin of the Albers projection is at (Lon0, Lat0),
+	// and the meridian of Lon0 is straight up the map.
+	if x, y := usAlbers.Forward(-96, 23); math.Abs(x) > 1e-6 || math.Abs(y) > 1e-6 {
+		t.Errorf("unexpected Albers origin: got:(%v, %v)", x, y)
+	}
+	if x, y := usAlbers.Forward(-96, 40); math.Abs(x) > 1e-6 || y <= 0 {
+		t.Errorf("unexpected Albers central meridian: got:(%v, %v)", x, y)
+	}
+	// The scale along the standard parallels is true.
+	x0, _ := usAlbers.Forward(-96.5, 45.5)
+	x1, _ := usAlbers.Forward(-95.5, 45.5)
+	if want := earthRadius * math.Pi / 180 * math.Cos(45.5*math.Pi/180); math.Abs((x1-x0)/want-1) > 1e-4 {
+		t.Errorf("unexpected Albers scale along the standard parallel: got:%v want:%v", x1-x0, want)
+	}
+
+	if p, err := ProjectionOf("3857"); err != nil || p != (Mercator{}) {
+		t.Errorf("unexpected projection of EPSG:3857: got:%T, %v", p, err)
+	}
+	if _, err := ProjectionOf("32631"); err == nil {
+		t.Error("expected error for unsupported projection")
+	}
+}
+
+func TestReproject(t *testing.T) {
+	// A geographic grid whose values are the latitudes.
+	g := newGrid(36, 12)
+	for c := range g.XCoord.Values {
+		g.XCoord.Values[c] = -175 + 10*float64(c)
+	}
+	for r := range g.YCoord.Values {
+		g.YCoord.Values[r] = -55 + 10*float64(r)
+	}
+	for i := range g.Data {
+		g.Data[i] = g.YCoord.Values[i/36]
+	}
+
+	for _, method := range []Resampling{Nearest, Bilinear, AreaWeighted} {
+		n := Reproject(g, Geographic{}, Mercator{}, 20, 20, method)
+		if x0, x1 := n.X(0), n.X(19); math.Abs(x0+x1) > 1e-6 || x1 < 1.9e7 {
+			t.Errorf("unexpected X coordinates for method %d: [%v, %v]", method, x0, x1)
+		}
+		// The rows are evenly spaced in Mercator
+		// coordinates, so uneven in latitude.
+		for r := 0; r < 20; r++ {
+			_, lat := (Mercator{}).Inverse(0, n.Y(r))
+			if z := n.Z(10, r); math.Abs(z-lat) > 10 {
+				t.Errorf("unexpected value at latitude %v for method %d: got:%v", lat, method, z)
+			}
+		}
+	}
+}
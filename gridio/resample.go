@@ -0,0 +1,230 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/plotter"
+)
+
+// Resampling is a method of finding the values of
+// the cells of a resampled grid.
+type Resampling int
+
+const (
+	// Nearest takes the value of the cell
+	// containing the center of the new cell.
+	Nearest Resampling = iota
+
+	// Bilinear interpolates linearly between the
+	// values at the centers of the four cells
+	// surrounding the center of the new cell.
+	Bilinear
+
+	// AreaWeighted averages the values of the cells
+	// overlapping the new cell, weighted by the area
+	// of their overlap, as suits grids resampled to
+	// a coarser resolution.
+	AreaWeighted
+)
+
+// source is a grid being resampled, with the edges
+// between its columns and rows.
+type source struct {
+	plotter.GridXYZ
+	xs, ys     []float64
+	xe, ye     []float64
+	cols, rows int
+}
+
+func newSource(g plotter.GridXYZ) *source {
+	s := &source{GridXYZ: g}
+	s.cols, s.rows = g.Dims()
+	s.xs = make([]float64, s.cols)
+	for c := range s.xs {
+		s.xs[c] = g.X(c)
+	}
+	s.ys = make([]float64, s.rows)
+	for r := range s.ys {
+		s.ys[r] = g.Y(r)
+	}
+	s.xe, s.ye = edges(s.xs), edges(s.ys)
+	return s
+}
+
+// edges returns the edges of the cells centered on
+// the coordinates, which are half way between them,
+// with the outer cells as wide as their neighbors.
+// A single cell is one unit wide, and no cells have no edges.
+func edges(vs []float64) []float64 {
+	if len(vs) == 0 {
+		return nil
+	}
+	e := make([]float64, len(vs)+1)
+	if len(vs) == 1 {
+		e[0], e[1] = vs[0]-0.5, vs[0]+0.5
+		return e
+	}
+	for i := 1; i < len(vs); i++ {
+		e[i] = (vs[i-1] + vs[i]) / 2
+	}
+	e[0] = vs[0] - (e[1] - vs[0])
+	e[len(vs)] = vs[len(vs)-1] + (vs[len(vs)-1] - e[len(vs)-1])
+	return e
+}
+
+// cell returns the index of the cell whose edges
+// contain v, or -1 if v is outside of the grid.
+func cell(e []float64, v float64) int {
+	if len(e) == 0 || !(e[0] <= v && v <= e[len(e)-1]) {
+		return -1
+	}
+	i := sort.SearchFloat64s(e, v)
+	if i > 0 && (i == len(e)-1 || e[i] > v) {
+		i--
+	}
+	return i
+}
+
+// between returns the index i and the weight w such that
+// v is w of the way from vs[i] to vs[i+1], clamping
+// v to the range of vs.
+func between(vs []float64, v float64) (i int, w float64) {
+	if len(vs) == 1 || v <= vs[0] {
+		return 0, 0
+	}
+	if v >= vs[len(vs)-1] {
+		return len(vs) - 2, 1
+	}
+	i = sort.SearchFloat64s(vs, v) - 1
+	return i, (v - vs[i]) / (vs[i+1] - vs[i])
+}
+
+// at returns the value of the source at (x, y), found by the
+// given method, with the new cell spanning the rectangle from
+// (x0, y0) to (x1, y1). It returns NaN outside of the grid.
+func (s *source) at(x, y, x0, y0, x1, y1 float64, method Resampling) float64 {
+	switch method {
+	case Nearest:
+		c, r := cell(s.xe, x), cell(s.ye, y)
+		if c < 0 || r < 0 {
+			return math.NaN()
+		}
+		return s.Z(c, r)
+
+	case Bilinear:
+		if cell(s.xe, x) < 0 || cell(s.ye, y) < 0 {
+			return math.NaN()
+		}
+		c, wx := between(s.xs, x)
+		r, wy := between(s.ys, y)
+		var sum, weight float64
+		for dc := 0; dc < 2 && c+dc < s.cols; dc++ {
+			for dr := 0; dr < 2 && r+dr < s.rows; dr++ {
+				w := math.Abs(1 - float64(dc) - wx)
+				w *= math.Abs(1 - float64(dr) - wy)
+				z := s.Z(c+dc, r+dr)
+				if w == 0 || math.IsNaN(z) {
+					continue
+				}
+				sum += w * z
+				weight += w
+			}
+		}
+		if weight == 0 {
+			return math.NaN()
+		}
+		return sum / weight
+
+	case AreaWeighted:
+		var sum, weight float64
+		for c := 0; c < s.cols; c++ {
+			w := overlap(s.xe[c], s.xe[c+1], x0, x1)
+			if w == 0 {
+				continue
+			}
+			for r := 0; r < s.rows; r++ {
+				h := overlap(s.ye[r], s.ye[r+1], y0, y1)
+				z := s.Z(c, r)
+				if h == 0 || math.IsNaN(z) {
+					continue
+				}
+				sum += w * h * z
+				weight += w * h
+			}
+		}
+		if weight == 0 {
+			return math.NaN()
+		}
+		return sum / weight
+	}
+	panic("gridio: unknown resampling method")
+}
+
+// overlap returns the length of the overlap of the
+// ranges from a0 to a1 and from b0 to b1.
+func overlap(a0, a1, b0, b1 float64) float64 {
+	return math.Max(0, math.Min(a1, b1)-math.Max(a0, b0))
+}
+
+// regular returns n coordinates of cells
+// evenly spaced from min to max.
+func regular(min, max float64, n int) []float64 {
+	vs := make([]float64, n)
+	for i := range vs {
+		vs[i] = min + (float64(i)+0.5)*(max-min)/float64(n)
+	}
+	return vs
+}
+
+// header returns a grid of the given coordinates with
+// the name and units of g, if it is a Grid.
+func header(g plotter.GridXYZ, xs, ys []float64) *Grid {
+	n := &Grid{
+		XCoord: Coordinate{Values: xs},
+		YCoord: Coordinate{Values: ys},
+		Attrs:  make(map[string]string),
+		Data:   make([]float64, len(xs)*len(ys)),
+	}
+	if g, ok := g.(*Grid); ok {
+		n.Name, n.Units = g.Name, g.Units
+		n.XCoord.Name, n.XCoord.Units = g.XCoord.Name, g.XCoord.Units
+		n.YCoord.Name, n.YCoord.Units = g.YCoord.Name, g.YCoord.Units
+	}
+	return n
+}
+
+// Resample returns the grid g resampled by the given method
+// to cells centered on the coordinates xs and ys, which must
+// be in increasing order, as must the coordinates of g. Cells
+// outside of g, and cells with no data in g, have no data in
+// the new grid, which is empty if xs or ys is.
+func Resample(g plotter.GridXYZ, xs, ys []float64, method Resampling) *Grid {
+	s := newSource(g)
+	n := header(g, append([]float64(nil), xs...), append([]float64(nil), ys...))
+	xe, ye := edges(xs), edges(ys)
+	for r, y := range ys {
+		for c, x := range xs {
+			n.Data[r*len(xs)+c] = s.at(x, y, xe[c], ye[r], xe[c+1], ye[r+1], method)
+		}
+	}
+	return n
+}
+
+// Resize returns the grid g resampled by the given method to
+// a regular grid of cols×rows cells covering the same area.
+// The new grid is empty if g is empty or either size is
+// not positive.
+func Resize(g plotter.GridXYZ, cols, rows int, method Resampling) *Grid {
+	s := newSource(g)
+	if s.cols == 0 || s.rows == 0 || cols <= 0 || rows <= 0 {
+		return header(g, nil, nil)
+	}
+	xs := regular(s.xe[0], s.xe[s.cols], cols)
+	ys := regular(s.ye[0], s.ye[s.rows], rows)
+	return Resample(g, xs, ys, method)
+}
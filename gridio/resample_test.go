@@ -0,0 +1,102 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// ramp returns a 4×2 grid whose values
+// are the sums of their coordinates.
+func ramp() *Grid {
+	g := newGrid(4, 2)
+	for r := 0; r < 2; r++ {
+		for c := 0; c < 4; c++ {
+			g.Data[r*4+c] = float64(c + 10*r)
+		}
+	}
+	g.Name = "ramp"
+	return g
+}
+
+func TestResample(t *testing.T) {
+	g := ramp()
+	g.Data[3] = math.NaN()
+	xs, ys := []float64{0.25, 1.5, 3.4, 5}, []float64{0.5}
+	for _, test := range []struct {
+		method Resampling
+		want   []float64
+	}{
+		{method: Nearest, want: []float64{10, 12, 13, math.NaN()}},
+		{method: Bilinear, want: []float64{5.25, 6.5, 13, math.NaN()}},
+	} {
+		n := Resample(g, xs, ys, test.method)
+		if !sameValues(n.Data, test.want) {
+			t.Errorf("unexpected values for method %d: got:%v want:%v", test.method, n.Data, test.want)
+		}
+		if n.Name != "ramp" || !reflect.DeepEqual(n.XCoord.Values, xs) {
+			t.Errorf("unexpected grid for method %d: name:%q xs:%v", test.method, n.Name, n.XCoord.Values)
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	g := ramp()
+	n := Resize(g, 2, 1, AreaWeighted)
+	if want := []float64{0.5, 2.5}; !reflect.DeepEqual(n.XCoord.Values, want) {
+		t.Errorf("unexpected X coordinates: got:%v want:%v", n.XCoord.Values, want)
+	}
+	if want := []float64{5.5, 7.5}; !sameValues(n.Data, want) {
+		t.Errorf("unexpected values: got:%v want:%v", n.Data, want)
+	}
+
+	// Cells with no data are left out of the averages.
+	g.Data[0] = math.NaN()
+	n = Resize(g, 2, 1, AreaWeighted)
+	if want := []float64{22.0 / 3, 7.5}; !sameValues(n.Data, want) {
+		t.Errorf("unexpected values with no data: got:%v want:%v", n.Data, want)
+	}
+}
+
+func TestResampleEmpty(t *testing.T) {
+	g := ramp()
+	for _, test := range []struct {
+		name string
+		grid *Grid
+	}{
+		{name: "no columns", grid: Resample(g, nil, []float64{0.5}, Nearest)},
+		{name: "no rows", grid: Resample(g, []float64{0.5}, nil, Bilinear)},
+		{name: "zero size", grid: Resize(g, 0, 2, AreaWeighted)},
+		{name: "negative size", grid: Resize(g, 2, -1, Nearest)},
+		{name: "empty grid", grid: Resize(newGrid(0, 0), 2, 2, Nearest)},
+		{name: "reprojected", grid: Reproject(g, Geographic{}, Mercator{}, 0, 0, Nearest)},
+	} {
+		if c, r := test.grid.Dims(); c*r != 0 || len(test.grid.Data) != 0 {
+			t.Errorf("unexpected size for %s: %d×%d with %d values", test.name, c, r, len(test.grid.Data))
+		}
+	}
+
+	// An empty grid has no data at any coordinates.
+	n := Resample(newGrid(0, 0), []float64{0}, []float64{0}, AreaWeighted)
+	if !sameValues(n.Data, []float64{math.NaN()}) {
+		t.Errorf("unexpected values from an empty grid: %v", n.Data)
+	}
+}
+
+// sameValues returns whether a and b hold equal
+// values, to within a small tolerance, or NaN.
+func sameValues(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.IsNaN(a[i]) != math.IsNaN(b[i]) || math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
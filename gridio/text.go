@@ -0,0 +1,162 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gridio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ReadPGM reads a grid from a PGM image, in either the plain
+// (P2) or the raw (P5) format. The values of the grid are the
+// gray levels of the pixels, and the coordinates of the cells
+// are their column and row indices, counted from the bottom
+// row of the image.
+func ReadPGM(r io.Reader) (*Grid, error) {
+	br := bufio.NewReader(r)
+	var header [4]int
+	magic, err := pgmToken(br)
+	if err != nil {
+		return nil, err
+	}
+	if magic != "P2" && magic != "P5" {
+		return nil, errors.New("Not a PGM image")
+	}
+	for i := 1; i < len(header); i++ {
+		tok, err := pgmToken(br)
+		if err != nil {
+			return nil, err
+		}
+		if header[i], err = strconv.Atoi(tok); err != nil || header[i] <= 0 {
+			return nil, fmt.Errorf("Invalid PGM header value %q", tok)
+		}
+	}
+	cols, rows, maxval := header[1], header[2], header[3]
+	if maxval > 65535 {
+		return nil, fmt.Errorf("Invalid PGM maximum value %d", maxval)
+	}
+
+	if cols > int(^uint(0)>>1)/rows {
+		return nil, fmt.Errorf("Invalid PGM image size %dx%d", cols, rows)
+	}
+
+	// The values are read before the grid is made, so that
+	// its size is bounded by the input rather than by the
+	// header.
+	var vals []float64
+	for len(vals) < cols*rows {
+		var v int
+		switch {
+		case magic == "P2":
+			tok, err := pgmToken(br)
+			if err != nil {
+				return nil, err
+			}
+			if v, err = strconv.Atoi(tok); err != nil {
+				return nil, fmt.Errorf("Invalid PGM value %q", tok)
+			}
+		case maxval < 256:
+			b, err := br.ReadByte()
+			if err != nil {
+				return nil, unexpected(err)
+			}
+			v = int(b)
+		default:
+			var b [2]byte
+			if _, err := io.ReadFull(br, b[:]); err != nil {
+				return nil, unexpected(err)
+			}
+			v = int(b[0])<<8 | int(b[1])
+		}
+		vals = append(vals, float64(v))
+	}
+	g := newGrid(cols, rows)
+	copy(g.Data, vals)
+	g.Attrs["maxval"] = strconv.Itoa(maxval)
+	g.flipRows()
+	for r := range g.YCoord.Values {
+		g.YCoord.Values[r] = float64(r)
+	}
+	return g, nil
+}
+
+// pgmToken returns the next token of a PGM header
+// or plain PGM data, skipping comments, and consuming
+// the single whitespace character after it.
+func pgmToken(br *bufio.Reader) (string, error) {
+	var tok []byte
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF && len(tok) > 0 {
+			return string(tok), nil
+		}
+		if err != nil {
+			return "", unexpected(err)
+		}
+		switch {
+		case b == '#' && len(tok) == 0:
+			if _, err := br.ReadString('\n'); err != nil {
+				return "", unexpected(err)
+			}
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f':
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, b)
+		}
+	}
+}
+
+// unexpected returns io.ErrUnexpectedEOF in
+// place of io.EOF, and other errors as they are.
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// ReadCSV reads a grid from a table of comma separated values,
+// with a row of the grid on each line, from the top row. Empty
+// fields and fields holding "NaN" or "NA" are cells with no
+// data. The coordinates of the cells are their column and row
+// indices, counted from the bottom row of the table.
+func ReadCSV(r io.Reader) (*Grid, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || len(records[0]) == 0 {
+		return nil, errors.New("No grid values")
+	}
+
+	g := newGrid(len(records[0]), len(records))
+	for i, rec := range records {
+		for j, f := range rec {
+			f = strings.TrimSpace(f)
+			v := math.NaN()
+			if f != "" && f != "NA" {
+				if v, err = strconv.ParseFloat(f, 64); err != nil {
+					return nil, fmt.Errorf("Invalid value %q on line %d", f, i+1)
+				}
+			}
+			g.Data[i*len(rec)+j] = v
+		}
+	}
+	g.flipRows()
+	for r := range g.YCoord.Values {
+		g.YCoord.Values[r] = float64(r)
+	}
+	return g, nil
+}
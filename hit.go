@@ -0,0 +1,84 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// HitBoxer wraps the HitBoxes method. Plotters implement
+// HitBoxer so that interactive frontends can find the data
+// drawn under a pointer, such as to highlight or select it.
+type HitBoxer interface {
+	// HitBoxes returns the boxes of the elements of the
+	// data, such as points or bars, drawn by the Plot
+	// method of the plotter on the data canvas c of plt.
+	HitBoxes(c draw.Canvas, plt *Plot) []HitBox
+}
+
+// A HitBox is the bounding box of an element of the data
+// of a plotter, in the coordinates of the canvas on which
+// it is drawn.
+type HitBox struct {
+	// Index is the index of the element
+	// among the data of the plotter.
+	Index int
+
+	vg.Rectangle
+}
+
+// Contains returns whether the point pt is within the box.
+func (b HitBox) Contains(pt vg.Point) bool {
+	return b.Min.X <= pt.X && pt.X <= b.Max.X && b.Min.Y <= pt.Y && pt.Y <= b.Max.Y
+}
+
+// A Hit is an element of the data of
+// a plotter of a plot, drawn in a box.
+type Hit struct {
+	// Plotter is the plotter
+	// drawing the element.
+	Plotter Plotter
+
+	HitBox
+}
+
+// HitBoxes returns the boxes of the elements of the data of
+// the plotters of the plot that implement HitBoxer, in the
+// order in which they are drawn, when the plot is drawn on
+// the canvas c by Draw.
+func (p *Plot) HitBoxes(c draw.Canvas) []Hit {
+	p.syncLinks()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dc := p.dataCanvas(c)
+	var hits []Hit
+	for _, i := range p.drawOrder() {
+		d := p.plotters[i]
+		hb, ok := d.(HitBoxer)
+		if !ok {
+			continue
+		}
+		for _, b := range hb.HitBoxes(dc, p) {
+			hits = append(hits, Hit{Plotter: d, HitBox: b})
+		}
+	}
+	return hits
+}
+
+// HitsAt returns the elements of the data of the plotters of
+// the plot whose boxes contain the point pt of the canvas c on
+// which the plot is drawn by Draw, from the last drawn, which
+// is on top, to the first.
+func (p *Plot) HitsAt(c draw.Canvas, pt vg.Point) []Hit {
+	all := p.HitBoxes(c)
+	var hits []Hit
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Contains(pt) {
+			hits = append(hits, all[i])
+		}
+	}
+	return hits
+}
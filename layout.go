@@ -0,0 +1,124 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// LayoutEvent is a decision made in the layout of a
+// plot: the area given to a part of the plot, or a
+// problem found with it.
+type LayoutEvent struct {
+	// Part is the name of the part of the plot,
+	// such as "title", "x-axis", "data", "legend"
+	// or "plotter-0" for the first plotter, as
+	// named by the groups of the drawing.
+	Part string
+
+	// Rectangle is the area of the part, in the
+	// coordinates of the canvas of the plot.
+	vg.Rectangle
+
+	// Note describes the decision.
+	Note string
+}
+
+// String returns the event on a line of text.
+func (e LayoutEvent) String() string {
+	return fmt.Sprintf("%s: (%.1f,%.1f)-(%.1f,%.1f) %s", e.Part,
+		e.Min.X.Points(), e.Min.Y.Points(), e.Max.X.Points(), e.Max.Y.Points(), e.Note)
+}
+
+// LayoutLog is a log of the layout of a plot, recorded
+// each time the plot is drawn when it is the Debug field of
+// the plot, for diagnosing clipped labels and legends that
+// overlap the data. It logs the area of each part of the
+// plot, the glyph boxes clipped by the data area and the
+// glyph boxes overlapped by the legend.
+type LayoutLog struct {
+	// Boxes specifies whether the areas of the
+	// parts of the plot and the problems found
+	// are drawn over the plot, labeled by their
+	// parts.
+	Boxes bool
+
+	// Events holds the events of the
+	// last drawing of the plot.
+	Events []LayoutEvent
+}
+
+// String returns the events of the log,
+// one on each line.
+func (l *LayoutLog) String() string {
+	var buf bytes.Buffer
+	for _, e := range l.Events {
+		fmt.Fprintln(&buf, e)
+	}
+	return buf.String()
+}
+
+// reset clears the log for a new drawing.
+func (l *LayoutLog) reset() {
+	if l != nil {
+		l.Events = l.Events[:0]
+	}
+}
+
+// record adds an event to the log, with its note formatted
+// by fmt.Sprintf. Nothing is recorded if the log is nil.
+func (l *LayoutLog) record(part string, r vg.Rectangle, format string, args ...interface{}) {
+	if l == nil {
+		return
+	}
+	l.Events = append(l.Events, LayoutEvent{Part: part, Rectangle: r, Note: fmt.Sprintf(format, args...)})
+}
+
+// layoutColors are the colors of the boxes
+// of the parts of a plot drawn by a LayoutLog.
+var layoutColors = []color.Color{
+	color.RGBA{R: 0xe4, G: 0x1a, B: 0x1c, A: 0xff},
+	color.RGBA{R: 0x37, G: 0x7e, B: 0xb8, A: 0xff},
+	color.RGBA{R: 0x4d, G: 0xaf, B: 0x4a, A: 0xff},
+	color.RGBA{R: 0x98, G: 0x4e, B: 0xa3, A: 0xff},
+	color.RGBA{R: 0xff, G: 0x7f, B: 0x00, A: 0xff},
+}
+
+// draw draws the boxes of the events of the log to c, each
+// labeled by its part at its top left corner, if Boxes is
+// true.
+func (l *LayoutLog) draw(c draw.Canvas) {
+	if l == nil || !l.Boxes {
+		return
+	}
+	fnt, err := vg.MakeFont(DefaultFont, vg.Points(6))
+	if err != nil {
+		panic(err)
+	}
+	c.BeginGroup("layout", "layout")
+	for i, e := range l.Events {
+		clr := layoutColors[i%len(layoutColors)]
+		c.StrokeLines(draw.LineStyle{Color: clr, Width: vg.Points(0.5), Dashes: []vg.Length{vg.Points(2), vg.Points(1)}},
+			[]vg.Point{e.Min, {X: e.Max.X, Y: e.Min.Y}, e.Max, {X: e.Min.X, Y: e.Max.Y}, e.Min})
+		sty := draw.TextStyle{Color: clr, Font: fnt, YAlign: draw.YTop}
+		c.FillText(sty, vg.Point{X: e.Min.X + vg.Points(1), Y: e.Max.Y - vg.Points(1)}, e.Part)
+	}
+	c.EndGroup()
+}
+
+// overlaps returns whether the rectangles overlap.
+func overlaps(a, b vg.Rectangle) bool {
+	return a.Min.X < b.Max.X && b.Min.X < a.Max.X && a.Min.Y < b.Max.Y && b.Min.Y < a.Max.Y
+}
+
+// contains returns whether a contains b.
+func contains(a, b vg.Rectangle) bool {
+	return a.Min.X <= b.Min.X && b.Max.X <= a.Max.X && a.Min.Y <= b.Min.Y && b.Max.Y <= a.Max.Y
+}
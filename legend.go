@@ -5,6 +5,9 @@
 package plot
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 )
@@ -41,6 +44,47 @@ type Legend struct {
 	// ThumbnailWidth is the width of legend thumbnails.
 	ThumbnailWidth vg.Length
 
+	// Title is drawn above the entries of the legend,
+	// with its Padding between it and the entries. The
+	// title is aligned with the edge of the plot along
+	// which the legend is located, so its XAlign is
+	// ignored.
+	Title TextBlock
+
+	// Unit is the unit of the quantity described by
+	// the legend. If Unit is not empty, it is drawn
+	// in parentheses after the title.
+	Unit string
+
+	// Box is the style of the box drawn around the
+	// legend, which is kept inside the plot.
+	Box draw.BoxStyle
+
+	// Columns is the number of columns into which the
+	// entries of the legend are laid out, filling each
+	// column from top to bottom before the next. If
+	// Columns is zero, the entries are laid out in a
+	// single column, unless MaxHeight wraps them.
+	Columns int
+
+	// ColumnPadding is the amount of padding between the
+	// columns of the legend. If ColumnPadding is zero,
+	// the columns are separated by the width of the
+	// thumbnails.
+	ColumnPadding vg.Length
+
+	// MaxHeight, if positive, is the maximum height of
+	// the columns of entries, not including the title.
+	// Entries that do not fit are wrapped into further
+	// columns.
+	MaxHeight vg.Length
+
+	// MaxEntries, if positive, is the maximum number of
+	// entries drawn. If there are more entries, the first
+	// MaxEntries-1 are drawn, followed by an entry with
+	// no thumbnail reading "+N more" for the N others.
+	MaxEntries int
+
 	// entries are all of the legendEntries described
 	// by this legend.
 	entries []legendEntry
@@ -74,14 +118,51 @@ func makeLegend() (Legend, error) {
 	if err != nil {
 		return Legend{}, err
 	}
-	return Legend{
+	l := Legend{
 		ThumbnailWidth: vg.Points(20),
 		TextStyle:      draw.TextStyle{Font: font},
-	}, nil
+	}
+	l.Title.TextStyle = draw.TextStyle{Font: font, YAlign: draw.YTop}
+	l.Title.Padding = vg.Points(2)
+	return l, nil
+}
+
+// WithUnit returns a title followed by a unit in
+// parentheses, or the title alone if unit is empty.
+func WithUnit(title, unit string) string {
+	switch {
+	case unit == "":
+		return title
+	case title == "":
+		return "(" + unit + ")"
+	}
+	return title + " (" + unit + ")"
+}
+
+// title returns the text of the title of the legend.
+func (l *Legend) title() string {
+	if l.Title.Text == "" && l.Unit == "" {
+		return ""
+	}
+	return WithUnit(l.Title.Text, l.Unit)
+}
+
+// titleHeight returns the height taken by the
+// title of the legend and its padding.
+func (l *Legend) titleHeight() vg.Length {
+	t := l.title()
+	if t == "" {
+		return 0
+	}
+	return l.Title.Height(t) - l.Title.Font.Extents().Descent + l.Title.Padding
 }
 
 // draw draws the legend to the given draw.Canvas.
 func (l *Legend) draw(c draw.Canvas) {
+	if in := l.Box.Inset(); in > 0 {
+		c = draw.Crop(c, in, -in, in, -in)
+	}
+
 	iconx := c.Min.X
 	sty := l.TextStyle
 	textx := iconx + l.ThumbnailWidth + sty.Rectangle(" ").Max.X
@@ -94,34 +175,183 @@ func (l *Legend) draw(c draw.Canvas) {
 	iconx += l.XOffs
 
 	enth := l.entryHeight()
-	y := c.Max.Y - enth
-	if !l.Top {
-		y = c.Min.Y + (enth+l.Padding)*(vg.Length(len(l.entries))-1)
+	y := l.top(c)
+	entries := l.visible()
+	rows, cols := l.layout()
+	colw, colpad := l.columnWidth(), l.columnPadding()
+	colx := colw + colpad
+	if !l.Left {
+		// The first column is leftmost whichever
+		// edge the legend is located along.
+		shift := vg.Length(cols-1) * colx
+		textx -= shift
+		iconx -= shift
 	}
-	y += l.YOffs
 
-	icon := &draw.Canvas{
-		Canvas: c.Canvas,
-		Rectangle: vg.Rectangle{
-			Min: vg.Point{X: iconx, Y: y},
-			Max: vg.Point{X: iconx + l.ThumbnailWidth, Y: y + enth},
-		},
+	if l.Box.Background != nil || l.Box.Border.Color != nil {
+		c.BeginGroup("legend-box", "legend-box")
+		c.DrawBox(l.Box, l.box(c, y))
+		c.EndGroup()
 	}
-	for _, e := range l.entries {
+
+	if t := l.title(); t != "" {
+		tsty := l.Title.TextStyle
+		x := c.Min.X + l.XOffs
+		tsty.XAlign = draw.XLeft
+		if !l.Left {
+			x = c.Max.X + l.XOffs
+			tsty.XAlign = draw.XRight
+		}
+		top := y + enth + l.titleHeight()
+		top -= vg.Length(1+tsty.YAlign) * tsty.Height(t)
+		c.BeginGroup("legend-title", "legend-title")
+		c.FillText(tsty, vg.Point{X: x, Y: top}, t)
+		c.EndGroup()
+	}
+
+	for i, e := range entries {
+		row, col := i%rows, i/rows
+		dx := vg.Length(col) * colx
+		dy := vg.Length(row) * (enth + l.Padding)
+		icon := &draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: iconx + dx, Y: y - dy},
+				Max: vg.Point{X: iconx + dx + l.ThumbnailWidth, Y: y - dy + enth},
+			},
+		}
+		c.BeginGroup(fmt.Sprintf("legend-entry-%d", i), "legend-entry")
 		for _, t := range e.thumbs {
 			t.Thumbnail(icon)
 		}
 		yoffs := (enth - sty.Rectangle(e.text).Max.Y) / 2
-		c.FillText(sty, vg.Point{X: textx, Y: icon.Min.Y + yoffs}, e.text)
-		icon.Min.Y -= enth + l.Padding
-		icon.Max.Y -= enth + l.Padding
+		c.FillText(sty, vg.Point{X: textx + dx, Y: icon.Min.Y + yoffs}, e.text)
+		c.EndGroup()
 	}
 }
 
+// visible returns the entries of the legend that are
+// drawn, summarizing those beyond MaxEntries.
+func (l *Legend) visible() []legendEntry {
+	if l.MaxEntries <= 0 || len(l.entries) <= l.MaxEntries {
+		return l.entries
+	}
+	n := l.MaxEntries - 1
+	entries := append([]legendEntry(nil), l.entries[:n]...)
+	return append(entries, legendEntry{text: fmt.Sprintf("+%d more", len(l.entries)-n)})
+}
+
+// layout returns the number of rows and columns
+// of the entries of the legend.
+func (l *Legend) layout() (rows, cols int) {
+	n := len(l.visible())
+	if n == 0 {
+		return 0, 1
+	}
+	cols = l.Columns
+	if cols < 1 {
+		cols = 1
+	}
+	if l.MaxHeight > 0 {
+		step := l.entryHeight() + l.Padding
+		fit := 1
+		if step > 0 {
+			fit = int((l.MaxHeight + l.Padding) / step)
+		}
+		if fit < 1 {
+			fit = 1
+		}
+		if c := (n + fit - 1) / fit; c > cols {
+			cols = c
+		}
+	}
+	if cols > n {
+		cols = n
+	}
+	rows = (n + cols - 1) / cols
+	return rows, cols
+}
+
+// columnWidth returns the width of a column of entries,
+// that of the widest of the entries.
+func (l *Legend) columnWidth() vg.Length {
+	var w vg.Length
+	for _, e := range l.visible() {
+		if tw := l.TextStyle.Width(e.text); tw > w {
+			w = tw
+		}
+	}
+	return w + l.ThumbnailWidth + l.TextStyle.Rectangle(" ").Max.X
+}
+
+// columnPadding returns the padding between the
+// columns of the legend.
+func (l *Legend) columnPadding() vg.Length {
+	if l.ColumnPadding != 0 {
+		return l.ColumnPadding
+	}
+	return l.ThumbnailWidth
+}
+
+// box returns the rectangle of the legend drawn to
+// the canvas c, inside its inset, whose top entry
+// is at y.
+func (l *Legend) box(c draw.Canvas, y vg.Length) vg.Rectangle {
+	w := l.width()
+	x := c.Min.X + l.XOffs
+	if !l.Left {
+		x = c.Max.X + l.XOffs - w
+	}
+	return vg.Rectangle{
+		Min: vg.Point{X: x, Y: y - (l.entryHeight()+l.Padding)*(vg.Length(l.rows())-1)},
+		Max: vg.Point{X: x + w, Y: y + l.entryHeight() + l.titleHeight()},
+	}
+}
+
+// rectangle returns the rectangle of
+// the legend drawn to the canvas c.
+func (l *Legend) rectangle(c draw.Canvas) vg.Rectangle {
+	if in := l.Box.Inset(); in > 0 {
+		c = draw.Crop(c, in, -in, in, -in)
+	}
+	return l.box(c, l.top(c))
+}
+
+// top returns the position of the top entry of
+// the legend drawn to the canvas c, inside its
+// inset.
+func (l *Legend) top(c draw.Canvas) vg.Length {
+	enth := l.entryHeight()
+	y := c.Max.Y - enth - l.titleHeight()
+	if !l.Top {
+		y = c.Min.Y + (enth+l.Padding)*(vg.Length(l.rows())-1)
+	}
+	return y + l.YOffs
+}
+
+// rows returns the number of rows of entries.
+func (l *Legend) rows() int {
+	rows, _ := l.layout()
+	return rows
+}
+
+// width returns the width of the wider of the
+// columns of legend entries and the title.
+func (l *Legend) width() vg.Length {
+	_, cols := l.layout()
+	w := vg.Length(cols)*l.columnWidth() + vg.Length(cols-1)*l.columnPadding()
+	if t := l.title(); t != "" {
+		if tw := l.Title.Width(t); tw > w {
+			w = tw
+		}
+	}
+	return w
+}
+
 // entryHeight returns the height of the tallest legend
 // entry text.
 func (l *Legend) entryHeight() (height vg.Length) {
-	for _, e := range l.entries {
+	for _, e := range l.visible() {
 		if h := l.TextStyle.Rectangle(e.text).Max.Y; h > height {
 			height = h
 		}
@@ -135,3 +365,19 @@ func (l *Legend) entryHeight() (height vg.Length) {
 func (l *Legend) Add(name string, thumbs ...Thumbnailer) {
 	l.entries = append(l.entries, legendEntry{text: name, thumbs: thumbs})
 }
+
+// EntryText returns the name of the first entry of the
+// legend with the thumbnailer t, and whether there is one.
+func (l *Legend) EntryText(t Thumbnailer) (string, bool) {
+	if t == nil || !reflect.TypeOf(t).Comparable() {
+		return "", false
+	}
+	for _, e := range l.entries {
+		for _, th := range e.thumbs {
+			if reflect.TypeOf(th) == reflect.TypeOf(t) && th == t {
+				return e.text, true
+			}
+		}
+	}
+	return "", false
+}
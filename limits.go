@@ -0,0 +1,96 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limits are limits on the resources used to draw a plot,
+// so that a service drawing plots of data supplied by its
+// users may not be exhausted by a pathological request.
+// A zero limit is no limit.
+type Limits struct {
+	// MaxPoints is the greatest number of
+	// points drawn by each plotter.
+	MaxPoints int
+
+	// MaxCells is the greatest number of grid
+	// cells drawn by each plotter.
+	MaxCells int
+
+	// MaxTime is the longest time for which the
+	// plotters are drawn. The plotters not yet
+	// drawn when it has passed are not drawn.
+	MaxTime time.Duration
+
+	// Downsample specifies whether the plotters
+	// exceeding MaxPoints or MaxCells that are
+	// Downsamplers are downsampled to the limits
+	// when they are drawn. Otherwise the plotters
+	// exceeding the limits are not drawn.
+	Downsample bool
+}
+
+// Sizer wraps the Size method. The sizes of
+// the plotters that are Sizers are limited by
+// the Limits of the plot.
+type Sizer interface {
+	// Size returns the number of points
+	// and grid cells drawn by the plotter.
+	Size() (points, cells int)
+}
+
+// Downsampler wraps the Downsample method.
+type Downsampler interface {
+	// Downsample returns a copy of the plotter
+	// drawing at most the given numbers of points
+	// and grid cells, either of which may be zero
+	// for no limit, showing the data as faithfully
+	// as it can.
+	Downsample(points, cells int) Plotter
+}
+
+// LimitError is the error of a plot whose
+// plotter exceeded the Limits of the plot.
+type LimitError struct {
+	// Plotter is the index of the first
+	// plotter that was not drawn, in the
+	// order in which it was added.
+	Plotter int
+
+	// Reason describes the limit exceeded.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("Plotter %d not drawn: %s", e.Plotter, e.Reason)
+}
+
+// limit returns the plotter to draw in place of data, which
+// is downsampled if it exceeds the size limits and Downsample
+// is true, or nil and the reason if it is not to be drawn.
+func (l Limits) limit(data Plotter) (Plotter, string) {
+	s, ok := data.(Sizer)
+	if !ok || l.MaxPoints <= 0 && l.MaxCells <= 0 {
+		return data, ""
+	}
+	points, cells := s.Size()
+	var reason string
+	switch {
+	case l.MaxPoints > 0 && points > l.MaxPoints:
+		reason = fmt.Sprintf("%d points exceed the limit of %d", points, l.MaxPoints)
+	case l.MaxCells > 0 && cells > l.MaxCells:
+		reason = fmt.Sprintf("%d grid cells exceed the limit of %d", cells, l.MaxCells)
+	default:
+		return data, ""
+	}
+	if d, ok := data.(Downsampler); ok && l.Downsample {
+		return d.Downsample(l.MaxPoints, l.MaxCells), ""
+	}
+	return nil, reason
+}
@@ -0,0 +1,110 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"sync"
+)
+
+// AxisLink links the X or the Y axes of several plots, so
+// that they share a range, scale and tick marks, as needed
+// for aligned panels of a figure, such as time series
+// stacked in a column.
+//
+// The linked axes are synchronized when any of the plots
+// is drawn, or when Sync is called. The axes whose range
+// changed since the last synchronization, whether it was
+// set or extended by adding plotters, give the shared range,
+// which is the union of their ranges. The other axes take
+// the shared range, and all of the axes take the Scale and
+// Tick.Marker of the axis of the first linked plot.
+type AxisLink struct {
+	// y specifies whether the
+	// Y axes are linked.
+	y bool
+
+	mu    sync.Mutex
+	plots []*Plot
+
+	// synced specifies whether the axes have been
+	// synchronized, to the range from min to max.
+	synced   bool
+	min, max float64
+}
+
+// LinkX links the X axes of the plots, replacing any
+// previous link of their X axes.
+func LinkX(ps ...*Plot) *AxisLink {
+	l := &AxisLink{plots: ps}
+	for _, p := range ps {
+		p.mu.Lock()
+		p.xLink = l
+		p.mu.Unlock()
+	}
+	return l
+}
+
+// LinkY links the Y axes of the plots, replacing any
+// previous link of their Y axes.
+func LinkY(ps ...*Plot) *AxisLink {
+	l := &AxisLink{y: true, plots: ps}
+	for _, p := range ps {
+		p.mu.Lock()
+		p.yLink = l
+		p.mu.Unlock()
+	}
+	return l
+}
+
+// axis returns the linked axis of p.
+func (l *AxisLink) axis(p *Plot) *Axis {
+	if l.y {
+		return &p.Y
+	}
+	return &p.X
+}
+
+// Sync synchronizes the linked axes.
+func (l *AxisLink) Sync() {
+	if l == nil || len(l.plots) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The plots are locked one at a time, so that plots
+	// linked in both axes may be drawn concurrently.
+	min, max := math.Inf(1), math.Inf(-1)
+	changed := false
+	for _, p := range l.plots {
+		p.mu.Lock()
+		a := l.axis(p)
+		if !l.synced || a.Min != l.min || a.Max != l.max {
+			min = math.Min(min, a.Min)
+			max = math.Max(max, a.Max)
+			changed = true
+		}
+		p.mu.Unlock()
+	}
+	if !changed {
+		return
+	}
+
+	first := l.plots[0]
+	first.mu.Lock()
+	scale, marker := l.axis(first).Scale, l.axis(first).Tick.Marker
+	first.mu.Unlock()
+
+	for _, p := range l.plots {
+		p.mu.Lock()
+		a := l.axis(p)
+		a.Min, a.Max = min, max
+		a.Scale, a.Tick.Marker = scale, marker
+		p.mu.Unlock()
+	}
+	l.synced = true
+	l.min, l.max = min, max
+}
@@ -0,0 +1,140 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"sort"
+)
+
+// Anchor is a color fixed at a data value
+// of a ColorMap returned by Anchored.
+type Anchor struct {
+	Value float64
+	Color color.Color
+}
+
+// anchored is a ColorMap interpolating linearly
+// between colors fixed at data values.
+type anchored struct {
+	values   []float64
+	colors   []color.NRGBA
+	min, max float64
+	alpha    float64
+}
+
+// Anchored returns a ColorMap that interpolates linearly in
+// RGBA space between colors fixed at data values, such as the
+// depths and heights of the tints of a map. Unlike the colors of
+// Linear, the anchors do not move when the minimum or maximum
+// of the ColorMap is changed: those only limit the range of
+// values that are accepted by At, and values beyond the first
+// or last anchor take its color. The minimum and maximum are
+// initially the values of the first and last anchors.
+//
+// The values of the anchors must not decrease. Two anchors at
+// the same value make a sharp break in the colors, such as at
+// a coastline, with the value itself taking the color of the
+// second anchor. Anchored panics if there are no anchors or
+// their values decrease.
+func Anchored(anchors ...Anchor) ColorMap {
+	if len(anchors) == 0 {
+		panic("palette: no anchors")
+	}
+	a := &anchored{
+		values: make([]float64, len(anchors)),
+		colors: make([]color.NRGBA, len(anchors)),
+		min:    anchors[0].Value,
+		max:    anchors[len(anchors)-1].Value,
+		alpha:  1,
+	}
+	for i, an := range anchors {
+		if i > 0 && !(an.Value >= anchors[i-1].Value) {
+			panic("palette: anchor values decrease")
+		}
+		a.values[i] = an.Value
+		a.colors[i] = color.NRGBAModel.Convert(an.Color).(color.NRGBA)
+	}
+	return a
+}
+
+// At implements the ColorMap interface.
+func (a *anchored) At(v float64) (color.Color, error) {
+	if err := checkRange(a.min, a.max, v); err != nil {
+		return nil, err
+	}
+	// i is the index of the first anchor above v.
+	i := sort.Search(len(a.values), func(i int) bool { return a.values[i] > v })
+	switch i {
+	case 0:
+		return a.withAlpha(a.colors[0]), nil
+	case len(a.values):
+		return a.withAlpha(a.colors[i-1]), nil
+	}
+	v0, v1 := a.values[i-1], a.values[i]
+	f := (v - v0) / (v1 - v0)
+	c0, c1 := a.colors[i-1], a.colors[i]
+	return a.withAlpha(color.NRGBA{
+		R: lerp(c0.R, c1.R, f),
+		G: lerp(c0.G, c1.G, f),
+		B: lerp(c0.B, c1.B, f),
+		A: lerp(c0.A, c1.A, f),
+	}), nil
+}
+
+// withAlpha returns c with its opacity scaled by the
+// alpha of the ColorMap.
+func (a *anchored) withAlpha(c color.NRGBA) color.NRGBA {
+	c.A = uint8(float64(c.A)*a.alpha + 0.5)
+	return c
+}
+
+// Max implements the ColorMap interface.
+func (a *anchored) Max() float64 { return a.max }
+
+// SetMax implements the ColorMap interface.
+func (a *anchored) SetMax(v float64) { a.max = v }
+
+// Min implements the ColorMap interface.
+func (a *anchored) Min() float64 { return a.min }
+
+// SetMin implements the ColorMap interface.
+func (a *anchored) SetMin(v float64) { a.min = v }
+
+// Alpha implements the ColorMap interface.
+func (a *anchored) Alpha() float64 { return a.alpha }
+
+// SetAlpha implements the ColorMap interface.
+func (a *anchored) SetAlpha(alpha float64) {
+	if alpha < 0 || alpha > 1 {
+		panic("palette: alpha must be between 0 and 1")
+	}
+	a.alpha = alpha
+}
+
+// Palette implements the ColorMap interface.
+func (a *anchored) Palette(colors int) Palette {
+	return colorMapPalette(a, colors)
+}
+
+// Hypsometric returns an Anchored ColorMap of elevations in
+// metres, with blues for depths below sea level and tints
+// from green through brown to white above it, broken at sea
+// level. The range of the ColorMap is from -6000 to 6000.
+func Hypsometric() ColorMap {
+	return Anchored(
+		Anchor{Value: -6000, Color: color.NRGBA{R: 8, G: 29, B: 88, A: 255}},
+		Anchor{Value: -3000, Color: color.NRGBA{R: 34, G: 94, B: 168, A: 255}},
+		Anchor{Value: -200, Color: color.NRGBA{R: 65, G: 182, B: 196, A: 255}},
+		Anchor{Value: 0, Color: color.NRGBA{R: 166, G: 217, B: 240, A: 255}},
+		Anchor{Value: 0, Color: color.NRGBA{R: 26, G: 133, B: 74, A: 255}},
+		Anchor{Value: 200, Color: color.NRGBA{R: 112, G: 173, B: 71, A: 255}},
+		Anchor{Value: 500, Color: color.NRGBA{R: 238, G: 220, B: 130, A: 255}},
+		Anchor{Value: 1500, Color: color.NRGBA{R: 191, G: 129, B: 45, A: 255}},
+		Anchor{Value: 3000, Color: color.NRGBA{R: 130, G: 82, B: 45, A: 255}},
+		Anchor{Value: 4500, Color: color.NRGBA{R: 230, G: 230, B: 230, A: 255}},
+		Anchor{Value: 6000, Color: color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+	)
+}
@@ -0,0 +1,89 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestAnchored(t *testing.T) {
+	var (
+		blue  = color.NRGBA{B: 0xff, A: 0xff}
+		white = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+		green = color.NRGBA{G: 0xff, A: 0xff}
+		red   = color.NRGBA{R: 0xff, A: 0xff}
+	)
+	cm := Anchored(
+		Anchor{Value: -100, Color: blue},
+		Anchor{Value: 0, Color: white},
+		Anchor{Value: 0, Color: green},
+		Anchor{Value: 1000, Color: red},
+	)
+	if cm.Min() != -100 || cm.Max() != 1000 {
+		t.Errorf("unexpected range: got:%v %v want:-100 1000", cm.Min(), cm.Max())
+	}
+
+	// Widening the range does not move the anchors.
+	cm.SetMin(-200)
+	cm.SetMax(2000)
+
+	for _, test := range []struct {
+		v    float64
+		want color.Color
+		err  error
+	}{
+		{v: -200, want: blue},
+		{v: -100, want: blue},
+		{v: -50, want: color.NRGBA{R: 0x80, G: 0x80, B: 0xff, A: 0xff}},
+		{v: -1e-9, want: white},
+		{v: 0, want: green},
+		{v: 500, want: color.NRGBA{R: 0x80, G: 0x80, A: 0xff}},
+		{v: 1000, want: red},
+		{v: 2000, want: red},
+		{v: -201, err: ErrUnderflow},
+		{v: 2001, err: ErrOverflow},
+		{v: math.NaN(), err: ErrNaN},
+	} {
+		got, err := cm.At(test.v)
+		if err != test.err {
+			t.Errorf("unexpected error for %v: got:%v want:%v", test.v, err, test.err)
+		}
+		if got != test.want {
+			t.Errorf("unexpected color for %v: got:%v want:%v", test.v, got, test.want)
+		}
+	}
+
+	cm.SetAlpha(0.5)
+	got, _ := cm.At(0)
+	if want := (color.NRGBA{G: 0xff, A: 0x80}); got != want {
+		t.Errorf("unexpected color with alpha: got:%v want:%v", got, want)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for decreasing anchors")
+			}
+		}()
+		Anchored(Anchor{Value: 1, Color: red}, Anchor{Value: 0, Color: blue})
+	}()
+}
+
+func TestHypsometric(t *testing.T) {
+	cm := Hypsometric()
+	sea, err := cm.At(-1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	land, err := cm.At(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s, l := sea.(color.NRGBA), land.(color.NRGBA); s.B <= s.G || l.G <= l.B {
+		t.Errorf("unexpected colors at sea level: sea:%v land:%v", sea, land)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// BlendMode specifies how Shade combines
+// a shade with a color.
+type BlendMode int
+
+const (
+	// Multiply multiplies the color by the shade,
+	// so a shade of one leaves the color unchanged
+	// and a shade of zero gives black.
+	Multiply BlendMode = iota
+
+	// SoftLight darkens the color for shades less
+	// than one half and lightens it for shades
+	// greater than one half, keeping more of the
+	// hue of the color in the shadows than Multiply.
+	SoftLight
+)
+
+// Shade returns the color c shaded by a gray level, such as
+// the illumination of a hillshade, between zero and one.
+// The color and the shade are combined by the blend mode in
+// linear RGB, so that blending is not skewed by the gamma
+// of sRGB colors. The alpha of c is kept.
+func Shade(c color.Color, shade float64, mode BlendMode) color.NRGBA {
+	shade = math.Max(0, math.Min(1, shade))
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	blend := func(v uint8) uint8 {
+		l := sRGBToLinear(float64(v) / 0xff)
+		switch mode {
+		case Multiply:
+			l *= shade
+		case SoftLight:
+			l = softLight(l, shade)
+		default:
+			panic("palette: unknown blend mode")
+		}
+		return uint8(linearToSRGB(l)*0xff + 0.5)
+	}
+	return color.NRGBA{R: blend(n.R), G: blend(n.G), B: blend(n.B), A: n.A}
+}
+
+// softLight returns the soft light blend of the
+// backdrop b with the source s, as specified by
+// the W3C compositing recommendation.
+func softLight(b, s float64) float64 {
+	if s <= 0.5 {
+		return b - (1-2*s)*b*(1-b)
+	}
+	var d float64
+	if b <= 0.25 {
+		d = ((16*b-12)*b + 4) * b
+	} else {
+		d = math.Sqrt(b)
+	}
+	return b + (2*s-1)*(d-b)
+}
+
+// sRGBToLinear returns the linear intensity of
+// an sRGB encoded component between zero and one.
+func sRGBToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSRGB returns the sRGB encoding of
+// a linear intensity between zero and one.
+func linearToSRGB(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
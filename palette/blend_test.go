@@ -0,0 +1,50 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestSRGBRoundTrip(t *testing.T) {
+	for i := 0; i <= 0xff; i++ {
+		v := float64(i) / 0xff
+		if got := linearToSRGB(sRGBToLinear(v)); math.Abs(got-v) > 1e-12 {
+			t.Errorf("unexpected round trip of %v: got:%v", v, got)
+		}
+	}
+	// The middle gray of sRGB is about a fifth of full intensity.
+	if got := sRGBToLinear(0.5); math.Abs(got-0.2140) > 1e-4 {
+		t.Errorf("unexpected linear intensity of sRGB 0.5: got:%v want:0.2140", got)
+	}
+}
+
+func TestShade(t *testing.T) {
+	c := color.NRGBA{R: 200, G: 100, B: 50, A: 128}
+	for _, test := range []struct {
+		shade float64
+		mode  BlendMode
+		want  color.NRGBA
+	}{
+		{shade: 1, mode: Multiply, want: c},
+		{shade: 0, mode: Multiply, want: color.NRGBA{A: 128}},
+		{shade: 0.5, mode: SoftLight, want: c},
+		{shade: 2, mode: Multiply, want: c},
+		{shade: 0, mode: SoftLight, want: color.NRGBA{R: 156, G: 34, B: 3, A: 128}},
+	} {
+		if got := Shade(c, test.shade, test.mode); got != test.want {
+			t.Errorf("unexpected shade %v by mode %d: got:%v want:%v", test.shade, test.mode, got, test.want)
+		}
+	}
+
+	// Multiplying by a shade in linear RGB is
+	// not the same as multiplying sRGB values.
+	got := Shade(color.NRGBA{R: 0xff, A: 0xff}, 0.5, Multiply)
+	if got.R != 188 {
+		t.Errorf("unexpected red of half shaded red: got:%d want:188", got.R)
+	}
+}
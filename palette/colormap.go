@@ -0,0 +1,196 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"errors"
+	"image/color"
+	"math"
+)
+
+var (
+	// ErrOverflow is returned by a ColorMap for
+	// values greater than its maximum.
+	ErrOverflow = errors.New("palette: color map overflow")
+
+	// ErrUnderflow is returned by a ColorMap for
+	// values less than its minimum.
+	ErrUnderflow = errors.New("palette: color map underflow")
+
+	// ErrNaN is returned by a ColorMap for NaN values.
+	ErrNaN = errors.New("palette: NaN value")
+)
+
+// A ColorMap maps scalar values to colors. It maps the values
+// in the range from Min to Max continuously onto its colors,
+// with an opacity that scales the alpha of each color. Linear
+// returns a ColorMap made from a Palette.
+//
+// The methods of a ColorMap that do not change it are safe for
+// concurrent use, so a ColorMap may be shared by plots drawn at
+// the same time. Its range and opacity must not be set while it
+// is in use: plots that need different ranges should each use
+// their own ColorMap.
+type ColorMap interface {
+	// At returns the color associated with the given value.
+	// If the value is not between Min and Max, an error is
+	// returned: ErrUnderflow for values less than Min,
+	// ErrOverflow for values greater than Max and ErrNaN
+	// for NaN, so that callers may choose colors of their
+	// own for out of range values.
+	At(float64) (color.Color, error)
+
+	// Max returns the current maximum value of the ColorMap.
+	Max() float64
+
+	// SetMax sets the maximum value of the ColorMap.
+	SetMax(float64)
+
+	// Min returns the current minimum value of the ColorMap.
+	Min() float64
+
+	// SetMin sets the minimum value of the ColorMap.
+	SetMin(float64)
+
+	// Alpha returns the opacity value of the ColorMap.
+	Alpha() float64
+
+	// SetAlpha sets the opacity value of the ColorMap. Zero is
+	// transparent and one is completely opaque. The default
+	// value of alpha should be expected to be one. SetAlpha
+	// should be expected to panic if alpha is not between
+	// zero and one.
+	SetAlpha(float64)
+
+	// Palette creates a Palette with the specified number
+	// of colors from the ColorMap, evenly spaced from the
+	// color at Min to the color at Max, for use where
+	// discrete colors are needed, such as the contours of
+	// a plotter.Contour.
+	Palette(colors int) Palette
+}
+
+// linear is a ColorMap interpolating linearly
+// between evenly spaced colors.
+type linear struct {
+	colors   []color.NRGBA
+	min, max float64
+	alpha    float64
+}
+
+// Linear returns a ColorMap that interpolates linearly in RGBA
+// space between the colors of p, which are evenly spaced between
+// the minimum and maximum of the ColorMap. The minimum and maximum
+// are initially zero and one. Linear panics if p has no colors.
+func Linear(p Palette) ColorMap {
+	cs := p.Colors()
+	if len(cs) == 0 {
+		panic("palette: empty palette")
+	}
+	l := &linear{
+		colors: make([]color.NRGBA, len(cs)),
+		max:    1,
+		alpha:  1,
+	}
+	for i, c := range cs {
+		l.colors[i] = color.NRGBAModel.Convert(c).(color.NRGBA)
+	}
+	return l
+}
+
+// At implements the ColorMap interface.
+func (l *linear) At(v float64) (color.Color, error) {
+	if err := checkRange(l.min, l.max, v); err != nil {
+		return nil, err
+	}
+	if len(l.colors) == 1 || l.max == l.min {
+		return l.withAlpha(l.colors[0]), nil
+	}
+	pos := (v - l.min) / (l.max - l.min) * float64(len(l.colors)-1)
+	i := int(pos)
+	if i >= len(l.colors)-1 {
+		return l.withAlpha(l.colors[len(l.colors)-1]), nil
+	}
+	f := pos - float64(i)
+	c0, c1 := l.colors[i], l.colors[i+1]
+	return l.withAlpha(color.NRGBA{
+		R: lerp(c0.R, c1.R, f),
+		G: lerp(c0.G, c1.G, f),
+		B: lerp(c0.B, c1.B, f),
+		A: lerp(c0.A, c1.A, f),
+	}), nil
+}
+
+// withAlpha returns c with its opacity scaled by the
+// alpha of the ColorMap.
+func (l *linear) withAlpha(c color.NRGBA) color.NRGBA {
+	c.A = uint8(float64(c.A)*l.alpha + 0.5)
+	return c
+}
+
+func lerp(a, b uint8, f float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*f + 0.5)
+}
+
+// checkRange returns an error if v is NaN or outside [min, max].
+func checkRange(min, max, v float64) error {
+	switch {
+	case math.IsNaN(v):
+		return ErrNaN
+	case v < min:
+		return ErrUnderflow
+	case v > max:
+		return ErrOverflow
+	}
+	return nil
+}
+
+// Max implements the ColorMap interface.
+func (l *linear) Max() float64 { return l.max }
+
+// SetMax implements the ColorMap interface.
+func (l *linear) SetMax(v float64) { l.max = v }
+
+// Min implements the ColorMap interface.
+func (l *linear) Min() float64 { return l.min }
+
+// SetMin implements the ColorMap interface.
+func (l *linear) SetMin(v float64) { l.min = v }
+
+// Alpha implements the ColorMap interface.
+func (l *linear) Alpha() float64 { return l.alpha }
+
+// SetAlpha implements the ColorMap interface.
+func (l *linear) SetAlpha(alpha float64) {
+	if alpha < 0 || alpha > 1 {
+		panic("palette: alpha must be between 0 and 1")
+	}
+	l.alpha = alpha
+}
+
+// Palette implements the ColorMap interface.
+func (l *linear) Palette(colors int) Palette {
+	return colorMapPalette(l, colors)
+}
+
+// colorMapPalette returns a palette of the given number of
+// colors evenly spaced across the range of the ColorMap.
+func colorMapPalette(cm ColorMap, colors int) Palette {
+	p := make(palette, colors)
+	min, max := cm.Min(), cm.Max()
+	for i := range p {
+		v := min
+		if colors > 1 {
+			v += float64(i) * (max - min) / float64(colors-1)
+		}
+		c, err := cm.At(v)
+		if err != nil {
+			// Rounding may take v just outside the range.
+			c, _ = cm.At(math.Max(min, math.Min(max, v)))
+		}
+		p[i] = c
+	}
+	return p
+}
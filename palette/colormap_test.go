@@ -0,0 +1,60 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLinear(t *testing.T) {
+	cm := Linear(palette{
+		color.NRGBA{R: 0xff, A: 0xff},
+		color.NRGBA{G: 0xff, A: 0xff},
+		color.NRGBA{B: 0xff, A: 0xff},
+	})
+	cm.SetMin(-1)
+	cm.SetMax(1)
+
+	for _, test := range []struct {
+		v    float64
+		want color.Color
+		err  error
+	}{
+		{v: -1, want: color.NRGBA{R: 0xff, A: 0xff}},
+		{v: -0.5, want: color.NRGBA{R: 0x80, G: 0x80, A: 0xff}},
+		{v: 0, want: color.NRGBA{G: 0xff, A: 0xff}},
+		{v: 1, want: color.NRGBA{B: 0xff, A: 0xff}},
+		{v: -1.5, err: ErrUnderflow},
+		{v: 1.5, err: ErrOverflow},
+		{v: math.NaN(), err: ErrNaN},
+	} {
+		got, err := cm.At(test.v)
+		if err != test.err {
+			t.Errorf("unexpected error for %v: got:%v want:%v", test.v, err, test.err)
+		}
+		if got != test.want {
+			t.Errorf("unexpected color for %v: got:%v want:%v", test.v, got, test.want)
+		}
+	}
+
+	cm.SetAlpha(0.5)
+	got, _ := cm.At(0)
+	if want := (color.NRGBA{G: 0xff, A: 0x80}); got != want {
+		t.Errorf("unexpected color with alpha: got:%v want:%v", got, want)
+	}
+	cm.SetAlpha(1)
+
+	want := palette{
+		color.NRGBA{R: 0xff, A: 0xff},
+		color.NRGBA{G: 0xff, A: 0xff},
+		color.NRGBA{B: 0xff, A: 0xff},
+	}
+	if p := cm.Palette(3); !reflect.DeepEqual(p, want) {
+		t.Errorf("unexpected palette: got:%v want:%v", p, want)
+	}
+}
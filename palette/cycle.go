@@ -0,0 +1,113 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// cycleSamples is the number of samples of a
+// ColorMap from which the colors of a Cycle are
+// chosen.
+const cycleSamples = 256
+
+// Cycle returns a palette of the given number of colors taken
+// from the ColorMap, for use as the color cycle of the series
+// of a chart, so that line charts may share the look of the
+// map used by the heat maps of a figure.
+//
+// The colors are taken in the order of the map, evenly spaced
+// by their perceptual difference along it, so that neighbors in
+// the cycle are as distinct as the map allows. The ends of the
+// map lighter than maxLightness, the CIELAB lightness between
+// 0 for black and 100 for white, are not used, since light
+// colors are hard to see on a white background; a maxLightness
+// of about 80 suits most maps.
+func Cycle(cm ColorMap, colors int, maxLightness float64) Palette {
+	if colors <= 0 {
+		return palette{}
+	}
+	min, max := cm.Min(), cm.Max()
+	vs := make([]float64, cycleSamples)
+	labs := make([][3]float64, cycleSamples)
+	for i := range vs {
+		vs[i] = min + float64(i)*(max-min)/(cycleSamples-1)
+		c, err := cm.At(vs[i])
+		if err != nil {
+			// Rounding may take v just outside the range.
+			c, _ = cm.At(math.Max(min, math.Min(max, vs[i])))
+		}
+		labs[i] = lab(c)
+	}
+
+	// Trim the light ends of the map, using
+	// all of it if it is lighter throughout.
+	lo, hi := 0, cycleSamples-1
+	for lo < hi && labs[lo][0] > maxLightness {
+		lo++
+	}
+	for hi > lo && labs[hi][0] > maxLightness {
+		hi--
+	}
+	if labs[lo][0] > maxLightness {
+		lo, hi = 0, cycleSamples-1
+	}
+
+	// dist holds the cumulative color difference
+	// along the map from the sample lo.
+	dist := make([]float64, hi-lo+1)
+	for i := 1; i < len(dist); i++ {
+		a, b := labs[lo+i-1], labs[lo+i]
+		dist[i] = dist[i-1] + math.Sqrt(sq(a[0]-b[0])+sq(a[1]-b[1])+sq(a[2]-b[2]))
+	}
+	total := dist[len(dist)-1]
+
+	p := make(palette, colors)
+	j := 0
+	for i := range p {
+		d := total / 2
+		if colors > 1 {
+			d = total * float64(i) / float64(colors-1)
+		}
+		for j < len(dist)-2 && dist[j+1] < d {
+			j++
+		}
+		v := vs[lo+j]
+		if len(dist) > 1 && dist[j+1] > dist[j] {
+			f := math.Min(1, (d-dist[j])/(dist[j+1]-dist[j]))
+			v += f * (vs[lo+j+1] - vs[lo+j])
+		}
+		c, err := cm.At(v)
+		if err != nil {
+			c, _ = cm.At(math.Max(min, math.Min(max, v)))
+		}
+		p[i] = c
+	}
+	return p
+}
+
+// lab returns the CIELAB coordinates of c,
+// relative to the D65 white point.
+func lab(c color.Color) [3]float64 {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	r := sRGBToLinear(float64(n.R) / 255)
+	g := sRGBToLinear(float64(n.G) / 255)
+	b := sRGBToLinear(float64(n.B) / 255)
+
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+func sq(x float64) float64 { return x * x }
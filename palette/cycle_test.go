@@ -0,0 +1,54 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestCycle(t *testing.T) {
+	gray := Linear(palette{color.Gray{}, color.Gray{Y: 0xff}})
+	gray.SetMin(-2)
+	gray.SetMax(3)
+
+	for _, n := range []int{1, 2, 5} {
+		cs := Cycle(gray, n, 80).Colors()
+		if len(cs) != n {
+			t.Fatalf("unexpected number of colors: got:%d want:%d", len(cs), n)
+		}
+		if n == 1 {
+			if l := lab(cs[0])[0]; math.Abs(l-40) > 2 {
+				t.Errorf("unexpected lightness of single color: got:%.1f want:40", l)
+			}
+			continue
+		}
+		if l := lab(cs[0])[0]; l != 0 {
+			t.Errorf("unexpected lightness of first color: got:%.1f want:0", l)
+		}
+		if l := lab(cs[n-1])[0]; math.Abs(l-80) > 1 {
+			t.Errorf("unexpected lightness of last color: got:%.1f want:80", l)
+		}
+		step := 80 / float64(n-1)
+		for i := 1; i < n; i++ {
+			d := lab(cs[i])[0] - lab(cs[i-1])[0]
+			if math.Abs(d-step) > 1 {
+				t.Errorf("unexpected lightness step %d of %d colors: got:%.1f want:%.1f", i, n, d, step)
+			}
+		}
+	}
+
+	if cs := Cycle(gray, 0, 80).Colors(); len(cs) != 0 {
+		t.Errorf("unexpected colors for empty cycle: %v", cs)
+	}
+
+	// A map lighter than maxLightness throughout is used whole.
+	light := Linear(palette{color.Gray{Y: 0xf0}, color.Gray{Y: 0xff}})
+	cs := Cycle(light, 2, 50).Colors()
+	if cs[0] != (color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}) || cs[1] != (color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+		t.Errorf("unexpected colors of light map: %v", cs)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// Dark returns the variant of p for plots with dark backgrounds,
+// with each color made by DarkColor. The lightness order of the
+// colors is inverted, so that the colors of a sequential palette
+// that stand out from a light background, its darkest, are the
+// lightest of the variant and stand out from a dark background.
+// The variant of a DivergingPalette is a DivergingPalette.
+func Dark(p Palette, minLightness float64) Palette {
+	cs := p.Colors()
+	dark := make([]color.Color, len(cs))
+	for i, c := range cs {
+		dark[i] = DarkColor(c, minLightness)
+	}
+	if _, ok := p.(DivergingPalette); ok {
+		return divergingPalette(dark)
+	}
+	return palette(dark)
+}
+
+// DarkColor returns the variant of c for plots with dark
+// backgrounds. Its CIELAB lightness, between 0 for black and
+// 100 for white, is inverted and scaled into the range from
+// minLightness to 100, keeping the hue of c, so that black
+// becomes white and white becomes a color of minLightness
+// that is still seen against a dark background. The alpha of
+// c is kept.
+func DarkColor(c color.Color, minLightness float64) color.NRGBA {
+	minLightness = math.Max(0, math.Min(100, minLightness))
+	l := lab(c)
+	l[0] = minLightness + (100-l[0])*(100-minLightness)/100
+	n := labToNRGBA(l)
+	n.A = color.NRGBAModel.Convert(c).(color.NRGBA).A
+	return n
+}
+
+// labToNRGBA returns the opaque sRGB color of the CIELAB
+// coordinates l, relative to the D65 white point, with
+// components outside of the sRGB gamut clamped.
+func labToNRGBA(l [3]float64) color.NRGBA {
+	fy := (l[0] + 16) / 116
+	fx := fy + l[1]/500
+	fz := fy - l[2]/200
+	f := func(t float64) float64 {
+		if t > 6.0/29 {
+			return t * t * t
+		}
+		return (116*t - 16) * 27 / 24389
+	}
+	x, y, z := f(fx)*0.95047, f(fy), f(fz)*1.08883
+
+	r := 3.2406*x - 1.5372*y - 0.4986*z
+	g := -0.9689*x + 1.8758*y + 0.0415*z
+	b := 0.0557*x - 0.2040*y + 1.0570*z
+	channel := func(v float64) uint8 {
+		v = math.Max(0, math.Min(1, v))
+		return uint8(linearToSRGB(v)*0xff + 0.5)
+	}
+	return color.NRGBA{R: channel(r), G: channel(g), B: channel(b), A: 0xff}
+}
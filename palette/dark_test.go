@@ -0,0 +1,53 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestDark(t *testing.T) {
+	for _, test := range []struct {
+		c    color.Color
+		min  float64
+		want float64
+	}{
+		{c: color.Black, min: 40, want: 100},
+		{c: color.White, min: 40, want: 40},
+		{c: color.Gray{Y: 0x77}, min: 0, want: 100 - lab(color.Gray{Y: 0x77})[0]},
+		{c: color.Gray{Y: 0x40}, min: 30, want: 30 + 0.7*(100-lab(color.Gray{Y: 0x40})[0])},
+	} {
+		got := lab(DarkColor(test.c, test.min))[0]
+		if math.Abs(got-test.want) > 1 {
+			t.Errorf("unexpected lightness of dark variant of %v: got:%.1f want:%.1f", test.c, got, test.want)
+		}
+	}
+
+	// The hue of a color in gamut is kept.
+	c := color.NRGBA{R: 0x33, G: 0x99, B: 0x33, A: 0x80}
+	d := DarkColor(c, 50)
+	if d.A != c.A {
+		t.Errorf("unexpected alpha: got:%d want:%d", d.A, c.A)
+	}
+	if !(d.G > d.R && d.G > d.B) {
+		t.Errorf("unexpected dark variant of green: %v", d)
+	}
+
+	seq := palette{color.White, color.Gray{Y: 0x80}, color.Black}
+	dark := Dark(seq, 40).Colors()
+	for i := 1; i < len(dark); i++ {
+		if lab(dark[i])[0] <= lab(dark[i-1])[0] {
+			t.Errorf("lightness order of dark palette not inverted at %d", i)
+		}
+	}
+	if _, ok := Dark(seq, 40).(DivergingPalette); ok {
+		t.Error("unexpected diverging dark variant of sequential palette")
+	}
+	if _, ok := Dark(Radial(5, Blue, Red, 1), 40).(DivergingPalette); !ok {
+		t.Error("expected diverging dark variant of diverging palette")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package palette
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var generateTestData = flag.Bool("regen", false, "Uses the current state to regenerate the test data.")
+
+const (
+	// stripWidth and stripHeight are the size in
+	// pixels of the strip of each ColorMap in the
+	// golden image.
+	stripWidth  = 256
+	stripHeight = 8
+
+	// maxDeltaE is the greatest CIEDE2000 difference
+	// from the golden image allowed in any color of a
+	// strip, about the least difference that can be
+	// seen side by side.
+	maxDeltaE = 1.0
+)
+
+// goldenMaps are the ColorMaps of this package, and the
+// ColorMaps of the palettes it generates, checked against
+// the golden image, in the order of their strips in it.
+// New maps are added at the end. The palettes of the
+// brewer package, which imports this one, are tables of
+// fixed colors and are not checked.
+var goldenMaps = []struct {
+	name string
+	cm   ColorMap
+}{
+	{name: "Hypsometric", cm: Hypsometric()},
+	{name: "Heat", cm: Linear(Heat(64, 1))},
+	{name: "Rainbow", cm: Linear(Rainbow(64, Red, Magenta, 1, 1, 1))},
+	{name: "Radial", cm: Linear(Radial(64, Blue, Red, 1))},
+	{name: "Dark", cm: Linear(Dark(Heat(64, 1), 40))},
+	{name: "Cycle", cm: Linear(Cycle(Hypsometric(), 8, 80))},
+}
+
+// strip returns the strip of the ColorMap cm, the color
+// at the centre of each column of pixels across its range.
+func strip(cm ColorMap) []color.NRGBA {
+	min, max := cm.Min(), cm.Max()
+	s := make([]color.NRGBA, stripWidth)
+	for i := range s {
+		c, err := cm.At(min + (float64(i)+0.5)*(max-min)/stripWidth)
+		if err != nil {
+			panic(err)
+		}
+		s[i] = color.NRGBAModel.Convert(c).(color.NRGBA)
+	}
+	return s
+}
+
+// TestColorMapsGolden checks that the colors of the goldenMaps
+// match those of testdata/colormaps_golden.png, so that changes
+// to the conversions of colors do not silently shift the
+// published maps. The golden image is regenerated from the
+// current maps with the -regen flag.
+func TestColorMapsGolden(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, stripWidth, stripHeight*len(goldenMaps)))
+	for i, m := range goldenMaps {
+		for x, c := range strip(m.cm) {
+			for y := 0; y < stripHeight; y++ {
+				img.SetNRGBA(x, i*stripHeight+y, c)
+			}
+		}
+	}
+
+	path := filepath.Join("testdata", "colormaps_golden.png")
+	if *generateTestData {
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("failed to create golden image: %v", err)
+		}
+		defer f.Close()
+		if err := png.Encode(f, img); err != nil {
+			t.Fatalf("failed to encode golden image: %v", err)
+		}
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open golden image: %v", err)
+	}
+	defer f.Close()
+	golden, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode golden image: %v", err)
+	}
+	if b := golden.Bounds(); b.Dx() != stripWidth || b.Dy()%stripHeight != 0 {
+		t.Fatalf("unexpected size of golden image: %v", b.Size())
+	}
+
+	for i, m := range goldenMaps {
+		y := i * stripHeight
+		if y >= golden.Bounds().Dy() {
+			t.Errorf("%s: no golden strip, regenerate the golden image", m.name)
+			continue
+		}
+		var worst float64
+		var at int
+		for x := 0; x < stripWidth; x++ {
+			d := deltaE(img.At(x, y), golden.At(x, y))
+			if d > worst {
+				worst, at = d, x
+			}
+		}
+		if worst > maxDeltaE {
+			t.Errorf("%s: color %d differs from golden by ΔE %.2f: got:%v want:%v",
+				m.name, at, worst, img.At(at, y), golden.At(at, y))
+		}
+	}
+}
+
+// deltaE returns the CIEDE2000 color difference of a and b.
+func deltaE(a, b color.Color) float64 {
+	return ciede2000(lab(a), lab(b))
+}
+
+// ciede2000 returns the CIEDE2000 color difference of
+// two CIELAB colors, with unit weighting factors.
+func ciede2000(lab1, lab2 [3]float64) float64 {
+	const deg = math.Pi / 180
+	l1, a1, b1 := lab1[0], lab1[1], lab1[2]
+	l2, a2, b2 := lab2[0], lab2[1], lab2[2]
+
+	cBar := (math.Hypot(a1, b1) + math.Hypot(a2, b2)) / 2
+	c7 := math.Pow(cBar, 7)
+	g := 0.5 * (1 - math.Sqrt(c7/(c7+math.Pow(25, 7))))
+	a1, a2 = a1*(1+g), a2*(1+g)
+	c1, c2 := math.Hypot(a1, b1), math.Hypot(a2, b2)
+	h1, h2 := hueAngle(a1, b1), hueAngle(a2, b2)
+
+	dL := l2 - l1
+	dC := c2 - c1
+	var dh float64
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		switch {
+		case dh > 180:
+			dh -= 360
+		case dh < -180:
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(dh/2*deg)
+
+	lBar := (l1 + l2) / 2
+	cBar = (c1 + c2) / 2
+	hBar := h1 + h2
+	if c1*c2 != 0 {
+		switch {
+		case math.Abs(h1-h2) <= 180:
+			hBar /= 2
+		case hBar < 360:
+			hBar = (hBar + 360) / 2
+		default:
+			hBar = (hBar - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos((hBar-30)*deg) +
+		0.24*math.Cos(2*hBar*deg) +
+		0.32*math.Cos((3*hBar+6)*deg) -
+		0.20*math.Cos((4*hBar-63)*deg)
+	dTheta := 30 * math.Exp(-sq((hBar-275)/25))
+	c7 = math.Pow(cBar, 7)
+	rC := 2 * math.Sqrt(c7/(c7+math.Pow(25, 7)))
+	sL := 1 + 0.015*sq(lBar-50)/math.Sqrt(20+sq(lBar-50))
+	sC := 1 + 0.045*cBar
+	sH := 1 + 0.015*cBar*t
+	rT := -math.Sin(2*dTheta*deg) * rC
+
+	return math.Sqrt(sq(dL/sL) + sq(dC/sC) + sq(dH/sH) + rT*(dC/sC)*(dH/sH))
+}
+
+// hueAngle returns the hue angle in degrees
+// of the CIELAB chromaticity a, b in [0, 360).
+func hueAngle(a, b float64) float64 {
+	if a == 0 && b == 0 {
+		return 0
+	}
+	h := math.Atan2(b, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+func TestCIEDE2000(t *testing.T) {
+	// Test data from Sharma, Wu and Dalal, "The CIEDE2000
+	// Color-Difference Formula: Implementation Notes,
+	// Supplementary Test Data, and Mathematical Observations",
+	// Color Research and Application 30(1), 2005.
+	for _, test := range []struct {
+		lab1, lab2 [3]float64
+		want       float64
+	}{
+		{lab1: [3]float64{50, 2.6772, -79.7751}, lab2: [3]float64{50, 0, -82.7485}, want: 2.0425},
+		{lab1: [3]float64{50, 3.1571, -77.2803}, lab2: [3]float64{50, 0, -82.7485}, want: 2.8615},
+		{lab1: [3]float64{50, 2.8361, -74.0200}, lab2: [3]float64{50, 0, -82.7485}, want: 3.4412},
+		{lab1: [3]float64{50, 0, 0}, lab2: [3]float64{50, -1, 2}, want: 2.3669},
+		{lab1: [3]float64{50, 2.5, 0}, lab2: [3]float64{73, 25, -18}, want: 27.1492},
+		{lab1: [3]float64{60.2574, -34.0099, 36.2677}, lab2: [3]float64{60.4626, -34.1751, 39.4387}, want: 1.2644},
+	} {
+		if got := ciede2000(test.lab1, test.lab2); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("unexpected difference of %v and %v: got:%.4f want:%.4f", test.lab1, test.lab2, got, test.want)
+		}
+		if got := ciede2000(test.lab2, test.lab1); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("unexpected difference of %v and %v: got:%.4f want:%.4f", test.lab2, test.lab1, got, test.want)
+		}
+	}
+}
@@ -6,7 +6,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package palette provides basic color palette handling.
+// Package palette provides basic color palette handling, and
+// color maps, such as Linear, that map scalar values to colors.
 package palette
 
 import (
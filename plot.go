@@ -13,15 +13,26 @@
 // github.com/gonum/plot/plotter package
 // which is documented here:
 // http://godoc.org/github.com/gonum/plot/plotter
+//
+// Separate plots may be built and drawn by separate goroutines,
+// even if they share color maps, palettes, text styles and fonts,
+// as long as those shared values and the package level defaults
+// of this package and its sub-packages are not changed while the
+// plots are built and drawn.
 package plot
 
 import (
+	"fmt"
 	"image/color"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
@@ -33,35 +44,159 @@ var (
 )
 
 // Plot is the basic type representing a plot.
+//
+// Distinct plots may be built and drawn concurrently. The Add
+// and Draw methods of a single plot may also be called
+// concurrently, but its exported fields and the data of its
+// plotters must not be modified while it is being drawn, except
+// by the function passed to Update.
 type Plot struct {
-	Title struct {
-		// Text is the text of the plot title.  If
-		// Text is the empty string then the plot
-		// will not have a title.
-		Text string
+	// Title is the title of the plot, drawn
+	// at the top of the plot. Its Padding is
+	// the amount of padding between the bottom
+	// of the title and the rest of the plot.
+	Title TextBlock
 
-		// Padding is the amount of padding
-		// between the bottom of the title and
-		// the top of the plot.
-		Padding vg.Length
+	// Subtitle is drawn below the title, with
+	// its Padding between its bottom and the
+	// rest of the plot.
+	Subtitle TextBlock
 
-		draw.TextStyle
-	}
+	// Caption is drawn at the bottom of the
+	// plot, with its Padding between the rest
+	// of the plot and its top.
+	Caption TextBlock
 
 	// BackgroundColor is the background color of the plot.
 	// The default is White.
 	BackgroundColor color.Color
 
+	// Margin is the blank space left around the edges
+	// of the plot, inside the background.
+	Margin struct {
+		Top, Bottom, Left, Right vg.Length
+	}
+
 	// X and Y are the horizontal and vertical axes
 	// of the plot respectively.
 	X, Y Axis
 
+	// X2 and Y2, if not nil, are the secondary axes
+	// drawn along the top and the right of the plot,
+	// marking the ranges of the X and Y axes in
+	// another unit.
+	X2, Y2 *SecondaryAxis
+
+	// Spines specifies which lines bound or cross
+	// the data area. By default the lines of the
+	// axes are drawn.
+	Spines Spines
+
 	// Legend is the plot's legend.
 	Legend Legend
 
+	// Debug, if not nil, logs the layout of the
+	// plot each time it is drawn.
+	Debug *LayoutLog
+
+	// Limits are the limits on the resources
+	// used to draw the plot.
+	Limits Limits
+
+	// mu guards plotters and the axis ranges
+	// in Add, Update and Draw.
+	mu sync.Mutex
+
 	// plotters are drawn by calling their Plot method
 	// after the axes are drawn.
 	plotters []Plotter
+
+	// xLink and yLink are the links of the
+	// axes to those of other plots, if any.
+	xLink, yLink *AxisLink
+}
+
+// TextBlock is a block of text drawn across the top or
+// the bottom of a plot, such as its title.
+type TextBlock struct {
+	// Text is the text of the block. If Text is
+	// the empty string then the block is not drawn
+	// and takes no space.
+	Text string
+
+	// Padding is the amount of padding between
+	// the block and the rest of the plot.
+	Padding vg.Length
+
+	// Wrap specifies whether the lines of the
+	// text are broken between words to fit the
+	// width of the plot.
+	Wrap bool
+
+	// TextStyle is the style of the text. Its
+	// XAlign aligns the text with the left edge,
+	// the center or the right edge of the plot.
+	draw.TextStyle
+}
+
+// text returns the text of the block, wrapped
+// to the given width if Wrap is true.
+func (b *TextBlock) text(width vg.Length) string {
+	if !b.Wrap {
+		return b.Text
+	}
+	var lines []string
+	for _, line := range strings.Split(b.Text, "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		cur := words[0]
+		for _, w := range words[1:] {
+			if b.Width(cur+" "+w) > width {
+				lines = append(lines, cur)
+				cur = w
+				continue
+			}
+			cur += " " + w
+		}
+		lines = append(lines, cur)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// crop returns the canvas left by the block, with its
+// padding, drawn at the top or the bottom of c.
+func (b *TextBlock) crop(c draw.Canvas, top bool) draw.Canvas {
+	if b.Text == "" {
+		return c
+	}
+	h := b.Height(b.text(c.Size().X)) - b.Font.Extents().Descent
+	if top {
+		c.Max.Y -= h
+		c.Max.Y -= b.Padding
+	} else {
+		c.Min.Y += h
+		c.Min.Y += b.Padding
+	}
+	return c
+}
+
+// drawTop draws the block at the top of the canvas.
+func (b *TextBlock) drawTop(c draw.Canvas) {
+	txt := b.text(c.Size().X)
+	x := c.Min.X - vg.Length(b.XAlign)*c.Size().X
+	y := c.Max.Y - vg.Length(1+b.YAlign)*b.Height(txt)
+	c.FillText(b.TextStyle, vg.Point{X: x, Y: y}, txt)
+}
+
+// drawBottom draws the block at the bottom of the canvas.
+func (b *TextBlock) drawBottom(c draw.Canvas) {
+	txt := b.text(c.Size().X)
+	x := c.Min.X - vg.Length(b.XAlign)*c.Size().X
+	y := c.Min.Y - b.Font.Extents().Descent - vg.Length(b.YAlign)*b.Height(txt)
+	c.FillText(b.TextStyle, vg.Point{X: x, Y: y}, txt)
 }
 
 // Plotter is an interface that wraps the Plot method.
@@ -74,6 +209,49 @@ type Plotter interface {
 	Plot(draw.Canvas, *Plot)
 }
 
+// Unclipper wraps the Unclipped method. Plotters are
+// clipped to the data area of the plot when drawn, unless
+// they implement Unclipper and Unclipped returns true.
+// Clipping needs a canvas that implements vg.Clipper:
+// plotters drawn to PDF output, or to a vgimg canvas
+// created with UseImageWithContext, are not clipped.
+type Unclipper interface {
+	// Unclipped returns whether the plotter may draw
+	// outside the data area.
+	Unclipped() bool
+}
+
+// Layerer wraps the Layer method. The plotters of a plot
+// are drawn in ascending order of their layers, and in the
+// order in which they were added within a layer. Plotters
+// that do not implement Layerer are in layer 0, so that a
+// plotter in a negative layer, such as a grid, is drawn
+// below them, and one in a positive layer above them.
+type Layerer interface {
+	// Layer returns the layer of the plotter.
+	Layer() int
+}
+
+// Cloner wraps the Clone method, which returns a copy
+// of a plotter, for Plot.Clone, whose data and styles may
+// be changed without changing those of the plotter. Values
+// that are not changed in place, such as functions and
+// palettes, may be shared, as documented by the plotter.
+type Cloner interface {
+	// Clone returns a copy of the plotter.
+	Clone() Plotter
+}
+
+// Relinker wraps the Relink method, implemented by the
+// clones of plotters that refer to other plotters of a
+// plot, such as a bar chart stacked on another.
+type Relinker interface {
+	// Relink replaces the references of the clone to the
+	// plotters that are keys of clones with references
+	// to their clones.
+	Relink(clones map[Plotter]Plotter)
+}
+
 // DataRanger wraps the DataRange method.
 type DataRanger interface {
 	// DataRange returns the range of X and Y values.
@@ -92,6 +270,14 @@ func New() (*Plot, error) {
 	if err != nil {
 		return nil, err
 	}
+	subtitleFont, err := vg.MakeFont(DefaultFont, 10)
+	if err != nil {
+		return nil, err
+	}
+	captionFont, err := vg.MakeFont(DefaultFont, 8)
+	if err != nil {
+		return nil, err
+	}
 	x, err := makeAxis(horizontal)
 	if err != nil {
 		return nil, err
@@ -116,6 +302,20 @@ func New() (*Plot, error) {
 		XAlign: draw.XCenter,
 		YAlign: draw.YTop,
 	}
+	p.Subtitle.TextStyle = draw.TextStyle{
+		Color:  color.Black,
+		Font:   subtitleFont,
+		XAlign: draw.XCenter,
+		YAlign: draw.YTop,
+	}
+	p.Caption.TextStyle = draw.TextStyle{
+		Color:  color.Black,
+		Font:   captionFont,
+		XAlign: draw.XLeft,
+		YAlign: draw.YTop,
+	}
+	p.Caption.Padding = vg.Points(5)
+	p.Caption.Wrap = true
 	return p, nil
 }
 
@@ -124,73 +324,455 @@ func New() (*Plot, error) {
 // If the plotters implements DataRanger then the
 // minimum and maximum values of the X and Y
 // axes are changed if necessary to fit the range of
-// the data.
+// the data, as set by the AutoRange of the axes.
 //
 // When drawing the plot, Plotters are drawn in the
 // order in which they were added to the plot.
 func (p *Plot) Add(ps ...Plotter) {
-	for _, d := range ps {
-		if x, ok := d.(DataRanger); ok {
-			xmin, xmax, ymin, ymax := x.DataRange()
-			p.X.Min = math.Min(p.X.Min, xmin)
-			p.X.Max = math.Max(p.X.Max, xmax)
-			p.Y.Min = math.Min(p.Y.Min, ymin)
-			p.Y.Max = math.Max(p.Y.Max, ymax)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.plotters = append(p.plotters, ps...)
+	p.fit(ps)
+}
+
+// Plotters returns the plotters of the
+// plot, in the order in which they were
+// added.
+func (p *Plot) Plotters() []Plotter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Plotter(nil), p.plotters...)
+}
+
+// Update calls f, which may change the data of the plotters of
+// the plot, such as by appending points to them, and then extends
+// the ranges of the axes to fit the data of the plotters, as Add
+// does. A live plot, such as that of a dashboard, may so be drawn
+// again as its data grow, keeping its layout and styles, rather
+// than rebuilt for each frame.
+//
+// Update may be called concurrently with Draw, which does not draw
+// the plot while f is running. The fields of the plot, such as the
+// ranges of the axes of a sliding window, may also be set by f, but
+// f must not call the methods of the plot.
+func (p *Plot) Update(f func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	f()
+	p.fit(p.plotters)
+}
+
+// Clone returns a copy of the plot that may be changed and
+// drawn independently of p, such as at another size or with
+// another theme. The plotters of the plot that implement
+// Cloner are cloned, and the entries of the legend and the
+// clones that implement Relinker refer to the clones. Other
+// plotters are shared by the plots. The
+// clone is not linked to other plots and has no Debug log.
+func (p *Plot) Clone() *Plot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c := &Plot{
+		Title:           p.Title,
+		Subtitle:        p.Subtitle,
+		Caption:         p.Caption,
+		BackgroundColor: p.BackgroundColor,
+		Margin:          p.Margin,
+		X:               p.X,
+		Y:               p.Y,
+		Legend:          p.Legend,
+		Limits:          p.Limits,
+		Spines:          p.Spines,
+	}
+	if p.X2 != nil {
+		x2 := *p.X2
+		c.X2 = &x2
+	}
+	if p.Y2 != nil {
+		y2 := *p.Y2
+		c.Y2 = &y2
+	}
+	clones := make(map[Thumbnailer]Thumbnailer)
+	plotters := make(map[Plotter]Plotter)
+	var relinkers []Relinker
+	c.plotters = make([]Plotter, len(p.plotters))
+	for i, d := range p.plotters {
+		c.plotters[i] = d
+		if cl, ok := d.(Cloner); ok {
+			c.plotters[i] = cl.Clone()
+			if r, ok := c.plotters[i].(Relinker); ok {
+				relinkers = append(relinkers, r)
+			}
+			if reflect.TypeOf(d).Comparable() {
+				plotters[d] = c.plotters[i]
+			}
+			t, ok := d.(Thumbnailer)
+			if ok && reflect.TypeOf(t).Comparable() {
+				clones[t], _ = c.plotters[i].(Thumbnailer)
+			}
+		}
+	}
+	for _, r := range relinkers {
+		r.Relink(plotters)
+	}
+	c.Legend.entries = make([]legendEntry, len(p.Legend.entries))
+	for i, e := range p.Legend.entries {
+		c.Legend.entries[i] = legendEntry{text: e.text, thumbs: make([]Thumbnailer, len(e.thumbs))}
+		for j, t := range e.thumbs {
+			c.Legend.entries[i].thumbs[j] = t
+			if reflect.TypeOf(t).Comparable() && clones[t] != nil {
+				c.Legend.entries[i].thumbs[j] = clones[t]
+			}
 		}
 	}
+	return c
+}
 
-	p.plotters = append(p.plotters, ps...)
+// fit extends the ranges of the axes to fit the data of those
+// of the plotters ps that are DataRangers. The ranges of the axes
+// with an AutoRange are fitted to the data of all of the plotters
+// of the plot, to which ps must have been added, so that they are
+// not padded or rounded again for each of the plotters.
+func (p *Plot) fit(ps []Plotter) {
+	for _, a := range []struct {
+		axis *Axis
+		x    bool
+	}{{&p.X, true}, {&p.Y, false}} {
+		fitted := ps
+		if a.axis.AutoRange != nil {
+			fitted = p.plotters
+		}
+		min, max := dataRange(fitted, a.x)
+		a.axis.fit(min, max)
+	}
+}
+
+// dataRange returns the range of the X or the Y data of
+// those of the plotters ps that are DataRangers. If there
+// are none, min is greater than max.
+func dataRange(ps []Plotter, x bool) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, d := range ps {
+		if r, ok := d.(DataRanger); ok {
+			xmin, xmax, ymin, ymax := r.DataRange()
+			if x {
+				min, max = math.Min(min, xmin), math.Max(max, xmax)
+			} else {
+				min, max = math.Min(min, ymin), math.Max(max, ymax)
+			}
+		}
+	}
+	return min, max
 }
 
 // Draw draws a plot to a draw.Canvas.
 //
 // Plotters are drawn in the order in which they were
-// added to the plot.  Plotters that  implement the
-// GlyphBoxer interface will have their GlyphBoxes
-// taken into account when padding the plot so that
-// none of their glyphs are clipped.
+// added to the plot, within the layers given by those
+// that implement the Layerer interface.  Plotters that
+// implement the GlyphBoxer interface will have their
+// GlyphBoxes taken into account when padding the plot
+// so that none of their glyphs are clipped.
+//
+// Axes linked to those of other plots are synchronized
+// before the plot is drawn.
+//
+// Plotters exceeding the Limits of the plot are
+// downsampled or not drawn, as by DrawLimited.
 func (p *Plot) Draw(c draw.Canvas) {
+	p.DrawLimited(c)
+}
+
+// DrawLimited draws a plot to a draw.Canvas as Draw does,
+// returning a *LimitError if a plotter exceeding the Limits
+// of the plot was not drawn. The rest of the plot is drawn
+// nevertheless.
+func (p *Plot) DrawLimited(c draw.Canvas) error {
+	start := time.Now()
+	var err error
+	p.syncLinks()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Debug.reset()
+	p.Debug.record("plot", c.Rectangle, "size %.1fx%.1f", c.Size().X.Points(), c.Size().Y.Points())
 	if p.BackgroundColor != nil {
 		c.SetColor(p.BackgroundColor)
 		c.Fill(c.Rectangle.Path())
 	}
-	if p.Title.Text != "" {
-		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
-		c.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
-		c.Max.Y -= p.Title.Padding
+	full := c
+	c = p.cropMargin(c)
+	p.Debug.record("margin", c.Rectangle, "margins top %.1f bottom %.1f left %.1f right %.1f",
+		p.Margin.Top.Points(), p.Margin.Bottom.Points(), p.Margin.Left.Points(), p.Margin.Right.Points())
+	for _, b := range []struct {
+		name  string
+		block *TextBlock
+	}{
+		{name: "title", block: &p.Title},
+		{name: "subtitle", block: &p.Subtitle},
+	} {
+		if b.block.Text == "" {
+			continue
+		}
+		c.BeginGroup(b.name, b.name)
+		b.block.drawTop(c)
+		c.EndGroup()
+		rest := b.block.crop(c, true)
+		p.Debug.record(b.name, vg.Rectangle{Min: vg.Point{X: c.Min.X, Y: rest.Max.Y}, Max: c.Max},
+			"height %.1f including padding %.1f", (c.Max.Y - rest.Max.Y).Points(), b.block.Padding.Points())
+		c = rest
+	}
+	if p.Caption.Text != "" {
+		c.BeginGroup("caption", "caption")
+		p.Caption.drawBottom(c)
+		c.EndGroup()
+		rest := p.Caption.crop(c, false)
+		p.Debug.record("caption", vg.Rectangle{Min: c.Min, Max: vg.Point{X: c.Max.X, Y: rest.Min.Y}},
+			"height %.1f including padding %.1f", (rest.Min.Y - c.Min.Y).Points(), p.Caption.Padding.Points())
+		c = rest
 	}
 
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
+	x, y, x2, y2 := p.axes()
+	p.fitAxes(c, &x, &y, x2, y2)
 
 	ywidth := y.size()
-	x.draw(padX(p, draw.Crop(c, ywidth, 0, 0, 0)))
+	x2height, y2width := p.oppositeSizes(x2, y2)
+	c.BeginGroup("x-axis", "axis")
+	xc := padX(p, draw.Crop(c, ywidth, -y2width, 0, 0))
+	x.draw(xc)
+	c.EndGroup()
+	if x2 != nil {
+		c.BeginGroup("x2-axis", "axis")
+		x2.drawTop(xc)
+		c.EndGroup()
+	}
 	xheight := x.size()
-	y.draw(padY(p, draw.Crop(c, 0, 0, xheight, 0)))
+	c.BeginGroup("y-axis", "axis")
+	yc := padY(p, draw.Crop(c, 0, 0, xheight, -x2height))
+	y.draw(yc)
+	c.EndGroup()
+	if y2 != nil {
+		c.BeginGroup("y2-axis", "axis")
+		y2.drawRight(yc)
+		c.EndGroup()
+	}
+	p.Debug.record("x-axis", vg.Rectangle{Min: vg.Point{X: xc.Min.X, Y: c.Min.Y}, Max: vg.Point{X: xc.Max.X, Y: c.Min.Y + xheight}},
+		"height %.1f, range [%g, %g]", xheight.Points(), p.X.Min, p.X.Max)
+	p.Debug.record("y-axis", vg.Rectangle{Min: vg.Point{X: c.Min.X, Y: yc.Min.Y}, Max: vg.Point{X: c.Min.X + ywidth, Y: yc.Max.Y}},
+		"width %.1f, range [%g, %g]", ywidth.Points(), p.Y.Min, p.Y.Max)
+	if x2 != nil {
+		p.Debug.record("x2-axis", vg.Rectangle{Min: vg.Point{X: xc.Min.X, Y: c.Max.Y - x2height}, Max: vg.Point{X: xc.Max.X, Y: c.Max.Y}},
+			"height %.1f, range [%g, %g]", x2height.Points(), x2.Min, x2.Max)
+	}
+	if y2 != nil {
+		p.Debug.record("y2-axis", vg.Rectangle{Min: vg.Point{X: c.Max.X - y2width, Y: yc.Min.Y}, Max: vg.Point{X: c.Max.X, Y: yc.Max.Y}},
+			"width %.1f, range [%g, %g]", y2width.Points(), y2.Min, y2.Max)
+	}
+
+	area := draw.Crop(c, ywidth, -y2width, xheight, -x2height)
+	dataC := padY(p, padX(p, area))
+	p.Debug.record("data", dataC.Rectangle, "padded for glyph boxes left %.1f right %.1f bottom %.1f top %.1f",
+		(dataC.Min.X - area.Min.X).Points(), (area.Max.X - dataC.Max.X).Points(),
+		(dataC.Min.Y - area.Min.Y).Points(), (area.Max.Y - dataC.Max.Y).Points())
+	if p.Spines == BoxSpines || p.Spines == ZeroSpines {
+		c.BeginGroup("spines", "axis")
+		p.drawSpines(c, dataC, x2, y2)
+		c.EndGroup()
+	}
+	for _, i := range p.drawOrder() {
+		data := p.plotters[i]
+		var reason string
+		if p.Limits.MaxTime > 0 && time.Since(start) > p.Limits.MaxTime {
+			reason = fmt.Sprintf("drawing time exceeds the limit of %v", p.Limits.MaxTime)
+		} else {
+			data, reason = p.Limits.limit(data)
+		}
+		if reason != "" {
+			p.Debug.record(fmt.Sprintf("plotter-%d", i), dataC.Rectangle, "not drawn: %s", reason)
+			if err == nil {
+				err = &LimitError{Plotter: i, Reason: reason}
+			}
+			continue
+		}
+		c.BeginGroup(fmt.Sprintf("plotter-%d", i), "plotter "+plotterClass(data))
+		if u, ok := data.(Unclipper); ok && u.Unclipped() {
+			data.Plot(dataC, p)
+		} else {
+			c.Push()
+			c.Clip(area.Rectangle.Path())
+			data.Plot(dataC, p)
+			c.Pop()
+			if p.Debug != nil {
+				for _, r := range p.glyphRects(data, dataC) {
+					if !contains(area.Rectangle, r) {
+						p.Debug.record(fmt.Sprintf("plotter-%d", i), r, "glyph box clipped by the data area")
+					}
+				}
+			}
+		}
+		c.EndGroup()
+	}
+
+	c.BeginGroup("legend", "legend")
+	lc := draw.Crop(c, ywidth, -y2width, xheight, -x2height)
+	p.Legend.draw(lc)
+	c.EndGroup()
+	if p.Debug != nil && len(p.Legend.entries) > 0 {
+		lr := p.Legend.rectangle(lc)
+		p.Debug.record("legend", lr, "entries %d", len(p.Legend.entries))
+		for i, data := range p.plotters {
+			for _, r := range p.glyphRects(data, dataC) {
+				if overlaps(lr, r) {
+					p.Debug.record(fmt.Sprintf("plotter-%d", i), r, "glyph box overlapped by the legend")
+				}
+			}
+		}
+	}
+	p.Debug.draw(full)
+	return err
+}
+
+// glyphRects returns the rectangles of the glyph boxes
+// of the plotter, if it is a GlyphBoxer, when drawn to
+// the data canvas c.
+func (p *Plot) glyphRects(data Plotter, c draw.Canvas) []vg.Rectangle {
+	gb, ok := data.(GlyphBoxer)
+	if !ok {
+		return nil
+	}
+	var rs []vg.Rectangle
+	for _, b := range gb.GlyphBoxes(p) {
+		off := vg.Point{X: c.X(b.X), Y: c.Y(b.Y)}
+		rs = append(rs, vg.Rectangle{Min: b.Min.Add(off), Max: b.Max.Add(off)})
+	}
+	return rs
+}
+
+// syncLinks synchronizes the linked axes of the plot.
+func (p *Plot) syncLinks() {
+	p.mu.Lock()
+	xl, yl := p.xLink, p.yLink
+	p.mu.Unlock()
+	xl.Sync()
+	yl.Sync()
+}
 
-	dataC := padY(p, padX(p, draw.Crop(c, ywidth, 0, xheight, 0)))
-	for _, data := range p.plotters {
-		data.Plot(dataC, p)
+// plotterClass returns the lower case name of the
+// type of the given Plotter, for example "line"
+// for a *plotter.Line, for use as a group class.
+func plotterClass(p Plotter) string {
+	name := fmt.Sprintf("%T", p)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
 	}
+	return strings.ToLower(strings.TrimLeft(name, "*[]"))
+}
+
+// drawOrder returns the indices of the plotters of
+// the plot in the order in which they are drawn.
+func (p *Plot) drawOrder() []int {
+	order := make([]int, len(p.plotters))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Stable(byLayer{order: order, plotters: p.plotters})
+	return order
+}
+
+// byLayer sorts the indices of plotters by their layers.
+type byLayer struct {
+	order    []int
+	plotters []Plotter
+}
 
-	p.Legend.draw(draw.Crop(draw.Crop(c, ywidth, 0, 0, 0), 0, 0, xheight, 0))
+func (l byLayer) Len() int      { return len(l.order) }
+func (l byLayer) Swap(i, j int) { l.order[i], l.order[j] = l.order[j], l.order[i] }
+func (l byLayer) Less(i, j int) bool {
+	return layer(l.plotters[l.order[i]]) < layer(l.plotters[l.order[j]])
+}
+
+// layer returns the layer of the plotter p.
+func layer(p Plotter) int {
+	if l, ok := p.(Layerer); ok {
+		return l.Layer()
+	}
+	return 0
 }
 
 // DataCanvas returns a new draw.Canvas that
 // is the subset of the given draw area into which
-// the plot data will be drawn.
+// the plot data will be drawn. Its Rectangle is the
+// data area of the plot when drawn to da.
 func (p *Plot) DataCanvas(da draw.Canvas) draw.Canvas {
-	if p.Title.Text != "" {
-		da.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
-		da.Max.Y -= p.Title.Padding
+	p.syncLinks()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.dataCanvas(da)
+}
+
+// DataValue returns the coordinates, in the data coordinate
+// system, of the point pt of the draw area da on which the plot
+// is drawn, and whether pt is inside the data area of the plot.
+func (p *Plot) DataValue(da draw.Canvas, pt vg.Point) (x, y float64, ok bool) {
+	p.syncLinks()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dc := p.dataCanvas(da)
+	fx, fy := fraction(dc, pt)
+	return p.X.Value(fx), p.Y.Value(fy), dc.Contains(pt)
+}
+
+// dataCanvas returns the data canvas of the plot as
+// DataCanvas does, without synchronizing its linked
+// axes. It must be called with p.mu held.
+func (p *Plot) dataCanvas(da draw.Canvas) draw.Canvas {
+	da = p.cropMargin(da)
+	da = p.Title.crop(da, true)
+	da = p.Subtitle.crop(da, true)
+	da = p.Caption.crop(da, false)
+	x, y, x2, y2 := p.axes()
+	p.fitAxes(da, &x, &y, x2, y2)
+	x2height, y2width := p.oppositeSizes(x2, y2)
+	return padY(p, padX(p, draw.Crop(da, y.size(), -y2width, x.size(), -x2height)))
+}
+
+// fitAxes sets the lengths of the axes x and y, and of the
+// secondary axes x2 and y2 if they are not nil, drawn on the
+// canvas c, along which the overlaps of their tick labels are
+// resolved. The X axes are fitted to the width left by the
+// Y axes with all of their tick labels, and the Y axes to the
+// height left by the fitted X axes.
+func (p *Plot) fitAxes(c draw.Canvas, x *horizontalAxis, y *verticalAxis, x2 *horizontalAxis, y2 *verticalAxis) {
+	if !overlapResolved(&x.Axis, &y.Axis, x2, y2) {
+		return
+	}
+	x2height, y2width := p.oppositeSizes(x2, y2)
+	xc := padX(p, draw.Crop(c, y.size(), -y2width, 0, 0))
+	x.length = xc.Max.X - xc.Min.X
+	if x2 != nil {
+		x2.length = x.length
+	}
+	yc := padY(p, draw.Crop(c, 0, 0, x.size(), -x2height))
+	y.length = yc.Max.Y - yc.Min.Y
+	if y2 != nil {
+		y2.length = y.length
+	}
+}
+
+// overlapResolved returns whether the overlaps of the tick
+// labels of any of the given axes are to be resolved.
+func overlapResolved(x, y *Axis, x2 *horizontalAxis, y2 *verticalAxis) bool {
+	if x.Tick.Overlap != OverlapAllowed || y.Tick.Overlap != OverlapAllowed {
+		return true
+	}
+	if x2 != nil && x2.Tick.Overlap != OverlapAllowed {
+		return true
 	}
-	p.X.sanitizeRange()
-	x := horizontalAxis{p.X}
-	p.Y.sanitizeRange()
-	y := verticalAxis{p.Y}
-	return padY(p, padX(p, draw.Crop(da, y.size(), x.size(), 0, 0)))
+	return y2 != nil && y2.Tick.Overlap != OverlapAllowed
+}
+
+// cropMargin returns the canvas inside the margin of the plot.
+func (p *Plot) cropMargin(c draw.Canvas) draw.Canvas {
+	return draw.Crop(c, p.Margin.Left, -p.Margin.Right, p.Margin.Bottom, -p.Margin.Top)
 }
 
 // DrawGlyphBoxes draws red outlines around the plot's
@@ -209,8 +791,11 @@ func (p *Plot) DrawGlyphBoxes(c *draw.Canvas) {
 func padX(p *Plot, c draw.Canvas) draw.Canvas {
 	glyphs := p.GlyphBoxes(p)
 	l := leftMost(&c, glyphs)
-	xAxis := horizontalAxis{p.X}
+	xAxis := horizontalAxis{Axis: p.X}
 	glyphs = append(glyphs, xAxis.GlyphBoxes(p)...)
+	if x2, _ := p.secondaryAxes(); x2 != nil {
+		glyphs = append(glyphs, x2.GlyphBoxes(p)...)
+	}
 	r := rightMost(&c, glyphs)
 
 	minx := c.Min.X - l.Min.X
@@ -265,8 +850,11 @@ func leftMost(c *draw.Canvas, boxes []GlyphBox) GlyphBox {
 func padY(p *Plot, c draw.Canvas) draw.Canvas {
 	glyphs := p.GlyphBoxes(p)
 	b := bottomMost(&c, glyphs)
-	yAxis := verticalAxis{p.Y}
+	yAxis := verticalAxis{Axis: p.Y}
 	glyphs = append(glyphs, yAxis.GlyphBoxes(p)...)
+	if _, y2 := p.secondaryAxes(); y2 != nil {
+		glyphs = append(glyphs, y2.GlyphBoxes(p)...)
+	}
 	t := topMost(&c, glyphs)
 
 	miny := c.Min.Y - b.Min.Y
@@ -442,17 +1030,25 @@ func (p *Plot) NominalY(names ...string) {
 }
 
 // WriterTo returns an io.WriterTo that will write the plot as
-// the specified image format.
+// the specified image format. The plot can then be written to
+// any io.Writer, such as an http.ResponseWriter or a buffer,
+// without using a file. The format is not case sensitive, and
+// may be given with a leading dot as a file extension.
 //
 // Supported formats are:
 //
 //  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
+//
+// WriterTo returns a *LimitError if a plotter exceeding
+// the Limits of the plot was not drawn.
 func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 	c, err := draw.NewFormattedCanvas(w, h, format)
 	if err != nil {
 		return nil, err
 	}
-	p.Draw(draw.New(c))
+	if err := p.DrawLimited(draw.New(c)); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -463,6 +1059,11 @@ func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 //
 //  .eps, .jpg, .jpeg, .pdf, .png, .svg, .tif and .tiff.
 func (p *Plot) Save(w, h vg.Length, file string) (err error) {
+	c, err := p.WriterTo(w, h, filepath.Ext(file))
+	if err != nil {
+		return err
+	}
+
 	f, err := os.Create(file)
 	if err != nil {
 		return err
@@ -474,15 +1075,6 @@ func (p *Plot) Save(w, h vg.Length, file string) (err error) {
 		}
 	}()
 
-	format := strings.ToLower(filepath.Ext(file))
-	if len(format) != 0 {
-		format = format[1:]
-	}
-	c, err := p.WriterTo(w, h, format)
-	if err != nil {
-		return err
-	}
-
 	_, err = c.WriteTo(f)
 	return err
 }
@@ -8,8 +8,12 @@ import (
 	"bytes"
 	"fmt"
 	"image/color"
+	"io/ioutil"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/plotter"
@@ -229,3 +233,590 @@ func printFirstDiff(got, want []recorder.Action) string {
 	}
 	return buf.String()
 }
+
+// unclipped is a plotter that may draw
+// outside the data area of a plot.
+type unclipped struct{ plot.Plotter }
+
+func (unclipped) Unclipped() bool { return true }
+
+func TestPlotterClipping(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l, unclipped{l})
+	p.HideAxes()
+
+	var r recorder.Canvas
+	p.Draw(draw.NewCanvas(&r, 100, 100))
+
+	var clips []*recorder.Clip
+	for _, a := range r.Actions {
+		if c, ok := a.(*recorder.Clip); ok {
+			clips = append(clips, c)
+		}
+	}
+	if len(clips) != 1 {
+		t.Fatalf("unexpected number of clips: got:%d want:1", len(clips))
+	}
+	// The hidden axes keep their padding.
+	want := vg.Rectangle{Min: vg.Point{X: 5, Y: 5}, Max: vg.Point{X: 100, Y: 100}}.Path()
+	if !reflect.DeepEqual(clips[0].Path, want) {
+		t.Errorf("unexpected clip path:\ngot: %#v\nwant:%#v", clips[0].Path, want)
+	}
+}
+
+func TestWriterTo(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	for _, test := range []struct {
+		format string
+		prefix string
+	}{
+		{format: "png", prefix: "\x89PNG"},
+		{format: "PNG", prefix: "\x89PNG"},
+		{format: ".svg", prefix: "<?xml"},
+		{format: "tif", prefix: "II*\x00"},
+		{format: "jpeg", prefix: "\xff\xd8"},
+	} {
+		c, err := p.WriterTo(vg.Inch, vg.Inch, test.format)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", test.format, err)
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := c.WriteTo(&buf); err != nil {
+			t.Errorf("unexpected error writing %q: %v", test.format, err)
+			continue
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte(test.prefix)) {
+			t.Errorf("unexpected output for %q: got prefix %q", test.format, buf.Bytes()[:len(test.prefix)])
+		}
+	}
+
+	if _, err := p.WriterTo(vg.Inch, vg.Inch, "bmp"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+// TestConcurrentPlots checks that plots can be built and
+// drawn concurrently. It is most useful with -race.
+func TestConcurrentPlots(t *testing.T) {
+	shared, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p, err := plot.New()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			p.Title.Text = fmt.Sprintf("plot %d", i)
+			l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, float64(i)}})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			p.Add(l, plotter.NewGrid())
+			p.Legend.Add("line", l)
+			shared.Add(l)
+
+			for _, format := range []string{"png", "svg", "eps", "pdf"} {
+				c, err := p.WriterTo(2*vg.Inch, 2*vg.Inch, format)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					continue
+				}
+				if _, err := c.WriteTo(ioutil.Discard); err != nil {
+					t.Errorf("unexpected error writing %s: %v", format, err)
+				}
+			}
+			if _, err := shared.WriterTo(2*vg.Inch, 2*vg.Inch, "png"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestLinkAxes(t *testing.T) {
+	newPlot := func(xys plotter.XYs) *plot.Plot {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s, err := plotter.NewScatter(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(s)
+		return p
+	}
+	p1 := newPlot(plotter.XYs{{0, 0}, {10, 1}})
+	p2 := newPlot(plotter.XYs{{5, 0}, {20, 100}})
+	p1.X.Tick.Marker = plot.ConstantTicks{{0, "0"}, {20, "20"}}
+	l := plot.LinkX(p1, p2)
+
+	check := func(step string, min, max float64) {
+		for i, p := range []*plot.Plot{p1, p2} {
+			if p.X.Min != min || p.X.Max != max {
+				t.Errorf("unexpected X range of plot %d %s: got:[%v,%v] want:[%v,%v]", i+1, step, p.X.Min, p.X.Max, min, max)
+			}
+		}
+	}
+
+	l.Sync()
+	check("after linking", 0, 20)
+	if _, ok := p2.X.Tick.Marker.(plot.ConstantTicks); !ok {
+		t.Errorf("unexpected tick marker of linked axis: %T", p2.X.Tick.Marker)
+	}
+	if p1.Y.Max != 1 || p2.Y.Max != 100 {
+		t.Errorf("unlinked Y axes changed: got:%v %v want:1 100", p1.Y.Max, p2.Y.Max)
+	}
+
+	p1.X.Min, p1.X.Max = 2, 3
+	l.Sync()
+	check("after setting the range", 2, 3)
+
+	s, err := plotter.NewScatter(plotter.XYs{{-5, 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p2.Add(s)
+	p2.Draw(draw.NewCanvas(new(recorder.Canvas), 100, 100))
+	check("after adding data and drawing", -5, 3)
+}
+
+func TestDataCanvas(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	p.Title.Text = "Title"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	// dataArea returns the data area of the plot, as given
+	// by DataCanvas and by the ends of the drawn line.
+	dataArea := func() (want, got vg.Rectangle) {
+		var r recorder.Canvas
+		c := draw.NewCanvas(&r, 200, 200)
+		want = p.DataCanvas(c).Rectangle
+		p.Draw(c)
+		var last *recorder.Stroke
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.Stroke); ok {
+				last = s
+			}
+		}
+		if last == nil {
+			t.Fatal("no line drawn")
+		}
+		return want, vg.Rectangle{Min: last.Path[0].Pos, Max: last.Path[len(last.Path)-1].Pos}
+	}
+
+	want, got := dataArea()
+	if got != want {
+		t.Errorf("unexpected data area without margins: got:%v want:%v", got, want)
+	}
+	before := want
+
+	p.Margin.Left, p.Margin.Right = 10, 20
+	p.Margin.Bottom, p.Margin.Top = 30, 40
+	p.X.Label.Padding = 5
+	p.Y.Tick.LabelPadding = 7
+	want, got = dataArea()
+	if got != want {
+		t.Errorf("unexpected data area with margins: got:%v want:%v", got, want)
+	}
+	if want.Max.X != before.Max.X-20 || want.Max.Y != before.Max.Y-40 || want.Min.Y != before.Min.Y+35 {
+		t.Errorf("unexpected data area with margins: got:%v before:%v", want, before)
+	}
+}
+
+func TestTextBlocks(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	p.Title.Text = "Title"
+	p.Subtitle.Text = "Subtitle"
+	p.Caption.Text = "A caption long enough to be wrapped across the width of the plot."
+
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 200, 200)
+	da := p.DataCanvas(c).Rectangle
+	p.Draw(c)
+
+	strs := make(map[string]vg.Point)
+	var caption []string
+	var last *recorder.Stroke
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.FillString:
+			strs[a.String] = a.Point
+			if a.Size == vg.Points(8) {
+				caption = append(caption, a.String)
+			}
+		case *recorder.Stroke:
+			last = a
+		}
+	}
+	if last == nil {
+		t.Fatal("no line drawn")
+	}
+	got := vg.Rectangle{Min: last.Path[0].Pos, Max: last.Path[len(last.Path)-1].Pos}
+	if got != da {
+		t.Errorf("unexpected data area: got:%v want:%v", got, da)
+	}
+
+	title, ok := strs["Title"]
+	if !ok {
+		t.Fatal("no title drawn")
+	}
+	sub, ok := strs["Subtitle"]
+	if !ok {
+		t.Fatal("no subtitle drawn")
+	}
+	if !(sub.Y < title.Y && da.Max.Y < sub.Y) {
+		t.Errorf("subtitle not between title and data area: title:%v subtitle:%v data:%v", title, sub, da)
+	}
+	if len(caption) < 2 {
+		t.Fatalf("caption not wrapped: %q", caption)
+	}
+	if got := strings.Join(caption, " "); got != p.Caption.Text {
+		t.Errorf("unexpected caption: got:%q want:%q", got, p.Caption.Text)
+	}
+	for _, s := range caption {
+		if pt := strs[s]; pt.Y >= da.Min.Y || pt.X != 0 {
+			t.Errorf("caption line %q misplaced at %v", s, pt)
+		}
+	}
+}
+
+func TestLegendTitle(t *testing.T) {
+	for _, top := range []bool{true, false} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(l)
+		p.Legend.Add("entry", l)
+		p.Legend.Title.Text = "Speed"
+		p.Legend.Unit = "m/s"
+		p.Legend.Top = top
+
+		var r recorder.Canvas
+		p.Draw(draw.NewCanvas(&r, 200, 200))
+		strs := make(map[string]vg.Point)
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok {
+				strs[s.String] = s.Point
+			}
+		}
+		title, ok := strs["Speed (m/s)"]
+		if !ok {
+			t.Fatalf("no legend title drawn with Top=%t: %v", top, strs)
+		}
+		entry := strs["entry"]
+		if title.Y <= entry.Y {
+			t.Errorf("legend title not above entry with Top=%t: title:%v entry:%v", top, title, entry)
+		}
+	}
+
+	for _, test := range []struct {
+		title, unit, want string
+	}{
+		{title: "Speed", unit: "m/s", want: "Speed (m/s)"},
+		{title: "Speed", want: "Speed"},
+		{unit: "m/s", want: "(m/s)"},
+	} {
+		if got := plot.WithUnit(test.title, test.unit); got != test.want {
+			t.Errorf("unexpected title for %q and %q: got:%q want:%q", test.title, test.unit, got, test.want)
+		}
+	}
+}
+
+func TestLegendBox(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	p.Legend.Add("entry", l)
+	p.Legend.Top = true
+	p.Legend.Box = draw.BoxStyle{
+		Padding:    4,
+		Border:     draw.LineStyle{Color: color.Black, Width: 2},
+		Background: color.White,
+	}
+
+	var r recorder.Canvas
+	p.Draw(draw.NewCanvas(&r, 200, 200))
+	var box *recorder.Fill
+	var entry vg.Point
+	for _, a := range r.Actions {
+		switch a := a.(type) {
+		case *recorder.Fill:
+			box = a
+		case *recorder.FillString:
+			if a.String == "entry" {
+				entry = a.Point
+			}
+		}
+	}
+	if box == nil {
+		t.Fatal("no legend box drawn")
+	}
+	for _, comp := range box.Path {
+		// The border is centered on the path.
+		if comp.Pos.X > 200-1 || comp.Pos.Y > 200-1 {
+			t.Errorf("legend box extends beyond the plot: %v", comp.Pos)
+		}
+	}
+	if entry.X >= 200-5 || entry.Y >= 200-5 {
+		t.Errorf("legend entry not inset in its box: %v", entry)
+	}
+}
+
+func TestLayoutLog(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Title"
+	s, err := plotter.NewScatter(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	p.Legend.Add("points", s)
+	p.Legend.Top = true
+	p.Debug = &plot.LayoutLog{Boxes: true}
+
+	for i := 0; i < 2; i++ {
+		var r recorder.Canvas
+		p.Draw(draw.NewCanvas(&r, 200, 200))
+
+		var parts []string
+		for _, e := range p.Debug.Events {
+			parts = append(parts, e.Part)
+		}
+		// The point at the top right is overlapped
+		// by the legend at the top right.
+		want := []string{"plot", "margin", "title", "x-axis", "y-axis", "data", "legend", "plotter-0"}
+		if !reflect.DeepEqual(parts, want) {
+			t.Errorf("unexpected layout parts on drawing %d: got:%v want:%v", i, parts, want)
+		}
+		if last := p.Debug.Events[len(p.Debug.Events)-1]; last.Note != "glyph box overlapped by the legend" {
+			t.Errorf("unexpected note: got:%q", last.Note)
+		}
+		if n := strings.Count(p.Debug.String(), "\n"); n != len(want) {
+			t.Errorf("unexpected number of log lines: got:%d want:%d", n, len(want))
+		}
+
+		var labels []string
+		for _, a := range r.Actions {
+			if a, ok := a.(*recorder.FillString); ok && a.Font == "Times-Roman" && a.Size == vg.Points(6) {
+				labels = append(labels, a.String)
+			}
+		}
+		if !reflect.DeepEqual(labels, want) {
+			t.Errorf("unexpected layout box labels: got:%v want:%v", labels, want)
+		}
+	}
+}
+
+// sized is a plotter of a number of points
+// recording the numbers of points drawn.
+type sized struct {
+	points int
+	drawn  *[]int
+	delay  time.Duration
+}
+
+func (s sized) Plot(draw.Canvas, *plot.Plot) {
+	time.Sleep(s.delay)
+	*s.drawn = append(*s.drawn, s.points)
+}
+
+func (s sized) Size() (points, cells int) { return s.points, 0 }
+
+func (s sized) Downsample(points, cells int) plot.Plotter {
+	s.points = points
+	return s
+}
+
+func TestLimits(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var drawn []int
+	p.Add(sized{points: 10, drawn: &drawn}, sized{points: 1000, drawn: &drawn})
+	p.Limits.MaxPoints = 100
+
+	err = p.DrawLimited(draw.NewCanvas(new(recorder.Canvas), 100, 100))
+	if e, ok := err.(*plot.LimitError); !ok || e.Plotter != 1 {
+		t.Errorf("unexpected error: got:%v want plotter 1 not drawn", err)
+	}
+	if want := []int{10}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("unexpected points drawn: got:%v want:%v", drawn, want)
+	}
+	if _, err := p.WriterTo(100, 100, "png"); err == nil {
+		t.Error("expected error from WriterTo")
+	}
+
+	drawn = nil
+	p.Limits.Downsample = true
+	if err := p.DrawLimited(draw.NewCanvas(new(recorder.Canvas), 100, 100)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if want := []int{10, 100}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("unexpected points drawn: got:%v want:%v", drawn, want)
+	}
+
+	p, err = plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	drawn = nil
+	p.Add(sized{points: 1, drawn: &drawn, delay: 20 * time.Millisecond}, sized{points: 2, drawn: &drawn})
+	p.Limits.MaxTime = 10 * time.Millisecond
+	err = p.DrawLimited(draw.NewCanvas(new(recorder.Canvas), 100, 100))
+	if e, ok := err.(*plot.LimitError); !ok || e.Plotter != 1 {
+		t.Errorf("unexpected error: got:%v want plotter 1 not drawn", err)
+	}
+	if want := []int{1}; !reflect.DeepEqual(drawn, want) {
+		t.Errorf("unexpected points drawn: got:%v want:%v", drawn, want)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	// Points are appended while the plot is drawn,
+	// which is most useful with -race.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 2; i <= 20; i++ {
+			var err error
+			p.Update(func() {
+				err = l.Append(plotter.XYs{{X: float64(i), Y: float64(i * i)}})
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		if _, err := p.WriterTo(2*vg.Inch, 2*vg.Inch, "png"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	<-done
+
+	if len(l.XYs) != 21 {
+		t.Errorf("unexpected number of points: got:%d want:21", len(l.XYs))
+	}
+	if p.X.Min != 0 || p.X.Max != 20 || p.Y.Min != 0 || p.Y.Max != 400 {
+		t.Errorf("unexpected axis ranges: got:[%v %v]×[%v %v] want:[0 20]×[0 400]",
+			p.X.Min, p.X.Max, p.Y.Min, p.Y.Max)
+	}
+}
+
+func TestLegendColumns(t *testing.T) {
+	for _, test := range []struct {
+		columns    int
+		maxHeight  vg.Length
+		maxEntries int
+		wantTexts  []string
+		wantCols   int
+	}{
+		{wantTexts: []string{"a", "b", "c", "d", "e"}, wantCols: 1},
+		{columns: 2, wantTexts: []string{"a", "b", "c", "d", "e"}, wantCols: 2},
+		{maxHeight: 25, wantTexts: []string{"a", "b", "c", "d", "e"}, wantCols: 3},
+		{maxEntries: 3, wantTexts: []string{"a", "b", "+3 more"}, wantCols: 1},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.HideAxes()
+		for _, n := range []string{"a", "b", "c", "d", "e"} {
+			l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			p.Legend.Add(n, l)
+		}
+		p.Legend.Left = true
+		p.Legend.Columns = test.columns
+		p.Legend.MaxHeight = test.maxHeight
+		p.Legend.MaxEntries = test.maxEntries
+
+		var r recorder.Canvas
+		p.Draw(draw.NewCanvas(&r, 200, 200))
+		var texts []string
+		xs := make(map[vg.Length]bool)
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok {
+				texts = append(texts, s.String)
+				xs[s.Point.X] = true
+			}
+		}
+		if !reflect.DeepEqual(texts, test.wantTexts) {
+			t.Errorf("unexpected entries: got:%q want:%q", texts, test.wantTexts)
+		}
+		if len(xs) != test.wantCols {
+			t.Errorf("unexpected number of columns for %+v: got:%d want:%d", test, len(xs), test.wantCols)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plothttp serves live plots over HTTP, such as the
+// progress charts of long-running jobs. The plots are drawn
+// afresh for each request of their images, and the page that
+// shows them reloads an image when its plot is updated, using
+// server-sent events.
+//
+// A job may serve its plot and update it as it runs:
+//
+//	s, err := plothttp.Serve(":8080", p)
+//	...
+//	s.Update(p, func() { err = line.Append(points) })
+package plothttp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// contentTypes holds the content types
+// of the formats of the plot images.
+var contentTypes = map[string]string{
+	"png":  "image/png",
+	"jpg":  "image/jpeg",
+	"jpeg": "image/jpeg",
+	"svg":  "image/svg+xml",
+	"pdf":  "application/pdf",
+}
+
+// Server is an http.Handler serving a page showing its plots,
+// the images of the plots, and the events of their updates.
+// The page is served at the root path, the image of the plot
+// i at "plot/i", and the events at "events", relative to the
+// path at which the server is mounted.
+type Server struct {
+	// Width and Height are the size of
+	// the images of the plots.
+	Width, Height vg.Length
+
+	// Format is the format of the images of the plots,
+	// one of "png", "jpg", "svg" or "pdf".
+	Format string
+
+	// Title is the title of the page.
+	Title string
+
+	// The fields above must not be changed
+	// while the server is serving requests.
+
+	mu      sync.Mutex
+	plots   []*plot.Plot
+	clients map[chan int]bool
+}
+
+// NewServer returns a server of the given plots, with
+// PNG images of 6 by 4 inches.
+func NewServer(ps ...*plot.Plot) *Server {
+	return &Server{
+		Width:   6 * vg.Inch,
+		Height:  4 * vg.Inch,
+		Format:  "png",
+		Title:   "Plots",
+		plots:   append([]*plot.Plot(nil), ps...),
+		clients: make(map[chan int]bool),
+	}
+}
+
+// Serve listens on the TCP network address addr and serves
+// the given plots in a new goroutine, returning the server
+// for their updates. It returns an error if the address
+// cannot be listened on.
+func Serve(addr string, ps ...*plot.Plot) (*Server, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := NewServer(ps...)
+	go http.Serve(l, s)
+	return s, nil
+}
+
+// Add adds plots to the server. Pages
+// loaded before they are added do not
+// show them.
+func (s *Server) Add(ps ...*plot.Plot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plots = append(s.plots, ps...)
+}
+
+// Update calls the Update method of the plot p with f, and
+// then notifies the pages showing p to reload its image. It
+// returns an error if p has not been added to the server.
+func (s *Server) Update(p *plot.Plot, f func()) error {
+	i := s.index(p)
+	if i < 0 {
+		return errors.New("plothttp: plot not served")
+	}
+	p.Update(f)
+	s.notify(i)
+	return nil
+}
+
+// index returns the index of
+// p, or -1 if it is not served.
+func (s *Server) index(p *plot.Plot) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, q := range s.plots {
+		if q == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// plot returns the plot i, or nil if there is none.
+func (s *Server) plot(i int) *plot.Plot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i < 0 || i >= len(s.plots) {
+		return nil
+	}
+	return s.plots[i]
+}
+
+// notify sends the index i to the clients of the events.
+// Clients that are not keeping up miss the event, but
+// still reload the image on the next event of the plot.
+func (s *Server) notify(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		select {
+		case c <- i:
+		default:
+		}
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case path == "":
+		s.servePage(w)
+	case path == "events":
+		s.serveEvents(w)
+	case strings.HasPrefix(path, "plot/"):
+		i, err := strconv.Atoi(strings.TrimPrefix(path, "plot/"))
+		p := s.plot(i)
+		if err != nil || p == nil {
+			http.NotFound(w, r)
+			return
+		}
+		s.servePlot(w, p)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// servePlot serves the image of the plot p.
+func (s *Server) servePlot(w http.ResponseWriter, p *plot.Plot) {
+	format := strings.ToLower(strings.TrimPrefix(s.Format, "."))
+	c, err := p.WriterTo(s.Width, s.Height, format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// The image is written to a buffer, so that an error
+	// writing it is reported rather than a partial image.
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if typ, ok := contentTypes[format]; ok {
+		w.Header().Set("Content-Type", typ)
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	buf.WriteTo(w)
+}
+
+// serveEvents serves the indices of the updated
+// plots as server-sent events, until the client
+// closes the connection.
+func (s *Server) serveEvents(w http.ResponseWriter) {
+	f, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	var closed <-chan bool
+	if cn, ok := w.(http.CloseNotifier); ok {
+		closed = cn.CloseNotify()
+	}
+
+	c := make(chan int, 16)
+	s.mu.Lock()
+	s.clients[c] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	f.Flush()
+	for {
+		select {
+		case i := <-c:
+			if _, err := fmt.Fprintf(w, "data: %d\n\n", i); err != nil {
+				return
+			}
+			f.Flush()
+		case <-closed:
+			return
+		}
+	}
+}
+
+// pageScript reloads the image of a plot on each
+// event of its update.
+const pageScript = `new EventSource("events").onmessage = function(e) {
+	var img = document.getElementById("plot" + e.data);
+	if (img) {
+		img.src = "plot/" + e.data + "?" + Date.now();
+	}
+};`
+
+// servePage serves the page showing the plots.
+func (s *Server) servePage(w http.ResponseWriter) {
+	s.mu.Lock()
+	n := len(s.plots)
+	s.mu.Unlock()
+
+	var buf bytes.Buffer
+	title := html.EscapeString(s.Title)
+	fmt.Fprintf(&buf, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n<h1>%s</h1>\n", title, title)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, "<img id=\"plot%d\" src=\"plot/%d\" alt=\"plot %d\">\n", i, i, i)
+	}
+	fmt.Fprintf(&buf, "<script>\n%s\n</script>\n</body>\n</html>\n", pageScript)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	buf.WriteTo(w)
+}
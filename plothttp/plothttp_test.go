@@ -0,0 +1,132 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plothttp
+
+import (
+	"bufio"
+	"image/png"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+)
+
+func ExampleServe() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Loss"
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 1}})
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(l)
+
+	s, err := Serve("localhost:8080", p)
+	if err != nil {
+		log.Panic(err)
+	}
+	for i := 1; i <= 100; i++ {
+		// Each step of the job appends a point to
+		// the line, and the page showing the plot
+		// reloads it.
+		s.Update(p, func() {
+			err = l.Append(plotter.XYs{{X: float64(i), Y: 1 / float64(i)}})
+		})
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	s := NewServer(p)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(page), `src="plot/0"`) {
+		t.Errorf("page does not show the plot:\n%s", page)
+	}
+
+	resp, err = http.Get(ts.URL + "/plot/0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if typ := resp.Header.Get("Content-Type"); typ != "image/png" {
+		t.Errorf("unexpected content type: got:%q want:%q", typ, "image/png")
+	}
+	if _, err := png.Decode(resp.Body); err != nil {
+		t.Errorf("unexpected error decoding image: %v", err)
+	}
+	resp.Body.Close()
+
+	for _, path := range []string{"/plot/1", "/plot/x", "/other"} {
+		resp, err = http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("unexpected status for %s: got:%d want:%d", path, resp.StatusCode, http.StatusNotFound)
+		}
+	}
+
+	resp, err = http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if typ := resp.Header.Get("Content-Type"); typ != "text/event-stream" {
+		t.Errorf("unexpected content type: got:%q want:%q", typ, "text/event-stream")
+	}
+	err = s.Update(p, func() {
+		err = l.Append(plotter.XYs{{X: 2, Y: 4}})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line != "data: 0\n" {
+		t.Errorf("unexpected event: got:%q want:%q", line, "data: 0\n")
+	}
+	if p.X.Max != 2 || p.Y.Max != 4 {
+		t.Errorf("axes not extended by update: got max:(%v, %v) want:(2, 4)", p.X.Max, p.Y.Max)
+	}
+
+	other, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Update(other, func() {}); err == nil {
+		t.Error("expected error for plot not served")
+	}
+}
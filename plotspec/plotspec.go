@@ -0,0 +1,451 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plotspec describes the structure of plots as specs:
+// the axes, the plotters with their data and styles, and the
+// legend of a plot, as values that may be encoded as JSON with
+// the encoding/json package, and from which the plots may be
+// built again. A spec may so be edited and drawn again, or kept
+// as the golden spec of a regression test.
+//
+// The data of a plotter may be held in the spec, or referenced
+// by a name under which it is given when the plot is built, so
+// that large or changing data are kept out of the spec. Specs
+// describe lines, scatters, grids and heat maps. Other fields
+// of the plots and plotters take their defaults when they are
+// built.
+package plotspec
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"reflect"
+	"strconv"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// The types of the plotters of a spec.
+const (
+	LineType    = "line"
+	ScatterType = "scatter"
+	GridType    = "grid"
+	HeatMapType = "heatmap"
+)
+
+// Plot is the spec of a plot.
+type Plot struct {
+	Title    string    `json:"title,omitempty"`
+	X        Axis      `json:"x"`
+	Y        Axis      `json:"y"`
+	Legend   Legend    `json:"legend"`
+	Plotters []Plotter `json:"plotters"`
+}
+
+// Axis is the spec of an axis of a plot.
+type Axis struct {
+	Label string `json:"label,omitempty"`
+
+	// Min and Max are the range of the axis.
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+
+	// Scale is "log" for an axis with a logarithmic
+	// scale and tick marks, "logit" for an axis with
+	// a logit scale and tick marks, or empty for a
+	// linear one.
+	Scale string `json:"scale,omitempty"`
+}
+
+// Legend is the spec of the location of the legend
+// of a plot, as given by the Top and Left fields of
+// plot.Legend. The entries of the legend are given
+// by the Legend fields of the plotters.
+type Legend struct {
+	Top  bool `json:"top,omitempty"`
+	Left bool `json:"left,omitempty"`
+}
+
+// Plotter is the spec of a plotter of a plot.
+type Plotter struct {
+	// Type is the type of the plotter,
+	// such as LineType or ScatterType.
+	Type string `json:"type"`
+
+	// Legend, if not empty, is the name of
+	// the entry of the plotter in the legend.
+	Legend string `json:"legend,omitempty"`
+
+	// Data, if not empty, is the name of the data of
+	// the plotter, which are given when the plot is
+	// built: the points of a line or a scatter, or the
+	// grid of a heat map. The points of a plotter with
+	// no named data are held by XYs.
+	Data string      `json:"data,omitempty"`
+	XYs  plotter.XYs `json:"xys,omitempty"`
+
+	// Line is the style of a line, or of the
+	// horizontal lines of a grid, and of its
+	// vertical lines if Vertical is nil.
+	Line *LineStyle `json:"line,omitempty"`
+
+	// Vertical is the style of the vertical
+	// lines of a grid.
+	Vertical *LineStyle `json:"vertical,omitempty"`
+
+	// Glyph is the style of the glyphs of a
+	// scatter, or of the points of a line.
+	Glyph *GlyphStyle `json:"glyph,omitempty"`
+
+	// Palette holds the colors of a heat map, and Min
+	// and Max the range of the values that they span.
+	Palette []string `json:"palette,omitempty"`
+	Min     float64  `json:"min,omitempty"`
+	Max     float64  `json:"max,omitempty"`
+}
+
+// LineStyle is the spec of a draw.LineStyle.
+// Lengths are in points and colors are as
+// given by Color.
+type LineStyle struct {
+	Color  string    `json:"color,omitempty"`
+	Width  float64   `json:"width"`
+	Dashes []float64 `json:"dashes,omitempty"`
+}
+
+// GlyphStyle is the spec of a draw.GlyphStyle.
+// The Shape is one of "circle", "ring", "square",
+// "box", "triangle", "pyramid", "plus" or "cross".
+type GlyphStyle struct {
+	Color  string  `json:"color,omitempty"`
+	Radius float64 `json:"radius"`
+	Shape  string  `json:"shape"`
+}
+
+// glyphShapes holds the glyph drawers
+// of the shapes of the glyph specs.
+var glyphShapes = map[string]draw.GlyphDrawer{
+	"circle":   draw.CircleGlyph{},
+	"ring":     draw.RingGlyph{},
+	"square":   draw.SquareGlyph{},
+	"box":      draw.BoxGlyph{},
+	"triangle": draw.TriangleGlyph{},
+	"pyramid":  draw.PyramidGlyph{},
+	"plus":     draw.PlusGlyph{},
+	"cross":    draw.CrossGlyph{},
+}
+
+// Color returns the spec of a color, "#rrggbb" for
+// an opaque color or "#rrggbbaa" for a translucent
+// one, or the empty string for a nil color.
+func Color(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
+
+// ParseColor returns the color of a spec returned
+// by Color, or an error if s is not such a spec.
+func ParseColor(s string) (color.Color, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if s[0] != '#' || (len(s) != 7 && len(s) != 9) {
+		return nil, fmt.Errorf("plotspec: invalid color %q", s)
+	}
+	v, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("plotspec: invalid color %q", s)
+	}
+	if len(s) == 7 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// FromPlot returns the spec of the plot p. The data of the
+// plotters in names are referenced by the given names, and
+// those of the other lines and scatters are held by the spec.
+// An error is returned if p has a plotter of a type that
+// specs do not describe, a heat map with no name for its
+// data, or a style that cannot be described.
+func FromPlot(p *plot.Plot, names map[plot.Plotter]string) (*Plot, error) {
+	s := &Plot{
+		Title:  p.Title.Text,
+		Legend: Legend{Top: p.Legend.Top, Left: p.Legend.Left},
+	}
+	var err error
+	s.X, err = axisSpec(&p.X)
+	if err != nil {
+		return nil, err
+	}
+	s.Y, err = axisSpec(&p.Y)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range p.Plotters() {
+		ps := Plotter{Data: names[d]}
+		if t, ok := d.(plot.Thumbnailer); ok {
+			ps.Legend, _ = p.Legend.EntryText(t)
+		}
+		switch d := d.(type) {
+		case *plotter.Line:
+			ps.Type = LineType
+			if ps.Data == "" {
+				ps.XYs = d.XYs
+			}
+			ps.Line = lineSpec(d.LineStyle)
+			if d.Points != nil {
+				ps.Glyph, err = glyphSpec(*d.Points)
+			}
+		case *plotter.Scatter:
+			ps.Type = ScatterType
+			if ps.Data == "" {
+				ps.XYs = d.XYs
+			}
+			ps.Glyph, err = glyphSpec(d.GlyphStyle)
+		case *plotter.Grid:
+			ps.Type = GridType
+			ps.Line = lineSpec(d.Horizontal)
+			ps.Vertical = lineSpec(d.Vertical)
+		case *plotter.HeatMap:
+			ps.Type = HeatMapType
+			if ps.Data == "" {
+				return nil, errors.New("plotspec: heat map data not named")
+			}
+			for _, c := range d.Palette.Colors() {
+				ps.Palette = append(ps.Palette, Color(c))
+			}
+			ps.Min, ps.Max = d.Min, d.Max
+		default:
+			return nil, fmt.Errorf("plotspec: unsupported plotter type %T", d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.Plotters = append(s.Plotters, ps)
+	}
+	return s, nil
+}
+
+// axisSpec returns the spec of the axis a.
+func axisSpec(a *plot.Axis) (Axis, error) {
+	s := Axis{Label: a.Label.Text, Min: a.Min, Max: a.Max}
+	switch a.Scale.(type) {
+	case plot.LinearScale:
+	case plot.LogScale:
+		s.Scale = "log"
+	case plot.LogitScale:
+		s.Scale = "logit"
+	default:
+		return Axis{}, fmt.Errorf("plotspec: unsupported axis scale %T", a.Scale)
+	}
+	return s, nil
+}
+
+// lineSpec returns the spec of the line style sty.
+func lineSpec(sty draw.LineStyle) *LineStyle {
+	s := &LineStyle{Color: Color(sty.Color), Width: sty.Width.Points()}
+	for _, d := range sty.Dashes {
+		s.Dashes = append(s.Dashes, d.Points())
+	}
+	return s
+}
+
+// glyphSpec returns the spec of the glyph style sty.
+func glyphSpec(sty draw.GlyphStyle) (*GlyphStyle, error) {
+	for name, shape := range glyphShapes {
+		if reflect.TypeOf(shape) == reflect.TypeOf(sty.Shape) {
+			return &GlyphStyle{Color: Color(sty.Color), Radius: sty.Radius.Points(), Shape: name}, nil
+		}
+	}
+	return nil, fmt.Errorf("plotspec: unsupported glyph shape %T", sty.Shape)
+}
+
+// Build returns the plot of the spec, whose named data are
+// given by data: a plotter.XYer for a line or a scatter, or
+// a plotter.GridXYZ for a heat map. An error is returned if
+// the spec is invalid or data do not hold its named data.
+func (s *Plot) Build(data map[string]interface{}) (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.Title.Text = s.Title
+	p.Legend.Top, p.Legend.Left = s.Legend.Top, s.Legend.Left
+	for _, ps := range s.Plotters {
+		d, err := ps.build(data)
+		if err != nil {
+			return nil, err
+		}
+		p.Add(d)
+		if t, ok := d.(plot.Thumbnailer); ok && ps.Legend != "" {
+			p.Legend.Add(ps.Legend, t)
+		}
+	}
+	// The ranges of the axes are set after the plotters
+	// are added, so that they are not extended.
+	for _, a := range []struct {
+		spec Axis
+		axis *plot.Axis
+	}{{s.X, &p.X}, {s.Y, &p.Y}} {
+		a.axis.Label.Text = a.spec.Label
+		a.axis.Min, a.axis.Max = a.spec.Min, a.spec.Max
+		switch a.spec.Scale {
+		case "":
+		case "log":
+			a.axis.Scale = plot.LogScale{}
+			a.axis.Tick.Marker = plot.LogTicks{}
+		case "logit":
+			a.axis.Scale = plot.LogitScale{}
+			a.axis.Tick.Marker = plot.LogitTicks{}
+		default:
+			return nil, fmt.Errorf("plotspec: unknown axis scale %q", a.spec.Scale)
+		}
+	}
+	return p, nil
+}
+
+// build returns the plotter of the spec.
+func (s *Plotter) build(data map[string]interface{}) (plot.Plotter, error) {
+	var d interface{}
+	if s.Data != "" {
+		var ok bool
+		d, ok = data[s.Data]
+		if !ok {
+			return nil, fmt.Errorf("plotspec: no data named %q", s.Data)
+		}
+	}
+	xys := func() (plotter.XYer, error) {
+		if d == nil {
+			return s.XYs, nil
+		}
+		xys, ok := d.(plotter.XYer)
+		if !ok {
+			return nil, fmt.Errorf("plotspec: data %q is not a plotter.XYer", s.Data)
+		}
+		return xys, nil
+	}
+
+	switch s.Type {
+	case LineType:
+		xys, err := xys()
+		if err != nil {
+			return nil, err
+		}
+		l, err := plotter.NewLine(xys)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Line.apply(&l.LineStyle); err != nil {
+			return nil, err
+		}
+		if s.Glyph != nil {
+			l.Points = &draw.GlyphStyle{}
+			if err := s.Glyph.apply(l.Points); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+
+	case ScatterType:
+		xys, err := xys()
+		if err != nil {
+			return nil, err
+		}
+		sc, err := plotter.NewScatter(xys)
+		if err != nil {
+			return nil, err
+		}
+		return sc, s.Glyph.apply(&sc.GlyphStyle)
+
+	case GridType:
+		g := plotter.NewGrid()
+		if err := s.Line.apply(&g.Horizontal); err != nil {
+			return nil, err
+		}
+		if s.Vertical == nil {
+			g.Vertical = g.Horizontal
+			return g, nil
+		}
+		return g, s.Vertical.apply(&g.Vertical)
+
+	case HeatMapType:
+		grid, ok := d.(plotter.GridXYZ)
+		if !ok {
+			return nil, fmt.Errorf("plotspec: data %q is not a plotter.GridXYZ", s.Data)
+		}
+		var p colors
+		for _, c := range s.Palette {
+			clr, err := ParseColor(c)
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, clr)
+		}
+		if len(p) == 0 {
+			return nil, errors.New("plotspec: heat map with no palette")
+		}
+		h := plotter.NewHeatMap(grid, p)
+		h.Min, h.Max = s.Min, s.Max
+		return h, nil
+
+	default:
+		return nil, fmt.Errorf("plotspec: unknown plotter type %q", s.Type)
+	}
+}
+
+// apply sets the fields of sty given by the spec,
+// leaving sty unchanged if the spec is nil.
+func (s *LineStyle) apply(sty *draw.LineStyle) error {
+	if s == nil {
+		return nil
+	}
+	clr, err := ParseColor(s.Color)
+	if err != nil {
+		return err
+	}
+	sty.Color = clr
+	sty.Width = vg.Points(s.Width)
+	sty.Dashes = make([]vg.Length, len(s.Dashes))
+	for i, d := range s.Dashes {
+		sty.Dashes[i] = vg.Points(d)
+	}
+	return nil
+}
+
+// apply sets the fields of sty given by the spec,
+// leaving sty unchanged if the spec is nil.
+func (s *GlyphStyle) apply(sty *draw.GlyphStyle) error {
+	if s == nil {
+		return nil
+	}
+	clr, err := ParseColor(s.Color)
+	if err != nil {
+		return err
+	}
+	shape, ok := glyphShapes[s.Shape]
+	if !ok {
+		return fmt.Errorf("plotspec: unknown glyph shape %q", s.Shape)
+	}
+	sty.Color = clr
+	sty.Radius = vg.Points(s.Radius)
+	sty.Shape = shape
+	return nil
+}
+
+// colors is a palette of the colors
+// of the spec of a heat map.
+type colors []color.Color
+
+func (p colors) Colors() []color.Color { return p }
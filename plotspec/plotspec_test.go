@@ -0,0 +1,150 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+type grid struct{ mat64.Matrix }
+
+func (g grid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g grid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g grid) X(c int) float64    { return float64(c) }
+func (g grid) Y(r int) float64    { return float64(r) }
+
+func TestRoundTrip(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Spec"
+	p.X.Label.Text = "x"
+	p.Legend.Top = true
+
+	g := grid{mat64.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})}
+	h := plotter.NewHeatMap(g, palette.Heat(4, 1))
+	l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 2}, {X: 2, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Color = color.RGBA{R: 255, A: 255}
+	l.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	l.Points = &draw.GlyphStyle{Color: color.NRGBA{B: 255, A: 128}, Radius: vg.Points(2), Shape: draw.CircleGlyph{}}
+	pts := plotter.XYs{{X: 0.5, Y: 1.5}, {X: 1.5, Y: 0.5}}
+	s, err := plotter.NewScatter(pts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.Shape = draw.CrossGlyph{}
+	p.Add(h, plotter.NewGrid(), l, s)
+	p.Legend.Add("line", l)
+	p.Legend.Add("points", s)
+
+	names := map[plot.Plotter]string{h: "field", s: "samples"}
+	spec, err := FromPlot(p, names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := spec.Plotters[2]; got.Legend != "line" || got.Data != "" || len(got.XYs) != 3 {
+		t.Errorf("unexpected line spec: %+v", got)
+	}
+	if got := spec.Plotters[3]; got.Data != "samples" || got.XYs != nil || got.Glyph.Shape != "cross" {
+		t.Errorf("unexpected scatter spec: %+v", got)
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var reloaded Plot
+	if err := json.Unmarshal(b, &reloaded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(&reloaded, spec) {
+		t.Errorf("spec changed by JSON round trip:\ngot: %+v\nwant:%+v", &reloaded, spec)
+	}
+
+	built, err := reloaded.Build(map[string]interface{}{"field": g, "samples": pts})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := FromPlot(built, map[plot.Plotter]string{
+		built.Plotters()[0]: "field",
+		built.Plotters()[3]: "samples",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Errorf("spec changed by building:\ngot: %+v\nwant:%+v", got, spec)
+	}
+
+	// The built plot is drawn as the original.
+	var want, have bytes.Buffer
+	for _, d := range []struct {
+		p   *plot.Plot
+		buf *bytes.Buffer
+	}{{p, &want}, {built, &have}} {
+		c, err := d.p.WriterTo(3*vg.Inch, 3*vg.Inch, "png")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := c.WriteTo(d.buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !bytes.Equal(have.Bytes(), want.Bytes()) {
+		t.Error("built plot not drawn as the original")
+	}
+
+	if _, err := FromPlot(p, nil); err == nil {
+		t.Error("expected error for unnamed heat map data")
+	}
+	if _, err := reloaded.Build(nil); err == nil {
+		t.Error("expected error for missing data")
+	}
+	if _, err := reloaded.Build(map[string]interface{}{"field": pts, "samples": pts}); err == nil {
+		t.Error("expected error for mismatched data")
+	}
+}
+
+func TestColor(t *testing.T) {
+	for _, test := range []struct {
+		c    color.Color
+		spec string
+	}{
+		{c: nil, spec: ""},
+		{c: color.NRGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}, spec: "#123456"},
+		{c: color.NRGBA{R: 0xab, G: 0xcd, B: 0xef, A: 0x80}, spec: "#abcdef80"},
+	} {
+		if got := Color(test.c); got != test.spec {
+			t.Errorf("unexpected spec of %v: got:%q want:%q", test.c, got, test.spec)
+		}
+		c, err := ParseColor(test.spec)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if c != test.c {
+			t.Errorf("unexpected color of %q: got:%v want:%v", test.spec, c, test.c)
+		}
+	}
+	for _, bad := range []string{"123456", "#12345", "#12345g"} {
+		if _, err := ParseColor(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import "math"
+
+// Append appends copies of the points of xys to the line,
+// such as the new points of a live plot, returning an error
+// if any of them is NaN or infinite, in which case none of
+// them are appended. If Values is not nil, it is extended
+// with NaN values for the new points, which may be set
+// after they are appended.
+//
+// The points of a line that has been added to a plot should
+// be appended within the Update method of the plot, so that
+// the line is not drawn while they are being appended.
+func (pts *Line) Append(xys XYer) error {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return err
+	}
+	pts.XYs = append(pts.XYs, data...)
+	if pts.Values != nil {
+		for range data {
+			pts.Values = append(pts.Values, math.NaN())
+		}
+	}
+	return nil
+}
+
+// Append appends copies of the points of xys to the scatter,
+// as Append of a Line does.
+func (pts *Scatter) Append(xys XYer) error {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return err
+	}
+	pts.XYs = append(pts.XYs, data...)
+	return nil
+}
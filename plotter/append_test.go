@@ -0,0 +1,47 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	l, err := NewLine(XYs{{X: 0, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Values = []float64{1}
+	pts := XYs{{X: 1, Y: 2}, {X: 2, Y: 3}}
+	if err := l.Append(pts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pts[0].Y = 10
+	if want := (XYs{{X: 0, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 3}}); !reflect.DeepEqual(l.XYs, want) {
+		t.Errorf("unexpected line points: got:%v want:%v", l.XYs, want)
+	}
+	if len(l.Values) != 3 || l.Values[0] != 1 || !math.IsNaN(l.Values[1]) || !math.IsNaN(l.Values[2]) {
+		t.Errorf("unexpected line values: got:%v want:[1 NaN NaN]", l.Values)
+	}
+	if err := l.Append(XYs{{X: 3, Y: math.Inf(1)}}); err == nil {
+		t.Error("expected error for infinite point")
+	}
+	if len(l.XYs) != 3 {
+		t.Errorf("points appended despite error: got:%d want:3", len(l.XYs))
+	}
+
+	s, err := NewScatter(XYs{{X: 0, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Append(XYs{{X: 1, Y: 2}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (XYs{{X: 0, Y: 1}, {X: 1, Y: 2}}); !reflect.DeepEqual(s.XYs, want) {
+		t.Errorf("unexpected scatter points: got:%v want:%v", s.XYs, want)
+	}
+}
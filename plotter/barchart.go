@@ -45,6 +45,23 @@ type BarChart struct {
 	// locations and distances.
 	Horizontal bool
 
+	// Truncation is the style of the break marks drawn
+	// across the bars when the value axis of the plot does
+	// not include zero, so that the bars, whose lengths are
+	// then not proportional to their values, are seen to be
+	// truncated. The marks are drawn across the bars at the
+	// edge of the plot where they are cut off, and the gap
+	// between them is filled with the background color of
+	// the plot. If the Color of Truncation is nil, no marks
+	// are drawn.
+	Truncation draw.LineStyle
+
+	// TruncationWarning, if not nil, is called with the
+	// range of the value axis each time the bars are drawn
+	// truncated, to warn of, or to forbid, charts whose
+	// bars are misleading.
+	TruncationWarning func(min, max float64)
+
 	// stackedOn is the bar chart upon which
 	// this bar chart is stacked.
 	stackedOn *BarChart
@@ -62,10 +79,11 @@ func NewBarChart(vs Valuer, width vg.Length) (*BarChart, error) {
 		return nil, err
 	}
 	return &BarChart{
-		Values:    values,
-		Width:     width,
-		Color:     color.Black,
-		LineStyle: DefaultLineStyle,
+		Values:     values,
+		Width:      width,
+		Color:      color.Black,
+		LineStyle:  DefaultLineStyle,
+		Truncation: DefaultLineStyle,
 	}, nil
 }
 
@@ -98,9 +116,14 @@ func (b *BarChart) StackOn(on *BarChart) {
 // Plot implements the plot.Plotter interface.
 func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 	trCat, trVal := plt.Transforms(&c)
+	valLo, valHi := c.Min.Y, c.Max.Y
+	valAxis := plt.Y
 	if b.Horizontal {
 		trCat, trVal = trVal, trCat
+		valLo, valHi = c.Min.X, c.Max.X
+		valAxis = plt.X
 	}
+	truncated := false
 
 	for i, ht := range b.Values {
 		catVal := b.XMin + float64(i)
@@ -150,7 +173,62 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 			outline = c.ClipLinesX(pts)
 		}
 		c.StrokeLines(b.LineStyle, outline...)
+
+		// Only the bars of the bottom chart of a
+		// stack start at zero.
+		if b.stackedOn != nil {
+			continue
+		}
+		switch top := bottom + ht; {
+		case bottom < valAxis.Min && valAxis.Min < top:
+			truncated = true
+			b.drawTruncation(c, plt, catMin, catMax, valLo, 1)
+		case top < valAxis.Max && valAxis.Max < bottom:
+			truncated = true
+			b.drawTruncation(c, plt, catMin, catMax, valHi, -1)
+		}
+	}
+	if truncated && b.TruncationWarning != nil {
+		b.TruncationWarning(valAxis.Min, valAxis.Max)
+	}
+}
+
+const (
+	// truncationInset is the distance of the break
+	// marks of a truncated bar from the edge of the
+	// plot at which the bar is cut off.
+	truncationInset = vg.Length(6)
+
+	// truncationGap is the distance between the
+	// two break marks of a truncated bar.
+	truncationGap = vg.Length(3)
+)
+
+// drawTruncation draws the break marks of a bar from
+// catMin to catMax, cut off at the value edge, dir being
+// 1 if the bar extends up from the edge and -1 if it
+// extends down.
+func (b *BarChart) drawTruncation(c draw.Canvas, plt *plot.Plot, catMin, catMax, edge, dir vg.Length) {
+	if b.Truncation.Color == nil {
+		return
+	}
+	pt := func(cat, val vg.Length) vg.Point {
+		if b.Horizontal {
+			return vg.Point{X: val, Y: cat}
+		}
+		return vg.Point{X: cat, Y: val}
+	}
+	ext := b.Truncation.Width + truncationGap/2
+	slant := (catMax - catMin) / 6
+	lo, hi := catMin-ext, catMax+ext
+	m := edge + dir*truncationInset
+	n := m + dir*truncationGap
+	first := []vg.Point{pt(lo, m-slant), pt(hi, m+slant)}
+	second := []vg.Point{pt(lo, n-slant), pt(hi, n+slant)}
+	if plt.BackgroundColor != nil {
+		c.FillPolygon(plt.BackgroundColor, []vg.Point{first[0], first[1], second[1], second[0]})
 	}
+	c.StrokeLines(b.Truncation, first, second)
 }
 
 // DataRange implements the plot.DataRanger interface.
@@ -11,6 +11,8 @@ import (
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
 )
 
 func ExampleBarChart() {
@@ -171,3 +173,75 @@ func TestBarChart(t *testing.T) {
 		"horizontalBarChart.png", "barChart2.png",
 		"stackedBarChart.png")
 }
+
+// ExampleBarChart_truncated draws bars on a value axis
+// that does not start at zero, marking them as truncated.
+func ExampleBarChart_truncated() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Truncated bars"
+	bars, err := NewBarChart(Values{102, 108, 105, 111}, 0.5*vg.Centimeter)
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.Color = color.RGBA{R: 90, G: 155, B: 212, A: 255}
+	bars.LineStyle.Width = vg.Length(0)
+	bars.Truncation = draw.LineStyle{Color: color.Black, Width: vg.Points(1)}
+	p.Add(bars)
+	p.NominalX("A", "B", "C", "D")
+	p.Y.Min = 100
+
+	err = p.Save(150, 150, "testdata/truncatedBarChart.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarChartTruncated(t *testing.T) {
+	checkPlot(ExampleBarChart_truncated, t, "truncatedBarChart.png")
+}
+
+func TestBarChartTruncationWarning(t *testing.T) {
+	for _, test := range []struct {
+		values     Values
+		horizontal bool
+		min, max   float64
+		warn       bool
+	}{
+		{values: Values{1, 2, 3}, min: 0, max: 3},
+		{values: Values{1, 2, 3}, min: 0.5, max: 3, warn: true},
+		{values: Values{1, 2, 3}, horizontal: true, min: 0.5, max: 3, warn: true},
+		{values: Values{-1, -2, -3}, min: -3, max: -0.5, warn: true},
+		{values: Values{1, 2, 3}, min: 4, max: 5},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := NewBarChart(test.values, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b.Horizontal = test.horizontal
+		var warned bool
+		b.TruncationWarning = func(min, max float64) {
+			warned = true
+			if min != test.min || max != test.max {
+				t.Errorf("unexpected warning range: got:[%v, %v] want:[%v, %v]", min, max, test.min, test.max)
+			}
+		}
+		p.Add(b)
+		if test.horizontal {
+			p.X.Min, p.X.Max = test.min, test.max
+		} else {
+			p.Y.Min, p.Y.Max = test.min, test.max
+		}
+		c := draw.New(vgimg.New(100, 100))
+		p.Draw(c)
+		if warned != test.warn {
+			t.Errorf("unexpected warning for %v on [%v, %v]: got:%t want:%t", test.values, test.min, test.max, warned, test.warn)
+		}
+	}
+}
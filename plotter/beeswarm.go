@@ -0,0 +1,299 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Beeswarm implements the Plotter interface, drawing a
+// summary of the contributions of the features of a model
+// to its predictions, such as SHAP values. Each feature is
+// drawn as a row of points, one for each sample, placed
+// along the X axis at the contribution of the feature and
+// spread vertically where they would overlap. Points are
+// colored by the value of the feature in the sample.
+//
+// Rows are ranked by the mean absolute contribution of
+// their feature, with the most important feature at the
+// top of the plot. As for Importance, the names of the
+// features may be shown on the Y axis with
+//
+//	p.NominalY(b.Labels()...)
+type Beeswarm struct {
+	// Names holds the names of the features in
+	// order of decreasing mean absolute contribution.
+	Names []string
+
+	// Contributions holds the contributions of each
+	// feature, in the order of Names, for each sample.
+	Contributions [][]float64
+
+	// Features holds the value of each feature, in the
+	// order of Names, for each sample.
+	Features [][]float64
+
+	// ColorMap is used to color the points by the value
+	// of their feature, scaled for each feature so that
+	// its smallest value maps to the minimum of the
+	// ColorMap and its largest value to the maximum.
+	ColorMap palette.ColorMap
+
+	// GlyphStyle is the style of the points. Its
+	// color is used for points whose feature value
+	// is NaN.
+	GlyphStyle draw.GlyphStyle
+
+	// ZeroLine is the style of the vertical line
+	// drawn at a contribution of zero.
+	ZeroLine draw.LineStyle
+
+	// Spread is the maximum distance in Y units that
+	// the points are spread from the center of their
+	// row.
+	Spread float64
+}
+
+// NewBeeswarm returns a beeswarm summary for the named
+// features. The contribs and features parameters hold, for
+// each sample, the contribution and the value of each of
+// the features, in the order of names. Feature values may
+// be NaN for missing data.
+func NewBeeswarm(names []string, contribs, features [][]float64, cmap palette.ColorMap) (*Beeswarm, error) {
+	if len(contribs) != len(features) {
+		return nil, errors.New("Number of contribution samples does not match the number of feature samples")
+	}
+	if len(contribs) == 0 || len(names) == 0 {
+		return nil, ErrNoData
+	}
+	for i := range contribs {
+		if len(contribs[i]) != len(names) || len(features[i]) != len(names) {
+			return nil, errors.New("Sample length does not match the number of names")
+		}
+		if err := CheckFloats(contribs[i]...); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rank the features by decreasing mean absolute contribution.
+	neg := make([]float64, len(names))
+	for _, s := range contribs {
+		for j, v := range s {
+			neg[j] -= math.Abs(v) / float64(len(contribs))
+		}
+	}
+	order := argsort(neg)
+
+	b := &Beeswarm{
+		Names:         make([]string, len(names)),
+		Contributions: make([][]float64, len(contribs)),
+		Features:      make([][]float64, len(features)),
+		ColorMap:      cmap,
+		GlyphStyle:    DefaultGlyphStyle,
+		ZeroLine:      DefaultBaselineStyle,
+		Spread:        0.4,
+	}
+	b.GlyphStyle.Shape = draw.CircleGlyph{}
+	b.GlyphStyle.Radius = vg.Points(2)
+	for k, j := range order {
+		b.Names[k] = names[j]
+	}
+	for i := range contribs {
+		b.Contributions[i] = make([]float64, len(names))
+		b.Features[i] = make([]float64, len(names))
+		for k, j := range order {
+			b.Contributions[i][k] = contribs[i][j]
+			b.Features[i][k] = features[i][j]
+		}
+	}
+	return b, nil
+}
+
+// Labels returns the names of the features in order
+// of increasing Y value, for use with plot.NominalY.
+func (b *Beeswarm) Labels() []string {
+	ls := make([]string, len(b.Names))
+	for i, n := range b.Names {
+		ls[len(ls)-1-i] = n
+	}
+	return ls
+}
+
+// y returns the Y value of the row of rank i.
+func (b *Beeswarm) y(i int) float64 {
+	return float64(len(b.Names) - 1 - i)
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *Beeswarm) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	x0 := trX(0)
+	c.StrokeLines(b.ZeroLine, c.ClipLinesY([]vg.Point{{X: x0, Y: c.Min.Y}, {X: x0, Y: c.Max.Y}})...)
+
+	sty := b.GlyphStyle
+	for j := range b.Names {
+		y := trY(b.y(j))
+		maxOff := trY(b.y(j)+b.Spread) - y
+
+		fmin, fmax := math.Inf(1), math.Inf(-1)
+		xs := make([]vg.Length, len(b.Contributions))
+		for i, s := range b.Contributions {
+			xs[i] = trX(s[j])
+			if f := b.Features[i][j]; !math.IsNaN(f) {
+				fmin = math.Min(fmin, f)
+				fmax = math.Max(fmax, f)
+			}
+		}
+		ys := swarm(xs, 2*sty.Radius, maxOff)
+
+		for i, x := range xs {
+			p := vg.Point{X: x, Y: y + ys[i]}
+			if !c.Contains(p) {
+				continue
+			}
+			sty.Color = b.GlyphStyle.Color
+			if b.ColorMap != nil {
+				if clr, ok := b.color(b.Features[i][j], fmin, fmax); ok {
+					sty.Color = clr
+				}
+			}
+			c.DrawGlyph(sty, p)
+		}
+	}
+}
+
+// color returns the color of a point with the given feature
+// value, for a feature with values spanning [min, max].
+func (b *Beeswarm) color(f, min, max float64) (color.Color, bool) {
+	if math.IsNaN(f) {
+		return nil, false
+	}
+	t := 0.5
+	if max > min {
+		t = (f - min) / (max - min)
+	}
+	lo, hi := b.ColorMap.Min(), b.ColorMap.Max()
+	v := math.Max(lo, math.Min(hi, lo+t*(hi-lo)))
+	clr, err := b.ColorMap.At(v)
+	return clr, err == nil
+}
+
+// swarm returns the vertical offsets of points at the given
+// horizontal positions so that points of diameter d do not
+// overlap, placing each point, in order of increasing
+// position, at the smallest offset that does not overlap
+// the points already placed. Offsets are limited to max in
+// magnitude, beyond which points are allowed to overlap.
+func swarm(xs []vg.Length, d, max vg.Length) []vg.Length {
+	pos := make([]float64, len(xs))
+	for i, x := range xs {
+		pos[i] = float64(x)
+	}
+	idx := argsort(pos)
+
+	ys := make([]vg.Length, len(xs))
+	var placed []int
+	for _, i := range idx {
+		// Drop points too far to the left to overlap.
+		k := 0
+		for k < len(placed) && xs[i]-xs[placed[k]] >= d {
+			k++
+		}
+		placed = placed[k:]
+
+		// Candidate offsets are those touching each of the
+		// nearby points, above and below it.
+		cands := []vg.Length{0}
+		for _, p := range placed {
+			dx := xs[i] - xs[p]
+			dy := vg.Length(math.Sqrt(float64(d*d - dx*dx)))
+			cands = append(cands, ys[p]+dy, ys[p]-dy)
+		}
+		best := vg.Length(math.Inf(1))
+		for _, y := range cands {
+			if math.Abs(float64(y)) >= math.Abs(float64(best)) || math.Abs(float64(y)) > float64(max) {
+				continue
+			}
+			if !overlaps(xs[i], y, xs, ys, placed, d) {
+				best = y
+			}
+		}
+		if math.IsInf(float64(best), 1) {
+			best = 0
+		}
+		ys[i] = best
+		placed = append(placed, i)
+	}
+	return ys
+}
+
+// overlaps returns whether a point of diameter d at (x, y)
+// overlaps any of the placed points.
+func overlaps(x, y vg.Length, xs, ys []vg.Length, placed []int, d vg.Length) bool {
+	const eps = 1e-6
+	for _, p := range placed {
+		dx, dy := x-xs[p], y-ys[p]
+		if float64(dx*dx+dy*dy) < float64(d*d)-eps {
+			return true
+		}
+	}
+	return false
+}
+
+// argsort returns the indices of vs in order of
+// increasing value, keeping equal values in order.
+func argsort(vs []float64) []int {
+	idx := make([]int, len(vs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Stable(byIndexedValue{idx: idx, vs: vs})
+	return idx
+}
+
+// byIndexedValue sorts indices by increasing value.
+type byIndexedValue struct {
+	idx []int
+	vs  []float64
+}
+
+func (s byIndexedValue) Len() int           { return len(s.idx) }
+func (s byIndexedValue) Less(i, j int) bool { return s.vs[s.idx[i]] < s.vs[s.idx[j]] }
+func (s byIndexedValue) Swap(i, j int)      { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
+
+// DataRange implements the plot.DataRanger interface.
+// The X range always includes zero.
+func (b *Beeswarm) DataRange() (xmin, xmax, ymin, ymax float64) {
+	for _, s := range b.Contributions {
+		for _, v := range s {
+			xmin = math.Min(xmin, v)
+			xmax = math.Max(xmax, v)
+		}
+	}
+	return xmin, xmax, -b.Spread, b.y(0) + b.Spread
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (b *Beeswarm) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var bs []plot.GlyphBox
+	for j := range b.Names {
+		for _, s := range b.Contributions {
+			bs = append(bs, plot.GlyphBox{
+				X:         plt.X.Norm(s[j]),
+				Y:         plt.Y.Norm(b.y(j)),
+				Rectangle: b.GlyphStyle.Rectangle(),
+			})
+		}
+	}
+	return bs
+}
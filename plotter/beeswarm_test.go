@@ -0,0 +1,132 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleBeeswarm draws a summary of the contributions
+// of the features of a model to its predictions, with
+// points colored from blue for low feature values to red
+// for high feature values.
+func ExampleBeeswarm() {
+	rnd := rand.New(rand.NewSource(1))
+
+	names := []string{"age", "income", "tenure", "visits"}
+	weights := []float64{0.5, 1.5, -0.3, 1}
+
+	const n = 150
+	contribs := make([][]float64, n)
+	features := make([][]float64, n)
+	for i := range contribs {
+		contribs[i] = make([]float64, len(names))
+		features[i] = make([]float64, len(names))
+		for j, w := range weights {
+			f := rnd.NormFloat64()
+			features[i][j] = f
+			contribs[i][j] = w*f + 0.1*rnd.NormFloat64()
+		}
+	}
+
+	cmap := palette.Linear(palette.Radial(3, palette.Blue, palette.Red, 1))
+	b, err := NewBeeswarm(names, contribs, features, cmap)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Feature contributions"
+	p.X.Label.Text = "Contribution to prediction"
+	p.Add(b)
+	p.NominalY(b.Labels()...)
+
+	err = p.Save(300, 200, "testdata/beeswarm.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBeeswarm(t *testing.T) {
+	checkPlot(ExampleBeeswarm, t, "beeswarm.png")
+}
+
+func TestNewBeeswarm(t *testing.T) {
+	b, err := NewBeeswarm(
+		[]string{"a", "b", "c"},
+		[][]float64{{1, -4, 2}, {-1, 2, 0}},
+		[][]float64{{10, 20, 30}, {11, 21, 31}},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(b.Names, want) {
+		t.Errorf("unexpected names: got:%v want:%v", b.Names, want)
+	}
+	if want := [][]float64{{-4, 1, 2}, {2, -1, 0}}; !reflect.DeepEqual(b.Contributions, want) {
+		t.Errorf("unexpected contributions: got:%v want:%v", b.Contributions, want)
+	}
+	if want := [][]float64{{20, 10, 30}, {21, 11, 31}}; !reflect.DeepEqual(b.Features, want) {
+		t.Errorf("unexpected features: got:%v want:%v", b.Features, want)
+	}
+
+	if _, err := NewBeeswarm([]string{"a"}, [][]float64{{1, 2}}, [][]float64{{1, 2}}, nil); err == nil {
+		t.Error("expected error for mismatched names")
+	}
+}
+
+func TestBeeswarmColor(t *testing.T) {
+	cmap := palette.Linear(palette.Radial(2, palette.Blue, palette.Red, 1))
+	cmap.SetMin(-1)
+	cmap.SetMax(1)
+	b := &Beeswarm{ColorMap: cmap}
+	for _, test := range []struct {
+		f    float64
+		want color.Color
+	}{
+		{f: 0, want: cmap.Palette(2).Colors()[0]},
+		{f: 10, want: cmap.Palette(2).Colors()[1]},
+	} {
+		got, ok := b.color(test.f, 0, 10)
+		if !ok {
+			t.Errorf("unexpected failure for %v", test.f)
+		}
+		if got != test.want {
+			t.Errorf("unexpected color for %v: got:%v want:%v", test.f, got, test.want)
+		}
+	}
+	if min, max := cmap.Min(), cmap.Max(); min != -1 || max != 1 {
+		t.Errorf("color map range modified: got:[%v, %v]", min, max)
+	}
+}
+
+func TestSwarm(t *testing.T) {
+	xs := []vg.Length{0, 1, 2, 10, 2.5}
+	const d = 4
+	ys := swarm(xs, d, 100)
+	for i := range xs {
+		for j := i + 1; j < len(xs); j++ {
+			dx, dy := xs[i]-xs[j], ys[i]-ys[j]
+			if dx*dx+dy*dy < d*d-1e-6 {
+				t.Errorf("points %d and %d overlap: (%v, %v) (%v, %v)", i, j, xs[i], ys[i], xs[j], ys[j])
+			}
+		}
+	}
+	if ys[0] != 0 || ys[3] != 0 {
+		t.Errorf("unexpected offset for isolated points: got:%v", ys)
+	}
+}
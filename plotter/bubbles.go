@@ -8,21 +8,33 @@ import (
 	"errors"
 	"image/color"
 	"math"
+	"strconv"
 
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 )
 
 // Bubbles implements the Plotter interface, drawing
 // a bubble plot of x, y, z triples where the z value
-// determines the radius of the bubble.
+// determines the radius of the bubble, and optionally
+// a fourth value of each bubble its color.
 type Bubbles struct {
 	XYZs
 
 	// Color is the color of the bubbles.
 	color.Color
 
+	// Values, if not nil, holds a value of each
+	// bubble by which it is colored using ColorMap.
+	// Bubbles whose values are outside of the range
+	// of the ColorMap are drawn in Color.
+	Values []float64
+
+	// ColorMap is the ColorMap of the Values.
+	ColorMap palette.ColorMap
+
 	// MinRadius and MaxRadius give the minimum
 	// and maximum bubble radius respectively.
 	// The radii of each bubble is interpolated linearly
@@ -66,7 +78,7 @@ func (bs *Bubbles) Plot(c draw.Canvas, plt *plot.Plot) {
 
 	c.SetColor(bs.Color)
 
-	for _, d := range bs.XYZs {
+	for i, d := range bs.XYZs {
 		x := trX(d.X)
 		y := trY(d.Y)
 		pt := vg.Point{X: x, Y: y}
@@ -75,6 +87,9 @@ func (bs *Bubbles) Plot(c draw.Canvas, plt *plot.Plot) {
 		}
 
 		rad := bs.radius(d.Z)
+		if bs.Values != nil && bs.ColorMap != nil {
+			c.SetColor(bs.color(i))
+		}
 
 		// draw a circle centered at x, y
 		var p vg.Path
@@ -95,6 +110,60 @@ func (bs *Bubbles) radius(z float64) vg.Length {
 	return vg.Length(d)*rng + bs.MinRadius
 }
 
+// color returns the color of bubble i.
+func (bs *Bubbles) color(i int) color.Color {
+	clr, err := bs.ColorMap.At(bs.Values[i])
+	if err != nil {
+		return bs.Color
+	}
+	return clr
+}
+
+// SizeLegend returns legend entries showing the radii of
+// bubbles of the given z values, their labels and their
+// thumbnails, to be added to the legend of a plot. If no
+// values are given, the labeled ticks of plot.DefaultTicks
+// within the Z range of the bubbles are used.
+func (bs *Bubbles) SizeLegend(zs ...float64) (labels []string, thumbnailers []plot.Thumbnailer) {
+	if len(zs) == 0 {
+		for _, t := range (plot.DefaultTicks{}).Ticks(bs.MinZ, bs.MaxZ) {
+			if t.IsMinor() || t.Value < bs.MinZ || t.Value > bs.MaxZ {
+				continue
+			}
+			zs = append(zs, t.Value)
+			labels = append(labels, t.Label)
+		}
+	} else {
+		for _, z := range zs {
+			labels = append(labels, strconv.FormatFloat(z, 'g', -1, 64))
+		}
+	}
+	thumbnailers = make([]plot.Thumbnailer, len(zs))
+	for i, z := range zs {
+		thumbnailers[i] = bubbleThumbnailer{Color: bs.Color, radius: bs.radius(z)}
+	}
+	return labels, thumbnailers
+}
+
+// bubbleThumbnailer implements the Thumbnailer
+// interface for the size legend of Bubbles.
+type bubbleThumbnailer struct {
+	color.Color
+	radius vg.Length
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface,
+// drawing a bubble centered in the canvas.
+func (t bubbleThumbnailer) Thumbnail(c *draw.Canvas) {
+	pt := vg.Point{X: (c.Min.X + c.Max.X) / 2, Y: (c.Min.Y + c.Max.Y) / 2}
+	var p vg.Path
+	p.Move(vg.Point{X: pt.X + t.radius, Y: pt.Y})
+	p.Arc(pt, t.radius, 0, 2*math.Pi)
+	p.Close()
+	c.SetColor(t.Color)
+	c.Fill(p)
+}
+
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (bs *Bubbles) DataRange() (xmin, xmax, ymin, ymax float64) {
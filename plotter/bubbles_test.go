@@ -8,9 +8,11 @@ import (
 	"image/color"
 	"log"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
 	"github.com/gonum/plot/vg"
 )
 
@@ -91,3 +93,77 @@ func ExampleBubbles() {
 func TestBubbles(t *testing.T) {
 	checkPlot(ExampleBubbles, t, "bubbles.png")
 }
+
+// ExampleBubbles_colored draws bubbles sized by one value and
+// colored by another, with a legend of the bubble sizes.
+func ExampleBubbles_colored() {
+	rnd := rand.New(rand.NewSource(1))
+	data := make(XYZs, 15)
+	values := make([]float64, len(data))
+	for i := range data {
+		data[i].X = rnd.Float64() * 10
+		data[i].Y = rnd.Float64() * 10
+		data[i].Z = 1 + rnd.Float64()*99
+		values[i] = data[i].X + data[i].Y
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Colored bubbles"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	bs, err := NewBubbles(data, vg.Points(2), vg.Points(14))
+	if err != nil {
+		log.Panic(err)
+	}
+	bs.Color = color.Gray{Y: 0x80}
+	bs.Values = values
+	bs.ColorMap = palette.Linear(palette.Heat(8, 0.8))
+	bs.ColorMap.SetMax(20)
+	p.Add(bs)
+
+	p.Legend.Title.Text = "Z"
+	p.Legend.Top = true
+	labels, thumbs := bs.SizeLegend()
+	for i, l := range labels {
+		p.Legend.Add(l, thumbs[i])
+	}
+	p.Legend.Padding = vg.Points(12)
+	p.Legend.XOffs = -vg.Points(8)
+	p.X.Max = 14
+
+	err = p.Save(250, 250, "testdata/coloredBubbles.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestColoredBubbles(t *testing.T) {
+	checkPlot(ExampleBubbles_colored, t, "coloredBubbles.png")
+}
+
+func TestBubblesSizeLegend(t *testing.T) {
+	bs := &Bubbles{MinRadius: 1, MaxRadius: 5, MinZ: 0.5, MaxZ: 10.5}
+
+	labels, thumbs := bs.SizeLegend()
+	if want := []string{"3", "6", "9"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected automatic labels: got:%v want:%v", labels, want)
+	}
+	if len(thumbs) != len(labels) {
+		t.Fatalf("unexpected number of thumbnails: got:%d want:%d", len(thumbs), len(labels))
+	}
+	if r := thumbs[len(thumbs)-1].(bubbleThumbnailer).radius; r != bs.radius(9) {
+		t.Errorf("unexpected thumbnail radius: got:%v want:%v", r, bs.radius(9))
+	}
+
+	labels, thumbs = bs.SizeLegend(1, 5.5)
+	if want := []string{"1", "5.5"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected labels: got:%v want:%v", labels, want)
+	}
+	if r := thumbs[1].(bubbleThumbnailer).radius; r != 3 {
+		t.Errorf("unexpected thumbnail radius: got:%v want:3", r)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// CalendarHeatMap implements the Plotter interface, drawing
+// a value for each day, such as a count of commits or of
+// incidents, as a calendar of cells colored by their values,
+// with a column for each week.
+//
+// The cells of the week w, counted from the week of the first
+// day, are drawn at an X value of w. The days of the week are
+// drawn from Monday at the top to Sunday at the bottom, at the
+// Y values of the days of a Punchcard, so the names of the days
+// and of the months may be shown on the axes with
+//
+//	p.NominalY(cal.Labels()...)
+//	p.X.Tick.Marker = cal.MonthTicks()
+type CalendarHeatMap struct {
+	// Start is the first day of the calendar. Only
+	// its year, month and day are used.
+	Start time.Time
+
+	// Values holds the value of each day from Start.
+	// Days whose values are NaN are drawn as empty.
+	Values []float64
+
+	// ColorMap is used to color the cells by their
+	// values. Values outside the range of the ColorMap
+	// are given the color of its nearest end.
+	ColorMap palette.ColorMap
+
+	// Empty is the color of the cells of days without
+	// values. If Empty is nil, they are not drawn.
+	Empty color.Color
+
+	// Gap is the gap between neighbouring cells.
+	Gap vg.Length
+}
+
+// NewCalendarHeatMap returns a calendar heat map of the sum of
+// the values of each day, from the day of the earliest time to
+// that of the latest, colored by cmap. The days of the times are
+// those of their own locations. Days without values are empty.
+func NewCalendarHeatMap(ts []time.Time, vs []float64, cmap palette.ColorMap) (*CalendarHeatMap, error) {
+	if len(ts) != len(vs) {
+		return nil, errors.New("Number of times does not match the number of values")
+	}
+	if len(ts) == 0 {
+		return nil, ErrNoData
+	}
+	if err := CheckFloats(vs...); err != nil {
+		return nil, err
+	}
+	first, last := calendarDate(ts[0]), calendarDate(ts[0])
+	for _, t := range ts[1:] {
+		d := calendarDate(t)
+		if d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+	cal := &CalendarHeatMap{
+		Start:    first,
+		Values:   make([]float64, daysBetween(first, last)+1),
+		ColorMap: cmap,
+		Empty:    color.Gray{Y: 235},
+		Gap:      vg.Points(1),
+	}
+	for i := range cal.Values {
+		cal.Values[i] = math.NaN()
+	}
+	for i, t := range ts {
+		d := daysBetween(first, calendarDate(t))
+		if math.IsNaN(cal.Values[d]) {
+			cal.Values[d] = 0
+		}
+		cal.Values[d] += vs[i]
+	}
+	return cal, nil
+}
+
+// calendarDate returns midnight UTC of the day of t in its location.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween returns the number of days from
+// the date from to the date to.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+// weekdayIndex returns the index of the day of
+// the week of d, from 0 for Monday to 6 for Sunday.
+func weekdayIndex(d time.Weekday) int {
+	return (int(d) + 6) % 7
+}
+
+// cell returns the week and the day of the week, the X
+// and Y values, of the cell of the ith day from Start.
+func (cal *CalendarHeatMap) cell(i int) (x, y float64) {
+	start := calendarDate(cal.Start)
+	w := (weekdayIndex(start.Weekday()) + i) / 7
+	d := start.AddDate(0, 0, i).Weekday()
+	return float64(w), float64(punchcardY(d))
+}
+
+// weeks returns the number of weeks of the calendar.
+func (cal *CalendarHeatMap) weeks() int {
+	if len(cal.Values) == 0 {
+		return 0
+	}
+	w, _ := cal.cell(len(cal.Values) - 1)
+	return int(w) + 1
+}
+
+// Labels returns the abbreviated names of the days of
+// the week in order of increasing Y value, for use with
+// plot.NominalY.
+func (cal *CalendarHeatMap) Labels() []string {
+	return (&Punchcard{}).Labels()
+}
+
+// MonthTicks returns tick marks at the weeks of the first
+// days of the months of the calendar, labeled with the
+// abbreviated names of the months, and with the year in
+// January and in the first month of the calendar.
+func (cal *CalendarHeatMap) MonthTicks() plot.ConstantTicks {
+	var ticks plot.ConstantTicks
+	start := calendarDate(cal.Start)
+	for i := range cal.Values {
+		d := start.AddDate(0, 0, i)
+		if d.Day() != 1 && i != 0 {
+			continue
+		}
+		x, _ := cal.cell(i)
+		label := d.Month().String()[:3]
+		if i == 0 || d.Month() == time.January {
+			label += " " + d.Format("2006")
+		}
+		ticks = append(ticks, plot.Tick{Value: x, Label: label})
+	}
+	return ticks
+}
+
+// color returns the color of the value v, or
+// nil if it is not drawn.
+func (cal *CalendarHeatMap) color(v float64) color.Color {
+	if math.IsNaN(v) || cal.ColorMap == nil {
+		return cal.Empty
+	}
+	lo, hi := cal.ColorMap.Min(), cal.ColorMap.Max()
+	clr, err := cal.ColorMap.At(math.Max(lo, math.Min(hi, v)))
+	if err != nil {
+		return cal.Empty
+	}
+	return clr
+}
+
+// Plot implements the plot.Plotter interface.
+func (cal *CalendarHeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, v := range cal.Values {
+		clr := cal.color(v)
+		if clr == nil {
+			continue
+		}
+		x, y := cal.cell(i)
+		pts := []vg.Point{
+			{X: trX(x-0.5) + cal.Gap/2, Y: trY(y-0.5) + cal.Gap/2},
+			{X: trX(x-0.5) + cal.Gap/2, Y: trY(y+0.5) - cal.Gap/2},
+			{X: trX(x+0.5) - cal.Gap/2, Y: trY(y+0.5) - cal.Gap/2},
+			{X: trX(x+0.5) - cal.Gap/2, Y: trY(y-0.5) + cal.Gap/2},
+		}
+		c.FillPolygon(clr, c.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (cal *CalendarHeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return -0.5, float64(cal.weeks()) - 0.5, -0.5, 6.5
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (cal *CalendarHeatMap) Thumbnail(c *draw.Canvas) {
+	clr := cal.Empty
+	if cal.ColorMap != nil {
+		clr, _ = cal.ColorMap.At((cal.ColorMap.Min() + cal.ColorMap.Max()) / 2)
+	}
+	if clr == nil {
+		return
+	}
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(clr, c.ClipPolygonY(pts))
+}
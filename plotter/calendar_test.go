@@ -0,0 +1,129 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleCalendarHeatMap draws the number of incidents
+// of each day over half a year, with fewer at weekends.
+func ExampleCalendarHeatMap() {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	var ts []time.Time
+	var vs []float64
+	for d := 0; d < 182; d++ {
+		t := start.AddDate(0, 0, d)
+		n := rnd.Intn(10)
+		if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			n /= 4
+		}
+		if n == 0 {
+			continue
+		}
+		ts = append(ts, t.Add(time.Duration(rnd.Intn(24))*time.Hour))
+		vs = append(vs, float64(n))
+	}
+
+	cmap := palette.Linear(palette.Heat(9, 1))
+	cmap.SetMin(0)
+	cmap.SetMax(9)
+	cal, err := NewCalendarHeatMap(ts, vs, cmap)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Incidents"
+	p.Add(cal)
+	p.NominalY(cal.Labels()...)
+	p.X.Tick.Marker = cal.MonthTicks()
+	p.X.Width = 0
+	p.X.Tick.Length = 0
+
+	err = p.Save(400, 130, "testdata/calendarHeatMap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCalendarHeatMap(t *testing.T) {
+	checkPlot(ExampleCalendarHeatMap, t, "calendarHeatMap.png")
+}
+
+func TestCalendarHeatMapCells(t *testing.T) {
+	zone := time.FixedZone("UTC+10", 10*60*60)
+	ts := []time.Time{
+		// Sunday 31 January 2016, but Monday
+		// 1 February in its location.
+		time.Date(2016, 1, 31, 20, 0, 0, 0, time.UTC).In(zone),
+		// Wednesday 3 February.
+		time.Date(2016, 2, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2016, 2, 3, 23, 0, 0, 0, time.UTC),
+		// Tuesday 9 February.
+		time.Date(2016, 2, 9, 0, 0, 0, 0, time.UTC),
+	}
+	cal, err := NewCalendarHeatMap(ts, []float64{1, 2, 3, 4}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cal.Values) != 9 {
+		t.Fatalf("unexpected number of days: got:%d want:9", len(cal.Values))
+	}
+	for i, want := range []float64{1, math.NaN(), 5, math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN(), 4} {
+		if got := cal.Values[i]; got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+			t.Errorf("unexpected value of day %d: got:%v want:%v", i, got, want)
+		}
+	}
+
+	for _, test := range []struct {
+		day  int
+		x, y float64
+	}{
+		{day: 0, x: 0, y: 6},
+		{day: 2, x: 0, y: 4},
+		{day: 6, x: 0, y: 0},
+		{day: 8, x: 1, y: 5},
+	} {
+		if x, y := cal.cell(test.day); x != test.x || y != test.y {
+			t.Errorf("unexpected cell of day %d: got:(%v, %v) want:(%v, %v)", test.day, x, y, test.x, test.y)
+		}
+	}
+	if xmin, xmax, _, _ := cal.DataRange(); xmin != -0.5 || xmax != 1.5 {
+		t.Errorf("unexpected X range: got:[%v, %v] want:[-0.5, 1.5]", xmin, xmax)
+	}
+
+	cal.Start = time.Date(2016, 12, 28, 0, 0, 0, 0, time.UTC)
+	cal.Values = make([]float64, 40)
+	ticks := cal.MonthTicks()
+	want := plot.ConstantTicks{
+		{Value: 0, Label: "Dec 2016"},
+		{Value: 0, Label: "Jan 2017"},
+		{Value: 5, Label: "Feb"},
+	}
+	if len(ticks) != len(want) {
+		t.Fatalf("unexpected month ticks: got:%v want:%v", ticks, want)
+	}
+	for i := range ticks {
+		if ticks[i] != want[i] {
+			t.Errorf("unexpected month tick %d: got:%v want:%v", i, ticks[i], want[i])
+		}
+	}
+
+	if _, err := NewCalendarHeatMap(ts, []float64{1}, nil); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
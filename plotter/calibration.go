@@ -0,0 +1,194 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Calibration implements the Plotter interface, drawing
+// a calibration (reliability) curve for the predicted
+// probabilities of a binary classifier. The observed
+// frequency of positive outcomes is plotted against
+// the mean predicted probability within each bin,
+// along with a diagonal reference line showing
+// perfect calibration.
+//
+// The distribution of the predictions is usually shown
+// alongside the curve, using the Histogram method.
+type Calibration struct {
+	// Bins holds the predictions grouped into
+	// equal width bins spanning [0, 1].
+	Bins []CalibrationBin
+
+	// XYs holds the mean predicted probability and the
+	// observed frequency of positive outcomes for each
+	// non-empty bin.
+	XYs
+
+	// LineStyle is the style of the line
+	// connecting the points of the curve.
+	draw.LineStyle
+
+	// GlyphStyle is the style of the glyphs
+	// drawn at each point of the curve.
+	GlyphStyle draw.GlyphStyle
+
+	// Reference is the style of the diagonal
+	// reference line.
+	Reference draw.LineStyle
+}
+
+// CalibrationBin is a bin of the predicted
+// probabilities of a Calibration curve.
+type CalibrationBin struct {
+	// Min and Max are the bounds of the bin.
+	Min, Max float64
+
+	// Count is the number of predictions in the bin,
+	// and Positives is the number of those with a
+	// positive outcome.
+	Count, Positives int
+
+	// Sum is the sum of the predictions in the bin.
+	Sum float64
+}
+
+// NewCalibration returns a calibration curve for the given
+// predicted probabilities and the corresponding observed
+// outcomes, grouping the predictions into n equal width bins.
+func NewCalibration(probs []float64, outcomes []bool, n int) (*Calibration, error) {
+	if n <= 0 {
+		return nil, errors.New("Calibration with non-positive number of bins")
+	}
+	if len(probs) != len(outcomes) {
+		return nil, errors.New("Number of predictions does not match the number of outcomes")
+	}
+	if len(probs) == 0 {
+		return nil, ErrNoData
+	}
+	if err := CheckFloats(probs...); err != nil {
+		return nil, err
+	}
+
+	bins := make([]CalibrationBin, n)
+	w := 1 / float64(n)
+	for i := range bins {
+		bins[i].Min = float64(i) * w
+		bins[i].Max = float64(i+1) * w
+	}
+	for i, p := range probs {
+		if p < 0 || p > 1 {
+			return nil, errors.New("Predicted probability outside [0, 1]")
+		}
+		b := &bins[binIndex(p, n)]
+		b.Count++
+		b.Sum += p
+		if outcomes[i] {
+			b.Positives++
+		}
+	}
+
+	var xys XYs
+	for _, b := range bins {
+		if b.Count == 0 {
+			continue
+		}
+		xys = append(xys, struct{ X, Y float64 }{
+			X: b.Sum / float64(b.Count),
+			Y: float64(b.Positives) / float64(b.Count),
+		})
+	}
+
+	return &Calibration{
+		Bins:       bins,
+		XYs:        xys,
+		LineStyle:  DefaultLineStyle,
+		GlyphStyle: DefaultGlyphStyle,
+		Reference:  DefaultBaselineStyle,
+	}, nil
+}
+
+// binIndex returns the index of the bin of n
+// equal width bins over [0, 1] containing p.
+// A p of 1 is placed in the last bin.
+func binIndex(p float64, n int) int {
+	i := int(p * float64(n))
+	if i == n {
+		i--
+	}
+	return i
+}
+
+// Plot draws the calibration curve, implementing
+// the plot.Plotter interface.
+func (cal *Calibration) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	ref := []vg.Point{{X: trX(0), Y: trY(0)}, {X: trX(1), Y: trY(1)}}
+	c.StrokeLines(cal.Reference, c.ClipLinesXY(ref)...)
+
+	ps := make([]vg.Point, len(cal.XYs))
+	for i, p := range cal.XYs {
+		ps[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	c.StrokeLines(cal.LineStyle, c.ClipLinesXY(ps)...)
+	for _, p := range ps {
+		c.DrawGlyph(cal.GlyphStyle, p)
+	}
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the plot.DataRanger
+// interface.
+func (cal *Calibration) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, 1
+}
+
+// GlyphBoxes returns a slice of GlyphBoxes, one for
+// each point of the curve, implementing the
+// plot.GlyphBoxer interface.
+func (cal *Calibration) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(cal.XYs))
+	for i, p := range cal.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = cal.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// Thumbnail draws a line with a glyph in the style
+// of the curve, implementing the plot.Thumbnailer
+// interface.
+func (cal *Calibration) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(cal.LineStyle, c.Min.X, y, c.Max.X, y)
+	c.DrawGlyph(cal.GlyphStyle, c.Center())
+}
+
+// Histogram returns a histogram of the number of
+// predictions in each bin of the calibration curve,
+// for display with the curve.
+func (cal *Calibration) Histogram() *Histogram {
+	bins := make([]HistogramBin, len(cal.Bins))
+	for i, b := range cal.Bins {
+		bins[i] = HistogramBin{Min: b.Min, Max: b.Max, Weight: float64(b.Count)}
+	}
+	var w float64
+	if len(bins) > 0 {
+		w = bins[0].Max - bins[0].Min
+	}
+	return &Histogram{
+		Bins:      bins,
+		Width:     w,
+		FillColor: color.Gray{128},
+		LineStyle: DefaultLineStyle,
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// ExampleCalibration draws the calibration curve of an
+// over-confident classifier above a histogram of its
+// predictions.
+func ExampleCalibration() {
+	rnd := rand.New(rand.NewSource(1))
+
+	const n = 2000
+	probs := make([]float64, n)
+	outcomes := make([]bool, n)
+	for i := range probs {
+		p := rnd.Float64()
+		outcomes[i] = rnd.Float64() < p
+		// Push the predictions towards 0 and 1.
+		probs[i] = 1 / (1 + math.Exp(-8*(p-0.5)))
+	}
+
+	cal, err := NewCalibration(probs, outcomes, 10)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	curve, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	curve.Title.Text = "Calibration"
+	curve.Y.Label.Text = "Observed frequency"
+	curve.Add(NewGrid(), cal)
+
+	hist, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	hist.X.Label.Text = "Predicted probability"
+	hist.Y.Label.Text = "Count"
+	hist.Add(cal.Histogram())
+
+	img := vgimg.New(200, 300)
+	dc := draw.New(img)
+	top := draw.Crop(dc, 0, 0, 100, 0)
+	bottom := draw.Crop(dc, 0, 0, 0, -200)
+	curve.Draw(top)
+	hist.Draw(bottom)
+
+	f, err := os.Create("testdata/calibration.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer f.Close()
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(f); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCalibration(t *testing.T) {
+	checkPlot(ExampleCalibration, t, "calibration.png")
+}
+
+func TestNewCalibration(t *testing.T) {
+	probs := []float64{0.125, 0.25, 0.375, 0.75, 0.875, 1}
+	outcomes := []bool{false, true, false, true, true, true}
+	cal, err := NewCalibration(probs, outcomes, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantBins := []CalibrationBin{
+		{Min: 0, Max: 0.5, Count: 3, Positives: 1, Sum: 0.75},
+		{Min: 0.5, Max: 1, Count: 3, Positives: 3, Sum: 2.625},
+	}
+	if !reflect.DeepEqual(cal.Bins, wantBins) {
+		t.Errorf("unexpected bins:\ngot: %+v\nwant:%+v", cal.Bins, wantBins)
+	}
+	wantXYs := XYs{{0.25, 1.0 / 3}, {0.875, 1}}
+	if !reflect.DeepEqual(cal.XYs, wantXYs) {
+		t.Errorf("unexpected curve:\ngot: %v\nwant:%v", cal.XYs, wantXYs)
+	}
+
+	h := cal.Histogram()
+	if h.Width != 0.5 {
+		t.Errorf("unexpected histogram bin width: got:%v want:0.5", h.Width)
+	}
+	for i, b := range h.Bins {
+		if b.Weight != float64(wantBins[i].Count) {
+			t.Errorf("unexpected histogram bin %d weight: got:%v want:%v", i, b.Weight, wantBins[i].Count)
+		}
+	}
+
+	for _, p := range []float64{-0.1, 1.1} {
+		if _, err := NewCalibration([]float64{p}, []bool{true}, 2); err == nil {
+			t.Errorf("expected error for prediction %v", p)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// NewCategoryBarChart returns a bar chart with a bar at the
+// location of each of the categories, whose height is the
+// value of the category in values. Categories without a
+// value have a bar of zero height. It is an error for values
+// to hold a category that is not in cats.
+func NewCategoryBarChart(cats *plot.Categories, values map[string]float64, width vg.Length) (*BarChart, error) {
+	if err := checkCategories(cats, values); err != nil {
+		return nil, err
+	}
+	vs := make(Values, cats.Len())
+	for i, name := range cats.Names() {
+		vs[i] = values[name]
+	}
+	return NewBarChart(vs, width)
+}
+
+// NewCategoryBoxPlots returns a box plot of the given width
+// at the location of each of the categories that has values,
+// in the order of the categories. It is an error for values
+// to hold a category that is not in cats.
+func NewCategoryBoxPlots(w vg.Length, cats *plot.Categories, values map[string]Valuer) ([]*BoxPlot, error) {
+	if err := checkCategories(cats, values); err != nil {
+		return nil, err
+	}
+	var bs []*BoxPlot
+	for i, name := range cats.Names() {
+		vs, ok := values[name]
+		if !ok {
+			continue
+		}
+		b, err := NewBoxPlot(w, float64(i), vs)
+		if err != nil {
+			return nil, err
+		}
+		bs = append(bs, b)
+	}
+	return bs, nil
+}
+
+// NewCategoryStrips returns a strip of the given width at the
+// location of each of the categories that has values, in the
+// order of the categories. It is an error for values to hold
+// a category that is not in cats.
+func NewCategoryStrips(w vg.Length, cats *plot.Categories, values map[string]Valuer) ([]*Strip, error) {
+	if err := checkCategories(cats, values); err != nil {
+		return nil, err
+	}
+	var ss []*Strip
+	for i, name := range cats.Names() {
+		vs, ok := values[name]
+		if !ok {
+			continue
+		}
+		s, err := NewStrip(w, float64(i), vs)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+// checkCategories returns an error naming the first, in lexical
+// order, of the keys of values that is not a category in cats.
+// The values must be a map keyed by category name.
+func checkCategories(cats *plot.Categories, values interface{}) error {
+	var names []string
+	switch values := values.(type) {
+	case map[string]float64:
+		for name := range values {
+			names = append(names, name)
+		}
+	case map[string]Valuer:
+		for name := range values {
+			names = append(names, name)
+		}
+	default:
+		panic("plotter: unexpected category values type")
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := cats.Location(name); !ok {
+			return fmt.Errorf("Unknown category %q", name)
+		}
+	}
+	return nil
+}
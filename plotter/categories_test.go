@@ -0,0 +1,50 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestCategoryPlotters(t *testing.T) {
+	cats := plot.NewCategories("a", "b", "c")
+
+	b, err := NewCategoryBarChart(cats, map[string]float64{"c": 3, "a": 1}, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(b.Values) != 3 || b.Values[0] != 1 || b.Values[1] != 0 || b.Values[2] != 3 {
+		t.Errorf("unexpected bar values: got:%v want:[1 0 3]", b.Values)
+	}
+
+	values := map[string]Valuer{
+		"a": Values{1, 2, 3},
+		"c": Values{4, 5, 6},
+	}
+	bs, err := NewCategoryBoxPlots(vg.Points(10), cats, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bs) != 2 || bs[0].Location != 0 || bs[1].Location != 2 {
+		t.Errorf("unexpected box plots: got:%d boxes", len(bs))
+	}
+	ss, err := NewCategoryStrips(vg.Points(10), cats, values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ss) != 2 || ss[0].Location != 0 || ss[1].Location != 2 {
+		t.Errorf("unexpected strips: got:%d strips", len(ss))
+	}
+
+	values["z"] = Values{1}
+	values["d"] = Values{1}
+	_, err = NewCategoryBoxPlots(vg.Points(10), cats, values)
+	if err == nil || err.Error() != `Unknown category "d"` {
+		t.Errorf("unexpected error for unknown category: got:%v", err)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Clone implements the plot.Cloner interface, returning a
+// copy of the line with copies of its points, values and
+// styles. Its ColorMap and Smoothing are shared.
+func (pts *Line) Clone() plot.Plotter {
+	c := *pts
+	cloneDashes(&c.LineStyle)
+	c.XYs = append(XYs(nil), pts.XYs...)
+	if pts.Values != nil {
+		c.Values = append([]float64(nil), pts.Values...)
+	}
+	if pts.ShadeColor != nil {
+		shade := *pts.ShadeColor
+		c.ShadeColor = &shade
+	}
+	if pts.Points != nil {
+		points := *pts.Points
+		c.Points = &points
+	}
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning
+// a copy of the scatter with copies of its points.
+func (pts *Scatter) Clone() plot.Plotter {
+	c := *pts
+	c.XYs = append(XYs(nil), pts.XYs...)
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning
+// a copy of the function, sharing its F.
+func (f *Function) Clone() plot.Plotter {
+	c := *f
+	cloneDashes(&c.LineStyle)
+	return &c
+}
+
+// Clone implements the plot.Cloner interface,
+// returning a copy of the grid.
+func (g *Grid) Clone() plot.Plotter {
+	c := *g
+	cloneDashes(&c.Vertical, &c.Horizontal, &c.MinorVertical, &c.MinorHorizontal)
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning a
+// copy of the bar chart with a copy of its values. The clone
+// is stacked on the same bar chart as b, if any, until it is
+// relinked to the clone of that bar chart by Plot.Clone.
+func (b *BarChart) Clone() plot.Plotter {
+	c := *b
+	c.Values = append(Values(nil), b.Values...)
+	cloneDashes(&c.LineStyle, &c.Truncation)
+	return &c
+}
+
+// Relink implements the plot.Relinker interface, stacking
+// the bar chart on the clone of the bar chart it is stacked
+// on, if that is in clones.
+func (b *BarChart) Relink(clones map[plot.Plotter]plot.Plotter) {
+	if on, ok := clones[b.stackedOn].(*BarChart); ok {
+		b.stackedOn = on
+	}
+}
+
+// Clone implements the plot.Cloner interface, returning
+// a copy of the histogram with copies of its bins.
+func (h *Histogram) Clone() plot.Plotter {
+	c := *h
+	cloneDashes(&c.LineStyle)
+	c.Bins = append([]HistogramBin(nil), h.Bins...)
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning a
+// copy of the heat map. Its GridXYZ and Palette, which may
+// be of any type, are shared.
+func (h *HeatMap) Clone() plot.Plotter {
+	c := *h
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning a
+// copy of the labels with copies of their points, texts
+// and styles.
+func (l *Labels) Clone() plot.Plotter {
+	c := *l
+	c.XYs = append(XYs(nil), l.XYs...)
+	c.Labels = append([]string(nil), l.Labels...)
+	c.TextStyle = append([]draw.TextStyle(nil), l.TextStyle...)
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning a
+// copy of the error bars with copies of their points and
+// errors.
+func (e *YErrorBars) Clone() plot.Plotter {
+	c := *e
+	cloneDashes(&c.LineStyle)
+	c.XYs = append(XYs(nil), e.XYs...)
+	c.YErrors = append(YErrors(nil), e.YErrors...)
+	return &c
+}
+
+// Clone implements the plot.Cloner interface, returning a
+// copy of the error bars with copies of their points and
+// errors.
+func (e *XErrorBars) Clone() plot.Plotter {
+	c := *e
+	cloneDashes(&c.LineStyle)
+	c.XYs = append(XYs(nil), e.XYs...)
+	c.XErrors = append(XErrors(nil), e.XErrors...)
+	return &c
+}
+
+// cloneDashes replaces the dashes of the line
+// styles with copies that may be changed in place.
+func cloneDashes(sty ...*draw.LineStyle) {
+	for _, s := range sty {
+		if s.Dashes != nil {
+			s.Dashes = append([]vg.Length(nil), s.Dashes...)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+func TestClone(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "Template"
+	l, err := NewLine(XYs{{X: 0, Y: 1}, {X: 1, Y: 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := NewScatter(XYs{{X: 0, Y: 2}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l, s, NewGrid())
+	p.Legend.Add("line", l)
+	p.Legend.Add("points", s)
+
+	c := p.Clone()
+	c.Title.Text = "Clone"
+	c.X.Max = 10
+	ps := c.Plotters()
+	if len(ps) != 3 {
+		t.Fatalf("unexpected number of plotters: got:%d want:3", len(ps))
+	}
+	cl, ok := ps[0].(*Line)
+	if !ok || cl == l {
+		t.Fatalf("line not cloned: got:%T", ps[0])
+	}
+	cl.XYs[0].Y = 5
+	cl.Color = color.White
+	cs := ps[1].(*Scatter)
+	cs.XYs = append(cs.XYs, struct{ X, Y float64 }{X: 2, Y: 3})
+
+	if p.Title.Text != "Template" || p.X.Max != 1 {
+		t.Errorf("plot changed by its clone: title:%q max:%g", p.Title.Text, p.X.Max)
+	}
+	if want := (XYs{{X: 0, Y: 1}, {X: 1, Y: 2}}); !reflect.DeepEqual(l.XYs, want) {
+		t.Errorf("line points changed by the clone: got:%v want:%v", l.XYs, want)
+	}
+	if l.Color == color.White {
+		t.Error("line style changed by the clone")
+	}
+	if len(s.XYs) != 2 || len(p.Plotters()) != 3 {
+		t.Errorf("scatter or plotters changed by the clone: %d points, %d plotters", len(s.XYs), len(p.Plotters()))
+	}
+
+	for _, test := range []struct {
+		t    plot.Thumbnailer
+		want string
+	}{
+		{t: cl, want: "line"},
+		{t: cs, want: "points"},
+	} {
+		if text, ok := c.Legend.EntryText(test.t); !ok || text != test.want {
+			t.Errorf("unexpected legend entry of clone: got:%q %t want:%q", text, ok, test.want)
+		}
+	}
+	if _, ok := c.Legend.EntryText(l); ok {
+		t.Error("legend of clone refers to the original line")
+	}
+}
+
+func TestCloneStackedBarChart(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bottom, err := NewBarChart(Values{1, 2}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bottom.Dashes = []vg.Length{1, 2}
+	top, err := NewBarChart(Values{3, 4}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	top.StackOn(bottom)
+	p.Add(bottom, top)
+
+	c := p.Clone()
+	ps := c.Plotters()
+	cb, ct := ps[0].(*BarChart), ps[1].(*BarChart)
+	if ct.stackedOn != cb {
+		t.Error("clone not stacked on the clone of its bar chart")
+	}
+	bottom.Values[0] = 10
+	bottom.Dashes[0] = 5
+	if got := ct.BarHeight(0); got != 4 {
+		t.Errorf("clone changed by the original bar chart: got height:%v want:4", got)
+	}
+	if cb.Dashes[0] != 1 {
+		t.Errorf("clone dashes changed by the original: got:%v want:1", cb.Dashes[0])
+	}
+	if top.stackedOn != bottom {
+		t.Error("original bar chart restacked by its clone")
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ColorBar implements the Plotter interface, drawing a bar
+// showing the colors of a ColorMap over its range, as a guide
+// to the colors of a plot such as a heat map. A color bar is
+// usually drawn as the only plotter of a narrow plot placed
+// beside the plot it describes, with the axis along the bar
+// giving the values of the colors and the other axis hidden.
+//
+// The bar fills the data area of the plot, with the range of
+// the ColorMap along the Y axis if it is vertical, or along
+// the X axis otherwise, and a range from zero to one across
+// the bar.
+type ColorBar struct {
+	// ColorMap is the ColorMap shown by the bar.
+	ColorMap palette.ColorMap
+
+	// Vertical specifies whether the bar
+	// is drawn vertically.
+	Vertical bool
+
+	// Colors is the number of colors drawn
+	// along the bar.
+	Colors int
+
+	// Title is the title of the bar, such as the name
+	// of the quantity shown by the colors, drawn beside
+	// a vertical bar, reading upwards, or above a
+	// horizontal bar. If Title and Unit are empty, no
+	// title is drawn.
+	Title string
+
+	// Unit is the unit of the quantity shown by the
+	// colors. If Unit is not empty, it is drawn in
+	// parentheses after the title.
+	Unit string
+
+	// TitleStyle is the style of the title.
+	TitleStyle draw.TextStyle
+
+	// TitlePadding is the distance between
+	// the bar and its title.
+	TitlePadding vg.Length
+}
+
+// NewColorBar returns a vertical color bar of the
+// ColorMap, with the given title and unit.
+func NewColorBar(cmap palette.ColorMap, title, unit string) (*ColorBar, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ColorBar{
+		ColorMap: cmap,
+		Vertical: true,
+		Colors:   255,
+		Title:    title,
+		Unit:     unit,
+		TitleStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YTop,
+		},
+		TitlePadding: vg.Points(5),
+	}, nil
+}
+
+// title returns the text of the title of the bar.
+func (cb *ColorBar) title() string {
+	if cb.Title == "" && cb.Unit == "" {
+		return ""
+	}
+	return plot.WithUnit(cb.Title, cb.Unit)
+}
+
+// Plot implements the plot.Plotter interface.
+func (cb *ColorBar) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	min, max := cb.ColorMap.Min(), cb.ColorMap.Max()
+	n := cb.Colors
+	if n < 1 {
+		n = 1
+	}
+	delta := (max - min) / float64(n)
+	for i := 0; i < n; i++ {
+		lo, hi := min+float64(i)*delta, min+float64(i+1)*delta
+		clr, err := cb.ColorMap.At(math.Min(max, (lo+hi)/2))
+		if err != nil {
+			continue
+		}
+		var r vg.Rectangle
+		if cb.Vertical {
+			r = vg.Rectangle{
+				Min: vg.Point{X: trX(0), Y: trY(lo)},
+				Max: vg.Point{X: trX(1), Y: trY(hi)},
+			}
+		} else {
+			r = vg.Rectangle{
+				Min: vg.Point{X: trX(lo), Y: trY(0)},
+				Max: vg.Point{X: trX(hi), Y: trY(1)},
+			}
+		}
+		// Overlap the edges of the neighbouring
+		// colors to avoid hairline gaps.
+		if i < n-1 {
+			if cb.Vertical {
+				r.Max.Y += 0.5
+			} else {
+				r.Max.X += 0.5
+			}
+		}
+		pts := []vg.Point{r.Min, {X: r.Min.X, Y: r.Max.Y}, r.Max, {X: r.Max.X, Y: r.Min.Y}}
+		c.FillPolygon(clr, c.ClipPolygonXY(pts))
+	}
+
+	t := cb.title()
+	if t == "" {
+		return
+	}
+	sty := cb.TitleStyle
+	sty.XAlign = draw.XCenter
+	if cb.Vertical {
+		// The top of the rotated title
+		// faces the bar.
+		sty.Rotation = math.Pi / 2
+		sty.YAlign = draw.YTop
+		c.FillText(sty, vg.Point{X: trX(1) + cb.TitlePadding, Y: (trY(min) + trY(max)) / 2}, t)
+		return
+	}
+	sty.YAlign = draw.YBottom
+	c.FillText(sty, vg.Point{X: (trX(min) + trX(max)) / 2, Y: trY(1) + cb.TitlePadding - sty.Font.Extents().Descent}, t)
+}
+
+// Unclipped implements the plot.Unclipper interface,
+// allowing the title to be drawn outside the data area.
+func (cb *ColorBar) Unclipped() bool { return true }
+
+// DataRange implements the plot.DataRanger interface.
+func (cb *ColorBar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if cb.Vertical {
+		return 0, 1, cb.ColorMap.Min(), cb.ColorMap.Max()
+	}
+	return cb.ColorMap.Min(), cb.ColorMap.Max(), 0, 1
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the title of the bar.
+func (cb *ColorBar) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	t := cb.title()
+	if t == "" {
+		return nil
+	}
+	h := cb.TitlePadding + cb.TitleStyle.Height(t) - cb.TitleStyle.Font.Extents().Descent
+	if cb.Vertical {
+		return []plot.GlyphBox{{
+			X:         1,
+			Y:         0.5,
+			Rectangle: vg.Rectangle{Max: vg.Point{X: h}},
+		}}
+	}
+	return []plot.GlyphBox{{
+		X:         0.5,
+		Y:         1,
+		Rectangle: vg.Rectangle{Max: vg.Point{Y: h}},
+	}}
+}
@@ -0,0 +1,67 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleColorBar draws a vertical color bar
+// of temperatures with a title and a unit.
+func ExampleColorBar() {
+	cmap := palette.Linear(palette.Radial(9, palette.Blue, palette.Red, 1))
+	cmap.SetMin(-10)
+	cmap.SetMax(30)
+	cb, err := NewColorBar(cmap, "Temperature", "°C")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(cb)
+	p.HideX()
+
+	err = p.Save(80, 250, "testdata/colorBar.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestColorBar(t *testing.T) {
+	checkPlot(ExampleColorBar, t, "colorBar.png")
+}
+
+// ExampleColorBar_horizontal draws a horizontal color
+// bar of elevations above and below sea level.
+func ExampleColorBar_horizontal() {
+	cb, err := NewColorBar(palette.Hypsometric(), "Elevation", "m")
+	if err != nil {
+		log.Panic(err)
+	}
+	cb.Vertical = false
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(cb)
+	p.HideY()
+
+	err = p.Save(300, 70, "testdata/colorBarHorizontal.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestColorBarHorizontal(t *testing.T) {
+	checkPlot(ExampleColorBar_horizontal, t, "colorBarHorizontal.png")
+}
@@ -0,0 +1,94 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// TestConcurrentFigures checks that separate plots sharing
+// color maps, palettes, text styles and fonts can be built
+// and drawn concurrently. It is most useful with -race.
+func TestConcurrentFigures(t *testing.T) {
+	cmap := palette.Linear(palette.Radial(9, palette.Blue, palette.Red, 1))
+	cmap.SetMax(9)
+	pal := palette.Heat(12, 1)
+	fnt, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sty := draw.TextStyle{Font: fnt, XAlign: draw.XCenter}
+	grid := offsetUnitGrid{Data: mat64.NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p, err := plot.New()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			p.Title.Text = fmt.Sprintf("figure %d", i)
+			p.Title.TextStyle = sty
+			p.X.Label.Text = "$x_i$"
+			p.X.Label.Math = true
+
+			// Fonts that are not loaded yet are
+			// loaded by all of the goroutines.
+			bold, err := vg.MakeFont("Courier-Bold", 8)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			p.Legend.TextStyle.Font = bold
+
+			cb, err := NewColorBar(cmap, "value", "")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			h := NewHeatMap(grid, pal)
+			labels, err := NewLabels(XYLabels{
+				XYs:    XYs{{X: 1, Y: 1}},
+				Labels: []string{fmt.Sprint(i)},
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			labels.TextStyle[0] = sty
+			l, err := NewLine(XYs{{X: 0, Y: 0}, {X: 2, Y: float64(i)}})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			p.Add(h, cb, labels, l)
+			p.Legend.Add("line", l)
+
+			for _, format := range []string{"png", "svg", "eps", "pdf"} {
+				w, err := p.WriterTo(3*vg.Inch, 3*vg.Inch, format)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					continue
+				}
+				if _, err := w.WriteTo(ioutil.Discard); err != nil {
+					t.Errorf("unexpected error writing %s: %v", format, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 	"sort"
@@ -160,17 +161,7 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 			}
 
 			style := h.LineStyles[i%len(h.LineStyles)]
-			var col color.Color
-			switch {
-			case z < h.Min:
-				col = h.Underflow
-			case z > h.Max:
-				col = h.Overflow
-			case len(pal) == 0:
-				col = style.Color
-			default:
-				col = pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
-			}
+			col := h.levelColor(z, style, pal, ps)
 			if col != nil && style.Width != 0 {
 				c.SetLineStyle(style)
 				c.SetColor(col)
@@ -180,6 +171,21 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 }
 
+// levelColor returns the color of the contour line of the given
+// style at level z, with the palette pal scaled across the levels
+// by ps. The levels must be sorted.
+func (h *Contour) levelColor(z float64, style draw.LineStyle, pal []color.Color, ps float64) color.Color {
+	switch {
+	case z < h.Min:
+		return h.Underflow
+	case z > h.Max:
+		return h.Overflow
+	case len(pal) == 0:
+		return style.Color
+	}
+	return pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
+}
+
 // naivePlot implements the a naive rendering approach for contours.
 // It is here as a debugging mode since it simply draws line segments
 // generated by conrec without further computation.
@@ -230,6 +236,47 @@ func (h *Contour) naivePlot(c draw.Canvas, plt *plot.Plot) {
 		pa.Close()
 
 		style := h.LineStyles[levelMap[z]%len(h.LineStyles)]
+		col := h.levelColor(z, style, pal, ps)
+		if col != nil && style.Width != 0 {
+			c.SetLineStyle(style)
+			c.SetColor(col)
+			c.Stroke(pa)
+		}
+	})
+}
+
+// BandThumbnailers creates a group of objects that can be used to
+// add legend entries for each band between consecutive contour
+// levels, as well as the labels giving the range of each band.
+// The swatch color of a band is the palette color at the middle
+// of the band, scaled across the levels in the same way as the
+// contour line colors. Bands lying outside the dynamic range
+// defined by Min and Max use the Underflow and Overflow colors.
+func (h *Contour) BandThumbnailers() (legendLabels []string, thumbnailers []plot.Thumbnailer) {
+	levels := make([]float64, 0, len(h.Levels))
+	for _, z := range h.Levels {
+		if !math.IsNaN(z) {
+			levels = append(levels, z)
+		}
+	}
+	if len(levels) < 2 {
+		return nil, nil
+	}
+	sort.Float64s(levels)
+
+	var pal []color.Color
+	if h.Palette != nil {
+		pal = h.Palette.Colors()
+	}
+	// ps is a palette scaling factor matching the one used by Plot.
+	ps := float64(len(pal)-1) / (levels[len(levels)-1] - levels[0])
+
+	legendLabels = make([]string, len(levels)-1)
+	thumbnailers = make([]plot.Thumbnailer, len(levels)-1)
+	for i, lo := range levels[:len(levels)-1] {
+		hi := levels[i+1]
+		z := (lo + hi) / 2
+
 		var col color.Color
 		switch {
 		case z < h.Min:
@@ -237,16 +284,85 @@ func (h *Contour) naivePlot(c draw.Canvas, plt *plot.Plot) {
 		case z > h.Max:
 			col = h.Overflow
 		case len(pal) == 0:
-			col = style.Color
+			col = h.LineStyles[i%len(h.LineStyles)].Color
 		default:
-			col = pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
+			col = pal[int((z-levels[0])*ps+0.5)] // Apply palette scaling.
 		}
-		if col != nil && style.Width != 0 {
-			c.SetLineStyle(style)
-			c.SetColor(col)
-			c.Stroke(pa)
+
+		legendLabels[i] = fmt.Sprintf("%g to %g", lo, hi)
+		thumbnailers[i] = contourBandThumbnailer{Color: col}
+	}
+	return legendLabels, thumbnailers
+}
+
+// LevelThumbnailers creates a group of objects that can be used
+// to add a legend entry for each contour level, drawn with the
+// line style and color of the contour lines of the level, as
+// well as the labels giving the levels. The entries are stacked
+// with the highest level first, as the levels are on the axis of
+// a color bar. LevelThumbnailers sorts the levels as a side
+// effect.
+func (h *Contour) LevelThumbnailers() (legendLabels []string, thumbnailers []plot.Thumbnailer) {
+	if len(h.Levels) == 0 {
+		return nil, nil
+	}
+	sort.Float64s(h.Levels)
+	var pal []color.Color
+	if h.Palette != nil {
+		pal = h.Palette.Colors()
+	}
+	// ps is a palette scaling factor matching the one used by Plot.
+	ps := float64(len(pal)-1) / (h.Levels[len(h.Levels)-1] - h.Levels[0])
+	if len(h.Levels) == 1 {
+		ps = 0
+	}
+	for i := len(h.Levels) - 1; i >= 0; i-- {
+		z := h.Levels[i]
+		if math.IsNaN(z) {
+			continue
 		}
-	})
+		style := h.LineStyles[i%len(h.LineStyles)]
+		style.Color = h.levelColor(z, style, pal, ps)
+		legendLabels = append(legendLabels, fmt.Sprintf("%g", z))
+		thumbnailers = append(thumbnailers, contourLevelThumbnailer{style})
+	}
+	return legendLabels, thumbnailers
+}
+
+// contourLevelThumbnailer implements the Thumbnailer
+// interface for contour levels.
+type contourLevelThumbnailer struct {
+	draw.LineStyle
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface.
+func (t contourLevelThumbnailer) Thumbnail(c *draw.Canvas) {
+	if t.Color == nil || t.Width == 0 {
+		return
+	}
+	y := c.Center().Y
+	c.StrokeLine2(t.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// contourBandThumbnailer implements the Thumbnailer
+// interface for contour bands.
+type contourBandThumbnailer struct {
+	color.Color
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface.
+func (t contourBandThumbnailer) Thumbnail(c *draw.Canvas) {
+	if t.Color == nil {
+		return
+	}
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	poly := c.ClipPolygonY(pts)
+	c.FillPolygon(t.Color, poly)
 }
 
 // DataRange implements the DataRange method
@@ -289,6 +405,17 @@ func isLoop(p vg.Path) bool {
 // on the value of the contour level. contouPaths sorts levels ascending as a
 // side effect.
 func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length) map[float64][]vg.Path {
+	paths := make(map[float64][]vg.Path)
+	for _, c := range contours(m, levels).sorted() {
+		paths[c.z] = append(paths[c.z], c.path(trX, trY))
+	}
+	return paths
+}
+
+// contours returns the set of contours of the data in m cut
+// at the given levels. It sorts levels ascending as a side
+// effect.
+func contours(m GridXYZ, levels []float64) contourSet {
 	sort.Float64s(levels)
 
 	ends := make(map[float64]endMap)
@@ -315,18 +442,40 @@ func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length)
 		c.exciseLoops(conts, true)
 	}
 
-	// Build vg.Paths.
-	paths := make(map[float64][]vg.Path)
-	for c := range conts {
-		paths[c.z] = append(paths[c.z], c.path(trX, trY))
-	}
-
-	return paths
+	return conts
 }
 
 // contourSet hold a working collection of contours.
 type contourSet map[*contour]struct{}
 
+// sorted returns the contours in the set ordered by height
+// and then by their first point, so that drawing is independent
+// of map iteration order.
+func (s contourSet) sorted() []*contour {
+	conts := make([]*contour, 0, len(s))
+	for c := range s {
+		conts = append(conts, c)
+	}
+	sort.Sort(byHeight(conts))
+	return conts
+}
+
+// byHeight sorts contours by height and then by first point.
+type byHeight []*contour
+
+func (c byHeight) Len() int      { return len(c) }
+func (c byHeight) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c byHeight) Less(i, j int) bool {
+	if c[i].z != c[j].z {
+		return c[i].z < c[j].z
+	}
+	a, b := c[i].front(), c[j].front()
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
+
 // endMap holds a working collection of available ends.
 type endMap map[point]*contour
 
@@ -418,6 +567,19 @@ func (c *contour) path(trX, trY func(float64) vg.Length) vg.Path {
 	return pa
 }
 
+// xys returns the points of the contour in order.
+func (c *contour) xys() XYs {
+	xys := make(XYs, 0, len(c.backward)+len(c.forward))
+	for i := len(c.backward) - 1; i >= 0; i-- {
+		p := c.backward[i]
+		xys = append(xys, struct{ X, Y float64 }{X: p.X, Y: p.Y})
+	}
+	for _, p := range c.forward {
+		xys = append(xys, struct{ X, Y float64 }{X: p.X, Y: p.Y})
+	}
+	return xys
+}
+
 // front returns the first point in the contour.
 func (c *contour) front() point { return c.backward[len(c.backward)-1] }
 
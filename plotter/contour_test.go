@@ -7,6 +7,7 @@ package plotter
 import (
 	"flag"
 	"fmt"
+	"image/color"
 	"math"
 	"math/rand"
 	"reflect"
@@ -173,6 +174,178 @@ func TestContourPaths(t *testing.T) {
 	}
 }
 
+func TestContourPathsStable(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	data := make([]float64, 6400)
+	for i := range data {
+		r := float64(i/80) - 40
+		c := float64(i%80) - 40
+
+		data[i] = rnd.NormFloat64()*4 + math.Hypot(r, c)
+	}
+
+	m := unitGrid{mat64.NewDense(80, 80, data)}
+
+	levels := []float64{-1, 3, 7, 9, 13, 15, 19, 23, 27, 31}
+
+	want := contourPaths(m, levels, unity, unity)
+	for i := 0; i < 10; i++ {
+		got := contourPaths(m, levels, unity, unity)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unstable contour paths on iteration %d", i)
+		}
+	}
+}
+
+func TestContourBandThumbnailers(t *testing.T) {
+	m := unitGrid{mat64.NewDense(3, 4, []float64{
+		2, 1, 4, 3,
+		6, 7, 2, 5,
+		9, 10, 11, 12,
+	})}
+
+	pal := palette.Rainbow(5, palette.Blue, palette.Red, 1, 1, 1)
+	c := NewContour(m, []float64{10, 2, 4, 6, 8}, pal)
+	c.Min = 3.5
+	c.Underflow = color.Black
+
+	labels, thumbs := c.BandThumbnailers()
+	wantLabels := []string{"2 to 4", "4 to 6", "6 to 8", "8 to 10"}
+	if !reflect.DeepEqual(labels, wantLabels) {
+		t.Errorf("unexpected labels: got:%q want:%q", labels, wantLabels)
+	}
+	colors := pal.Colors()
+	wantColors := []color.Color{color.Black, colors[2], colors[3], colors[4]}
+	if len(thumbs) != len(wantColors) {
+		t.Fatalf("unexpected number of thumbnailers: got:%d want:%d", len(thumbs), len(wantColors))
+	}
+	for i, th := range thumbs {
+		got := th.(contourBandThumbnailer).Color
+		if got != wantColors[i] {
+			t.Errorf("unexpected color for band %d: got:%v want:%v", i, got, wantColors[i])
+		}
+	}
+
+	c.Levels = []float64{1}
+	labels, thumbs = c.BandThumbnailers()
+	if labels != nil || thumbs != nil {
+		t.Errorf("unexpected bands for single level: got:%q", labels)
+	}
+}
+
+func TestContourLines(t *testing.T) {
+	m := unitGrid{mat64.NewDense(3, 3, []float64{
+		0, 0, 0,
+		0, 2, 0,
+		0, 0, 0,
+	})}
+	c := NewContour(m, []float64{1}, nil)
+	lines := c.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("unexpected number of lines: got:%d want:1", len(lines))
+	}
+	l := lines[0]
+	if l.Level != 1 || !l.Closed || l.XYs[0] != l.XYs[len(l.XYs)-1] {
+		t.Fatalf("unexpected line: got:%+v", l)
+	}
+	for _, p := range l.XYs {
+		// The contour crosses the grid lines half way
+		// to the peak, and the diagonals of the cells
+		// a little nearer to it.
+		if d := math.Hypot(p.X-1, p.Y-1); d < 0.45 || d > 0.5 {
+			t.Errorf("unexpected point off the contour: %v", p)
+		}
+	}
+
+	m = unitGrid{mat64.NewDense(2, 3, []float64{
+		0, 1, 2,
+		0, 1, 2,
+	})}
+	c = NewContour(m, []float64{1.5, 0.5}, nil)
+	c.Max = 1
+	lines = c.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("unexpected number of lines: got:%d want:1", len(lines))
+	}
+	l = lines[0]
+	if l.Level != 0.5 || l.Closed {
+		t.Errorf("unexpected line: got:%+v", l)
+	}
+	for _, p := range l.XYs {
+		if p.X != 0.5 {
+			t.Errorf("unexpected point off the contour: %v", p)
+		}
+	}
+	c.Overflow = color.Black
+	if lines = c.Lines(); len(lines) != 2 || lines[1].Level != 1.5 {
+		t.Errorf("unexpected overflow lines: got:%+v", lines)
+	}
+}
+
+func TestContourLineExport(t *testing.T) {
+	lines := ContourLines{
+		{Level: 1, XYs: XYs{{0, 0.5}, {1, 0.5}}},
+		{Level: 2.5, XYs: XYs{{0, 0}, {1, 0}, {0.5, 1}, {0, 0}}, Closed: true},
+	}
+	wantWKT := []string{
+		"LINESTRING (0 0.5, 1 0.5)",
+		"POLYGON ((0 0, 1 0, 0.5 1, 0 0))",
+	}
+	for i, l := range lines {
+		if got := l.WKT(); got != wantWKT[i] {
+			t.Errorf("unexpected WKT for line %d: got:%q want:%q", i, got, wantWKT[i])
+		}
+	}
+
+	b, err := lines.GeoJSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"type":"FeatureCollection","features":[` +
+		`{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0.5],[1,0.5]]},"properties":{"level":1}},` +
+		`{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[0.5,1],[0,0]]]},"properties":{"level":2.5}}]}`
+	if string(b) != want {
+		t.Errorf("unexpected GeoJSON:\ngot: %s\nwant:%s", b, want)
+	}
+}
+
+func TestContourLevelThumbnailers(t *testing.T) {
+	m := unitGrid{mat64.NewDense(3, 4, []float64{
+		2, 1, 4, 3,
+		6, 7, 2, 5,
+		9, 10, 11, 12,
+	})}
+
+	pal := palette.Rainbow(5, palette.Blue, palette.Red, 1, 1, 1)
+	c := NewContour(m, []float64{10, 2, 4, 6, 8}, pal)
+	c.Min = 3
+	c.Underflow = color.Black
+
+	labels, thumbs := c.LevelThumbnailers()
+	wantLabels := []string{"10", "8", "6", "4", "2"}
+	if !reflect.DeepEqual(labels, wantLabels) {
+		t.Errorf("unexpected labels: got:%q want:%q", labels, wantLabels)
+	}
+	colors := pal.Colors()
+	wantColors := []color.Color{colors[4], colors[3], colors[2], colors[1], color.Black}
+	if len(thumbs) != len(wantColors) {
+		t.Fatalf("unexpected number of thumbnailers: got:%d want:%d", len(thumbs), len(wantColors))
+	}
+	for i, th := range thumbs {
+		got := th.(contourLevelThumbnailer).Color
+		if got != wantColors[i] {
+			t.Errorf("unexpected color for level %s: got:%v want:%v", labels[i], got, wantColors[i])
+		}
+	}
+
+	c.Levels = nil
+	labels, thumbs = c.LevelThumbnailers()
+	if labels != nil || thumbs != nil {
+		t.Errorf("unexpected levels for no levels: got:%q", labels)
+	}
+}
+
 type byLength []vg.Path
 
 func (p byLength) Len() int           { return len(p) }
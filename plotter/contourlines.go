@@ -0,0 +1,138 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ContourLine is a contour line computed for a Contour,
+// in the coordinates of the data.
+type ContourLine struct {
+	// Level is the contour level of the line.
+	Level float64
+
+	// XYs holds the points of the line in order.
+	// The first and last points of a closed line
+	// are the same.
+	XYs XYs
+
+	// Closed specifies whether the line is a
+	// closed loop, the boundary of a polygon.
+	Closed bool
+}
+
+// ContourLines is a set of contour lines.
+type ContourLines []ContourLine
+
+// Lines returns the contour lines stroked by Plot, ordered by
+// level and then by their first points, so that the lines
+// plotted can be exported for further analysis. Lines sorts
+// the levels as a side effect.
+func (h *Contour) Lines() ContourLines {
+	var lines ContourLines
+	for c := range contours(h.GridXYZ, h.Levels) {
+		// Lines outside of the dynamic range are only
+		// drawn if they have an Underflow or Overflow
+		// color.
+		if c.z < h.Min && h.Underflow == nil || c.z > h.Max && h.Overflow == nil {
+			continue
+		}
+		xys := c.xys()
+		lines = append(lines, ContourLine{
+			Level:  c.z,
+			XYs:    xys,
+			Closed: len(xys) > 2 && xys[0] == xys[len(xys)-1],
+		})
+	}
+	sort.Sort(byLevel(lines))
+	return lines
+}
+
+// byLevel sorts contour lines by level and then
+// by the X and Y values of their first points.
+type byLevel ContourLines
+
+func (l byLevel) Len() int      { return len(l) }
+func (l byLevel) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+func (l byLevel) Less(i, j int) bool {
+	if l[i].Level != l[j].Level {
+		return l[i].Level < l[j].Level
+	}
+	a, b := l[i].XYs[0], l[j].XYs[0]
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
+
+// WKT returns the line in the well-known text format, as a
+// LINESTRING, or a POLYGON if the line is closed.
+func (l ContourLine) WKT() string {
+	var buf bytes.Buffer
+	if l.Closed {
+		buf.WriteString("POLYGON ((")
+	} else {
+		buf.WriteString("LINESTRING (")
+	}
+	for i, p := range l.XYs {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s %s", formatCoord(p.X), formatCoord(p.Y))
+	}
+	if l.Closed {
+		buf.WriteString("))")
+	} else {
+		buf.WriteString(")")
+	}
+	return buf.String()
+}
+
+// formatCoord formats a coordinate with the fewest
+// digits that represent it exactly.
+func formatCoord(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// GeoJSON returns the lines as a GeoJSON FeatureCollection,
+// with a LineString or, if the line is closed, a Polygon
+// feature for each line, whose "level" property holds the
+// contour level of the line.
+func (ls ContourLines) GeoJSON() ([]byte, error) {
+	type geometry struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string             `json:"type"`
+		Geometry   geometry           `json:"geometry"`
+		Properties map[string]float64 `json:"properties"`
+	}
+	fc := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{Type: "FeatureCollection", Features: make([]feature, len(ls))}
+	for i, l := range ls {
+		coords := make([][2]float64, len(l.XYs))
+		for j, p := range l.XYs {
+			coords[j] = [2]float64{p.X, p.Y}
+		}
+		g := geometry{Type: "LineString", Coordinates: coords}
+		if l.Closed {
+			g = geometry{Type: "Polygon", Coordinates: [][][2]float64{coords}}
+		}
+		fc.Features[i] = feature{
+			Type:       "Feature",
+			Geometry:   g,
+			Properties: map[string]float64{"level": l.Level},
+		}
+	}
+	return json.Marshal(fc)
+}
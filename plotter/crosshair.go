@@ -0,0 +1,171 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"strconv"
+	"sync"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Crosshair implements the Plotter interface, drawing a vertical
+// and a horizontal line across the data area through a point,
+// with readouts of the coordinates of the point along the bottom
+// and the left edges of the data area.
+//
+// A Crosshair is meant to follow the pointer of an interactive
+// application: the application moves it with MoveTo or SetPosition,
+// which may be called concurrently with drawing the plot, and draws
+// the plot again. A Crosshair is drawn above the other plotters of
+// the plot, in layer 1, and does not change the ranges of the axes.
+type Crosshair struct {
+	// LineStyle is the style of the lines.
+	draw.LineStyle
+
+	// TextStyle is the style of the readouts.
+	TextStyle draw.TextStyle
+
+	// Box is the style of the boxes drawn
+	// behind the readouts.
+	Box draw.BoxStyle
+
+	// FormatX and FormatY return the readouts of the
+	// X and Y coordinates of the point. If they are nil,
+	// the coordinates are formatted with four significant
+	// digits. Readouts that are empty are not drawn.
+	FormatX, FormatY func(v float64) string
+
+	// mu guards the position of the crosshair.
+	mu sync.Mutex
+
+	// x and y are the coordinates of the point, which is
+	// drawn only if visible is true.
+	x, y    float64
+	visible bool
+}
+
+// NewCrosshair returns a hidden Crosshair using the default
+// line style and the DefaultFont and DefaultFontSize for its
+// readouts, drawn on a white background.
+func NewCrosshair() (*Crosshair, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Crosshair{
+		LineStyle: DefaultLineStyle,
+		TextStyle: draw.TextStyle{Color: color.Black, Font: fnt},
+		Box: draw.BoxStyle{
+			Padding:    vg.Points(2),
+			Background: color.White,
+		},
+	}, nil
+}
+
+// SetPosition shows the crosshair at the point (x, y),
+// given in the data coordinate system.
+func (ch *Crosshair) SetPosition(x, y float64) {
+	ch.mu.Lock()
+	ch.x, ch.y, ch.visible = x, y, true
+	ch.mu.Unlock()
+}
+
+// MoveTo shows the crosshair at the point pt of the canvas c
+// on which the plot p is drawn, or hides it if pt is outside
+// the data area of the plot.
+func (ch *Crosshair) MoveTo(c draw.Canvas, p *plot.Plot, pt vg.Point) {
+	x, y, ok := p.DataValue(c, pt)
+	if !ok {
+		ch.Hide()
+		return
+	}
+	ch.SetPosition(x, y)
+}
+
+// Hide hides the crosshair, as when the pointer
+// leaves the plot.
+func (ch *Crosshair) Hide() {
+	ch.mu.Lock()
+	ch.visible = false
+	ch.mu.Unlock()
+}
+
+// Position returns the point at which the crosshair is
+// shown, and whether it is shown.
+func (ch *Crosshair) Position() (x, y float64, ok bool) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	return ch.x, ch.y, ch.visible
+}
+
+// Layer implements the plot.Layerer interface,
+// drawing the crosshair above the data.
+func (ch *Crosshair) Layer() int { return 1 }
+
+// Plot implements the Plotter interface, drawing the
+// crosshair and its readouts if it is shown.
+func (ch *Crosshair) Plot(c draw.Canvas, plt *plot.Plot) {
+	x, y, ok := ch.Position()
+	if !ok {
+		return
+	}
+	trX, trY := plt.Transforms(&c)
+	px, py := trX(x), trY(y)
+	if c.ContainsX(px) {
+		c.StrokeLine2(ch.LineStyle, px, c.Min.Y, px, c.Max.Y)
+	}
+	if c.ContainsY(py) {
+		c.StrokeLine2(ch.LineStyle, c.Min.X, py, c.Max.X, py)
+	}
+
+	in := ch.Box.Inset()
+	if c.ContainsX(px) {
+		if txt := ch.format(ch.FormatX, x); txt != "" {
+			sty := ch.TextStyle
+			sty.XAlign, sty.YAlign = draw.XCenter, draw.YBottom
+			ch.readout(c, sty, vg.Point{X: px, Y: c.Min.Y + in}, txt)
+		}
+	}
+	if c.ContainsY(py) {
+		if txt := ch.format(ch.FormatY, y); txt != "" {
+			sty := ch.TextStyle
+			sty.XAlign, sty.YAlign = draw.XLeft, draw.YCenter
+			ch.readout(c, sty, vg.Point{X: c.Min.X + in, Y: py}, txt)
+		}
+	}
+}
+
+// format returns the readout of the coordinate v.
+func (ch *Crosshair) format(f func(float64) string, v float64) string {
+	if f != nil {
+		return f(v)
+	}
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+// readout draws the readout txt at pt in its box, moved
+// along the edge of the canvas c to be kept inside it.
+func (ch *Crosshair) readout(c draw.Canvas, sty draw.TextStyle, pt vg.Point, txt string) {
+	r := sty.Rectangle(txt)
+	in := ch.Box.Inset()
+	if min := c.Min.X + in - r.Min.X; pt.X < min {
+		pt.X = min
+	}
+	if max := c.Max.X - in - r.Max.X; pt.X > max {
+		pt.X = max
+	}
+	if min := c.Min.Y + in - r.Min.Y; pt.Y < min {
+		pt.Y = min
+	}
+	if max := c.Max.Y - in - r.Max.Y; pt.Y > max {
+		pt.Y = max
+	}
+	c.DrawBox(ch.Box, vg.Rectangle{Min: r.Min.Add(pt), Max: r.Max.Add(pt)})
+	c.FillText(sty, pt, txt)
+}
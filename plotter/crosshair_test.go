@@ -0,0 +1,102 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestCrosshair(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := NewLine(XYs{{X: 0, Y: 0}, {X: 10, Y: 100}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ch, err := NewCrosshair()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ch.FormatY = func(v float64) string { return "y" }
+	p.Add(ch, l)
+
+	readouts := func() []string {
+		var r recorder.Canvas
+		p.Draw(draw.NewCanvas(&r, 300, 200))
+		var strs []string
+		for _, a := range r.Actions {
+			if s, ok := a.(*recorder.FillString); ok && (s.String == "y" || s.String == "2.5") {
+				strs = append(strs, s.String)
+			}
+		}
+		return strs
+	}
+
+	if got := readouts(); got != nil {
+		t.Errorf("unexpected readouts of hidden crosshair: %q", got)
+	}
+
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	dc := p.DataCanvas(c)
+	size := dc.Size()
+	ch.MoveTo(c, p, vg.Point{X: dc.Min.X + size.X/4, Y: dc.Min.Y + size.Y/2})
+	x, y, ok := ch.Position()
+	if !ok || math.Abs(x-2.5) > 1e-9 || math.Abs(y-50) > 1e-9 {
+		t.Errorf("unexpected position: got:(%v, %v, %t) want:(2.5, 50, true)", x, y, ok)
+	}
+	if got, want := readouts(), []string{"2.5", "y"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected readouts: got:%q want:%q", got, want)
+	}
+
+	ch.MoveTo(c, p, vg.Point{X: dc.Min.X - 1, Y: dc.Min.Y})
+	if _, _, ok := ch.Position(); ok {
+		t.Error("crosshair shown outside the data area")
+	}
+}
+
+// TestCrosshairConcurrent checks that a crosshair may follow the
+// pointer while the plot is panned and drawn. It is most useful
+// with -race.
+func TestCrosshairConcurrent(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := NewLine(XYs{{X: 0, Y: 0}, {X: 10, Y: 100}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ch, err := NewCrosshair()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(ch, l)
+	v := plot.NewViewport(p)
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	dc := p.DataCanvas(c)
+	mid := vg.Point{X: (dc.Min.X + dc.Max.X) / 2, Y: (dc.Min.Y + dc.Max.Y) / 2}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ch.MoveTo(c, p, mid)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		v.Pan(0.01, 0.01)
+		p.Draw(c)
+	}
+	<-done
+}
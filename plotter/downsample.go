@@ -0,0 +1,263 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot"
+)
+
+// Size implements the plot.Sizer interface.
+func (pts *Line) Size() (points, cells int) {
+	return len(pts.XYs), 0
+}
+
+// Downsample implements the plot.Downsampler interface,
+// keeping the first and last points and the points of
+// least and greatest Y value in each of equal runs of
+// the other points, so that the peaks of the line are
+// drawn.
+func (pts *Line) Downsample(points, cells int) plot.Plotter {
+	if points <= 0 || len(pts.XYs) <= points {
+		return pts
+	}
+	l := *pts
+	idx := minMaxIndices(pts.XYs, points)
+	l.XYs = make(XYs, len(idx))
+	if pts.Values != nil {
+		l.Values = make([]float64, len(idx))
+	}
+	for i, j := range idx {
+		l.XYs[i] = pts.XYs[j]
+		if pts.Values != nil {
+			l.Values[i] = pts.Values[j]
+		}
+	}
+	return &l
+}
+
+// minMaxIndices returns the indices, in order, of at most n
+// of the points: the first and last, and the least and the
+// greatest in Y of each of equal runs of the other points.
+func minMaxIndices(xys XYs, n int) []int {
+	if n < 2 {
+		n = 2
+	}
+	inner := xys[1 : len(xys)-1]
+	runs := (n - 2) / 2
+	idx := []int{0}
+	for r := 0; r < runs; r++ {
+		lo, hi := r*len(inner)/runs, (r+1)*len(inner)/runs
+		if lo == hi {
+			continue
+		}
+		min, max := lo, lo
+		for i := lo; i < hi; i++ {
+			if inner[i].Y < inner[min].Y {
+				min = i
+			}
+			if inner[i].Y > inner[max].Y {
+				max = i
+			}
+		}
+		switch {
+		case min == max:
+			idx = append(idx, min+1)
+		case min < max:
+			idx = append(idx, min+1, max+1)
+		default:
+			idx = append(idx, max+1, min+1)
+		}
+	}
+	return append(idx, len(xys)-1)
+}
+
+// Size implements the plot.Sizer interface.
+func (pts *Scatter) Size() (points, cells int) {
+	return len(pts.XYs), 0
+}
+
+// Downsample implements the plot.Downsampler interface,
+// keeping evenly spaced points in order.
+func (pts *Scatter) Downsample(points, cells int) plot.Plotter {
+	if points <= 0 || len(pts.XYs) <= points {
+		return pts
+	}
+	s := *pts
+	s.XYs = make(XYs, points)
+	for i := range s.XYs {
+		s.XYs[i] = pts.XYs[i*len(pts.XYs)/points]
+	}
+	return &s
+}
+
+// Size implements the plot.Sizer interface.
+func (h *HeatMap) Size() (points, cells int) {
+	c, r := h.GridXYZ.Dims()
+	return 0, c * r
+}
+
+// Downsample implements the plot.Downsampler interface,
+// drawing every kth column and row of the grid.
+func (h *HeatMap) Downsample(points, cells int) plot.Plotter {
+	g, ok := strideGridOf(h.GridXYZ, cells)
+	if !ok {
+		return h
+	}
+	m := *h
+	m.GridXYZ = g
+	return &m
+}
+
+// Size implements the plot.Sizer interface.
+func (h *Contour) Size() (points, cells int) {
+	c, r := h.GridXYZ.Dims()
+	return 0, c * r
+}
+
+// Downsample implements the plot.Downsampler interface,
+// contouring every kth column and row of the grid.
+func (h *Contour) Downsample(points, cells int) plot.Plotter {
+	g, ok := strideGridOf(h.GridXYZ, cells)
+	if !ok {
+		return h
+	}
+	m := *h
+	m.GridXYZ = g
+	m.Levels = append([]float64(nil), h.Levels...)
+	return &m
+}
+
+// strideGrid is a GridXYZ of every kth
+// column and row of a grid, including
+// its last column and row.
+type strideGrid struct {
+	GridXYZ
+	k, cols, rows int
+}
+
+// strideGridOf returns a strideGrid of g of at most
+// cells cells, and whether g has more cells than that.
+func strideGridOf(g GridXYZ, cells int) (GridXYZ, bool) {
+	c, r := g.Dims()
+	if cells <= 0 || c*r <= cells {
+		return g, false
+	}
+	k := int(math.Ceil(math.Sqrt(float64(c*r) / float64(cells))))
+	s := &strideGrid{GridXYZ: g, k: k}
+	for {
+		s.cols, s.rows = (c+k-1)/k, (r+k-1)/k
+		// The last column and row are kept,
+		// adding one of each if they do not
+		// fall on the stride.
+		if (c-1)%k != 0 {
+			s.cols++
+		}
+		if (r-1)%k != 0 {
+			s.rows++
+		}
+		if s.cols*s.rows <= cells || s.cols <= 2 && s.rows <= 2 {
+			return s, true
+		}
+		k++
+		s.k = k
+	}
+}
+
+// index returns the index in the grid
+// of the ith of n strided indices.
+func (g *strideGrid) index(i, n, last int) int {
+	if i == n-1 {
+		return last
+	}
+	return i * g.k
+}
+
+// Dims implements the GridXYZ interface.
+func (g *strideGrid) Dims() (c, r int) { return g.cols, g.rows }
+
+// Z implements the GridXYZ interface.
+func (g *strideGrid) Z(c, r int) float64 {
+	cols, rows := g.GridXYZ.Dims()
+	return g.GridXYZ.Z(g.index(c, g.cols, cols-1), g.index(r, g.rows, rows-1))
+}
+
+// X implements the GridXYZ interface.
+func (g *strideGrid) X(c int) float64 {
+	cols, _ := g.GridXYZ.Dims()
+	return g.GridXYZ.X(g.index(c, g.cols, cols-1))
+}
+
+// Y implements the GridXYZ interface.
+func (g *strideGrid) Y(r int) float64 {
+	_, rows := g.GridXYZ.Dims()
+	return g.GridXYZ.Y(g.index(r, g.rows, rows-1))
+}
+
+// LTTB returns at most n of the points of data, chosen by the
+// Largest-Triangle-Three-Buckets algorithm to keep the visual
+// shape of a line through them, for drawing series of many
+// more points than can be seen, such as long high-frequency
+// time series, quickly and in small vector images. The points
+// should be in order of X.
+//
+// The first and the last points are kept, and the others are
+// split into n-2 buckets of equal numbers of points. The point
+// kept from each bucket is the one making the largest triangle
+// with the point kept from the bucket before it and the mean of
+// the points of the bucket after it. All of the points are
+// returned if n is not less than their number or is zero.
+func LTTB(data XYer, n int) (XYs, error) {
+	xys, err := CopyXYs(data)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 || n >= len(xys) {
+		return xys, nil
+	}
+	if n < 3 {
+		if n == 1 {
+			return xys[:1], nil
+		}
+		return XYs{xys[0], xys[len(xys)-1]}, nil
+	}
+
+	sampled := make(XYs, 0, n)
+	sampled = append(sampled, xys[0])
+	every := float64(len(xys)-2) / float64(n-2)
+	a := 0
+	for i := 0; i < n-2; i++ {
+		// The mean of the next bucket, or the
+		// last point for the last bucket.
+		lo := int(float64(i+1)*every) + 1
+		hi := int(float64(i+2)*every) + 1
+		if hi > len(xys) {
+			hi = len(xys)
+		}
+		var mx, my float64
+		for _, p := range xys[lo:hi] {
+			mx += p.X
+			my += p.Y
+		}
+		mx /= float64(hi - lo)
+		my /= float64(hi - lo)
+
+		// The point of this bucket making the
+		// largest triangle with the point a and
+		// the mean of the next bucket.
+		from, to := int(float64(i)*every)+1, lo
+		best, max := from, -1.0
+		for j := from; j < to; j++ {
+			area := math.Abs((xys[a].X-mx)*(xys[j].Y-xys[a].Y) - (xys[a].X-xys[j].X)*(my-xys[a].Y))
+			if area > max {
+				best, max = j, area
+			}
+		}
+		sampled = append(sampled, xys[best])
+		a = best
+	}
+	return append(sampled, xys[len(xys)-1]), nil
+}
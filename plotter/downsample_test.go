@@ -0,0 +1,123 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestDownsample(t *testing.T) {
+	xys := make(XYs, 10)
+	for i := range xys {
+		xys[i].X = float64(i)
+	}
+	xys[3].Y, xys[4].Y, xys[7].Y = 5, -5, 2
+
+	l, err := NewLine(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Values = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	got := l.Downsample(6, 0).(*Line)
+	// The peaks of each half of the inner
+	// points are kept, in order.
+	want := XYs{{0, 0}, {3, 5}, {4, -5}, {5, 0}, {7, 2}, {9, 0}}
+	if !reflect.DeepEqual(got.XYs, want) {
+		t.Errorf("unexpected line points: got:%v want:%v", got.XYs, want)
+	}
+	if want := []float64{0, 3, 4, 5, 7, 9}; !reflect.DeepEqual(got.Values, want) {
+		t.Errorf("unexpected line values: got:%v want:%v", got.Values, want)
+	}
+	if len(l.XYs) != 10 {
+		t.Error("downsampling modified the line")
+	}
+	if l.Downsample(20, 0) != l {
+		t.Error("line downsampled within the limit")
+	}
+
+	s, err := NewScatter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.Downsample(5, 0).(*Scatter).XYs; len(got) != 5 || got[1].X != 2 || got[4].X != 8 {
+		t.Errorf("unexpected scatter points: got:%v", got)
+	}
+
+	m := unitGrid{mat64.NewDense(5, 7, nil)}
+	h := NewHeatMap(m, nil)
+	if _, cells := h.Size(); cells != 35 {
+		t.Errorf("unexpected number of cells: got:%d want:35", cells)
+	}
+	g := h.Downsample(0, 9).(*HeatMap).GridXYZ
+	c, r := g.Dims()
+	if c*r > 9 {
+		t.Errorf("too many cells: got:%dx%d", c, r)
+	}
+	var xs, ys []float64
+	for i := 0; i < c; i++ {
+		xs = append(xs, g.X(i))
+	}
+	for i := 0; i < r; i++ {
+		ys = append(ys, g.Y(i))
+	}
+	// The first and last columns and rows are kept.
+	if want := []float64{0, 3, 6}; !reflect.DeepEqual(xs, want) {
+		t.Errorf("unexpected columns: got:%v want:%v", xs, want)
+	}
+	if want := []float64{0, 3, 4}; !reflect.DeepEqual(ys, want) {
+		t.Errorf("unexpected rows: got:%v want:%v", ys, want)
+	}
+}
+
+func TestLTTB(t *testing.T) {
+	xys := make(XYs, 1000)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = math.Sin(float64(i) / 50)
+	}
+	xys[321].Y = 10
+	xys[654].Y = -10
+
+	got, err := LTTB(xys, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 100 {
+		t.Fatalf("unexpected number of points: got:%d want:100", len(got))
+	}
+	if got[0] != xys[0] || got[99] != xys[999] {
+		t.Errorf("end points not kept: got:%v and %v", got[0], got[99])
+	}
+	var peaks int
+	for i, p := range got {
+		if i > 0 && p.X <= got[i-1].X {
+			t.Errorf("points out of order at %d: %v after %v", i, p, got[i-1])
+		}
+		if math.Abs(p.Y) == 10 {
+			peaks++
+		}
+	}
+	// The spikes make the largest triangles
+	// of their buckets, so they are kept.
+	if peaks != 2 {
+		t.Errorf("unexpected number of spikes kept: got:%d want:2", peaks)
+	}
+
+	for _, n := range []int{0, 1000, 2000} {
+		if got, _ := LTTB(xys, n); len(got) != len(xys) {
+			t.Errorf("unexpected number of points for n=%d: got:%d want:%d", n, len(got), len(xys))
+		}
+	}
+	if got, _ := LTTB(xys, 2); !reflect.DeepEqual(got, XYs{xys[0], xys[999]}) {
+		t.Errorf("unexpected points for n=2: got:%v", got)
+	}
+	if _, err := LTTB(XYs{{0, math.NaN()}}, 1); err == nil {
+		t.Error("expected error for NaN point")
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ellipseSegments is the number of line
+// segments drawn for each ellipse.
+const ellipseSegments = 90
+
+// ErrorEllipse implements the Plotter interface, drawing the
+// ellipses of constant probability density of a bivariate
+// normal distribution with a given mean and covariance, such
+// as the uncertainty of an estimate of a position, at the
+// Mahalanobis distances given by Sigmas.
+type ErrorEllipse struct {
+	// X and Y are the mean of the distribution,
+	// the center of the ellipses.
+	X, Y float64
+
+	// VarX, VarY and Cov are the variances and the
+	// covariance of the distribution.
+	VarX, VarY, Cov float64
+
+	// Sigmas holds the distances, in standard
+	// deviations, of the ellipses from the mean.
+	Sigmas []float64
+
+	// LineStyle is the style of the ellipses.
+	draw.LineStyle
+
+	// FillColor, if not nil, is the color with which
+	// the ellipses are filled, the largest first, so
+	// that translucent fills deepen towards the mean.
+	FillColor color.Color
+}
+
+// NewErrorEllipse returns an ErrorEllipse of the distribution
+// with mean (x, y) and the 2×2 covariance matrix cov, drawn at
+// the given numbers of standard deviations, or at one, two and
+// three if none are given. An error is returned if cov is not
+// a symmetric positive semi-definite 2×2 matrix.
+func NewErrorEllipse(x, y float64, cov mat64.Matrix, sigmas ...float64) (*ErrorEllipse, error) {
+	if r, c := cov.Dims(); r != 2 || c != 2 {
+		return nil, errors.New("Covariance matrix is not 2×2")
+	}
+	vx, vy, cxy := cov.At(0, 0), cov.At(1, 1), cov.At(0, 1)
+	if err := CheckFloats(x, y, vx, vy, cxy); err != nil {
+		return nil, err
+	}
+	if cxy != cov.At(1, 0) {
+		return nil, errors.New("Covariance matrix is not symmetric")
+	}
+	if vx < 0 || vy < 0 || vx*vy < cxy*cxy {
+		return nil, errors.New("Covariance matrix is not positive semi-definite")
+	}
+	if len(sigmas) == 0 {
+		sigmas = []float64{1, 2, 3}
+	}
+	for _, s := range sigmas {
+		if !(s > 0) || math.IsInf(s, 1) {
+			return nil, errors.New("Invalid ellipse sigma")
+		}
+	}
+	return &ErrorEllipse{
+		X: x, Y: y,
+		VarX: vx, VarY: vy, Cov: cxy,
+		Sigmas:    append([]float64(nil), sigmas...),
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// ConfidenceSigma returns the number of standard deviations,
+// the Mahalanobis distance, of the ellipse of a bivariate normal
+// distribution holding the given probability, such as 0.95, for
+// use in the Sigmas of an ErrorEllipse.
+func ConfidenceSigma(p float64) float64 {
+	return math.Sqrt(-2 * math.Log1p(-p))
+}
+
+// axes returns the semi-axes of the one sigma
+// ellipse and the angle of the first in radians
+// anticlockwise from the X axis.
+func (e *ErrorEllipse) axes() (a, b, theta float64) {
+	mid := (e.VarX + e.VarY) / 2
+	d := math.Hypot((e.VarX-e.VarY)/2, e.Cov)
+	a = math.Sqrt(mid + d)
+	b = math.Sqrt(math.Max(0, mid-d))
+	theta = math.Atan2(2*e.Cov, e.VarX-e.VarY) / 2
+	return a, b, theta
+}
+
+// Points returns the points of the ellipse
+// at k standard deviations from the mean.
+func (e *ErrorEllipse) Points(k float64) XYs {
+	a, b, theta := e.axes()
+	sinT, cosT := math.Sincos(theta)
+	pts := make(XYs, ellipseSegments+1)
+	for i := range pts {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / ellipseSegments)
+		u, v := k*a*cos, k*b*sin
+		pts[i].X = e.X + u*cosT - v*sinT
+		pts[i].Y = e.Y + u*sinT + v*cosT
+	}
+	return pts
+}
+
+// Plot implements the Plotter interface.
+func (e *ErrorEllipse) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	sigmas := append([]float64(nil), e.Sigmas...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sigmas)))
+	for _, k := range sigmas {
+		xys := e.Points(k)
+		pts := make([]vg.Point, len(xys))
+		for i, p := range xys {
+			pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		}
+		if e.FillColor != nil {
+			c.FillPolygon(e.FillColor, c.ClipPolygonXY(pts))
+		}
+		if e.LineStyle.Color != nil && e.LineStyle.Width > 0 {
+			c.StrokeLines(e.LineStyle, c.ClipLinesXY(pts)...)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface,
+// returning the bounding box of the largest ellipse.
+func (e *ErrorEllipse) DataRange() (xmin, xmax, ymin, ymax float64) {
+	var k float64
+	for _, s := range e.Sigmas {
+		k = math.Max(k, s)
+	}
+	dx, dy := k*math.Sqrt(e.VarX), k*math.Sqrt(e.VarY)
+	return e.X - dx, e.X + dx, e.Y - dy, e.Y + dy
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (e *ErrorEllipse) Thumbnail(c *draw.Canvas) {
+	ctr := c.Center()
+	w, h := (c.Max.X-c.Min.X)/2, (c.Max.Y-c.Min.Y)/2
+	pts := make([]vg.Point, ellipseSegments+1)
+	for i := range pts {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / ellipseSegments)
+		pts[i] = vg.Point{X: ctr.X + w*vg.Length(cos), Y: ctr.Y + h*vg.Length(sin)}
+	}
+	if e.FillColor != nil {
+		c.FillPolygon(e.FillColor, pts)
+	}
+	if e.LineStyle.Color != nil && e.LineStyle.Width > 0 {
+		c.StrokeLines(e.LineStyle, pts)
+	}
+}
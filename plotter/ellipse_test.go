@@ -0,0 +1,120 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ExampleErrorEllipse draws correlated measurements with
+// the ellipses of one, two and three standard deviations
+// of the distribution from which they are drawn.
+func ExampleErrorEllipse() {
+	rnd := rand.New(rand.NewSource(1))
+	cov := mat64.NewSymDense(2, []float64{
+		4, 3,
+		3, 4,
+	})
+	var chol mat64.Cholesky
+	if !chol.Factorize(cov) {
+		log.Panic("covariance is not positive definite")
+	}
+	var l mat64.TriDense
+	l.LFromCholesky(&chol)
+	pts := make(XYs, 200)
+	for i := range pts {
+		z0, z1 := rnd.NormFloat64(), rnd.NormFloat64()
+		pts[i].X = 10 + l.At(0, 0)*z0
+		pts[i].Y = 5 + l.At(1, 0)*z0 + l.At(1, 1)*z1
+	}
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Shape = draw.CircleGlyph{}
+	s.Radius = vg.Points(1.5)
+	e, err := NewErrorEllipse(10, 5, cov)
+	if err != nil {
+		log.Panic(err)
+	}
+	e.Color = color.RGBA{R: 196, B: 64, A: 255}
+	e.FillColor = color.NRGBA{R: 196, B: 64, A: 32}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Error ellipses"
+	p.Add(e, s)
+	p.Legend.Add("1, 2, 3σ", e)
+
+	err = p.Save(250, 250, "testdata/errorEllipse.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestErrorEllipse(t *testing.T) {
+	checkPlot(ExampleErrorEllipse, t, "errorEllipse.png")
+}
+
+func TestErrorEllipsePoints(t *testing.T) {
+	cov := mat64.NewDense(2, 2, []float64{
+		5, 3,
+		3, 5,
+	})
+	e, err := NewErrorEllipse(1, 2, cov, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The eigenvalues are 8 along the diagonal
+	// and 2 across it.
+	a, b, theta := e.axes()
+	if math.Abs(a-math.Sqrt(8)) > 1e-12 || math.Abs(b-math.Sqrt(2)) > 1e-12 || math.Abs(theta-math.Pi/4) > 1e-12 {
+		t.Errorf("unexpected axes: got:(%v, %v, %v) want:(%v, %v, %v)", a, b, theta, math.Sqrt(8), math.Sqrt(2), math.Pi/4)
+	}
+	// Each point of the ellipse is at a Mahalanobis
+	// distance of two from the mean.
+	var inv mat64.Dense
+	if err := inv.Inverse(cov); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range e.Points(2) {
+		dx, dy := p.X-1, p.Y-2
+		d2 := dx*dx*inv.At(0, 0) + 2*dx*dy*inv.At(0, 1) + dy*dy*inv.At(1, 1)
+		if math.Abs(d2-4) > 1e-9 {
+			t.Errorf("unexpected squared distance of point %d: got:%v want:4", i, d2)
+		}
+	}
+	xmin, xmax, ymin, ymax := e.DataRange()
+	w := 2 * math.Sqrt(5)
+	if math.Abs(xmin-(1-w)) > 1e-12 || math.Abs(xmax-(1+w)) > 1e-12 || math.Abs(ymin-(2-w)) > 1e-12 || math.Abs(ymax-(2+w)) > 1e-12 {
+		t.Errorf("unexpected data range: got:[%v %v]×[%v %v]", xmin, xmax, ymin, ymax)
+	}
+
+	if got, want := ConfidenceSigma(1-math.Exp(-2)), 2.0; math.Abs(got-want) > 1e-12 {
+		t.Errorf("unexpected confidence sigma: got:%v want:%v", got, want)
+	}
+
+	for _, bad := range []mat64.Matrix{
+		mat64.NewDense(2, 2, []float64{1, 2, 3, 4}),
+		mat64.NewDense(2, 2, []float64{1, 2, 2, 1}),
+		mat64.NewDense(3, 3, nil),
+	} {
+		if _, err := NewErrorEllipse(0, 0, bad); err == nil {
+			t.Errorf("expected error for covariance %v", mat64.Formatted(bad))
+		}
+	}
+}
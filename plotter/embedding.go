@@ -0,0 +1,440 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Embedding implements the Plotter interface, drawing a
+// two dimensional embedding of labeled data, such as the
+// output of t-SNE or UMAP. Each point is drawn in the color
+// of its class, with optional density contours for each
+// class and labels at the centers of the classes.
+//
+// Large embeddings are downsampled when drawn, keeping the
+// proportion of points in each class, so embeddings of
+// millions of points can be plotted.
+type Embedding struct {
+	// XYs is a copy of the points of the embedding.
+	XYs
+
+	// Classes holds the class of each point,
+	// an index into Names.
+	Classes []int
+
+	// Names holds the name of each class.
+	Names []string
+
+	// Palette is the palette used to color the classes.
+	// Colors are applied to each class in order, modulo
+	// the number of colors. If Palette is nil, the
+	// GlyphStyle color is used for all classes.
+	Palette palette.Palette
+
+	// GlyphStyle is the style of the points.
+	GlyphStyle draw.GlyphStyle
+
+	// MaxPoints is the maximum number of points drawn.
+	// If there are more points in the embedding, evenly
+	// spaced points of each class are drawn. If MaxPoints
+	// is zero or less, all points are drawn.
+	MaxPoints int
+
+	// Levels holds the heights of the density contours
+	// drawn for each class, as fractions of the peak
+	// density of the class. If Levels is nil, no
+	// contours are drawn.
+	Levels []float64
+
+	// ContourStyle is the style of the density contours.
+	// If its color is nil, the color of the class is used.
+	ContourStyle draw.LineStyle
+
+	// Bins is the number of bins along each axis of
+	// the grid on which densities are estimated.
+	Bins int
+
+	// Bandwidth is the standard deviation, in bins,
+	// of the Gaussian kernel used to smooth densities.
+	Bandwidth float64
+
+	// Labels is whether the name of each class is
+	// drawn at the median of its points.
+	Labels bool
+
+	// LabelStyle is the style of the class labels.
+	LabelStyle draw.TextStyle
+
+	// LabelBackground is the color of the box drawn
+	// behind each label. If LabelBackground is nil,
+	// no box is drawn.
+	LabelBackground color.Color
+}
+
+// NewEmbedding returns an embedding of the given points,
+// where the class of each point is an index into names.
+// Up to 10000 points are drawn, with labels at the centers
+// of the classes and no density contours.
+func NewEmbedding(xys XYer, classes []int, names []string) (*Embedding, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	if len(classes) != len(data) {
+		return nil, errors.New("Number of classes does not match the number of points")
+	}
+	for _, k := range classes {
+		if k < 0 || k >= len(names) {
+			return nil, errors.New("Class out of range")
+		}
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Embedding{
+		XYs:          data,
+		Classes:      append([]int(nil), classes...),
+		Names:        append([]string(nil), names...),
+		GlyphStyle:   DefaultGlyphStyle,
+		MaxPoints:    10000,
+		ContourStyle: DefaultLineStyle,
+		Bins:         50,
+		Bandwidth:    1.5,
+		Labels:       true,
+		LabelStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		LabelBackground: color.White,
+	}
+	e.GlyphStyle.Shape = draw.CircleGlyph{}
+	e.GlyphStyle.Radius = vg.Points(1.5)
+	if len(names) > 1 {
+		// Spread the hues around the color wheel
+		// without returning to the first.
+		end := palette.Hue(float64(len(names)-1) / float64(len(names)))
+		e.Palette = palette.Rainbow(len(names), palette.Red, end, 1, 0.8, 1)
+	}
+	return e, nil
+}
+
+// color returns the color of class k.
+func (e *Embedding) color(k int) color.Color {
+	if e.Palette == nil {
+		return e.GlyphStyle.Color
+	}
+	cs := e.Palette.Colors()
+	if len(cs) == 0 {
+		return e.GlyphStyle.Color
+	}
+	return cs[k%len(cs)]
+}
+
+// Plot implements the plot.Plotter interface.
+func (e *Embedding) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	sty := e.GlyphStyle
+	for _, i := range e.sample() {
+		p := vg.Point{X: trX(e.XYs[i].X), Y: trY(e.XYs[i].Y)}
+		if !c.Contains(p) {
+			continue
+		}
+		sty.Color = e.color(e.Classes[i])
+		c.DrawGlyph(sty, p)
+	}
+
+	if len(e.Levels) != 0 {
+		for k := range e.Names {
+			g := e.density(k)
+			if g == nil {
+				continue
+			}
+			sty := e.ContourStyle
+			if sty.Color == nil {
+				sty.Color = e.color(k)
+			}
+			levels := append([]float64(nil), e.Levels...)
+			for _, ps := range contourPaths(g, levels, trX, trY) {
+				for _, p := range ps {
+					if isLoop(p) {
+						p.Close()
+					}
+					c.SetLineStyle(sty)
+					c.Stroke(p)
+				}
+			}
+		}
+	}
+
+	if e.Labels {
+		for k, n := range e.Names {
+			x, y, ok := e.center(k)
+			if !ok {
+				continue
+			}
+			p := vg.Point{X: trX(x), Y: trY(y)}
+			if !c.Contains(p) {
+				continue
+			}
+			if e.LabelBackground != nil {
+				r := e.LabelStyle.Rectangle(n)
+				pts := []vg.Point{
+					{X: p.X + r.Min.X, Y: p.Y + r.Min.Y},
+					{X: p.X + r.Min.X, Y: p.Y + r.Max.Y},
+					{X: p.X + r.Max.X, Y: p.Y + r.Max.Y},
+					{X: p.X + r.Max.X, Y: p.Y + r.Min.Y},
+				}
+				c.FillPolygon(e.LabelBackground, pts)
+			}
+			c.FillText(e.LabelStyle, p, n)
+		}
+	}
+}
+
+// sample returns the indices of the points to draw, in
+// order. If there are more than MaxPoints points, evenly
+// spaced points are taken from each class in proportion
+// to its size.
+func (e *Embedding) sample() []int {
+	n := len(e.XYs)
+	if e.MaxPoints <= 0 || n <= e.MaxPoints {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	size := make([]int, len(e.Names))
+	for _, k := range e.Classes {
+		size[k]++
+	}
+	keep := make([]int, len(size))
+	for k, s := range size {
+		// Keep at least one point of each class.
+		keep[k] = int(math.Ceil(float64(s) * float64(e.MaxPoints) / float64(n)))
+	}
+
+	idx := make([]int, 0, e.MaxPoints+len(size))
+	seen := make([]int, len(size))
+	for i, k := range e.Classes {
+		// Take the points at which the count of kept
+		// points of the class increases.
+		if seen[k]*keep[k]%size[k] < keep[k] {
+			idx = append(idx, i)
+		}
+		seen[k]++
+	}
+	return idx
+}
+
+// center returns the median of the points of class k.
+func (e *Embedding) center(k int) (x, y float64, ok bool) {
+	var xs, ys Values
+	for i, p := range e.XYs {
+		if e.Classes[i] == k {
+			xs = append(xs, p.X)
+			ys = append(ys, p.Y)
+		}
+	}
+	if len(xs) == 0 {
+		return 0, 0, false
+	}
+	sort.Float64s(xs)
+	sort.Float64s(ys)
+	return median(xs), median(ys), true
+}
+
+// density returns the smoothed density of the points
+// of class k, scaled so that its peak is one, or nil
+// if the class has no points.
+func (e *Embedding) density(k int) *densityGrid {
+	bins := e.Bins
+	if bins < 2 {
+		bins = 2
+	}
+	bw := math.Max(e.Bandwidth, 0)
+
+	// Pad the grid so that the contours of
+	// classes at the edges are closed.
+	pad := int(math.Ceil(3*bw)) + 1
+	xmin, xmax, ymin, ymax := XYRange(e)
+	dx := (xmax - xmin) / float64(bins)
+	dy := (ymax - ymin) / float64(bins)
+	if dx == 0 {
+		dx = 1
+	}
+	if dy == 0 {
+		dy = 1
+	}
+	g := &densityGrid{
+		x0:   xmin - float64(pad)*dx,
+		y0:   ymin - float64(pad)*dy,
+		dx:   dx,
+		dy:   dy,
+		cols: bins + 2*pad,
+		rows: bins + 2*pad,
+	}
+	g.z = make([]float64, g.cols*g.rows)
+
+	var n int
+	for i, p := range e.XYs {
+		if e.Classes[i] != k {
+			continue
+		}
+		col := pad + int((p.X-xmin)/dx)
+		row := pad + int((p.Y-ymin)/dy)
+		if col >= g.cols-pad {
+			col = g.cols - pad - 1
+		}
+		if row >= g.rows-pad {
+			row = g.rows - pad - 1
+		}
+		g.z[row*g.cols+col]++
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	g.smooth(bw)
+	var max float64
+	for _, v := range g.z {
+		max = math.Max(max, v)
+	}
+	for i := range g.z {
+		g.z[i] /= max
+	}
+	return g
+}
+
+// densityGrid is a regular grid of densities,
+// implementing the GridXYZ interface.
+type densityGrid struct {
+	x0, y0     float64
+	dx, dy     float64
+	cols, rows int
+	z          []float64
+}
+
+func (g *densityGrid) Dims() (c, r int)   { return g.cols, g.rows }
+func (g *densityGrid) Z(c, r int) float64 { return g.z[r*g.cols+c] }
+func (g *densityGrid) X(c int) float64    { return g.x0 + (float64(c)+0.5)*g.dx }
+func (g *densityGrid) Y(r int) float64    { return g.y0 + (float64(r)+0.5)*g.dy }
+
+// smooth convolves the grid with a Gaussian kernel
+// with a standard deviation of sd bins.
+func (g *densityGrid) smooth(sd float64) {
+	if sd == 0 {
+		return
+	}
+	n := int(math.Ceil(3 * sd))
+	kernel := make([]float64, 2*n+1)
+	for i := range kernel {
+		d := float64(i - n)
+		kernel[i] = math.Exp(-d * d / (2 * sd * sd))
+	}
+
+	// The kernel is separable, so smooth the
+	// rows and then the columns.
+	tmp := make([]float64, len(g.z))
+	for r := 0; r < g.rows; r++ {
+		for c := 0; c < g.cols; c++ {
+			var s float64
+			for i, w := range kernel {
+				if cc := c + i - n; cc >= 0 && cc < g.cols {
+					s += w * g.z[r*g.cols+cc]
+				}
+			}
+			tmp[r*g.cols+c] = s
+		}
+	}
+	for r := 0; r < g.rows; r++ {
+		for c := 0; c < g.cols; c++ {
+			var s float64
+			for i, w := range kernel {
+				if rr := r + i - n; rr >= 0 && rr < g.rows {
+					s += w * tmp[rr*g.cols+c]
+				}
+			}
+			g.z[r*g.cols+c] = s
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (e *Embedding) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(e)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+// Since all points are drawn with the same glyph, only
+// the boxes of the outermost points are returned.
+func (e *Embedding) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var ext [4]int
+	for i, p := range e.XYs {
+		if p.X < e.XYs[ext[0]].X {
+			ext[0] = i
+		}
+		if p.X > e.XYs[ext[1]].X {
+			ext[1] = i
+		}
+		if p.Y < e.XYs[ext[2]].Y {
+			ext[2] = i
+		}
+		if p.Y > e.XYs[ext[3]].Y {
+			ext[3] = i
+		}
+	}
+	bs := make([]plot.GlyphBox, len(ext))
+	for j, i := range ext {
+		bs[j].X = plt.X.Norm(e.XYs[i].X)
+		bs[j].Y = plt.Y.Norm(e.XYs[i].Y)
+		bs[j].Rectangle = e.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// ClassThumbnailers returns the names of the classes and
+// a Thumbnailer drawing a point in the color of each class,
+// for adding a legend entry for each class to a plot.
+func (e *Embedding) ClassThumbnailers() (names []string, thumbnailers []plot.Thumbnailer) {
+	names = append([]string(nil), e.Names...)
+	thumbnailers = make([]plot.Thumbnailer, len(e.Names))
+	for k := range e.Names {
+		sty := e.GlyphStyle
+		sty.Color = e.color(k)
+		thumbnailers[k] = classThumbnailer{sty}
+	}
+	return names, thumbnailers
+}
+
+// classThumbnailer implements the Thumbnailer
+// interface for the classes of an embedding.
+type classThumbnailer struct {
+	draw.GlyphStyle
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface.
+func (t classThumbnailer) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(t.GlyphStyle, c.Center())
+}
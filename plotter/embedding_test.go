@@ -0,0 +1,102 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleEmbedding draws an embedding of three classes
+// of points with density contours, keeping only one in
+// ten of the points.
+func ExampleEmbedding() {
+	rnd := rand.New(rand.NewSource(1))
+
+	names := []string{"cats", "dogs", "birds"}
+	centers := XYs{{0, 0}, {4, 1}, {1, 4}}
+
+	const n = 20000
+	xys := make(XYs, n)
+	classes := make([]int, n)
+	for i := range xys {
+		k := rnd.Intn(len(centers))
+		classes[i] = k
+		xys[i].X = centers[k].X + rnd.NormFloat64()
+		xys[i].Y = centers[k].Y + 0.8*rnd.NormFloat64()
+	}
+
+	e, err := NewEmbedding(xys, classes, names)
+	if err != nil {
+		log.Panic(err)
+	}
+	e.MaxPoints = n / 10
+	e.Levels = []float64{0.1, 0.5, 0.9}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Embedding"
+	p.Add(e)
+	names, thumbs := e.ClassThumbnailers()
+	for i, name := range names {
+		p.Legend.Add(name, thumbs[i])
+	}
+
+	err = p.Save(300, 300, "testdata/embedding.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestEmbedding(t *testing.T) {
+	checkPlot(ExampleEmbedding, t, "embedding.png")
+}
+
+func TestEmbeddingSample(t *testing.T) {
+	xys := make(XYs, 10)
+	classes := []int{0, 0, 1, 0, 0, 0, 0, 0, 0, 2}
+	e, err := NewEmbedding(xys, classes, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	e.MaxPoints = 0
+	if got := len(e.sample()); got != len(xys) {
+		t.Errorf("unexpected number of points without downsampling: got:%d want:%d", got, len(xys))
+	}
+
+	e.MaxPoints = 4
+	got := e.sample()
+	count := make([]int, len(e.Names))
+	for i, idx := range got {
+		if i > 0 && idx <= got[i-1] {
+			t.Errorf("sample not in order: %v", got)
+		}
+		count[e.Classes[idx]]++
+	}
+	for k, c := range count {
+		if c == 0 {
+			t.Errorf("no points of class %d in sample %v", k, got)
+		}
+	}
+	if count[0] != 4 {
+		t.Errorf("unexpected number of points of class 0: got:%d want:4", count[0])
+	}
+}
+
+func TestNewEmbeddingErrors(t *testing.T) {
+	xys := XYs{{0, 0}, {1, 1}}
+	if _, err := NewEmbedding(xys, []int{0}, []string{"a"}); err == nil {
+		t.Error("expected error for mismatched classes")
+	}
+	if _, err := NewEmbedding(xys, []int{0, 1}, []string{"a"}); err == nil {
+		t.Error("expected error for class out of range")
+	}
+}
@@ -117,8 +117,8 @@ func (e *YErrorBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		x := plt.X.Norm(e.XYs[i].X)
 		y := e.XYs[i].Y
 		bs = append(bs,
-			plot.GlyphBox{X: x, Y: plt.Y.Norm(y - err.Low), Rectangle: rect},
-			plot.GlyphBox{X: x, Y: plt.Y.Norm(y + err.High), Rectangle: rect})
+			plot.GlyphBox{X: x, Y: plt.Y.Norm(y - math.Abs(err.Low)), Rectangle: rect},
+			plot.GlyphBox{X: x, Y: plt.Y.Norm(y + math.Abs(err.High)), Rectangle: rect})
 	}
 	return bs
 }
@@ -225,8 +225,8 @@ func (e *XErrorBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		x := e.XYs[i].X
 		y := plt.Y.Norm(e.XYs[i].Y)
 		bs = append(bs,
-			plot.GlyphBox{X: plt.X.Norm(x - err.Low), Y: y, Rectangle: rect},
-			plot.GlyphBox{X: plt.X.Norm(x + err.High), Y: y, Rectangle: rect})
+			plot.GlyphBox{X: plt.X.Norm(x - math.Abs(err.Low)), Y: y, Rectangle: rect},
+			plot.GlyphBox{X: plt.X.Norm(x + math.Abs(err.High)), Y: y, Rectangle: rect})
 	}
 	return bs
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 )
 
@@ -81,3 +82,51 @@ func ExampleErrors() {
 func TestErrors(t *testing.T) {
 	checkPlot(ExampleErrors, t, "errorBars.png")
 }
+
+// ExampleXYErrors draws asymmetric error bars in X
+// and Y attached to the points of a line.
+func ExampleXYErrors() {
+	line, err := NewLine(XYs{{X: 1, Y: 2}, {X: 2, Y: 5}, {X: 3, Y: 4}, {X: 4, Y: 7}})
+	if err != nil {
+		log.Panic(err)
+	}
+	line.LineStyle.Width = vg.Points(1)
+	data, err := NewXYErrors(line,
+		Errors{{Low: 0.1, High: 0.4}, {Low: 0.3, High: 0.1}, {Low: 0.2, High: 0.2}, {Low: 0.5, High: 0}},
+		Errors{{Low: 0.5, High: 1.5}, {Low: 1, High: 0.25}, {Low: 0.2, High: 2}, {Low: 1.5, High: 0.5}},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	xerrs, err := NewXErrorBars(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	xerrs.LineStyle.Width = vg.Points(1)
+	yerrs, err := NewYErrorBars(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	yerrs.LineStyle.Width = vg.Points(1)
+	yerrs.CapWidth = vg.Points(10)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(line, xerrs, yerrs)
+
+	err = p.Save(200, 200, "testdata/errorBarsXY.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestXYErrors(t *testing.T) {
+	checkPlot(ExampleXYErrors, t, "errorBarsXY.png")
+
+	_, err := NewXYErrors(XYs{{X: 1, Y: 2}}, Errors{{Low: 1, High: 1}}, nil)
+	if err == nil {
+		t.Error("expected error for missing Y errors")
+	}
+}
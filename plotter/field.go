@@ -0,0 +1,119 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+)
+
+// Magnitude returns the magnitude, √(u²+v²), of the vector
+// field whose X and Y components are the values of u and v,
+// which must have the same dimensions and coordinates.
+//
+// The returned grid has the X and Y coordinates of u, and
+// may be drawn by a HeatMap or a Contour plot.
+func Magnitude(u, v GridXYZ) (GridXYZ, error) {
+	f, err := newFieldGrid(u, v)
+	if err != nil {
+		return nil, err
+	}
+	for r := 0; r < f.rows; r++ {
+		for c := 0; c < f.cols; c++ {
+			f.z[r*f.cols+c] = math.Hypot(u.Z(c, r), v.Z(c, r))
+		}
+	}
+	return f, nil
+}
+
+// Divergence returns the divergence, ∂u/∂x + ∂v/∂y, of the
+// vector field whose X and Y components are the values of u
+// and v, which must have the same dimensions and coordinates.
+// Positive values mark sources of the field and negative values
+// sinks.
+//
+// The derivatives are estimated by central differences, except
+// at the edges, so the coordinates need not be evenly spaced.
+// The returned grid has the X and Y coordinates of u.
+func Divergence(u, v GridXYZ) (GridXYZ, error) {
+	f, err := newFieldGrid(u, v)
+	if err != nil {
+		return nil, err
+	}
+	for r := 0; r < f.rows; r++ {
+		for c := 0; c < f.cols; c++ {
+			f.z[r*f.cols+c] = ddx(u, c, r) + ddy(v, c, r)
+		}
+	}
+	return f, nil
+}
+
+// Curl returns the curl, ∂v/∂x - ∂u/∂y, of the vector field
+// whose X and Y components are the values of u and v, which
+// must have the same dimensions and coordinates. Positive
+// values mark anticlockwise rotation of the field and negative
+// values clockwise rotation.
+//
+// The derivatives are estimated by central differences, except
+// at the edges, so the coordinates need not be evenly spaced.
+// The returned grid has the X and Y coordinates of u.
+func Curl(u, v GridXYZ) (GridXYZ, error) {
+	f, err := newFieldGrid(u, v)
+	if err != nil {
+		return nil, err
+	}
+	for r := 0; r < f.rows; r++ {
+		for c := 0; c < f.cols; c++ {
+			f.z[r*f.cols+c] = ddx(v, c, r) - ddy(u, c, r)
+		}
+	}
+	return f, nil
+}
+
+// ddx and ddy return the derivatives of the values of g
+// along X and Y at (c, r), or zero if g has a single
+// column or row.
+func ddx(g GridXYZ, c, r int) float64 {
+	cols, _ := g.Dims()
+	l, h := neighbours(c, cols)
+	if l == h {
+		return 0
+	}
+	return (g.Z(h, r) - g.Z(l, r)) / (g.X(h) - g.X(l))
+}
+
+func ddy(g GridXYZ, c, r int) float64 {
+	_, rows := g.Dims()
+	l, h := neighbours(r, rows)
+	if l == h {
+		return 0
+	}
+	return (g.Z(c, h) - g.Z(c, l)) / (g.Y(h) - g.Y(l))
+}
+
+// fieldGrid is a grid of values derived from a vector
+// field on the coordinates of its X component.
+type fieldGrid struct {
+	GridXYZ
+	cols, rows int
+	z          []float64
+}
+
+// newFieldGrid returns an empty fieldGrid on the coordinates
+// of u, returning an error if v has different dimensions.
+func newFieldGrid(u, v GridXYZ) (*fieldGrid, error) {
+	cols, rows := u.Dims()
+	vc, vr := v.Dims()
+	if cols != vc || rows != vr {
+		return nil, errors.New("Vector field components with different dimensions")
+	}
+	if cols == 0 || rows == 0 {
+		return nil, ErrNoData
+	}
+	return &fieldGrid{GridXYZ: u, cols: cols, rows: rows, z: make([]float64, cols*rows)}, nil
+}
+
+func (g *fieldGrid) Dims() (c, r int)   { return g.cols, g.rows }
+func (g *fieldGrid) Z(c, r int) float64 { return g.z[r*g.cols+c] }
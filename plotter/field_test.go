@@ -0,0 +1,143 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleCurl draws the curl of a pair of counter-rotating
+// vortices, with contours of the speed of the flow.
+func ExampleCurl() {
+	const n = 60
+	u := mat64.NewDense(n, n, nil)
+	v := mat64.NewDense(n, n, nil)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			x, y := float64(c)/n, float64(r)/n
+			var vx, vy float64
+			for _, vortex := range []struct{ x, y, s float64 }{
+				{x: 0.35, y: 0.5, s: 1},
+				{x: 0.65, y: 0.5, s: -1},
+			} {
+				dx, dy := x-vortex.x, y-vortex.y
+				w := vortex.s * math.Exp(-(dx*dx+dy*dy)/0.01)
+				vx -= w * dy
+				vy += w * dx
+			}
+			u.Set(r, c, vx)
+			v.Set(r, c, vy)
+		}
+	}
+
+	curl, err := Curl(unitGrid{u}, unitGrid{v})
+	if err != nil {
+		log.Panic(err)
+	}
+	speed, err := Magnitude(unitGrid{u}, unitGrid{v})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	h := NewHeatMap(curl, palette.Radial(11, palette.Blue, palette.Red, 1))
+	h.Min, h.Max = -0.015, 0.015
+	c := NewContour(speed, []float64{0.01, 0.02, 0.03}, palette.Heat(3, 1))
+	for i := range c.LineStyles {
+		c.LineStyles[i].Width = 0.5
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Curl"
+	p.Add(h, c)
+
+	err = p.Save(250, 250, "testdata/curl.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCurl(t *testing.T) {
+	checkPlot(ExampleCurl, t, "curl.png")
+}
+
+func TestVectorFieldQuantities(t *testing.T) {
+	const tol = 1e-12
+
+	// A field spreading out from and
+	// rotating about the origin, on
+	// unevenly spaced coordinates.
+	xs := []float64{-2, -1, 0, 0.5, 2}
+	ys := []float64{-1, 0, 1, 3}
+	u := mat64.NewDense(len(ys), len(xs), nil)
+	v := mat64.NewDense(len(ys), len(xs), nil)
+	for r, y := range ys {
+		for c, x := range xs {
+			u.Set(r, c, 3*x-2*y)
+			v.Set(r, c, 2*x+3*y)
+		}
+	}
+	ug := fieldTestGrid{Dense: u, xs: xs, ys: ys}
+	vg := fieldTestGrid{Dense: v, xs: xs, ys: ys}
+
+	for _, test := range []struct {
+		name string
+		f    func(u, v GridXYZ) (GridXYZ, error)
+		want func(x, y float64) float64
+	}{
+		{name: "divergence", f: Divergence, want: func(x, y float64) float64 { return 6 }},
+		{name: "curl", f: Curl, want: func(x, y float64) float64 { return 4 }},
+		{name: "magnitude", f: Magnitude, want: func(x, y float64) float64 {
+			return math.Hypot(3*x-2*y, 2*x+3*y)
+		}},
+	} {
+		g, err := test.f(ug, vg)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", test.name, err)
+		}
+		cols, rows := g.Dims()
+		if cols != len(xs) || rows != len(ys) {
+			t.Errorf("unexpected %s dimensions: got %dx%d want %dx%d", test.name, cols, rows, len(xs), len(ys))
+			continue
+		}
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				if g.X(c) != xs[c] || g.Y(r) != ys[r] {
+					t.Errorf("unexpected %s coordinates at (%d, %d): got (%v, %v)", test.name, c, r, g.X(c), g.Y(r))
+				}
+				want := test.want(xs[c], ys[r])
+				if got := g.Z(c, r); math.Abs(got-want) > tol {
+					t.Errorf("unexpected %s at (%d, %d): got %v want %v", test.name, c, r, got, want)
+				}
+			}
+		}
+	}
+
+	short := unitGrid{mat64.NewDense(2, len(xs), nil)}
+	for _, f := range []func(u, v GridXYZ) (GridXYZ, error){Divergence, Curl, Magnitude} {
+		if _, err := f(ug, short); err == nil {
+			t.Error("expected error for components with different dimensions")
+		}
+	}
+}
+
+// fieldTestGrid is a grid with the given coordinates.
+type fieldTestGrid struct {
+	*mat64.Dense
+	xs, ys []float64
+}
+
+func (g fieldTestGrid) Dims() (c, r int)   { return len(g.xs), len(g.ys) }
+func (g fieldTestGrid) Z(c, r int) float64 { return g.Dense.At(r, c) }
+func (g fieldTestGrid) X(c int) float64    { return g.xs[c] }
+func (g fieldTestGrid) Y(r int) float64    { return g.ys[r] }
@@ -0,0 +1,205 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// PolynomialFit implements the Plotter interface, drawing
+// the polynomial fitted by least squares to a set of points.
+// Its Equation and Annotation methods describe the fit with
+// its coefficients and its coefficient of determination.
+type PolynomialFit struct {
+	// Coeffs holds the coefficients of the
+	// polynomial, from the constant term up.
+	Coeffs []float64
+
+	// R2 is the coefficient of determination of the
+	// fit, the proportion of the variance of the Y
+	// values explained by the polynomial.
+	R2 float64
+
+	// XUnit and YUnit are the units of the X and Y
+	// values, given in the annotation of the fit.
+	XUnit, YUnit string
+
+	// Samples is the number of points at
+	// which the polynomial is drawn.
+	Samples int
+
+	// LineStyle is the style of the curve.
+	draw.LineStyle
+}
+
+// NewPolynomialFit returns the polynomial of the given degree
+// fitted to the points by least squares. There must be more
+// points than the degree of the polynomial.
+func NewPolynomialFit(xys XYer, degree int) (*PolynomialFit, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if degree < 0 {
+		return nil, errors.New("Negative polynomial degree")
+	}
+	if len(data) <= degree {
+		return nil, errors.New("Too few points for the polynomial degree")
+	}
+
+	a := mat64.NewDense(len(data), degree+1, nil)
+	b := mat64.NewDense(len(data), 1, nil)
+	for i, p := range data {
+		x := 1.0
+		for j := 0; j <= degree; j++ {
+			a.Set(i, j, x)
+			x *= p.X
+		}
+		b.Set(i, 0, p.Y)
+	}
+	var sol mat64.Dense
+	if err := sol.Solve(a, b); err != nil {
+		return nil, err
+	}
+	f := &PolynomialFit{
+		Coeffs:    make([]float64, degree+1),
+		Samples:   50,
+		LineStyle: DefaultLineStyle,
+	}
+	for j := range f.Coeffs {
+		f.Coeffs[j] = sol.At(j, 0)
+	}
+
+	var mean float64
+	for _, p := range data {
+		mean += p.Y
+	}
+	mean /= float64(len(data))
+	var res, tot float64
+	for _, p := range data {
+		res += (p.Y - f.At(p.X)) * (p.Y - f.At(p.X))
+		tot += (p.Y - mean) * (p.Y - mean)
+	}
+	f.R2 = 1
+	if tot > 0 {
+		f.R2 = 1 - res/tot
+	}
+	return f, nil
+}
+
+// At returns the value of the polynomial at x.
+func (f *PolynomialFit) At(x float64) float64 {
+	var y float64
+	for i := len(f.Coeffs) - 1; i >= 0; i-- {
+		y = y*x + f.Coeffs[i]
+	}
+	return y
+}
+
+// Plot implements the Plotter interface, drawing
+// the polynomial across the X range of the plot.
+func (f *PolynomialFit) Plot(c draw.Canvas, p *plot.Plot) {
+	fn := Function{F: f.At, Samples: f.Samples, LineStyle: f.LineStyle}
+	fn.Plot(c, p)
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (f *PolynomialFit) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// Equation returns the equation of the polynomial in the
+// math notation of a draw.TextStyle enabling Math, such as
+// "$y = 2.1x^{2} - 0.53x + 1.2$", with the coefficients
+// formatted to the given number of significant digits.
+func (f *PolynomialFit) Equation(prec int) string {
+	var terms []string
+	for i := len(f.Coeffs) - 1; i >= 0; i-- {
+		c := f.Coeffs[i]
+		if c == 0 && (i > 0 || len(terms) > 0) {
+			continue
+		}
+		sign := "+"
+		if c < 0 {
+			sign = "-"
+		}
+		coeff := strconv.FormatFloat(math.Abs(c), 'g', prec, 64)
+		if e := strings.Index(coeff, "e"); e >= 0 {
+			exp, _ := strconv.Atoi(coeff[e+1:])
+			coeff = coeff[:e] + "\\times10^{" + strconv.Itoa(exp) + "}"
+		}
+		switch {
+		case i > 0 && coeff == "1":
+			coeff = "x"
+		case i > 0:
+			coeff += "x"
+		}
+		if i > 1 {
+			coeff += "^{" + strconv.Itoa(i) + "}"
+		}
+		switch {
+		case len(terms) > 0:
+			terms = append(terms, sign, coeff)
+		case sign == "-":
+			terms = append(terms, "-"+coeff)
+		default:
+			terms = append(terms, coeff)
+		}
+	}
+	return "$y = " + strings.Join(terms, " ") + "$"
+}
+
+// Annotation returns Labels describing the fit in a box above
+// and to the right of the point of the curve at x, giving the
+// equation of the polynomial, its coefficient of determination
+// and the units of the values, if any, with numbers formatted
+// to the given number of digits. The plot's axes may need to
+// be extended to leave room for the box.
+func (f *PolynomialFit) Annotation(x float64, prec int) (*Labels, error) {
+	lines := []string{
+		f.Equation(prec),
+		"$R^{2} = " + strconv.FormatFloat(f.R2, 'f', prec, 64) + "$",
+	}
+	var units []string
+	if f.XUnit != "" {
+		units = append(units, "x in "+f.XUnit)
+	}
+	if f.YUnit != "" {
+		units = append(units, "y in "+f.YUnit)
+	}
+	if len(units) > 0 {
+		lines = append(lines, strings.Join(units, ", "))
+	}
+
+	l, err := NewLabels(XYLabels{
+		XYs:    XYs{{X: x, Y: f.At(x)}},
+		Labels: []string{strings.Join(lines, "\n")},
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.TextStyle[0].Math = true
+	l.Box = draw.BoxStyle{
+		Padding:    vg.Points(3),
+		Border:     draw.LineStyle{Color: color.Gray{128}, Width: vg.Points(0.5)},
+		Background: color.White,
+		Radius:     vg.Points(2),
+	}
+	// Place the box above and to the right of the point.
+	r := l.TextStyle[0].Rectangle(l.Labels[0])
+	l.XOffset = vg.Points(6) + l.Box.Inset() - r.Min.X
+	l.YOffset = vg.Points(6) + l.Box.Inset() - r.Min.Y
+	return l, nil
+}
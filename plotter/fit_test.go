@@ -0,0 +1,130 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExamplePolynomialFit draws the quadratic fitted to
+// the noisy positions of a falling body, annotated
+// with its equation.
+func ExamplePolynomialFit() {
+	rnd := rand.New(rand.NewSource(1))
+
+	pts := make(XYs, 20)
+	for i := range pts {
+		t := float64(i) / 10
+		pts[i].X = t
+		pts[i].Y = 20 - 4.9*t*t + rnd.NormFloat64()*0.3
+	}
+
+	fit, err := NewPolynomialFit(pts, 2)
+	if err != nil {
+		log.Panic(err)
+	}
+	fit.XUnit, fit.YUnit = "s", "m"
+	fit.Width = vg.Points(1)
+
+	ann, err := fit.Annotation(0, 3)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Radius = vg.Points(2)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Falling body"
+	p.X.Label.Text = "Time"
+	p.Y.Label.Text = "Height"
+	p.Add(s, fit, ann)
+	// Leave room for the annotation above the curve.
+	p.Y.Max = 35
+
+	err = p.Save(200, 200, "testdata/polynomialFit.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPolynomialFit(t *testing.T) {
+	checkPlot(ExamplePolynomialFit, t, "polynomialFit.png")
+}
+
+func TestNewPolynomialFit(t *testing.T) {
+	pts := XYs{{0, 1}, {1, 3}, {2, 5}, {3, 7}}
+	fit, err := NewPolynomialFit(pts, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{1, 2}
+	for i, c := range fit.Coeffs {
+		if math.Abs(c-want[i]) > 1e-12 {
+			t.Errorf("unexpected coefficient %d: got:%v want:%v", i, c, want[i])
+		}
+	}
+	if math.Abs(fit.R2-1) > 1e-12 {
+		t.Errorf("unexpected R²: got:%v want:1", fit.R2)
+	}
+	if got := fit.At(4); math.Abs(got-9) > 1e-12 {
+		t.Errorf("unexpected value at 4: got:%v want:9", got)
+	}
+
+	// A line through points on a parabola explains none of the variance.
+	fit, err = NewPolynomialFit(XYs{{-1, 1}, {0, 0}, {1, 1}}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(fit.R2) > 1e-12 {
+		t.Errorf("unexpected R²: got:%v want:0", fit.R2)
+	}
+
+	if _, err := NewPolynomialFit(pts, 4); err == nil {
+		t.Error("expected error for too few points")
+	}
+}
+
+func TestPolynomialFitEquation(t *testing.T) {
+	for _, test := range []struct {
+		coeffs []float64
+		want   string
+	}{
+		{coeffs: []float64{1.23456, -0.5, 2}, want: "$y = 2x^{2} - 0.5x + 1.23$"},
+		{coeffs: []float64{0, 1}, want: "$y = x$"},
+		{coeffs: []float64{-3, 0, -1}, want: "$y = -x^{2} - 3$"},
+		{coeffs: []float64{0}, want: "$y = 0$"},
+		{coeffs: []float64{1.5e-7, 2e6}, want: "$y = 2\\times10^{6}x + 1.5\\times10^{-7}$"},
+	} {
+		f := &PolynomialFit{Coeffs: test.coeffs}
+		if got := f.Equation(3); got != test.want {
+			t.Errorf("unexpected equation for %v: got:%q want:%q", test.coeffs, got, test.want)
+		}
+	}
+
+	f := &PolynomialFit{Coeffs: []float64{1, 1}, R2: 0.5, XUnit: "s"}
+	l, err := f.Annotation(1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "$y = x + 1$\n$R^{2} = 0.50$\nx in s"; l.Labels[0] != want {
+		t.Errorf("unexpected annotation: got:%q want:%q", l.Labels[0], want)
+	}
+	if l.XYs[0].X != 1 || l.XYs[0].Y != 2 {
+		t.Errorf("unexpected annotation position: got:%v want:{1 2}", l.XYs[0])
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Stacker wraps the Len, Stack and Value methods,
+// describing sampled call stacks such as those of
+// a CPU profile.
+type Stacker interface {
+	// Len returns the number of stacks.
+	Len() int
+
+	// Stack returns the names of the functions
+	// of stack i, from the root to the leaf.
+	Stack(i int) []string
+
+	// Value returns the value of stack i, such
+	// as the number of samples or the time spent
+	// in the stack.
+	Value(i int) float64
+}
+
+// Stack is a call stack with its value.
+type Stack struct {
+	// Frames holds the names of the functions of
+	// the stack, from the root to the leaf.
+	Frames []string
+
+	// Value is the value of the stack.
+	Value float64
+}
+
+// Stacks implements the Stacker interface.
+//
+// A pprof profile may be converted to Stacks by taking,
+// for each sample, the function names of the lines of
+// its locations in reverse order, and one of the values
+// of the sample.
+type Stacks []Stack
+
+// Len implements the Len method of the Stacker interface.
+func (s Stacks) Len() int { return len(s) }
+
+// Stack implements the Stack method of the Stacker interface.
+func (s Stacks) Stack(i int) []string { return s[i].Frames }
+
+// Value implements the Value method of the Stacker interface.
+func (s Stacks) Value(i int) float64 { return s[i].Value }
+
+// ParseFolded parses stacks in the folded format produced by
+// the stackcollapse scripts of the FlameGraph tools, where each
+// line holds the frames of a stack separated by semicolons, from
+// the root to the leaf, followed by a space and the value of the
+// stack. Blank lines are ignored.
+func ParseFolded(r io.Reader) (Stacks, error) {
+	var s Stacks
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		i := strings.LastIndex(line, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("Missing value on line %d of folded stacks", n)
+		}
+		v, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value on line %d of folded stacks: %v", n, err)
+		}
+		s = append(s, Stack{
+			Frames: strings.Split(strings.TrimSpace(line[:i]), ";"),
+			Value:  v,
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// FlameGraph implements the Plotter interface, drawing a flame
+// graph of sampled call stacks. Each function in a stack is drawn
+// as a frame, with a width proportional to the total value of the
+// stacks that pass through it, above the frame of its caller.
+// Frames with the same caller are ordered by name.
+//
+// Frame i of a stack is drawn between the Y values i and i+1, and
+// frames span the X values from zero to the total value of the
+// stacks, so the axes of the plot are usually hidden.
+type FlameGraph struct {
+	root *flameFrame
+
+	// depth is the depth of the deepest stack.
+	depth int
+
+	// Icicle specifies whether the graph is drawn
+	// upside down, with the root at the top.
+	Icicle bool
+
+	// Palette is used to color the frames by the package
+	// of their function, so that the functions of a
+	// package share a color. If Palette is nil, frames
+	// are filled with gray.
+	Palette palette.Palette
+
+	// LineStyle is the style of the outlines of the frames.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the function names drawn
+	// in the frames. Names are shortened to fit the width
+	// of their frame, and omitted from frames that are
+	// too narrow.
+	TextStyle draw.TextStyle
+
+	// Padding is the distance between the left edge
+	// of a frame and the start of its name.
+	Padding vg.Length
+
+	// MinWidth is the width below which frames are not
+	// drawn, along with the functions that they call.
+	MinWidth vg.Length
+}
+
+// flameFrame is a frame of a flame graph.
+type flameFrame struct {
+	name     string
+	value    float64
+	children []*flameFrame
+}
+
+// child returns the child frame with the given
+// name, adding it if it does not exist.
+func (f *flameFrame) child(name string) *flameFrame {
+	for _, c := range f.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &flameFrame{name: name}
+	f.children = append(f.children, c)
+	return c
+}
+
+// sort orders the children of the frame
+// and its descendants by name.
+func (f *flameFrame) sort() {
+	sort.Sort(byFrameName(f.children))
+	for _, c := range f.children {
+		c.sort()
+	}
+}
+
+// byFrameName sorts frames by name.
+type byFrameName []*flameFrame
+
+func (s byFrameName) Len() int           { return len(s) }
+func (s byFrameName) Less(i, j int) bool { return s[i].name < s[j].name }
+func (s byFrameName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// NewFlameGraph returns a flame graph of the given stacks.
+// Stack values must not be negative.
+func NewFlameGraph(s Stacker) (*FlameGraph, error) {
+	if s.Len() == 0 {
+		return nil, ErrNoData
+	}
+	fnt, err := vg.MakeFont(DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+
+	fg := &FlameGraph{
+		root:      &flameFrame{},
+		Palette:   palette.Rainbow(16, palette.Red, palette.Yellow, 0.6, 1, 1),
+		LineStyle: draw.LineStyle{Color: color.White, Width: vg.Points(0.5)},
+		TextStyle: draw.TextStyle{Font: fnt, YAlign: draw.YCenter},
+		Padding:   vg.Points(2),
+		MinWidth:  vg.Points(0.5),
+	}
+	for i := 0; i < s.Len(); i++ {
+		v := s.Value(i)
+		if err := CheckFloats(v); err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, errors.New("Negative stack value")
+		}
+		st := s.Stack(i)
+		if len(st) > fg.depth {
+			fg.depth = len(st)
+		}
+		f := fg.root
+		f.value += v
+		for _, name := range st {
+			f = f.child(name)
+			f.value += v
+		}
+	}
+	if fg.root.value == 0 {
+		return nil, ErrNoData
+	}
+	fg.root.sort()
+	return fg, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (fg *FlameGraph) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	var x float64
+	for _, f := range fg.root.children {
+		fg.drawFrame(c, trX, trY, f, x, 0)
+		x += f.value
+	}
+}
+
+// drawFrame draws the frame f, starting at the X value x,
+// at the given depth, followed by the frames it calls.
+func (fg *FlameGraph) drawFrame(c draw.Canvas, trX, trY func(float64) vg.Length, f *flameFrame, x float64, depth int) {
+	x0, x1 := trX(x), trX(x+f.value)
+	if x1-x0 < fg.MinWidth {
+		return
+	}
+	y := float64(depth)
+	if fg.Icicle {
+		y = float64(fg.depth - depth - 1)
+	}
+	y0, y1 := trY(y), trY(y+1)
+	pts := []vg.Point{{X: x0, Y: y0}, {X: x0, Y: y1}, {X: x1, Y: y1}, {X: x1, Y: y0}}
+	c.FillPolygon(fg.color(f.name), c.ClipPolygonXY(pts))
+	pts = append(pts, pts[0])
+	c.StrokeLines(fg.LineStyle, c.ClipLinesXY(pts)...)
+
+	if name := elide(fg.TextStyle, f.name, x1-x0-2*fg.Padding); name != "" {
+		pt := vg.Point{X: x0 + fg.Padding, Y: (y0 + y1) / 2}
+		if c.Contains(pt) {
+			c.FillText(fg.TextStyle, pt, name)
+		}
+	}
+
+	for _, ch := range f.children {
+		fg.drawFrame(c, trX, trY, ch, x, depth+1)
+		x += ch.value
+	}
+}
+
+// color returns the color of frames
+// of the named function.
+func (fg *FlameGraph) color(name string) color.Color {
+	if fg.Palette == nil {
+		return color.Gray{192}
+	}
+	cs := fg.Palette.Colors()
+	if len(cs) == 0 {
+		return color.Gray{192}
+	}
+	h := fnv.New32a()
+	io.WriteString(h, funcPackage(name))
+	return cs[h.Sum32()%uint32(len(cs))]
+}
+
+// funcPackage returns the package of a Go function name
+// such as "github.com/gonum/plot.(*Plot).Draw", or the
+// name itself if it does not have a package.
+func funcPackage(name string) string {
+	slash := strings.LastIndex(name, "/") + 1
+	if i := strings.Index(name[slash:], "."); i >= 0 {
+		return name[:slash+i]
+	}
+	return name
+}
+
+// elide returns s, shortened to fit within the given width
+// when drawn in the text style by replacing its end with "..",
+// or the empty string if not even the first letter of s and
+// the dots fit.
+func elide(sty draw.TextStyle, s string, width vg.Length) string {
+	if sty.Width(s) <= width {
+		return s
+	}
+	const dots = ".."
+	rs := []rune(s)
+
+	// Find the longest prefix that fits.
+	lo, hi := 0, len(rs)
+	for lo < hi {
+		n := (lo + hi + 1) / 2
+		if sty.Width(string(rs[:n])+dots) <= width {
+			lo = n
+		} else {
+			hi = n - 1
+		}
+	}
+	if lo == 0 {
+		return ""
+	}
+	return string(rs[:lo]) + dots
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (fg *FlameGraph) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, fg.root.value, 0, float64(fg.depth)
+}
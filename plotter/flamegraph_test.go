@@ -0,0 +1,141 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+const folded = `
+runtime.main;main.main;net/http.(*Server).Serve;net/http.(*conn).serve;main.handler;encoding/json.Marshal 120
+runtime.main;main.main;net/http.(*Server).Serve;net/http.(*conn).serve;main.handler;github.com/gonum/plot.(*Plot).Draw;github.com/gonum/plot/vg/vgimg.(*Canvas).FillString 240
+runtime.main;main.main;net/http.(*Server).Serve;net/http.(*conn).serve;main.handler;github.com/gonum/plot.(*Plot).Draw;github.com/gonum/plot/plotter.(*Line).Plot 90
+runtime.main;main.main;net/http.(*Server).Serve;net/http.(*conn).serve;bufio.(*Reader).ReadLine 30
+runtime.gcBgMarkWorker;runtime.gcDrain;runtime.scanobject 60
+runtime.mcall;runtime.park_m;runtime.schedule 20
+`
+
+// ExampleFlameGraph draws a flame graph of the CPU profile
+// of a web service, given as folded stacks.
+func ExampleFlameGraph() {
+	stacks, err := ParseFolded(strings.NewReader(folded))
+	if err != nil {
+		log.Panic(err)
+	}
+	fg, err := NewFlameGraph(stacks)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "CPU profile"
+	p.Add(fg)
+	p.HideAxes()
+
+	err = p.Save(6*vg.Inch, 3*vg.Inch, "testdata/flamegraph.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFlameGraph(t *testing.T) {
+	checkPlot(ExampleFlameGraph, t, "flamegraph.png")
+}
+
+func TestParseFolded(t *testing.T) {
+	got, err := ParseFolded(strings.NewReader("a;b 2\n\na;c;d 1.5\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Stacks{
+		{Frames: []string{"a", "b"}, Value: 2},
+		{Frames: []string{"a", "c", "d"}, Value: 1.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected stacks: got:%v want:%v", got, want)
+	}
+
+	for _, in := range []string{"a;b", "a;b x"} {
+		if _, err := ParseFolded(strings.NewReader(in)); err == nil {
+			t.Errorf("expected error for %q", in)
+		}
+	}
+}
+
+func TestNewFlameGraph(t *testing.T) {
+	fg, err := NewFlameGraph(Stacks{
+		{Frames: []string{"a", "c"}, Value: 1},
+		{Frames: []string{"a", "b", "d"}, Value: 2},
+		{Frames: []string{"a", "c"}, Value: 3},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if xmin, xmax, ymin, ymax := fg.DataRange(); xmin != 0 || xmax != 6 || ymin != 0 || ymax != 3 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 6 0 3", xmin, xmax, ymin, ymax)
+	}
+	a := fg.root.children[0]
+	var names []string
+	var values []float64
+	for _, f := range a.children {
+		names = append(names, f.name)
+		values = append(values, f.value)
+	}
+	if !reflect.DeepEqual(names, []string{"b", "c"}) || !reflect.DeepEqual(values, []float64{2, 4}) {
+		t.Errorf("unexpected children of a: got:%v %v want:[b c] [2 4]", names, values)
+	}
+
+	if _, err := NewFlameGraph(Stacks{{Frames: []string{"a"}, Value: -1}}); err == nil {
+		t.Error("expected error for negative value")
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	for _, test := range []struct {
+		name, want string
+	}{
+		{name: "github.com/gonum/plot.(*Plot).Draw", want: "github.com/gonum/plot"},
+		{name: "github.com/gonum/plot/vg/vgimg.New", want: "github.com/gonum/plot/vg/vgimg"},
+		{name: "runtime.main", want: "runtime"},
+		{name: "main", want: "main"},
+	} {
+		if got := funcPackage(test.name); got != test.want {
+			t.Errorf("unexpected package for %q: got:%q want:%q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestElide(t *testing.T) {
+	fnt, err := vg.MakeFont(DefaultFont, vg.Points(10))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fg := &FlameGraph{}
+	fg.TextStyle.Font = fnt
+
+	const s = "encoding/json.Marshal"
+	if got := elide(fg.TextStyle, s, fnt.Width(s)); got != s {
+		t.Errorf("unexpected elision of fitting text: got:%q", got)
+	}
+	got := elide(fg.TextStyle, s, fnt.Width(s)/2)
+	if !strings.HasSuffix(got, "..") || !strings.HasPrefix(s, strings.TrimSuffix(got, "..")) {
+		t.Errorf("unexpected elision: got:%q", got)
+	}
+	if fnt.Width(got) > fnt.Width(s)/2 {
+		t.Errorf("elided text too wide: %q", got)
+	}
+	if got := elide(fg.TextStyle, s, 1); got != "" {
+		t.Errorf("unexpected elision in narrow width: got:%q", got)
+	}
+}
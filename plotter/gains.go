@@ -0,0 +1,250 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// DefaultBaselineStyle is the default style for the
+// reference lines of gains and lift charts.
+var DefaultBaselineStyle = draw.LineStyle{
+	Color:  color.Gray{128},
+	Width:  vg.Points(1),
+	Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
+}
+
+// Gains implements the Plotter interface, drawing a cumulative
+// gains chart evaluating the scores of a binary classifier.
+//
+// Several models can be compared by adding a Gains for each to
+// the same plot. In that case the baseline need only be drawn
+// once, so Baseline.Width may be set to zero for all but one.
+type Gains struct {
+	// XYs holds the cumulative gains at the end of each
+	// decile of the population ordered by decreasing
+	// score. X is the fraction of the population and Y
+	// is the fraction of positive outcomes found within
+	// it. The first point is at (0, 0).
+	XYs
+
+	// LineStyle is the style of the gains curve.
+	draw.LineStyle
+
+	// Baseline is the style of the diagonal reference
+	// line showing the gains of a random model.
+	Baseline draw.LineStyle
+}
+
+// NewGains returns a cumulative gains chart for the given
+// classifier scores and the corresponding observed outcomes.
+// Higher scores are taken to predict positive outcomes.
+func NewGains(scores []float64, outcomes []bool) (*Gains, error) {
+	d, err := newDeciles(scores, outcomes)
+	if err != nil {
+		return nil, err
+	}
+	xys := make(XYs, 1, len(d)+1)
+	for _, q := range d {
+		xys = append(xys, struct{ X, Y float64 }{
+			X: float64(q.n) / float64(d.total()),
+			Y: float64(q.pos) / float64(d.positives()),
+		})
+	}
+	return &Gains{
+		XYs:       xys,
+		LineStyle: DefaultLineStyle,
+		Baseline:  DefaultBaselineStyle,
+	}, nil
+}
+
+// Plot draws the gains chart, implementing the
+// plot.Plotter interface.
+func (g *Gains) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	base := []vg.Point{{X: trX(0), Y: trY(0)}, {X: trX(1), Y: trY(1)}}
+	c.StrokeLines(g.Baseline, c.ClipLinesXY(base)...)
+
+	ps := make([]vg.Point, len(g.XYs))
+	for i, p := range g.XYs {
+		ps[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	c.StrokeLines(g.LineStyle, c.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the plot.DataRanger
+// interface.
+func (g *Gains) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, 1
+}
+
+// Thumbnail draws a line in the style of the gains
+// curve, implementing the plot.Thumbnailer interface.
+func (g *Gains) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(g.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// Lift implements the Plotter interface, drawing a cumulative
+// lift chart evaluating the scores of a binary classifier.
+//
+// Several models can be compared by adding a Lift for each to
+// the same plot. In that case the baseline need only be drawn
+// once, so Baseline.Width may be set to zero for all but one.
+type Lift struct {
+	// XYs holds the cumulative lift at the end of each
+	// decile of the population ordered by decreasing
+	// score. X is the fraction of the population and Y
+	// is the ratio of the rate of positive outcomes
+	// within it to the rate in the whole population.
+	XYs
+
+	// LineStyle is the style of the lift curve.
+	draw.LineStyle
+
+	// Baseline is the style of the horizontal reference
+	// line at a lift of one, the lift of a random model.
+	Baseline draw.LineStyle
+}
+
+// NewLift returns a cumulative lift chart for the given
+// classifier scores and the corresponding observed outcomes.
+// Higher scores are taken to predict positive outcomes.
+func NewLift(scores []float64, outcomes []bool) (*Lift, error) {
+	d, err := newDeciles(scores, outcomes)
+	if err != nil {
+		return nil, err
+	}
+	rate := float64(d.positives()) / float64(d.total())
+	xys := make(XYs, 0, len(d))
+	for _, q := range d {
+		xys = append(xys, struct{ X, Y float64 }{
+			X: float64(q.n) / float64(d.total()),
+			Y: float64(q.pos) / float64(q.n) / rate,
+		})
+	}
+	return &Lift{
+		XYs:       xys,
+		LineStyle: DefaultLineStyle,
+		Baseline:  DefaultBaselineStyle,
+	}, nil
+}
+
+// Plot draws the lift chart, implementing the
+// plot.Plotter interface.
+func (l *Lift) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	base := []vg.Point{{X: trX(plt.X.Min), Y: trY(1)}, {X: trX(plt.X.Max), Y: trY(1)}}
+	c.StrokeLines(l.Baseline, c.ClipLinesXY(base)...)
+
+	ps := make([]vg.Point, len(l.XYs))
+	for i, p := range l.XYs {
+		ps[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	c.StrokeLines(l.LineStyle, c.ClipLinesXY(ps)...)
+}
+
+// DataRange returns the minimum and maximum x
+// and y values, implementing the plot.DataRanger
+// interface. The y range always includes the
+// baseline lift of one.
+func (l *Lift) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(l)
+	if ymin > 1 {
+		ymin = 1
+	}
+	if ymax < 1 {
+		ymax = 1
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail draws a line in the style of the lift
+// curve, implementing the plot.Thumbnailer interface.
+func (l *Lift) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(l.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// quantile holds the cumulative number of
+// observations and of positive outcomes at
+// the end of a quantile of the population.
+type quantile struct {
+	n, pos int
+}
+
+// deciles holds the cumulative counts for each
+// non-empty decile of a population ordered by
+// decreasing score.
+type deciles []quantile
+
+// newDeciles returns the cumulative deciles for
+// the given scores and outcomes.
+func newDeciles(scores []float64, outcomes []bool) (deciles, error) {
+	if len(scores) != len(outcomes) {
+		return nil, errors.New("Number of scores does not match the number of outcomes")
+	}
+	if len(scores) == 0 {
+		return nil, ErrNoData
+	}
+	if err := CheckFloats(scores...); err != nil {
+		return nil, err
+	}
+
+	s := byScore{
+		scores:   append([]float64(nil), scores...),
+		outcomes: append([]bool(nil), outcomes...),
+	}
+	sort.Stable(sort.Reverse(s))
+
+	const n = 10
+	d := make(deciles, 0, n)
+	var q quantile
+	for k := 1; k <= n; k++ {
+		end := (len(s.scores)*k + n/2) / n
+		if end == q.n {
+			// Skip empty deciles of small populations.
+			continue
+		}
+		for ; q.n < end; q.n++ {
+			if s.outcomes[q.n] {
+				q.pos++
+			}
+		}
+		d = append(d, q)
+	}
+	if d.positives() == 0 {
+		return nil, errors.New("No positive outcomes")
+	}
+	return d, nil
+}
+
+// total returns the size of the population.
+func (d deciles) total() int { return d[len(d)-1].n }
+
+// positives returns the number of positive
+// outcomes in the population.
+func (d deciles) positives() int { return d[len(d)-1].pos }
+
+// byScore sorts scores and their outcomes
+// by increasing score.
+type byScore struct {
+	scores   []float64
+	outcomes []bool
+}
+
+func (s byScore) Len() int           { return len(s.scores) }
+func (s byScore) Less(i, j int) bool { return s.scores[i] < s.scores[j] }
+func (s byScore) Swap(i, j int) {
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+	s.outcomes[i], s.outcomes[j] = s.outcomes[j], s.outcomes[i]
+}
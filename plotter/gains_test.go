@@ -0,0 +1,148 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleGains draws cumulative gains and lift
+// charts comparing two classifiers.
+func ExampleGains() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// The scores of the good model are better
+	// correlated with the outcomes than those of
+	// the poor model.
+	const n = 1000
+	good := make([]float64, n)
+	poor := make([]float64, n)
+	outcomes := make([]bool, n)
+	for i := range outcomes {
+		outcomes[i] = rnd.Float64() < 0.3
+		var signal float64
+		if outcomes[i] {
+			signal = 1
+		}
+		good[i] = signal + 0.5*rnd.NormFloat64()
+		poor[i] = signal + 2*rnd.NormFloat64()
+	}
+
+	gains, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	gains.Title.Text = "Cumulative gains"
+	gains.X.Label.Text = "Fraction of population"
+	gains.Y.Label.Text = "Fraction of positives"
+
+	lift, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	lift.Title.Text = "Lift"
+	lift.X.Label.Text = "Fraction of population"
+	lift.Y.Label.Text = "Lift"
+
+	for i, m := range []struct {
+		name   string
+		scores []float64
+		color  color.Color
+	}{
+		{name: "good", scores: good, color: color.RGBA{B: 255, A: 255}},
+		{name: "poor", scores: poor, color: color.RGBA{R: 255, A: 255}},
+	} {
+		g, err := NewGains(m.scores, outcomes)
+		if err != nil {
+			log.Panic(err)
+		}
+		g.Color = m.color
+		l, err := NewLift(m.scores, outcomes)
+		if err != nil {
+			log.Panic(err)
+		}
+		l.Color = m.color
+		if i > 0 {
+			// Only draw the baselines once.
+			g.Baseline.Width = 0
+			l.Baseline.Width = 0
+		}
+
+		gains.Add(g)
+		gains.Legend.Add(m.name, g)
+		lift.Add(l)
+		lift.Legend.Add(m.name, l)
+	}
+	lift.Legend.Top = true
+
+	err = gains.Save(200, 200, "testdata/gains.png")
+	if err != nil {
+		log.Panic(err)
+	}
+	err = lift.Save(200, 200, "testdata/lift.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGains(t *testing.T) {
+	checkPlot(ExampleGains, t, "gains.png", "lift.png")
+}
+
+func TestGainsLift(t *testing.T) {
+	// Twenty observations, two per decile, with the
+	// positive outcomes at the highest scores except
+	// for one in the lowest decile.
+	scores := make([]float64, 20)
+	outcomes := make([]bool, 20)
+	for i := range scores {
+		scores[i] = float64(i)
+	}
+	for _, i := range []int{19, 18, 17, 1} {
+		outcomes[i] = true
+	}
+
+	g, err := NewGains(scores, outcomes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantGains := XYs{
+		{0, 0}, {0.1, 0.5}, {0.2, 0.75}, {0.3, 0.75}, {0.4, 0.75}, {0.5, 0.75},
+		{0.6, 0.75}, {0.7, 0.75}, {0.8, 0.75}, {0.9, 0.75}, {1, 1},
+	}
+	if !reflect.DeepEqual(g.XYs, wantGains) {
+		t.Errorf("unexpected gains:\ngot: %v\nwant:%v", g.XYs, wantGains)
+	}
+
+	l, err := NewLift(scores, outcomes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range l.XYs {
+		want := wantGains[i+1].Y / wantGains[i+1].X
+		if diff := p.Y - want; diff > 1e-12 || diff < -1e-12 {
+			t.Errorf("unexpected lift at %v: got:%v want:%v", p.X, p.Y, want)
+		}
+	}
+
+	for _, test := range []struct {
+		scores   []float64
+		outcomes []bool
+	}{
+		{scores: []float64{1, 2}, outcomes: []bool{true}},
+		{scores: nil, outcomes: nil},
+		{scores: []float64{1, 2}, outcomes: []bool{false, false}},
+	} {
+		if _, err := NewGains(test.scores, test.outcomes); err == nil {
+			t.Errorf("expected error for scores=%v outcomes=%v", test.scores, test.outcomes)
+		}
+	}
+}
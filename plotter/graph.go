@@ -0,0 +1,290 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/biogo/graphics/bezier"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Edge is an edge of a Graph, joining the
+// nodes of the indices From and To.
+type Edge struct {
+	From, To int
+}
+
+// Graph implements the plot.Plotter interface, drawing a
+// node-link diagram of a small graph: a glyph at each node,
+// and a straight or curved line along each edge.
+type Graph struct {
+	// Nodes holds the positions of the nodes.
+	Nodes XYs
+
+	// Edges holds the edges between the nodes.
+	Edges []Edge
+
+	// GlyphStyle is the style of the node glyphs.
+	// Its Radius is used if Sizes is nil, and its
+	// Color if Values is nil.
+	draw.GlyphStyle
+
+	// Sizes, if not nil, holds an attribute of each
+	// node, by which the radii of the node glyphs are
+	// interpolated linearly between MinRadius and
+	// MaxRadius, as are those of Bubbles.
+	Sizes []float64
+
+	// MinRadius and MaxRadius are the radii of the
+	// node glyphs of the least and the greatest Sizes.
+	MinRadius, MaxRadius vg.Length
+
+	// Values, if not nil, holds an attribute of each
+	// node, by which the node glyphs are colored
+	// using ColorMap. Nodes whose values are outside
+	// of the range of the ColorMap are drawn in the
+	// color of the GlyphStyle.
+	Values []float64
+
+	// ColorMap is the ColorMap of the Values.
+	ColorMap palette.ColorMap
+
+	// EdgeStyle is the style of the edges.
+	EdgeStyle draw.LineStyle
+
+	// Curvature is the distance of the middle of each
+	// edge from the straight line between its nodes,
+	// as a fraction of the length of the line, to the
+	// left going from the From node to the To node.
+	// The edges are straight if Curvature is zero.
+	Curvature float64
+}
+
+// NewGraph returns a Graph of n nodes with the given edges.
+// If pos is not nil, it holds the fixed positions of the
+// nodes, and otherwise the nodes are placed by ForceLayout.
+func NewGraph(n int, edges []Edge, pos XYer) (*Graph, error) {
+	if n == 0 {
+		return nil, ErrNoData
+	}
+	for _, e := range edges {
+		if e.From < 0 || e.From >= n || e.To < 0 || e.To >= n {
+			return nil, errors.New("Edge node index out of range")
+		}
+	}
+	var nodes XYs
+	if pos == nil {
+		nodes = ForceLayout(n, edges, 0)
+	} else {
+		if pos.Len() != n {
+			return nil, errors.New("Number of node positions does not match the number of nodes")
+		}
+		var err error
+		nodes, err = CopyXYs(pos)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Graph{
+		Nodes:      nodes,
+		Edges:      append([]Edge(nil), edges...),
+		GlyphStyle: DefaultGlyphStyle,
+		MinRadius:  DefaultGlyphStyle.Radius,
+		MaxRadius:  4 * DefaultGlyphStyle.Radius,
+		EdgeStyle:  DefaultLineStyle,
+	}, nil
+}
+
+// DefaultLayoutIterations is the number of iterations of
+// ForceLayout when it is called with no iterations.
+const DefaultLayoutIterations = 500
+
+// ForceLayout returns positions of n nodes found by the
+// force-directed layout of Fruchterman and Reingold, in which
+// all of the nodes repel each other and the nodes of each edge
+// attract each other, with the given number of iterations, or
+// DefaultLayoutIterations if iterations is not positive. The
+// layout is deterministic, starting from nodes on a spiral,
+// and fills about a unit square centered on the origin.
+func ForceLayout(n int, edges []Edge, iterations int) XYs {
+	if iterations <= 0 {
+		iterations = DefaultLayoutIterations
+	}
+	pos := make(XYs, n)
+	for i := range pos {
+		// The golden angle spreads the nodes evenly
+		// without the symmetry of a circle, from which
+		// the layout may not escape.
+		r := 0.5 * math.Sqrt((float64(i)+0.5)/float64(n))
+		pos[i].Y, pos[i].X = math.Sincos(float64(i) * math.Pi * (3 - math.Sqrt(5)))
+		pos[i].X *= r
+		pos[i].Y *= r
+	}
+	if n < 2 {
+		return pos
+	}
+
+	k := math.Sqrt(1 / float64(n))
+	disp := make([]struct{ X, Y float64 }, n)
+	for it := 0; it < iterations; it++ {
+		for i := range disp {
+			disp[i].X, disp[i].Y = 0, 0
+		}
+		for i := range pos {
+			for j := i + 1; j < n; j++ {
+				dx, dy := pos[i].X-pos[j].X, pos[i].Y-pos[j].Y
+				d := math.Max(math.Hypot(dx, dy), 1e-6)
+				f := k * k / d / d
+				disp[i].X += dx * f
+				disp[i].Y += dy * f
+				disp[j].X -= dx * f
+				disp[j].Y -= dy * f
+			}
+		}
+		for _, e := range edges {
+			if e.From == e.To {
+				continue
+			}
+			dx, dy := pos[e.From].X-pos[e.To].X, pos[e.From].Y-pos[e.To].Y
+			f := math.Hypot(dx, dy) / k
+			disp[e.From].X -= dx * f
+			disp[e.From].Y -= dy * f
+			disp[e.To].X += dx * f
+			disp[e.To].Y += dy * f
+		}
+
+		// The nodes move at most by the temperature,
+		// which cools linearly to zero.
+		temp := 0.1 * (1 - float64(it)/float64(iterations))
+		for i := range pos {
+			d := math.Hypot(disp[i].X, disp[i].Y)
+			if d == 0 {
+				continue
+			}
+			s := math.Min(d, temp) / d
+			pos[i].X += disp[i].X * s
+			pos[i].Y += disp[i].Y * s
+		}
+	}
+
+	// Center the layout on the origin.
+	var cx, cy float64
+	for _, p := range pos {
+		cx += p.X
+		cy += p.Y
+	}
+	cx /= float64(n)
+	cy /= float64(n)
+	for i := range pos {
+		pos[i].X -= cx
+		pos[i].Y -= cy
+	}
+	return pos
+}
+
+// Plot implements the plot.Plotter interface.
+func (g *Graph) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pts := make([]vg.Point, len(g.Nodes))
+	for i, p := range g.Nodes {
+		pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+
+	if g.EdgeStyle.Color != nil && g.EdgeStyle.Width > 0 {
+		for _, e := range g.Edges {
+			if e.From == e.To {
+				continue
+			}
+			line := g.edge(pts[e.From], pts[e.To])
+			c.StrokeLines(g.EdgeStyle, c.ClipLinesXY(line)...)
+		}
+	}
+
+	for i, pt := range pts {
+		if !c.Contains(pt) {
+			continue
+		}
+		sty := g.GlyphStyle
+		sty.Radius = g.radius(i)
+		sty.Color = g.color(i)
+		c.DrawGlyph(sty, pt)
+	}
+}
+
+// edgeSegments is the number of line
+// segments of each curved edge.
+const edgeSegments = 20
+
+// edge returns the line of the edge from a to b.
+func (g *Graph) edge(a, b vg.Point) []vg.Point {
+	if g.Curvature == 0 {
+		return []vg.Point{a, b}
+	}
+	// The middle of a quadratic Bézier curve is
+	// half way from the middle of its ends to its
+	// control point.
+	d := b.Sub(a)
+	ctrl := vg.Point{
+		X: (a.X+b.X)/2 - 2*vg.Length(g.Curvature)*d.Y,
+		Y: (a.Y+b.Y)/2 + 2*vg.Length(g.Curvature)*d.X,
+	}
+	line := make([]vg.Point, edgeSegments+1)
+	bezier.New(a, ctrl, b).Curve(line)
+	return line
+}
+
+// radius returns the glyph radius of node i.
+func (g *Graph) radius(i int) vg.Length {
+	if g.Sizes == nil {
+		return g.GlyphStyle.Radius
+	}
+	min, max := g.Sizes[0], g.Sizes[0]
+	for _, s := range g.Sizes {
+		min, max = math.Min(min, s), math.Max(max, s)
+	}
+	rng := g.MaxRadius - g.MinRadius
+	if max == min {
+		return g.MinRadius + rng/2
+	}
+	return g.MinRadius + vg.Length((g.Sizes[i]-min)/(max-min))*rng
+}
+
+// color returns the glyph color of node i.
+func (g *Graph) color(i int) color.Color {
+	if g.Values == nil || g.ColorMap == nil {
+		return g.GlyphStyle.Color
+	}
+	c, err := g.ColorMap.At(g.Values[i])
+	if err != nil {
+		return g.GlyphStyle.Color
+	}
+	return c
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (g *Graph) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(g.Nodes)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (g *Graph) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(g.Nodes))
+	for i, p := range g.Nodes {
+		r := g.radius(i)
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: r, Y: r},
+		}
+	}
+	return bs
+}
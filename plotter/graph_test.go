@@ -0,0 +1,127 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ExampleGraph draws a small graph of two communities
+// placed by the force-directed layout, with the nodes
+// sized and colored by their degree.
+func ExampleGraph() {
+	edges := []Edge{
+		{0, 1}, {0, 2}, {0, 3}, {1, 2}, {2, 3}, {3, 4}, {1, 4},
+		{4, 5},
+		{5, 6}, {5, 7}, {6, 7}, {7, 8}, {8, 9}, {6, 9}, {5, 9},
+		{9, 10}, {10, 11},
+	}
+	g, err := NewGraph(12, edges, nil)
+	if err != nil {
+		log.Panic(err)
+	}
+	degree := make([]float64, len(g.Nodes))
+	for _, e := range edges {
+		degree[e.From]++
+		degree[e.To]++
+	}
+	g.Sizes = degree
+	g.Values = degree
+	g.ColorMap = palette.Linear(palette.Heat(8, 1))
+	g.ColorMap.SetMin(1)
+	g.ColorMap.SetMax(4)
+	g.MinRadius = vg.Points(3)
+	g.MaxRadius = vg.Points(8)
+	g.Shape = draw.CircleGlyph{}
+	g.EdgeStyle = draw.LineStyle{Color: color.Gray{Y: 0x80}, Width: vg.Points(1)}
+	g.Curvature = 0.1
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Graph"
+	p.HideAxes()
+	p.Add(g)
+
+	err = p.Save(250, 250, "testdata/graph.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	checkPlot(ExampleGraph, t, "graph.png")
+}
+
+func TestForceLayout(t *testing.T) {
+	// A path of four nodes is laid out
+	// with its neighbors the closest.
+	edges := []Edge{{0, 1}, {1, 2}, {2, 3}}
+	pos := ForceLayout(4, edges, 0)
+	dist := func(i, j int) float64 {
+		return math.Hypot(pos[i].X-pos[j].X, pos[i].Y-pos[j].Y)
+	}
+	for _, e := range edges {
+		if d := dist(e.From, e.To); d >= dist(0, 3) {
+			t.Errorf("unexpected distance of edge %v: got:%.3f not less than %.3f", e, d, dist(0, 3))
+		}
+	}
+	var cx, cy float64
+	for _, p := range pos {
+		cx += p.X
+		cy += p.Y
+	}
+	if math.Abs(cx) > 1e-12 || math.Abs(cy) > 1e-12 {
+		t.Errorf("layout not centered: got:(%v, %v)", cx/4, cy/4)
+	}
+	again := ForceLayout(4, edges, 0)
+	for i := range pos {
+		if pos[i] != again[i] {
+			t.Errorf("layout not deterministic at node %d", i)
+		}
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	pos := XYs{{0, 0}, {1, 0}, {0, 1}}
+	g, err := NewGraph(3, []Edge{{0, 1}, {1, 2}}, pos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range pos {
+		if g.Nodes[i] != pos[i] {
+			t.Errorf("unexpected position of node %d: got:%v want:%v", i, g.Nodes[i], pos[i])
+		}
+	}
+	g.Sizes = []float64{1, 2, 3}
+	g.MinRadius, g.MaxRadius = 2, 6
+	if r := g.radius(1); r != 4 {
+		t.Errorf("unexpected radius: got:%v want:4", r)
+	}
+
+	for _, test := range []struct {
+		n     int
+		edges []Edge
+		pos   XYer
+	}{
+		{n: 0},
+		{n: 2, edges: []Edge{{0, 2}}},
+		{n: 2, edges: []Edge{{-1, 1}}},
+		{n: 2, pos: XYs{{0, 0}}},
+	} {
+		if _, err := NewGraph(test.n, test.edges, test.pos); err == nil {
+			t.Errorf("expected error for %d nodes with edges %v and positions %v", test.n, test.edges, test.pos)
+		}
+	}
+}
@@ -18,20 +18,75 @@ var (
 		Color: color.Gray{128},
 		Width: vg.Points(0.25),
 	}
+
+	// DefaultMinorGridLineStyle is the default style
+	// for minor grid lines.
+	DefaultMinorGridLineStyle = draw.LineStyle{
+		Color:  color.Gray{192},
+		Width:  vg.Points(0.25),
+		Dashes: []vg.Length{vg.Points(1), vg.Points(1)},
+	}
+)
+
+// GridOrder specifies when a Grid is drawn
+// relative to the other plotters of a plot.
+type GridOrder int
+
+const (
+	// GridInOrder draws the grid in the order
+	// in which it was added to the plot.
+	GridInOrder GridOrder = iota
+
+	// GridBelow draws the grid below the data,
+	// before the plotters that are not in a
+	// negative layer.
+	GridBelow
+
+	// GridAbove draws the grid above the data,
+	// after the plotters that are not in a
+	// positive layer.
+	GridAbove
 )
 
 // Grid implements the plot.Plotter interface, drawing
-// a set of grid lines at the major tick marks.
+// a set of grid lines at the major tick marks, and
+// optionally at the minor tick marks. Lines whose style
+// has a nil Color are not drawn.
 type Grid struct {
 	// Vertical is the style of the vertical lines.
 	Vertical draw.LineStyle
 
 	// Horizontal is the style of the horizontal lines.
 	Horizontal draw.LineStyle
+
+	// MinorVertical is the style of the vertical
+	// lines at the minor tick marks.
+	MinorVertical draw.LineStyle
+
+	// MinorHorizontal is the style of the horizontal
+	// lines at the minor tick marks.
+	MinorHorizontal draw.LineStyle
+
+	// Order specifies when the grid is drawn
+	// relative to the data of the plot.
+	Order GridOrder
+}
+
+// Layer implements the plot.Layerer interface, returning
+// -1 for GridBelow, 1 for GridAbove and 0 otherwise.
+func (g *Grid) Layer() int {
+	switch g.Order {
+	case GridBelow:
+		return -1
+	case GridAbove:
+		return 1
+	}
+	return 0
 }
 
 // NewGrid returns a new grid with both vertical and
-// horizontal lines using the default grid line style.
+// horizontal lines using the default grid line style,
+// and no minor lines.
 func NewGrid() *Grid {
 	return &Grid{
 		Vertical:   DefaultGridLineStyle,
@@ -39,30 +94,44 @@ func NewGrid() *Grid {
 	}
 }
 
+// NewMinorGrid returns a new grid with both vertical
+// and horizontal lines using the default grid line
+// style, and minor lines using the default minor grid
+// line style.
+func NewMinorGrid() *Grid {
+	return &Grid{
+		Vertical:        DefaultGridLineStyle,
+		Horizontal:      DefaultGridLineStyle,
+		MinorVertical:   DefaultMinorGridLineStyle,
+		MinorHorizontal: DefaultMinorGridLineStyle,
+	}
+}
+
 // Plot implements the plot.Plotter interface.
 func (g *Grid) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 
-	if g.Vertical.Color == nil {
-		goto horiz
-	}
 	for _, tk := range plt.X.Tick.Marker.Ticks(plt.X.Min, plt.X.Max) {
+		sty := g.Vertical
 		if tk.IsMinor() {
+			sty = g.MinorVertical
+		}
+		if sty.Color == nil {
 			continue
 		}
 		x := trX(tk.Value)
-		c.StrokeLine2(g.Vertical, x, c.Min.Y, x, c.Min.Y+c.Size().Y)
+		c.StrokeLine2(sty, x, c.Min.Y, x, c.Min.Y+c.Size().Y)
 	}
 
-horiz:
-	if g.Horizontal.Color == nil {
-		return
-	}
 	for _, tk := range plt.Y.Tick.Marker.Ticks(plt.Y.Min, plt.Y.Max) {
+		sty := g.Horizontal
 		if tk.IsMinor() {
+			sty = g.MinorHorizontal
+		}
+		if sty.Color == nil {
 			continue
 		}
 		y := trY(tk.Value)
-		c.StrokeLine2(g.Horizontal, c.Min.X, y, c.Min.X+c.Size().X, y)
+		c.StrokeLine2(sty, c.Min.X, y, c.Min.X+c.Size().X, y)
 	}
 }
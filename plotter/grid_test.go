@@ -0,0 +1,94 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// ExampleGrid_minor draws a damped oscillation over a
+// grid with lines at the minor tick marks, which divide
+// each interval between major tick marks into five.
+func ExampleGrid_minor() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Damped oscillation"
+	p.X.Label.Text = "t (s)"
+	p.Y.Label.Text = "x (mm)"
+	p.X.Tick.Marker = plot.MinorTicks{N: 5}
+	p.Y.Tick.Marker = plot.MinorTicks{N: 5}
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		a.Tick.MinorLength = vg.Points(3)
+		a.Tick.MinorLineStyle.Color = color.Gray{96}
+		a.Tick.MinorLineStyle.Width = vg.Points(0.25)
+	}
+
+	f := NewFunction(func(t float64) float64 {
+		return 10 * math.Exp(-t/4) * math.Cos(2*math.Pi*t/3)
+	})
+	f.Samples = 200
+	p.Add(NewMinorGrid(), f)
+	p.X.Min, p.X.Max = 0, 12
+	p.Y.Min, p.Y.Max = -10, 10
+
+	err = p.Save(300, 200, "testdata/minorGrid.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestMinorGrid(t *testing.T) {
+	checkPlot(ExampleGrid_minor, t, "minorGrid.png")
+}
+
+func TestGridOrder(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	for _, test := range []struct {
+		order GridOrder
+		want  []color.Color
+	}{
+		{order: GridInOrder, want: []color.Color{red, blue}},
+		{order: GridBelow, want: []color.Color{blue, red}},
+		{order: GridAbove, want: []color.Color{red, blue}},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l, err := NewLine(XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.Color = red
+		g := &Grid{Vertical: draw.LineStyle{Color: blue, Width: 1}, Order: test.order}
+		p.Add(l, g)
+
+		var rec recorder.Canvas
+		p.Draw(draw.NewCanvas(&rec, 100, 100))
+		var got []color.Color
+		for _, a := range rec.Actions {
+			if c, ok := a.(*recorder.SetColor); ok && (c.Color == red || c.Color == blue) {
+				if len(got) == 0 || got[len(got)-1] != c.Color {
+					got = append(got, c.Color)
+				}
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected drawing order for %v: got:%v want:%v", test.order, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Group implements the Plotter interface, drawing a set of
+// plotters as a unit, such as a point with its error bars and
+// label, that can be positioned, scaled and rotated together
+// and reused at several places in a plot.
+//
+// The data coordinates of the plotters of a Group are relative
+// to the position of the Group: the point (0, 0) of the plotters
+// is drawn at (X, Y) in the data coordinates of the plot, moved
+// by Offset. The plotters are then scaled and rotated around
+// that point in the drawing coordinates of the canvas, so the
+// scale and rotation also apply to glyphs, line widths and text.
+type Group struct {
+	// Plotters are the plotters of the group,
+	// drawn in order.
+	Plotters []plot.Plotter
+
+	// X and Y are the position of the group
+	// in the data coordinates of the plot.
+	X, Y float64
+
+	// Offset is added to the position of
+	// the group in drawing coordinates.
+	Offset vg.Point
+
+	// Scale is the factor by which the
+	// plotters of the group are scaled.
+	Scale float64
+
+	// Rotation is the angle in radians by which
+	// the plotters of the group are rotated
+	// counter-clockwise.
+	Rotation float64
+}
+
+// NewGroup returns a Group of the given plotters at the
+// origin of the data coordinates, with a scale of one.
+func NewGroup(ps ...plot.Plotter) *Group {
+	return &Group{Plotters: ps, Scale: 1}
+}
+
+// offsetScale is a plot.Normalizer that normalizes
+// values offset by a constant.
+type offsetScale struct {
+	plot.Normalizer
+	offset float64
+}
+
+// Normalize implements the plot.Normalizer interface.
+func (s offsetScale) Normalize(min, max, x float64) float64 {
+	return s.Normalizer.Normalize(min, max, x+s.offset)
+}
+
+// plot returns a plot with the axes of plt, with
+// the data coordinates relative to the group.
+func (g *Group) plot(plt *plot.Plot) *plot.Plot {
+	p := &plot.Plot{X: plt.X, Y: plt.Y}
+	p.X.Scale = offsetScale{Normalizer: plt.X.Scale, offset: g.X}
+	p.Y.Scale = offsetScale{Normalizer: plt.Y.Scale, offset: g.Y}
+	return p
+}
+
+// Plot implements the plot.Plotter interface.
+func (g *Group) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pt := vg.Point{X: trX(g.X), Y: trY(g.Y)}
+	p := g.plot(plt)
+
+	c.Push()
+	c.Translate(pt.Add(g.Offset))
+	c.Rotate(g.Rotation)
+	c.Scale(g.Scale, g.Scale)
+	c.Translate(vg.Point{X: -pt.X, Y: -pt.Y})
+	for _, d := range g.Plotters {
+		d.Plot(c, p)
+	}
+	c.Pop()
+}
+
+// DataRange implements the plot.DataRanger interface,
+// returning the data range of the plotters of the group
+// that implement it, moved to the position of the group.
+// If none of them do, the range is the position itself.
+func (g *Group) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, d := range g.Plotters {
+		dr, ok := d.(plot.DataRanger)
+		if !ok {
+			continue
+		}
+		x0, x1, y0, y1 := dr.DataRange()
+		xmin = math.Min(xmin, x0+g.X)
+		xmax = math.Max(xmax, x1+g.X)
+		ymin = math.Min(ymin, y0+g.Y)
+		ymax = math.Max(ymax, y1+g.Y)
+	}
+	if xmin > xmax {
+		xmin, xmax = g.X, g.X
+	}
+	if ymin > ymax {
+		ymin, ymax = g.Y, g.Y
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// returning the glyph boxes of the plotters of the group
+// that implement it, moved to the position of the group.
+// The boxes are scaled but not rotated with the group.
+func (g *Group) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	p := g.plot(plt)
+	s := vg.Length(math.Abs(g.Scale))
+	var boxes []plot.GlyphBox
+	for _, d := range g.Plotters {
+		gb, ok := d.(plot.GlyphBoxer)
+		if !ok {
+			continue
+		}
+		for _, b := range gb.GlyphBoxes(p) {
+			b.Min = b.Min.Scale(s).Add(g.Offset)
+			b.Max = b.Max.Scale(s).Add(g.Offset)
+			boxes = append(boxes, b)
+		}
+	}
+	return boxes
+}
+
+// Thumbnail implements the plot.Thumbnailer interface,
+// drawing the thumbnails of the plotters of the group
+// that implement it on top of each other.
+func (g *Group) Thumbnail(c *draw.Canvas) {
+	for _, d := range g.Plotters {
+		if t, ok := d.(plot.Thumbnailer); ok {
+			t.Thumbnail(c)
+		}
+	}
+}
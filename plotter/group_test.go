@@ -0,0 +1,101 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ExampleGroup draws a labeled point with error bars
+// as a composite mark, reused at several positions,
+// with a scale and a rotation.
+func ExampleGroup() {
+	// mark is a point at the origin
+	// with its error bars and a label.
+	type mark struct {
+		XYs
+		YErrors
+		Labels []string
+	}
+	m := mark{
+		XYs:     XYs{{X: 0, Y: 0}},
+		YErrors: YErrors{{Low: 0.5, High: 0.8}},
+		Labels:  []string{"±"},
+	}
+	pts, err := NewScatter(m)
+	if err != nil {
+		log.Panic(err)
+	}
+	pts.Shape = draw.CircleGlyph{}
+	pts.Radius = vg.Points(2)
+	bars, err := NewYErrorBars(m)
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.LineStyle.Width = vg.Points(1)
+	labels, err := NewLabels(XYLabels{XYs: m.XYs, Labels: m.Labels})
+	if err != nil {
+		log.Panic(err)
+	}
+	labels.XOffset = vg.Points(5)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Groups"
+	positions := XYs{{X: 1, Y: 2}, {X: 2, Y: 4}, {X: 3, Y: 3}}
+	for i, pos := range positions {
+		g := NewGroup(pts, bars, labels)
+		g.X, g.Y = pos.X, pos.Y
+		switch i {
+		case 1:
+			g.Scale = 1.5
+		case 2:
+			g.Rotation = math.Pi / 4
+		}
+		p.Add(g)
+	}
+	// The data range of a group does not
+	// include its scale and rotation.
+	p.X.Min, p.X.Max = 0, 4
+	p.Y.Min, p.Y.Max = 1, 5.5
+	p.Legend.Add("mark", NewGroup(pts, bars))
+
+	err = p.Save(200, 200, "testdata/group.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	checkPlot(ExampleGroup, t, "group.png")
+}
+
+func TestGroupDataRange(t *testing.T) {
+	s, err := NewScatter(XYs{{X: -1, Y: 2}, {X: 1, Y: 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := NewGroup(s)
+	g.X, g.Y = 10, 20
+	xmin, xmax, ymin, ymax := g.DataRange()
+	if xmin != 9 || xmax != 11 || ymin != 22 || ymax != 23 {
+		t.Errorf("unexpected data range: got:[%v,%v]x[%v,%v] want:[9,11]x[22,23]", xmin, xmax, ymin, ymax)
+	}
+
+	g = NewGroup()
+	g.X, g.Y = 1, 2
+	xmin, xmax, ymin, ymax = g.DataRange()
+	if xmin != 1 || xmax != 1 || ymin != 2 || ymax != 2 {
+		t.Errorf("unexpected data range of empty group: got:[%v,%v]x[%v,%v] want:[1,1]x[2,2]", xmin, xmax, ymin, ymax)
+	}
+}
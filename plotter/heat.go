@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"image"
 	"image/color"
 	"math"
 
@@ -52,6 +53,14 @@ type HeatMap struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// Rasterized specifies whether the heat map is drawn
+	// by writing pixels directly into the image backing
+	// the canvas, when the canvas is a vg.Blitter, rather
+	// than by filling a path for each grid element.
+	// Rasterized drawing is much faster for large grids,
+	// but element edges are not anti-aliased.
+	Rasterized bool
 }
 
 // NewHeatMap creates as new heat map plotter for the given data,
@@ -103,24 +112,19 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	// ps scales the palette uniformly across the data range.
 	ps := float64(len(pal)-1) / (h.Max - h.Min)
 
+	if h.Rasterized {
+		if img, r, ok := c.BlitRect(c.Rectangle); ok {
+			h.blit(c, plt, img, r, pal, ps)
+			return
+		}
+	}
+
 	trX, trY := plt.Transforms(&c)
 
 	var pa vg.Path
 	cols, rows := h.GridXYZ.Dims()
 	for i := 0; i < cols; i++ {
-
-		var right, left float64
-		switch i {
-		case 0:
-			right = (h.GridXYZ.X(i+1) - h.GridXYZ.X(i)) / 2
-			left = -right
-		case cols - 1:
-			right = (h.GridXYZ.X(i) - h.GridXYZ.X(i-1)) / 2
-			left = -right
-		default:
-			right = (h.GridXYZ.X(i+1) - h.GridXYZ.X(i)) / 2
-			left = -(h.GridXYZ.X(i) - h.GridXYZ.X(i-1)) / 2
-		}
+		left, right := h.columnExtent(i, cols)
 
 		for j := 0; j < rows; j++ {
 			v := h.GridXYZ.Z(i, j)
@@ -130,18 +134,7 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 
 			pa = pa[:0]
 
-			var up, down float64
-			switch j {
-			case 0:
-				up = (h.GridXYZ.Y(j+1) - h.GridXYZ.Y(j)) / 2
-				down = -up
-			case rows - 1:
-				up = (h.GridXYZ.Y(j) - h.GridXYZ.Y(j-1)) / 2
-				down = -up
-			default:
-				up = (h.GridXYZ.Y(j+1) - h.GridXYZ.Y(j)) / 2
-				down = -(h.GridXYZ.Y(j) - h.GridXYZ.Y(j-1)) / 2
-			}
+			down, up := h.rowExtent(j, rows)
 
 			x, y := trX(h.GridXYZ.X(i)+left), trY(h.GridXYZ.Y(j)+down)
 			dx, dy := trX(h.GridXYZ.X(i)+right), trY(h.GridXYZ.Y(j)+up)
@@ -173,25 +166,173 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 }
 
+// columnExtent returns the offsets of the left and right
+// edges of the ith of cols grid columns from its X coordinate.
+func (h *HeatMap) columnExtent(i, cols int) (left, right float64) {
+	switch i {
+	case 0:
+		right = (h.GridXYZ.X(i+1) - h.GridXYZ.X(i)) / 2
+		left = -right
+	case cols - 1:
+		right = (h.GridXYZ.X(i) - h.GridXYZ.X(i-1)) / 2
+		left = -right
+	default:
+		right = (h.GridXYZ.X(i+1) - h.GridXYZ.X(i)) / 2
+		left = -(h.GridXYZ.X(i) - h.GridXYZ.X(i-1)) / 2
+	}
+	return left, right
+}
+
+// rowExtent returns the offsets of the lower and upper
+// edges of the jth of rows grid rows from its Y coordinate.
+func (h *HeatMap) rowExtent(j, rows int) (down, up float64) {
+	switch j {
+	case 0:
+		up = (h.GridXYZ.Y(j+1) - h.GridXYZ.Y(j)) / 2
+		down = -up
+	case rows - 1:
+		up = (h.GridXYZ.Y(j) - h.GridXYZ.Y(j-1)) / 2
+		down = -up
+	default:
+		up = (h.GridXYZ.Y(j+1) - h.GridXYZ.Y(j)) / 2
+		down = -(h.GridXYZ.Y(j) - h.GridXYZ.Y(j-1)) / 2
+	}
+	return down, up
+}
+
+// blit draws the heat map by writing pixels directly into img,
+// where r is the rectangle of pixels covered by the canvas c.
+// A pixel is given the color of the grid element containing
+// its center. As for path based drawing, grid elements that
+// are not completely within c are not drawn.
+func (h *HeatMap) blit(c draw.Canvas, plt *plot.Plot, img *image.RGBA, r image.Rectangle, pal []color.Color, ps float64) {
+	trX, trY := plt.Transforms(&c)
+
+	// xIdx and yIdx hold the grid column and row
+	// for each pixel column and row in r, or -1
+	// when no grid element covers the pixel.
+	cols, rows := h.GridXYZ.Dims()
+	xScale := float64(r.Dx()) / (c.Max.X - c.Min.X).Points()
+	xIdx := make([]int, r.Dx())
+	for i := range xIdx {
+		xIdx[i] = -1
+	}
+	for i := 0; i < cols; i++ {
+		left, right := h.columnExtent(i, cols)
+		x, dx := trX(h.GridXYZ.X(i)+left), trX(h.GridXYZ.X(i)+right)
+		if !c.ContainsX(x) || !c.ContainsX(dx) {
+			continue
+		}
+		fillIndex(xIdx, (x-c.Min.X).Points()*xScale, (dx-c.Min.X).Points()*xScale, i)
+	}
+	yScale := float64(r.Dy()) / (c.Max.Y - c.Min.Y).Points()
+	yIdx := make([]int, r.Dy())
+	for i := range yIdx {
+		yIdx[i] = -1
+	}
+	for j := 0; j < rows; j++ {
+		down, up := h.rowExtent(j, rows)
+		y, dy := trY(h.GridXYZ.Y(j)+down), trY(h.GridXYZ.Y(j)+up)
+		if !c.ContainsY(y) || !c.ContainsY(dy) {
+			continue
+		}
+		// Image rows increase downwards.
+		fillIndex(yIdx, (c.Max.Y-y).Points()*yScale, (c.Max.Y-dy).Points()*yScale, j)
+	}
+
+	b := r.Intersect(img.Bounds())
+	for py := b.Min.Y; py < b.Max.Y; py++ {
+		j := yIdx[py-r.Min.Y]
+		if j < 0 {
+			continue
+		}
+		for px := b.Min.X; px < b.Max.X; px++ {
+			i := xIdx[px-r.Min.X]
+			if i < 0 {
+				continue
+			}
+			v := h.GridXYZ.Z(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			var col color.Color
+			switch {
+			case v < h.Min:
+				col = h.Underflow
+			case v > h.Max:
+				col = h.Overflow
+			default:
+				col = pal[int((v-h.Min)*ps+0.5)] // Apply palette scaling.
+			}
+			if col != nil {
+				blendRGBA(img, px, py, col)
+			}
+		}
+	}
+}
+
+// fillIndex sets the elements of idx whose centers
+// lie in the half open interval between a and b to v.
+func fillIndex(idx []int, a, b float64, v int) {
+	if a > b {
+		a, b = b, a
+	}
+	start := int(math.Ceil(a - 0.5))
+	end := int(math.Ceil(b - 0.5))
+	if start < 0 {
+		start = 0
+	}
+	if end > len(idx) {
+		end = len(idx)
+	}
+	for k := start; k < end; k++ {
+		idx[k] = v
+	}
+}
+
+// blendRGBA composites col over the pixel of img at (x, y).
+func blendRGBA(img *image.RGBA, x, y int, col color.Color) {
+	src := color.RGBAModel.Convert(col).(color.RGBA)
+	if src.A == 0xff {
+		img.SetRGBA(x, y, src)
+		return
+	}
+	dst := img.RGBAAt(x, y)
+	a := uint32(0xff - src.A)
+	img.SetRGBA(x, y, color.RGBA{
+		R: src.R + uint8(uint32(dst.R)*a/0xff),
+		G: src.G + uint8(uint32(dst.G)*a/0xff),
+		B: src.B + uint8(uint32(dst.B)*a/0xff),
+		A: src.A + uint8(uint32(dst.A)*a/0xff),
+	})
+}
+
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (h *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
-	c, r := h.GridXYZ.Dims()
+	return gridRange(h.GridXYZ)
+}
+
+// gridRange returns the range of X and Y values covered by
+// the elements of the grid, which extend half way to the
+// coordinates of their neighbours.
+func gridRange(g GridXYZ) (xmin, xmax, ymin, ymax float64) {
+	c, r := g.Dims()
 	switch c {
 	case 1: // Make a unit length when there is no neighbour.
 		xmax = 0.5
 		xmin = -0.5
 	default:
-		xmax = h.GridXYZ.X(c-1) + (h.GridXYZ.X(c-1)-h.GridXYZ.X(c-2))/2
-		xmin = h.GridXYZ.X(0) - (h.GridXYZ.X(1)-h.GridXYZ.X(0))/2
+		xmax = g.X(c-1) + (g.X(c-1)-g.X(c-2))/2
+		xmin = g.X(0) - (g.X(1)-g.X(0))/2
 	}
 	switch r {
 	case 1: // Make a unit length when there is no neighbour.
 		ymax = 0.5
 		ymin = -0.5
 	default:
-		ymax = h.GridXYZ.Y(r-1) + (h.GridXYZ.Y(r-1)-h.GridXYZ.Y(r-2))/2
-		ymin = h.GridXYZ.Y(0) - (h.GridXYZ.Y(1)-h.GridXYZ.Y(0))/2
+		ymax = g.Y(r-1) + (g.Y(r-1)-g.Y(r-2))/2
+		ymin = g.Y(0) - (g.Y(1)-g.Y(0))/2
 	}
 	return xmin, xmax, ymin, ymax
 }
@@ -5,14 +5,18 @@
 package plotter
 
 import (
+	"image"
+	"image/color"
 	"log"
 	"testing"
 
 	"github.com/gonum/matrix/mat64"
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 	"github.com/gonum/plot/vg/recorder"
+	"github.com/gonum/plot/vg/vgimg"
 )
 
 type offsetUnitGrid struct {
@@ -101,3 +105,85 @@ func TestFlatHeat(t *testing.T) {
 		p.Draw(c)
 	}()
 }
+
+func TestHeatMapRasterized(t *testing.T) {
+	m := offsetUnitGrid{
+		Data: mat64.NewDense(3, 4, []float64{
+			1, 2, 3, 4,
+			5, 6, 7, 8,
+			9, 10, 11, 12,
+		})}
+	pal := palette.Heat(12, 1)
+	h := NewHeatMap(m, pal)
+	h.Rasterized = true
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h)
+	p.HideAxes()
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	// Each grid element is 10×10 pixels.
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	c := vgimg.NewWith(vgimg.UseImage(img), vgimg.UseDPI(72))
+	p.Draw(draw.New(c))
+
+	colors := pal.Colors()
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			want := color.RGBAModel.Convert(colors[int(m.Z(i, j))-1])
+			for _, off := range []image.Point{{1, 1}, {5, 5}, {8, 8}} {
+				x, y := i*10+off.X, (2-j)*10+off.Y
+				got := img.At(x, y)
+				if got != want {
+					t.Errorf("unexpected color at (%d, %d) for element (%d, %d): got:%v want:%v", x, y, i, j, got, want)
+				}
+			}
+		}
+	}
+
+	// Canvases that are not vg.Blitters fall back to filling paths.
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 40, 30))
+	var fills int
+	for _, a := range rec.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			fills++
+		}
+	}
+	// One fill for the background and one for each element.
+	if want := 1 + 4*3; fills != want {
+		t.Errorf("unexpected number of fills: got:%d want:%d", fills, want)
+	}
+}
+
+func BenchmarkHeatMapRasterized(b *testing.B) { heatMapBench(200, true, b) }
+func BenchmarkHeatMapVector(b *testing.B)     { heatMapBench(200, false, b) }
+
+// The 2000×2000 heat maps are the size for which
+// rasterized drawing was added.
+func BenchmarkHeatMapRasterized2000(b *testing.B) { heatMapBench(2000, true, b) }
+func BenchmarkHeatMapVector2000(b *testing.B)     { heatMapBench(2000, false, b) }
+
+func heatMapBench(n int, rasterized bool, b *testing.B) {
+	data := make([]float64, n*n)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	h := NewHeatMap(offsetUnitGrid{Data: mat64.NewDense(n, n, data)}, palette.Heat(12, 1))
+	h.Rasterized = rasterized
+
+	p, err := plot.New()
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Draw(draw.New(vgimg.New(4*vg.Inch, 4*vg.Inch)))
+	}
+}
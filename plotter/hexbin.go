@@ -0,0 +1,203 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// HexBinCell is a hexagonal bin of a HexBin, holding the
+// number of points within the hexagon centered at X, Y.
+type HexBinCell struct {
+	X, Y  float64
+	Count float64
+}
+
+// HexBin implements the Plotter interface, drawing a set of
+// points aggregated into hexagonal bins, each colored by the
+// number of points within it. Binning shows the density of
+// point clouds too large to be drawn as scatter plots.
+//
+// The hexagons are regular when the data area of the plot
+// is square, and stretched with it otherwise.
+type HexBin struct {
+	// Cells holds the bins that hold at least one point.
+	Cells []HexBinCell
+
+	// Width is the width of a hexagon, the distance
+	// between the centers of neighboring bins in a row,
+	// and Height is the distance between the centers of
+	// neighboring rows, in data coordinates.
+	Width, Height float64
+
+	// ColorMap is used to color the bins by their
+	// count, scaled so that the smallest count maps
+	// to the minimum of the ColorMap and the largest
+	// count to the maximum.
+	ColorMap palette.ColorMap
+
+	// LogCounts specifies whether counts are scaled
+	// logarithmically onto the ColorMap.
+	LogCounts bool
+
+	// LineStyle is the style of the outlines
+	// of the hexagons. If its Color is nil, no
+	// outlines are drawn.
+	draw.LineStyle
+
+	// minCount and maxCount are the smallest
+	// and the largest bin counts.
+	minCount, maxCount float64
+}
+
+// NewHexBin returns a HexBin of the points, with n hexagons
+// across the range of their X values, colored with the
+// ColorMap.
+func NewHexBin(xys XYer, n int, cmap palette.ColorMap) (*HexBin, error) {
+	if cmap == nil {
+		return nil, errors.New("Nil color map")
+	}
+	if n <= 0 {
+		return nil, errors.New("Hexbin with non-positive number of bins")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	xmin, xmax, ymin, ymax := XYRange(data)
+	if xmax == xmin {
+		xmax++
+	}
+	if ymax == ymin {
+		ymax++
+	}
+
+	h := &HexBin{
+		Width:    (xmax - xmin) / float64(n),
+		ColorMap: cmap,
+		minCount: math.Inf(1),
+		maxCount: math.Inf(-1),
+	}
+	// Rows of regular hexagons are √3/2 of their
+	// width apart, scaled to the range of Y values.
+	h.Height = h.Width * math.Sqrt(3) / 2 * (ymax - ymin) / (xmax - xmin)
+
+	// Each point is binned into the nearest center of
+	// the two rectangular lattices of the hexagonal grid,
+	// with the centers of the second lattice offset by half
+	// a row and a column. The bins are indexed in units of
+	// half a column and a row from the minimum.
+	counts := make(map[[2]int]float64)
+	for _, p := range data {
+		u, v := (p.X-xmin)/h.Width, (p.Y-ymin)/h.Height
+		i0, j0 := math.Floor(u+0.5), 2*math.Floor(v/2+0.5)
+		i1, j1 := math.Floor(u)+0.5, 2*math.Floor(v/2)+1
+		d0 := (u-i0)*(u-i0) + 0.75*(v-j0)*(v-j0)
+		d1 := (u-i1)*(u-i1) + 0.75*(v-j1)*(v-j1)
+		if d1 < d0 {
+			i0, j0 = i1, j1
+		}
+		counts[[2]int{int(2 * i0), int(j0)}]++
+	}
+	for k, count := range counts {
+		h.Cells = append(h.Cells, HexBinCell{
+			X:     xmin + float64(k[0])/2*h.Width,
+			Y:     ymin + float64(k[1])*h.Height,
+			Count: count,
+		})
+		h.minCount = math.Min(h.minCount, count)
+		h.maxCount = math.Max(h.maxCount, count)
+	}
+	sort.Sort(byRow(h.Cells))
+	return h, nil
+}
+
+// byRow sorts hexagonal bins by row and then by column,
+// so that they are drawn in a fixed order.
+type byRow []HexBinCell
+
+func (b byRow) Len() int { return len(b) }
+func (b byRow) Less(i, j int) bool {
+	if b[i].Y != b[j].Y {
+		return b[i].Y < b[j].Y
+	}
+	return b[i].X < b[j].X
+}
+func (b byRow) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+// Plot implements the plot.Plotter interface.
+func (h *HexBin) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, cell := range h.Cells {
+		clr, ok := countColor(h.ColorMap, h.LogCounts, h.minCount, h.maxCount, cell.Count)
+		if !ok {
+			continue
+		}
+		pts := h.hexagon(cell, trX, trY)
+		c.FillPolygon(clr, c.ClipPolygonXY(pts))
+		if h.LineStyle.Color != nil && h.LineStyle.Width > 0 {
+			c.StrokeLines(h.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+	}
+}
+
+// hexagon returns the corners of the hexagon of a cell.
+func (h *HexBin) hexagon(cell HexBinCell, trX, trY func(float64) vg.Length) []vg.Point {
+	dx, dy := h.Width/2, h.Height/3
+	return []vg.Point{
+		{X: trX(cell.X), Y: trY(cell.Y + 2*dy)},
+		{X: trX(cell.X - dx), Y: trY(cell.Y + dy)},
+		{X: trX(cell.X - dx), Y: trY(cell.Y - dy)},
+		{X: trX(cell.X), Y: trY(cell.Y - 2*dy)},
+		{X: trX(cell.X + dx), Y: trY(cell.Y - dy)},
+		{X: trX(cell.X + dx), Y: trY(cell.Y + dy)},
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (h *HexBin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, cell := range h.Cells {
+		xmin = math.Min(xmin, cell.X-h.Width/2)
+		xmax = math.Max(xmax, cell.X+h.Width/2)
+		ymin = math.Min(ymin, cell.Y-2*h.Height/3)
+		ymax = math.Max(ymax, cell.Y+2*h.Height/3)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// countColor returns the color of a bin with the given count,
+// scaled from the range of counts from min to max onto the
+// range of the ColorMap, logarithmically if log is true. It
+// returns false for bins that are not to be drawn.
+func countColor(cmap palette.ColorMap, log bool, min, max, count float64) (color.Color, bool) {
+	if cmap == nil || count <= 0 {
+		return nil, false
+	}
+	t := 1.0
+	if max > min {
+		if log {
+			t = math.Log(count/min) / math.Log(max/min)
+		} else {
+			t = (count - min) / (max - min)
+		}
+	}
+	lo, hi := cmap.Min(), cmap.Max()
+	v := math.Max(lo, math.Min(hi, lo+t*(hi-lo)))
+	clr, err := cmap.At(v)
+	return clr, err == nil
+}
@@ -0,0 +1,83 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// cloud returns n points drawn from
+// two overlapping normal distributions.
+func cloud(n int) XYs {
+	rnd := rand.New(rand.NewSource(1))
+	pts := make(XYs, n)
+	for i := range pts {
+		x, y := rnd.NormFloat64(), rnd.NormFloat64()
+		if i%3 == 0 {
+			x, y = 0.5*x+2, 0.5*y+1.5
+		}
+		pts[i].X, pts[i].Y = x, 0.6*x+0.8*y
+	}
+	return pts
+}
+
+// ExampleHexBin draws the density of a cloud of
+// points too large to be read as a scatter plot.
+func ExampleHexBin() {
+	h, err := NewHexBin(cloud(100000), 30, palette.Linear(palette.Heat(8, 1)))
+	if err != nil {
+		log.Panic(err)
+	}
+	h.LogCounts = true
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Hexagonal bins"
+	p.Add(h)
+
+	err = p.Save(250, 250, "testdata/hexbin.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHexBin(t *testing.T) {
+	checkPlot(ExampleHexBin, t, "hexbin.png")
+}
+
+func TestNewHexBin(t *testing.T) {
+	// Points around the centers of bins of a grid
+	// two units wide and √3 units high, over a
+	// square range.
+	pts := XYs{{0, 0}, {0, 0}, {2, 0}, {1, math.Sqrt(3)}, {0.1, 0.2}, {1.9, 1.6}, {0, 2}}
+	h, err := NewHexBin(pts, 1, palette.Linear(palette.Heat(2, 1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Width != 2 || math.Abs(h.Height-math.Sqrt(3)) > 1e-12 {
+		t.Errorf("unexpected bin size: got:%vx%v want:2x√3", h.Width, h.Height)
+	}
+	want := []HexBinCell{{X: 0, Y: 0, Count: 3}, {X: 2, Y: 0, Count: 1}, {X: 1, Y: math.Sqrt(3), Count: 3}}
+	if len(h.Cells) != len(want) {
+		t.Fatalf("unexpected number of bins: got:%d want:%d", len(h.Cells), len(want))
+	}
+	for i, c := range h.Cells {
+		if math.Abs(c.X-want[i].X) > 1e-12 || math.Abs(c.Y-want[i].Y) > 1e-12 || c.Count != want[i].Count {
+			t.Errorf("unexpected bin %d: got:%v want:%v", i, c, want[i])
+		}
+	}
+
+	if _, err := NewHexBin(XYs{}, 10, palette.Linear(palette.Heat(2, 1))); err != ErrNoData {
+		t.Errorf("unexpected error for no data: got:%v want:%v", err, ErrNoData)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Histogram2DBin is a rectangular bin of a Histogram2D,
+// holding the number of points within it.
+type Histogram2DBin struct {
+	XMin, XMax float64
+	YMin, YMax float64
+	Count      float64
+}
+
+// Histogram2D implements the Plotter interface, drawing a
+// two dimensional histogram of a set of points, aggregated
+// into a grid of rectangular bins each colored by the number
+// of points within it. Empty bins are not drawn.
+type Histogram2D struct {
+	// Bins holds the bins of the histogram,
+	// by row from the bottom left bin.
+	Bins []Histogram2DBin
+
+	// ColorMap is used to color the bins by their
+	// count, scaled so that the smallest non-zero count
+	// maps to the minimum of the ColorMap and the largest
+	// count to the maximum.
+	ColorMap palette.ColorMap
+
+	// LogCounts specifies whether counts are scaled
+	// logarithmically onto the ColorMap.
+	LogCounts bool
+
+	// minCount and maxCount are the smallest
+	// non-zero and the largest bin counts.
+	minCount, maxCount float64
+}
+
+// NewHistogram2D returns a two dimensional histogram of
+// the points, with the given numbers of bins across the
+// ranges of their X and Y values, colored with the
+// ColorMap.
+func NewHistogram2D(xys XYer, xbins, ybins int, cmap palette.ColorMap) (*Histogram2D, error) {
+	if cmap == nil {
+		return nil, errors.New("Nil color map")
+	}
+	if xbins <= 0 || ybins <= 0 {
+		return nil, errors.New("Histogram with non-positive number of bins")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	xmin, xmax, ymin, ymax := XYRange(data)
+	if xmax == xmin {
+		xmax++
+	}
+	if ymax == ymin {
+		ymax++
+	}
+	w := (xmax - xmin) / float64(xbins)
+	hgt := (ymax - ymin) / float64(ybins)
+
+	h := &Histogram2D{
+		Bins:     make([]Histogram2DBin, xbins*ybins),
+		ColorMap: cmap,
+		minCount: math.Inf(1),
+		maxCount: math.Inf(-1),
+	}
+	for j := 0; j < ybins; j++ {
+		for i := 0; i < xbins; i++ {
+			h.Bins[j*xbins+i] = Histogram2DBin{
+				XMin: xmin + float64(i)*w, XMax: xmin + float64(i+1)*w,
+				YMin: ymin + float64(j)*hgt, YMax: ymin + float64(j+1)*hgt,
+			}
+		}
+	}
+	for _, p := range data {
+		i := int((p.X - xmin) / w)
+		if i >= xbins {
+			i = xbins - 1
+		}
+		j := int((p.Y - ymin) / hgt)
+		if j >= ybins {
+			j = ybins - 1
+		}
+		h.Bins[j*xbins+i].Count++
+	}
+	for _, b := range h.Bins {
+		if b.Count > 0 {
+			h.minCount = math.Min(h.minCount, b.Count)
+			h.maxCount = math.Max(h.maxCount, b.Count)
+		}
+	}
+	return h, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (h *Histogram2D) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, b := range h.Bins {
+		clr, ok := countColor(h.ColorMap, h.LogCounts, h.minCount, h.maxCount, b.Count)
+		if !ok {
+			continue
+		}
+		x0, x1 := trX(b.XMin), trX(b.XMax)
+		y0, y1 := trY(b.YMin), trY(b.YMax)
+		pts := []vg.Point{{X: x0, Y: y0}, {X: x0, Y: y1}, {X: x1, Y: y1}, {X: x1, Y: y0}}
+		c.FillPolygon(clr, c.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (h *Histogram2D) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, b := range h.Bins {
+		xmin = math.Min(xmin, b.XMin)
+		xmax = math.Max(xmax, b.XMax)
+		ymin = math.Min(ymin, b.YMin)
+		ymax = math.Max(ymax, b.YMax)
+	}
+	return xmin, xmax, ymin, ymax
+}
@@ -0,0 +1,63 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleHistogram2D draws a two dimensional
+// histogram of a large cloud of points.
+func ExampleHistogram2D() {
+	h, err := NewHistogram2D(cloud(100000), 40, 40, palette.Linear(palette.Heat(8, 1)))
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "2D histogram"
+	p.Add(h)
+
+	err = p.Save(250, 250, "testdata/histogram2d.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHistogram2D(t *testing.T) {
+	checkPlot(ExampleHistogram2D, t, "histogram2d.png")
+}
+
+func TestNewHistogram2D(t *testing.T) {
+	pts := XYs{{0, 0}, {1, 0}, {4, 0}, {4, 2}, {2.5, 1.5}}
+	h, err := NewHistogram2D(pts, 2, 2, palette.Linear(palette.Heat(2, 1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Histogram2DBin{
+		{XMin: 0, XMax: 2, YMin: 0, YMax: 1, Count: 2},
+		{XMin: 2, XMax: 4, YMin: 0, YMax: 1, Count: 1},
+		{XMin: 0, XMax: 2, YMin: 1, YMax: 2, Count: 0},
+		{XMin: 2, XMax: 4, YMin: 1, YMax: 2, Count: 2},
+	}
+	for i, b := range h.Bins {
+		if b != want[i] {
+			t.Errorf("unexpected bin %d: got:%v want:%v", i, b, want[i])
+		}
+	}
+	if h.minCount != 1 || h.maxCount != 2 {
+		t.Errorf("unexpected count range: got:[%v, %v] want:[1, 2]", h.minCount, h.maxCount)
+	}
+	if xmin, xmax, ymin, ymax := h.DataRange(); xmin != 0 || xmax != 4 || ymin != 0 || ymax != 2 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 4 0 2", xmin, xmax, ymin, ymax)
+	}
+}
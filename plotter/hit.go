@@ -0,0 +1,107 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// appendHitBox appends the box of the element i, bounded by
+// the corners a and b, clipped to the canvas c, to boxes,
+// unless the box is outside of the canvas.
+func appendHitBox(boxes []plot.HitBox, c draw.Canvas, i int, a, b vg.Point) []plot.HitBox {
+	if a.X > b.X {
+		a.X, b.X = b.X, a.X
+	}
+	if a.Y > b.Y {
+		a.Y, b.Y = b.Y, a.Y
+	}
+	r := vg.Rectangle{Min: a, Max: b}
+	if r.Min.X < c.Min.X {
+		r.Min.X = c.Min.X
+	}
+	if r.Min.Y < c.Min.Y {
+		r.Min.Y = c.Min.Y
+	}
+	if r.Max.X > c.Max.X {
+		r.Max.X = c.Max.X
+	}
+	if r.Max.Y > c.Max.Y {
+		r.Max.Y = c.Max.Y
+	}
+	if r.Min.X > r.Max.X || r.Min.Y > r.Max.Y {
+		return boxes
+	}
+	return append(boxes, plot.HitBox{Index: i, Rectangle: r})
+}
+
+// pointHitBoxes returns the boxes of the given radius
+// around the points xys, drawn on the canvas c of plt.
+func pointHitBoxes(xys XYs, radius vg.Length, c draw.Canvas, plt *plot.Plot) []plot.HitBox {
+	trX, trY := plt.Transforms(&c)
+	var boxes []plot.HitBox
+	for i, p := range xys {
+		pt := vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		d := vg.Point{X: radius, Y: radius}
+		boxes = appendHitBox(boxes, c, i, pt.Sub(d), pt.Add(d))
+	}
+	return boxes
+}
+
+// HitBoxes implements the plot.HitBoxer interface, returning
+// the boxes of the glyphs of the points of the scatter.
+func (pts *Scatter) HitBoxes(c draw.Canvas, plt *plot.Plot) []plot.HitBox {
+	return pointHitBoxes(pts.XYs, pts.Radius, c, plt)
+}
+
+// HitBoxes implements the plot.HitBoxer interface, returning
+// the boxes of the glyphs of the points of the line, or of
+// half of the width of the line around the points if they
+// have no glyphs.
+func (pts *Line) HitBoxes(c draw.Canvas, plt *plot.Plot) []plot.HitBox {
+	radius := pts.Width / 2
+	if pts.Points != nil {
+		radius = pts.Points.Radius
+	}
+	return pointHitBoxes(pts.XYs, radius, c, plt)
+}
+
+// HitBoxes implements the plot.HitBoxer interface,
+// returning the boxes of the bars of the chart.
+func (b *BarChart) HitBoxes(c draw.Canvas, plt *plot.Plot) []plot.HitBox {
+	trCat, trVal := plt.Transforms(&c)
+	if b.Horizontal {
+		trCat, trVal = trVal, trCat
+	}
+	var boxes []plot.HitBox
+	for i, ht := range b.Values {
+		catMin := trCat(b.XMin+float64(i)) - b.Width/2 + b.Offset
+		catMax := catMin + b.Width
+		bottom := b.stackedOn.BarHeight(i)
+		valMin, valMax := trVal(bottom), trVal(bottom+ht)
+		lo, hi := vg.Point{X: catMin, Y: valMin}, vg.Point{X: catMax, Y: valMax}
+		if b.Horizontal {
+			lo, hi = vg.Point{X: valMin, Y: catMin}, vg.Point{X: valMax, Y: catMax}
+		}
+		boxes = appendHitBox(boxes, c, i, lo, hi)
+	}
+	return boxes
+}
+
+// HitBoxes implements the plot.HitBoxer interface,
+// returning the boxes of the bins of the histogram.
+func (h *Histogram) HitBoxes(c draw.Canvas, plt *plot.Plot) []plot.HitBox {
+	trX, trY := plt.Transforms(&c)
+	var boxes []plot.HitBox
+	for i, bin := range h.Bins {
+		boxes = appendHitBox(boxes, c, i,
+			vg.Point{X: trX(bin.Min), Y: trY(0)},
+			vg.Point{X: trX(bin.Max), Y: trY(bin.Weight)},
+		)
+	}
+	return boxes
+}
@@ -0,0 +1,65 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestHitBoxes(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bars, err := NewBarChart(Values{1, 3, 2}, vg.Points(20))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := NewScatter(XYs{{X: 0, Y: 1}, {X: 1, Y: 3}, {X: 2, Y: 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(bars, s)
+
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	hits := p.HitBoxes(c)
+	if len(hits) != 6 {
+		t.Fatalf("unexpected number of hit boxes: got:%d want:6", len(hits))
+	}
+	dc := p.DataCanvas(c)
+	trX, trY := p.Transforms(&dc)
+	for i, h := range hits[:3] {
+		if h.Plotter != bars || h.Index != i {
+			t.Errorf("unexpected hit %d: got:%T %d", i, h.Plotter, h.Index)
+		}
+		// The outer bars are clipped to the data area.
+		want := vg.Points(10)
+		if i == 1 {
+			want = vg.Points(20)
+		}
+		if w := h.Size().X; w != want {
+			t.Errorf("unexpected width of bar %d: got:%v want:%v", i, w, want)
+		}
+		if top := h.Max.Y; top != trY(bars.Values[i]) {
+			t.Errorf("unexpected top of bar %d: got:%v want:%v", i, top, trY(bars.Values[i]))
+		}
+	}
+
+	// The point of the scatter on top of the
+	// middle bar is hit before the bar.
+	pt := vg.Point{X: trX(1), Y: trY(3)}
+	at := p.HitsAt(c, pt)
+	if len(at) != 2 || at[0].Plotter != s || at[0].Index != 1 || at[1].Plotter != bars || at[1].Index != 1 {
+		t.Errorf("unexpected hits at the top of the middle bar: got:%v", at)
+	}
+	if at := p.HitsAt(c, vg.Point{X: trX(1), Y: trY(3) + 2*s.Radius}); len(at) != 0 {
+		t.Errorf("unexpected hits above the middle bar: got:%v", at)
+	}
+}
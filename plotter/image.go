@@ -6,6 +6,7 @@ package plotter
 
 import (
 	"image"
+	"image/color"
 	"math"
 
 	"github.com/gonum/plot"
@@ -13,8 +14,38 @@ import (
 	"github.com/gonum/plot/vg/draw"
 )
 
+// Interpolation is a method of resampling the
+// pixels of an Image to the size it is drawn at.
+type Interpolation int
+
+const (
+	// NoInterpolation leaves the scaling of
+	// the image to the backend of the canvas.
+	NoInterpolation Interpolation = iota
+
+	// NearestNeighbor draws each pixel of the image
+	// as a sharp block, as suits images of discrete
+	// values such as classified rasters.
+	NearestNeighbor
+
+	// Bilinear interpolates linearly between the
+	// centers of the pixels of the image, as suits
+	// images of continuous values such as maps and
+	// micrographs.
+	Bilinear
+)
+
+// resampleDPI is the resolution at which an
+// Image is resampled for interpolation.
+const resampleDPI = 192
+
 // Image is a plotter that draws a scaled, raster image.
 type Image struct {
+	// Interpolation is the method by which the image
+	// is resampled to the size it is drawn at, so that
+	// it is drawn alike by all of the backends.
+	Interpolation Interpolation
+
 	img            image.Image
 	cols           int
 	rows           int
@@ -56,7 +87,87 @@ func (img *Image) Plot(c draw.Canvas, p *plot.Plot) {
 		Min: vg.Point{X: xmin, Y: ymin},
 		Max: vg.Point{X: xmax, Y: ymax},
 	}
-	c.DrawImage(rect, img.img)
+	src := img.img
+	if img.Interpolation != NoInterpolation {
+		size := rect.Size()
+		w := int(math.Ceil(math.Abs(size.X.Points()) / vg.Inch.Points() * resampleDPI))
+		h := int(math.Ceil(math.Abs(size.Y.Points()) / vg.Inch.Points() * resampleDPI))
+		src = resample(src, w, h, img.Interpolation)
+	}
+	c.DrawImage(rect, src)
+}
+
+// resample returns img resampled to w×h pixels
+// with the given interpolation.
+func resample(img image.Image, w, h int, interp Interpolation) *image.RGBA64 {
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	b := img.Bounds()
+	dst := image.NewRGBA64(image.Rect(0, 0, w, h))
+	sx := float64(b.Dx()) / float64(w)
+	sy := float64(b.Dy()) / float64(h)
+	for j := 0; j < h; j++ {
+		// v is the position of the center of the row
+		// in the image, in units of its pixels.
+		v := (float64(j) + 0.5) * sy
+		for i := 0; i < w; i++ {
+			u := (float64(i) + 0.5) * sx
+			switch interp {
+			case NearestNeighbor:
+				dst.Set(i, j, img.At(b.Min.X+int(u), b.Min.Y+int(v)))
+			case Bilinear:
+				dst.SetRGBA64(i, j, bilinear(img, u-0.5, v-0.5))
+			default:
+				panic("plotter: unknown interpolation")
+			}
+		}
+	}
+	return dst
+}
+
+// bilinear returns the color of img interpolated at (u, v)
+// in units of its pixels relative to the center of its first
+// pixel, clamping to the edges of the image. Colors are
+// interpolated as premultiplied by their alpha.
+func bilinear(img image.Image, u, v float64) color.RGBA64 {
+	b := img.Bounds()
+	clamp := func(f float64, n int) (i0, i1 int, t float64) {
+		f = math.Max(0, math.Min(f, float64(n-1)))
+		i0 = int(f)
+		i1 = i0 + 1
+		if i1 >= n {
+			i1 = n - 1
+		}
+		return i0, i1, f - float64(i0)
+	}
+	x0, x1, tx := clamp(u, b.Dx())
+	y0, y1, ty := clamp(v, b.Dy())
+	var sum [4]float64
+	for _, p := range []struct {
+		x, y int
+		w    float64
+	}{
+		{x0, y0, (1 - tx) * (1 - ty)},
+		{x1, y0, tx * (1 - ty)},
+		{x0, y1, (1 - tx) * ty},
+		{x1, y1, tx * ty},
+	} {
+		r, g, bl, a := img.At(b.Min.X+p.x, b.Min.Y+p.y).RGBA()
+		sum[0] += p.w * float64(r)
+		sum[1] += p.w * float64(g)
+		sum[2] += p.w * float64(bl)
+		sum[3] += p.w * float64(a)
+	}
+	return color.RGBA64{
+		R: uint16(sum[0] + 0.5),
+		G: uint16(sum[1] + 0.5),
+		B: uint16(sum[2] + 0.5),
+		A: uint16(sum[3] + 0.5),
+	}
 }
 
 // DataRange implements the DataRange method
@@ -5,6 +5,8 @@
 package plotter
 
 import (
+	"image"
+	"image/color"
 	"image/png"
 	"log"
 	"os"
@@ -52,3 +54,65 @@ func ExampleImage() {
 func TestImagePlot(t *testing.T) {
 	checkPlot(ExampleImage, t, "image_plot.png")
 }
+
+// ExampleImage_interpolated draws a small raster beneath a
+// line, once with each interpolation.
+func ExampleImage_interpolated() {
+	img := image.NewGray(image.Rect(0, 0, 3, 3))
+	for i, v := range []uint8{
+		40, 120, 200,
+		120, 255, 120,
+		200, 120, 40,
+	} {
+		img.Pix[i] = v
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Nearest neighbor and bilinear"
+
+	nearest := NewImage(img, 0, 0, 3, 3)
+	nearest.Interpolation = NearestNeighbor
+	bilinear := NewImage(img, 4, 0, 7, 3)
+	bilinear.Interpolation = Bilinear
+
+	l, err := NewLine(XYs{{0, 0.5}, {7, 2.5}})
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Color = color.RGBA{R: 255, A: 255}
+	l.Width = vg.Points(2)
+	p.Add(nearest, bilinear, l)
+
+	err = p.Save(300, 150, "testdata/interpolatedImage.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestInterpolatedImage(t *testing.T) {
+	checkPlot(ExampleImage_interpolated, t, "interpolatedImage.png")
+}
+
+func TestResample(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 2, 1))
+	img.Pix[0], img.Pix[1] = 0, 255
+
+	got := resample(img, 4, 1, NearestNeighbor)
+	for i, want := range []uint16{0, 0, 0xffff, 0xffff} {
+		if c := got.RGBA64At(i, 0); c.R != want {
+			t.Errorf("unexpected nearest neighbor value at %d: got:%#x want:%#x", i, c.R, want)
+		}
+	}
+
+	// The outer pixels are clamped to the edges,
+	// and the inner pixels are interpolated.
+	got = resample(img, 4, 1, Bilinear)
+	for i, want := range []uint16{0, 0x4000, 0xbfff, 0xffff} {
+		if c := got.RGBA64At(i, 0); c.R != want {
+			t.Errorf("unexpected bilinear value at %d: got:%#x want:%#x", i, c.R, want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Importance implements the Plotter interface, drawing
+// horizontal bars ranking the importance of the features
+// of a model, with optional error whiskers. The most
+// important feature is drawn at the top of the plot.
+//
+// The bar for the feature of rank i, counting from zero,
+// is drawn at a Y value of len(Values)-1-i, so the names
+// of the features may be shown on the Y axis with
+//
+//	p.NominalY(imp.Labels()...)
+type Importance struct {
+	// Names holds the names of the features
+	// in order of decreasing importance.
+	Names []string
+
+	// Values holds the importance of each feature.
+	Values
+
+	// Errors holds the error in the importance
+	// of each feature. Errors may be nil.
+	Errors Values
+
+	// Width is the width of the bars.
+	Width vg.Length
+
+	// Color is the fill color of the bars.
+	Color color.Color
+
+	// LineStyle is the style of the outline of the bars.
+	draw.LineStyle
+
+	// ErrorStyle is the style of the error whiskers.
+	ErrorStyle draw.LineStyle
+
+	// CapWidth is the width of the caps at
+	// the ends of the error whiskers.
+	CapWidth vg.Length
+}
+
+// NewImportance returns an importance chart for the named
+// features, ranked by decreasing value. The errs parameter
+// holds the error of each value and may be nil.
+func NewImportance(names []string, values, errs []float64) (*Importance, error) {
+	if len(names) != len(values) {
+		return nil, errors.New("Number of names does not match the number of values")
+	}
+	if errs != nil && len(errs) != len(values) {
+		return nil, errors.New("Number of errors does not match the number of values")
+	}
+	if len(values) == 0 {
+		return nil, ErrNoData
+	}
+	if err := CheckFloats(values...); err != nil {
+		return nil, err
+	}
+	if err := CheckFloats(errs...); err != nil {
+		return nil, err
+	}
+
+	r := byImportance{
+		names:  append([]string(nil), names...),
+		values: append(Values(nil), values...),
+	}
+	if errs != nil {
+		r.errs = make(Values, len(errs))
+		for i, e := range errs {
+			r.errs[i] = math.Abs(e)
+		}
+	}
+	sort.Stable(sort.Reverse(r))
+
+	return &Importance{
+		Names:      r.names,
+		Values:     r.values,
+		Errors:     r.errs,
+		Width:      vg.Points(10),
+		Color:      color.Gray{128},
+		LineStyle:  DefaultLineStyle,
+		ErrorStyle: DefaultLineStyle,
+		CapWidth:   DefaultCapWidth,
+	}, nil
+}
+
+// Labels returns the names of the features in order
+// of increasing Y value, for use with plot.NominalY.
+func (imp *Importance) Labels() []string {
+	ls := make([]string, len(imp.Names))
+	for i, n := range imp.Names {
+		ls[len(ls)-1-i] = n
+	}
+	return ls
+}
+
+// y returns the Y value of the bar of rank i.
+func (imp *Importance) y(i int) float64 {
+	return float64(len(imp.Values) - 1 - i)
+}
+
+// Plot implements the plot.Plotter interface.
+func (imp *Importance) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	x0 := trX(0)
+	for i, v := range imp.Values {
+		y := trY(imp.y(i))
+		if !c.ContainsY(y) {
+			continue
+		}
+		ymin, ymax := y-imp.Width/2, y+imp.Width/2
+		x := trX(v)
+		pts := []vg.Point{{X: x0, Y: ymin}, {X: x0, Y: ymax}, {X: x, Y: ymax}, {X: x, Y: ymin}}
+		c.FillPolygon(imp.Color, c.ClipPolygonX(pts))
+		pts = append(pts, pts[0])
+		c.StrokeLines(imp.LineStyle, c.ClipLinesX(pts)...)
+
+		if imp.Errors == nil {
+			continue
+		}
+		xlow, xhigh := trX(v-imp.Errors[i]), trX(v+imp.Errors[i])
+		c.StrokeLines(imp.ErrorStyle, c.ClipLinesX([]vg.Point{{X: xlow, Y: y}, {X: xhigh, Y: y}})...)
+		for _, x := range []vg.Length{xlow, xhigh} {
+			if c.ContainsX(x) {
+				c.StrokeLine2(imp.ErrorStyle, x, y-imp.CapWidth/2, x, y+imp.CapWidth/2)
+			}
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+// The X range always includes zero.
+func (imp *Importance) DataRange() (xmin, xmax, ymin, ymax float64) {
+	for i, v := range imp.Values {
+		var e float64
+		if imp.Errors != nil {
+			e = imp.Errors[i]
+		}
+		xmin = math.Min(xmin, v-e)
+		xmax = math.Max(xmax, v+e)
+	}
+	return xmin, xmax, 0, imp.y(0)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the width of the bars.
+func (imp *Importance) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	h := imp.Width
+	if imp.Errors != nil && imp.CapWidth > h {
+		h = imp.CapWidth
+	}
+	bs := make([]plot.GlyphBox, len(imp.Values))
+	for i := range imp.Values {
+		bs[i].Y = plt.Y.Norm(imp.y(i))
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{Y: -h / 2},
+			Max: vg.Point{Y: h / 2},
+		}
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (imp *Importance) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(imp.Color, c.ClipPolygonY(pts))
+	pts = append(pts, pts[0])
+	c.StrokeLines(imp.LineStyle, c.ClipLinesY(pts)...)
+}
+
+// byImportance sorts features by increasing value.
+type byImportance struct {
+	names  []string
+	values Values
+	errs   Values
+}
+
+func (s byImportance) Len() int           { return len(s.values) }
+func (s byImportance) Less(i, j int) bool { return s.values[i] < s.values[j] }
+func (s byImportance) Swap(i, j int) {
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	if s.errs != nil {
+		s.errs[i], s.errs[j] = s.errs[j], s.errs[i]
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleImportance draws the permutation importance of
+// the features of a model, with the standard deviation
+// over repeated permutations.
+func ExampleImportance() {
+	names := []string{"age", "income", "tenure", "region", "visits"}
+	values := []float64{0.12, 0.31, 0.05, 0.02, 0.18}
+	errs := []float64{0.02, 0.04, 0.01, 0.015, 0.03}
+
+	imp, err := NewImportance(names, values, errs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Feature importance"
+	p.X.Label.Text = "Decrease in accuracy"
+	p.Add(imp)
+	p.NominalY(imp.Labels()...)
+
+	err = p.Save(200, 200, "testdata/importance.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestImportance(t *testing.T) {
+	checkPlot(ExampleImportance, t, "importance.png")
+}
+
+func TestNewImportance(t *testing.T) {
+	imp, err := NewImportance(
+		[]string{"a", "b", "c", "d"},
+		[]float64{2, 3, 1, 3},
+		[]float64{-0.5, 0.25, 0.125, 1},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"b", "d", "a", "c"}; !reflect.DeepEqual(imp.Names, want) {
+		t.Errorf("unexpected names: got:%v want:%v", imp.Names, want)
+	}
+	if want := (Values{3, 3, 2, 1}); !reflect.DeepEqual(imp.Values, want) {
+		t.Errorf("unexpected values: got:%v want:%v", imp.Values, want)
+	}
+	if want := (Values{0.25, 1, 0.5, 0.125}); !reflect.DeepEqual(imp.Errors, want) {
+		t.Errorf("unexpected errors: got:%v want:%v", imp.Errors, want)
+	}
+	if want := []string{"c", "a", "d", "b"}; !reflect.DeepEqual(imp.Labels(), want) {
+		t.Errorf("unexpected labels: got:%v want:%v", imp.Labels(), want)
+	}
+	xmin, xmax, ymin, ymax := imp.DataRange()
+	if xmin != 0 || xmax != 4 || ymin != 0 || ymax != 3 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 4 0 3", xmin, xmax, ymin, ymax)
+	}
+
+	if _, err := NewImportance([]string{"a"}, []float64{1, 2}, nil); err == nil {
+		t.Error("expected error for mismatched names")
+	}
+}
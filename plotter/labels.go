@@ -36,6 +36,10 @@ type Labels struct {
 	// XOffset and YOffset are added directly to the final
 	// label X and Y location respectively.
 	XOffset, YOffset vg.Length
+
+	// Box is the style of the box drawn
+	// behind each label.
+	Box draw.BoxStyle
 }
 
 // NewLabels returns a new Labels using the DefaultFont and
@@ -82,6 +86,10 @@ func (l *Labels) Plot(c draw.Canvas, p *plot.Plot) {
 		}
 		pt.X += l.XOffset
 		pt.Y += l.YOffset
+		if l.Box.Background != nil || l.Box.Border.Color != nil {
+			r := l.TextStyle[i].Rectangle(label)
+			c.DrawBox(l.Box, vg.Rectangle{Min: r.Min.Add(pt), Max: r.Max.Add(pt)})
+		}
 		c.FillText(l.TextStyle[i], pt, label)
 	}
 }
@@ -100,7 +108,12 @@ func (l *Labels) GlyphBoxes(p *plot.Plot) []plot.GlyphBox {
 		bs[i].X = p.X.Norm(l.XYs[i].X)
 		bs[i].Y = p.Y.Norm(l.XYs[i].Y)
 		sty := l.TextStyle[i]
-		bs[i].Rectangle = sty.Rectangle(label)
+		r := sty.Rectangle(label)
+		if in := l.Box.Inset(); in > 0 {
+			r.Min = r.Min.Sub(vg.Point{X: in, Y: in})
+			r.Max = r.Max.Add(vg.Point{X: in, Y: in})
+		}
+		bs[i].Rectangle = r
 	}
 	return bs
 }
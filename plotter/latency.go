@@ -0,0 +1,249 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// LatencyBucket is a bucket of a latency histogram, holding
+// the number of latencies recorded between Low and High.
+type LatencyBucket struct {
+	Low, High float64
+	Count     float64
+}
+
+// LatencyHistogram is a histogram of the latencies recorded
+// during an interval of time, such as an interval exported
+// by an HdrHistogram log.
+type LatencyHistogram struct {
+	// Start and End are the times of
+	// the start and end of the interval.
+	Start, End float64
+
+	// Buckets holds the buckets of the histogram.
+	Buckets []LatencyBucket
+}
+
+// LatencyHeatMap implements the Plotter interface, drawing a
+// heat map of latency histograms over time. Time runs along
+// the X axis and latency along the Y axis, and each bucket
+// of each histogram is drawn as a rectangle colored by its
+// count. Lines may be drawn through the percentiles of the
+// latencies of each interval.
+//
+// Latencies usually span several orders of magnitude, and
+// are best shown on a logarithmic axis with
+//
+//	p.Y.Scale = plot.LogScale{}
+//	p.Y.Tick.Marker = plot.LogTicks{}
+type LatencyHeatMap struct {
+	// Histograms holds the histograms of each
+	// interval, with their buckets in order of
+	// increasing latency.
+	Histograms []LatencyHistogram
+
+	// ColorMap is used to color the buckets by their
+	// count, scaled so that the smallest non-zero count
+	// maps to the minimum of the ColorMap and the largest
+	// count to the maximum. Empty buckets are not drawn.
+	ColorMap palette.ColorMap
+
+	// LogCounts specifies whether counts are scaled
+	// logarithmically onto the ColorMap.
+	LogCounts bool
+
+	// Percentiles holds the quantiles, between zero and
+	// one, of the latencies through which lines are drawn.
+	Percentiles []float64
+
+	// PercentileStyles holds the styles of the percentile
+	// lines. The line of Percentiles[i] is drawn with
+	// PercentileStyles[i%len(PercentileStyles)].
+	PercentileStyles []draw.LineStyle
+
+	// minCount and maxCount are the smallest non-zero
+	// and the largest bucket counts.
+	minCount, maxCount float64
+}
+
+// NewLatencyHeatMap returns a latency heat map of the given
+// histograms, colored with the ColorMap. The bounds of buckets
+// with a non-zero count must be positive, and histograms must
+// not overlap in time. Buckets are copied and sorted by latency.
+func NewLatencyHeatMap(hs []LatencyHistogram, cmap palette.ColorMap) (*LatencyHeatMap, error) {
+	if cmap == nil {
+		return nil, errors.New("Nil color map")
+	}
+	h := &LatencyHeatMap{
+		Histograms: make([]LatencyHistogram, len(hs)),
+		ColorMap:   cmap,
+		LogCounts:  true,
+		minCount:   math.Inf(1),
+		maxCount:   math.Inf(-1),
+		PercentileStyles: []draw.LineStyle{
+			{Color: color.Black, Width: vg.Points(1)},
+			{Color: color.Black, Width: vg.Points(1), Dashes: []vg.Length{vg.Points(4), vg.Points(2)}},
+			{Color: color.Black, Width: vg.Points(1), Dashes: []vg.Length{vg.Points(1), vg.Points(2)}},
+		},
+	}
+	for i, hist := range hs {
+		if err := CheckFloats(hist.Start, hist.End); err != nil {
+			return nil, err
+		}
+		if hist.End <= hist.Start {
+			return nil, errors.New("Latency histogram interval ends before it starts")
+		}
+		bs := make([]LatencyBucket, 0, len(hist.Buckets))
+		for _, b := range hist.Buckets {
+			if err := CheckFloats(b.Low, b.High, b.Count); err != nil {
+				return nil, err
+			}
+			if b.Count < 0 {
+				return nil, errors.New("Negative latency bucket count")
+			}
+			if b.Count == 0 {
+				continue
+			}
+			if b.Low <= 0 || b.High < b.Low {
+				return nil, errors.New("Invalid latency bucket bounds")
+			}
+			h.minCount = math.Min(h.minCount, b.Count)
+			h.maxCount = math.Max(h.maxCount, b.Count)
+			bs = append(bs, b)
+		}
+		sort.Sort(byLatency(bs))
+		h.Histograms[i] = LatencyHistogram{Start: hist.Start, End: hist.End, Buckets: bs}
+	}
+	if math.IsInf(h.maxCount, -1) {
+		return nil, ErrNoData
+	}
+	return h, nil
+}
+
+// byLatency sorts latency buckets by their lower bound.
+type byLatency []LatencyBucket
+
+func (b byLatency) Len() int           { return len(b) }
+func (b byLatency) Less(i, j int) bool { return b[i].Low < b[j].Low }
+func (b byLatency) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+// Plot implements the plot.Plotter interface.
+func (h *LatencyHeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for _, hist := range h.Histograms {
+		x0, x1 := trX(hist.Start), trX(hist.End)
+		for _, b := range hist.Buckets {
+			clr, ok := h.color(b.Count)
+			if !ok {
+				continue
+			}
+			y0, y1 := trY(b.Low), trY(b.High)
+			pts := []vg.Point{{X: x0, Y: y0}, {X: x0, Y: y1}, {X: x1, Y: y1}, {X: x1, Y: y0}}
+			c.FillPolygon(clr, c.ClipPolygonXY(pts))
+		}
+	}
+
+	if len(h.PercentileStyles) == 0 {
+		return
+	}
+	for i, q := range h.Percentiles {
+		sty := h.PercentileStyles[i%len(h.PercentileStyles)]
+		pts := h.Percentile(q)
+		lines := make([]vg.Point, len(pts))
+		for j, pt := range pts {
+			lines[j] = vg.Point{X: trX(pt.X), Y: trY(pt.Y)}
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(lines)...)
+	}
+}
+
+// color returns the color of a bucket with the given count.
+func (h *LatencyHeatMap) color(count float64) (color.Color, bool) {
+	return countColor(h.ColorMap, h.LogCounts, h.minCount, h.maxCount, count)
+}
+
+// Percentile returns the points of the line through the q
+// quantile of the latencies of each histogram, placed at
+// the middle of its interval. Histograms without recorded
+// latencies are skipped. The quantile is interpolated
+// linearly within its bucket.
+func (h *LatencyHeatMap) Percentile(q float64) XYs {
+	var pts XYs
+	for _, hist := range h.Histograms {
+		var total float64
+		for _, b := range hist.Buckets {
+			total += b.Count
+		}
+		if total == 0 {
+			continue
+		}
+		target := q * total
+		var cum float64
+		v := hist.Buckets[len(hist.Buckets)-1].High
+		for _, b := range hist.Buckets {
+			if cum+b.Count >= target {
+				v = b.Low + (b.High-b.Low)*(target-cum)/b.Count
+				break
+			}
+			cum += b.Count
+		}
+		pts = append(pts, struct{ X, Y float64 }{X: (hist.Start + hist.End) / 2, Y: v})
+	}
+	return pts
+}
+
+// PercentileThumbnailers returns the names of the
+// percentiles, such as "p99.9", and a Thumbnailer
+// drawing the line of each percentile, for adding
+// a legend entry for each line to a plot.
+func (h *LatencyHeatMap) PercentileThumbnailers() (names []string, thumbnailers []plot.Thumbnailer) {
+	if len(h.PercentileStyles) == 0 {
+		return nil, nil
+	}
+	for i, q := range h.Percentiles {
+		names = append(names, "p"+strconv.FormatFloat(100*q, 'f', -1, 64))
+		thumbnailers = append(thumbnailers, percentileThumbnailer{h.PercentileStyles[i%len(h.PercentileStyles)]})
+	}
+	return names, thumbnailers
+}
+
+// percentileThumbnailer implements the Thumbnailer
+// interface for the percentile lines of a latency
+// heat map.
+type percentileThumbnailer struct {
+	draw.LineStyle
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface.
+func (t percentileThumbnailer) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(t.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (h *LatencyHeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, hist := range h.Histograms {
+		xmin = math.Min(xmin, hist.Start)
+		xmax = math.Max(xmax, hist.End)
+		for _, b := range hist.Buckets {
+			ymin = math.Min(ymin, b.Low)
+			ymax = math.Max(ymax, b.High)
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
@@ -0,0 +1,140 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleLatencyHeatMap draws the latencies of requests to a
+// service over an hour, in histograms of one minute intervals
+// with buckets growing by a factor of 1.25, with a slowdown
+// half way through the hour.
+func ExampleLatencyHeatMap() {
+	rnd := rand.New(rand.NewSource(1))
+
+	var hs []LatencyHistogram
+	for m := 0; m < 60; m++ {
+		h := LatencyHistogram{Start: float64(m), End: float64(m + 1)}
+		for low := 1.0; low < 1e4; low *= 1.25 {
+			h.Buckets = append(h.Buckets, LatencyBucket{Low: low, High: low * 1.25})
+		}
+		mu := math.Log(20)
+		if m >= 30 && m < 40 {
+			mu = math.Log(80)
+		}
+		for i := 0; i < 2000; i++ {
+			v := math.Exp(mu + 0.6*rnd.NormFloat64())
+			if rnd.Float64() < 0.01 {
+				v *= 20
+			}
+			k := int(math.Log(v) / math.Log(1.25))
+			if k >= 0 && k < len(h.Buckets) {
+				h.Buckets[k].Count++
+			}
+		}
+		hs = append(hs, h)
+	}
+
+	cmap := palette.Linear(palette.Heat(8, 1))
+	lh, err := NewLatencyHeatMap(hs, cmap)
+	if err != nil {
+		log.Panic(err)
+	}
+	lh.Percentiles = []float64{0.5, 0.99, 0.999}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Request latency"
+	p.X.Label.Text = "Time (min)"
+	p.Y.Label.Text = "Latency (ms)"
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+	p.Add(lh)
+	names, thumbs := lh.PercentileThumbnailers()
+	for i, name := range names {
+		p.Legend.Add(name, thumbs[i])
+	}
+	p.Legend.Top = true
+	p.Y.Max = 1e5 // Leave room for the legend.
+
+	err = p.Save(400, 250, "testdata/latency.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLatencyHeatMap(t *testing.T) {
+	checkPlot(ExampleLatencyHeatMap, t, "latency.png")
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	hs := []LatencyHistogram{
+		{Start: 0, End: 2, Buckets: []LatencyBucket{
+			{Low: 2, High: 4, Count: 2},
+			{Low: 1, High: 2, Count: 2},
+		}},
+		{Start: 2, End: 4},
+		{Start: 4, End: 6, Buckets: []LatencyBucket{
+			{Low: 10, High: 20, Count: 4},
+			{Low: 20, High: 40, Count: 0},
+		}},
+	}
+	h, err := NewLatencyHeatMap(hs, palette.Linear(palette.Heat(2, 1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hs[0].Buckets) != 2 || hs[0].Buckets[0].Low != 2 {
+		t.Errorf("input histogram modified: %v", hs[0].Buckets)
+	}
+
+	for _, test := range []struct {
+		q    float64
+		want XYs
+	}{
+		{q: 0.5, want: XYs{{1, 2}, {5, 15}}},
+		{q: 0.75, want: XYs{{1, 3}, {5, 17.5}}},
+		{q: 1, want: XYs{{1, 4}, {5, 20}}},
+	} {
+		if got := h.Percentile(test.q); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected percentile %v: got:%v want:%v", test.q, got, test.want)
+		}
+	}
+
+	if xmin, xmax, ymin, ymax := h.DataRange(); xmin != 0 || xmax != 6 || ymin != 1 || ymax != 20 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 6 1 20", xmin, xmax, ymin, ymax)
+	}
+
+	h.Percentiles = []float64{0.5, 0.999}
+	if names, _ := h.PercentileThumbnailers(); !reflect.DeepEqual(names, []string{"p50", "p99.9"}) {
+		t.Errorf("unexpected percentile names: got:%q", names)
+	}
+}
+
+func TestNewLatencyHeatMapErrors(t *testing.T) {
+	cmap := palette.Linear(palette.Heat(2, 1))
+	for _, test := range []struct {
+		name string
+		hs   []LatencyHistogram
+	}{
+		{name: "empty", hs: []LatencyHistogram{{Start: 0, End: 1}}},
+		{name: "reversed interval", hs: []LatencyHistogram{{Start: 1, End: 0, Buckets: []LatencyBucket{{Low: 1, High: 2, Count: 1}}}}},
+		{name: "zero latency", hs: []LatencyHistogram{{Start: 0, End: 1, Buckets: []LatencyBucket{{Low: 0, High: 2, Count: 1}}}}},
+		{name: "negative count", hs: []LatencyHistogram{{Start: 0, End: 1, Buckets: []LatencyBucket{{Low: 1, High: 2, Count: -1}}}}},
+	} {
+		if _, err := NewLatencyHeatMap(test.hs, cmap); err == nil {
+			t.Errorf("expected error for %s histograms", test.name)
+		}
+	}
+}
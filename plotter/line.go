@@ -6,8 +6,10 @@ package plotter
 
 import (
 	"image/color"
+	"math"
 
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 )
@@ -23,6 +25,29 @@ type Line struct {
 
 	// ShadeColor is the color of the shaded area.
 	ShadeColor *color.Color
+
+	// Values, if not nil, holds a value at each
+	// point, such as the speed along a track, by
+	// which the line is colored using ColorMap,
+	// the color varying smoothly along the line.
+	// The parts of the line whose values are
+	// outside of the range of the ColorMap are
+	// drawn in the color of the LineStyle.
+	Values []float64
+
+	// ColorMap is the ColorMap of the Values.
+	ColorMap palette.ColorMap
+
+	// Smoothing is the method of drawing the line
+	// through the points. The points are joined
+	// by straight lines if it is NoSmoothing.
+	Smoothing Smoothing
+
+	// Points, if not nil, is the style of the
+	// glyphs drawn at the points of the line,
+	// such as to show the data through which a
+	// smoothed line is drawn.
+	Points *draw.GlyphStyle
 }
 
 // NewLine returns a Line that uses the default line style and
@@ -42,9 +67,10 @@ func NewLine(xys XYer) (*Line, error) {
 // interface.
 func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	ps := make([]vg.Point, len(pts.XYs))
+	xys, steps := smooth(pts.XYs, pts.Smoothing)
+	ps := make([]vg.Point, len(xys))
 
-	for i, p := range pts.XYs {
+	for i, p := range xys {
 		ps[i].X = trX(p.X)
 		ps[i].Y = trY(p.Y)
 	}
@@ -54,22 +80,114 @@ func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 		minY := trY(plt.Y.Min)
 		var pa vg.Path
 		pa.Move(vg.Point{X: ps[0].X, Y: minY})
-		for i := range pts.XYs {
+		for i := range ps {
 			pa.Line(ps[i])
 		}
-		pa.Line(vg.Point{X: ps[len(pts.XYs)-1].X, Y: minY})
+		pa.Line(vg.Point{X: ps[len(ps)-1].X, Y: minY})
 		pa.Close()
 		c.Fill(pa)
 	}
 
-	c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
+	if pts.Values != nil && pts.ColorMap != nil {
+		pts.strokeGradient(c, ps, smoothValues(pts.Values, steps))
+	} else {
+		c.StrokeLines(pts.LineStyle, c.ClipLinesXY(ps)...)
+	}
+
+	if pts.Points != nil {
+		for _, p := range pts.XYs {
+			pt := vg.Point{X: trX(p.X), Y: trY(p.Y)}
+			if c.Contains(pt) {
+				c.DrawGlyph(*pts.Points, pt)
+			}
+		}
+	}
+}
+
+// smoothValues returns the values at the points of a
+// line smoothed with the given number of steps between
+// each pair of points, interpolated linearly.
+func smoothValues(vs []float64, steps int) []float64 {
+	if steps == 1 || len(vs) == 0 {
+		return vs
+	}
+	out := make([]float64, 0, (len(vs)-1)*steps+1)
+	for i := 0; i < len(vs)-1; i++ {
+		for j := 0; j < steps; j++ {
+			out = append(out, vs[i]+(vs[i+1]-vs[i])*float64(j)/float64(steps))
+		}
+	}
+	return append(out, vs[len(vs)-1])
+}
+
+// gradientSteps is the number of steps of color across
+// the range of the ColorMap of a gradient line, setting
+// the length of the pieces into which its segments are
+// divided.
+const gradientSteps = 64
+
+// strokeGradient strokes the line through ps with the
+// values vs, divided into pieces each colored by the
+// value at its middle.
+func (pts *Line) strokeGradient(c draw.Canvas, ps []vg.Point, vs []float64) {
+	step := (pts.ColorMap.Max() - pts.ColorMap.Min()) / gradientSteps
+	sty := pts.LineStyle
+	for i := 1; i < len(ps); i++ {
+		v0, v1 := vs[i-1], vs[i]
+		n := 1
+		if step > 0 {
+			n = int(math.Ceil(math.Abs(v1-v0) / step))
+			if n < 1 {
+				n = 1
+			}
+		}
+		a, b := ps[i-1], ps[i]
+		for j := 0; j < n; j++ {
+			f0, f1 := float64(j)/float64(n), float64(j+1)/float64(n)
+			sty.Color = pts.color(v0 + (v1-v0)*(f0+f1)/2)
+			piece := []vg.Point{lerpPoint(a, b, f0), lerpPoint(a, b, f1)}
+			c.StrokeLines(sty, c.ClipLinesXY(piece)...)
+		}
+	}
+}
+
+// lerpPoint returns the point a fraction f
+// of the way from a to b.
+func lerpPoint(a, b vg.Point, f float64) vg.Point {
+	return vg.Point{X: a.X + vg.Length(f)*(b.X-a.X), Y: a.Y + vg.Length(f)*(b.Y-a.Y)}
+}
+
+// color returns the color of the line at value v.
+func (pts *Line) color(v float64) color.Color {
+	clr, err := pts.ColorMap.At(v)
+	if err != nil {
+		return pts.LineStyle.Color
+	}
+	return clr
 }
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
 // interface.
 func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	xys, _ := smooth(pts.XYs, pts.Smoothing)
+	return XYRange(xys)
+}
+
+// GlyphBoxes returns the glyph boxes of the Points
+// of the line, if any, implementing the
+// plot.GlyphBoxer interface.
+func (pts *Line) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if pts.Points == nil {
+		return nil
+	}
+	bs := make([]plot.GlyphBox, len(pts.XYs))
+	for i, p := range pts.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = pts.Points.Rectangle()
+	}
+	return bs
 }
 
 // Thumbnail the thumbnail for the Line,
@@ -86,10 +204,26 @@ func (pts *Line) Thumbnail(c *draw.Canvas) {
 		c.FillPolygon(*pts.ShadeColor, poly)
 
 		points = append(points, vg.Point{X: c.Min.X, Y: c.Min.Y})
+	} else if pts.Values != nil && pts.ColorMap != nil {
+		// The thumbnail shows the colors of the
+		// whole range of the ColorMap.
+		y := c.Center().Y
+		min, max := pts.ColorMap.Min(), pts.ColorMap.Max()
+		sty := pts.LineStyle
+		for i := 0; i < gradientSteps; i++ {
+			f0, f1 := float64(i)/gradientSteps, float64(i+1)/gradientSteps
+			sty.Color = pts.color(min + (max-min)*(f0+f1)/2)
+			x0 := c.Min.X + vg.Length(f0)*(c.Max.X-c.Min.X)
+			x1 := c.Min.X + vg.Length(f1)*(c.Max.X-c.Min.X)
+			c.StrokeLine2(sty, x0, y, x1, y)
+		}
 	} else {
 		y := c.Center().Y
 		c.StrokeLine2(pts.LineStyle, c.Min.X, y, c.Max.X, y)
 	}
+	if pts.Points != nil {
+		c.DrawGlyph(*pts.Points, c.Center())
+	}
 }
 
 // NewLinePoints returns both a Line and a
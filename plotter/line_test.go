@@ -0,0 +1,190 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// ExampleLine_gradient draws a track colored by
+// the speed along it.
+func ExampleLine_gradient() {
+	const n = 200
+	track := make(XYs, n)
+	speed := make([]float64, n)
+	for i := range track {
+		t := 4 * math.Pi * float64(i) / (n - 1)
+		r := 1 + 0.1*t
+		track[i].X = r * math.Cos(t)
+		track[i].Y = r * math.Sin(t)
+		speed[i] = 10 + 5*math.Sin(3*t)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Speed along a track"
+
+	l, err := NewLine(track)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Width = vg.Points(3)
+	l.Values = speed
+	l.ColorMap = palette.Linear(palette.Heat(8, 1))
+	l.ColorMap.SetMin(5)
+	l.ColorMap.SetMax(15)
+	p.Add(l)
+	p.Legend.Add("speed", l)
+
+	err = p.Save(250, 250, "testdata/gradientLine.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineGradient(t *testing.T) {
+	checkPlot(ExampleLine_gradient, t, "gradientLine.png")
+}
+
+func TestLineGradientPieces(t *testing.T) {
+	for _, test := range []struct {
+		values []float64
+		want   int
+	}{
+		{values: []float64{0, 1, 1}, want: gradientSteps + 1},
+		{values: []float64{0.5, 0.5, 0.25}, want: 1 + gradientSteps/4},
+		{values: []float64{2, 3, 4}, want: 2 * gradientSteps},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l, err := NewLine(XYs{{0, 0}, {1, 1}, {2, 2}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.Values = test.values
+		l.ColorMap = palette.Linear(palette.Heat(2, 1))
+		p.Add(l)
+
+		var r recorder.Canvas
+		l.Plot(draw.NewCanvas(&r, 100, 100), p)
+		var strokes int
+		for _, a := range r.Actions {
+			if _, ok := a.(*recorder.Stroke); ok {
+				strokes++
+			}
+		}
+		if strokes != test.want {
+			t.Errorf("unexpected number of pieces for values %v: got:%d want:%d", test.values, strokes, test.want)
+		}
+	}
+}
+
+// ExampleLine_smooth draws smooth curves through sparse
+// points, by each of the methods of smoothing.
+func ExampleLine_smooth() {
+	data := XYs{{0, 0}, {1, 0.2}, {2, 0.1}, {3, 2}, {4, 2.1}, {5, 2}, {6, 0.5}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Smoothed lines"
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	for i, s := range []struct {
+		name      string
+		smoothing Smoothing
+	}{
+		{name: "none", smoothing: NoSmoothing},
+		{name: "cubic spline", smoothing: CubicSpline},
+		{name: "monotone cubic", smoothing: MonotoneCubic},
+		{name: "Bézier", smoothing: Bezier},
+	} {
+		xys := make(XYs, len(data))
+		for j, d := range data {
+			xys[j].X = d.X
+			xys[j].Y = d.Y + 3*float64(3-i)
+		}
+		l, err := NewLine(xys)
+		if err != nil {
+			log.Panic(err)
+		}
+		l.Smoothing = s.smoothing
+		l.Width = vg.Points(1)
+		l.Color = color.RGBA{R: uint8(60 * i), B: uint8(255 - 60*i), A: 255}
+		l.Points = &draw.GlyphStyle{Color: color.Black, Radius: vg.Points(2), Shape: draw.CircleGlyph{}}
+		p.Add(l)
+		p.Legend.Add(s.name, l)
+	}
+	p.Y.Max = 16
+
+	err = p.Save(250, 250, "testdata/smoothLine.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineSmooth(t *testing.T) {
+	checkPlot(ExampleLine_smooth, t, "smoothLine.png")
+}
+
+func TestSmooth(t *testing.T) {
+	data := XYs{{0, 0}, {1, 1}, {3, 1.5}, {4, 4}, {6, 4}}
+	for _, s := range []Smoothing{CubicSpline, MonotoneCubic, Bezier} {
+		xys, steps := smooth(data, s)
+		if steps != smoothSteps || len(xys) != (len(data)-1)*steps+1 {
+			t.Fatalf("unexpected number of points for smoothing %d: got:%d with %d steps", s, len(xys), steps)
+		}
+		// The curve passes through the points.
+		for i, d := range data {
+			p := xys[i*steps]
+			if math.Abs(p.X-d.X) > 1e-12 || math.Abs(p.Y-d.Y) > 1e-12 {
+				t.Errorf("curve of smoothing %d misses point %d: got:%v want:%v", s, i, p, d)
+			}
+		}
+		if s == MonotoneCubic {
+			for i := 1; i < len(xys); i++ {
+				if xys[i].Y < xys[i-1].Y-1e-12 || xys[i].Y > 4 {
+					t.Errorf("monotone curve not monotone at %d: %v after %v", i, xys[i], xys[i-1])
+				}
+			}
+		}
+	}
+
+	// The natural cubic spline through
+	// points on a line is the line.
+	line := XYs{{0, 1}, {1, 3}, {4, 9}, {5, 11}}
+	xys, _ := smooth(line, CubicSpline)
+	for _, p := range xys {
+		if math.Abs(p.Y-(2*p.X+1)) > 1e-12 {
+			t.Errorf("spline through a line not straight: %v", p)
+		}
+	}
+
+	// Points whose X values do not increase are
+	// joined by straight lines, except by Bézier
+	// curves.
+	loop := XYs{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	if xys, steps := smooth(loop, CubicSpline); steps != 1 || len(xys) != len(loop) {
+		t.Errorf("unexpected smoothing of loop: got:%d points with %d steps", len(xys), steps)
+	}
+	if _, steps := smooth(loop, Bezier); steps != smoothSteps {
+		t.Errorf("unexpected Bézier steps of loop: got:%d want:%d", steps, smoothSteps)
+	}
+}
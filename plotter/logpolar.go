@@ -0,0 +1,249 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// logPolarSegments is the number of line
+// segments drawn for each circle of a grid.
+const logPolarSegments = 180
+
+// LogPolarGrid implements the plot.Plotter interface,
+// drawing log-polar axes, the circles of constant radius
+// and the spokes of constant angle of a polar plot whose
+// radii are on a logarithmic scale, as for Nyquist plots
+// of frequency responses spanning several decades.
+//
+// Polar data are drawn over the grid by the normal plotters
+// after mapping them onto the plane with the XY and XYs
+// methods. The plot's X and Y axes are normally hidden, and
+// the plot drawn with equal X and Y scales so that the
+// circles are round.
+type LogPolarGrid struct {
+	// RMin and RMax are the radii at the center
+	// and at the outer circle of the axes. Radii
+	// less than RMin are drawn at the center.
+	RMin, RMax float64
+
+	// Ticker returns the radii of the circles,
+	// which are labeled by the labels of the major
+	// ticks. It defaults to plot.LogTicks.
+	Ticker plot.Ticker
+
+	// Circles and MinorCircles are the styles of
+	// the circles at the major and minor ticks,
+	// and Spokes is the style of the spokes. Lines
+	// whose style has a nil Color are not drawn.
+	Circles, MinorCircles, Spokes draw.LineStyle
+
+	// Angles holds the angles of the spokes in
+	// degrees anticlockwise from the positive X
+	// direction. They are labeled outside of the
+	// outer circle.
+	Angles []float64
+
+	// LabelAngle is the angle in degrees of the
+	// line along which the circles are labeled.
+	LabelAngle float64
+
+	// TextStyle is the style of the labels.
+	draw.TextStyle
+}
+
+// NewLogPolarGrid returns log-polar axes with radii from
+// rmin to rmax, which must be positive, with spokes every
+// 30°, using the default grid line styles.
+func NewLogPolarGrid(rmin, rmax float64) (*LogPolarGrid, error) {
+	if !(0 < rmin && rmin < rmax) || math.IsInf(rmax, 1) {
+		return nil, errors.New("Invalid log-polar radius range")
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	angles := make([]float64, 12)
+	for i := range angles {
+		angles[i] = float64(30 * i)
+	}
+	return &LogPolarGrid{
+		RMin:         rmin,
+		RMax:         rmax,
+		Ticker:       plot.LogTicks{},
+		Circles:      DefaultGridLineStyle,
+		MinorCircles: DefaultMinorGridLineStyle,
+		Spokes:       DefaultGridLineStyle,
+		Angles:       angles,
+		LabelAngle:   15,
+		TextStyle:    draw.TextStyle{Color: DefaultGridLineStyle.Color, Font: fnt},
+	}, nil
+}
+
+// rho returns the distance from the center
+// of the axes at which radius r is drawn.
+func (g *LogPolarGrid) rho(r float64) float64 {
+	if !(r > g.RMin) {
+		return 0
+	}
+	return math.Log10(r / g.RMin)
+}
+
+// XY returns the point of the plane at which the polar
+// coordinates, radius r and angle theta in degrees
+// anticlockwise from the positive X direction, are drawn.
+func (g *LogPolarGrid) XY(r, theta float64) (x, y float64) {
+	rho := g.rho(r)
+	sin, cos := math.Sincos(theta * math.Pi / 180)
+	return rho * cos, rho * sin
+}
+
+// XYs returns the points of the plane at which the polar
+// coordinates with radii r and angles theta in degrees are
+// drawn, for drawing by the normal plotters.
+func (g *LogPolarGrid) XYs(r, theta []float64) (XYs, error) {
+	if len(r) != len(theta) {
+		return nil, errors.New("Radius and angle lengths do not match")
+	}
+	xys := make(XYs, len(r))
+	for i := range r {
+		if err := CheckFloats(r[i], theta[i]); err != nil {
+			return nil, err
+		}
+		xys[i].X, xys[i].Y = g.XY(r[i], theta[i])
+	}
+	return xys, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (g *LogPolarGrid) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	point := func(r, theta float64) vg.Point {
+		x, y := g.XY(r, theta)
+		return vg.Point{X: trX(x), Y: trY(y)}
+	}
+
+	for _, theta := range g.Angles {
+		if g.Spokes.Color == nil {
+			break
+		}
+		line := []vg.Point{point(g.RMin, theta), point(g.RMax, theta)}
+		c.StrokeLines(g.Spokes, c.ClipLinesXY(line)...)
+	}
+
+	ticks := g.ticks()
+	for _, t := range ticks {
+		sty := g.Circles
+		if t.IsMinor() {
+			sty = g.MinorCircles
+		}
+		if sty.Color == nil {
+			continue
+		}
+		circle := make([]vg.Point, logPolarSegments+1)
+		for i := range circle {
+			circle[i] = point(t.Value, 360*float64(i)/logPolarSegments)
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(circle)...)
+	}
+
+	if g.TextStyle.Color == nil {
+		return
+	}
+	// The circles are labeled inside, so that
+	// the outer label does not meet the labels
+	// of the spokes.
+	sin, cos := math.Sincos(g.LabelAngle * math.Pi / 180)
+	sty := g.TextStyle
+	sty.XAlign = draw.XAlignment(-0.5 - 0.5*cos)
+	sty.YAlign = draw.YAlignment(-0.5 - 0.5*sin)
+	for _, t := range ticks {
+		if t.IsMinor() || t.Value == g.RMin {
+			continue
+		}
+		c.FillText(sty, point(t.Value, g.LabelAngle), t.Label)
+	}
+	for _, theta := range g.Angles {
+		pt, sty := g.angleLabel(point(g.RMax, theta), theta)
+		c.FillText(sty, pt, angleText(theta))
+	}
+}
+
+// ticks returns the ticks of the circles within the
+// radius range of the axes, with the outer circle
+// drawn as a major tick.
+func (g *LogPolarGrid) ticks() []plot.Tick {
+	ticker := g.Ticker
+	if ticker == nil {
+		ticker = plot.LogTicks{}
+	}
+	var ticks []plot.Tick
+	outer := false
+	for _, t := range ticker.Ticks(g.RMin, g.RMax) {
+		if t.Value < g.RMin || t.Value > g.RMax {
+			continue
+		}
+		if t.Value == g.RMax {
+			outer = true
+			if t.IsMinor() {
+				t.Label = strconv.FormatFloat(t.Value, 'g', -1, 64)
+			}
+		}
+		ticks = append(ticks, t)
+	}
+	if !outer {
+		ticks = append(ticks, plot.Tick{Value: g.RMax, Label: strconv.FormatFloat(g.RMax, 'g', -1, 64)})
+	}
+	return ticks
+}
+
+// angleLabel returns the position and the style of the
+// label of the spoke at angle theta ending at pt, aligned
+// so that the label lies outside of the outer circle.
+func (g *LogPolarGrid) angleLabel(pt vg.Point, theta float64) (vg.Point, draw.TextStyle) {
+	sin, cos := math.Sincos(theta * math.Pi / 180)
+	sty := g.TextStyle
+	sty.XAlign = draw.XAlignment(-0.5 + 0.5*cos)
+	sty.YAlign = draw.YAlignment(-0.5 + 0.5*sin)
+	pad := sty.Font.Size / 4
+	return vg.Point{X: pt.X + vg.Length(cos)*pad, Y: pt.Y + vg.Length(sin)*pad}, sty
+}
+
+// angleText returns the label of an angle in degrees.
+func angleText(theta float64) string {
+	return strconv.FormatFloat(theta, 'g', -1, 64) + "°"
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (g *LogPolarGrid) DataRange() (xmin, xmax, ymin, ymax float64) {
+	rho := g.rho(g.RMax)
+	return -rho, rho, -rho, rho
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// reserving space for the labels of the spokes.
+func (g *LogPolarGrid) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if g.TextStyle.Color == nil {
+		return nil
+	}
+	bs := make([]plot.GlyphBox, len(g.Angles))
+	for i, theta := range g.Angles {
+		x, y := g.XY(g.RMax, theta)
+		pt, sty := g.angleLabel(vg.Point{}, theta)
+		r := sty.Rectangle(angleText(theta))
+		bs[i] = plot.GlyphBox{
+			X:         plt.X.Norm(x),
+			Y:         plt.Y.Norm(y),
+			Rectangle: vg.Rectangle{Min: r.Min.Add(pt), Max: r.Max.Add(pt)},
+		}
+	}
+	return bs
+}
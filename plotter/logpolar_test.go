@@ -0,0 +1,102 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleLogPolarGrid draws a Nyquist plot of the open-loop
+// frequency response 100/((s+1)(s+2)(s+5)) on log-polar axes,
+// showing its magnitude over four decades.
+func ExampleLogPolarGrid() {
+	const n = 200
+	r := make([]float64, n)
+	theta := make([]float64, n)
+	for i := range r {
+		w := math.Pow(10, -2+4*float64(i)/(n-1))
+		s := complex(0, w)
+		g := 100 / ((s + 1) * (s + 2) * (s + 5))
+		r[i], theta[i] = cmplx.Abs(g), cmplx.Phase(g)*180/math.Pi
+	}
+
+	grid, err := NewLogPolarGrid(0.01, 100)
+	if err != nil {
+		log.Panic(err)
+	}
+	xys, err := grid.XYs(r, theta)
+	if err != nil {
+		log.Panic(err)
+	}
+	l, err := NewLine(xys)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Color = color.RGBA{R: 196, B: 128, A: 255}
+	l.Width = vg.Points(1)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Log-polar Nyquist plot"
+	p.HideAxes()
+	p.Add(grid, l)
+
+	err = p.Save(300, 300, "testdata/logPolarGrid.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLogPolarGrid(t *testing.T) {
+	checkPlot(ExampleLogPolarGrid, t, "logPolarGrid.png")
+}
+
+func TestLogPolarXY(t *testing.T) {
+	const tol = 1e-12
+	g, err := NewLogPolarGrid(0.1, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		r, theta float64
+		x, y     float64
+	}{
+		{r: 0.1, theta: 45, x: 0, y: 0},
+		{r: 0.01, theta: 0, x: 0, y: 0},
+		{r: 1, theta: 0, x: 1, y: 0},
+		{r: 10, theta: 90, x: 0, y: 2},
+		{r: 100, theta: 180, x: -3, y: 0},
+		{r: 1000, theta: -90, x: 0, y: -4},
+	} {
+		x, y := g.XY(test.r, test.theta)
+		if math.Abs(x-test.x) > tol || math.Abs(y-test.y) > tol {
+			t.Errorf("unexpected point for r=%v θ=%v: got (%v, %v) want (%v, %v)",
+				test.r, test.theta, x, y, test.x, test.y)
+		}
+	}
+
+	xmin, xmax, ymin, ymax := g.DataRange()
+	if xmin != -4 || xmax != 4 || ymin != -4 || ymax != 4 {
+		t.Errorf("unexpected data range: got %v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+
+	if _, err := g.XYs([]float64{1, 2}, []float64{0}); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+	for _, rmin := range []float64{0, -1, 1000} {
+		if _, err := NewLogPolarGrid(rmin, 100); err == nil {
+			t.Errorf("expected error for radius range %v to 100", rmin)
+		}
+	}
+}
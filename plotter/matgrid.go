@@ -0,0 +1,110 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// MatGrid implements the GridXYZ interface, exposing
+// the elements of a matrix as the values of a grid, so
+// that matrices can be drawn by heat maps and contour
+// plots. The rows of the matrix are the rows of the
+// grid, from the bottom, unless the view is transposed
+// or flipped.
+type MatGrid struct {
+	// Matrix holds the values of the grid.
+	Matrix mat64.Matrix
+
+	// XCoords and YCoords hold the coordinates of the
+	// columns and the rows of the grid. If either is
+	// nil, the coordinates are the indices.
+	XCoords, YCoords []float64
+
+	// Transpose specifies whether the columns
+	// of the matrix are the rows of the grid.
+	Transpose bool
+
+	// Flip specifies whether the first row of the
+	// matrix, or column if it is transposed, is the
+	// top row of the grid, as the matrix is printed,
+	// rather than the bottom row. The coordinates of
+	// the rows of the matrix are flipped with them,
+	// so they should decrease along the matrix for
+	// the rows of the grid to be increasing.
+	Flip bool
+}
+
+// NewMatGrid returns a grid of the elements of m, with the
+// coordinates xs and ys of its columns and rows, which may be
+// nil. An error is returned if the number of coordinates does
+// not match the dimensions of m.
+func NewMatGrid(m mat64.Matrix, xs, ys []float64) (*MatGrid, error) {
+	r, c := m.Dims()
+	if xs != nil && len(xs) != c {
+		return nil, errors.New("Number of X coordinates does not match the number of columns")
+	}
+	if ys != nil && len(ys) != r {
+		return nil, errors.New("Number of Y coordinates does not match the number of rows")
+	}
+	return &MatGrid{Matrix: m, XCoords: xs, YCoords: ys}, nil
+}
+
+// Dims implements the Dims method of the GridXYZ interface.
+func (g *MatGrid) Dims() (c, r int) {
+	r, c = g.Matrix.Dims()
+	if g.Transpose {
+		return r, c
+	}
+	return c, r
+}
+
+// Z implements the Z method of the GridXYZ interface.
+func (g *MatGrid) Z(c, r int) float64 {
+	i, j := g.element(c, r)
+	return g.Matrix.At(i, j)
+}
+
+// X implements the X method of the GridXYZ interface.
+func (g *MatGrid) X(c int) float64 {
+	i, j := g.element(c, 0)
+	if g.Transpose {
+		return coord(g.YCoords, i, c)
+	}
+	return coord(g.XCoords, j, c)
+}
+
+// Y implements the Y method of the GridXYZ interface.
+func (g *MatGrid) Y(r int) float64 {
+	i, j := g.element(0, r)
+	if g.Transpose {
+		return coord(g.XCoords, j, r)
+	}
+	return coord(g.YCoords, i, r)
+}
+
+// element returns the row i and the column j of the
+// matrix holding the value of the grid cell (c, r).
+func (g *MatGrid) element(c, r int) (i, j int) {
+	if g.Flip {
+		_, rows := g.Dims()
+		r = rows - 1 - r
+	}
+	if g.Transpose {
+		return c, r
+	}
+	return r, c
+}
+
+// coord returns the coordinate i of xs, or
+// the grid index k if xs is nil.
+func coord(xs []float64, i, k int) float64 {
+	if xs == nil {
+		return float64(k)
+	}
+	return xs[i]
+}
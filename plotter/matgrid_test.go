@@ -0,0 +1,140 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleMatGrid draws a heat map of a matrix of values
+// sampled at decreasing latitudes from the top row, as
+// the rows of an image are.
+func ExampleMatGrid() {
+	lons := []float64{-40, -30, -20, -10, 0, 10, 20, 30, 40}
+	lats := []float64{40, 30, 20, 10, 0, -10, -20}
+	m := mat64.NewDense(len(lats), len(lons), nil)
+	for i, lat := range lats {
+		for j, lon := range lons {
+			m.Set(i, j, math.Cos(lon/25)*math.Sin((lat+10)/20))
+		}
+	}
+	g, err := NewMatGrid(m, lons, lats)
+	if err != nil {
+		log.Panic(err)
+	}
+	g.Flip = true
+
+	h := NewHeatMap(g, palette.Heat(16, 1))
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Matrix grid"
+	p.X.Label.Text = "Longitude"
+	p.Y.Label.Text = "Latitude"
+	p.Add(h)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(250, 200, "testdata/matGrid.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestMatGrid(t *testing.T) {
+	checkPlot(ExampleMatGrid, t, "matGrid.png")
+}
+
+func TestMatGridViews(t *testing.T) {
+	m := mat64.NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})
+	xs := []float64{10, 20, 30}
+	ys := []float64{200, 100}
+	for _, test := range []struct {
+		transpose, flip bool
+		cols, rows      int
+		z               [][]float64
+		x, y            []float64
+	}{
+		{
+			cols: 3, rows: 2,
+			z: [][]float64{{1, 2, 3}, {4, 5, 6}},
+			x: xs, y: ys,
+		},
+		{
+			flip: true,
+			cols: 3, rows: 2,
+			z: [][]float64{{4, 5, 6}, {1, 2, 3}},
+			x: xs, y: []float64{100, 200},
+		},
+		{
+			transpose: true,
+			cols:      2, rows: 3,
+			z: [][]float64{{1, 4}, {2, 5}, {3, 6}},
+			x: ys, y: xs,
+		},
+		{
+			transpose: true, flip: true,
+			cols: 2, rows: 3,
+			z: [][]float64{{3, 6}, {2, 5}, {1, 4}},
+			x: ys, y: []float64{30, 20, 10},
+		},
+	} {
+		g, err := NewMatGrid(m, xs, ys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		g.Transpose, g.Flip = test.transpose, test.flip
+		c, r := g.Dims()
+		if c != test.cols || r != test.rows {
+			t.Errorf("unexpected dimensions for transpose=%t flip=%t: got:(%d, %d) want:(%d, %d)",
+				test.transpose, test.flip, c, r, test.cols, test.rows)
+			continue
+		}
+		for j := 0; j < r; j++ {
+			for i := 0; i < c; i++ {
+				if z := g.Z(i, j); z != test.z[j][i] {
+					t.Errorf("unexpected value of (%d, %d) for transpose=%t flip=%t: got:%v want:%v",
+						i, j, test.transpose, test.flip, z, test.z[j][i])
+				}
+			}
+			if y := g.Y(j); y != test.y[j] {
+				t.Errorf("unexpected Y of row %d for transpose=%t flip=%t: got:%v want:%v",
+					j, test.transpose, test.flip, y, test.y[j])
+			}
+		}
+		for i := 0; i < c; i++ {
+			if x := g.X(i); x != test.x[i] {
+				t.Errorf("unexpected X of column %d for transpose=%t flip=%t: got:%v want:%v",
+					i, test.transpose, test.flip, x, test.x[i])
+			}
+		}
+	}
+
+	g, err := NewMatGrid(m, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g.Flip = true
+	if x, y := g.X(2), g.Y(0); x != 2 || y != 0 {
+		t.Errorf("unexpected index coordinates: got:(%v, %v) want:(2, 0)", x, y)
+	}
+	if _, err := NewMatGrid(m, ys, nil); err == nil {
+		t.Error("expected error for mismatched X coordinates")
+	}
+	if _, err := NewMatGrid(m, nil, xs); err == nil {
+		t.Error("expected error for mismatched Y coordinates")
+	}
+}
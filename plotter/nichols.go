@@ -0,0 +1,215 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"math/cmplx"
+	"strconv"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// nicholsSteps is the number of line segments
+// in each contour of a Nichols grid.
+const nicholsSteps = 720
+
+var (
+	// DefaultNicholsMagnitudes are the closed-loop
+	// magnitudes, in dB, of the contours of a Nichols
+	// grid returned by NewNicholsGrid.
+	DefaultNicholsMagnitudes = []float64{-40, -20, -12, -6, -3, -1, 0, 0.25, 0.5, 1, 3, 6}
+
+	// DefaultNicholsPhases are the closed-loop phases,
+	// in degrees, of the contours of a Nichols grid
+	// returned by NewNicholsGrid.
+	DefaultNicholsPhases = []float64{
+		-1, -5, -10, -20, -30, -45, -60, -90, -120, -150,
+		-180, -210, -240, -270, -300, -315, -330, -340, -350, -355, -359,
+	}
+)
+
+// NicholsGrid implements the plot.Plotter interface,
+// drawing the grid of a Nichols chart, which plots the
+// gain in dB of an open-loop frequency response against
+// its phase in degrees. The grid is made of the contours
+// of constant magnitude and phase of the closed-loop
+// response, G/(1+G), of a unity feedback loop around an
+// open-loop response G.
+//
+// The open-loop response is drawn over the grid by the
+// normal plotters, such as a Line of its phase and gain.
+// The grid repeats every 360° of open-loop phase across
+// the X axis of the plot.
+type NicholsGrid struct {
+	// Magnitudes and Phases hold the closed-loop
+	// magnitudes in dB and the closed-loop phases in
+	// degrees of the contours.
+	Magnitudes, Phases []float64
+
+	// MagnitudeStyle and PhaseStyle are the styles
+	// of the contours of constant closed-loop
+	// magnitude and phase. Contours whose style has
+	// a nil Color are not drawn.
+	MagnitudeStyle, PhaseStyle draw.LineStyle
+
+	// TextStyle is the style of the labels of the
+	// magnitude contours. If its Color is nil, no
+	// labels are drawn.
+	draw.TextStyle
+}
+
+// NewNicholsGrid returns a Nichols grid of the default
+// magnitudes and phases, using the default grid line style
+// for the magnitude contours and the default minor grid line
+// style for the phase contours.
+func NewNicholsGrid() (*NicholsGrid, error) {
+	fnt, err := vg.MakeFont(DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+	return &NicholsGrid{
+		Magnitudes:     append([]float64(nil), DefaultNicholsMagnitudes...),
+		Phases:         append([]float64(nil), DefaultNicholsPhases...),
+		MagnitudeStyle: DefaultGridLineStyle,
+		PhaseStyle:     DefaultMinorGridLineStyle,
+		TextStyle: draw.TextStyle{
+			Color:  DefaultGridLineStyle.Color,
+			Font:   fnt,
+			XAlign: draw.XLeft,
+			YAlign: draw.YBottom,
+		},
+	}, nil
+}
+
+// openLoop returns the phase in degrees and the gain in dB
+// of the open-loop response whose closed-loop response has
+// magnitude m and phase psi in radians, and false if the
+// open-loop gain is infinite.
+func openLoop(m, psi float64) (phase, gain float64, ok bool) {
+	t := cmplx.Rect(m, psi)
+	if t == 1 {
+		return 0, 0, false
+	}
+	g := t / (1 - t)
+	if g == 0 || cmplx.IsInf(g) {
+		return 0, 0, false
+	}
+	return cmplx.Phase(g) * 180 / math.Pi, 20 * math.Log10(cmplx.Abs(g)), true
+}
+
+// magnitudeContour returns the contour of closed-loop
+// magnitude m, sweeping the closed-loop phase.
+func magnitudeContour(m float64) XYs {
+	var xys XYs
+	for i := 1; i < nicholsSteps; i++ {
+		phase, gain, ok := openLoop(m, 2*math.Pi*float64(i)/nicholsSteps)
+		if ok {
+			xys = append(xys, struct{ X, Y float64 }{X: phase, Y: gain})
+		}
+	}
+	return unwrapPhase(xys, -180)
+}
+
+// phaseContour returns the contour of closed-loop phase
+// psi in degrees, sweeping the closed-loop magnitude
+// logarithmically from -120dB to 120dB.
+func phaseContour(psi float64) XYs {
+	var xys XYs
+	for i := 0; i <= nicholsSteps; i++ {
+		m := math.Pow(10, -6+12*float64(i)/nicholsSteps)
+		phase, gain, ok := openLoop(m, psi*math.Pi/180)
+		if ok {
+			xys = append(xys, struct{ X, Y float64 }{X: phase, Y: gain})
+		}
+	}
+	return unwrapPhase(xys, psi)
+}
+
+// unwrapPhase removes the jumps of 360° from the phases of
+// a contour, shifting the contour by a multiple of 360° so
+// that it starts within 180° of the phase from.
+func unwrapPhase(xys XYs, from float64) XYs {
+	if len(xys) == 0 {
+		return xys
+	}
+	xys[0].X -= 360 * math.Floor((xys[0].X-from+180)/360)
+	for i := 1; i < len(xys); i++ {
+		xys[i].X -= 360 * math.Floor((xys[i].X-xys[i-1].X+180)/360)
+	}
+	return xys
+}
+
+// Plot implements the plot.Plotter interface.
+func (g *NicholsGrid) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	stroke := func(sty draw.LineStyle, xys XYs) {
+		if len(xys) == 0 {
+			return
+		}
+		min, max := xys[0].X, xys[0].X
+		for _, p := range xys {
+			min, max = math.Min(min, p.X), math.Max(max, p.X)
+		}
+		for k := math.Ceil((plt.X.Min - max) / 360); min+360*k <= plt.X.Max; k++ {
+			line := make([]vg.Point, len(xys))
+			for i, p := range xys {
+				line[i] = vg.Point{X: trX(p.X + 360*k), Y: trY(p.Y)}
+			}
+			c.StrokeLines(sty, c.ClipLinesXY(line)...)
+		}
+	}
+
+	if g.PhaseStyle.Color != nil {
+		for _, psi := range g.Phases {
+			stroke(g.PhaseStyle, phaseContour(psi))
+		}
+	}
+	if g.MagnitudeStyle.Color != nil {
+		for _, m := range g.Magnitudes {
+			stroke(g.MagnitudeStyle, magnitudeContour(math.Pow(10, m/20)))
+		}
+	}
+
+	if g.TextStyle.Color == nil {
+		return
+	}
+	for _, m := range g.Magnitudes {
+		phase, gain, ok := nicholsLabel(m)
+		if !ok || gain < plt.Y.Min || gain > plt.Y.Max {
+			continue
+		}
+		y := trY(gain)
+		for k := math.Ceil((plt.X.Min - phase) / 360); phase+360*k <= plt.X.Max; k++ {
+			x := trX(phase + 360*k)
+			c.FillText(g.TextStyle, vg.Point{X: x, Y: y}, strconv.FormatFloat(m, 'g', -1, 64)+" dB")
+		}
+	}
+}
+
+// nicholsLabel returns the open-loop phase in degrees and
+// gain in dB at which the contour of closed-loop magnitude m
+// in dB is labeled. Magnitudes of 0dB and more are labeled
+// where they cross -180°, above the critical point, and
+// lesser magnitudes where they cross -270°, so that the
+// labels of the closely spaced contours below the critical
+// point do not overlap.
+func nicholsLabel(m float64) (phase, gain float64, ok bool) {
+	mag := math.Pow(10, m/20)
+	switch {
+	case mag > 1:
+		// The open-loop response is -g, with g = mag/(mag-1).
+		return -180, 20 * math.Log10(mag/(mag-1)), true
+	case mag == 1:
+		// The 0dB contour is the line Re(G) = -1/2.
+		return -180, 20 * math.Log10(0.5), true
+	case mag > 0:
+		// The open-loop response is jg, with g = mag/√(1-mag²).
+		return -270, 20 * math.Log10(mag/math.Sqrt(1-mag*mag)), true
+	}
+	return 0, 0, false
+}
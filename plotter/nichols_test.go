@@ -0,0 +1,115 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleNicholsGrid draws the open-loop frequency response
+// of a type 1 system, 2/(s(s+1)(s+2)), on a Nichols chart.
+func ExampleNicholsGrid() {
+	const n = 200
+	resp := make(XYs, n)
+	for i := range resp {
+		w := math.Pow(10, -1.5+3*float64(i)/(n-1))
+		s := complex(0, w)
+		g := 2 / (s * (s + 1) * (s + 2))
+		// The phase is unwrapped to fall from -90°.
+		phase := cmplx.Phase(g) * 180 / math.Pi
+		if phase > 0 {
+			phase -= 360
+		}
+		resp[i].X, resp[i].Y = phase, 20*math.Log10(cmplx.Abs(g))
+	}
+
+	grid, err := NewNicholsGrid()
+	if err != nil {
+		log.Panic(err)
+	}
+	l, err := NewLine(resp)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Color = color.RGBA{R: 196, B: 128, A: 255}
+	l.Width = vg.Points(1)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Nichols chart"
+	p.X.Label.Text = "Open-loop phase (deg)"
+	p.Y.Label.Text = "Open-loop gain (dB)"
+	p.Add(grid, l)
+	p.X.Min, p.X.Max = -360, 0
+	p.Y.Min, p.Y.Max = -40, 40
+
+	err = p.Save(300, 300, "testdata/nicholsGrid.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestNicholsGrid(t *testing.T) {
+	checkPlot(ExampleNicholsGrid, t, "nicholsGrid.png")
+}
+
+func TestNicholsContours(t *testing.T) {
+	const tol = 1e-9
+	closedLoop := func(phase, gain float64) complex128 {
+		g := cmplx.Rect(math.Pow(10, gain/20), phase*math.Pi/180)
+		return g / (1 + g)
+	}
+
+	for _, m := range []float64{-20, -3, 0, 3} {
+		xys := magnitudeContour(math.Pow(10, m/20))
+		if len(xys) == 0 {
+			t.Errorf("empty contour for magnitude %vdB", m)
+		}
+		for i, p := range xys {
+			if got := 20 * math.Log10(cmplx.Abs(closedLoop(p.X, p.Y))); math.Abs(got-m) > tol {
+				t.Errorf("unexpected closed-loop magnitude on %vdB contour: got %v", m, got)
+			}
+			if i > 0 && math.Abs(p.X-xys[i-1].X) > 180 {
+				t.Errorf("phase not unwrapped on %vdB contour at %d: %v to %v", m, i, xys[i-1].X, p.X)
+			}
+		}
+	}
+
+	for _, psi := range []float64{-30, -180, -300} {
+		xys := phaseContour(psi)
+		if len(xys) == 0 {
+			t.Errorf("empty contour for phase %v°", psi)
+		}
+		for _, p := range xys {
+			got := cmplx.Phase(closedLoop(p.X, p.Y)) * 180 / math.Pi
+			if d := math.Mod(got-psi+540, 360) - 180; math.Abs(d) > 1e-6 {
+				t.Errorf("unexpected closed-loop phase on %v° contour: got %v", psi, got)
+			}
+		}
+		if math.Abs(xys[0].X-psi) > 1 {
+			t.Errorf("unexpected start of %v° contour: got phase %v", psi, xys[0].X)
+		}
+	}
+
+	for _, m := range []float64{-20, -6, 0, 3} {
+		phase, gain, ok := nicholsLabel(m)
+		if !ok {
+			t.Fatalf("no label for %vdB", m)
+		}
+		got := 20 * math.Log10(cmplx.Abs(closedLoop(phase, gain)))
+		if math.Abs(got-m) > tol {
+			t.Errorf("unexpected closed-loop magnitude at label of %vdB contour: got %v", m, got)
+		}
+	}
+}
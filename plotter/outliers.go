@@ -0,0 +1,299 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// OutlierRule finds the outliers of a set of points, returning
+// whether each point is an outlier.
+type OutlierRule func(xys XYs) []bool
+
+// IQROutliers returns an OutlierRule finding the points whose Y
+// values are more than k interquartile ranges below the first
+// quartile or above the third, as the outside points of a box
+// plot are for k of 1.5.
+func IQROutliers(k float64) OutlierRule {
+	return func(xys XYs) []bool {
+		out := make([]bool, len(xys))
+		if len(xys) == 0 {
+			return out
+		}
+		ys := make([]float64, len(xys))
+		for i, p := range xys {
+			ys[i] = p.Y
+		}
+		sort.Float64s(ys)
+		q1, q3 := quantileR7(ys, 0.25), quantileR7(ys, 0.75)
+		lo, hi := q1-k*(q3-q1), q3+k*(q3-q1)
+		for i, p := range xys {
+			out[i] = p.Y < lo || p.Y > hi
+		}
+		return out
+	}
+}
+
+// ZScoreOutliers returns an OutlierRule finding the points whose
+// Y values are more than z standard deviations from their mean,
+// as suits the residuals of a fit.
+func ZScoreOutliers(z float64) OutlierRule {
+	return func(xys XYs) []bool {
+		out := make([]bool, len(xys))
+		if len(xys) < 2 {
+			return out
+		}
+		var mean float64
+		for _, p := range xys {
+			mean += p.Y
+		}
+		mean /= float64(len(xys))
+		var ss float64
+		for _, p := range xys {
+			ss += (p.Y - mean) * (p.Y - mean)
+		}
+		sd := math.Sqrt(ss / float64(len(xys)-1))
+		for i, p := range xys {
+			out[i] = math.Abs(p.Y-mean) > z*sd
+		}
+		return out
+	}
+}
+
+// PredicateOutliers returns an OutlierRule finding the points
+// for which the predicate returns true, given their index and
+// their X and Y values.
+func PredicateOutliers(outlier func(i int, x, y float64) bool) OutlierRule {
+	return func(xys XYs) []bool {
+		out := make([]bool, len(xys))
+		for i, p := range xys {
+			out[i] = outlier(i, p.X, p.Y)
+		}
+		return out
+	}
+}
+
+// OutlierLabels implements the Plotter interface, labeling
+// the outliers of a set of points, such as those of a scatter
+// or residual plot, with their identifiers. The labels are
+// placed beside their points where they do not overlap one
+// another or the other points, and joined to their points by
+// leader lines when they are moved away from them.
+type OutlierLabels struct {
+	// XYs holds all of the points, which
+	// are avoided by the labels.
+	XYs
+
+	// Labels holds the label of each point.
+	Labels []string
+
+	// Outliers holds the indices of the
+	// labeled points, in increasing order.
+	Outliers []int
+
+	// TextStyle is the style of the labels.
+	draw.TextStyle
+
+	// Radius is the radius of the glyphs drawn
+	// at the points, which the labels avoid.
+	Radius vg.Length
+
+	// Gap is the distance between a label
+	// and its point.
+	Gap vg.Length
+
+	// LineStyle is the style of the leader lines.
+	// If its Color is nil, no lines are drawn.
+	LineStyle draw.LineStyle
+}
+
+// NewOutlierLabels returns labels of the outliers of the
+// points found by the rule, using the DefaultFont and the
+// DefaultFontSize, leaving room for glyphs of the default
+// radius.
+func NewOutlierLabels(d XYLabeller, rule OutlierRule) (*OutlierLabels, error) {
+	xys, err := CopyXYs(d)
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]string, len(xys))
+	for i := range labels {
+		labels[i] = d.Label(i)
+	}
+	out := rule(xys)
+	if len(out) != len(xys) {
+		return nil, errors.New("Number of outlier flags does not match the number of points")
+	}
+	var outliers []int
+	for i, o := range out {
+		if o {
+			outliers = append(outliers, i)
+		}
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &OutlierLabels{
+		XYs:       xys,
+		Labels:    labels,
+		Outliers:  outliers,
+		TextStyle: draw.TextStyle{Color: DefaultLineStyle.Color, Font: fnt},
+		Radius:    DefaultGlyphStyle.Radius,
+		Gap:       vg.Points(2),
+		LineStyle: draw.LineStyle{Color: DefaultLineStyle.Color, Width: vg.Points(0.5)},
+	}, nil
+}
+
+// labelPositions holds the directions, in order of
+// preference, in which labels are placed from their
+// points, as the alignments of the label text.
+var labelPositions = []struct {
+	x draw.XAlignment
+	y draw.YAlignment
+}{
+	{draw.XLeft, draw.YBottom},
+	{draw.XRight, draw.YBottom},
+	{draw.XLeft, draw.YTop},
+	{draw.XRight, draw.YTop},
+	{draw.XLeft, draw.YCenter},
+	{draw.XRight, draw.YCenter},
+	{draw.XCenter, draw.YBottom},
+	{draw.XCenter, draw.YTop},
+}
+
+// maxLabelDistance is the number of steps, each of the
+// size of the font, by which labels are moved away from
+// their points to avoid overlaps.
+const maxLabelDistance = 4
+
+// place returns the rectangle of the label of each outlier,
+// in the coordinates of the canvas, placed at the first of
+// the positions around its point, at increasing distances,
+// that lies within the canvas and does not overlap the labels
+// already placed or any of the points. Labels that cannot be
+// placed are given their first position.
+func (l *OutlierLabels) place(c draw.Canvas, pts []vg.Point) []vg.Rectangle {
+	rects := make([]vg.Rectangle, len(l.Outliers))
+	free := func(r vg.Rectangle, n int) bool {
+		if r.Min.X < c.Min.X || r.Min.Y < c.Min.Y || r.Max.X > c.Max.X || r.Max.Y > c.Max.Y {
+			return false
+		}
+		for _, p := range rects[:n] {
+			if overlapsRect(r, p) {
+				return false
+			}
+		}
+		for _, p := range pts {
+			if overlapsRect(r, vg.Rectangle{
+				Min: vg.Point{X: p.X - l.Radius, Y: p.Y - l.Radius},
+				Max: vg.Point{X: p.X + l.Radius, Y: p.Y + l.Radius},
+			}) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for n, i := range l.Outliers {
+		placed := false
+		for d := 0; d < maxLabelDistance && !placed; d++ {
+			for _, pos := range labelPositions {
+				r := l.rect(pts[i], l.Labels[i], pos.x, pos.y, vg.Length(d)*l.Font.Size)
+				if free(r, n) {
+					rects[n], placed = r, true
+					break
+				}
+			}
+		}
+		if !placed {
+			pos := labelPositions[0]
+			rects[n] = l.rect(pts[i], l.Labels[i], pos.x, pos.y, 0)
+		}
+	}
+	return rects
+}
+
+// rect returns the rectangle of a label aligned as given
+// beside the point pt, a further distance d away from it.
+func (l *OutlierLabels) rect(pt vg.Point, label string, x draw.XAlignment, y draw.YAlignment, d vg.Length) vg.Rectangle {
+	sty := l.TextStyle
+	sty.XAlign, sty.YAlign = x, y
+	r := sty.Rectangle(label)
+
+	// The label is moved away from the point
+	// in the direction given by its alignment.
+	dx := 2*vg.Length(x) + 1
+	dy := 2*vg.Length(y) + 1
+	off := l.Radius + l.Gap + d
+	pt.X += dx * off
+	pt.Y += dy * off
+	return vg.Rectangle{Min: r.Min.Add(pt), Max: r.Max.Add(pt)}
+}
+
+// overlapsRect returns whether the rectangles overlap.
+func overlapsRect(a, b vg.Rectangle) bool {
+	return a.Min.X < b.Max.X && b.Min.X < a.Max.X && a.Min.Y < b.Max.Y && b.Min.Y < a.Max.Y
+}
+
+// Plot implements the plot.Plotter interface.
+func (l *OutlierLabels) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pts := make([]vg.Point, len(l.XYs))
+	for i, p := range l.XYs {
+		pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	sty := l.TextStyle
+	sty.XAlign, sty.YAlign = draw.XLeft, draw.YBottom
+	for n, r := range l.place(c, pts) {
+		pt := pts[l.Outliers[n]]
+		if !c.Contains(pt) {
+			continue
+		}
+		if l.LineStyle.Color != nil && l.LineStyle.Width > 0 {
+			if end, ok := leaderEnd(pt, r, l.Radius+l.Gap); ok {
+				c.StrokeLine2(l.LineStyle, pt.X, pt.Y, end.X, end.Y)
+			}
+		}
+		c.FillText(sty, r.Min, l.Labels[l.Outliers[n]])
+	}
+}
+
+// leaderEnd returns the point of the rectangle nearest to pt,
+// and whether it is further than dist from pt, needing a
+// leader line to join a label to its point.
+func leaderEnd(pt vg.Point, r vg.Rectangle, dist vg.Length) (vg.Point, bool) {
+	end := vg.Point{
+		X: vg.Length(math.Max(float64(r.Min.X), math.Min(float64(pt.X), float64(r.Max.X)))),
+		Y: vg.Length(math.Max(float64(r.Min.Y), math.Min(float64(pt.Y), float64(r.Max.Y)))),
+	}
+	d := end.Sub(pt)
+	return end, math.Hypot(float64(d.X), float64(d.Y)) > 1.5*float64(dist)
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (l *OutlierLabels) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(l)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// reserving room for the labels in their first positions.
+func (l *OutlierLabels) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(l.Outliers))
+	pos := labelPositions[0]
+	for n, i := range l.Outliers {
+		bs[n].X = plt.X.Norm(l.XYs[i].X)
+		bs[n].Y = plt.Y.Norm(l.XYs[i].Y)
+		bs[n].Rectangle = l.rect(vg.Point{}, l.Labels[i], pos.x, pos.y, 0)
+	}
+	return bs
+}
@@ -0,0 +1,132 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// ExampleOutlierLabels draws the residuals of a set of
+// samples, labeling the outliers with the sample names.
+func ExampleOutlierLabels() {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 80
+	res := XYLabels{XYs: make(XYs, n), Labels: make([]string, n)}
+	for i := range res.XYs {
+		res.XYs[i].X = float64(i)
+		res.XYs[i].Y = rnd.NormFloat64()
+		res.Labels[i] = fmt.Sprintf("s%02d", i)
+	}
+	// Some of the samples were mislabeled.
+	for _, i := range []int{12, 13, 47, 70} {
+		res.XYs[i].Y += 4
+	}
+	res.XYs[71].Y -= 4.5
+
+	s, err := NewScatter(res)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.GlyphStyle = draw.GlyphStyle{Color: color.Gray{96}, Radius: vg.Points(2), Shape: draw.CircleGlyph{}}
+
+	l, err := NewOutlierLabels(res, IQROutliers(1.5))
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Radius = s.Radius
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Residuals"
+	p.X.Label.Text = "Sample"
+	p.Add(NewGrid(), s, l)
+
+	err = p.Save(300, 200, "testdata/outlierLabels.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestOutlierLabels(t *testing.T) {
+	checkPlot(ExampleOutlierLabels, t, "outlierLabels.png")
+}
+
+func TestOutlierRules(t *testing.T) {
+	xys := XYs{{0, 1}, {1, 2}, {2, 3}, {3, 2}, {4, 1}, {5, 2}, {6, 30}, {7, -20}}
+	outliers := func(out []bool) []int {
+		var idx []int
+		for i, o := range out {
+			if o {
+				idx = append(idx, i)
+			}
+		}
+		return idx
+	}
+	for _, test := range []struct {
+		name string
+		rule OutlierRule
+		want []int
+	}{
+		{name: "IQR", rule: IQROutliers(1.5), want: []int{6, 7}},
+		{name: "z-score", rule: ZScoreOutliers(1.5), want: []int{6, 7}},
+		{name: "z-score wide", rule: ZScoreOutliers(2), want: []int{6}},
+		{name: "predicate", rule: PredicateOutliers(func(i int, x, y float64) bool {
+			return x < 1 || y == 3
+		}), want: []int{0, 2}},
+	} {
+		if got := outliers(test.rule(xys)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected %s outliers: got:%v want:%v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestOutlierLabelsPlace(t *testing.T) {
+	// A tight cluster of outliers, whose
+	// labels must be spread out.
+	xys := XYLabels{
+		XYs:    XYs{{0, 0}, {10, 10}, {1, 1}, {1.1, 1}, {1, 1.1}, {1.1, 1.1}},
+		Labels: []string{"a", "b", "c", "d", "e", "f"},
+	}
+	l, err := NewOutlierLabels(xys, PredicateOutliers(func(i int, x, y float64) bool { return i >= 2 }))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{2, 3, 4, 5}; !reflect.DeepEqual(l.Outliers, want) {
+		t.Fatalf("unexpected outliers: got:%v want:%v", l.Outliers, want)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+	c := draw.New(vgimg.New(300, 300))
+	da := p.DataCanvas(c)
+	trX, trY := p.Transforms(&da)
+	pts := make([]vg.Point, len(l.XYs))
+	for i, pt := range l.XYs {
+		pts[i] = vg.Point{X: trX(pt.X), Y: trY(pt.Y)}
+	}
+	rects := l.place(da, pts)
+	for i, a := range rects {
+		for j, b := range rects[:i] {
+			if overlapsRect(a, b) {
+				t.Errorf("labels %d and %d overlap: %v %v", i, j, a, b)
+			}
+		}
+	}
+}
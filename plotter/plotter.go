@@ -13,6 +13,10 @@ styles such as lines, scatter plots, box plots, labels, and more.
 New* functions return an error if the data contains Inf, NaN, or is
 empty. Some of the New* functions return other plotter-specific errors
 too.
+
+The New* functions read the Default* variables of this package to
+style the plotters they return, so plotters may be created by several
+goroutines at once only while those variables are left unchanged.
 */
 package plotter
 
@@ -254,3 +258,33 @@ type YErrors Errors
 func (ye YErrors) YError(i int) (float64, float64) {
 	return ye[i].Low, ye[i].High
 }
+
+// XYErrors holds XY data with low and high errors in
+// both X and Y, implementing the XYer, XErrorer and
+// YErrorer interfaces, so that it can be used to make
+// both XErrorBars and YErrorBars for the same points.
+// The ith errors correspond to the ith XY.
+type XYErrors struct {
+	XYs
+	XErrors
+	YErrors
+}
+
+// NewXYErrors returns XYErrors holding a copy of the points
+// of any XYer, such as the data of a Line or a Scatter, and
+// of the X and Y errors, which must have one element for
+// each point.
+func NewXYErrors(d XYer, xerrs, yerrs Errors) (XYErrors, error) {
+	xys, err := CopyXYs(d)
+	if err != nil {
+		return XYErrors{}, err
+	}
+	if len(xerrs) != len(xys) || len(yerrs) != len(xys) {
+		return XYErrors{}, errors.New("Number of errors does not match the number of points")
+	}
+	return XYErrors{
+		XYs:     xys,
+		XErrors: append(XErrors(nil), xerrs...),
+		YErrors: append(YErrors(nil), yerrs...),
+	}, nil
+}
@@ -0,0 +1,152 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Punchcard implements the Plotter interface, drawing the
+// number of events, such as commits or requests, in each
+// hour of each day of the week as a grid of circles, with
+// areas proportional to the counts.
+//
+// The circles of an hour h are drawn at an X value of h,
+// from 0 to 23. The days of the week are drawn from Monday
+// at the top to Sunday at the bottom, with the day d drawn
+// at a Y value of 6 for Monday down to 0 for Sunday, so the
+// names of the days may be shown on the Y axis with
+//
+//	p.NominalY(pc.Labels()...)
+type Punchcard struct {
+	// Counts holds the number of events in each
+	// hour of each day, indexed by time.Weekday
+	// and by hour.
+	Counts [7][24]float64
+
+	// MaxRadius is the radius of the circle
+	// of the largest count.
+	MaxRadius vg.Length
+
+	// Color is the color of the circles.
+	Color color.Color
+
+	// ColorMap, if not nil, is used to color the
+	// circles by their count, scaled so that zero
+	// maps to the minimum of the ColorMap and the
+	// largest count to the maximum.
+	ColorMap palette.ColorMap
+}
+
+// NewPunchcard returns a punchcard of the given times,
+// counted by their day of the week and hour in the given
+// location. If loc is nil, the location of each time is
+// used.
+func NewPunchcard(ts []time.Time, loc *time.Location) (*Punchcard, error) {
+	if len(ts) == 0 {
+		return nil, ErrNoData
+	}
+	pc := &Punchcard{
+		MaxRadius: vg.Points(8),
+		Color:     color.Gray{64},
+	}
+	for _, t := range ts {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		pc.Counts[t.Weekday()][t.Hour()]++
+	}
+	return pc, nil
+}
+
+// Labels returns the abbreviated names of the days of
+// the week in order of increasing Y value, for use with
+// plot.NominalY.
+func (pc *Punchcard) Labels() []string {
+	ls := make([]string, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		ls[punchcardY(d)] = d.String()[:3]
+	}
+	return ls
+}
+
+// punchcardY returns the Y value of the given day.
+func punchcardY(d time.Weekday) int {
+	return (7 - int(d)) % 7
+}
+
+// max returns the largest count.
+func (pc *Punchcard) max() float64 {
+	var max float64
+	for d := range pc.Counts {
+		for _, n := range pc.Counts[d] {
+			max = math.Max(max, n)
+		}
+	}
+	return max
+}
+
+// Plot implements the plot.Plotter interface.
+func (pc *Punchcard) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	max := pc.max()
+	if max == 0 {
+		return
+	}
+	sty := draw.GlyphStyle{Color: pc.Color, Shape: draw.CircleGlyph{}}
+	for d := range pc.Counts {
+		y := trY(float64(punchcardY(time.Weekday(d))))
+		for h, n := range pc.Counts[d] {
+			if n <= 0 {
+				continue
+			}
+			p := vg.Point{X: trX(float64(h)), Y: y}
+			if !c.Contains(p) {
+				continue
+			}
+			sty.Radius = pc.MaxRadius * vg.Length(math.Sqrt(n/max))
+			if pc.ColorMap != nil {
+				lo, hi := pc.ColorMap.Min(), pc.ColorMap.Max()
+				if clr, err := pc.ColorMap.At(lo + n/max*(hi-lo)); err == nil {
+					sty.Color = clr
+				}
+			}
+			c.DrawGlyph(sty, p)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (pc *Punchcard) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 23, 0, 6
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the largest circles at the corners
+// of the grid.
+func (pc *Punchcard) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	r := pc.MaxRadius
+	var bs []plot.GlyphBox
+	for _, x := range []float64{0, 23} {
+		for _, y := range []float64{0, 6} {
+			bs = append(bs, plot.GlyphBox{
+				X: plt.X.Norm(x),
+				Y: plt.Y.Norm(y),
+				Rectangle: vg.Rectangle{
+					Min: vg.Point{X: -r, Y: -r},
+					Max: vg.Point{X: r, Y: r},
+				},
+			})
+		}
+	}
+	return bs
+}
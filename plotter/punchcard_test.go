@@ -0,0 +1,111 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExamplePunchcard draws the times of the commits to a
+// repository, recorded in UTC, by the hour of the week
+// in the time zone of its developers.
+func ExamplePunchcard() {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2016, 1, 4, 0, 0, 0, 0, time.UTC)
+	zone := time.FixedZone("UTC-5", -5*60*60)
+
+	// Commits are made mostly on working days, during
+	// office hours, with a dip at lunch time.
+	var ts []time.Time
+	for len(ts) < 2000 {
+		day := rnd.Intn(7 * 52)
+		hour := 9 + 4*rnd.NormFloat64()
+		if hour < 0 || hour >= 24 || int(hour) == 12 && rnd.Float64() < 0.5 {
+			continue
+		}
+		if (day%7 == 5 || day%7 == 6) && rnd.Float64() < 0.8 {
+			continue
+		}
+		local := start.AddDate(0, 0, day).Add(time.Duration(hour * float64(time.Hour)))
+		ts = append(ts, local.Add(5*time.Hour))
+	}
+
+	pc, err := NewPunchcard(ts, zone)
+	if err != nil {
+		log.Panic(err)
+	}
+	pc.ColorMap = palette.Linear(palette.Radial(3, palette.Blue, palette.Red, 1))
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Commits"
+	p.X.Label.Text = "Hour (UTC-5)"
+	p.Add(pc)
+	p.NominalY(pc.Labels()...)
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{
+		{Value: 0, Label: "0"}, {Value: 6, Label: "6"},
+		{Value: 12, Label: "12"}, {Value: 18, Label: "18"},
+		{Value: 23, Label: "23"},
+	})
+
+	err = p.Save(400, 200, "testdata/punchcard.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPunchcard(t *testing.T) {
+	checkPlot(ExamplePunchcard, t, "punchcard.png")
+}
+
+func TestPunchcardCounts(t *testing.T) {
+	ts := []time.Time{
+		// Monday 01:30 UTC, Sunday 20:30 in UTC-5.
+		time.Date(2016, 1, 4, 1, 30, 0, 0, time.UTC),
+		time.Date(2016, 1, 4, 1, 45, 0, 0, time.UTC),
+		// Wednesday 12:00 in UTC+1.
+		time.Date(2016, 1, 6, 12, 0, 0, 0, time.FixedZone("UTC+1", 60*60)),
+	}
+
+	pc, err := NewPunchcard(ts, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var want [7][24]float64
+	want[time.Monday][1] = 2
+	want[time.Wednesday][12] = 1
+	if pc.Counts != want {
+		t.Errorf("unexpected counts in the times' locations:\ngot: %v\nwant:%v", pc.Counts, want)
+	}
+
+	pc, err = NewPunchcard(ts, time.FixedZone("UTC-5", -5*60*60))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = [7][24]float64{}
+	want[time.Sunday][20] = 2
+	want[time.Wednesday][6] = 1
+	if pc.Counts != want {
+		t.Errorf("unexpected counts in UTC-5:\ngot: %v\nwant:%v", pc.Counts, want)
+	}
+
+	wantLabels := []string{"Sun", "Sat", "Fri", "Thu", "Wed", "Tue", "Mon"}
+	if got := pc.Labels(); !reflect.DeepEqual(got, wantLabels) {
+		t.Errorf("unexpected labels: got:%v want:%v", got, wantLabels)
+	}
+
+	if _, err := NewPunchcard(nil, nil); err != ErrNoData {
+		t.Errorf("unexpected error for no times: got:%v want:%v", err, ErrNoData)
+	}
+}
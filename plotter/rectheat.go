@@ -0,0 +1,172 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// RectilinearGrid describes values on a grid of cells whose
+// edges are given explicitly, such as the output of a
+// simulation on a stretched mesh.
+type RectilinearGrid interface {
+	// Dims returns the number of columns
+	// and rows of cells of the grid.
+	Dims() (c, r int)
+
+	// Z returns the value of the cell at (c, r).
+	// It will panic if c or r are out of bounds
+	// for the grid.
+	Z(c, r int) float64
+
+	// XEdge returns the X coordinate of the left edge
+	// of column c, or of the right edge of the last
+	// column when c is the number of columns.
+	XEdge(c int) float64
+
+	// YEdge returns the Y coordinate of the lower edge
+	// of row r, or of the upper edge of the last row
+	// when r is the number of rows.
+	YEdge(r int) float64
+}
+
+// RectilinearHeatMap implements the Plotter interface, drawing
+// a heat map of the values of a RectilinearGrid, with each
+// cell filled between its edges, so grids with unevenly
+// spaced rows and columns may be drawn without resampling.
+type RectilinearHeatMap struct {
+	Grid RectilinearGrid
+
+	// ColorMap is used to color the cells. The range
+	// from Min to Max is mapped onto the range of
+	// the ColorMap.
+	ColorMap palette.ColorMap
+
+	// Underflow and Overflow are colors used to fill
+	// cells with values outside the range from Min
+	// to Max. If they are nil, those cells are not
+	// drawn.
+	Underflow color.Color
+	Overflow  color.Color
+
+	// Min and Max define the dynamic range
+	// of the heat map.
+	Min, Max float64
+}
+
+// NewRectilinearHeatMap returns a heat map of the values of
+// the grid, colored by cmap, with the range of the heat map
+// set to the range of the values. The edges of the grid must
+// be strictly increasing.
+func NewRectilinearHeatMap(g RectilinearGrid, cmap palette.ColorMap) (*RectilinearHeatMap, error) {
+	cols, rows := g.Dims()
+	if cols == 0 || rows == 0 {
+		return nil, ErrNoData
+	}
+	for i := 0; i <= cols; i++ {
+		x := g.XEdge(i)
+		if err := CheckFloats(x); err != nil {
+			return nil, err
+		}
+		if i > 0 && x <= g.XEdge(i-1) {
+			return nil, errors.New("Grid X edges are not increasing")
+		}
+	}
+	for j := 0; j <= rows; j++ {
+		y := g.YEdge(j)
+		if err := CheckFloats(y); err != nil {
+			return nil, err
+		}
+		if j > 0 && y <= g.YEdge(j-1) {
+			return nil, errors.New("Grid Y edges are not increasing")
+		}
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := 0; i < cols; i++ {
+		for j := 0; j < rows; j++ {
+			v := g.Z(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	return &RectilinearHeatMap{
+		Grid:     g,
+		ColorMap: cmap,
+		Min:      min,
+		Max:      max,
+	}, nil
+}
+
+// color returns the color of a cell with value v.
+func (h *RectilinearHeatMap) color(v float64) color.Color {
+	switch {
+	case v < h.Min:
+		return h.Underflow
+	case v > h.Max:
+		return h.Overflow
+	}
+	t := 0.5
+	if h.Max > h.Min {
+		t = (v - h.Min) / (h.Max - h.Min)
+	}
+	lo, hi := h.ColorMap.Min(), h.ColorMap.Max()
+	clr, err := h.ColorMap.At(math.Max(lo, math.Min(hi, lo+t*(hi-lo))))
+	if err != nil {
+		return nil
+	}
+	return clr
+}
+
+// Plot implements the plot.Plotter interface.
+func (h *RectilinearHeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	var pa vg.Path
+	cols, rows := h.Grid.Dims()
+	for i := 0; i < cols; i++ {
+		x, dx := trX(h.Grid.XEdge(i)), trX(h.Grid.XEdge(i+1))
+		for j := 0; j < rows; j++ {
+			v := h.Grid.Z(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			col := h.color(v)
+			if col == nil {
+				continue
+			}
+
+			y, dy := trY(h.Grid.YEdge(j)), trY(h.Grid.YEdge(j+1))
+			if !c.Contains(vg.Point{X: x, Y: y}) || !c.Contains(vg.Point{X: dx, Y: dy}) {
+				continue
+			}
+
+			pa = pa[:0]
+			pa.Move(vg.Point{X: x, Y: y})
+			pa.Line(vg.Point{X: dx, Y: y})
+			pa.Line(vg.Point{X: dx, Y: dy})
+			pa.Line(vg.Point{X: x, Y: dy})
+			pa.Close()
+			c.SetColor(col)
+			c.Fill(pa)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (h *RectilinearHeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	cols, rows := h.Grid.Dims()
+	return h.Grid.XEdge(0), h.Grid.XEdge(cols), h.Grid.YEdge(0), h.Grid.YEdge(rows)
+}
@@ -0,0 +1,146 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+type edgeGrid struct {
+	xs, ys []float64
+	Data   mat64.Matrix
+}
+
+func (g edgeGrid) Dims() (c, r int)    { r, c = g.Data.Dims(); return c, r }
+func (g edgeGrid) Z(c, r int) float64  { return g.Data.At(r, c) }
+func (g edgeGrid) XEdge(c int) float64 { return g.xs[c] }
+func (g edgeGrid) YEdge(r int) float64 { return g.ys[r] }
+
+// ExampleRectilinearHeatMap draws the speed of the flow in
+// a boundary layer growing along a wall, on a mesh whose
+// rows are stretched away from the wall.
+func ExampleRectilinearHeatMap() {
+	const cols, rows = 30, 24
+
+	// The rows grow geometrically in height
+	// from the wall at y = 0.
+	ys := make([]float64, rows+1)
+	h := 0.002
+	for j := 1; j <= rows; j++ {
+		ys[j] = ys[j-1] + h
+		h *= 1.12
+	}
+	xs := make([]float64, cols+1)
+	for i := range xs {
+		xs[i] = float64(i) / cols
+	}
+
+	// The boundary layer thickens with the square
+	// root of the distance along the wall.
+	speed := mat64.NewDense(rows, cols, nil)
+	for i := 0; i < cols; i++ {
+		x := (xs[i] + xs[i+1]) / 2
+		delta := 0.02 + 0.1*math.Sqrt(x)
+		for j := 0; j < rows; j++ {
+			y := (ys[j] + ys[j+1]) / 2
+			speed.Set(j, i, math.Tanh(2*y/delta))
+		}
+	}
+
+	cmap := palette.Linear(palette.Rainbow(8, palette.Blue, palette.Red, 1, 1, 1))
+	hm, err := NewRectilinearHeatMap(edgeGrid{xs: xs, ys: ys, Data: speed}, cmap)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Boundary layer"
+	p.X.Label.Text = "Distance along the wall"
+	p.Y.Label.Text = "Height"
+	p.Add(hm)
+
+	// Draw the edges of the rows to show
+	// the stretching of the mesh.
+	for _, y := range ys {
+		l, err := NewLine(XYs{{0, y}, {0.02, y}})
+		if err != nil {
+			log.Panic(err)
+		}
+		p.Add(l)
+	}
+
+	err = p.Save(300, 250, "testdata/rectilinearHeatMap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRectilinearHeatMap(t *testing.T) {
+	checkPlot(ExampleRectilinearHeatMap, t, "rectilinearHeatMap.png")
+}
+
+func TestRectilinearHeatMapCells(t *testing.T) {
+	g := edgeGrid{
+		xs:   []float64{0, 1, 4},
+		ys:   []float64{0, 2},
+		Data: mat64.NewDense(1, 2, []float64{1, math.NaN()}),
+	}
+	cmap := palette.Linear(palette.Heat(2, 1))
+	hm, err := NewRectilinearHeatMap(g, cmap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hm.Min != 1 || hm.Max != 1 {
+		t.Errorf("unexpected range: got:%v %v want:1 1", hm.Min, hm.Max)
+	}
+	if xmin, xmax, ymin, ymax := hm.DataRange(); xmin != 0 || xmax != 4 || ymin != 0 || ymax != 2 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 4 0 2", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(hm)
+	p.HideAxes()
+	var r recorder.Canvas
+	c := draw.NewCanvas(&r, 40, 20)
+	p.Draw(c)
+	var fills int
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			fills++
+		}
+	}
+	// The background and the cell with a value.
+	if fills != 2 {
+		t.Errorf("unexpected number of fills: got:%d want:2", fills)
+	}
+
+	g.xs = []float64{0, 1, 1}
+	if _, err := NewRectilinearHeatMap(g, cmap); err == nil {
+		t.Error("expected error for non-increasing edges")
+	}
+
+	hm.Underflow = color.Black
+	if got := hm.color(0); got != color.Black {
+		t.Errorf("unexpected underflow color: got:%v want:%v", got, color.Black)
+	}
+	if got := hm.color(2); got != nil {
+		t.Errorf("unexpected overflow color: got:%v want:nil", got)
+	}
+}
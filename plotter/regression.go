@@ -0,0 +1,419 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Regression implements the Plotter interface, drawing the
+// line fitted to a set of points by ordinary least squares,
+// or the curve fitted by LOESS if Span is not zero, with a
+// shaded band showing the confidence interval of the fit or
+// the prediction interval of new points. The band and the
+// curve are drawn across the X range of the points.
+type Regression struct {
+	// XYs holds the points, in order
+	// of increasing X value.
+	XYs
+
+	// Intercept and Slope are the coefficients
+	// of the line fitted by least squares.
+	Intercept, Slope float64
+
+	// InterceptErr and SlopeErr are the standard
+	// errors of the Intercept and the Slope.
+	InterceptErr, SlopeErr float64
+
+	// R2 is the coefficient of determination of the
+	// line, the proportion of the variance of the Y
+	// values explained by it.
+	R2 float64
+
+	// Sigma is the residual standard error of
+	// the line, the estimated standard deviation
+	// of the points about it.
+	Sigma float64
+
+	// Span, if not zero, is the fraction of the
+	// points, between zero and one, to which a line
+	// is fitted locally by LOESS at each X value,
+	// weighted by their distance from it. The LOESS
+	// curve and its band are drawn instead of the
+	// line. Greater spans give smoother curves.
+	Span float64
+
+	// Level is the confidence level of the
+	// band, between zero and one.
+	Level float64
+
+	// Prediction is whether the band is the interval
+	// in which new points are expected to fall, rather
+	// than the confidence interval of the fit.
+	Prediction bool
+
+	// Samples is the number of points at
+	// which the curve and band are drawn.
+	Samples int
+
+	// LineStyle is the style of the curve.
+	draw.LineStyle
+
+	// BandColor is the fill color of the band.
+	// If BandColor is nil, no band is drawn.
+	BandColor color.Color
+}
+
+// NewRegression returns the line fitted by least squares
+// to the points, drawn with a 95% confidence band. There
+// must be at least three points, of at least two distinct
+// X values. The points are copied and sorted by X value.
+func NewRegression(xys XYer) (*Regression, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 3 {
+		return nil, errors.New("Too few points for a regression")
+	}
+	sort.Sort(xysByX(data))
+	if data[0].X == data[len(data)-1].X {
+		return nil, errors.New("Regression points have a single X value")
+	}
+
+	n := float64(len(data))
+	var mx, my float64
+	for _, p := range data {
+		mx += p.X
+		my += p.Y
+	}
+	mx /= n
+	my /= n
+	var sxx, sxy, syy float64
+	for _, p := range data {
+		sxx += (p.X - mx) * (p.X - mx)
+		sxy += (p.X - mx) * (p.Y - my)
+		syy += (p.Y - my) * (p.Y - my)
+	}
+
+	r := &Regression{
+		XYs:       data,
+		Slope:     sxy / sxx,
+		Level:     0.95,
+		Samples:   50,
+		LineStyle: DefaultLineStyle,
+		BandColor: color.NRGBA{R: 128, G: 128, B: 128, A: 64},
+	}
+	r.Intercept = my - r.Slope*mx
+	var rss float64
+	for _, p := range data {
+		d := p.Y - r.Intercept - r.Slope*p.X
+		rss += d * d
+	}
+	r.Sigma = math.Sqrt(rss / (n - 2))
+	r.SlopeErr = r.Sigma / math.Sqrt(sxx)
+	r.InterceptErr = r.Sigma * math.Sqrt(1/n+mx*mx/sxx)
+	r.R2 = 1
+	if syy > 0 {
+		r.R2 = 1 - rss/syy
+	}
+	return r, nil
+}
+
+// At returns the value of the fit at x.
+func (r *Regression) At(x float64) float64 {
+	if r.Span == 0 {
+		return r.Intercept + r.Slope*x
+	}
+	var y float64
+	for i, l := range r.loess(x) {
+		y += l * r.XYs[i].Y
+	}
+	return y
+}
+
+// Band returns the low and high bounds of
+// the band of the fit at x.
+func (r *Regression) Band(x float64) (low, high float64) {
+	return r.band()(x)
+}
+
+// band returns a function returning the bounds of the band
+// at x, computing the residual standard error of the fit
+// once for all of the calls.
+func (r *Regression) band() func(x float64) (low, high float64) {
+	n := float64(len(r.XYs))
+	if r.Span == 0 {
+		var mx, sxx float64
+		for _, p := range r.XYs {
+			mx += p.X
+		}
+		mx /= n
+		for _, p := range r.XYs {
+			sxx += (p.X - mx) * (p.X - mx)
+		}
+		t := studentTQuantile((1+r.Level)/2, n-2)
+		return func(x float64) (low, high float64) {
+			// v is the variance of the fit
+			// in units of Sigma².
+			v := 1/n + (x-mx)*(x-mx)/sxx
+			if r.Prediction {
+				v++
+			}
+			y, d := r.At(x), t*r.Sigma*math.Sqrt(v)
+			return y - d, y + d
+		}
+	}
+
+	sigma, df := r.loessSigma()
+	t := studentTQuantile((1+r.Level)/2, df)
+	return func(x float64) (low, high float64) {
+		var y, v float64
+		for i, l := range r.loess(x) {
+			y += l * r.XYs[i].Y
+			v += l * l
+		}
+		if r.Prediction {
+			v++
+		}
+		d := t * sigma * math.Sqrt(v)
+		return y - d, y + d
+	}
+}
+
+// loess returns the weights of the points in the value at x
+// of the line fitted locally by LOESS, with tricube weights
+// over the nearest Span of the points.
+func (r *Regression) loess(x float64) []float64 {
+	if r.Span <= 0 || r.Span > 1 {
+		panic("plotter: regression span out of range")
+	}
+	n := len(r.XYs)
+	q := int(math.Ceil(r.Span * float64(n)))
+	if q < 2 {
+		q = 2
+	}
+	dist := make([]float64, n)
+	for i, p := range r.XYs {
+		dist[i] = math.Abs(p.X - x)
+	}
+	sorted := append([]float64(nil), dist...)
+	sort.Float64s(sorted)
+	// The window is widened a little so that
+	// its furthest points have a weight.
+	h := sorted[q-1] * (1 + 1e-8)
+	if h == 0 {
+		h = 1
+	}
+
+	w := make([]float64, n)
+	var sw, mx float64
+	for i, d := range dist {
+		if u := d / h; u < 1 {
+			w[i] = math.Pow(1-u*u*u, 3)
+		}
+		sw += w[i]
+		mx += w[i] * r.XYs[i].X
+	}
+	mx /= sw
+	var sxx float64
+	for i, p := range r.XYs {
+		sxx += w[i] * (p.X - mx) * (p.X - mx)
+	}
+	for i, p := range r.XYs {
+		l := w[i] / sw
+		if sxx > 0 {
+			l += w[i] * (p.X - mx) * (x - mx) / sxx
+		}
+		w[i] = l
+	}
+	return w
+}
+
+// loessSigma returns the residual standard error of
+// the LOESS fit and its degrees of freedom, the number
+// of points less the trace of the smoother matrix.
+func (r *Regression) loessSigma() (sigma, df float64) {
+	var rss, tr float64
+	for j, p := range r.XYs {
+		var y float64
+		l := r.loess(p.X)
+		for i, li := range l {
+			y += li * r.XYs[i].Y
+		}
+		rss += (p.Y - y) * (p.Y - y)
+		tr += l[j]
+	}
+	df = float64(len(r.XYs)) - tr
+	return math.Sqrt(rss / df), df
+}
+
+// samples returns the X values at which
+// the curve and band are drawn.
+func (r *Regression) samples() []float64 {
+	min, max := r.XYs[0].X, r.XYs[len(r.XYs)-1].X
+	xs := make([]float64, r.Samples)
+	for i := range xs {
+		xs[i] = min + (max-min)*float64(i)/float64(r.Samples-1)
+	}
+	return xs
+}
+
+// Plot implements the plot.Plotter interface.
+func (r *Regression) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	xs := r.samples()
+
+	if r.BandColor != nil {
+		bounds := r.band()
+		band := make([]vg.Point, 2*len(xs))
+		for i, x := range xs {
+			low, high := bounds(x)
+			band[i] = vg.Point{X: trX(x), Y: trY(high)}
+			band[len(band)-1-i] = vg.Point{X: trX(x), Y: trY(low)}
+		}
+		c.FillPolygon(r.BandColor, c.ClipPolygonXY(band))
+	}
+
+	line := make([]vg.Point, len(xs))
+	for i, x := range xs {
+		line[i] = vg.Point{X: trX(x), Y: trY(r.At(x))}
+	}
+	c.StrokeLines(r.LineStyle, c.ClipLinesXY(line)...)
+}
+
+// DataRange implements the plot.DataRanger interface,
+// covering the points and the band.
+func (r *Regression) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(r.XYs)
+	if r.BandColor == nil {
+		return xmin, xmax, ymin, ymax
+	}
+	bounds := r.band()
+	for _, x := range r.samples() {
+		low, high := bounds(x)
+		ymin = math.Min(ymin, low)
+		ymax = math.Max(ymax, high)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface,
+// drawing the line over the band.
+func (r *Regression) Thumbnail(c *draw.Canvas) {
+	if r.BandColor != nil {
+		inset := c.Size().Y / 4
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y + inset},
+			{X: c.Min.X, Y: c.Max.Y - inset},
+			{X: c.Max.X, Y: c.Max.Y - inset},
+			{X: c.Max.X, Y: c.Min.Y + inset},
+		}
+		c.FillPolygon(r.BandColor, c.ClipPolygonY(pts))
+	}
+	y := c.Center().Y
+	c.StrokeLine2(r.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// studentTQuantile returns the quantile of the Student's
+// t distribution with df degrees of freedom at p.
+func studentTQuantile(p, df float64) float64 {
+	if p == 0.5 {
+		return 0
+	}
+	if p < 0.5 {
+		return -studentTQuantile(1-p, df)
+	}
+	// Bracket the quantile and bisect.
+	lo, hi := 0.0, 1.0
+	for studentTCDF(hi, df) < p {
+		lo, hi = hi, 2*hi
+		if math.IsInf(hi, 0) {
+			return hi
+		}
+	}
+	for i := 0; i < 100 && hi-lo > 1e-12*hi; i++ {
+		mid := (lo + hi) / 2
+		if studentTCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// studentTCDF returns the cumulative distribution function
+// of the Student's t distribution with df degrees of freedom
+// at t.
+func studentTCDF(t, df float64) float64 {
+	tail := 0.5 * regIncBeta(df/2, 0.5, df/(df+t*t))
+	if t < 0 {
+		return tail
+	}
+	return 1 - tail
+}
+
+// regIncBeta returns the regularized incomplete beta
+// function I_x(a, b), evaluated by its continued fraction.
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	// The continued fraction converges
+	// quickly for x below its mean.
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaFrac(b, a, 1-x)/b
+	}
+	return front * betaFrac(a, b, x) / a
+}
+
+// betaFrac evaluates the continued fraction of the incomplete
+// beta function by the modified method of Lentz.
+func betaFrac(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	f := d
+	for m := 1; m <= 200; m++ {
+		fm := float64(m)
+		for _, num := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + num*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + num/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			f *= c * d
+		}
+		if math.Abs(c*d-1) < 1e-15 {
+			break
+		}
+	}
+	return f
+}
@@ -0,0 +1,144 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleRegression draws a line fitted to noisy points with
+// its confidence band, and a LOESS curve with its prediction
+// band.
+func ExampleRegression() {
+	rnd := rand.New(rand.NewSource(1))
+
+	pts := make(XYs, 60)
+	for i := range pts {
+		x := 10 * rnd.Float64()
+		pts[i].X = x
+		pts[i].Y = 0.5*x + math.Sin(x) + 0.4*rnd.NormFloat64()
+	}
+
+	line, err := NewRegression(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	line.Width = vg.Points(1)
+
+	curve, err := NewRegression(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	curve.Span = 0.3
+	curve.Prediction = true
+	curve.Color = color.RGBA{R: 196, B: 128, A: 255}
+	curve.Width = vg.Points(1)
+	curve.BandColor = color.NRGBA{R: 196, B: 128, A: 32}
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Radius = vg.Points(2)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Regression"
+	p.Add(curve, line, s)
+	p.Legend.Add("least squares", line)
+	p.Legend.Add("LOESS", curve)
+
+	err = p.Save(200, 200, "testdata/regression.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRegression(t *testing.T) {
+	checkPlot(ExampleRegression, t, "regression.png")
+}
+
+func TestNewRegression(t *testing.T) {
+	pts := XYs{{3, 5}, {1, 2}, {2, 4}, {4, 6}, {5, 8}}
+	r, err := NewRegression(pts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.XYs[0].X != 1 || r.XYs[4].X != 5 {
+		t.Errorf("points not sorted: %v", r.XYs)
+	}
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "intercept", got: r.Intercept, want: 0.8},
+		{name: "slope", got: r.Slope, want: 1.4},
+		{name: "sigma", got: r.Sigma, want: math.Sqrt(0.4 / 3)},
+		{name: "slope error", got: r.SlopeErr, want: math.Sqrt(0.4 / 3 / 10)},
+		{name: "intercept error", got: r.InterceptErr, want: math.Sqrt(0.4 / 3 * (1.0/5 + 9.0/10))},
+		{name: "R²", got: r.R2, want: 1 - 0.4/20},
+	} {
+		if math.Abs(test.got-test.want) > 1e-12 {
+			t.Errorf("unexpected %s: got:%v want:%v", test.name, test.got, test.want)
+		}
+	}
+
+	// The band is narrowest at the mean of X.
+	low, high := r.Band(3)
+	want := studentTQuantile(0.975, 3) * r.Sigma * math.Sqrt(1.0/5)
+	if math.Abs(high-low-2*want) > 1e-12 || math.Abs((low+high)/2-r.At(3)) > 1e-12 {
+		t.Errorf("unexpected band at 3: got:[%v %v] want half width %v", low, high, want)
+	}
+	r.Prediction = true
+	plow, phigh := r.Band(3)
+	if !(plow < low && phigh > high) {
+		t.Errorf("prediction band not wider than confidence band: got:[%v %v] and [%v %v]", plow, phigh, low, high)
+	}
+
+	// Local lines through points on a line are the line.
+	r, err = NewRegression(XYs{{0, 1}, {1, 3}, {2, 5}, {3, 7}, {4, 9}, {5, 11}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Span = 0.5
+	for _, x := range []float64{0, 1.5, 2.25, 5} {
+		if got := r.At(x); math.Abs(got-(2*x+1)) > 1e-12 {
+			t.Errorf("unexpected LOESS value at %v: got:%v want:%v", x, got, 2*x+1)
+		}
+	}
+
+	if _, err := NewRegression(XYs{{0, 1}, {1, 2}}); err == nil {
+		t.Error("expected error for too few points")
+	}
+	if _, err := NewRegression(XYs{{1, 1}, {1, 2}, {1, 3}}); err == nil {
+		t.Error("expected error for a single X value")
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	for _, test := range []struct {
+		p, df, want float64
+	}{
+		{p: 0.975, df: 1, want: 12.706204736174703},
+		{p: 0.975, df: 3, want: 3.182446305284263},
+		{p: 0.975, df: 10, want: 2.2281388519649385},
+		{p: 0.995, df: 30, want: 2.7499956535672423},
+		{p: 0.025, df: 10, want: -2.2281388519649385},
+		{p: 0.5, df: 4, want: 0},
+	} {
+		if got := studentTQuantile(test.p, test.df); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("unexpected quantile at %v for %v degrees of freedom: got:%v want:%v", test.p, test.df, got, test.want)
+		}
+	}
+}
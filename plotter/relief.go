@@ -0,0 +1,172 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Hillshade returns the illumination, between zero and one,
+// of the elevations in g lit by a distant light, such as the
+// sun, with the given azimuth, in degrees clockwise from the
+// positive Y direction, and altitude, in degrees above the
+// horizon. The slopes of the terrain are exaggerated by the
+// factor zf, for elevations in different units from the X
+// and Y coordinates.
+//
+// The returned grid has the X and Y coordinates of g, and
+// Min and Max methods returning zero and one, so it may be
+// drawn by a HeatMap with a gray palette.
+func Hillshade(g GridXYZ, azimuth, altitude, zf float64) GridXYZ {
+	az := azimuth * math.Pi / 180
+	alt := altitude * math.Pi / 180
+	lx, ly, lz := math.Sin(az)*math.Cos(alt), math.Cos(az)*math.Cos(alt), math.Sin(alt)
+
+	cols, rows := g.Dims()
+	s := shadeGrid{GridXYZ: g, cols: cols, shade: make([]float64, cols*rows)}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			// The slopes are estimated by central
+			// differences, except at the edges.
+			var dx, dy float64
+			if l, h := neighbours(c, cols); l != h {
+				dx = (g.Z(h, r) - g.Z(l, r)) / (g.X(h) - g.X(l))
+			}
+			if l, h := neighbours(r, rows); l != h {
+				dy = (g.Z(c, h) - g.Z(c, l)) / (g.Y(h) - g.Y(l))
+			}
+			nx, ny := -zf*dx, -zf*dy
+			shade := (nx*lx + ny*ly + lz) / math.Sqrt(nx*nx+ny*ny+1)
+			s.shade[r*cols+c] = math.Max(0, shade)
+		}
+	}
+	return s
+}
+
+// neighbours returns the indices of the neighbours
+// of index i of n, or i itself at the edges.
+func neighbours(i, n int) (lo, hi int) {
+	lo, hi = i-1, i+1
+	if lo < 0 {
+		lo = 0
+	}
+	if hi >= n {
+		hi = n - 1
+	}
+	return lo, hi
+}
+
+// shadeGrid is a grid of illuminations
+// on the coordinates of another grid.
+type shadeGrid struct {
+	GridXYZ
+	cols  int
+	shade []float64
+}
+
+func (g shadeGrid) Z(c, r int) float64 { return g.shade[r*g.cols+c] }
+func (g shadeGrid) Min() float64       { return 0 }
+func (g shadeGrid) Max() float64       { return 1 }
+
+// ShadedRelief implements the Plotter interface, drawing
+// the values of a grid colored by a ColorMap and shaded by
+// a grayscale layer, such as a hillshade returned by
+// Hillshade, for terrain-style maps of data such as
+// temperatures or land use over elevation.
+//
+// The X and Y coordinates of the grids must increase
+// with their column and row indices, and are assumed
+// to be evenly spaced.
+type ShadedRelief struct {
+	// Data holds the values that are colored.
+	Data GridXYZ
+
+	// ColorMap is used to color the values of Data.
+	// Values outside the range of the ColorMap are
+	// given the color of its nearest end, and NaN
+	// values are not drawn.
+	ColorMap palette.ColorMap
+
+	// Shade holds the gray level, between zero
+	// and one, by which each element is shaded.
+	// NaN shades leave elements unshaded.
+	Shade GridXYZ
+
+	// Blend is the mode by which the colors
+	// and the shades are combined.
+	Blend palette.BlendMode
+}
+
+// NewShadedRelief returns a shaded relief of the values in
+// data, colored by cmap and shaded by shade, blending by
+// multiplication. The grids must have the same dimensions.
+func NewShadedRelief(data, shade GridXYZ, cmap palette.ColorMap) (*ShadedRelief, error) {
+	c, r := data.Dims()
+	sc, sr := shade.Dims()
+	if c != sc || r != sr {
+		return nil, errors.New("Shade dimensions do not match the data dimensions")
+	}
+	if c == 0 || r == 0 {
+		return nil, ErrNoData
+	}
+	return &ShadedRelief{
+		Data:     data,
+		ColorMap: cmap,
+		Shade:    shade,
+		Blend:    palette.Multiply,
+	}, nil
+}
+
+// image returns the image of the relief, with a pixel for
+// each element of the grid.
+func (s *ShadedRelief) image() image.Image {
+	cols, rows := s.Data.Dims()
+	img := image.NewNRGBA(image.Rect(0, 0, cols, rows))
+	lo, hi := s.ColorMap.Min(), s.ColorMap.Max()
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			v := s.Data.Z(c, r)
+			if math.IsNaN(v) {
+				continue
+			}
+			clr, err := s.ColorMap.At(math.Max(lo, math.Min(hi, v)))
+			if err != nil {
+				continue
+			}
+			var n color.NRGBA
+			if sh := s.Shade.Z(c, r); math.IsNaN(sh) {
+				n = color.NRGBAModel.Convert(clr).(color.NRGBA)
+			} else {
+				n = palette.Shade(clr, sh, s.Blend)
+			}
+			img.SetNRGBA(c, rows-1-r, n)
+		}
+	}
+	return img
+}
+
+// Plot implements the plot.Plotter interface.
+func (s *ShadedRelief) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	xmin, xmax, ymin, ymax := s.DataRange()
+	rect := vg.Rectangle{
+		Min: vg.Point{X: trX(xmin), Y: trY(ymin)},
+		Max: vg.Point{X: trX(xmax), Y: trY(ymax)},
+	}
+	c.DrawImage(rect, s.image())
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (s *ShadedRelief) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return gridRange(s.Data)
+}
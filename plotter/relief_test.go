@@ -0,0 +1,103 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleShadedRelief draws the temperature over a range
+// of hills, colored by a diverging color map and shaded
+// by the hillshade of the terrain lit from the north west.
+func ExampleShadedRelief() {
+	const n = 100
+	elev := mat64.NewDense(n, n, nil)
+	temp := mat64.NewDense(n, n, nil)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			x, y := float64(c)/n, float64(r)/n
+			z := 900*math.Exp(-(sq(x-0.3)+sq(y-0.6))/0.02) +
+				600*math.Exp(-(sq(x-0.7)+sq(y-0.3))/0.03) +
+				20*math.Sin(25*x)*math.Cos(20*y)
+			elev.Set(r, c, z)
+
+			// The temperature falls with elevation
+			// and rises towards the south.
+			temp.Set(r, c, 15-0.0065*z-4*y)
+		}
+	}
+
+	// The X and Y coordinates are in units of 50m.
+	shade := Hillshade(offsetUnitGrid{Data: elev}, 315, 45, 0.02)
+	cmap := palette.Linear(palette.Radial(9, palette.Blue, palette.Red, 1))
+	cmap.SetMin(5)
+	cmap.SetMax(15)
+	s, err := NewShadedRelief(offsetUnitGrid{Data: temp}, shade, cmap)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Temperature"
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/shadedRelief.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func sq(x float64) float64 { return x * x }
+
+func TestShadedRelief(t *testing.T) {
+	checkPlot(ExampleShadedRelief, t, "shadedRelief.png")
+}
+
+func TestHillshade(t *testing.T) {
+	// A plane rising to the east with a slope of one.
+	plane := mat64.NewDense(3, 4, []float64{
+		0, 1, 2, 3,
+		0, 1, 2, 3,
+		0, 1, 2, 3,
+	})
+	for _, test := range []struct {
+		azimuth, altitude float64
+		want              float64
+	}{
+		// Lit from above.
+		{azimuth: 0, altitude: 90, want: math.Sqrt(0.5)},
+		// Lit from the west, along the normal.
+		{azimuth: 270, altitude: 45, want: 1},
+		// Lit from the east, grazing the plane.
+		{azimuth: 90, altitude: 45, want: 0},
+		// Lit from the north.
+		{azimuth: 0, altitude: 30, want: math.Sqrt(0.5) * 0.5},
+	} {
+		g := Hillshade(offsetUnitGrid{Data: plane}, test.azimuth, test.altitude, 1)
+		c, r := g.Dims()
+		for i := 0; i < c; i++ {
+			for j := 0; j < r; j++ {
+				if got := g.Z(i, j); math.Abs(got-test.want) > 1e-12 {
+					t.Errorf("unexpected shade at (%d, %d) for azimuth %v and altitude %v: got:%v want:%v",
+						i, j, test.azimuth, test.altitude, got, test.want)
+				}
+			}
+		}
+	}
+
+	g := Hillshade(offsetUnitGrid{Data: plane}, 0, 45, 1)
+	if _, err := NewShadedRelief(offsetUnitGrid{Data: mat64.NewDense(2, 2, nil)}, g, palette.Linear(palette.Heat(2, 1))); err == nil {
+		t.Error("expected error for mismatched grid dimensions")
+	}
+}
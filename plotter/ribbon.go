@@ -0,0 +1,253 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// QuantileBand is a band between two quantiles
+// of a PercentileRibbon.
+type QuantileBand struct {
+	// Low and High are the quantiles, between
+	// zero and one, bounding the band.
+	Low, High float64
+
+	// Color is the fill color of the band.
+	Color color.Color
+}
+
+// PercentileRibbon implements the Plotter interface, drawing
+// rolling quantiles of samples, such as the latencies of
+// requests over time. The quantiles at an X value are those
+// of the Y values of the samples in the window of width
+// Window ending at it. The bands between quantiles are drawn
+// as shaded ribbons, in order, over which a line is drawn
+// through the median.
+type PercentileRibbon struct {
+	// XYs holds the samples, in order
+	// of increasing X value.
+	XYs
+
+	// Window is the width of the window
+	// of samples in X units.
+	Window float64
+
+	// Step is the distance between the X values
+	// at which the quantiles are computed, starting
+	// at the first sample. If Step is zero, the
+	// quantiles are computed at the X value of
+	// each sample. The ribbons are broken where
+	// a window holds no samples.
+	Step float64
+
+	// Bands holds the bands that are drawn, in order.
+	// Nested bands are drawn from the widest to the
+	// narrowest.
+	Bands []QuantileBand
+
+	// LineStyle is the style of the line through
+	// the median. If its Color is nil, the median
+	// is not drawn.
+	LineStyle draw.LineStyle
+}
+
+// NewPercentileRibbon returns a percentile ribbon of the samples
+// in xys, computed over windows of the given width. The samples
+// are copied and sorted by X value. By default, bands are drawn
+// from the median to the 99th and to the 90th percentiles.
+func NewPercentileRibbon(xys XYer, window float64) (*PercentileRibbon, error) {
+	if window <= 0 || math.IsInf(window, 0) || math.IsNaN(window) {
+		return nil, errors.New("Invalid percentile ribbon window")
+	}
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	sort.Stable(xysByX(data))
+
+	line := DefaultLineStyle
+	line.Color = color.NRGBA{R: 24, G: 90, B: 169, A: 255}
+	return &PercentileRibbon{
+		XYs:    data,
+		Window: window,
+		Bands: []QuantileBand{
+			{Low: 0.5, High: 0.99, Color: color.NRGBA{R: 90, G: 155, B: 212, A: 64}},
+			{Low: 0.5, High: 0.9, Color: color.NRGBA{R: 90, G: 155, B: 212, A: 128}},
+		},
+		LineStyle: line,
+	}, nil
+}
+
+// xysByX sorts XYs by X value.
+type xysByX XYs
+
+func (s xysByX) Len() int           { return len(s) }
+func (s xysByX) Less(i, j int) bool { return s[i].X < s[j].X }
+func (s xysByX) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// ribbonPoint holds the quantiles of
+// the window ending at an X value.
+type ribbonPoint struct {
+	x float64
+
+	// q holds the median, followed by the low
+	// and high quantiles of each band.
+	q []float64
+}
+
+// quantiles returns the rolling quantiles of the samples,
+// in runs of windows holding at least one sample.
+func (r *PercentileRibbon) quantiles() [][]ribbonPoint {
+	ps := []float64{0.5}
+	for _, b := range r.Bands {
+		ps = append(ps, b.Low, b.High)
+	}
+
+	var xs []float64
+	first, last := r.XYs[0].X, r.XYs[len(r.XYs)-1].X
+	if r.Step > 0 {
+		for i := 0; ; i++ {
+			x := first + float64(i)*r.Step
+			if x > last {
+				break
+			}
+			xs = append(xs, x)
+		}
+	} else {
+		for i, xy := range r.XYs {
+			if i == 0 || xy.X != r.XYs[i-1].X {
+				xs = append(xs, xy.X)
+			}
+		}
+	}
+
+	var (
+		runs   [][]ribbonPoint
+		run    []ribbonPoint
+		lo, hi int
+		window []float64
+	)
+	for _, x := range xs {
+		// The window holds the samples
+		// in (x-Window, x].
+		for hi < len(r.XYs) && r.XYs[hi].X <= x {
+			hi++
+		}
+		for lo < hi && r.XYs[lo].X <= x-r.Window {
+			lo++
+		}
+		// Without a step, a window ending between samples
+		// more than Window apart holds no samples.
+		gap := r.Step <= 0 && len(run) > 0 && x-run[len(run)-1].x > r.Window
+		if lo == hi || gap {
+			if len(run) > 0 {
+				runs = append(runs, run)
+				run = nil
+			}
+			if lo == hi {
+				continue
+			}
+		}
+		window = window[:0]
+		for _, xy := range r.XYs[lo:hi] {
+			window = append(window, xy.Y)
+		}
+		sort.Float64s(window)
+		pt := ribbonPoint{x: x, q: make([]float64, len(ps))}
+		for i, p := range ps {
+			pt.q[i] = quantileR7(window, p)
+		}
+		run = append(run, pt)
+	}
+	if len(run) > 0 {
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// quantileR7 returns the p quantile of the sorted
+// values vs, estimated by the R-7 method.
+func quantileR7(vs []float64, p float64) float64 {
+	h := float64(len(vs)-1) * p
+	i := int(h)
+	if i >= len(vs)-1 {
+		return vs[len(vs)-1]
+	}
+	return vs[i] + (h-float64(i))*(vs[i+1]-vs[i])
+}
+
+// Plot implements the plot.Plotter interface.
+func (r *PercentileRibbon) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	for _, run := range r.quantiles() {
+		for i, b := range r.Bands {
+			pts := make([]vg.Point, 0, 2*len(run))
+			for _, pt := range run {
+				pts = append(pts, vg.Point{X: trX(pt.x), Y: trY(pt.q[1+2*i])})
+			}
+			for j := len(run) - 1; j >= 0; j-- {
+				pt := run[j]
+				pts = append(pts, vg.Point{X: trX(pt.x), Y: trY(pt.q[2+2*i])})
+			}
+			c.FillPolygon(b.Color, c.ClipPolygonXY(pts))
+		}
+
+		if r.LineStyle.Color == nil {
+			continue
+		}
+		pts := make([]vg.Point, len(run))
+		for j, pt := range run {
+			pts[j] = vg.Point{X: trX(pt.x), Y: trY(pt.q[0])}
+		}
+		c.StrokeLines(r.LineStyle, c.ClipLinesXY(pts)...)
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (r *PercentileRibbon) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = r.XYs[0].X, r.XYs[len(r.XYs)-1].X
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for _, run := range r.quantiles() {
+		for _, pt := range run {
+			for _, v := range pt.q {
+				ymin = math.Min(ymin, v)
+				ymax = math.Max(ymax, v)
+			}
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface,
+// drawing the bands as nested rectangles under the
+// median line.
+func (r *PercentileRibbon) Thumbnail(c *draw.Canvas) {
+	for i, b := range r.Bands {
+		inset := vg.Length(i) * c.Size().Y / vg.Length(2*len(r.Bands)+2)
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y + inset},
+			{X: c.Min.X, Y: c.Max.Y - inset},
+			{X: c.Max.X, Y: c.Max.Y - inset},
+			{X: c.Max.X, Y: c.Min.Y + inset},
+		}
+		c.FillPolygon(b.Color, c.ClipPolygonY(pts))
+	}
+	if r.LineStyle.Color != nil {
+		y := c.Center().Y
+		c.StrokeLine2(r.LineStyle, c.Min.X, y, c.Max.X, y)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExamplePercentileRibbon draws the rolling median, 90th and
+// 99th percentiles over one minute windows of the latencies
+// of requests to a service during an hour, with a slowdown
+// of the slowest requests half way through.
+func ExamplePercentileRibbon() {
+	rnd := rand.New(rand.NewSource(1))
+
+	const n = 20000
+	xys := make(XYs, n)
+	for i := range xys {
+		t := rnd.Float64() * 3600
+		v := math.Exp(math.Log(20) + 0.4*rnd.NormFloat64())
+		if t > 1500 && t < 2100 && rnd.Float64() < 0.15 {
+			v *= 4
+		}
+		xys[i].X = t / 60
+		xys[i].Y = v
+	}
+
+	r, err := NewPercentileRibbon(xys, 1)
+	if err != nil {
+		log.Panic(err)
+	}
+	r.Step = 0.25
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Request latency"
+	p.X.Label.Text = "Time (min)"
+	p.Y.Label.Text = "Latency (ms)"
+	p.Add(r)
+	p.Legend.Add("p50, p90, p99", r)
+	p.Legend.Top = true
+
+	err = p.Save(400, 250, "testdata/percentileRibbon.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestPercentileRibbon(t *testing.T) {
+	checkPlot(ExamplePercentileRibbon, t, "percentileRibbon.png")
+}
+
+func TestPercentileRibbonQuantiles(t *testing.T) {
+	xys := XYs{{3, 30}, {0, 0}, {1, 10}, {2, 20}, {10, 5}}
+	r, err := NewPercentileRibbon(xys, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Bands = []QuantileBand{{Low: 0, High: 1}}
+
+	// Windows ending at each sample, with a
+	// break before the isolated last sample.
+	want := [][]ribbonPoint{
+		{
+			{x: 0, q: []float64{0, 0, 0}},
+			{x: 1, q: []float64{5, 0, 10}},
+			{x: 2, q: []float64{15, 10, 20}},
+			{x: 3, q: []float64{25, 20, 30}},
+		},
+		{
+			{x: 10, q: []float64{5, 5, 5}},
+		},
+	}
+	if got := r.quantiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected quantiles:\ngot: %v\nwant:%v", got, want)
+	}
+
+	// Windows ending at 0, 4 and 8, the
+	// last of which holds no samples.
+	r.Step = 4
+	want = [][]ribbonPoint{
+		{
+			{x: 0, q: []float64{0, 0, 0}},
+			{x: 4, q: []float64{30, 30, 30}},
+		},
+	}
+	if got := r.quantiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected quantiles with step:\ngot: %v\nwant:%v", got, want)
+	}
+
+	if xmin, xmax, ymin, ymax := r.DataRange(); xmin != 0 || xmax != 10 || ymin != 0 || ymax != 30 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 10 0 30", xmin, xmax, ymin, ymax)
+	}
+
+	if _, err := NewPercentileRibbon(xys, 0); err == nil {
+		t.Error("expected error for zero window")
+	}
+}
+
+func TestQuantileR7(t *testing.T) {
+	vs := []float64{1, 2, 3, 4}
+	for _, test := range []struct {
+		p, want float64
+	}{
+		{p: 0, want: 1},
+		{p: 0.5, want: 2.5},
+		{p: 0.9, want: 3.7},
+		{p: 1, want: 4},
+	} {
+		if got := quantileR7(vs, test.p); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected quantile %v: got:%v want:%v", test.p, got, test.want)
+		}
+	}
+}
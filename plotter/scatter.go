@@ -5,6 +5,9 @@
 package plotter
 
 import (
+	"image/color"
+	"math"
+
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
@@ -19,6 +22,32 @@ type Scatter struct {
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	draw.GlyphStyle
+
+	// Density modulates the glyphs by the local
+	// density of the points, to reduce overplotting.
+	// If its Radius is zero, all glyphs are drawn
+	// with the GlyphStyle.
+	Density DensityStyle
+}
+
+// DensityStyle describes how the glyphs of a Scatter are
+// modulated by the local density of the points, so that the
+// glyphs of points in dense cores are drawn lighter or smaller,
+// and those of isolated points with the full GlyphStyle.
+//
+// The density at a point is estimated by the number of points
+// on the canvas within Radius of it, and scaled logarithmically
+// from one, for isolated points, to the largest number.
+type DensityStyle struct {
+	// Radius is the distance on the canvas within
+	// which the neighbors of a point are counted.
+	Radius vg.Length
+
+	// Alpha and Size are the fractions, between
+	// zero and one, by which the opacity and the
+	// radius of the glyphs of the points in the
+	// densest regions are reduced.
+	Alpha, Size float64
 }
 
 // NewScatter returns a Scatter that uses the
@@ -38,9 +67,75 @@ func NewScatter(xys XYer) (*Scatter, error) {
 // interface.
 func (pts *Scatter) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	for _, p := range pts.XYs {
-		c.DrawGlyph(pts.GlyphStyle, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	points := make([]vg.Point, len(pts.XYs))
+	for i, p := range pts.XYs {
+		points[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	if pts.Density.Radius <= 0 {
+		for _, pt := range points {
+			c.DrawGlyph(pts.GlyphStyle, pt)
+		}
+		return
+	}
+
+	counts := neighborCounts(points, pts.Density.Radius)
+	max := 1
+	for _, n := range counts {
+		if n > max {
+			max = n
+		}
+	}
+	var clr color.NRGBA
+	if pts.Color != nil {
+		clr = color.NRGBAModel.Convert(pts.Color).(color.NRGBA)
+	}
+	for i, pt := range points {
+		var t float64
+		if max > 1 {
+			t = math.Log(float64(counts[i])) / math.Log(float64(max))
+		}
+		sty := pts.GlyphStyle
+		sty.Radius = vg.Length(float64(sty.Radius) * (1 - t*pts.Density.Size))
+		if pts.Color != nil {
+			fill := clr
+			fill.A = uint8(float64(fill.A)*(1-t*pts.Density.Alpha) + 0.5)
+			sty.Color = fill
+		}
+		c.DrawGlyph(sty, pt)
+	}
+}
+
+// neighborCounts returns the number of the points within
+// distance r of each point, including the point itself.
+func neighborCounts(points []vg.Point, r vg.Length) []int {
+	// The points are binned into square cells of
+	// side r, so that the neighbors of a point are
+	// in its own cell or the eight around it.
+	type cell struct{ x, y int }
+	cellOf := func(p vg.Point) cell {
+		return cell{x: int(math.Floor(float64(p.X / r))), y: int(math.Floor(float64(p.Y / r)))}
+	}
+	cells := make(map[cell][]int)
+	for i, p := range points {
+		k := cellOf(p)
+		cells[k] = append(cells[k], i)
+	}
+
+	counts := make([]int, len(points))
+	for i, p := range points {
+		k := cellOf(p)
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for _, j := range cells[cell{x: k.x + dx, y: k.y + dy}] {
+					d := points[j].Sub(p)
+					if d.X*d.X+d.Y*d.Y <= r*r {
+						counts[i]++
+					}
+				}
+			}
+		}
 	}
+	return counts
 }
 
 // DataRange returns the minimum and maximum
@@ -0,0 +1,169 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Scatter3D implements the Plotter interface, drawing
+// a glyph for each of a set of points in a three
+// dimensional view. The glyphs are drawn from the
+// furthest point to the nearest, so that nearer
+// glyphs cover those behind them.
+type Scatter3D struct {
+	// XYZs holds the points.
+	XYZs
+
+	// Camera is the view of the points.
+	Camera
+
+	// GlyphStyle is the style of the glyphs.
+	draw.GlyphStyle
+
+	// Values, if not nil, holds a value of each
+	// point by which its glyph is colored using
+	// ColorMap. Points whose values are outside of
+	// the range of the ColorMap are drawn in the
+	// color of the GlyphStyle.
+	Values []float64
+
+	// ColorMap is the ColorMap of the Values.
+	ColorMap palette.ColorMap
+
+	// DropLines is the style of the lines dropped from
+	// each point to the base plane, the plane of the
+	// least Z of the points, which help to show their
+	// positions in depth. If its Color is nil, the
+	// lines are not drawn.
+	DropLines draw.LineStyle
+}
+
+// NewScatter3D returns a Scatter3D of the points
+// in the default view and glyph style, with no
+// drop lines.
+func NewScatter3D(xyz XYZer) (*Scatter3D, error) {
+	cpy, err := CopyXYZs(xyz)
+	if err != nil {
+		return nil, err
+	}
+	return &Scatter3D{
+		XYZs:       cpy,
+		Camera:     DefaultCamera,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// box returns the ranges of the coordinates of the points.
+func (s *Scatter3D) box() [3][2]float64 {
+	box := [3][2]float64{
+		{math.Inf(1), math.Inf(-1)},
+		{math.Inf(1), math.Inf(-1)},
+		{math.Inf(1), math.Inf(-1)},
+	}
+	for _, p := range s.XYZs {
+		for i, v := range [3]float64{p.X, p.Y, p.Z} {
+			box[i][0], box[i][1] = math.Min(box[i][0], v), math.Max(box[i][1], v)
+		}
+	}
+	for i := range box {
+		if box[i][0] > box[i][1] {
+			box[i] = [2]float64{0, 0}
+		}
+	}
+	return box
+}
+
+// depthOrder sorts the indices of
+// points from the furthest to the
+// nearest.
+type depthOrder struct {
+	idx   []int
+	depth []float64
+}
+
+func (o depthOrder) Len() int           { return len(o.idx) }
+func (o depthOrder) Less(i, j int) bool { return o.depth[o.idx[i]] > o.depth[o.idx[j]] }
+func (o depthOrder) Swap(i, j int)      { o.idx[i], o.idx[j] = o.idx[j], o.idx[i] }
+
+// order returns the indices of the points in the
+// order in which they are drawn in the view of p.
+func (s *Scatter3D) order(p projector) []int {
+	o := depthOrder{idx: make([]int, len(s.XYZs)), depth: make([]float64, len(s.XYZs))}
+	for i, pt := range s.XYZs {
+		o.idx[i] = i
+		_, _, o.depth[i] = p.project(pt.X, pt.Y, pt.Z)
+	}
+	sort.Stable(o)
+	return o.idx
+}
+
+// Plot implements the Plotter interface.
+func (s *Scatter3D) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	box := s.box()
+	p := newProjector(s.Camera, box)
+	for _, i := range s.order(p) {
+		pt := s.XYZs[i]
+		vx, vy, _ := p.project(pt.X, pt.Y, pt.Z)
+		at := vg.Point{X: trX(vx), Y: trY(vy)}
+		if s.DropLines.Color != nil && s.DropLines.Width > 0 {
+			bx, by, _ := p.project(pt.X, pt.Y, box[2][0])
+			base := vg.Point{X: trX(bx), Y: trY(by)}
+			c.StrokeLines(s.DropLines, c.ClipLinesXY([]vg.Point{base, at})...)
+		}
+		if !c.Contains(at) {
+			continue
+		}
+		sty := s.GlyphStyle
+		sty.Color = s.color(i)
+		c.DrawGlyph(sty, at)
+	}
+}
+
+// color returns the color of point i.
+func (s *Scatter3D) color(i int) color.Color {
+	if s.Values == nil || s.ColorMap == nil || i >= len(s.Values) {
+		return s.GlyphStyle.Color
+	}
+	clr, err := s.ColorMap.At(s.Values[i])
+	if err != nil {
+		return s.GlyphStyle.Color
+	}
+	return clr
+}
+
+// DataRange implements the DataRange interface,
+// returning the range of the view of the box
+// holding the points.
+func (s *Scatter3D) DataRange() (xmin, xmax, ymin, ymax float64) {
+	box := s.box()
+	return newProjector(s.Camera, box).dataRange(box)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (s *Scatter3D) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	p := newProjector(s.Camera, s.box())
+	bs := make([]plot.GlyphBox, len(s.XYZs))
+	for i, pt := range s.XYZs {
+		vx, vy, _ := p.project(pt.X, pt.Y, pt.Z)
+		bs[i].X = plt.X.Norm(vx)
+		bs[i].Y = plt.Y.Norm(vy)
+		bs[i].Rectangle = s.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s *Scatter3D) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(s.GlyphStyle, c.Center())
+}
@@ -0,0 +1,89 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ExampleScatter3D draws points on a helix, colored
+// by their distance along it, with lines dropped to
+// the base plane.
+func ExampleScatter3D() {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 40
+	xyz := make(XYZs, n)
+	vs := make([]float64, n)
+	for i := range xyz {
+		t := 4 * math.Pi * float64(i) / n
+		xyz[i].X = math.Cos(t) + 0.1*rnd.NormFloat64()
+		xyz[i].Y = math.Sin(t) + 0.1*rnd.NormFloat64()
+		xyz[i].Z = t
+		vs[i] = t
+	}
+
+	s, err := NewScatter3D(xyz)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Azimuth = 20
+	s.Elevation = 20
+	s.Shape = draw.CircleGlyph{}
+	s.Radius = vg.Points(3)
+	s.Values = vs
+	s.ColorMap = palette.Linear(palette.Heat(16, 1))
+	s.ColorMap.SetMax(4 * math.Pi)
+	s.DropLines = draw.LineStyle{Color: color.Gray{Y: 160}, Width: vg.Points(0.5)}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Helix"
+	p.HideAxes()
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/scatter3D.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestScatter3D(t *testing.T) {
+	checkPlot(ExampleScatter3D, t, "scatter3D.png")
+}
+
+func TestScatter3DOrder(t *testing.T) {
+	s, err := NewScatter3D(XYZs{{0, 0, 0}, {0, 1, 0}, {1, 0, 1}, {0, 0.5, 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		cam  Camera
+		want []int
+	}{
+		// From the front, points of greater Y are further.
+		{cam: Camera{}, want: []int{1, 3, 0, 2}},
+		// From the back, points of less Y are further.
+		{cam: Camera{Azimuth: 180}, want: []int{0, 2, 3, 1}},
+		// From high above the front, points of less
+		// Z are further, then those of greater Y.
+		{cam: Camera{Elevation: 60}, want: []int{1, 3, 0, 2}},
+	} {
+		if got := s.order(newProjector(test.cam, s.box())); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected order for %+v: got:%v want:%v", test.cam, got, test.want)
+		}
+	}
+}
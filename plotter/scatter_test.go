@@ -8,6 +8,7 @@ import (
 	"image/color"
 	"log"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/gonum/plot"
@@ -86,3 +87,51 @@ func ExampleScatter() {
 func TestScatter(t *testing.T) {
 	checkPlot(ExampleScatter, t, "scatter.png")
 }
+
+// ExampleScatter_density draws an overplotted cloud of
+// points, with the glyphs in the dense core drawn lighter
+// and smaller than those of isolated points.
+func ExampleScatter_density() {
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(XYs, 5000)
+	for i := range xys {
+		xys[i].X = rnd.NormFloat64()
+		xys[i].Y = 0.6*xys[i].X + 0.8*rnd.NormFloat64()
+	}
+
+	s, err := NewScatter(xys)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.GlyphStyle = draw.GlyphStyle{
+		Color:  color.RGBA{B: 160, A: 255},
+		Radius: vg.Points(2),
+		Shape:  draw.CircleGlyph{},
+	}
+	s.Density = DensityStyle{Radius: vg.Points(4), Alpha: 0.9, Size: 0.5}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Density shaded scatter"
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/scatterDensity.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestScatterDensity(t *testing.T) {
+	checkPlot(ExampleScatter_density, t, "scatterDensity.png")
+}
+
+func TestNeighborCounts(t *testing.T) {
+	pts := []vg.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1.5}, {X: 10, Y: 10}, {X: -1.9, Y: 0}}
+	got := neighborCounts(pts, 2)
+	want := []int{4, 3, 3, 1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected neighbor counts: got:%v want:%v", got, want)
+	}
+}
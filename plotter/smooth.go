@@ -0,0 +1,192 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import "math"
+
+// Smoothing is a method of drawing a smooth
+// curve through the points of a Line.
+type Smoothing int
+
+const (
+	// NoSmoothing joins the points by straight lines.
+	NoSmoothing Smoothing = iota
+
+	// CubicSpline draws the natural cubic spline
+	// through the points, which may overshoot them.
+	// The X values of the points must increase, or
+	// the points are joined by straight lines.
+	CubicSpline
+
+	// MonotoneCubic draws the monotone cubic
+	// interpolant of Fritsch and Carlson through
+	// the points, which does not overshoot them:
+	// the curve rises or falls between two points
+	// as they do. The X values of the points must
+	// increase, or the points are joined by
+	// straight lines.
+	MonotoneCubic
+
+	// Bezier draws cubic Bézier curves between the
+	// points, with the control points of a
+	// Catmull-Rom spline, for points in any order
+	// such as those of a closed path.
+	Bezier
+)
+
+// smoothSteps is the number of line segments drawn
+// between each pair of points of a smoothed Line.
+const smoothSteps = 16
+
+// smooth returns the points of the curve through xys drawn
+// with smoothing s, and the number of steps of the curve
+// between each pair of points of xys.
+func smooth(xys XYs, s Smoothing) (XYs, int) {
+	if s == NoSmoothing || len(xys) < 3 {
+		return xys, 1
+	}
+	if s != Bezier {
+		for i := 1; i < len(xys); i++ {
+			if !(xys[i].X > xys[i-1].X) {
+				return xys, 1
+			}
+		}
+	}
+
+	var at func(i int, t float64) (x, y float64)
+	switch s {
+	case CubicSpline:
+		at = hermite(xys, splineSlopes(xys))
+	case MonotoneCubic:
+		at = hermite(xys, monotoneSlopes(xys))
+	case Bezier:
+		at = catmullRom(xys)
+	default:
+		panic("plotter: unknown smoothing")
+	}
+	out := make(XYs, 0, (len(xys)-1)*smoothSteps+1)
+	for i := 0; i < len(xys)-1; i++ {
+		for j := 0; j < smoothSteps; j++ {
+			x, y := at(i, float64(j)/smoothSteps)
+			out = append(out, struct{ X, Y float64 }{X: x, Y: y})
+		}
+	}
+	return append(out, xys[len(xys)-1]), smoothSteps
+}
+
+// hermite returns the cubic Hermite interpolant of y(x) through
+// xys with the slopes m at the points, as a function of the
+// interval i and the fraction t of the way along it.
+func hermite(xys XYs, m []float64) func(i int, t float64) (x, y float64) {
+	return func(i int, t float64) (x, y float64) {
+		p, q := xys[i], xys[i+1]
+		h := q.X - p.X
+		t2, t3 := t*t, t*t*t
+		y = (2*t3-3*t2+1)*p.Y + (t3-2*t2+t)*h*m[i] + (-2*t3+3*t2)*q.Y + (t3-t2)*h*m[i+1]
+		return p.X + t*h, y
+	}
+}
+
+// splineSlopes returns the slopes at the points of xys of
+// the natural cubic spline through them, whose second
+// derivative is zero at the ends.
+func splineSlopes(xys XYs) []float64 {
+	n := len(xys)
+	h := make([]float64, n-1)
+	d := make([]float64, n-1)
+	for i := range h {
+		h[i] = xys[i+1].X - xys[i].X
+		d[i] = (xys[i+1].Y - xys[i].Y) / h[i]
+	}
+
+	// Solve the tridiagonal system for the slopes
+	// by the Thomas algorithm.
+	diag := make([]float64, n)
+	upper := make([]float64, n)
+	rhs := make([]float64, n)
+	diag[0], upper[0], rhs[0] = 2, 1, 3*d[0]
+	for i := 1; i < n-1; i++ {
+		diag[i] = 2 * (h[i-1] + h[i])
+		upper[i] = h[i-1]
+		rhs[i] = 3 * (h[i]*d[i-1] + h[i-1]*d[i])
+	}
+	diag[n-1], rhs[n-1] = 2, 3*d[n-2]
+	lower := func(i int) float64 {
+		if i == n-1 {
+			return 1
+		}
+		return h[i]
+	}
+	for i := 1; i < n; i++ {
+		w := lower(i) / diag[i-1]
+		diag[i] -= w * upper[i-1]
+		rhs[i] -= w * rhs[i-1]
+	}
+	m := make([]float64, n)
+	m[n-1] = rhs[n-1] / diag[n-1]
+	for i := n - 2; i >= 0; i-- {
+		m[i] = (rhs[i] - upper[i]*m[i+1]) / diag[i]
+	}
+	return m
+}
+
+// monotoneSlopes returns the slopes at the points of xys
+// of the monotone cubic interpolant of Fritsch and Carlson.
+func monotoneSlopes(xys XYs) []float64 {
+	n := len(xys)
+	d := make([]float64, n-1)
+	for i := range d {
+		d[i] = (xys[i+1].Y - xys[i].Y) / (xys[i+1].X - xys[i].X)
+	}
+	m := make([]float64, n)
+	m[0], m[n-1] = d[0], d[n-2]
+	for i := 1; i < n-1; i++ {
+		if d[i-1]*d[i] <= 0 {
+			// The points turn at a local extremum.
+			m[i] = 0
+		} else {
+			m[i] = (d[i-1] + d[i]) / 2
+		}
+	}
+	for i, di := range d {
+		if di == 0 {
+			m[i], m[i+1] = 0, 0
+			continue
+		}
+		a, b := m[i]/di, m[i+1]/di
+		if s := a*a + b*b; s > 9 {
+			tau := 3 / math.Sqrt(s)
+			m[i], m[i+1] = tau*a*di, tau*b*di
+		}
+	}
+	return m
+}
+
+// catmullRom returns the cubic Bézier curves through xys with
+// the control points of a Catmull-Rom spline, as a function of
+// the interval i and the fraction t of the way along it.
+func catmullRom(xys XYs) func(i int, t float64) (x, y float64) {
+	last := len(xys) - 1
+	pt := func(i int) (x, y float64) {
+		if i < 0 {
+			i = 0
+		}
+		if i > last {
+			i = last
+		}
+		return xys[i].X, xys[i].Y
+	}
+	return func(i int, t float64) (x, y float64) {
+		x0, y0 := pt(i - 1)
+		x1, y1 := pt(i)
+		x2, y2 := pt(i + 1)
+		x3, y3 := pt(i + 2)
+		c1x, c1y := x1+(x2-x0)/6, y1+(y2-y0)/6
+		c2x, c2y := x2-(x3-x1)/6, y2-(y3-y1)/6
+		u := 1 - t
+		b0, b1, b2, b3 := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		return b0*x1 + b1*c1x + b2*c2x + b3*x2, b0*y1 + b1*c1y + b2*c2y + b3*y2
+	}
+}
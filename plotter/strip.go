@@ -0,0 +1,160 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Jitter is the method by which the points
+// of a Strip are spread across its width.
+type Jitter int
+
+const (
+	// UniformJitter spreads the points at uniformly
+	// distributed random offsets across the width.
+	UniformJitter Jitter = iota
+
+	// SwarmJitter places each point at the offset
+	// nearest the center at which it does not overlap
+	// the other points, as in a beeswarm plot, so
+	// that the shape of the strip follows the
+	// distribution of the values.
+	SwarmJitter
+)
+
+// Strip implements the Plotter interface, drawing a glyph
+// for each of a set of values of a category, spread across
+// a strip at the location of the category so that equal and
+// close values may be told apart. Strips may be drawn on
+// their own, or with the box plots of the same values at the
+// same locations.
+type Strip struct {
+	// Values is a copy of the values of the strip.
+	Values
+
+	// Location is the location of the strip along its axis.
+	Location float64
+
+	// Width is the width of the strip across which
+	// the points are spread.
+	Width vg.Length
+
+	// Jitter is the method by which the
+	// points are spread across the strip.
+	Jitter Jitter
+
+	// Seed is the seed of the random offsets
+	// of UniformJitter, which are the same
+	// each time the strip is drawn.
+	Seed int64
+
+	// GlyphStyle is the style of the points.
+	draw.GlyphStyle
+
+	// Horizontal dictates whether the Strip should be
+	// in the vertical (default) or horizontal direction.
+	Horizontal bool
+}
+
+// NewStrip returns a Strip of the given width of the
+// values at the location loc, with uniform jitter.
+func NewStrip(w vg.Length, loc float64, values Valuer) (*Strip, error) {
+	if w < 0 {
+		return nil, errors.New("Negative strip width")
+	}
+	vs, err := CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	return &Strip{
+		Values:     vs,
+		Location:   loc,
+		Width:      w,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// offsets returns the offsets across the strip of
+// points at the given positions along it.
+func (s *Strip) offsets(pos []vg.Length) []vg.Length {
+	if s.Jitter == SwarmJitter {
+		return swarm(pos, 2*s.Radius, s.Width/2)
+	}
+	rnd := rand.New(rand.NewSource(s.Seed))
+	off := make([]vg.Length, len(pos))
+	for i := range off {
+		off[i] = vg.Length(rnd.Float64()-0.5) * s.Width
+	}
+	return off
+}
+
+// Plot implements the Plotter interface.
+func (s *Strip) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	tr, loc := trY, trX(s.Location)
+	if s.Horizontal {
+		tr, loc = trX, trY(s.Location)
+	}
+	pos := make([]vg.Length, len(s.Values))
+	for i, v := range s.Values {
+		pos[i] = tr(v)
+	}
+	// The points spread beyond the edge of the canvas at
+	// the first and last locations, so they are clipped
+	// by their values only, as the outside points of a
+	// BoxPlot are.
+	for i, off := range s.offsets(pos) {
+		if s.Horizontal {
+			if c.ContainsX(pos[i]) {
+				c.DrawGlyphNoClip(s.GlyphStyle, vg.Point{X: pos[i], Y: loc + off})
+			}
+			continue
+		}
+		if c.ContainsY(pos[i]) {
+			c.DrawGlyphNoClip(s.GlyphStyle, vg.Point{X: loc + off, Y: pos[i]})
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (s *Strip) DataRange() (xmin, xmax, ymin, ymax float64) {
+	min, max := Range(s.Values)
+	if s.Horizontal {
+		return min, max, s.Location, s.Location
+	}
+	return s.Location, s.Location, min, max
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the points spread across the strip.
+func (s *Strip) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	r := s.GlyphStyle.Rectangle()
+	half := s.Width / 2
+	bs := make([]plot.GlyphBox, len(s.Values))
+	for i, v := range s.Values {
+		if s.Horizontal {
+			bs[i].X = plt.X.Norm(v)
+			bs[i].Y = plt.Y.Norm(s.Location)
+			r.Min.Y, r.Max.Y = -half-s.Radius, half+s.Radius
+		} else {
+			bs[i].X = plt.X.Norm(s.Location)
+			bs[i].Y = plt.Y.Norm(v)
+			r.Min.X, r.Max.X = -half-s.Radius, half+s.Radius
+		}
+		bs[i].Rectangle = r
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s *Strip) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(s.GlyphStyle, c.Center())
+}
@@ -0,0 +1,102 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ExampleStrip draws the observations of three groups as
+// strips over their box plots, spread by uniform jitter
+// and, for the last group, as a swarm.
+func ExampleStrip() {
+	rnd := rand.New(rand.NewSource(1))
+	groups := make([]Values, 3)
+	for i := range groups {
+		groups[i] = make(Values, 40)
+		for j := range groups[i] {
+			groups[i][j] = float64(i) + rnd.NormFloat64()
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Strips"
+	p.Y.Label.Text = "Value"
+	for i, g := range groups {
+		b, err := NewBoxPlot(vg.Points(30), float64(i), g)
+		if err != nil {
+			log.Panic(err)
+		}
+		b.GlyphStyle.Radius = 0
+		s, err := NewStrip(vg.Points(24), float64(i), g)
+		if err != nil {
+			log.Panic(err)
+		}
+		s.Shape = draw.CircleGlyph{}
+		s.Radius = vg.Points(2)
+		if i == 2 {
+			s.Jitter = SwarmJitter
+		}
+		p.Add(b, s)
+	}
+	p.NominalX("Uniform", "Uniform", "Swarm")
+
+	err = p.Save(250, 250, "testdata/strip.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestStrip(t *testing.T) {
+	checkPlot(ExampleStrip, t, "strip.png")
+}
+
+func TestStripOffsets(t *testing.T) {
+	s, err := NewStrip(vg.Points(10), 0, Values{1, 1, 1, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pos := []vg.Length{0, 0, 1, 10}
+	off := s.offsets(pos)
+	for i, o := range off {
+		if math.Abs(float64(o)) > 5 {
+			t.Errorf("uniform offset %d outside of strip: got:%v", i, o)
+		}
+	}
+	for i, o := range s.offsets(pos) {
+		if o != off[i] {
+			t.Errorf("uniform offset %d not repeated: got:%v want:%v", i, o, off[i])
+		}
+	}
+
+	s.Jitter = SwarmJitter
+	s.Radius = 1
+	off = s.offsets(pos)
+	for i := range pos {
+		for j := i + 1; j < len(pos); j++ {
+			dx, dy := float64(off[i]-off[j]), float64(pos[i]-pos[j])
+			if math.Hypot(dx, dy) < 2-1e-6 {
+				t.Errorf("swarm points %d and %d overlap: offsets %v and %v", i, j, off[i], off[j])
+			}
+		}
+	}
+	if off[3] != 0 {
+		t.Errorf("isolated swarm point off the center: got:%v", off[3])
+	}
+
+	if _, err := NewStrip(-1, 0, Values{1}); err == nil {
+		t.Error("expected error for negative width")
+	}
+}
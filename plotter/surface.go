@@ -0,0 +1,293 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Camera is the view of three dimensional data drawn
+// on the two dimensional canvas of a plot. The data are
+// scaled to fill a unit cube centred on the origin, which
+// is viewed from the direction given by the azimuth and
+// the elevation.
+//
+// The X and Y axes of a plot of three dimensional data
+// are the coordinates of the projected data, which have
+// no meaning of their own, so the axes are usually hidden
+// with the HideAxes method of the plot.
+type Camera struct {
+	// Azimuth is the angle of the viewer in degrees
+	// anticlockwise about the Z axis from the negative
+	// Y axis, so that, at an azimuth of zero, the X axis
+	// runs from left to right across the view.
+	Azimuth float64
+
+	// Elevation is the angle of the viewer in degrees
+	// above the X-Y plane, so that, at an elevation of
+	// 90, the data are viewed from above.
+	Elevation float64
+
+	// Distance is the distance of the viewer from the
+	// centre of the cube, in units of its sides, for
+	// a perspective projection. The data are drawn in
+	// an orthographic projection, as if viewed from
+	// afar, if Distance is zero. Distance must be
+	// otherwise greater than the half diagonal of the
+	// cube, about 0.87.
+	Distance float64
+}
+
+// DefaultCamera is the default Camera
+// of three dimensional plotters.
+var DefaultCamera = Camera{Azimuth: 30, Elevation: 30}
+
+// projector projects the points of data
+// within a box in the view of a Camera.
+type projector struct {
+	mid, span          [3]float64
+	sinAz, cosAz       float64
+	sinEl, cosEl, dist float64
+}
+
+// newProjector returns a projector of the points within the
+// box given by the ranges of their X, Y and Z coordinates.
+func newProjector(cam Camera, box [3][2]float64) projector {
+	p := projector{dist: cam.Distance}
+	for i, r := range box {
+		p.mid[i] = (r[0] + r[1]) / 2
+		p.span[i] = r[1] - r[0]
+		if !(p.span[i] > 0) {
+			p.span[i] = 1
+		}
+	}
+	p.sinAz, p.cosAz = math.Sincos(cam.Azimuth * math.Pi / 180)
+	p.sinEl, p.cosEl = math.Sincos(cam.Elevation * math.Pi / 180)
+	return p
+}
+
+// project returns the coordinates of the point (x, y, z) in the
+// view, and its depth, which is greater for points further from
+// the viewer.
+func (p projector) project(x, y, z float64) (vx, vy, depth float64) {
+	u := (x - p.mid[0]) / p.span[0]
+	v := (y - p.mid[1]) / p.span[1]
+	w := (z - p.mid[2]) / p.span[2]
+
+	// Turn the cube against the viewer about the Z axis,
+	// then tilt it to the elevation of the viewer.
+	vx = u*p.cosAz + v*p.sinAz
+	along := v*p.cosAz - u*p.sinAz
+	vy = along*p.sinEl + w*p.cosEl
+	depth = along*p.cosEl - w*p.sinEl
+
+	if p.dist > 0 {
+		f := p.dist / (p.dist + depth)
+		vx, vy = vx*f, vy*f
+	}
+	return vx, vy, depth
+}
+
+// dataRange returns the range of the view of the box,
+// the ranges of the views of its corners.
+func (p projector) dataRange(box [3][2]float64) (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for i := 0; i < 8; i++ {
+		x, y, _ := p.project(box[0][i&1], box[1][i>>1&1], box[2][i>>2&1])
+		xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+		ymin, ymax = math.Min(ymin, y), math.Max(ymax, y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Surface implements the Plotter interface, drawing
+// the values of a grid as the heights of a surface in
+// a three dimensional view. The surface is drawn as a
+// facet between each four neighbouring grid points,
+// colored by the mean of their values, and the facets
+// are drawn from the furthest to the nearest, so that
+// the nearer hide those behind them.
+type Surface struct {
+	// GridXYZ holds the heights of the surface.
+	// Facets with NaN corners are not drawn.
+	GridXYZ GridXYZ
+
+	// Camera is the view of the surface.
+	Camera
+
+	// ColorMap is used to color the facets by their
+	// values, scaled so that the least value of the
+	// grid maps to the minimum of the ColorMap and
+	// the greatest to its maximum. If ColorMap is
+	// nil, the facets are filled with Color.
+	ColorMap palette.ColorMap
+
+	// Color is the fill color of the facets if
+	// ColorMap is nil. If Color is also nil, the
+	// facets are not filled, leaving the wireframe.
+	Color color.Color
+
+	// Wireframe is the style of the edges of the
+	// facets. If its Color is nil, the edges are
+	// not drawn.
+	Wireframe draw.LineStyle
+}
+
+// NewSurface returns a Surface of the values of g,
+// colored by cmap, in the default view and with no
+// wireframe.
+func NewSurface(g GridXYZ, cmap palette.ColorMap) *Surface {
+	return &Surface{
+		GridXYZ:  g,
+		Camera:   DefaultCamera,
+		ColorMap: cmap,
+		Color:    color.Gray{Y: 192},
+	}
+}
+
+// box returns the ranges of the coordinates and
+// the values of the grid, ignoring NaN values.
+func (s *Surface) box() [3][2]float64 {
+	c, r := s.GridXYZ.Dims()
+	box := [3][2]float64{
+		{math.Inf(1), math.Inf(-1)},
+		{math.Inf(1), math.Inf(-1)},
+		{math.Inf(1), math.Inf(-1)},
+	}
+	for i := 0; i < c; i++ {
+		x := s.GridXYZ.X(i)
+		box[0][0], box[0][1] = math.Min(box[0][0], x), math.Max(box[0][1], x)
+	}
+	for j := 0; j < r; j++ {
+		y := s.GridXYZ.Y(j)
+		box[1][0], box[1][1] = math.Min(box[1][0], y), math.Max(box[1][1], y)
+		for i := 0; i < c; i++ {
+			z := s.GridXYZ.Z(i, j)
+			if math.IsNaN(z) {
+				continue
+			}
+			box[2][0], box[2][1] = math.Min(box[2][0], z), math.Max(box[2][1], z)
+		}
+	}
+	if box[2][0] > box[2][1] {
+		box[2] = [2]float64{0, 0}
+	}
+	return box
+}
+
+// facet is a facet of a surface in a view.
+type facet struct {
+	corners [4]vg.Point
+	depth   float64
+	z       float64
+}
+
+// byDepth sorts facets from the
+// furthest to the nearest.
+type byDepth []facet
+
+func (f byDepth) Len() int           { return len(f) }
+func (f byDepth) Less(i, j int) bool { return f[i].depth > f[j].depth }
+func (f byDepth) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+
+// Plot implements the Plotter interface.
+func (s *Surface) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	box := s.box()
+	p := newProjector(s.Camera, box)
+
+	cols, rows := s.GridXYZ.Dims()
+	var facets []facet
+	for j := 0; j < rows-1; j++ {
+		for i := 0; i < cols-1; i++ {
+			var f facet
+			ok := true
+			for k, d := range [4][2]int{{0, 0}, {1, 0}, {1, 1}, {0, 1}} {
+				x, y := s.GridXYZ.X(i+d[0]), s.GridXYZ.Y(j+d[1])
+				z := s.GridXYZ.Z(i+d[0], j+d[1])
+				if math.IsNaN(z) {
+					ok = false
+					break
+				}
+				vx, vy, depth := p.project(x, y, z)
+				f.corners[k] = vg.Point{X: trX(vx), Y: trY(vy)}
+				f.depth += depth / 4
+				f.z += z / 4
+			}
+			if ok {
+				facets = append(facets, f)
+			}
+		}
+	}
+	sort.Stable(byDepth(facets))
+
+	for _, f := range facets {
+		var pa vg.Path
+		pa.Move(f.corners[0])
+		for _, pt := range f.corners[1:] {
+			pa.Line(pt)
+		}
+		pa.Close()
+		if col := s.color(f.z, box[2]); col != nil {
+			c.SetColor(col)
+			c.Fill(pa)
+		}
+		if s.Wireframe.Color != nil && s.Wireframe.Width > 0 {
+			c.SetLineStyle(s.Wireframe)
+			c.Stroke(pa)
+		}
+	}
+}
+
+// color returns the fill color of a facet of
+// value z, for a grid of values in the range r.
+func (s *Surface) color(z float64, r [2]float64) color.Color {
+	if s.ColorMap == nil {
+		return s.Color
+	}
+	t := 0.5
+	if r[1] > r[0] {
+		t = (z - r[0]) / (r[1] - r[0])
+	}
+	lo, hi := s.ColorMap.Min(), s.ColorMap.Max()
+	clr, err := s.ColorMap.At(math.Max(lo, math.Min(hi, lo+t*(hi-lo))))
+	if err != nil {
+		return s.Color
+	}
+	return clr
+}
+
+// DataRange implements the DataRange interface,
+// returning the range of the view of the box
+// holding the surface.
+func (s *Surface) DataRange() (xmin, xmax, ymin, ymax float64) {
+	box := s.box()
+	return newProjector(s.Camera, box).dataRange(box)
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s *Surface) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	if col := s.color(0.5, [2]float64{0, 1}); col != nil {
+		c.FillPolygon(col, c.ClipPolygonY(pts))
+	}
+	if s.Wireframe.Color != nil && s.Wireframe.Width > 0 {
+		pts = append(pts, pts[0])
+		c.StrokeLines(s.Wireframe, c.ClipLinesY(pts)...)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleSurface draws a ripple as a surface colored
+// by its height, with a wireframe, in perspective.
+func ExampleSurface() {
+	const n = 25
+	m := mat64.NewDense(n, n, nil)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			x, y := float64(c-n/2)/3, float64(r-n/2)/3
+			d := math.Hypot(x, y)
+			m.Set(r, c, math.Cos(2*d)*math.Exp(-d/3))
+		}
+	}
+
+	s := NewSurface(unitGrid{m}, palette.Linear(palette.Heat(16, 1)))
+	s.Distance = 2
+	s.Wireframe.Color = color.Gray{Y: 64}
+	s.Wireframe.Width = vg.Points(0.25)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Ripple"
+	p.HideAxes()
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/surface.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSurface(t *testing.T) {
+	checkPlot(ExampleSurface, t, "surface.png")
+}
+
+func TestProjector(t *testing.T) {
+	box := [3][2]float64{{0, 2}, {0, 4}, {-1, 1}}
+	for _, test := range []struct {
+		cam           Camera
+		x, y, z       float64
+		vx, vy, depth float64
+	}{
+		// From the front, X runs across and Z up the view,
+		// and points of greater Y are further away.
+		{cam: Camera{}, x: 2, y: 4, z: 1, vx: 0.5, vy: 0.5, depth: 0.5},
+		// From above, Y runs up the view, and points
+		// of greater Z are nearer.
+		{cam: Camera{Elevation: 90}, x: 2, y: 4, z: 1, vx: 0.5, vy: 0.5, depth: -0.5},
+		// From the east, Y runs across the view, and
+		// points of greater X are nearer.
+		{cam: Camera{Azimuth: 90}, x: 2, y: 0, z: 0, vx: -0.5, vy: 0, depth: -0.5},
+		// In perspective, further points are
+		// nearer the centre of the view.
+		{cam: Camera{Distance: 1.5}, x: 2, y: 4, z: 1, vx: 0.375, vy: 0.375, depth: 0.5},
+		{cam: Camera{Distance: 1.5}, x: 2, y: 0, z: 1, vx: 0.75, vy: 0.75, depth: -0.5},
+	} {
+		p := newProjector(test.cam, box)
+		vx, vy, depth := p.project(test.x, test.y, test.z)
+		if math.Abs(vx-test.vx) > 1e-12 || math.Abs(vy-test.vy) > 1e-12 || math.Abs(depth-test.depth) > 1e-12 {
+			t.Errorf("unexpected projection of (%v, %v, %v) by %+v: got:(%v, %v, %v) want:(%v, %v, %v)",
+				test.x, test.y, test.z, test.cam, vx, vy, depth, test.vx, test.vy, test.depth)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// StateInterval is an interval of time during
+// which an entity, such as a service, is in a state,
+// such as "up" or "down".
+type StateInterval struct {
+	// Entity is the name of the entity.
+	Entity string
+
+	// State is the name of the state.
+	State string
+
+	// Start and End are the times at which
+	// the interval starts and ends.
+	Start, End float64
+}
+
+// StateTimeline implements the Plotter interface, drawing
+// a horizontal band for each entity, colored by its state
+// over time, in the style of an uptime or status timeline.
+//
+// The band of the entity at index i of Entities is drawn
+// at a Y value of len(Entities)-1-i, so the first entity
+// is at the top of the plot, and the names of the entities
+// may be shown on the Y axis with
+//
+//	p.NominalY(t.Labels()...)
+type StateTimeline struct {
+	// Entities holds the names of the entities,
+	// in order from the top of the plot. Intervals
+	// of entities that are not in Entities are
+	// not drawn.
+	Entities []string
+
+	// States holds the names of the states, in
+	// the order in which they take the colors of
+	// the palette and appear in the legend.
+	States []string
+
+	// Intervals holds the intervals of the entities,
+	// ordered by entity and start time, with adjacent
+	// intervals in the same state merged.
+	Intervals []StateInterval
+
+	// Palette is the palette used to color the states.
+	// Colors are applied to each state in order, modulo
+	// the number of colors. If Palette is nil, states
+	// are filled with gray.
+	Palette palette.Palette
+
+	// Width is the height of the bands.
+	Width vg.Length
+
+	// LineStyle is the style of the outline of
+	// each interval. If its Color is nil, no
+	// outlines are drawn.
+	LineStyle draw.LineStyle
+}
+
+// NewStateTimeline returns a state timeline of the given
+// intervals. Entities and states are ordered by their
+// first appearance in ivs. Intervals of an entity in the
+// same state that overlap or touch are merged into one.
+//
+// By default, the states are colored in order from green
+// to red, so states given from best to worst, such as
+// "up", "degraded" and "down", take the familiar colors.
+func NewStateTimeline(ivs []StateInterval) (*StateTimeline, error) {
+	if len(ivs) == 0 {
+		return nil, ErrNoData
+	}
+	var entities, states []string
+	entity := make(map[string]int)
+	state := make(map[string]bool)
+	for _, iv := range ivs {
+		if err := CheckFloats(iv.Start, iv.End); err != nil {
+			return nil, err
+		}
+		if iv.End < iv.Start {
+			return nil, errors.New("State interval ends before it starts")
+		}
+		if _, ok := entity[iv.Entity]; !ok {
+			entity[iv.Entity] = len(entities)
+			entities = append(entities, iv.Entity)
+		}
+		if !state[iv.State] {
+			state[iv.State] = true
+			states = append(states, iv.State)
+		}
+	}
+
+	sorted := byEntityStart{
+		ivs:    append([]StateInterval(nil), ivs...),
+		entity: entity,
+	}
+	sort.Stable(sorted)
+	var merged []StateInterval
+	for _, iv := range sorted.ivs {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if last.Entity == iv.Entity && last.State == iv.State && iv.Start <= last.End {
+				last.End = math.Max(last.End, iv.End)
+				continue
+			}
+		}
+		merged = append(merged, iv)
+	}
+
+	n := len(states)
+	if n < 2 {
+		n = 2
+	}
+	return &StateTimeline{
+		Entities:  entities,
+		States:    states,
+		Intervals: merged,
+		Palette:   palette.Rainbow(n, palette.Green, palette.Red, 0.7, 0.85, 1),
+		Width:     vg.Points(12),
+	}, nil
+}
+
+// byEntityStart sorts intervals by the first appearance
+// of their entity, and then by start time.
+type byEntityStart struct {
+	ivs    []StateInterval
+	entity map[string]int
+}
+
+func (s byEntityStart) Len() int { return len(s.ivs) }
+func (s byEntityStart) Less(i, j int) bool {
+	ei, ej := s.entity[s.ivs[i].Entity], s.entity[s.ivs[j].Entity]
+	if ei != ej {
+		return ei < ej
+	}
+	return s.ivs[i].Start < s.ivs[j].Start
+}
+func (s byEntityStart) Swap(i, j int) { s.ivs[i], s.ivs[j] = s.ivs[j], s.ivs[i] }
+
+// Labels returns the names of the entities in order
+// of increasing Y value, for use with plot.NominalY.
+func (t *StateTimeline) Labels() []string {
+	ls := make([]string, len(t.Entities))
+	for i, e := range t.Entities {
+		ls[len(ls)-1-i] = e
+	}
+	return ls
+}
+
+// color returns the color of the named state.
+func (t *StateTimeline) color(state string) color.Color {
+	if t.Palette == nil {
+		return color.Gray{128}
+	}
+	cs := t.Palette.Colors()
+	if len(cs) == 0 {
+		return color.Gray{128}
+	}
+	for k, s := range t.States {
+		if s == state {
+			return cs[k%len(cs)]
+		}
+	}
+	return color.Gray{128}
+}
+
+// Plot implements the plot.Plotter interface.
+func (t *StateTimeline) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	ys := make(map[string]vg.Length, len(t.Entities))
+	for i, e := range t.Entities {
+		ys[e] = trY(float64(len(t.Entities) - 1 - i))
+	}
+	for _, iv := range t.Intervals {
+		y, ok := ys[iv.Entity]
+		if !ok || !c.ContainsY(y) {
+			continue
+		}
+		ymin, ymax := y-t.Width/2, y+t.Width/2
+		xmin, xmax := trX(iv.Start), trX(iv.End)
+		pts := []vg.Point{{X: xmin, Y: ymin}, {X: xmin, Y: ymax}, {X: xmax, Y: ymax}, {X: xmax, Y: ymin}}
+		c.FillPolygon(t.color(iv.State), c.ClipPolygonX(pts))
+		if t.LineStyle.Color != nil {
+			pts = append(pts, pts[0])
+			c.StrokeLines(t.LineStyle, c.ClipLinesX(pts)...)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (t *StateTimeline) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	for _, iv := range t.Intervals {
+		xmin = math.Min(xmin, iv.Start)
+		xmax = math.Max(xmax, iv.End)
+	}
+	return xmin, xmax, 0, float64(len(t.Entities) - 1)
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the width of the bands.
+func (t *StateTimeline) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(t.Entities))
+	for i := range t.Entities {
+		bs[i].Y = plt.Y.Norm(float64(i))
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{Y: -t.Width / 2},
+			Max: vg.Point{Y: t.Width / 2},
+		}
+	}
+	return bs
+}
+
+// StateThumbnailers returns the names of the states and
+// a Thumbnailer drawing a box in the color of each state,
+// for adding a legend entry for each state to a plot.
+func (t *StateTimeline) StateThumbnailers() (states []string, thumbnailers []plot.Thumbnailer) {
+	states = append([]string(nil), t.States...)
+	thumbnailers = make([]plot.Thumbnailer, len(t.States))
+	for k, s := range t.States {
+		thumbnailers[k] = stateThumbnailer{color: t.color(s), LineStyle: t.LineStyle}
+	}
+	return states, thumbnailers
+}
+
+// stateThumbnailer implements the Thumbnailer
+// interface for the states of a timeline.
+type stateThumbnailer struct {
+	color color.Color
+	draw.LineStyle
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface.
+func (t stateThumbnailer) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(t.color, c.ClipPolygonY(pts))
+	if t.LineStyle.Color != nil {
+		pts = append(pts, pts[0])
+		c.StrokeLines(t.LineStyle, c.ClipLinesY(pts)...)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleStateTimeline draws the status of four
+// services, checked every hour, over a day.
+func ExampleStateTimeline() {
+	status := map[string]string{
+		"api":      "uuuuuuuuuddduuuuuuuuuuuu",
+		"database": "uuuuuuuuuuuuuuxxuuuuuuuu",
+		"queue":    "uuudddduuuuuuuuuuuuduuuu",
+		"web":      "uuuuuuuuuddduuuuuuuuuuuu",
+	}
+	names := map[byte]string{'u': "up", 'd': "degraded", 'x': "down"}
+
+	var ivs []StateInterval
+	for _, svc := range []string{"web", "api", "database", "queue"} {
+		for h := range status[svc] {
+			ivs = append(ivs, StateInterval{
+				Entity: svc,
+				State:  names[status[svc][h]],
+				Start:  float64(h),
+				End:    float64(h + 1),
+			})
+		}
+	}
+
+	t, err := NewStateTimeline(ivs)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Service status"
+	p.X.Label.Text = "Time (h)"
+	p.Add(t)
+	p.NominalY(t.Labels()...)
+	states, thumbs := t.StateThumbnailers()
+	for i, s := range states {
+		p.Legend.Add(s, thumbs[i])
+	}
+	p.Legend.Top = true
+	p.Y.Max += 1.5
+
+	err = p.Save(300, 200, "testdata/stateTimeline.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestStateTimeline(t *testing.T) {
+	checkPlot(ExampleStateTimeline, t, "stateTimeline.png")
+}
+
+func TestStateTimelineMerge(t *testing.T) {
+	tl, err := NewStateTimeline([]StateInterval{
+		{Entity: "b", State: "up", Start: 2, End: 3},
+		{Entity: "a", State: "up", Start: 1, End: 2},
+		{Entity: "b", State: "up", Start: 0, End: 2},
+		{Entity: "a", State: "down", Start: 2, End: 4},
+		{Entity: "a", State: "up", Start: 0, End: 1},
+		{Entity: "a", State: "up", Start: 5, End: 6},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []StateInterval{
+		{Entity: "b", State: "up", Start: 0, End: 3},
+		{Entity: "a", State: "up", Start: 0, End: 2},
+		{Entity: "a", State: "down", Start: 2, End: 4},
+		{Entity: "a", State: "up", Start: 5, End: 6},
+	}
+	if !reflect.DeepEqual(tl.Intervals, want) {
+		t.Errorf("unexpected intervals:\ngot: %v\nwant:%v", tl.Intervals, want)
+	}
+	if got, want := tl.Labels(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected labels: got:%v want:%v", got, want)
+	}
+	if got, want := tl.States, []string{"up", "down"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected states: got:%v want:%v", got, want)
+	}
+
+	_, err = NewStateTimeline([]StateInterval{{Start: 1, End: 0}})
+	if err == nil {
+		t.Error("expected error for interval ending before its start")
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// TreemapNode is a node of the tree drawn by a Treemap.
+type TreemapNode struct {
+	// Name is the name of the node.
+	Name string
+
+	// Value is the value of a leaf node, such as
+	// the size of a file. The value of a node with
+	// children is the sum of the values of its
+	// children, and its Value field is ignored.
+	Value float64
+
+	// Children holds the children of the node.
+	Children []TreemapNode
+}
+
+// Total returns the value of the node.
+func (n *TreemapNode) Total() float64 {
+	if len(n.Children) == 0 {
+		return n.Value
+	}
+	var sum float64
+	for i := range n.Children {
+		sum += n.Children[i].Total()
+	}
+	return sum
+}
+
+// Treemap implements the Plotter interface, drawing a tree
+// of values as nested rectangles. Each node is drawn as a
+// rectangle with an area proportional to its value, with the
+// rectangles of its children laid out inside it below its
+// label. The rectangles of the children of a node are laid
+// out by the squarified algorithm, which keeps their aspect
+// ratios close to one.
+//
+// The treemap fills the X and Y range from zero to one, so
+// the axes of the plot are usually hidden.
+type Treemap struct {
+	// Root is the root node of the tree.
+	Root TreemapNode
+
+	// Palette is used to fill the rectangles by their
+	// depth in the tree, with the root at depth zero.
+	// Colors are reused when the tree is deeper than
+	// the palette. If Palette is nil, rectangles are
+	// filled with gray.
+	Palette palette.Palette
+
+	// LineStyle is the style of the outlines
+	// of the rectangles.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the labels of the nodes,
+	// which are drawn at the top left of their rectangles.
+	// Labels are shortened to fit the width of their
+	// rectangle, and omitted from rectangles that are
+	// too small.
+	TextStyle draw.TextStyle
+
+	// Label returns the label of a node given its name
+	// and value. If Label is nil, nodes are labeled with
+	// their names.
+	Label func(name string, value float64) string
+
+	// Padding is the distance between the edges of a
+	// rectangle and its label and children.
+	Padding vg.Length
+
+	// MaxDepth is the depth of the deepest nodes that
+	// are drawn. If MaxDepth is zero, all nodes are
+	// drawn.
+	MaxDepth int
+}
+
+// NewTreemap returns a treemap of the tree with the
+// given root. Values of leaf nodes must not be negative.
+func NewTreemap(root TreemapNode) (*Treemap, error) {
+	if err := checkTreemap(&root); err != nil {
+		return nil, err
+	}
+	if root.Total() == 0 {
+		return nil, ErrNoData
+	}
+	fnt, err := vg.MakeFont(DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+	return &Treemap{
+		Root:      root,
+		Palette:   palette.Rainbow(6, palette.Blue, palette.Red, 0.3, 1, 1),
+		LineStyle: draw.LineStyle{Color: color.Gray{64}, Width: vg.Points(0.5)},
+		TextStyle: draw.TextStyle{Font: fnt, YAlign: draw.YTop},
+		Padding:   vg.Points(2),
+	}, nil
+}
+
+// checkTreemap returns an error if the value of
+// a leaf of the tree rooted at n is invalid.
+func checkTreemap(n *TreemapNode) error {
+	if len(n.Children) == 0 {
+		if err := CheckFloats(n.Value); err != nil {
+			return err
+		}
+		if n.Value < 0 {
+			return errors.New("Negative treemap node value")
+		}
+	}
+	for i := range n.Children {
+		if err := checkTreemap(&n.Children[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (t *Treemap) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	r := vg.Rectangle{
+		Min: vg.Point{X: trX(0), Y: trY(0)},
+		Max: vg.Point{X: trX(1), Y: trY(1)},
+	}
+	t.drawNode(c, &t.Root, r, 0)
+}
+
+// drawNode draws the node n, at the given
+// depth, in the rectangle r.
+func (t *Treemap) drawNode(c draw.Canvas, n *TreemapNode, r vg.Rectangle, depth int) {
+	size := r.Size()
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+	pts := []vg.Point{r.Min, {X: r.Min.X, Y: r.Max.Y}, r.Max, {X: r.Max.X, Y: r.Min.Y}}
+	c.FillPolygon(t.color(depth), c.ClipPolygonXY(pts))
+	pts = append(pts, pts[0])
+	c.StrokeLines(t.LineStyle, c.ClipLinesXY(pts)...)
+
+	total := n.Total()
+	label := n.Name
+	if t.Label != nil {
+		label = t.Label(n.Name, total)
+	}
+	header := t.TextStyle.Height(label) + 2*t.Padding
+	if header <= size.Y {
+		if s := elide(t.TextStyle, label, size.X-2*t.Padding); s != "" {
+			c.FillText(t.TextStyle, vg.Point{X: r.Min.X + t.Padding, Y: r.Max.Y - t.Padding}, s)
+		}
+	}
+
+	if len(n.Children) == 0 || t.MaxDepth > 0 && depth >= t.MaxDepth-1 {
+		return
+	}
+	inner := vg.Rectangle{
+		Min: vg.Point{X: r.Min.X + t.Padding, Y: r.Min.Y + t.Padding},
+		Max: vg.Point{X: r.Max.X - t.Padding, Y: r.Max.Y - header},
+	}
+	if inner.Max.X <= inner.Min.X || inner.Max.Y <= inner.Min.Y {
+		return
+	}
+	vs := make([]float64, len(n.Children))
+	for i := range n.Children {
+		vs[i] = n.Children[i].Total()
+	}
+	for i, cr := range squarify(vs, inner) {
+		t.drawNode(c, &n.Children[i], cr, depth+1)
+	}
+}
+
+// color returns the fill color of
+// rectangles at the given depth.
+func (t *Treemap) color(depth int) color.Color {
+	if t.Palette == nil {
+		return color.Gray{192}
+	}
+	cs := t.Palette.Colors()
+	if len(cs) == 0 {
+		return color.Gray{192}
+	}
+	return cs[depth%len(cs)]
+}
+
+// squarify returns rectangles within r, with areas
+// proportional to the values vs, laid out by the
+// squarified treemap algorithm of Bruls, Huizing and
+// van Wijk. The rectangle of vs[i] is returned at
+// index i. Values of zero get an empty rectangle.
+func squarify(vs []float64, r vg.Rectangle) []vg.Rectangle {
+	rects := make([]vg.Rectangle, len(vs))
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	if sum <= 0 {
+		return rects
+	}
+
+	// Lay out the values in order of decreasing size,
+	// as areas filling the rectangle.
+	size := r.Size()
+	scale := float64(size.X) * float64(size.Y) / sum
+	var order []int
+	for i, v := range vs {
+		if v > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.Sort(byDecreasingValue{order: order, vs: vs})
+
+	free := r
+	for len(order) > 0 {
+		fs := free.Size()
+		short := math.Min(float64(fs.X), float64(fs.Y))
+
+		// Add areas to the row while the worst
+		// aspect ratio in the row improves.
+		n := 1
+		worst := worstRatio(vs, order[:1], scale, short)
+		for n < len(order) {
+			w := worstRatio(vs, order[:n+1], scale, short)
+			if w > worst {
+				break
+			}
+			worst = w
+			n++
+		}
+
+		// Place the row along the short side
+		// of the free rectangle.
+		var rowArea float64
+		for _, i := range order[:n] {
+			rowArea += vs[i] * scale
+		}
+		thick := vg.Length(rowArea / short)
+		if fs.X >= fs.Y {
+			y := free.Max.Y
+			for _, i := range order[:n] {
+				h := vg.Length(vs[i] * scale / float64(thick))
+				rects[i] = vg.Rectangle{
+					Min: vg.Point{X: free.Min.X, Y: y - h},
+					Max: vg.Point{X: free.Min.X + thick, Y: y},
+				}
+				y -= h
+			}
+			free.Min.X += thick
+		} else {
+			x := free.Min.X
+			for _, i := range order[:n] {
+				w := vg.Length(vs[i] * scale / float64(thick))
+				rects[i] = vg.Rectangle{
+					Min: vg.Point{X: x, Y: free.Max.Y - thick},
+					Max: vg.Point{X: x + w, Y: free.Max.Y},
+				}
+				x += w
+			}
+			free.Max.Y -= thick
+		}
+		order = order[n:]
+	}
+	return rects
+}
+
+// worstRatio returns the largest aspect ratio of the
+// rectangles of a row of the values with the given
+// indices, scaled to areas, placed along a side of
+// the given length.
+func worstRatio(vs []float64, row []int, scale, side float64) float64 {
+	var sum float64
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, i := range row {
+		a := vs[i] * scale
+		sum += a
+		min = math.Min(min, a)
+		max = math.Max(max, a)
+	}
+	s2, sum2 := side*side, sum*sum
+	return math.Max(s2*max/sum2, sum2/(s2*min))
+}
+
+// byDecreasingValue sorts indices by decreasing value.
+type byDecreasingValue struct {
+	order []int
+	vs    []float64
+}
+
+func (s byDecreasingValue) Len() int           { return len(s.order) }
+func (s byDecreasingValue) Less(i, j int) bool { return s.vs[s.order[i]] > s.vs[s.order[j]] }
+func (s byDecreasingValue) Swap(i, j int)      { s.order[i], s.order[j] = s.order[j], s.order[i] }
+
+// DataRange implements the plot.DataRanger interface.
+func (t *Treemap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, 1
+}
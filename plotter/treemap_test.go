@@ -0,0 +1,114 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleTreemap draws the sizes of the
+// source files of a project.
+func ExampleTreemap() {
+	root := TreemapNode{
+		Name: "plot",
+		Children: []TreemapNode{
+			{Name: "plot.go", Value: 16},
+			{Name: "axis.go", Value: 14},
+			{Name: "plotter", Children: []TreemapNode{
+				{Name: "barchart.go", Value: 6},
+				{Name: "boxplot.go", Value: 12},
+				{Name: "contour.go", Value: 15},
+				{Name: "heat.go", Value: 5},
+				{Name: "line.go", Value: 4},
+				{Name: "scatter.go", Value: 3},
+			}},
+			{Name: "vg", Children: []TreemapNode{
+				{Name: "vg.go", Value: 5},
+				{Name: "draw", Children: []TreemapNode{
+					{Name: "canvas.go", Value: 20},
+					{Name: "text.go", Value: 8},
+				}},
+				{Name: "vgimg", Children: []TreemapNode{
+					{Name: "vgimg.go", Value: 9},
+				}},
+			}},
+		},
+	}
+	t, err := NewTreemap(root)
+	if err != nil {
+		log.Panic(err)
+	}
+	t.Label = func(name string, v float64) string {
+		return name + " " + strconv.FormatFloat(v, 'g', -1, 64) + "k"
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Source size"
+	p.Add(t)
+	p.HideAxes()
+
+	err = p.Save(5*vg.Inch, 3*vg.Inch, "testdata/treemap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTreemap(t *testing.T) {
+	checkPlot(ExampleTreemap, t, "treemap.png")
+}
+
+func TestSquarify(t *testing.T) {
+	// The example of Bruls, Huizing and van Wijk.
+	vs := []float64{6, 6, 4, 3, 2, 2, 1}
+	r := vg.Rectangle{Max: vg.Point{X: 6, Y: 4}}
+	rects := squarify(vs, r)
+
+	var area float64
+	for i, rect := range rects {
+		s := rect.Size()
+		if got := float64(s.X * s.Y); math.Abs(got-vs[i]) > 1e-9 {
+			t.Errorf("unexpected area of rectangle %d: got:%v want:%v", i, got, vs[i])
+		}
+		if rect.Min.X < r.Min.X-1e-9 || rect.Max.X > r.Max.X+1e-9 || rect.Min.Y < r.Min.Y-1e-9 || rect.Max.Y > r.Max.Y+1e-9 {
+			t.Errorf("rectangle %d outside bounds: %v", i, rect)
+		}
+		area += float64(s.X * s.Y)
+	}
+	if math.Abs(area-24) > 1e-9 {
+		t.Errorf("unexpected total area: got:%v want:24", area)
+	}
+
+	// The first row holds the two largest
+	// values, stacked in a column.
+	if rects[0].Min.X != 0 || rects[1].Min.X != 0 || rects[0].Max.X != 3 {
+		t.Errorf("unexpected first row: %v %v", rects[0], rects[1])
+	}
+
+	if rects := squarify([]float64{0, 1}, r); rects[0] != (vg.Rectangle{}) || rects[1] != r {
+		t.Errorf("unexpected layout with a zero value: %v", rects)
+	}
+}
+
+func TestTreemapTotal(t *testing.T) {
+	n := TreemapNode{Value: 100, Children: []TreemapNode{
+		{Value: 1},
+		{Value: 5, Children: []TreemapNode{{Value: 2}, {Value: 3}}},
+	}}
+	if got := n.Total(); got != 6 {
+		t.Errorf("unexpected total: got:%v want:6", got)
+	}
+	if _, err := NewTreemap(TreemapNode{Children: []TreemapNode{{Value: -1}, {Value: 2}}}); err == nil {
+		t.Error("expected error for negative value")
+	}
+}
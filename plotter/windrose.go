@@ -0,0 +1,332 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// windRoseSegments is the number of line segments
+// drawn for each ring and for the arc of each wedge.
+const windRoseSegments = 120
+
+// WindRose implements the plot.Plotter interface, drawing a
+// rose diagram, or polar histogram, of directions such as those
+// of the wind. The directions are binned into sectors, and the
+// wedge of each sector is stacked from the center with a band
+// for each class of magnitude, such as wind speed, whose radius
+// is the percentage of the observations in the sector and class.
+//
+// The directions are in degrees clockwise from north, at the
+// top, as for compass bearings. The X and Y axes of the plot
+// are normally hidden, and the plot drawn with equal X and Y
+// scales so that the rings are round.
+type WindRose struct {
+	// Percents holds, for each sector from the
+	// one centred on north, the percentage of the
+	// observations in each class of magnitude.
+	Percents [][]float64
+
+	// Bounds holds the increasing bounds of the
+	// classes of magnitude. The first class holds
+	// magnitudes less than the first bound, each
+	// following class those from its bound to the
+	// next, and the last those from the last bound.
+	Bounds []float64
+
+	// Palette holds the colors of the classes.
+	// Classes beyond the colors of the Palette
+	// are drawn with the colors taken in turn.
+	Palette palette.Palette
+
+	// Fill is the fraction of the angle of its
+	// sector spanned by each wedge.
+	Fill float64
+
+	// LineStyle is the style of the outlines of the
+	// bands. If its Color is nil, they are not drawn.
+	LineStyle draw.LineStyle
+
+	// Rings and Spokes are the styles of the rings of
+	// constant percentage and of the spokes of the
+	// compass points. Lines whose style has a nil
+	// Color are not drawn.
+	Rings, Spokes draw.LineStyle
+
+	// LabelAngle is the direction in degrees
+	// along which the rings are labeled.
+	LabelAngle float64
+
+	// TextStyle is the style of the labels of the
+	// rings and the compass points. If its Color is
+	// nil, the labels are not drawn.
+	draw.TextStyle
+}
+
+// NewWindRose returns a wind rose of the directions in degrees
+// and their magnitudes, binned into the given number of sectors
+// and into the classes of magnitude given by bounds, colored by
+// p. The outlines of the bands are not drawn.
+func NewWindRose(dirs, mags []float64, sectors int, bounds []float64, p palette.Palette) (*WindRose, error) {
+	if len(dirs) != len(mags) {
+		return nil, errors.New("Number of directions does not match the number of magnitudes")
+	}
+	if len(dirs) == 0 {
+		return nil, ErrNoData
+	}
+	if sectors <= 0 {
+		return nil, errors.New("Wind rose with non-positive number of sectors")
+	}
+	if !sort.Float64sAreSorted(bounds) {
+		return nil, errors.New("Wind rose class bounds are not increasing")
+	}
+	if err := CheckFloats(dirs...); err != nil {
+		return nil, err
+	}
+	if err := CheckFloats(mags...); err != nil {
+		return nil, err
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &WindRose{
+		Percents:   make([][]float64, sectors),
+		Bounds:     append([]float64(nil), bounds...),
+		Palette:    p,
+		Fill:       0.9,
+		Rings:      DefaultGridLineStyle,
+		Spokes:     DefaultGridLineStyle,
+		LabelAngle: 22.5,
+		TextStyle:  draw.TextStyle{Color: color.Gray{Y: 64}, Font: fnt},
+	}
+	for s := range w.Percents {
+		w.Percents[s] = make([]float64, len(bounds)+1)
+	}
+	width := 360 / float64(sectors)
+	for i, d := range dirs {
+		a := math.Mod(d+width/2, 360)
+		if a < 0 {
+			a += 360
+		}
+		s := int(a/width) % sectors
+		k := sort.Search(len(bounds), func(j int) bool { return mags[i] < bounds[j] })
+		w.Percents[s][k] += 100 / float64(len(dirs))
+	}
+	return w, nil
+}
+
+// windRosePoint returns the point of the plane at the
+// radius r in the direction theta in degrees.
+func windRosePoint(r, theta float64) (x, y float64) {
+	sin, cos := math.Sincos(theta * math.Pi / 180)
+	return r * sin, r * cos
+}
+
+// max returns the greatest total percentage of a sector.
+func (w *WindRose) max() float64 {
+	var max float64
+	for _, s := range w.Percents {
+		var sum float64
+		for _, v := range s {
+			sum += v
+		}
+		max = math.Max(max, sum)
+	}
+	return max
+}
+
+// rings returns the labeled ticks of the rings,
+// including a ring at the greatest percentage.
+func (w *WindRose) rings() []plot.Tick {
+	max := w.max()
+	var ticks []plot.Tick
+	for _, t := range (plot.DefaultTicks{}).Ticks(0, max) {
+		if t.IsMinor() || t.Value <= 0 {
+			continue
+		}
+		ticks = append(ticks, t)
+	}
+	if len(ticks) == 0 || ticks[len(ticks)-1].Value < max {
+		step := max
+		if len(ticks) > 0 {
+			step = ticks[0].Value
+		}
+		r := step * math.Ceil(max/step)
+		ticks = append(ticks, plot.Tick{Value: r, Label: fmt.Sprintf("%g", r)})
+	}
+	return ticks
+}
+
+// radius returns the radius of the outer ring.
+func (w *WindRose) radius() float64 {
+	ticks := w.rings()
+	return ticks[len(ticks)-1].Value
+}
+
+// color returns the color of class k.
+func (w *WindRose) color(k int) color.Color {
+	if w.Palette == nil {
+		return nil
+	}
+	cs := w.Palette.Colors()
+	if len(cs) == 0 {
+		return nil
+	}
+	return cs[k%len(cs)]
+}
+
+// Plot implements the plot.Plotter interface.
+func (w *WindRose) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	point := func(r, theta float64) vg.Point {
+		x, y := windRosePoint(r, theta)
+		return vg.Point{X: trX(x), Y: trY(y)}
+	}
+	arc := func(r, from, to float64) []vg.Point {
+		n := int(math.Ceil(windRoseSegments*(to-from)/360)) + 1
+		pts := make([]vg.Point, n+1)
+		for i := range pts {
+			pts[i] = point(r, from+(to-from)*float64(i)/float64(n))
+		}
+		return pts
+	}
+
+	rings := w.rings()
+	outer := rings[len(rings)-1].Value
+	if w.Spokes.Color != nil {
+		for theta := 0.0; theta < 360; theta += 45 {
+			line := []vg.Point{point(0, theta), point(outer, theta)}
+			c.StrokeLines(w.Spokes, c.ClipLinesXY(line)...)
+		}
+	}
+	if w.Rings.Color != nil {
+		for _, t := range rings {
+			c.StrokeLines(w.Rings, c.ClipLinesXY(arc(t.Value, 0, 360))...)
+		}
+	}
+
+	width := 360 / float64(len(w.Percents))
+	for s, classes := range w.Percents {
+		mid := float64(s) * width
+		from, to := mid-w.Fill*width/2, mid+w.Fill*width/2
+		var r0 float64
+		for k, v := range classes {
+			if v <= 0 {
+				continue
+			}
+			r1 := r0 + v
+			band := append(arc(r1, from, to), reversed(arc(r0, from, to))...)
+			if clr := w.color(k); clr != nil {
+				c.FillPolygon(clr, c.ClipPolygonXY(band))
+			}
+			if w.LineStyle.Color != nil && w.LineStyle.Width > 0 {
+				c.StrokeLines(w.LineStyle, c.ClipLinesXY(append(band, band[0]))...)
+			}
+			r0 = r1
+		}
+	}
+
+	if w.TextStyle.Color == nil {
+		return
+	}
+	sin, cos := math.Sincos(w.LabelAngle * math.Pi / 180)
+	sty := w.TextStyle
+	sty.XAlign = draw.XAlignment(-0.5 + 0.5*sin)
+	sty.YAlign = draw.YAlignment(-0.5 + 0.5*cos)
+	for _, t := range rings {
+		c.FillText(sty, point(t.Value, w.LabelAngle), t.Label+"%")
+	}
+	for i, name := range compassPoints {
+		pt, sty := w.compassLabel(point(outer, float64(90*i)), float64(90*i))
+		c.FillText(sty, pt, name)
+	}
+}
+
+// compassPoints are the labels of the
+// cardinal directions, from north.
+var compassPoints = []string{"N", "E", "S", "W"}
+
+// compassLabel returns the position and the style of the
+// label of the direction theta at pt on the outer ring,
+// aligned so that the label lies outside of the ring.
+func (w *WindRose) compassLabel(pt vg.Point, theta float64) (vg.Point, draw.TextStyle) {
+	sin, cos := math.Sincos(theta * math.Pi / 180)
+	sty := w.TextStyle
+	sty.XAlign = draw.XAlignment(-0.5 + 0.5*sin)
+	sty.YAlign = draw.YAlignment(-0.5 + 0.5*cos)
+	pad := sty.Font.Size / 4
+	return vg.Point{X: pt.X + vg.Length(sin)*pad, Y: pt.Y + vg.Length(cos)*pad}, sty
+}
+
+// reversed returns the points of pts in reverse order.
+func reversed(pts []vg.Point) []vg.Point {
+	r := make([]vg.Point, len(pts))
+	for i, p := range pts {
+		r[len(pts)-1-i] = p
+	}
+	return r
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (w *WindRose) DataRange() (xmin, xmax, ymin, ymax float64) {
+	r := w.radius()
+	return -r, r, -r, r
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// reserving space for the labels of the compass points.
+func (w *WindRose) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if w.TextStyle.Color == nil {
+		return nil
+	}
+	r := w.radius()
+	bs := make([]plot.GlyphBox, len(compassPoints))
+	for i, name := range compassPoints {
+		theta := float64(90 * i)
+		x, y := windRosePoint(r, theta)
+		pt, sty := w.compassLabel(vg.Point{}, theta)
+		rect := sty.Rectangle(name)
+		bs[i] = plot.GlyphBox{
+			X:         plt.X.Norm(x),
+			Y:         plt.Y.Norm(y),
+			Rectangle: vg.Rectangle{Min: rect.Min.Add(pt), Max: rect.Max.Add(pt)},
+		}
+	}
+	return bs
+}
+
+// Thumbnailers returns the labels of the classes of
+// magnitude and thumbnailers of their colors, for
+// adding a legend entry for each class.
+func (w *WindRose) Thumbnailers() (legendLabels []string, thumbnailers []plot.Thumbnailer) {
+	n := len(w.Bounds) + 1
+	legendLabels = make([]string, n)
+	thumbnailers = make([]plot.Thumbnailer, n)
+	for k := range legendLabels {
+		switch {
+		case len(w.Bounds) == 0:
+			legendLabels[k] = "all"
+		case k == 0:
+			legendLabels[k] = fmt.Sprintf("< %g", w.Bounds[0])
+		case k == n-1:
+			legendLabels[k] = fmt.Sprintf("≥ %g", w.Bounds[k-1])
+		default:
+			legendLabels[k] = fmt.Sprintf("%g to %g", w.Bounds[k-1], w.Bounds[k])
+		}
+		thumbnailers[k] = contourBandThumbnailer{Color: w.color(k)}
+	}
+	return legendLabels, thumbnailers
+}
@@ -0,0 +1,98 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleWindRose draws the directions and speeds of
+// winds blowing mostly from the south west, the faster
+// the more westerly.
+func ExampleWindRose() {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 1000
+	dirs := make([]float64, n)
+	speeds := make([]float64, n)
+	for i := range dirs {
+		dirs[i] = math.Mod(225+50*rnd.NormFloat64()+360, 360)
+		speeds[i] = math.Abs(4 + (dirs[i]-200)/20 + 2*rnd.NormFloat64())
+	}
+
+	w, err := NewWindRose(dirs, speeds, 16, []float64{2, 4, 6, 8}, palette.Heat(5, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Wind speed (m/s)"
+	p.HideAxes()
+	p.Add(w)
+	p.Legend.Add("Speed")
+	labels, thumbs := w.Thumbnailers()
+	for i := len(labels) - 1; i >= 0; i-- {
+		p.Legend.Add(labels[i], thumbs[i])
+	}
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	err = p.Save(300, 300, "testdata/windRose.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestWindRose(t *testing.T) {
+	checkPlot(ExampleWindRose, t, "windRose.png")
+}
+
+func TestWindRoseBins(t *testing.T) {
+	dirs := []float64{0, 44, 46, 359, 180, -90, 450}
+	mags := []float64{1, 5, 10, 2, 3, 12, 0}
+	w, err := NewWindRose(dirs, mags, 4, []float64{2, 10}, palette.Heat(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := 100.0 / 7
+	want := [][]float64{
+		// North holds 0°, 44°, and 359°.
+		{p, 2 * p, 0},
+		// East holds 46° and 450°.
+		{p, 0, p},
+		// South holds 180°.
+		{0, p, 0},
+		// West holds -90°.
+		{0, 0, p},
+	}
+	if !reflect.DeepEqual(w.Percents, want) {
+		t.Errorf("unexpected percentages: got:%v want:%v", w.Percents, want)
+	}
+
+	ticks := w.rings()
+	if last := ticks[len(ticks)-1].Value; last < 3*p {
+		t.Errorf("outer ring inside the longest wedge: got:%v want at least:%v", last, 3*p)
+	}
+	labels, thumbs := w.Thumbnailers()
+	if want := []string{"< 2", "2 to 10", "≥ 10"}; !reflect.DeepEqual(labels, want) || len(thumbs) != 3 {
+		t.Errorf("unexpected legend labels: got:%v want:%v", labels, want)
+	}
+
+	if _, err := NewWindRose(dirs, mags, 4, []float64{10, 2}, nil); err == nil {
+		t.Error("expected error for decreasing bounds")
+	}
+	if _, err := NewWindRose(dirs, mags[1:], 4, nil, nil); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Benchmark holds the results of the runs
+// of a Go benchmark.
+type Benchmark struct {
+	// Name is the name of the benchmark,
+	// such as "BenchmarkEncode-8".
+	Name string
+
+	// Samples holds the value measured in each
+	// run of the benchmark, by unit, such as
+	// "ns/op" or "allocs/op".
+	Samples map[string][]float64
+}
+
+// ParseBenchmarks parses the output of go test -bench,
+// returning the benchmarks in the order in which they
+// first appear. Runs of a benchmark that is repeated,
+// as with the -count flag, are collected into a single
+// Benchmark. Lines that are not benchmark results are
+// ignored.
+func ParseBenchmarks(r io.Reader) ([]Benchmark, error) {
+	var bs []Benchmark
+	idx := make(map[string]int)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		f := strings.Fields(sc.Text())
+		if len(f) < 4 || !strings.HasPrefix(f[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(f[1]); err != nil {
+			continue
+		}
+		i, ok := idx[f[0]]
+		if !ok {
+			i = len(bs)
+			idx[f[0]] = i
+			bs = append(bs, Benchmark{Name: f[0], Samples: make(map[string][]float64)})
+		}
+		for j := 2; j+1 < len(f); j += 2 {
+			v, err := strconv.ParseFloat(f[j], 64)
+			if err != nil {
+				break
+			}
+			bs[i].Samples[f[j+1]] = append(bs[i].Samples[f[j+1]], v)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// AddBenchComparison adds a comparison of the old and new
+// results of the benchmarks, measured in the given unit, to
+// a plot. Each benchmark present in both old and new is shown
+// as a row, labeled with its name on the Y axis, with the
+// old and new results drawn as points at their means and
+// lines spanning their samples. Results are scaled by the
+// mean of the old result, so that the old results are
+// centered on one and the new results show the ratio of
+// new to old. Benchmarks with an old mean of zero, such
+// as those that do not allocate, cannot be scaled and are
+// omitted.
+//
+// The change in the mean is written to the right of each
+// row, followed by the p-value of a Mann-Whitney U test
+// of the difference between the old and new samples. Changes
+// that are not significant at the 0.05 level are written
+// as "~".
+//
+// If the X axis has no label it is labeled with the unit.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddBenchComparison(plt *plot.Plot, old, new []Benchmark, unit string) error {
+	bc, err := newBenchComparison(old, new, unit)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(bc.rows))
+	for i, r := range bc.rows {
+		names[len(bc.rows)-1-i] = strings.TrimPrefix(r.name, "Benchmark")
+	}
+	plt.Add(bc)
+	plt.NominalY(names...)
+	if plt.X.Label.Text == "" {
+		plt.X.Label.Text = "new/old " + unit
+	}
+	plt.Legend.Add("old", benchThumbnailer{bc.Old})
+	plt.Legend.Add("new", benchThumbnailer{bc.New})
+	return nil
+}
+
+// newBenchComparison returns a benchmark comparison
+// plotter for AddBenchComparison.
+func newBenchComparison(old, new []Benchmark, unit string) (*benchComparison, error) {
+	fnt, err := vg.MakeFont(plotter.DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+	bc := &benchComparison{
+		Old:       draw.GlyphStyle{Color: Color(2), Radius: vg.Points(2.5), Shape: draw.CircleGlyph{}},
+		New:       draw.GlyphStyle{Color: Color(0), Radius: vg.Points(2.5), Shape: draw.CircleGlyph{}},
+		Baseline:  plotter.DefaultBaselineStyle,
+		TextStyle: draw.TextStyle{Font: fnt, YAlign: draw.YCenter},
+		Padding:   vg.Points(4),
+	}
+	for _, o := range old {
+		os := o.Samples[unit]
+		if len(os) == 0 {
+			continue
+		}
+		var ns []float64
+		for _, n := range new {
+			if n.Name == o.Name {
+				ns = n.Samples[unit]
+				break
+			}
+		}
+		if len(ns) == 0 {
+			continue
+		}
+		if err := plotter.CheckFloats(os...); err != nil {
+			return nil, err
+		}
+		if err := plotter.CheckFloats(ns...); err != nil {
+			return nil, err
+		}
+		m := mean(os)
+		if m == 0 {
+			continue
+		}
+		r := benchRow{
+			name: o.Name,
+			old:  make([]float64, len(os)),
+			new:  make([]float64, len(ns)),
+			p:    mannWhitney(os, ns),
+		}
+		for i, v := range os {
+			r.old[i] = v / m
+		}
+		for i, v := range ns {
+			r.new[i] = v / m
+		}
+		r.delta = mean(ns)/m - 1
+		bc.rows = append(bc.rows, r)
+	}
+	if len(bc.rows) == 0 {
+		return nil, plotter.ErrNoData
+	}
+	return bc, nil
+}
+
+// mannWhitney returns the two-sided p-value of a Mann-Whitney
+// U test of the difference between the samples a and b, using
+// the normal approximation with corrections for ties and
+// continuity.
+func mannWhitney(a, b []float64) float64 {
+	type obs struct {
+		v     float64
+		first bool
+	}
+	all := make([]obs, 0, len(a)+len(b))
+	for _, v := range a {
+		all = append(all, obs{v: v, first: true})
+	}
+	for _, v := range b {
+		all = append(all, obs{v: v})
+	}
+	vs := make([]float64, len(all))
+	for i, o := range all {
+		vs[i] = o.v
+	}
+	sort.Float64s(vs)
+
+	// Rank the observations, giving tied
+	// values the mean of their ranks.
+	ranks := make(map[float64]float64)
+	var ties float64
+	for i := 0; i < len(vs); {
+		j := i
+		for j < len(vs) && vs[j] == vs[i] {
+			j++
+		}
+		ranks[vs[i]] = float64(i+j+1) / 2
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	var r float64
+	for _, o := range all {
+		if o.first {
+			r += ranks[o.v]
+		}
+	}
+
+	n1, n2 := float64(len(a)), float64(len(b))
+	n := n1 + n2
+	u := r - n1*(n1+1)/2
+	sigma := math.Sqrt(n1 * n2 / 12 * (n + 1 - ties/(n*(n-1))))
+	if sigma == 0 || math.IsNaN(sigma) {
+		return 1
+	}
+	z := (math.Abs(u-n1*n2/2) - 0.5) / sigma
+	if z <= 0 {
+		return 1
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// benchRow is a row of a benchmark comparison.
+type benchRow struct {
+	name string
+
+	// old and new hold the samples of the
+	// benchmark, scaled by the old mean.
+	old, new []float64
+
+	// delta is the relative change in the
+	// mean and p is the p-value of the change.
+	delta, p float64
+}
+
+// label returns the text written to the right of the row.
+func (r benchRow) label() string {
+	if r.p >= 0.05 {
+		return fmt.Sprintf("~ (p=%.3f)", r.p)
+	}
+	return fmt.Sprintf("%+.1f%% (p=%.3f)", 100*r.delta, r.p)
+}
+
+// benchComparison is a benchmark comparison plotter.
+// Row i of n is drawn at a Y value of n-1-i, so that
+// the first row is at the top of the plot.
+type benchComparison struct {
+	rows []benchRow
+
+	// Old and New are the styles of the points
+	// of the old and new results. The lines
+	// spanning the samples are drawn in the
+	// color of the points.
+	Old, New draw.GlyphStyle
+
+	// Baseline is the style of the vertical
+	// line drawn at a ratio of one.
+	Baseline draw.LineStyle
+
+	// TextStyle is the style of the labels
+	// of the changes.
+	TextStyle draw.TextStyle
+
+	// Padding is the distance between the
+	// right end of a row and its label.
+	Padding vg.Length
+}
+
+// Plot implements the plot.Plotter interface.
+func (bc *benchComparison) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	x := trX(1)
+	c.StrokeLines(bc.Baseline, c.ClipLinesX([]vg.Point{{X: x, Y: c.Min.Y}, {X: x, Y: c.Max.Y}})...)
+
+	for i, r := range bc.rows {
+		y := float64(len(bc.rows) - 1 - i)
+		bc.drawSamples(c, trX, trY(y+0.15), r.old, bc.Old)
+		bc.drawSamples(c, trX, trY(y-0.15), r.new, bc.New)
+
+		_, max := span(r.old, r.new)
+		// The label may lie in the padding made for it
+		// to the right of the data area by GlyphBoxes.
+		c.FillText(bc.TextStyle, vg.Point{X: trX(max) + bc.Padding, Y: trY(y)}, r.label())
+	}
+}
+
+// drawSamples draws a line spanning the samples at
+// the height y, with a point at their mean.
+func (bc *benchComparison) drawSamples(c draw.Canvas, trX func(float64) vg.Length, y vg.Length, vs []float64, sty draw.GlyphStyle) {
+	min, max := span(vs)
+	ls := draw.LineStyle{Color: sty.Color, Width: vg.Points(1)}
+	c.StrokeLines(ls, c.ClipLinesXY([]vg.Point{{X: trX(min), Y: y}, {X: trX(max), Y: y}})...)
+	pt := vg.Point{X: trX(mean(vs)), Y: y}
+	if c.Contains(pt) {
+		c.DrawGlyph(sty, pt)
+	}
+}
+
+// span returns the smallest and largest of the values.
+func span(vss ...[]float64) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, vs := range vss {
+		for _, v := range vs {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	return min, max
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (bc *benchComparison) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = 1, 1
+	for _, r := range bc.rows {
+		min, max := span(r.old, r.new)
+		xmin = math.Min(xmin, min)
+		xmax = math.Max(xmax, max)
+	}
+	return xmin, xmax, -0.5, float64(len(bc.rows)) - 0.5
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// returning boxes for the labels of the changes.
+func (bc *benchComparison) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(bc.rows))
+	for i, r := range bc.rows {
+		_, max := span(r.old, r.new)
+		rect := bc.TextStyle.Rectangle(r.label())
+		rect.Min.X += bc.Padding
+		rect.Max.X += bc.Padding
+		bs[i] = plot.GlyphBox{
+			X:         plt.X.Norm(max),
+			Y:         plt.Y.Norm(float64(len(bc.rows) - 1 - i)),
+			Rectangle: rect,
+		}
+	}
+	return bs
+}
+
+// benchThumbnailer implements the plot.Thumbnailer
+// interface for the old and new results of a
+// benchmark comparison.
+type benchThumbnailer struct {
+	draw.GlyphStyle
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (t benchThumbnailer) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(draw.LineStyle{Color: t.Color, Width: vg.Points(1)}, c.Min.X, y, c.Max.X, y)
+	c.DrawGlyph(t.GlyphStyle, c.Center())
+}
@@ -0,0 +1,143 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+const oldBench = `goos: linux
+goarch: amd64
+BenchmarkEncode-8   	  200000	      7021 ns/op	    1024 B/op	      12 allocs/op
+BenchmarkEncode-8   	  200000	      7140 ns/op	    1024 B/op	      12 allocs/op
+BenchmarkEncode-8   	  200000	      6985 ns/op	    1024 B/op	      12 allocs/op
+BenchmarkEncode-8   	  200000	      7088 ns/op	    1024 B/op	      12 allocs/op
+BenchmarkDecode-8   	  100000	     15210 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkDecode-8   	  100000	     15480 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkDecode-8   	  100000	     15022 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkDecode-8   	  100000	     15390 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkValidate-8 	 1000000	      1210 ns/op	       0 B/op	       0 allocs/op
+BenchmarkValidate-8 	 1000000	      1250 ns/op	       0 B/op	       0 allocs/op
+BenchmarkValidate-8 	 1000000	      1190 ns/op	       0 B/op	       0 allocs/op
+BenchmarkValidate-8 	 1000000	      1232 ns/op	       0 B/op	       0 allocs/op
+PASS
+ok  	example.com/codec	12.345s
+`
+
+const newBench = `BenchmarkEncode-8   	  300000	      5012 ns/op	     512 B/op	       6 allocs/op
+BenchmarkEncode-8   	  300000	      5120 ns/op	     512 B/op	       6 allocs/op
+BenchmarkEncode-8   	  300000	      4988 ns/op	     512 B/op	       6 allocs/op
+BenchmarkEncode-8   	  300000	      5060 ns/op	     512 B/op	       6 allocs/op
+BenchmarkDecode-8   	  100000	     16020 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkDecode-8   	  100000	     16350 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkDecode-8   	  100000	     15910 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkDecode-8   	  100000	     16180 ns/op	    2048 B/op	      31 allocs/op
+BenchmarkValidate-8 	 1000000	      1240 ns/op	       0 B/op	       0 allocs/op
+BenchmarkValidate-8 	 1000000	      1195 ns/op	       0 B/op	       0 allocs/op
+BenchmarkValidate-8 	 1000000	      1222 ns/op	       0 B/op	       0 allocs/op
+BenchmarkValidate-8 	 1000000	      1205 ns/op	       0 B/op	       0 allocs/op
+PASS
+`
+
+func ExampleAddBenchComparison() {
+	old, err := ParseBenchmarks(strings.NewReader(oldBench))
+	if err != nil {
+		panic(err)
+	}
+	new, err := ParseBenchmarks(strings.NewReader(newBench))
+	if err != nil {
+		panic(err)
+	}
+
+	plt, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	plt.Title.Text = "Codec benchmarks"
+	if err := AddBenchComparison(plt, old, new, "ns/op"); err != nil {
+		panic(err)
+	}
+	plt.Legend.Top = true
+	plt.Save(5*vg.Inch, 3*vg.Inch, "bench.png")
+}
+
+func TestParseBenchmarks(t *testing.T) {
+	bs, err := ParseBenchmarks(strings.NewReader(oldBench))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, b := range bs {
+		names = append(names, b.Name)
+	}
+	if want := []string{"BenchmarkEncode-8", "BenchmarkDecode-8", "BenchmarkValidate-8"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("unexpected benchmarks: got:%q want:%q", names, want)
+	}
+	if got, want := bs[0].Samples["ns/op"], []float64{7021, 7140, 6985, 7088}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected ns/op: got:%v want:%v", got, want)
+	}
+	if got, want := bs[1].Samples["allocs/op"], []float64{31, 31, 31, 31}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected allocs/op: got:%v want:%v", got, want)
+	}
+}
+
+func TestMannWhitney(t *testing.T) {
+	for _, test := range []struct {
+		a, b []float64
+		want float64
+	}{
+		// Completely separated samples of five.
+		{a: []float64{1, 2, 3, 4, 5}, b: []float64{6, 7, 8, 9, 10}, want: 0.0122},
+		// Interleaved samples.
+		{a: []float64{1, 3, 5, 7}, b: []float64{2, 4, 6, 8}, want: 0.6650},
+		// Identical samples.
+		{a: []float64{1, 1, 1}, b: []float64{1, 1, 1}, want: 1},
+	} {
+		if got := mannWhitney(test.a, test.b); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("unexpected p-value for %v and %v: got:%.4f want:%.4f", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestBenchComparison(t *testing.T) {
+	old := []Benchmark{
+		{Name: "BenchmarkA", Samples: map[string][]float64{"ns/op": {10, 10, 10, 10, 10}}},
+		{Name: "BenchmarkB", Samples: map[string][]float64{"ns/op": {4, 4}}},
+		{Name: "BenchmarkC", Samples: map[string][]float64{"B/op": {1}}},
+		{Name: "BenchmarkD", Samples: map[string][]float64{"ns/op": {0, 0}}},
+	}
+	new := []Benchmark{
+		{Name: "BenchmarkB", Samples: map[string][]float64{"ns/op": {5, 5}}},
+		{Name: "BenchmarkA", Samples: map[string][]float64{"ns/op": {8, 8, 8, 8, 8}}},
+		{Name: "BenchmarkD", Samples: map[string][]float64{"ns/op": {1, 1}}},
+	}
+	bc, err := newBenchComparison(old, new, "ns/op")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bc.rows) != 2 {
+		t.Fatalf("unexpected number of rows: got:%d want:2", len(bc.rows))
+	}
+	a := bc.rows[0]
+	if a.name != "BenchmarkA" || math.Abs(a.delta+0.2) > 1e-12 || !reflect.DeepEqual(a.new, []float64{0.8, 0.8, 0.8, 0.8, 0.8}) {
+		t.Errorf("unexpected row: %+v", a)
+	}
+	if got := a.label(); !strings.HasPrefix(got, "-20.0% (p=") {
+		t.Errorf("unexpected label of significant change: %q", got)
+	}
+	if got := bc.rows[1].label(); !strings.HasPrefix(got, "~ (p=") {
+		t.Errorf("unexpected label of insignificant change: %q", got)
+	}
+
+	if _, err := newBenchComparison(old, new, "allocs/op"); err == nil {
+		t.Error("expected error for missing unit")
+	}
+}
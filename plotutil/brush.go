@@ -0,0 +1,364 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Brush is a scatter plot of a set of points with histograms
+// of their X and Y values along its top and right sides, for
+// comparing the distributions of the coordinates. A range of
+// values may be selected on either histogram, by SelectX or
+// SelectY or by dragging across a drawn histogram with Drag,
+// to highlight the points within it in the scatter plot and
+// overlay the distribution of their other coordinate on the
+// other histogram.
+//
+// The axes of the histograms are kept in line with the axes
+// of the scatter plot that they share when the Brush is drawn.
+type Brush struct {
+	// Scatter is the scatter plot of the points, and
+	// X and Y are the plots of the histograms of their
+	// X and Y values. Titles, labels and other plotters
+	// may be added to them.
+	Scatter, X, Y *plot.Plot
+
+	// HistSize is the height of the X histogram
+	// and the width of the Y histogram.
+	HistSize vg.Length
+
+	// GlyphStyle is the style of the points, and
+	// SelectedStyle is the style of the points
+	// within the selection.
+	GlyphStyle, SelectedStyle draw.GlyphStyle
+
+	// FillColor is the color of the bars of the
+	// histograms, and SelectedColor is the color of
+	// the bars of the distribution of the selected
+	// points.
+	FillColor, SelectedColor color.Color
+
+	// RangeColor is the color of the band showing
+	// the selected range on its histogram.
+	RangeColor color.Color
+
+	xys  plotter.XYs
+	bins int
+
+	// sel is the axis of the selection, 'x' or 'y',
+	// or zero if there is none, and min and max are
+	// the bounds of the selected range.
+	sel      byte
+	min, max float64
+
+	// xData and yData are the data areas of the
+	// histograms when the Brush was last drawn.
+	xData, yData vg.Rectangle
+}
+
+// NewBrush returns a Brush of the points, with the given
+// number of bins in each histogram.
+func NewBrush(xys plotter.XYer, bins int) (*Brush, error) {
+	data, err := plotter.CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, plotter.ErrNoData
+	}
+	b := &Brush{
+		HistSize:      vg.Inch,
+		GlyphStyle:    plotter.DefaultGlyphStyle,
+		SelectedStyle: plotter.DefaultGlyphStyle,
+		FillColor:     color.Gray{192},
+		SelectedColor: color.RGBA{R: 196, G: 48, B: 48, A: 255},
+		RangeColor:    color.NRGBA{R: 255, G: 192, A: 96},
+		xys:           data,
+		bins:          bins,
+	}
+	b.SelectedStyle.Color = b.SelectedColor
+	b.SelectedStyle.Shape = draw.CircleGlyph{}
+
+	for _, p := range []**plot.Plot{&b.Scatter, &b.X, &b.Y} {
+		if *p, err = plot.New(); err != nil {
+			return nil, err
+		}
+	}
+	x, err := newBrushHist(b, false)
+	if err != nil {
+		return nil, err
+	}
+	y, err := newBrushHist(b, true)
+	if err != nil {
+		return nil, err
+	}
+	b.Scatter.Add(brushScatter{b})
+	b.X.Add(x)
+	b.X.HideX()
+	b.Y.Add(y)
+	b.Y.HideY()
+	return b, nil
+}
+
+// SelectX selects the points whose X values
+// are in the range from min to max.
+func (b *Brush) SelectX(min, max float64) {
+	b.sel, b.min, b.max = 'x', math.Min(min, max), math.Max(min, max)
+}
+
+// SelectY selects the points whose Y values
+// are in the range from min to max.
+func (b *Brush) SelectY(min, max float64) {
+	b.sel, b.min, b.max = 'y', math.Min(min, max), math.Max(min, max)
+}
+
+// Clear clears the selection.
+func (b *Brush) Clear() {
+	b.sel = 0
+}
+
+// selected returns whether the point at index i is selected.
+func (b *Brush) selected(i int) bool {
+	var v float64
+	switch b.sel {
+	case 'x':
+		v = b.xys[i].X
+	case 'y':
+		v = b.xys[i].Y
+	default:
+		return false
+	}
+	return b.min <= v && v <= b.max
+}
+
+// Selected returns the indices of the selected points.
+func (b *Brush) Selected() []int {
+	var idx []int
+	for i := range b.xys {
+		if b.selected(i) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// Drag selects the range dragged across from one point to
+// another of the canvas to which the Brush was last drawn.
+// If the drag starts in the data area of the X histogram, the
+// range of X values between the points is selected, and if it
+// starts in that of the Y histogram, the range of Y values.
+// Drag returns whether a range was selected. The axes of the
+// histograms are assumed to be linear.
+func (b *Brush) Drag(from, to vg.Point) bool {
+	switch {
+	case contains(b.xData, from):
+		min, max := b.Scatter.X.Min, b.Scatter.X.Max
+		at := func(x vg.Length) float64 {
+			return min + float64((x-b.xData.Min.X)/(b.xData.Max.X-b.xData.Min.X))*(max-min)
+		}
+		b.SelectX(at(from.X), at(to.X))
+	case contains(b.yData, from):
+		min, max := b.Scatter.Y.Min, b.Scatter.Y.Max
+		at := func(y vg.Length) float64 {
+			return min + float64((y-b.yData.Min.Y)/(b.yData.Max.Y-b.yData.Min.Y))*(max-min)
+		}
+		b.SelectY(at(from.Y), at(to.Y))
+	default:
+		return false
+	}
+	return true
+}
+
+// contains returns whether the point is within the rectangle.
+func contains(r vg.Rectangle, p vg.Point) bool {
+	return r.Min.X <= p.X && p.X <= r.Max.X && r.Min.Y <= p.Y && p.Y <= r.Max.Y
+}
+
+// Draw draws the scatter plot and the histograms to the
+// canvas, with the histograms taking the top and right
+// sides of the canvas.
+func (b *Brush) Draw(c draw.Canvas) {
+	b.X.X.Min, b.X.X.Max, b.X.X.Scale = b.Scatter.X.Min, b.Scatter.X.Max, b.Scatter.X.Scale
+	b.Y.Y.Min, b.Y.Y.Max, b.Y.Y.Scale = b.Scatter.Y.Min, b.Scatter.Y.Max, b.Scatter.Y.Scale
+
+	w := c.Max.X - c.Min.X
+	h := c.Max.Y - c.Min.Y
+	main := draw.Crop(c, 0, -b.HistSize, 0, -b.HistSize)
+	top := draw.Crop(c, 0, -b.HistSize, h-b.HistSize, 0)
+	right := draw.Crop(c, w-b.HistSize, 0, 0, -b.HistSize)
+
+	// Line up the data areas of the histograms
+	// with that of the scatter plot.
+	da := b.Scatter.DataCanvas(main)
+	xda := b.X.DataCanvas(top)
+	top = draw.Crop(top, da.Min.X-xda.Min.X, da.Max.X-xda.Max.X, 0, 0)
+	yda := b.Y.DataCanvas(right)
+	right = draw.Crop(right, 0, 0, da.Min.Y-yda.Min.Y, da.Max.Y-yda.Max.Y)
+	b.xData = b.X.DataCanvas(top).Rectangle
+	b.yData = b.Y.DataCanvas(right).Rectangle
+
+	b.Scatter.Draw(main)
+	b.X.Draw(top)
+	b.Y.Draw(right)
+}
+
+// brushScatter draws the points of a Brush,
+// highlighting the selected points.
+type brushScatter struct {
+	*Brush
+}
+
+func (s brushScatter) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for i, p := range s.xys {
+		if !s.selected(i) {
+			c.DrawGlyph(s.GlyphStyle, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		}
+	}
+	for i, p := range s.xys {
+		if s.selected(i) {
+			c.DrawGlyph(s.SelectedStyle, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		}
+	}
+}
+
+func (s brushScatter) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return plotter.XYRange(s.xys)
+}
+
+func (s brushScatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(s.xys))
+	for i, p := range s.xys {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		sty := s.GlyphStyle
+		if sty.Radius < s.SelectedStyle.Radius {
+			sty = s.SelectedStyle
+		}
+		bs[i].Rectangle = sty.Rectangle()
+	}
+	return bs
+}
+
+// brushHist draws the histogram of the X or Y values of
+// the points of a Brush, with the distribution of the
+// selected points or the selected range.
+type brushHist struct {
+	*Brush
+
+	// y specifies whether the histogram is of
+	// the Y values, with horizontal bars.
+	y bool
+
+	// hist is the histogram of all of the values.
+	hist *plotter.Histogram
+}
+
+func newBrushHist(b *Brush, y bool) (brushHist, error) {
+	vs := make(plotter.Values, len(b.xys))
+	for i, p := range b.xys {
+		vs[i] = p.X
+		if y {
+			vs[i] = p.Y
+		}
+	}
+	h, err := plotter.NewHist(vs, b.bins)
+	return brushHist{Brush: b, y: y, hist: h}, err
+}
+
+// value returns the value of the point at index i
+// shown by the histogram.
+func (h brushHist) value(i int) float64 {
+	if h.y {
+		return h.xys[i].Y
+	}
+	return h.xys[i].X
+}
+
+// bar returns the corners of the bar from lo to hi
+// of the given weight.
+func (h brushHist) bar(lo, hi, weight float64, trX, trY func(float64) vg.Length) []vg.Point {
+	if h.y {
+		return []vg.Point{
+			{X: trX(0), Y: trY(lo)}, {X: trX(weight), Y: trY(lo)},
+			{X: trX(weight), Y: trY(hi)}, {X: trX(0), Y: trY(hi)},
+		}
+	}
+	return []vg.Point{
+		{X: trX(lo), Y: trY(0)}, {X: trX(hi), Y: trY(0)},
+		{X: trX(hi), Y: trY(weight)}, {X: trX(lo), Y: trY(weight)},
+	}
+}
+
+func (h brushHist) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	own := (h.sel == 'y') == h.y
+	if h.sel != 0 && own {
+		// Shade the selected range.
+		var band []vg.Point
+		if h.y {
+			band = []vg.Point{
+				{X: c.Min.X, Y: trY(h.min)}, {X: c.Max.X, Y: trY(h.min)},
+				{X: c.Max.X, Y: trY(h.max)}, {X: c.Min.X, Y: trY(h.max)},
+			}
+		} else {
+			band = []vg.Point{
+				{X: trX(h.min), Y: c.Min.Y}, {X: trX(h.max), Y: c.Min.Y},
+				{X: trX(h.max), Y: c.Max.Y}, {X: trX(h.min), Y: c.Max.Y},
+			}
+		}
+		c.FillPolygon(h.RangeColor, c.ClipPolygonXY(band))
+	}
+
+	for _, bin := range h.hist.Bins {
+		pts := h.bar(bin.Min, bin.Max, bin.Weight, trX, trY)
+		if h.FillColor != nil {
+			c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+		}
+		c.StrokeLines(h.hist.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+	}
+	if h.sel == 0 || own || h.SelectedColor == nil {
+		return
+	}
+
+	// Overlay the distribution of the selected points
+	// in the bins of the histogram of all of the points.
+	counts := make([]float64, len(h.hist.Bins))
+	for i := range h.xys {
+		if !h.selected(i) {
+			continue
+		}
+		var k int
+		if h.hist.Width > 0 {
+			k = int((h.value(i) - h.hist.Bins[0].Min) / h.hist.Width)
+		}
+		if k >= len(counts) {
+			k = len(counts) - 1
+		}
+		counts[k]++
+	}
+	for k, bin := range h.hist.Bins {
+		if counts[k] > 0 {
+			c.FillPolygon(h.SelectedColor, c.ClipPolygonXY(h.bar(bin.Min, bin.Max, counts[k], trX, trY)))
+		}
+	}
+}
+
+func (h brushHist) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = h.hist.DataRange()
+	ymin = 0
+	if h.y {
+		return ymin, ymax, xmin, xmax
+	}
+	return xmin, xmax, ymin, ymax
+}
@@ -0,0 +1,110 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// correlated returns n points whose
+// coordinates are positively correlated.
+func correlated(n int) plotter.XYs {
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(plotter.XYs, n)
+	for i := range xys {
+		x := rnd.NormFloat64()
+		xys[i].X = x
+		xys[i].Y = 0.7*x + 0.5*rnd.NormFloat64()
+	}
+	return xys
+}
+
+func ExampleBrush() {
+	b, err := NewBrush(correlated(500), 20)
+	if err != nil {
+		panic(err)
+	}
+	b.Scatter.X.Label.Text = "X"
+	b.Scatter.Y.Label.Text = "Y"
+
+	// Highlight the points with large X values and
+	// show the distribution of their Y values.
+	b.SelectX(1, 3)
+
+	img := vgimg.New(5*vg.Inch, 5*vg.Inch)
+	b.Draw(draw.New(img))
+	f, err := os.Create("brush.png")
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	png := vgimg.PngCanvas{Canvas: img}
+	if _, err := png.WriteTo(f); err != nil {
+		panic(err)
+	}
+}
+
+func TestBrush(t *testing.T) {
+	xys := plotter.XYs{{0, 4}, {1, 3}, {2, 2}, {3, 1}, {4, 0}}
+	b, err := NewBrush(xys, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := b.Selected(); got != nil {
+		t.Errorf("unexpected selection before selecting: %v", got)
+	}
+	b.SelectX(2.5, 0.5)
+	if got, want := b.Selected(), []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected X selection: got:%v want:%v", got, want)
+	}
+	b.SelectY(0, 1)
+	if got, want := b.Selected(), []int{3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected Y selection: got:%v want:%v", got, want)
+	}
+	b.Clear()
+	if got := b.Selected(); got != nil {
+		t.Errorf("unexpected selection after clearing: %v", got)
+	}
+
+	c := draw.New(vgimg.New(4*vg.Inch, 4*vg.Inch))
+	b.Draw(c)
+	if b.xData.Min.X >= b.xData.Max.X || b.yData.Min.Y >= b.yData.Max.Y {
+		t.Fatalf("unexpected histogram data areas: x:%v y:%v", b.xData, b.yData)
+	}
+	da := b.Scatter.DataCanvas(draw.Crop(c, 0, -b.HistSize, 0, -b.HistSize))
+	if b.xData.Min.X != da.Min.X || b.xData.Max.X != da.Max.X {
+		t.Errorf("X histogram not in line with scatter: got:[%v, %v] want:[%v, %v]",
+			b.xData.Min.X, b.xData.Max.X, da.Min.X, da.Max.X)
+	}
+	if b.yData.Min.Y != da.Min.Y || b.yData.Max.Y != da.Max.Y {
+		t.Errorf("Y histogram not in line with scatter: got:[%v, %v] want:[%v, %v]",
+			b.yData.Min.Y, b.yData.Max.Y, da.Min.Y, da.Max.Y)
+	}
+
+	// Drag across the right half of the X histogram.
+	mid := (b.xData.Min.X + b.xData.Max.X) / 2
+	y := (b.xData.Min.Y + b.xData.Max.Y) / 2
+	if !b.Drag(vg.Point{X: b.xData.Max.X, Y: y}, vg.Point{X: mid, Y: y}) {
+		t.Fatal("drag across X histogram did not select")
+	}
+	if got, want := b.Selected(), []int{2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected dragged selection: got:%v want:%v", got, want)
+	}
+	if b.Drag(da.Center(), da.Max) {
+		t.Error("drag across scatter plot selected")
+	}
+
+	if _, err := NewBrush(plotter.XYs{}, 4); err != plotter.ErrNoData {
+		t.Errorf("unexpected error for no data: got:%v want:%v", err, plotter.ErrNoData)
+	}
+}
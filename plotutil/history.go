@@ -0,0 +1,357 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// History holds the values of metrics recorded over the
+// epochs of one or more training runs, such as the training
+// and validation loss of a model, for plotting as learning
+// curves with AddHistory.
+//
+// Values may be added all at once with Add, or streamed
+// with Record. The methods of a History may be called
+// concurrently, so a History may be plotted while it is
+// being recorded.
+type History struct {
+	// Minimize is whether lower values of the metrics
+	// are better, as for a loss. Otherwise higher values
+	// are better, as for an accuracy.
+	Minimize bool
+
+	// LogScale is whether the metrics are drawn on
+	// a logarithmic scale.
+	LogScale bool
+
+	// Best is the name of the metric whose best epoch
+	// is marked, usually a validation metric. If Best
+	// is empty, the metric added last is used.
+	Best string
+
+	// Summary returns the center and the low and high
+	// errors of the values of a metric at an epoch
+	// across runs. The center is drawn as a line and
+	// the errors as a shaded band around it. If Summary
+	// is nil, MeanAndConf95 is used.
+	Summary func([]float64) (c, lowerr, higherr float64)
+
+	mu      sync.Mutex
+	metrics []*metric
+}
+
+// metric holds the values of a metric
+// for each run and epoch.
+type metric struct {
+	name string
+	runs [][]float64
+}
+
+// Metric is the value of a metric at
+// an epoch of a training run.
+type Metric struct {
+	// Name is the name of the metric.
+	Name string
+
+	// Run is the index of the training run,
+	// counting from zero.
+	Run int
+
+	// Value is the value of the metric.
+	Value float64
+}
+
+// metric returns the named metric, adding
+// it if it does not exist.
+func (h *History) metric(name string) *metric {
+	for _, m := range h.metrics {
+		if m.name == name {
+			return m
+		}
+	}
+	m := &metric{name: name}
+	h.metrics = append(h.metrics, m)
+	return m
+}
+
+// Add adds runs of the named metric to the history.
+// Each run holds the value of the metric for each
+// epoch, starting with the first.
+func (h *History) Add(name string, runs ...[]float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.metric(name)
+	for _, r := range runs {
+		m.runs = append(m.runs, append([]float64(nil), r...))
+	}
+}
+
+// Record appends the values received on c to the
+// history until c is closed. Each value is taken to
+// be for the epoch following the last value recorded
+// for its metric and run.
+func (h *History) Record(c <-chan Metric) {
+	for v := range c {
+		h.record(v)
+	}
+}
+
+// record appends v to the history.
+func (h *History) record(v Metric) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.metric(v.Name)
+	for len(m.runs) <= v.Run {
+		m.runs = append(m.runs, nil)
+	}
+	m.runs[v.Run] = append(m.runs[v.Run], v.Value)
+}
+
+// curve is the summary of a metric over epochs.
+type curve struct {
+	name            string
+	line, low, high plotter.XYs
+}
+
+// curves returns the summary of each metric.
+func (h *History) curves() ([]curve, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	summary := h.Summary
+	if summary == nil {
+		summary = MeanAndConf95
+	}
+	var cs []curve
+	for _, m := range h.metrics {
+		c := curve{name: m.name}
+		for e := 0; ; e++ {
+			var vs []float64
+			for _, r := range m.runs {
+				if e < len(r) {
+					vs = append(vs, r[e])
+				}
+			}
+			if len(vs) == 0 {
+				break
+			}
+			if err := plotter.CheckFloats(vs...); err != nil {
+				return nil, err
+			}
+			min := math.Inf(1)
+			for _, v := range vs {
+				min = math.Min(min, v)
+			}
+			y, lo, hi := summary(vs)
+			low := y - math.Abs(lo)
+			if h.LogScale && low <= 0 {
+				// Keep the band on the scale.
+				low = min
+			}
+			x := float64(e + 1)
+			c.line = append(c.line, struct{ X, Y float64 }{x, y})
+			c.low = append(c.low, struct{ X, Y float64 }{x, low})
+			c.high = append(c.high, struct{ X, Y float64 }{x, y + math.Abs(hi)})
+		}
+		if len(c.line) == 0 {
+			return nil, fmt.Errorf("No values for %s", m.name)
+		}
+		cs = append(cs, c)
+	}
+	if len(cs) == 0 {
+		return nil, plotter.ErrNoData
+	}
+	return cs, nil
+}
+
+// AddHistory adds learning curves for the metrics of
+// the history to a plot. Each metric is drawn as a line
+// at its summary value for each epoch, using the next
+// color via the Color function, with a shaded band
+// showing its variability across runs. The best epoch
+// of the metric named by h.Best is marked with a
+// vertical line.
+//
+// If the X axis has no label it is labeled "Epoch".
+// If h.LogScale is true, the Y axis is given a
+// logarithmic scale.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddHistory(plt *plot.Plot, h *History) error {
+	cs, err := h.curves()
+	if err != nil {
+		return err
+	}
+	if h.LogScale {
+		for _, c := range cs {
+			for _, p := range c.low {
+				if p.Y <= 0 {
+					return errors.New("Non-positive value on a logarithmic scale")
+				}
+			}
+		}
+	}
+
+	x, y, err := h.best(cs)
+	if err != nil {
+		return err
+	}
+	m := &bestEpoch{
+		X:          x,
+		Y:          y,
+		LineStyle:  plotter.DefaultBaselineStyle,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+	}
+	m.GlyphStyle.Shape = draw.CircleGlyph{}
+	m.GlyphStyle.Radius = vg.Points(3)
+
+	var ps []plot.Plotter
+	var thumbs [][]plot.Thumbnailer
+	for i, c := range cs {
+		clr := color.NRGBAModel.Convert(Color(i)).(color.NRGBA)
+		l, err := plotter.NewLine(c.line)
+		if err != nil {
+			return err
+		}
+		l.Color = clr
+		clr.A = 0x40
+		b := &band{low: c.low, high: c.high, Color: clr}
+		ps = append(ps, b, l)
+		thumbs = append(thumbs, []plot.Thumbnailer{b, l})
+	}
+	ps = append(ps, m)
+
+	if h.LogScale {
+		plt.Y.Scale = plot.LogScale{}
+		plt.Y.Tick.Marker = plot.LogTicks{}
+	}
+	if plt.X.Label.Text == "" {
+		plt.X.Label.Text = "Epoch"
+	}
+	plt.Add(ps...)
+	for i, c := range cs {
+		plt.Legend.Add(c.name, thumbs[i]...)
+	}
+	plt.Legend.Add(fmt.Sprintf("best epoch (%g)", m.X), m)
+	return nil
+}
+
+// best returns the best epoch of the metric named by
+// h.Best and the summary value of the metric at it.
+func (h *History) best(cs []curve) (epoch, value float64, err error) {
+	best := cs[len(cs)-1]
+	if h.Best != "" {
+		found := false
+		for _, c := range cs {
+			if c.name == h.Best {
+				best, found = c, true
+				break
+			}
+		}
+		if !found {
+			return 0, 0, fmt.Errorf("No metric named %s", h.Best)
+		}
+	}
+	epoch, value = best.line[0].X, best.line[0].Y
+	for _, p := range best.line[1:] {
+		if h.Minimize && p.Y < value || !h.Minimize && p.Y > value {
+			epoch, value = p.X, p.Y
+		}
+	}
+	return epoch, value, nil
+}
+
+// band is a shaded region between two curves.
+type band struct {
+	low, high plotter.XYs
+	Color     color.Color
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *band) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pts := make([]vg.Point, 0, 2*len(b.low))
+	for _, p := range b.low {
+		pts = append(pts, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+	for i := len(b.high) - 1; i >= 0; i-- {
+		p := b.high[i]
+		pts = append(pts, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+	c.FillPolygon(b.Color, c.ClipPolygonXY(pts))
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (b *band) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, _ = plotter.XYRange(b.low)
+	_, _, _, ymax = plotter.XYRange(b.high)
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (b *band) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+	}
+	c.FillPolygon(b.Color, c.ClipPolygonY(pts))
+}
+
+// bestEpoch marks the best epoch of a learning curve.
+type bestEpoch struct {
+	// X is the epoch and Y is the value
+	// of the metric at the epoch.
+	X, Y float64
+
+	// LineStyle is the style of the vertical
+	// line drawn at the epoch.
+	draw.LineStyle
+
+	// GlyphStyle is the style of the glyph
+	// drawn at the value of the metric.
+	GlyphStyle draw.GlyphStyle
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *bestEpoch) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	x := trX(b.X)
+	c.StrokeLines(b.LineStyle, c.ClipLinesX([]vg.Point{{X: x, Y: c.Min.Y}, {X: x, Y: c.Max.Y}})...)
+	c.DrawGlyph(b.GlyphStyle, vg.Point{X: x, Y: trY(b.Y)})
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (b *bestEpoch) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return b.X, b.X, b.Y, b.Y
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (b *bestEpoch) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return []plot.GlyphBox{{
+		X:         plt.X.Norm(b.X),
+		Y:         plt.Y.Norm(b.Y),
+		Rectangle: b.GlyphStyle.Rectangle(),
+	}}
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (b *bestEpoch) Thumbnail(c *draw.Canvas) {
+	x := c.Center().X
+	c.StrokeLine2(b.LineStyle, x, c.Min.Y, x, c.Max.Y)
+	c.DrawGlyph(b.GlyphStyle, c.Center())
+}
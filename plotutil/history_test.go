@@ -0,0 +1,124 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+func ExampleAddHistory() {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Simulate the loss over 30 epochs of five
+	// training runs, streaming the values as
+	// they are computed.
+	h := &History{Minimize: true, LogScale: true, Best: "validation"}
+	c := make(chan Metric)
+	done := make(chan struct{})
+	go func() {
+		h.Record(c)
+		close(done)
+	}()
+	for run := 0; run < 5; run++ {
+		for e := 1; e <= 30; e++ {
+			train := 2*math.Exp(-float64(e)/6) + 0.05
+			val := train + 0.002*float64(e-12)*float64(e-12)/12
+			c <- Metric{Name: "training", Run: run, Value: train * (1 + 0.1*rnd.NormFloat64())}
+			c <- Metric{Name: "validation", Run: run, Value: val * (1 + 0.1*rnd.NormFloat64())}
+		}
+	}
+	close(c)
+	<-done
+
+	plt, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	plt.Title.Text = "Learning curves"
+	plt.Y.Label.Text = "Loss"
+	plt.Legend.Top = true
+	if err := AddHistory(plt, h); err != nil {
+		panic(err)
+	}
+	plt.Save(4, 4, "history.png")
+}
+
+func TestHistory(t *testing.T) {
+	for _, test := range []struct {
+		minimize bool
+		best     string
+		epoch    float64
+		value    float64
+	}{
+		{minimize: true, epoch: 4, value: 2},
+		{minimize: false, epoch: 2, value: 4.5},
+		{minimize: true, best: "training", epoch: 4, value: 1},
+	} {
+		h := &History{Minimize: test.minimize, Best: test.best}
+		h.Add("training", []float64{4, 3, 2, 1})
+		h.Add("validation", []float64{4, 3, 3, 2})
+		h.Add("validation", []float64{4, 6, 3})
+
+		cs, err := h.curves()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cs) != 2 {
+			t.Fatalf("unexpected number of curves: got:%d want:2", len(cs))
+		}
+		if n := len(cs[1].line); n != 4 {
+			t.Errorf("unexpected number of epochs: got:%d want:4", n)
+		}
+		epoch, value, err := h.best(cs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if epoch != test.epoch || value != test.value {
+			t.Errorf("unexpected best epoch for minimize=%t best=%q: got:%v (%v) want:%v (%v)",
+				test.minimize, test.best, epoch, value, test.epoch, test.value)
+		}
+
+		plt, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := AddHistory(plt, h); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	h := &History{Best: "missing"}
+	h.Add("loss", []float64{1, 2})
+	plt, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := AddHistory(plt, h); err == nil {
+		t.Error("expected error for missing metric")
+	}
+}
+
+func TestHistoryRecord(t *testing.T) {
+	h := &History{}
+	c := make(chan Metric, 4)
+	c <- Metric{Name: "loss", Run: 1, Value: 3}
+	c <- Metric{Name: "loss", Run: 0, Value: 1}
+	c <- Metric{Name: "loss", Run: 1, Value: 5}
+	c <- Metric{Name: "loss", Run: 0, Value: 3}
+	close(c)
+	h.Record(c)
+
+	cs, err := h.curves()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cs[0].line; len(got) != 2 || got[0].Y != 2 || got[1].Y != 4 {
+		t.Errorf("unexpected curve: got:%v want:[{1 2} {2 4}]", got)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+)
+
+// FormatSize returns a human-readable form of a size in
+// bytes, using binary multiples, such as "1.5 MiB".
+func FormatSize(bytes float64) string {
+	const units = "KMGTPE"
+	if bytes < 1024 && bytes > -1024 {
+		return fmt.Sprintf("%.0f B", bytes)
+	}
+	i := -1
+	for (bytes >= 1024 || bytes <= -1024) && i < len(units)-1 {
+		bytes /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %ciB", bytes, units[i])
+}
+
+// SizeTree returns the tree of the sizes of the paths in
+// sizes, such as the sizes of the packages of a binary or
+// of the files on a disk, rooted at a node with the given
+// name. Paths are split into their elements by sep.
+//
+// Children are ordered by name. Chains of nodes with a
+// single child are merged into one node, named with the
+// joined path. The size of a path that also has children,
+// such as a package with sub-packages, is given to a child
+// named "(self)".
+func SizeTree(name string, sizes map[string]float64, sep string) plotter.TreemapNode {
+	root := &sizeNode{}
+	for path, size := range sizes {
+		n := root
+		for _, elem := range strings.Split(path, sep) {
+			n = n.child(elem)
+		}
+		n.size += size
+	}
+	return root.node(name, sep)
+}
+
+// sizeNode is a node of a size tree under construction.
+type sizeNode struct {
+	size     float64
+	children map[string]*sizeNode
+}
+
+// child returns the child node with the given
+// name, adding it if it does not exist.
+func (n *sizeNode) child(name string) *sizeNode {
+	if n.children == nil {
+		n.children = make(map[string]*sizeNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &sizeNode{}
+		n.children[name] = c
+	}
+	return c
+}
+
+// tree returns the treemap node of the named size
+// node, merged with its descendants while they have
+// a single child.
+func (n *sizeNode) tree(name, sep string) plotter.TreemapNode {
+	for len(n.children) == 1 && n.size == 0 {
+		for elem, c := range n.children {
+			name += sep + elem
+			n = c
+		}
+	}
+	return n.node(name, sep)
+}
+
+// node returns the treemap node of the named size node.
+func (n *sizeNode) node(name, sep string) plotter.TreemapNode {
+	t := plotter.TreemapNode{Name: name, Value: n.size}
+	if len(n.children) == 0 {
+		return t
+	}
+	names := make([]string, 0, len(n.children))
+	for elem := range n.children {
+		names = append(names, elem)
+	}
+	sort.Strings(names)
+	for _, elem := range names {
+		t.Children = append(t.Children, n.children[elem].tree(elem, sep))
+	}
+	if n.size != 0 {
+		t.Children = append(t.Children, plotter.TreemapNode{Name: "(self)", Value: n.size})
+	}
+	return t
+}
+
+// AddSizeTreemap adds a treemap of the sizes of the paths in
+// sizes, as returned by SizeTree, to a plot, in the style of
+// a disk usage report. Each node is labeled with its name and
+// its total size formatted by FormatSize, and the rectangles
+// are colored by their depth. The axes of the plot are hidden.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddSizeTreemap(plt *plot.Plot, name string, sizes map[string]float64, sep string) error {
+	t, err := plotter.NewTreemap(SizeTree(name, sizes, sep))
+	if err != nil {
+		return err
+	}
+	t.Label = func(name string, size float64) string {
+		return name + " (" + FormatSize(size) + ")"
+	}
+	plt.Add(t)
+	plt.HideAxes()
+	return nil
+}
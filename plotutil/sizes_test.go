@@ -0,0 +1,88 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+)
+
+func ExampleAddSizeTreemap() {
+	// Sizes in bytes of the packages linked
+	// into a binary, as reported by a tool
+	// such as go tool nm -size.
+	sizes := map[string]float64{
+		"runtime":                             1420000,
+		"reflect":                             310000,
+		"fmt":                                 92000,
+		"net/http":                            1870000,
+		"net/url":                             54000,
+		"net":                                 640000,
+		"crypto/tls":                          720000,
+		"crypto/x509":                         410000,
+		"github.com/gonum/plot":               380000,
+		"github.com/gonum/plot/vg":            95000,
+		"github.com/gonum/plot/vg/draw":       160000,
+		"github.com/gonum/plot/plotter":       520000,
+		"github.com/llgcode/draw2d":           240000,
+		"github.com/golang/freetype/truetype": 180000,
+	}
+
+	plt, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	plt.Title.Text = "Binary size by package"
+	if err := AddSizeTreemap(plt, "server", sizes, "/"); err != nil {
+		panic(err)
+	}
+	plt.Save(6*vg.Inch, 4*vg.Inch, "sizes.png")
+}
+
+func TestFormatSize(t *testing.T) {
+	for _, test := range []struct {
+		bytes float64
+		want  string
+	}{
+		{bytes: 0, want: "0 B"},
+		{bytes: 1023, want: "1023 B"},
+		{bytes: 1536, want: "1.5 KiB"},
+		{bytes: 5 << 20, want: "5.0 MiB"},
+		{bytes: 3 << 40, want: "3.0 TiB"},
+		{bytes: -2048, want: "-2.0 KiB"},
+	} {
+		if got := FormatSize(test.bytes); got != test.want {
+			t.Errorf("unexpected size for %v bytes: got:%q want:%q", test.bytes, got, test.want)
+		}
+	}
+}
+
+func TestSizeTree(t *testing.T) {
+	got := SizeTree("bin", map[string]float64{
+		"a/b/c": 1,
+		"a/b/d": 2,
+		"e":     3,
+		"f":     4,
+		"f/g":   5,
+	}, "/")
+	want := plotter.TreemapNode{Name: "bin", Children: []plotter.TreemapNode{
+		{Name: "a/b", Children: []plotter.TreemapNode{
+			{Name: "c", Value: 1},
+			{Name: "d", Value: 2},
+		}},
+		{Name: "e", Value: 3},
+		{Name: "f", Value: 4, Children: []plotter.TreemapNode{
+			{Name: "g", Value: 5},
+			{Name: "(self)", Value: 4},
+		}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tree:\ngot: %+v\nwant:%+v", got, want)
+	}
+}
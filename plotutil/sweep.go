@@ -0,0 +1,402 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Trial is a trial of a hyperparameter sweep, holding
+// the hyperparameters a model was trained with and the
+// resulting value of a metric, such as the validation
+// accuracy of the model.
+type Trial struct {
+	// Params holds the value of each
+	// hyperparameter by name.
+	Params map[string]float64
+
+	// Metric is the value of the metric.
+	Metric float64
+}
+
+// Slice returns the trials whose hyperparameters
+// have the values given in fixed.
+func Slice(trials []Trial, fixed map[string]float64) []Trial {
+	var s []Trial
+	for _, t := range trials {
+		ok := true
+		for name, v := range fixed {
+			if p, has := t.Params[name]; !has || p != v {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			s = append(s, t)
+		}
+	}
+	return s
+}
+
+// param returns the values of the named
+// hyperparameter of each trial.
+func param(trials []Trial, name string) ([]float64, error) {
+	vs := make([]float64, len(trials))
+	for i, t := range trials {
+		v, ok := t.Params[name]
+		if !ok {
+			return nil, fmt.Errorf("No hyperparameter named %s in trial %d", name, i)
+		}
+		vs[i] = v
+	}
+	if err := plotter.CheckFloats(vs...); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+// distinct returns the distinct values of vs in
+// increasing order, and the index into them of
+// each element of vs.
+func distinct(vs []float64) (vals []float64, idx []int) {
+	vals = append([]float64(nil), vs...)
+	sort.Float64s(vals)
+	n := 0
+	for i, v := range vals {
+		if i == 0 || v != vals[n-1] {
+			vals[n] = v
+			n++
+		}
+	}
+	vals = vals[:n]
+	idx = make([]int, len(vs))
+	for i, v := range vs {
+		idx[i] = sort.SearchFloat64s(vals, v)
+	}
+	return vals, idx
+}
+
+// labels returns the values formatted as tick labels.
+func labels(vs []float64) []string {
+	ls := make([]string, len(vs))
+	for i, v := range vs {
+		ls[i] = fmt.Sprintf("%g", v)
+	}
+	return ls
+}
+
+// AddSweepHeatMap adds a heat map of the metric of the
+// trials over the values of the hyperparameters named
+// x and y to a plot, colored using the palette p. Each
+// distinct value of the hyperparameters is shown as a
+// column or row of the heat map, labeled on the axes.
+//
+// The metrics of trials with the same values of x and y,
+// differing in other hyperparameters, are combined with
+// agg, for example by taking their maximum to show the
+// best trial. If agg is nil the mean of the metrics is
+// used. To show a slice of the sweep at given values of
+// the other hyperparameters, pass the trials returned by
+// Slice.
+//
+// Cells with no trials are left empty. If the axes have
+// no labels they are labeled with x and y.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddSweepHeatMap(plt *plot.Plot, trials []Trial, x, y string, agg func([]float64) float64, p palette.Palette) error {
+	if agg == nil {
+		agg = mean
+	}
+	g, err := newSweepGrid(trials, x, y, agg)
+	if err != nil {
+		return err
+	}
+	h := plotter.NewHeatMap(g, p)
+	if h.Min == h.Max {
+		// Give a single value a range
+		// so that it can be drawn.
+		h.Min--
+		h.Max++
+	}
+
+	plt.Add(h)
+	plt.NominalX(labels(g.xs)...)
+	plt.NominalY(labels(g.ys)...)
+	if plt.X.Label.Text == "" {
+		plt.X.Label.Text = x
+	}
+	if plt.Y.Label.Text == "" {
+		plt.Y.Label.Text = y
+	}
+	return nil
+}
+
+// sweepGrid is a grid of metrics indexed by the
+// distinct values of two hyperparameters,
+// implementing the plotter.GridXYZ interface.
+type sweepGrid struct {
+	// xs and ys hold the distinct values of the
+	// hyperparameters of the columns and rows.
+	xs, ys []float64
+
+	// z holds the metric of each cell, or NaN
+	// for cells with no trials.
+	z []float64
+}
+
+// newSweepGrid returns a grid of the metrics of the trials
+// over the hyperparameters named x and y, combined with agg.
+func newSweepGrid(trials []Trial, x, y string, agg func([]float64) float64) (*sweepGrid, error) {
+	if len(trials) == 0 {
+		return nil, plotter.ErrNoData
+	}
+	xs, err := param(trials, x)
+	if err != nil {
+		return nil, err
+	}
+	ys, err := param(trials, y)
+	if err != nil {
+		return nil, err
+	}
+	g := &sweepGrid{}
+	var xidx, yidx []int
+	g.xs, xidx = distinct(xs)
+	g.ys, yidx = distinct(ys)
+
+	cells := make([][]float64, len(g.xs)*len(g.ys))
+	for i, t := range trials {
+		if math.IsNaN(t.Metric) || math.IsInf(t.Metric, 0) {
+			return nil, errors.New("Invalid metric")
+		}
+		k := yidx[i]*len(g.xs) + xidx[i]
+		cells[k] = append(cells[k], t.Metric)
+	}
+	g.z = make([]float64, len(cells))
+	for k, ms := range cells {
+		if len(ms) == 0 {
+			g.z[k] = math.NaN()
+			continue
+		}
+		g.z[k] = agg(ms)
+	}
+	return g, nil
+}
+
+func (g *sweepGrid) Dims() (c, r int)   { return len(g.xs), len(g.ys) }
+func (g *sweepGrid) Z(c, r int) float64 { return g.z[r*len(g.xs)+c] }
+func (g *sweepGrid) X(c int) float64    { return float64(c) }
+func (g *sweepGrid) Y(r int) float64    { return float64(r) }
+
+// mean returns the mean of vs.
+func mean(vs []float64) float64 {
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum / float64(len(vs))
+}
+
+// AddParallelCoordinates adds a parallel coordinates view
+// of the trials to a plot. Each of the named hyperparameters
+// is shown as a vertical axis, followed by an axis for the
+// metric labeled with metric, and each trial is drawn as a
+// line joining its values on the axes. The axes are scaled
+// from the smallest to the largest value on them, which are
+// labeled at their ends.
+//
+// The lines are colored by the value of the metric using
+// cmap, with the smallest metric mapped to the minimum of
+// cmap and the largest to its maximum, and are drawn in
+// order of increasing metric.
+//
+// If an error occurs then none of the plotters are added
+// to the plot, and the error is returned.
+func AddParallelCoordinates(plt *plot.Plot, trials []Trial, params []string, metric string, cmap palette.ColorMap) error {
+	pc, err := newParallel(trials, params, metric, cmap)
+	if err != nil {
+		return err
+	}
+	plt.Add(pc)
+	plt.NominalX(pc.names...)
+	plt.HideY()
+	return nil
+}
+
+// newParallel returns a parallel coordinates
+// plotter for AddParallelCoordinates.
+func newParallel(trials []Trial, params []string, metric string, cmap palette.ColorMap) (*parallel, error) {
+	if len(trials) == 0 {
+		return nil, plotter.ErrNoData
+	}
+	cols := make([][]float64, len(params)+1)
+	for j, name := range params {
+		vs, err := param(trials, name)
+		if err != nil {
+			return nil, err
+		}
+		cols[j] = vs
+	}
+	ms := make([]float64, len(trials))
+	for i, t := range trials {
+		ms[i] = t.Metric
+	}
+	if err := plotter.CheckFloats(ms...); err != nil {
+		return nil, err
+	}
+	cols[len(params)] = ms
+
+	fnt, err := vg.MakeFont(plotter.DefaultFont, vg.Points(8))
+	if err != nil {
+		return nil, err
+	}
+	pc := &parallel{
+		names:     append(append([]string(nil), params...), metric),
+		mins:      make([]float64, len(cols)),
+		maxs:      make([]float64, len(cols)),
+		lines:     make([][]float64, len(trials)),
+		colors:    make([]color.Color, len(trials)),
+		LineStyle: plotter.DefaultLineStyle,
+		AxisStyle: plotter.DefaultLineStyle,
+		TextStyle: draw.TextStyle{Font: fnt, XAlign: draw.XCenter},
+		Padding:   vg.Points(2),
+	}
+	for j, vs := range cols {
+		pc.mins[j], pc.maxs[j] = math.Inf(1), math.Inf(-1)
+		for _, v := range vs {
+			pc.mins[j] = math.Min(pc.mins[j], v)
+			pc.maxs[j] = math.Max(pc.maxs[j], v)
+		}
+	}
+
+	// Draw the lines with the largest metrics last.
+	order := make([]int, len(trials))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Stable(byMetric{order: order, ms: ms})
+	for k, i := range order {
+		l := make([]float64, len(cols))
+		for j, vs := range cols {
+			l[j] = 0.5
+			if pc.maxs[j] > pc.mins[j] {
+				l[j] = (vs[i] - pc.mins[j]) / (pc.maxs[j] - pc.mins[j])
+			}
+		}
+		pc.lines[k] = l
+		pc.colors[k] = pc.LineStyle.Color
+		if cmap != nil {
+			if clr, err := cmap.At(scale(cmap, l[len(l)-1])); err == nil {
+				pc.colors[k] = clr
+			}
+		}
+	}
+	return pc, nil
+}
+
+// scale returns the value in the range of cmap at
+// the fraction t of the way from its minimum to its
+// maximum.
+func scale(cmap palette.ColorMap, t float64) float64 {
+	lo, hi := cmap.Min(), cmap.Max()
+	return math.Max(lo, math.Min(hi, lo+t*(hi-lo)))
+}
+
+// byMetric sorts trial indices by increasing metric.
+type byMetric struct {
+	order []int
+	ms    []float64
+}
+
+func (s byMetric) Len() int           { return len(s.order) }
+func (s byMetric) Less(i, j int) bool { return s.ms[s.order[i]] < s.ms[s.order[j]] }
+func (s byMetric) Swap(i, j int)      { s.order[i], s.order[j] = s.order[j], s.order[i] }
+
+// parallel is a parallel coordinates plotter. Axis j is
+// drawn at an X value of j, with values scaled into the
+// Y range from zero to one.
+type parallel struct {
+	names      []string
+	mins, maxs []float64
+
+	// lines holds the scaled values of each
+	// line on the axes, drawn in order.
+	lines  [][]float64
+	colors []color.Color
+
+	// LineStyle is the style of the lines. Its
+	// color is replaced by the color of each line.
+	LineStyle draw.LineStyle
+
+	// AxisStyle is the style of the axes.
+	AxisStyle draw.LineStyle
+
+	// TextStyle is the style of the labels of the
+	// smallest and largest values on each axis.
+	TextStyle draw.TextStyle
+
+	// Padding is the distance between the
+	// ends of the axes and their labels.
+	Padding vg.Length
+}
+
+// Plot implements the plot.Plotter interface.
+func (pc *parallel) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	sty := pc.LineStyle
+	pts := make([]vg.Point, len(pc.names))
+	for i, l := range pc.lines {
+		for j, v := range l {
+			pts[j] = vg.Point{X: trX(float64(j)), Y: trY(v)}
+		}
+		sty.Color = pc.colors[i]
+		c.StrokeLines(sty, c.ClipLinesXY(pts)...)
+	}
+
+	top, bottom := pc.TextStyle, pc.TextStyle
+	bottom.YAlign = draw.YTop
+	for j := range pc.names {
+		x := trX(float64(j))
+		c.StrokeLine2(pc.AxisStyle, x, trY(0), x, trY(1))
+		c.FillText(top, vg.Point{X: x, Y: trY(1) + pc.Padding}, fmt.Sprintf("%.4g", pc.maxs[j]))
+		c.FillText(bottom, vg.Point{X: x, Y: trY(0) - pc.Padding}, fmt.Sprintf("%.4g", pc.mins[j]))
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (pc *parallel) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, float64(len(pc.names) - 1), 0, 1
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the labels at the ends of the axes.
+func (pc *parallel) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bottom := pc.TextStyle
+	bottom.YAlign = draw.YTop
+	var bs []plot.GlyphBox
+	for j := range pc.names {
+		x := plt.X.Norm(float64(j))
+		r := pc.TextStyle.Rectangle(fmt.Sprintf("%.4g", pc.maxs[j]))
+		r.Min.Y += pc.Padding
+		r.Max.Y += pc.Padding
+		bs = append(bs, plot.GlyphBox{X: x, Y: plt.Y.Norm(1), Rectangle: r})
+		r = bottom.Rectangle(fmt.Sprintf("%.4g", pc.mins[j]))
+		r.Min.Y -= pc.Padding
+		r.Max.Y -= pc.Padding
+		bs = append(bs, plot.GlyphBox{X: x, Y: plt.Y.Norm(0), Rectangle: r})
+	}
+	return bs
+}
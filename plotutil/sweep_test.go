@@ -0,0 +1,142 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+)
+
+// sweep returns the trials of a simulated grid search
+// over the learning rate, batch size and dropout of
+// a model, with the resulting accuracy.
+func sweep() []Trial {
+	rnd := rand.New(rand.NewSource(1))
+	var trials []Trial
+	for _, lr := range []float64{1e-4, 1e-3, 1e-2, 1e-1} {
+		for _, bs := range []float64{16, 32, 64, 128} {
+			for _, dropout := range []float64{0, 0.25, 0.5} {
+				d := math.Log10(lr) + 2.5
+				acc := 0.9 - 0.05*d*d - 0.02*math.Abs(math.Log2(bs/32)) - 0.1*dropout*dropout
+				trials = append(trials, Trial{
+					Params: map[string]float64{
+						"learning rate": lr,
+						"batch size":    bs,
+						"dropout":       dropout,
+					},
+					Metric: acc + 0.01*rnd.NormFloat64(),
+				})
+			}
+		}
+	}
+	return trials
+}
+
+func ExampleAddSweepHeatMap() {
+	plt, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	plt.Title.Text = "Best accuracy"
+
+	// Show the best accuracy over
+	// the values of the dropout.
+	best := func(ms []float64) float64 {
+		max := math.Inf(-1)
+		for _, m := range ms {
+			max = math.Max(max, m)
+		}
+		return max
+	}
+	err = AddSweepHeatMap(plt, sweep(), "learning rate", "batch size", best, palette.Heat(12, 1))
+	if err != nil {
+		panic(err)
+	}
+	plt.Save(4*vg.Inch, 4*vg.Inch, "sweep_heatmap.png")
+}
+
+func ExampleAddParallelCoordinates() {
+	plt, err := plot.New()
+	if err != nil {
+		panic(err)
+	}
+	plt.Title.Text = "Hyperparameter sweep"
+
+	cmap := palette.Linear(palette.Radial(3, palette.Blue, palette.Red, 1))
+	params := []string{"learning rate", "batch size", "dropout"}
+	err = AddParallelCoordinates(plt, sweep(), params, "accuracy", cmap)
+	if err != nil {
+		panic(err)
+	}
+	plt.Save(6*vg.Inch, 4*vg.Inch, "sweep_parallel.png")
+}
+
+func TestSlice(t *testing.T) {
+	trials := sweep()
+	s := Slice(trials, map[string]float64{"dropout": 0.25, "batch size": 32})
+	if len(s) != 4 {
+		t.Fatalf("unexpected number of trials: got:%d want:4", len(s))
+	}
+	for _, tr := range s {
+		if tr.Params["dropout"] != 0.25 || tr.Params["batch size"] != 32 {
+			t.Errorf("unexpected trial in slice: %v", tr.Params)
+		}
+	}
+}
+
+func TestSweepGrid(t *testing.T) {
+	trials := []Trial{
+		{Params: map[string]float64{"a": 1, "b": 10, "c": 0}, Metric: 1},
+		{Params: map[string]float64{"a": 1, "b": 10, "c": 1}, Metric: 3},
+		{Params: map[string]float64{"a": 2, "b": 10, "c": 0}, Metric: 4},
+		{Params: map[string]float64{"a": 2, "b": 20, "c": 0}, Metric: 5},
+	}
+	g, err := newSweepGrid(trials, "a", "b", mean)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c, r := g.Dims(); c != 2 || r != 2 {
+		t.Fatalf("unexpected grid dimensions: got:%d×%d want:2×2", c, r)
+	}
+	if !reflect.DeepEqual(g.xs, []float64{1, 2}) || !reflect.DeepEqual(g.ys, []float64{10, 20}) {
+		t.Errorf("unexpected hyperparameter values: got:%v %v", g.xs, g.ys)
+	}
+	got := []float64{g.Z(0, 0), g.Z(1, 0), g.Z(1, 1)}
+	if want := []float64{2, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected values: got:%v want:%v", got, want)
+	}
+	if z := g.Z(0, 1); !math.IsNaN(z) {
+		t.Errorf("unexpected value for empty cell: got:%v want:NaN", z)
+	}
+
+	if _, err := newSweepGrid(trials, "a", "d", mean); err == nil {
+		t.Error("expected error for missing hyperparameter")
+	}
+}
+
+func TestParallel(t *testing.T) {
+	trials := []Trial{
+		{Params: map[string]float64{"a": 1, "b": 5}, Metric: 3},
+		{Params: map[string]float64{"a": 3, "b": 5}, Metric: 1},
+	}
+	pc, err := newParallel(trials, []string{"a", "b"}, "m", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"a", "b", "m"}; !reflect.DeepEqual(pc.names, want) {
+		t.Errorf("unexpected axis names: got:%v want:%v", pc.names, want)
+	}
+	// Lines are in order of increasing metric, and
+	// constant values are placed mid-axis.
+	if want := [][]float64{{1, 0.5, 0}, {0, 0.5, 1}}; !reflect.DeepEqual(pc.lines, want) {
+		t.Errorf("unexpected lines: got:%v want:%v", pc.lines, want)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPoll is the interval at which a Tail
+	// checks its file when its Poll is zero.
+	DefaultPoll = 500 * time.Millisecond
+
+	// DefaultDebounce is the time for which the file of a
+	// Tail must be unchanged before it is redrawn when its
+	// Debounce is zero.
+	DefaultDebounce = time.Second
+)
+
+// Tail follows a log file of records, written in CSV or
+// NDJSON by a long-running experiment, as it grows. The
+// numeric fields of the records appended to the file are
+// recorded in a History, and the plots of the History are
+// redrawn once the file stops changing, so that the
+// experiment may be monitored as it runs.
+type Tail struct {
+	// Path is the path of the file. The file
+	// need not exist when it is first followed.
+	// If the file is truncated, it is followed
+	// again from its start.
+	Path string
+
+	// NDJSON is whether the file holds a JSON object
+	// on each line. Otherwise the file is CSV, with
+	// the names of the fields on its first line.
+	NDJSON bool
+
+	// Fields holds the names of the fields recorded
+	// as metrics, in order. If Fields is nil, all of
+	// the numeric fields are recorded, in the order of
+	// the columns of a CSV file or of their names for
+	// an NDJSON file.
+	Fields []string
+
+	// RunField is the name of the field holding the
+	// index of the training run of each record. The
+	// run of the records is zero if RunField is empty
+	// or the field is missing.
+	RunField string
+
+	// Poll is the interval at which the file is
+	// checked for new records.
+	Poll time.Duration
+
+	// Debounce is the time for which the file must be
+	// unchanged after new records are read before
+	// Render is called, so that the plots are not
+	// redrawn for each record of a burst of them.
+	Debounce time.Duration
+
+	// Render, if not nil, redraws the plots
+	// of the History, such as by saving them.
+	Render func() error
+}
+
+// Follow records the records of the file in h as they are
+// appended to it, calling Render when they have been, until
+// stop is closed or an error occurs. Each numeric field of a
+// record is recorded as the value of the metric of its name
+// for the epoch following the last one of its run, as by
+// History.Record. Lines without a final newline are held
+// back until they are complete.
+func (t *Tail) Follow(h *History, stop <-chan struct{}) error {
+	poll := t.Poll
+	if poll <= 0 {
+		poll = DefaultPoll
+	}
+	debounce := t.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	tick := time.NewTicker(poll)
+	defer tick.Stop()
+
+	f := &tailFile{Tail: t}
+	var (
+		pending bool
+		changed time.Time
+	)
+	for {
+		n, err := f.read(h)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if n > 0 {
+			pending, changed = true, now
+		}
+		if pending && now.Sub(changed) >= debounce {
+			pending = false
+			if err := t.render(); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stop:
+			if pending {
+				return t.render()
+			}
+			return nil
+		case <-tick.C:
+		}
+	}
+}
+
+// render calls Render if it is not nil.
+func (t *Tail) render() error {
+	if t.Render == nil {
+		return nil
+	}
+	return t.Render()
+}
+
+// tailFile is the state of a followed file.
+type tailFile struct {
+	*Tail
+
+	// offset is the length of the file read.
+	offset int64
+
+	// partial is the last line read,
+	// if it is not yet complete.
+	partial []byte
+
+	// header holds the field names
+	// of a CSV file.
+	header []string
+}
+
+// read records the complete lines appended to the file
+// since it was last read in h, returning the number of
+// records read.
+func (f *tailFile) read(h *History) (int, error) {
+	file, err := os.Open(f.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if info.Size() < f.offset {
+		// The file was truncated or replaced.
+		f.offset, f.partial, f.header = 0, nil, nil
+	}
+	if info.Size() == f.offset {
+		return 0, nil
+	}
+	if _, err := file.Seek(f.offset, os.SEEK_SET); err != nil {
+		return 0, err
+	}
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return 0, err
+	}
+	f.offset += int64(len(b))
+
+	b = append(f.partial, b...)
+	end := bytes.LastIndexByte(b, '\n') + 1
+	f.partial = append([]byte(nil), b[end:]...)
+	var n int
+	for _, line := range strings.Split(string(b[:end]), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		ok, err := f.record(h, line)
+		if err != nil {
+			return n, err
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// record records the fields of a line in h, returning
+// whether the line is a record rather than a header.
+func (f *tailFile) record(h *History, line string) (bool, error) {
+	var (
+		names  []string
+		values = make(map[string]float64)
+	)
+	if f.NDJSON {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			return false, fmt.Errorf("Invalid NDJSON record %q: %v", line, err)
+		}
+		for name, v := range obj {
+			if v, ok := v.(float64); ok {
+				names = append(names, name)
+				values[name] = v
+			}
+		}
+		sort.Strings(names)
+	} else {
+		fields, err := csv.NewReader(strings.NewReader(line)).Read()
+		if err != nil {
+			return false, fmt.Errorf("Invalid CSV record %q: %v", line, err)
+		}
+		if f.header == nil {
+			f.header = make([]string, len(fields))
+			for i, name := range fields {
+				f.header[i] = strings.TrimSpace(name)
+			}
+			return false, nil
+		}
+		for i, field := range fields {
+			if i >= len(f.header) {
+				break
+			}
+			// Empty and non-numeric fields are
+			// skipped, so that metrics may be
+			// logged at different intervals.
+			v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				continue
+			}
+			names = append(names, f.header[i])
+			values[f.header[i]] = v
+		}
+	}
+
+	var run int
+	if v, ok := values[f.RunField]; ok && f.RunField != "" {
+		if v < 0 || v != float64(int(v)) {
+			return false, fmt.Errorf("Invalid run index %v", v)
+		}
+		run = int(v)
+	}
+	if f.Fields != nil {
+		names = f.Fields
+	}
+	for _, name := range names {
+		v, ok := values[name]
+		if !ok || name == f.RunField {
+			continue
+		}
+		h.record(Metric{Name: name, Run: run, Value: v})
+	}
+	return true, nil
+}
@@ -0,0 +1,110 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTail(t *testing.T) {
+	for _, test := range []struct {
+		tail   Tail
+		writes []string
+		want   map[string][][]float64
+	}{
+		{
+			tail: Tail{Fields: []string{"loss", "val_loss"}},
+			writes: []string{
+				"epoch,loss,val_loss\n1,4,5\n",
+				"2,3,\n3,2",
+				",4\n",
+			},
+			want: map[string][][]float64{
+				"loss":     {{4, 3, 2}},
+				"val_loss": {{5, 4}},
+			},
+		},
+		{
+			tail: Tail{NDJSON: true, RunField: "run"},
+			writes: []string{
+				`{"run": 0, "loss": 4, "note": "start"}` + "\n",
+				`{"run": 1, "loss": 6}` + "\n" + `{"run": 0, "loss": 2}` + "\n",
+			},
+			want: map[string][][]float64{
+				"loss": {{4, 2}, {6}},
+			},
+		},
+	} {
+		dir, err := ioutil.TempDir("", "tail")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		tail := test.tail
+		tail.Path = filepath.Join(dir, "log")
+		tail.Poll = time.Millisecond
+		tail.Debounce = 5 * time.Millisecond
+		rendered := make(chan struct{}, 10)
+		tail.Render = func() error {
+			rendered <- struct{}{}
+			return nil
+		}
+
+		h := &History{}
+		stop := make(chan struct{})
+		done := make(chan error)
+		go func() { done <- tail.Follow(h, stop) }()
+
+		file, err := os.Create(tail.Path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, w := range test.writes {
+			if _, err := file.WriteString(w); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			select {
+			case <-rendered:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no render after writing %q", w)
+			}
+		}
+		file.Close()
+		close(stop)
+		if err := <-done; err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got := make(map[string][][]float64)
+		for _, m := range h.metrics {
+			got[m.name] = m.runs
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("unexpected history: got:%v want:%v", got, test.want)
+		}
+	}
+}
+
+func TestTailInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tail")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log")
+	if err := ioutil.WriteFile(path, []byte("{\"loss\": 1}\nnot json\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tail := Tail{Path: path, NDJSON: true, Poll: time.Millisecond}
+	if err := tail.Follow(&History{}, make(chan struct{})); err == nil {
+		t.Error("expected error for invalid record")
+	}
+}
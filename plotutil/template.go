@@ -0,0 +1,182 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Template is a styled plot with named data slots, defined
+// once and used to make many plots by binding a different
+// dataset to each slot, as for the figures of a report that
+// differ only in their data.
+//
+// The plotters of a Template, those added with Add and those
+// made from the data bound to its slots, are added to each
+// plot in the order in which they were added to the Template,
+// so that guides such as grids may be drawn below the data and
+// annotations above it.
+type Template struct {
+	// Style, if not nil, is called with each new
+	// plot before its plotters are added, to set
+	// its title, axis labels and tickers.
+	Style func(*plot.Plot)
+
+	layers []layer
+	slots  map[string]bool
+}
+
+// layer is a set of plotters of a Template: either
+// fixed plotters, or the plotters of a data slot.
+type layer struct {
+	plotters []plot.Plotter
+
+	// slot is the name of the data slot,
+	// whose plotters are made by build.
+	slot  string
+	build func(data interface{}) ([]plot.Plotter, error)
+
+	// legend specifies whether the first plotter of the
+	// slot is added to the legend under the slot name.
+	legend bool
+}
+
+// NewTemplate returns a Template whose
+// plots are styled by style.
+func NewTemplate(style func(*plot.Plot)) *Template {
+	return &Template{Style: style, slots: make(map[string]bool)}
+}
+
+// Add adds plotters, such as grids, reference lines and
+// annotations, that are added to every plot made from the
+// template.
+func (t *Template) Add(ps ...plot.Plotter) {
+	t.layers = append(t.layers, layer{plotters: ps})
+}
+
+// Slot adds a named data slot to the template. The plotters
+// of the slot are made by build from the data bound to it.
+// Slot panics if the template already has a slot of the
+// same name.
+func (t *Template) Slot(name string, build func(data interface{}) ([]plot.Plotter, error)) {
+	t.addSlot(layer{slot: name, build: build})
+}
+
+// addSlot adds the data slot of the layer l.
+func (t *Template) addSlot(l layer) {
+	if t.slots == nil {
+		t.slots = map[string]bool{}
+	}
+	if t.slots[l.slot] {
+		panic(fmt.Sprintf("plotutil: duplicate template slot %q", l.slot))
+	}
+	t.slots[l.slot] = true
+	t.layers = append(t.layers, l)
+}
+
+// Line adds a named data slot to the template drawn as
+// a Line of the given style, with a legend entry named
+// by the slot. The data bound to the slot must be a
+// plotter.XYer.
+func (t *Template) Line(name string, sty draw.LineStyle) {
+	t.addSlot(layer{
+		slot: name,
+		build: func(data interface{}) ([]plot.Plotter, error) {
+			xys, ok := data.(plotter.XYer)
+			if !ok {
+				return nil, fmt.Errorf("Slot %s needs a plotter.XYer, got %T", name, data)
+			}
+			l, err := plotter.NewLine(xys)
+			if err != nil {
+				return nil, err
+			}
+			l.LineStyle = sty
+			return []plot.Plotter{l}, nil
+		},
+		legend: true,
+	})
+}
+
+// Scatter adds a named data slot to the template drawn
+// as a Scatter of the given style, with a legend entry
+// named by the slot. The data bound to the slot must be
+// a plotter.XYer.
+func (t *Template) Scatter(name string, sty draw.GlyphStyle) {
+	t.addSlot(layer{
+		slot: name,
+		build: func(data interface{}) ([]plot.Plotter, error) {
+			xys, ok := data.(plotter.XYer)
+			if !ok {
+				return nil, fmt.Errorf("Slot %s needs a plotter.XYer, got %T", name, data)
+			}
+			s, err := plotter.NewScatter(xys)
+			if err != nil {
+				return nil, err
+			}
+			s.GlyphStyle = sty
+			return []plot.Plotter{s}, nil
+		},
+		legend: true,
+	})
+}
+
+// Slots returns the names of the data
+// slots of the template, in sorted order.
+func (t *Template) Slots() []string {
+	names := make([]string, 0, len(t.slots))
+	for name := range t.slots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New returns a new plot made from the template, with
+// the data in bind bound to the slots of the same names.
+// It returns an error if a slot has no data bound to it,
+// if bind names a slot that the template does not have,
+// or if the plotters of a slot cannot be made from its
+// data.
+func (t *Template) New(bind map[string]interface{}) (*plot.Plot, error) {
+	for name := range bind {
+		if !t.slots[name] {
+			return nil, fmt.Errorf("No template slot named %s", name)
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	if t.Style != nil {
+		t.Style(p)
+	}
+	for _, l := range t.layers {
+		if l.build == nil {
+			p.Add(l.plotters...)
+			continue
+		}
+		data, ok := bind[l.slot]
+		if !ok {
+			return nil, fmt.Errorf("No data bound to template slot %s", l.slot)
+		}
+		ps, err := l.build(data)
+		if err != nil {
+			return nil, err
+		}
+		p.Add(ps...)
+		if l.legend && len(ps) > 0 {
+			if th, ok := ps[0].(plot.Thumbnailer); ok {
+				p.Legend.Add(l.slot, th)
+			}
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,123 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+func ExampleTemplate() {
+	// The template is defined once, with a grid
+	// below the data and a threshold above it.
+	t := NewTemplate(func(p *plot.Plot) {
+		p.X.Label.Text = "Day"
+		p.Y.Label.Text = "Requests per second"
+		p.Legend.Top = true
+	})
+	t.Add(plotter.NewGrid())
+	t.Line("model", draw.LineStyle{Color: color.Gray{128}, Width: vg.Points(1)})
+	t.Scatter("observed", draw.GlyphStyle{Color: Color(0), Radius: vg.Points(2), Shape: draw.CircleGlyph{}})
+	threshold := plotter.NewFunction(func(float64) float64 { return 90 })
+	threshold.Color = Color(1)
+	threshold.Dashes = Dashes(1)
+	t.Add(threshold)
+
+	// A figure is made for each service by
+	// binding its data to the slots.
+	for i, service := range []string{"api", "search"} {
+		model := make(plotter.XYs, 8)
+		observed := make(plotter.XYs, 8)
+		for d := range model {
+			model[d].X, model[d].Y = float64(d), float64(40+10*i+5*d)
+			observed[d].X, observed[d].Y = float64(d), model[d].Y+float64(d%3*4-4)
+		}
+		p, err := t.New(map[string]interface{}{
+			"model":    model,
+			"observed": observed,
+		})
+		if err != nil {
+			panic(err)
+		}
+		p.Title.Text = service
+		if err := p.Save(4*vg.Inch, 3*vg.Inch, fmt.Sprintf("template-%s.png", service)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	var styled int
+	tmpl := NewTemplate(func(p *plot.Plot) {
+		p.X.Label.Text = "X"
+		styled++
+	})
+	grid := plotter.NewGrid()
+	tmpl.Add(grid)
+	tmpl.Line("a", draw.LineStyle{Color: color.Black, Width: 1})
+	tmpl.Scatter("b", draw.GlyphStyle{Color: color.Black, Radius: 1, Shape: draw.CrossGlyph{}})
+	tmpl.Slot("c", func(data interface{}) ([]plot.Plotter, error) {
+		f, ok := data.(func(float64) float64)
+		if !ok {
+			return nil, fmt.Errorf("not a function: %T", data)
+		}
+		return []plot.Plotter{plotter.NewFunction(f)}, nil
+	})
+
+	if got, want := tmpl.Slots(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected slots: got:%q want:%q", got, want)
+	}
+
+	xys := plotter.XYs{{0, 0}, {1, 2}}
+	for i := 0; i < 2; i++ {
+		p, err := tmpl.New(map[string]interface{}{
+			"a": xys,
+			"b": xys,
+			"c": func(x float64) float64 { return x },
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p.X.Label.Text != "X" {
+			t.Errorf("plot %d not styled", i)
+		}
+		if p.X.Max != 1 || p.Y.Max != 2 {
+			t.Errorf("unexpected plot %d range: got X max %v Y max %v", i, p.X.Max, p.Y.Max)
+		}
+	}
+	if styled != 2 {
+		t.Errorf("unexpected number of styled plots: got:%d want:2", styled)
+	}
+
+	for _, test := range []struct {
+		bind map[string]interface{}
+		desc string
+	}{
+		{bind: map[string]interface{}{"a": xys, "b": xys}, desc: "unbound slot"},
+		{bind: map[string]interface{}{"a": xys, "b": xys, "c": nil, "d": xys}, desc: "unknown slot"},
+		{bind: map[string]interface{}{"a": 1, "b": xys, "c": nil}, desc: "wrong data type"},
+		{bind: map[string]interface{}{"a": xys, "b": xys, "c": 1}, desc: "wrong custom data type"},
+	} {
+		if _, err := tmpl.New(test.bind); err == nil {
+			t.Errorf("expected error for %s", test.desc)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic for duplicate slot")
+			}
+		}()
+		tmpl.Line("a", draw.LineStyle{})
+	}()
+}
@@ -0,0 +1,127 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"image/color"
+	"sync"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// DarkLightness is the least CIELAB lightness, between 0 and
+// 100, of the dark variants of the colors registered without
+// a variant by RegisterDarkColors.
+const DarkLightness = 45
+
+// Theme is a set of colors for the parts of a plot other than
+// its data.
+type Theme struct {
+	// Background is the background color of the plot.
+	Background color.Color
+
+	// Foreground is the color of the text, the
+	// axes and the tick marks of the plot.
+	Foreground color.Color
+
+	// Dark specifies whether the background is dark,
+	// so that applying the theme replaces the colors
+	// registered with RegisterDarkColors by their
+	// dark variants.
+	Dark bool
+}
+
+var (
+	// LightTheme is the theme of black
+	// on white of a new plot.
+	LightTheme = Theme{Background: color.White, Foreground: color.Black}
+
+	// DarkTheme is a theme of light gray
+	// on a background of dark gray.
+	DarkTheme = Theme{Background: rgb(30, 30, 30), Foreground: rgb(220, 220, 220), Dark: true}
+)
+
+// Apply applies the theme to p, coloring its background, its
+// text and its axes. It also sets the colors registered with
+// RegisterDarkColors, such as DefaultColors, to their dark
+// variants if the theme is dark, and to their original colors
+// otherwise, so that the colors of the plotters made after
+// applying a theme suit its background. Apply may be used as
+// the Theme of a report.Report.
+func (t Theme) Apply(p *plot.Plot) {
+	p.BackgroundColor = t.Background
+	p.Title.Color = t.Foreground
+	p.Subtitle.Color = t.Foreground
+	p.Caption.Color = t.Foreground
+	for _, a := range []*plot.Axis{&p.X, &p.Y} {
+		a.Label.Color = t.Foreground
+		a.LineStyle.Color = t.Foreground
+		a.Tick.Label.Color = t.Foreground
+		a.Tick.LineStyle.Color = t.Foreground
+		if a.Tick.MinorLineStyle.Color != nil {
+			a.Tick.MinorLineStyle.Color = t.Foreground
+		}
+	}
+	p.Legend.Color = t.Foreground
+	p.Legend.Title.Color = t.Foreground
+
+	darkColors.Lock()
+	for _, r := range darkColors.registered {
+		if t.Dark {
+			*r.colors = r.dark
+		} else {
+			*r.colors = r.light
+		}
+	}
+	darkColors.Unlock()
+}
+
+// darkColors holds the colors registered
+// by RegisterDarkColors.
+var darkColors struct {
+	sync.Mutex
+	registered []darkVariant
+}
+
+// darkVariant is a set of registered colors
+// and its light and dark variants.
+type darkVariant struct {
+	colors      *[]color.Color
+	light, dark []color.Color
+}
+
+func init() {
+	RegisterDarkColors(&DefaultColors, nil)
+}
+
+// RegisterDarkColors registers dark as the variant for dark
+// backgrounds of the colors held in colors, which replaces them
+// when a dark Theme is applied. The colors held when they are
+// registered are restored when a Theme that is not dark is
+// applied. If dark is nil, the variant is made of the colors
+// returned by palette.DarkColor with the least lightness
+// DarkLightness. Registering colors again replaces their
+// variant. DefaultColors is registered with a variant made by
+// palette.DarkColor.
+func RegisterDarkColors(colors *[]color.Color, dark []color.Color) {
+	light := *colors
+	if dark == nil {
+		dark = make([]color.Color, len(light))
+		for i, c := range light {
+			dark[i] = palette.DarkColor(c, DarkLightness)
+		}
+	}
+
+	darkColors.Lock()
+	defer darkColors.Unlock()
+	for i, r := range darkColors.registered {
+		if r.colors == colors {
+			darkColors.registered[i].dark = dark
+			return
+		}
+	}
+	darkColors.registered = append(darkColors.registered, darkVariant{colors: colors, light: light, dark: dark})
+}
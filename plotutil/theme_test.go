@@ -0,0 +1,55 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+func TestTheme(t *testing.T) {
+	light := DefaultColors
+	custom := []color.Color{color.Black, color.White}
+	lightCustom := custom
+	RegisterDarkColors(&custom, []color.Color{color.White, color.Black})
+	defer func() {
+		p, _ := plot.New()
+		LightTheme.Apply(p)
+	}()
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	DarkTheme.Apply(p)
+	if p.BackgroundColor != DarkTheme.Background {
+		t.Errorf("unexpected background: got:%v want:%v", p.BackgroundColor, DarkTheme.Background)
+	}
+	for _, c := range []color.Color{p.Title.Color, p.X.Color, p.Y.Tick.Label.Color, p.Y.Tick.Color, p.Legend.Color} {
+		if c != DarkTheme.Foreground {
+			t.Errorf("unexpected foreground: got:%v want:%v", c, DarkTheme.Foreground)
+		}
+	}
+	if reflect.DeepEqual(DefaultColors, light) {
+		t.Error("DefaultColors not replaced by dark variant")
+	}
+	if !reflect.DeepEqual(custom, []color.Color{color.White, color.Black}) {
+		t.Errorf("unexpected registered colors: %v", custom)
+	}
+
+	LightTheme.Apply(p)
+	if p.BackgroundColor != color.White || p.X.Color != color.Black {
+		t.Error("light theme not applied")
+	}
+	if !reflect.DeepEqual(DefaultColors, light) {
+		t.Error("DefaultColors not restored")
+	}
+	if !reflect.DeepEqual(custom, lightCustom) {
+		t.Errorf("registered colors not restored: %v", custom)
+	}
+}
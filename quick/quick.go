@@ -0,0 +1,109 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package quick provides functions that save a plot of some
+// data in a single call, with sensible defaults, for scripts
+// and tests that just need a picture of their data. Plots that
+// need more than the defaults should be made with the plot and
+// plotter packages.
+//
+// The plots are saved in the format given by the extension of
+// the file name, as by the Save method of plot.Plot.
+package quick
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/plotutil"
+	"github.com/gonum/plot/vg"
+)
+
+var (
+	// Width and Height are the size of the saved plots.
+	Width  = 4 * vg.Inch
+	Height = 3 * vg.Inch
+
+	// Theme is the theme applied to the plots.
+	Theme = plotutil.LightTheme
+)
+
+// heatColors is the number of colors of the
+// palette of the ColorMap of a heat map.
+const heatColors = 256
+
+// Line saves a line plot of the points with the given X and
+// Y coordinates to the named file.
+func Line(xs, ys []float64, file string) error {
+	if len(xs) != len(ys) {
+		return errors.New("X/Y length mismatch")
+	}
+	xys := make(plotter.XYs, len(xs))
+	for i := range xs {
+		xys[i].X, xys[i].Y = xs[i], ys[i]
+	}
+	p, err := newPlot()
+	if err != nil {
+		return err
+	}
+	l, err := plotter.NewLine(xys)
+	if err != nil {
+		return err
+	}
+	l.Color = plotutil.Color(0)
+	p.Add(plotter.NewGrid(), l)
+	return p.Save(Width, Height, file)
+}
+
+// Heatmap saves a heat map of the values of g to the named
+// file, colored by cm over the range of the values. If cm is
+// nil, the values are colored by a heat palette.
+func Heatmap(g plotter.GridXYZ, cm palette.ColorMap, file string) error {
+	var pal palette.Palette
+	if cm != nil {
+		pal = cm.Palette(heatColors)
+	} else {
+		pal = palette.Heat(heatColors, 1)
+	}
+	p, err := newPlot()
+	if err != nil {
+		return err
+	}
+	p.Add(plotter.NewHeatMap(g, pal))
+	return p.Save(Width, Height, file)
+}
+
+// Hist saves a histogram of the samples in the given number
+// of bins to the named file. If bins is not positive, the
+// number of bins is the square root of the number of samples.
+func Hist(samples []float64, bins int, file string) error {
+	if bins <= 0 {
+		bins = int(math.Ceil(math.Sqrt(float64(len(samples)))))
+	}
+	p, err := newPlot()
+	if err != nil {
+		return err
+	}
+	h, err := plotter.NewHist(plotter.Values(samples), bins)
+	if err != nil {
+		return err
+	}
+	h.FillColor = plotutil.Color(0)
+	p.Add(h)
+	p.Y.Label.Text = "Count"
+	return p.Save(Width, Height, file)
+}
+
+// newPlot returns a new plot with Theme applied.
+func newPlot() (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	Theme.Apply(p)
+	return p, nil
+}
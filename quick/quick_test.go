@@ -0,0 +1,77 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quick
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot/palette"
+)
+
+func ExampleLine() {
+	xs := make([]float64, 100)
+	ys := make([]float64, 100)
+	for i := range xs {
+		xs[i] = float64(i) / 10
+		ys[i] = math.Sin(xs[i])
+	}
+	if err := Line(xs, ys, "sine.png"); err != nil {
+		panic(err)
+	}
+}
+
+// grid is a GridXYZ of a matrix
+// with unit spaced coordinates.
+type grid struct{ mat64.Matrix }
+
+func (g grid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g grid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g grid) X(c int) float64    { return float64(c) }
+func (g grid) Y(r int) float64    { return float64(r) }
+
+func TestQuick(t *testing.T) {
+	dir, err := ioutil.TempDir("", "quick")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	samples := []float64{1, 2, 2, 3, 3, 3, 4, 4, 5}
+	g := grid{mat64.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})}
+	for _, test := range []struct {
+		name string
+		save func(string) error
+	}{
+		{name: "line.png", save: func(f string) error { return Line(samples, samples, f) }},
+		{name: "line.svg", save: func(f string) error { return Line(samples, samples, f) }},
+		{name: "heatmap.png", save: func(f string) error { return Heatmap(g, nil, f) }},
+		{name: "heatmap.pdf", save: func(f string) error {
+			return Heatmap(g, palette.Hypsometric(), f)
+		}},
+		{name: "hist.png", save: func(f string) error { return Hist(samples, 4, f) }},
+		{name: "sqrtbins.png", save: func(f string) error { return Hist(samples, 0, f) }},
+	} {
+		path := filepath.Join(dir, test.name)
+		if err := test.save(path); err != nil {
+			t.Errorf("unexpected error saving %s: %v", test.name, err)
+			continue
+		}
+		if fi, err := os.Stat(path); err != nil || fi.Size() == 0 {
+			t.Errorf("%s not saved", test.name)
+		}
+	}
+
+	if err := Line([]float64{1, 2}, []float64{1}, filepath.Join(dir, "bad.png")); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+	if err := Line([]float64{1}, []float64{1}, filepath.Join(dir, "bad.unknown")); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
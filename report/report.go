@@ -0,0 +1,370 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package report assembles plots into reports, such as the
+// output of automated jobs: an ordered list of figures with
+// captions, grouped into sections with headings, written as
+// a multi-page PDF document or as a single HTML page with the
+// figures embedded as SVG.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgpdf"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+// Figure is a plot in a report.
+type Figure struct {
+	// Plot is the plot of the figure.
+	Plot *plot.Plot
+
+	// Caption is the text drawn below the
+	// plot. If Caption is empty, no caption
+	// is drawn.
+	Caption string
+
+	// Width and Height are the size of the
+	// plot. If either is zero, the figure size
+	// of the report is used. In PDF reports,
+	// figures that do not fit in a page are
+	// scaled down to fit.
+	Width, Height vg.Length
+}
+
+// entry is a section heading or a
+// figure in a report.
+type entry struct {
+	heading string
+	figure  *Figure
+}
+
+// Report is an ordered list of section
+// headings and figures.
+type Report struct {
+	// Title is the title of the report, drawn
+	// at the top of the first page. If Title is
+	// empty, no title is drawn.
+	Title string
+
+	// PageWidth and PageHeight are the size
+	// of the pages of PDF reports.
+	PageWidth, PageHeight vg.Length
+
+	// Margin is the distance between the
+	// content of a page and its edges.
+	Margin vg.Length
+
+	// Spacing is the vertical distance between
+	// the title, headings and figures of a page.
+	Spacing vg.Length
+
+	// FigureWidth and FigureHeight are the size
+	// of the figures that do not give their own.
+	FigureWidth, FigureHeight vg.Length
+
+	// TitleStyle, HeadingStyle and CaptionStyle
+	// are the styles of the text of the report.
+	TitleStyle, HeadingStyle, CaptionStyle draw.TextStyle
+
+	// Theme, if not nil, is called with the plot
+	// of each figure as it is added to the report,
+	// to give the plots of the report a consistent
+	// style.
+	Theme func(*plot.Plot)
+
+	entries []entry
+}
+
+// New returns a new Report with the given title, with A4
+// pages and the default font.
+func New(title string) (*Report, error) {
+	titleFont, err := vg.MakeFont(plot.DefaultFont, 18)
+	if err != nil {
+		return nil, err
+	}
+	headingFont, err := vg.MakeFont(plot.DefaultFont, 14)
+	if err != nil {
+		return nil, err
+	}
+	captionFont, err := vg.MakeFont(plot.DefaultFont, 10)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Title:        title,
+		PageWidth:    210 * vg.Millimeter,
+		PageHeight:   297 * vg.Millimeter,
+		Margin:       20 * vg.Millimeter,
+		Spacing:      vg.Points(12),
+		FigureWidth:  5 * vg.Inch,
+		FigureHeight: 3.5 * vg.Inch,
+		TitleStyle: draw.TextStyle{
+			Font:   titleFont,
+			XAlign: draw.XCenter,
+			YAlign: draw.YTop,
+		},
+		HeadingStyle: draw.TextStyle{
+			Font:   headingFont,
+			XAlign: draw.XLeft,
+			YAlign: draw.YTop,
+		},
+		CaptionStyle: draw.TextStyle{
+			Font:   captionFont,
+			XAlign: draw.XCenter,
+			YAlign: draw.YTop,
+		},
+	}, nil
+}
+
+// Section starts a new section of the report
+// with the given heading. The figures added
+// after it belong to the section.
+func (r *Report) Section(heading string) {
+	r.entries = append(r.entries, entry{heading: heading})
+}
+
+// Add adds figures to the report, in order,
+// applying the Theme of the report to their plots.
+func (r *Report) Add(figs ...Figure) {
+	for i := range figs {
+		f := figs[i]
+		if r.Theme != nil {
+			r.Theme(f.Plot)
+		}
+		r.entries = append(r.entries, entry{figure: &f})
+	}
+}
+
+// size returns the size of the figure.
+func (r *Report) size(f *Figure) (w, h vg.Length) {
+	if f.Width == 0 || f.Height == 0 {
+		return r.FigureWidth, r.FigureHeight
+	}
+	return f.Width, f.Height
+}
+
+// item is the text or figure of an entry
+// placed at a height on a page.
+type item struct {
+	entry
+
+	// text is the wrapped text of
+	// the title, heading or caption.
+	text string
+
+	// top is the position of the
+	// top of the item on its page.
+	top vg.Length
+
+	// height is the height of the text of a title
+	// or a heading, or the height of the plot of
+	// a figure, and width is the width of the plot.
+	width, height vg.Length
+
+	// caption is the height of the caption of
+	// a figure, with the space above it.
+	caption vg.Length
+}
+
+// textHeight returns the height of the
+// text drawn in the given style.
+func textHeight(sty draw.TextStyle, txt string) vg.Length {
+	if txt == "" {
+		return 0
+	}
+	return sty.Height(txt) - sty.Font.Extents().Descent
+}
+
+// layout returns the items of the report placed
+// on its pages. A heading is kept on the same page
+// as the figure that follows it, and a figure is
+// kept on the same page as its caption.
+func (r *Report) layout() [][]item {
+	width := r.PageWidth - 2*r.Margin
+	height := r.PageHeight - 2*r.Margin
+	top := r.PageHeight - r.Margin
+	bottom := r.Margin
+
+	var (
+		pages [][]item
+		page  []item
+		y     = top
+	)
+	// place adds items to the current page, starting
+	// a new page if they do not fit on the current one.
+	place := func(items ...item) {
+		var h vg.Length
+		for _, it := range items {
+			h += it.height + it.caption
+		}
+		h += vg.Length(len(items)-1) * r.Spacing
+		if len(page) > 0 && y-h < bottom {
+			pages = append(pages, page)
+			page = nil
+			y = top
+		}
+		for _, it := range items {
+			it.top = y
+			page = append(page, it)
+			y -= it.height + it.caption + r.Spacing
+		}
+	}
+
+	if r.Title != "" {
+		txt := wrap(r.TitleStyle, r.Title, width)
+		place(item{text: txt, height: textHeight(r.TitleStyle, txt)})
+	}
+	for i := 0; i < len(r.entries); i++ {
+		e := r.entries[i]
+		if e.figure == nil {
+			txt := wrap(r.HeadingStyle, e.heading, width)
+			heading := item{entry: e, text: txt, height: textHeight(r.HeadingStyle, txt)}
+			if i+1 < len(r.entries) && r.entries[i+1].figure != nil {
+				i++
+				place(heading, r.figureItem(r.entries[i], width, height-heading.height-r.Spacing))
+				continue
+			}
+			place(heading)
+			continue
+		}
+		place(r.figureItem(e, width, height))
+	}
+	if len(page) > 0 {
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// figureItem returns the item of a figure scaled
+// down to fit within the given width and height,
+// with its caption.
+func (r *Report) figureItem(e entry, width, height vg.Length) item {
+	w, h := r.size(e.figure)
+	txt := wrap(r.CaptionStyle, e.figure.Caption, width)
+	var caption vg.Length
+	if txt != "" {
+		caption = r.Spacing/2 + textHeight(r.CaptionStyle, txt)
+	}
+	if w > width {
+		w, h = width, h*width/w
+	}
+	if avail := height - caption; h > avail && avail > 0 {
+		w, h = w*avail/h, avail
+	}
+	return item{entry: e, text: txt, width: w, height: h, caption: caption}
+}
+
+// WritePDF writes the report to w as a PDF document,
+// starting a new page when the next heading or figure
+// does not fit on the current page.
+func (r *Report) WritePDF(w io.Writer) error {
+	c := vgpdf.New(r.PageWidth, r.PageHeight)
+	center := r.PageWidth / 2
+	for i, page := range r.layout() {
+		if i > 0 {
+			c.NextPage()
+		}
+		dc := draw.NewCanvas(c, r.PageWidth, r.PageHeight)
+		for _, it := range page {
+			switch {
+			case it.figure != nil:
+				min := vg.Point{X: center - it.width/2, Y: it.top - it.height}
+				it.figure.Plot.Draw(draw.Canvas{
+					Canvas:    c,
+					Rectangle: vg.Rectangle{Min: min, Max: min.Add(vg.Point{X: it.width, Y: it.height})},
+				})
+				if it.text != "" {
+					dc.FillText(r.CaptionStyle, vg.Point{X: center, Y: min.Y - r.Spacing/2}, it.text)
+				}
+			case it.heading != "":
+				dc.FillText(r.HeadingStyle, vg.Point{X: r.Margin, Y: it.top}, it.text)
+			default:
+				dc.FillText(r.TitleStyle, vg.Point{X: center, Y: it.top}, it.text)
+			}
+		}
+	}
+	_, err := c.WriteTo(w)
+	return err
+}
+
+// style is the style sheet of HTML reports.
+const style = `body { font-family: serif; max-width: 50em; margin: 2em auto; }
+h1 { text-align: center; }
+figure { text-align: center; margin: 1em 0; }
+figcaption { font-size: smaller; }`
+
+// WriteHTML writes the report to w as a single HTML
+// page, with the plots of the figures embedded as SVG.
+func (r *Report) WriteHTML(w io.Writer) error {
+	var buf bytes.Buffer
+	title := html.EscapeString(r.Title)
+	fmt.Fprintf(&buf, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n", title, style)
+	if r.Title != "" {
+		fmt.Fprintf(&buf, "<h1>%s</h1>\n", title)
+	}
+	var n int
+	for _, e := range r.entries {
+		if e.figure == nil {
+			fmt.Fprintf(&buf, "<h2>%s</h2>\n", html.EscapeString(e.heading))
+			continue
+		}
+		n++
+		buf.WriteString("<figure>\n")
+		width, height := r.size(e.figure)
+		c := vgsvg.New(width, height)
+		c.IDPrefix = fmt.Sprintf("fig%d-", n)
+		e.figure.Plot.Draw(draw.New(c))
+		var svg bytes.Buffer
+		if _, err := c.WriteTo(&svg); err != nil {
+			return err
+		}
+		// The XML declaration is not allowed in HTML.
+		data := svg.Bytes()
+		if i := bytes.Index(data, []byte("<svg")); i > 0 {
+			data = data[i:]
+		}
+		buf.Write(data)
+		if e.figure.Caption != "" {
+			fmt.Fprintf(&buf, "<figcaption>%s</figcaption>\n", html.EscapeString(e.figure.Caption))
+		}
+		buf.WriteString("</figure>\n")
+	}
+	buf.WriteString("</body>\n</html>\n")
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// wrap returns txt with its lines broken between
+// words to fit within the width when drawn in the
+// given style.
+func wrap(sty draw.TextStyle, txt string, width vg.Length) string {
+	var lines []string
+	for _, line := range strings.Split(txt, "\n") {
+		words := strings.Fields(line)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		cur := words[0]
+		for _, w := range words[1:] {
+			if sty.Width(cur+" "+w) > width {
+				lines = append(lines, cur)
+				cur = w
+				continue
+			}
+			cur += " " + w
+		}
+		lines = append(lines, cur)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,129 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+)
+
+// newReport returns a report with two sections
+// of n figures each.
+func newReport(t *testing.T, n int) *Report {
+	r, err := New("Nightly <results>")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var themed int
+	r.Theme = func(p *plot.Plot) {
+		p.Title.Text = strings.ToUpper(p.Title.Text)
+		themed++
+	}
+	for _, section := range []string{"Latency", "Throughput"} {
+		r.Section(section)
+		for i := 0; i < n; i++ {
+			p, err := plot.New()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			p.Title.Text = fmt.Sprintf("%s %d", section, i)
+			l, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: float64(i)}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			p.Add(l)
+			r.Add(Figure{Plot: p, Caption: fmt.Sprintf("%s of run %d & more.", section, i)})
+		}
+	}
+	if themed != 2*n {
+		t.Errorf("unexpected number of themed plots: got:%d want:%d", themed, 2*n)
+	}
+	return r
+}
+
+func TestLayout(t *testing.T) {
+	r := newReport(t, 3)
+	pages := r.layout()
+	if len(pages) < 2 {
+		t.Fatalf("unexpected number of pages: got:%d want at least 2", len(pages))
+	}
+	var figs int
+	for i, page := range pages {
+		for j, it := range page {
+			if it.figure != nil {
+				figs++
+				if it.width > r.PageWidth-2*r.Margin {
+					t.Errorf("figure wider than the page on page %d", i)
+				}
+			}
+			if it.top-it.height-it.caption < r.Margin-1e-9 {
+				t.Errorf("item %d on page %d extends below the margin", j, i)
+			}
+			if it.heading != "" && (j == len(page)-1 || page[j+1].figure == nil) {
+				t.Errorf("heading %q on page %d not followed by its figure", it.heading, i)
+			}
+		}
+	}
+	if figs != 6 {
+		t.Errorf("unexpected number of figures: got:%d want:6", figs)
+	}
+
+	// Figures taller than a page are scaled down.
+	r.FigureHeight = 2 * r.PageHeight
+	for _, page := range r.layout() {
+		for _, it := range page {
+			if it.figure != nil && it.height > r.PageHeight-2*r.Margin {
+				t.Errorf("figure taller than the page: %v", it.height)
+			}
+		}
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	r := newReport(t, 2)
+	var buf bytes.Buffer
+	if err := r.WriteHTML(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<h1>Nightly &lt;results&gt;</h1>",
+		"<h2>Latency</h2>",
+		"<h2>Throughput</h2>",
+		"<figcaption>Throughput of run 1 &amp; more.</figcaption>",
+		`id="fig1-clip1"`,
+		`id="fig4-clip1"`,
+		`id="fig1-x-axis"`,
+		`id="fig4-legend"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	if got := strings.Count(out, "<svg"); got != 4 {
+		t.Errorf("unexpected number of SVG documents: got:%d want:4", got)
+	}
+	if strings.Contains(out, "<?xml") {
+		t.Error("output contains an XML declaration")
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	r := newReport(t, 2)
+	r.FigureWidth = 3 * vg.Inch
+	var buf bytes.Buffer
+	if err := r.WritePDF(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Error("output is not a PDF document")
+	}
+}
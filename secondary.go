@@ -0,0 +1,207 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// A SecondaryAxis is drawn opposite a primary axis of a plot,
+// along the top of the data area for the X axis and along its
+// right for the Y axis, marking the range of the primary axis
+// in another unit, such as °F for an axis in °C.
+//
+// The label, the style and the tick marks of a SecondaryAxis
+// are those of its Axis. The range and the scale of the Axis
+// are ignored: the range is that of the primary axis converted
+// by Transform, and values are placed at the location of the
+// primary values they convert.
+type SecondaryAxis struct {
+	Axis
+
+	// Transform converts values in the unit of the primary
+	// axis, with its Forward method, to the unit of the
+	// secondary axis. It must be monotonic over the range
+	// of the primary axis.
+	Transform Transform
+}
+
+// AddSecondaryX sets the secondary X axis of the plot to a new
+// SecondaryAxis with the default style of an X axis, converting
+// the values of the X axis by t, and returns it.
+func (p *Plot) AddSecondaryX(t Transform) (*SecondaryAxis, error) {
+	a, err := makeAxis(horizontal)
+	if err != nil {
+		return nil, err
+	}
+	a.Tick.Label.YAlign = draw.YBottom
+	p.X2 = &SecondaryAxis{Axis: a, Transform: t}
+	return p.X2, nil
+}
+
+// AddSecondaryY sets the secondary Y axis of the plot to a new
+// SecondaryAxis with the default style of a Y axis, converting
+// the values of the Y axis by t, and returns it.
+func (p *Plot) AddSecondaryY(t Transform) (*SecondaryAxis, error) {
+	a, err := makeAxis(vertical)
+	if err != nil {
+		return nil, err
+	}
+	a.Tick.Label.XAlign = draw.XLeft
+	p.Y2 = &SecondaryAxis{Axis: a, Transform: t}
+	return p.Y2, nil
+}
+
+// fitted returns the Axis of the secondary axis, with the range
+// of the primary axis a converted to the unit of the secondary
+// axis and a scale placing values at the location of the primary
+// values they convert.
+func (s *SecondaryAxis) fitted(a *Axis) Axis {
+	f := s.Axis
+	f.Min = s.Transform.Forward(a.Min)
+	f.Max = s.Transform.Forward(a.Max)
+	if f.Min > f.Max {
+		f.Min, f.Max = f.Max, f.Min
+	}
+	f.Scale = secondaryScale{min: a.Min, max: a.Max, scale: a.Scale, t: s.Transform}
+	return f
+}
+
+// secondaryScale normalizes the values of a secondary
+// axis to the location of the primary values they convert.
+type secondaryScale struct {
+	min, max float64
+	scale    Normalizer
+	t        Transform
+}
+
+// Normalize returns the normalized location along the primary
+// axis of the primary value converted to x, ignoring min and max.
+func (s secondaryScale) Normalize(_, _, x float64) float64 {
+	return s.scale.Normalize(s.min, s.max, s.t.Inverse(x))
+}
+
+// secondaryAxes returns the secondary axes of the plot fitted
+// to its primary axes, or nil for those that are not set. The
+// ranges of the primary axes must have been sanitized.
+func (p *Plot) secondaryAxes() (x2 *horizontalAxis, y2 *verticalAxis) {
+	if p.X2 != nil {
+		x2 = &horizontalAxis{Axis: p.X2.fitted(&p.X)}
+	}
+	if p.Y2 != nil {
+		y2 = &verticalAxis{Axis: p.Y2.fitted(&p.Y)}
+	}
+	return x2, y2
+}
+
+// drawTop draws the axis along the upper edge of a draw.Canvas,
+// mirroring draw, with the line at the bottom of the axis and
+// the label at the top.
+func (a *horizontalAxis) drawTop(c draw.Canvas) {
+	y := c.Max.Y
+	if a.Label.Text != "" {
+		sty := a.Label.TextStyle
+		sty.YAlign = draw.YTop
+		c.FillText(sty, vg.Point{X: c.Center().X, Y: y}, a.Label.Text)
+		y -= a.Label.Height(a.Label.Text)
+		y += a.Label.Font.Extents().Descent
+		y -= a.Label.Padding
+	}
+
+	marks, sty := a.fitTicks(a.length, true)
+	if sty.Rotation != 0 {
+		// Rotated labels read upwards from the axis.
+		sty.XAlign = draw.XLeft
+	}
+	ticklabelheight := tickLabelHeight(sty, marks)
+	for _, t := range marks {
+		x := c.X(a.Norm(t.Value))
+		if !c.ContainsX(x) || t.IsMinor() {
+			continue
+		}
+		c.FillText(sty, vg.Point{X: x, Y: y - ticklabelheight}, t.Label)
+	}
+
+	if len(marks) > 0 {
+		y -= ticklabelheight
+		if ticklabelheight > 0 {
+			y -= a.Tick.LabelPadding
+		}
+	} else {
+		y -= a.Width / 2
+	}
+
+	if len(marks) > 0 && a.drawTicks() {
+		len := a.Tick.Length
+		for _, t := range marks {
+			x := c.X(a.Norm(t.Value))
+			if !c.ContainsX(x) {
+				continue
+			}
+			sty, tlen := a.tickStyle(t)
+			c.StrokeLine2(sty, x, y-len+tlen, x, y-len)
+		}
+		y -= len
+	}
+
+	if !a.noLine {
+		c.StrokeLine2(a.LineStyle, c.Min.X, y, c.Max.X, y)
+		a.drawArrow(c, vg.Point{X: c.Max.X, Y: y}, vg.Point{X: 1})
+	}
+}
+
+// drawRight draws the axis along the right side of a draw.Canvas,
+// mirroring draw, with the line at the left of the axis and the
+// label at the right.
+func (a *verticalAxis) drawRight(c draw.Canvas) {
+	x := c.Max.X
+	if a.Label.Text != "" {
+		sty := a.Label.TextStyle
+		sty.Rotation += math.Pi / 2
+		x += a.Label.Font.Extents().Descent
+		c.FillText(sty, vg.Point{X: x, Y: c.Center().Y}, a.Label.Text)
+		x -= a.Label.Height(a.Label.Text)
+		x -= a.Label.Padding
+	}
+	marks, sty := a.fitTicks(a.length, false)
+	if w := tickLabelWidth(sty, marks); len(marks) > 0 && w > 0 {
+		x -= w
+	}
+	major := false
+	for _, t := range marks {
+		y := c.Y(a.Norm(t.Value))
+		if !c.ContainsY(y) || t.IsMinor() {
+			continue
+		}
+		c.FillText(sty, vg.Point{X: x, Y: y}, t.Label)
+		major = true
+	}
+	if major {
+		if a.Tick.LabelPadding != 0 {
+			x -= a.Tick.LabelPadding
+		} else {
+			x -= a.Tick.Label.Width(" ")
+		}
+	}
+	if a.drawTicks() && len(marks) > 0 {
+		len := a.Tick.Length
+		for _, t := range marks {
+			y := c.Y(a.Norm(t.Value))
+			if !c.ContainsY(y) {
+				continue
+			}
+			sty, tlen := a.tickStyle(t)
+			c.StrokeLine2(sty, x-len+tlen, y, x-len, y)
+		}
+		x -= len
+	}
+	if !a.noLine {
+		c.StrokeLine2(a.LineStyle, x, c.Min.Y, x, c.Max.Y)
+		a.drawArrow(c, vg.Point{X: x, Y: c.Max.Y}, vg.Point{Y: 1})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestSecondaryAxis(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(rangeData{xmin: 1, xmax: 4, ymin: 0, ymax: 100})
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	before := p.DataCanvas(c)
+
+	inv := func(x float64) float64 { return 1 / x }
+	_, err = p.AddSecondaryX(FuncTransform{Func: inv, InverseFunc: inv})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f2c := func(x float64) float64 { return (x - 32) * 5 / 9 }
+	c2f := func(x float64) float64 { return x*9/5 + 32 }
+	_, err = p.AddSecondaryY(FuncTransform{Func: c2f, InverseFunc: f2c})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x2, y2 := p.secondaryAxes()
+	if x2.Min != 0.25 || x2.Max != 1 {
+		t.Errorf("unexpected secondary X range: got:[%v, %v] want:[0.25, 1]", x2.Min, x2.Max)
+	}
+	if got := x2.Norm(0.5); got != 1.0/3 {
+		t.Errorf("unexpected location of secondary X value: got:%v want:%v", got, 1.0/3)
+	}
+	if y2.Min != 32 || y2.Max != 212 {
+		t.Errorf("unexpected secondary Y range: got:[%v, %v] want:[32, 212]", y2.Min, y2.Max)
+	}
+	if got := y2.Norm(122); math.Abs(got-0.5) > 1e-12 {
+		t.Errorf("unexpected location of secondary Y value: got:%v want:0.5", got)
+	}
+
+	after := p.DataCanvas(c)
+	if after.Max.X >= before.Max.X || after.Max.Y >= before.Max.Y {
+		t.Errorf("data area not reduced by secondary axes: got:%v before:%v", after.Rectangle, before.Rectangle)
+	}
+	if after.Min != before.Min {
+		t.Errorf("unexpected change of the data area origin: got:%v want:%v", after.Min, before.Min)
+	}
+	p.Draw(c)
+}
@@ -0,0 +1,130 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Spines specifies the spines of a plot, the lines
+// bounding or crossing its data area.
+type Spines int
+
+const (
+	// AxisSpines draws the lines of the axes along the
+	// bottom and the left of the data area, and those of
+	// the secondary axes, if any, along its top and right.
+	AxisSpines Spines = iota
+
+	// BoxSpines draws the lines of the axes and closes a
+	// box around the data area with lines along its top
+	// and right, in the styles of the X and Y axis lines,
+	// where there are no secondary axes. The box lines are
+	// separated from the data by the Padding of the axes.
+	BoxSpines
+
+	// ZeroSpines draws the lines of the axes across the
+	// data area, through the zero of the other axis, as
+	// in mathematics textbooks. The tick marks and the
+	// labels are drawn along the edges of the data area.
+	// A line whose zero is out of the range of the other
+	// axis is drawn at the minimum of that axis.
+	ZeroSpines
+
+	// NoSpines draws no axis lines.
+	NoSpines
+)
+
+// axes returns the axes of the plot, and its secondary axes
+// or nil for those that are not set, as they are drawn with
+// the spines of the plot. The ranges of the X and Y axes
+// are sanitized.
+func (p *Plot) axes() (x horizontalAxis, y verticalAxis, x2 *horizontalAxis, y2 *verticalAxis) {
+	p.X.sanitizeRange()
+	x = horizontalAxis{Axis: p.X}
+	p.Y.sanitizeRange()
+	y = verticalAxis{Axis: p.Y}
+	x2, y2 = p.secondaryAxes()
+	if p.Spines == ZeroSpines || p.Spines == NoSpines {
+		x.noLine, y.noLine = true, true
+		if x2 != nil {
+			x2.noLine = true
+		}
+		if y2 != nil {
+			y2.noLine = true
+		}
+	}
+	return x, y, x2, y2
+}
+
+// oppositeSizes returns the height taken along the top of
+// the plot by the secondary X axis or by the top line of
+// BoxSpines, and the width taken along the right of the
+// plot by the secondary Y axis or by the right line of
+// BoxSpines.
+func (p *Plot) oppositeSizes(x2 *horizontalAxis, y2 *verticalAxis) (top, right vg.Length) {
+	switch {
+	case x2 != nil:
+		top = x2.size()
+	case p.Spines == BoxSpines:
+		top = p.X.Width/2 + p.X.Padding
+	}
+	switch {
+	case y2 != nil:
+		right = y2.size()
+	case p.Spines == BoxSpines:
+		right = p.Y.Width/2 + p.Y.Padding
+	}
+	return top, right
+}
+
+// drawSpines draws the spines of the plot that are not drawn
+// with its axes: the top and right lines of BoxSpines, where
+// there are no secondary axes, and the lines of ZeroSpines.
+// The canvas c is the canvas of the axes, and dataC is the
+// data canvas.
+func (p *Plot) drawSpines(c, dataC draw.Canvas, x2 *horizontalAxis, y2 *verticalAxis) {
+	switch p.Spines {
+	case BoxSpines:
+		if x2 == nil {
+			y := c.Max.Y - p.X.Width/2
+			c.StrokeLine2(p.X.LineStyle, dataC.Min.X, y, dataC.Max.X, y)
+		}
+		if y2 == nil {
+			x := c.Max.X - p.Y.Width/2
+			c.StrokeLine2(p.Y.LineStyle, x, dataC.Min.Y, x, dataC.Max.Y)
+		}
+	case ZeroSpines:
+		y := dataC.Y(p.Y.Norm(zeroOrMin(&p.Y)))
+		c.StrokeLine2(p.X.LineStyle, dataC.Min.X, y, dataC.Max.X, y)
+		p.X.drawArrow(c, vg.Point{X: dataC.Max.X, Y: y}, vg.Point{X: 1})
+		x := dataC.X(p.X.Norm(zeroOrMin(&p.X)))
+		c.StrokeLine2(p.Y.LineStyle, x, dataC.Min.Y, x, dataC.Max.Y)
+		p.Y.drawArrow(c, vg.Point{X: x, Y: dataC.Max.Y}, vg.Point{Y: 1})
+	}
+}
+
+// zeroOrMin returns zero if it is in the range of the
+// axis, and the minimum of the axis otherwise.
+func zeroOrMin(a *Axis) float64 {
+	if a.Min <= 0 && 0 <= a.Max {
+		return 0
+	}
+	return a.Min
+}
+
+// drawArrow draws the arrowhead of the axis line, if Arrow
+// is not zero, with its tip at the given point, pointing in
+// the direction of the unit vector dir.
+func (a *Axis) drawArrow(c draw.Canvas, tip, dir vg.Point) {
+	if a.Arrow == 0 || a.Color == nil {
+		return
+	}
+	back := dir.Scale(-a.Arrow)
+	side := vg.Point{X: -dir.Y, Y: dir.X}.Scale(a.Arrow / 3)
+	base := tip.Add(back)
+	c.FillPolygon(a.Color, []vg.Point{tip, base.Add(side), base.Sub(side)})
+}
@@ -0,0 +1,73 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestSpines(t *testing.T) {
+	for _, test := range []struct {
+		spines     Spines
+		arrow      vg.Length
+		wantStroke int
+		wantFill   int
+	}{
+		{spines: AxisSpines, wantStroke: 2},
+		{spines: AxisSpines, arrow: 5, wantStroke: 2, wantFill: 2},
+		{spines: BoxSpines, wantStroke: 4},
+		{spines: ZeroSpines, arrow: 5, wantStroke: 2, wantFill: 2},
+		{spines: NoSpines, arrow: 5},
+	} {
+		p, err := New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(rangeData{xmin: -1, xmax: 3, ymin: -2, ymax: 5})
+		p.HideAxes()
+		p.X.Width = vg.Points(1)
+		p.Y.Width = vg.Points(1)
+		p.X.Arrow = test.arrow
+		p.Y.Arrow = test.arrow
+		p.Spines = test.spines
+
+		var rec recorder.Canvas
+		p.Draw(draw.NewCanvas(&rec, 300, 200))
+		var strokes, fills int
+		for _, a := range rec.Actions {
+			switch a.(type) {
+			case *recorder.Stroke:
+				strokes++
+			case *recorder.Fill:
+				fills++
+			}
+		}
+		// The background of the plot is filled.
+		fills--
+		if strokes != test.wantStroke || fills != test.wantFill {
+			t.Errorf("unexpected spines for %v with arrow %v: got:%d strokes %d fills want:%d strokes %d fills",
+				test.spines, test.arrow, strokes, fills, test.wantStroke, test.wantFill)
+		}
+	}
+}
+
+func TestBoxSpinesDataCanvas(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	before := p.DataCanvas(c)
+	p.Spines = BoxSpines
+	after := p.DataCanvas(c)
+	want := before.Max.Sub(vg.Point{X: p.Y.Width/2 + p.Y.Padding, Y: p.X.Width/2 + p.X.Padding})
+	if after.Max != want || after.Min != before.Min {
+		t.Errorf("unexpected data area: got:%v want:%v", after.Rectangle, vg.Rectangle{Min: before.Min, Max: want})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import "math"
+
+// A Transform is a monotonic transform of the data coordinates
+// of an axis, such as a power or a calibration curve.
+type Transform interface {
+	// Forward returns the transformed value of x.
+	Forward(x float64) float64
+
+	// Inverse returns the value whose
+	// transformed value is y.
+	Inverse(y float64) float64
+}
+
+// TransformScale can be used as the value of an Axis.Scale function
+// to set the axis to the scale of a transform, linear in the
+// transformed values. Transforms may be chained by ChainTransform.
+type TransformScale struct {
+	Transform Transform
+}
+
+var _ Normalizer = TransformScale{}
+
+// Normalize returns the fractional distance of the
+// transformed value of x between those of min and max.
+func (s TransformScale) Normalize(min, max, x float64) float64 {
+	tMin := s.Transform.Forward(min)
+	return (s.Transform.Forward(x) - tMin) / (s.Transform.Forward(max) - tMin)
+}
+
+// TransformTicks is suitable for the Tick.Marker field of an Axis
+// with a TransformScale. It returns the tick marks returned by its
+// Marker in the range of the transformed values, evenly spaced along
+// the axis, at the values they are the transforms of, labeled with
+// those values.
+type TransformTicks struct {
+	// Transform is the transform of the axis.
+	Transform Transform
+
+	// Marker returns the tick marks in the range of
+	// the transformed values. If Marker is nil,
+	// DefaultTicks is used.
+	Marker Ticker
+}
+
+var _ Ticker = TransformTicks{}
+
+// Ticks returns Ticks in a specified range
+func (t TransformTicks) Ticks(min, max float64) []Tick {
+	marker := t.Marker
+	if marker == nil {
+		marker = DefaultTicks{}
+	}
+	tMin, tMax := t.Transform.Forward(min), t.Transform.Forward(max)
+	if tMin > tMax {
+		tMin, tMax = tMax, tMin
+	}
+	ticks := marker.Ticks(tMin, tMax)
+	inv := make([]Tick, len(ticks))
+	for i, tick := range ticks {
+		v := t.Transform.Inverse(tick.Value)
+		inv[i].Value = v
+		if !tick.IsMinor() {
+			inv[i].Label = formatFloatTick(v, displayPrecision)
+		}
+	}
+	return inv
+}
+
+// ChainTransform is a Transform applying each of its
+// transforms in turn, from the first.
+type ChainTransform []Transform
+
+var _ Transform = ChainTransform{}
+
+// Forward returns x transformed by each of the
+// transforms of the chain, from the first.
+func (c ChainTransform) Forward(x float64) float64 {
+	for _, t := range c {
+		x = t.Forward(x)
+	}
+	return x
+}
+
+// Inverse returns y transformed by the inverse of
+// each of the transforms of the chain, from the last.
+func (c ChainTransform) Inverse(y float64) float64 {
+	for i := len(c) - 1; i >= 0; i-- {
+		y = c[i].Inverse(y)
+	}
+	return y
+}
+
+// SqrtTransform is the square root, which compresses large
+// values less than a log scale does. It is defined for
+// non-negative values.
+type SqrtTransform struct{}
+
+var _ Transform = SqrtTransform{}
+
+// Forward returns the square root of x.
+func (SqrtTransform) Forward(x float64) float64 { return math.Sqrt(x) }
+
+// Inverse returns the square of y.
+func (SqrtTransform) Inverse(y float64) float64 { return y * y }
+
+// PowerTransform raises values to the power of its Exponent,
+// which must not be zero. It is defined for non-negative
+// values.
+type PowerTransform struct {
+	Exponent float64
+}
+
+var _ Transform = PowerTransform{}
+
+// Forward returns x raised to the power of the exponent.
+func (t PowerTransform) Forward(x float64) float64 { return math.Pow(x, t.Exponent) }
+
+// Inverse returns y raised to the power of the
+// reciprocal of the exponent.
+func (t PowerTransform) Inverse(y float64) float64 { return math.Pow(y, 1/t.Exponent) }
+
+// BoxCoxTransform is the Box-Cox power transform of parameter
+// Lambda, (x^λ-1)/λ, or log(x) if Lambda is zero. It is defined
+// for positive values.
+type BoxCoxTransform struct {
+	Lambda float64
+}
+
+var _ Transform = BoxCoxTransform{}
+
+// Forward returns the Box-Cox transform of x.
+func (t BoxCoxTransform) Forward(x float64) float64 {
+	if t.Lambda == 0 {
+		return math.Log(x)
+	}
+	return (math.Pow(x, t.Lambda) - 1) / t.Lambda
+}
+
+// Inverse returns the inverse Box-Cox transform of y.
+func (t BoxCoxTransform) Inverse(y float64) float64 {
+	if t.Lambda == 0 {
+		return math.Exp(y)
+	}
+	return math.Pow(t.Lambda*y+1, 1/t.Lambda)
+}
+
+// FuncTransform is a Transform of given functions,
+// such as a calibration curve and its inverse.
+type FuncTransform struct {
+	// Func returns the transformed value of x.
+	// Func must be monotonic.
+	Func func(x float64) float64
+
+	// InverseFunc is the inverse of Func.
+	InverseFunc func(y float64) float64
+}
+
+var _ Transform = FuncTransform{}
+
+// Forward returns the value of Func at x.
+func (t FuncTransform) Forward(x float64) float64 { return t.Func(x) }
+
+// Inverse returns the value of InverseFunc at y.
+func (t FuncTransform) Inverse(y float64) float64 { return t.InverseFunc(y) }
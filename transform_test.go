@@ -0,0 +1,65 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	for _, test := range []struct {
+		t    Transform
+		x, y float64
+	}{
+		{t: SqrtTransform{}, x: 9, y: 3},
+		{t: PowerTransform{Exponent: 3}, x: 2, y: 8},
+		{t: BoxCoxTransform{Lambda: 0}, x: math.E, y: 1},
+		{t: BoxCoxTransform{Lambda: 0.5}, x: 4, y: 2},
+		{t: ChainTransform{SqrtTransform{}, PowerTransform{Exponent: 3}}, x: 4, y: 8},
+		{t: ChainTransform{PowerTransform{Exponent: 3}, BoxCoxTransform{Lambda: 1}}, x: 2, y: 7},
+		{
+			t: FuncTransform{
+				Func:        func(x float64) float64 { return 2*x + 1 },
+				InverseFunc: func(y float64) float64 { return (y - 1) / 2 },
+			},
+			x: 3, y: 7,
+		},
+	} {
+		if y := test.t.Forward(test.x); math.Abs(y-test.y) > 1e-12 {
+			t.Errorf("unexpected forward transform by %#v of %g: got:%g want:%g", test.t, test.x, y, test.y)
+		}
+		if x := test.t.Inverse(test.y); math.Abs(x-test.x) > 1e-12 {
+			t.Errorf("unexpected inverse transform by %#v of %g: got:%g want:%g", test.t, test.y, x, test.x)
+		}
+	}
+}
+
+func TestTransformScale(t *testing.T) {
+	s := TransformScale{Transform: SqrtTransform{}}
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{x: 0, want: 0},
+		{x: 25, want: 0.5},
+		{x: 100, want: 1},
+	} {
+		if got := s.Normalize(0, 100, test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected normalized value of %g: got:%g want:%g", test.x, got, test.want)
+		}
+	}
+
+	ticks := TransformTicks{Transform: SqrtTransform{}}.Ticks(0, 100)
+	want := []string{"0", "9", "36", "81"}
+	if got := labelsOf(ticks); !reflect.DeepEqual(got, want) {
+		t.Errorf("tick labels mismatch:\ngot: %q\nwant:%q", got, want)
+	}
+	for _, tick := range ticks {
+		if tick.Value < 0 || tick.Value > 100 {
+			t.Errorf("tick out of range: %v", tick)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/vg"
+)
+
+// BoxStyle describes the box drawn around a component
+// of a plot, such as a legend or a label, to set it off
+// from the data drawn behind it. The zero BoxStyle draws
+// nothing and takes no space.
+type BoxStyle struct {
+	// Padding is the distance between the
+	// content of the box and its border.
+	Padding vg.Length
+
+	// Border is the style of the border of the box.
+	// If its Color is nil, no border is drawn.
+	Border LineStyle
+
+	// Background is the color that the box is
+	// filled with. If Background is nil, the box
+	// is not filled.
+	Background color.Color
+
+	// Radius is the radius of the rounded
+	// corners of the box.
+	Radius vg.Length
+}
+
+// Inset returns the distance between the content of
+// the box and the outer edge of its border, which is
+// centered on the edge of the padding.
+func (b BoxStyle) Inset() vg.Length {
+	in := b.Padding
+	if b.Border.Color != nil {
+		in += b.Border.Width / 2
+	}
+	return in
+}
+
+// Rectangle returns the rectangle bounding the
+// border of a box around the content r.
+func (b BoxStyle) Rectangle(r vg.Rectangle) vg.Rectangle {
+	return vg.Rectangle{
+		Min: vg.Point{X: r.Min.X - b.Padding, Y: r.Min.Y - b.Padding},
+		Max: vg.Point{X: r.Max.X + b.Padding, Y: r.Max.Y + b.Padding},
+	}
+}
+
+// Path returns the path of the border of a box
+// around the content r.
+func (b BoxStyle) Path(r vg.Rectangle) vg.Path {
+	r = b.Rectangle(r)
+	rad := b.Radius
+	if s := r.Size(); rad > s.X/2 || rad > s.Y/2 {
+		rad = vg.Length(math.Min(float64(s.X), float64(s.Y))) / 2
+	}
+
+	var p vg.Path
+	if rad <= 0 {
+		p.Move(r.Min)
+		p.Line(vg.Point{X: r.Max.X, Y: r.Min.Y})
+		p.Line(r.Max)
+		p.Line(vg.Point{X: r.Min.X, Y: r.Max.Y})
+		p.Close()
+		return p
+	}
+	p.Move(vg.Point{X: r.Min.X + rad, Y: r.Min.Y})
+	p.Arc(vg.Point{X: r.Max.X - rad, Y: r.Min.Y + rad}, rad, -math.Pi/2, math.Pi/2)
+	p.Arc(vg.Point{X: r.Max.X - rad, Y: r.Max.Y - rad}, rad, 0, math.Pi/2)
+	p.Arc(vg.Point{X: r.Min.X + rad, Y: r.Max.Y - rad}, rad, math.Pi/2, math.Pi/2)
+	p.Arc(vg.Point{X: r.Min.X + rad, Y: r.Min.Y + rad}, rad, math.Pi, math.Pi/2)
+	p.Close()
+	return p
+}
+
+// DrawBox draws a box in the given style around
+// the content r, behind anything drawn after it.
+func (c *Canvas) DrawBox(sty BoxStyle, r vg.Rectangle) {
+	if sty.Background == nil && sty.Border.Color == nil {
+		return
+	}
+	p := sty.Path(r)
+	if sty.Background != nil {
+		c.SetColor(sty.Background)
+		c.Fill(p)
+	}
+	if sty.Border.Color != nil && sty.Border.Width > 0 {
+		c.SetLineStyle(sty.Border)
+		c.Stroke(p)
+	}
+}
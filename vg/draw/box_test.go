@@ -0,0 +1,77 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestBoxStyle(t *testing.T) {
+	content := vg.Rectangle{Min: vg.Point{X: 10, Y: 10}, Max: vg.Point{X: 30, Y: 20}}
+
+	var r recorder.Canvas
+	c := NewCanvas(&r, 100, 100)
+	c.DrawBox(BoxStyle{Padding: 2}, content)
+	if len(r.Actions) != 0 {
+		t.Errorf("unexpected actions for invisible box: %v", r.Actions)
+	}
+
+	b := BoxStyle{
+		Padding:    2,
+		Border:     LineStyle{Color: color.Black, Width: 1},
+		Background: color.White,
+		Radius:     3,
+	}
+	if got, want := b.Inset(), vg.Length(2.5); got != want {
+		t.Errorf("unexpected inset: got:%v want:%v", got, want)
+	}
+	want := vg.Rectangle{Min: vg.Point{X: 8, Y: 8}, Max: vg.Point{X: 32, Y: 22}}
+	if got := b.Rectangle(content); got != want {
+		t.Errorf("unexpected rectangle: got:%v want:%v", got, want)
+	}
+
+	p := b.Path(content)
+	var arcs int
+	for _, comp := range p {
+		if comp.Type != vg.ArcComp {
+			continue
+		}
+		arcs++
+		if comp.Radius != 3 || comp.Angle != math.Pi/2 {
+			t.Errorf("unexpected corner: %+v", comp)
+		}
+	}
+	if arcs != 4 {
+		t.Errorf("unexpected number of corners: got:%d want:4", arcs)
+	}
+
+	// The radius is limited to half of the shorter side.
+	b.Radius = 100
+	for _, comp := range b.Path(content) {
+		if comp.Type == vg.ArcComp && comp.Radius != 7 {
+			t.Errorf("unexpected corner radius: got:%v want:7", comp.Radius)
+		}
+	}
+
+	r.Reset()
+	c.DrawBox(b, content)
+	var fills, strokes int
+	for _, a := range r.Actions {
+		switch a.(type) {
+		case *recorder.Fill:
+			fills++
+		case *recorder.Stroke:
+			strokes++
+		}
+	}
+	if fills != 1 || strokes != 1 {
+		t.Errorf("unexpected actions: got %d fills and %d strokes, want 1 and 1", fills, strokes)
+	}
+}
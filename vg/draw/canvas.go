@@ -6,6 +6,7 @@ package draw
 
 import (
 	"fmt"
+	"image"
 	"image/color"
 	"math"
 	"strings"
@@ -40,6 +41,11 @@ type TextStyle struct {
 	// XAlign and YAlign specify the alignment of the text.
 	XAlign XAlignment
 	YAlign YAlignment
+
+	// Math specifies whether math notation between pairs of
+	// dollar signs is laid out, as described in mathtext.go.
+	// If Math is false, dollar signs are drawn as they are.
+	Math bool
 }
 
 // XAlignment specifies text alignment in the X direction. Three preset
@@ -264,14 +270,15 @@ func New(c vg.CanvasSizer) Canvas {
 }
 
 // NewFormattedCanvas creates a new vg.CanvasWriterTo with the specified
-// image format.
+// image format. The format is not case sensitive, and may be given with
+// a leading dot as a file extension.
 //
 // Supported formats are:
 //
 //  eps, jpg|jpeg, pdf, png, svg, and tif|tiff.
 func NewFormattedCanvas(w, h vg.Length, format string) (vg.CanvasWriterTo, error) {
 	var c vg.CanvasWriterTo
-	switch format {
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
 	case "eps":
 		c = vgeps.New(w, h)
 
@@ -404,6 +411,57 @@ func (ts Tiles) At(c Canvas, x, y int) Canvas {
 	}
 }
 
+// BeginGroup starts a group of drawing operations with the
+// given id and class if the underlying vg.Canvas implements
+// vg.Grouper. Otherwise it does nothing.
+//
+// BeginGroup and EndGroup have value receivers so that a
+// Canvas wrapping another Canvas is itself a vg.Grouper.
+func (c Canvas) BeginGroup(id, class string) {
+	if g, ok := c.Canvas.(vg.Grouper); ok {
+		g.BeginGroup(id, class)
+	}
+}
+
+// EndGroup ends the group started by the matching call to
+// BeginGroup if the underlying vg.Canvas implements vg.Grouper.
+// Otherwise it does nothing.
+func (c Canvas) EndGroup() {
+	if g, ok := c.Canvas.(vg.Grouper); ok {
+		g.EndGroup()
+	}
+}
+
+// BlitRect implements the vg.Blitter interface by calling
+// BlitRect on the underlying vg.Canvas. If the underlying
+// canvas is not a vg.Blitter, ok is false.
+func (c Canvas) BlitRect(rect vg.Rectangle) (img *image.RGBA, r image.Rectangle, ok bool) {
+	if b, ok := c.Canvas.(vg.Blitter); ok {
+		return b.BlitRect(rect)
+	}
+	return nil, image.Rectangle{}, false
+}
+
+// Clip implements the vg.Clipper interface by calling
+// Clip on the underlying vg.Canvas. If the underlying
+// canvas is not a vg.Clipper, Clip does nothing.
+func (c Canvas) Clip(p vg.Path) {
+	if cl, ok := c.Canvas.(vg.Clipper); ok {
+		cl.Clip(p)
+	}
+}
+
+// TypesetsMath implements the vg.MathTypesetter interface
+// by calling TypesetsMath on the underlying vg.Canvas. If
+// the underlying canvas is not a vg.MathTypesetter, it
+// returns false.
+func (c Canvas) TypesetsMath() bool {
+	if t, ok := c.Canvas.(vg.MathTypesetter); ok {
+		return t.TypesetsMath()
+	}
+	return false
+}
+
 // SetLineStyle sets the current line style
 func (c *Canvas) SetLineStyle(sty LineStyle) {
 	c.SetColor(sty.Color)
@@ -613,6 +671,12 @@ func isect(p0, p1, clip, norm vg.Point) vg.Point {
 
 // FillText fills lines of text in the draw area.
 // pt specifies the location where the text is to be drawn.
+// Lines containing right to left text are reordered for
+// display and Arabic letters are given their joined forms
+// before the lines are drawn. If sty.Math is true, math
+// notation between pairs of dollar signs is laid out as
+// described in mathtext.go, unless the canvas typesets
+// math itself.
 func (c *Canvas) FillText(sty TextStyle, pt vg.Point, txt string) {
 	txt = strings.TrimRight(txt, "\n")
 	if len(txt) == 0 {
@@ -632,10 +696,19 @@ func (c *Canvas) FillText(sty TextStyle, pt vg.Point, txt string) {
 
 	nl := textNLines(txt)
 	ht := sty.Height(txt)
-	pt.Y += ht*vg.Length(sty.YAlign) - sty.Font.Extents().Ascent
+	_, below := sty.mathExtra(txt)
+	pt.Y += ht*vg.Length(sty.YAlign) - sty.Font.Extents().Ascent + below
+	native := c.TypesetsMath()
 	for i, line := range strings.Split(txt, "\n") {
-		xoffs := vg.Length(sty.XAlign) * sty.Font.Width(line)
 		n := vg.Length(nl - i)
+		if !native && sty.hasMath(line) {
+			b := layoutLine(sty.Font, line)
+			w, _, _ := b.extents()
+			b.draw(c, pt.Add(vg.Point{X: vg.Length(sty.XAlign) * w, Y: n * sty.Font.Size}))
+			continue
+		}
+		line = shape(line)
+		xoffs := vg.Length(sty.XAlign) * sty.Font.Width(line)
 		c.FillString(sty.Font, pt.Add(vg.Point{X: xoffs, Y: n * sty.Font.Size}), line)
 	}
 
@@ -663,7 +736,13 @@ func rotatePoint(theta float64, p vg.Point) vg.Point {
 func (sty TextStyle) Width(txt string) (max vg.Length) {
 	txt = strings.TrimRight(txt, "\n")
 	for _, line := range strings.Split(txt, "\n") {
-		if w := sty.Font.Width(line); w > max {
+		var w vg.Length
+		if sty.hasMath(line) {
+			w, _, _ = layoutLine(sty.Font, line).extents()
+		} else {
+			w = sty.Font.Width(shape(line))
+		}
+		if w > max {
 			max = w
 		}
 	}
@@ -678,7 +757,33 @@ func (sty TextStyle) Height(txt string) vg.Length {
 		return vg.Length(0)
 	}
 	e := sty.Font.Extents()
-	return e.Height*vg.Length(nl-1) + e.Ascent
+	above, below := sty.mathExtra(txt)
+	return e.Height*vg.Length(nl-1) + e.Ascent + above + below
+}
+
+// mathExtra returns the distances by which math in the
+// first line of the text extends above the ascent of the
+// font and math in the last line extends below its descent.
+func (sty TextStyle) mathExtra(txt string) (above, below vg.Length) {
+	txt = strings.TrimRight(txt, "\n")
+	if !sty.Math || !strings.Contains(txt, "$") {
+		return 0, 0
+	}
+	lines := strings.Split(txt, "\n")
+	e := sty.Font.Extents()
+	if first := lines[0]; sty.hasMath(first) {
+		_, a, _ := layoutLine(sty.Font, first).extents()
+		if a > e.Ascent {
+			above = a - e.Ascent
+		}
+	}
+	if last := lines[len(lines)-1]; sty.hasMath(last) {
+		_, _, d := layoutLine(sty.Font, last).extents()
+		if d > -e.Descent {
+			below = d + e.Descent
+		}
+	}
+	return above, below
 }
 
 // Rectangle returns a rectangle giving the bounds of
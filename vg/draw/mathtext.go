@@ -0,0 +1,607 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gonum/plot/vg"
+)
+
+// Text drawn by FillText in a TextStyle whose Math field is
+// true may contain math notation between pairs of dollar
+// signs, written in a subset of TeX:
+//
+//	x^2, x^{n+1}     superscripts
+//	x_i, x_{i,j}     subscripts
+//	\alpha, \Sigma   Greek letters
+//	\times, \pm, ... common symbols and operators
+//	\frac{a}{b}      fractions
+//	\sqrt{x}         square roots
+//	\text{...}       text, keeping spaces
+//	\, \: \; \quad   spacing
+//
+// As in TeX, spaces within math are ignored. A dollar sign
+// that does not start or end math is written as \$. Math is
+// set in the font of the text, without italics.
+//
+// Math is drawn using the usual vg.Canvas primitives, so it
+// is rendered by all backends. Canvases that typeset math
+// themselves, such as vgtex, are passed the text unchanged.
+
+// box is a laid out element of math text.
+type box interface {
+	// extents returns the width of the box and its
+	// height above and depth below the baseline.
+	extents() (width, ascent, descent vg.Length)
+
+	// draw draws the box with the left end of its
+	// baseline at pt.
+	draw(c vg.Canvas, pt vg.Point)
+}
+
+// textBox is a string drawn in a single font.
+type textBox struct {
+	font vg.Font
+	text string
+}
+
+func (b textBox) extents() (w, a, d vg.Length) {
+	e := b.font.Extents()
+	return b.font.Width(b.text), e.Ascent, -e.Descent
+}
+
+func (b textBox) draw(c vg.Canvas, pt vg.Point) {
+	c.FillString(b.font, pt, b.text)
+}
+
+// hbox is a sequence of boxes set side by side.
+type hbox []box
+
+func (b hbox) extents() (w, a, d vg.Length) {
+	for _, c := range b {
+		cw, ca, cd := c.extents()
+		w += cw
+		if ca > a {
+			a = ca
+		}
+		if cd > d {
+			d = cd
+		}
+	}
+	return w, a, d
+}
+
+func (b hbox) draw(c vg.Canvas, pt vg.Point) {
+	for _, cb := range b {
+		cb.draw(c, pt)
+		w, _, _ := cb.extents()
+		pt.X += w
+	}
+}
+
+// spaceBox is empty horizontal space.
+type spaceBox vg.Length
+
+func (b spaceBox) extents() (w, a, d vg.Length) { return vg.Length(b), 0, 0 }
+func (b spaceBox) draw(vg.Canvas, vg.Point)     {}
+
+// scriptBox is a base with a superscript
+// and a subscript, either of which may be nil.
+type scriptBox struct {
+	base     box
+	sup, sub box
+
+	// supShift and subShift are the distances the
+	// baselines of the superscript and subscript
+	// are raised and lowered.
+	supShift, subShift vg.Length
+}
+
+func newScriptBox(font vg.Font, base, sup, sub box) scriptBox {
+	s := scriptBox{base: base, sup: sup, sub: sub}
+	_, ba, bd := base.extents()
+	if sup != nil {
+		_, sa, _ := sup.extents()
+		s.supShift = max(0.4*font.Size, ba-0.6*sa)
+	}
+	if sub != nil {
+		_, _, sd := sub.extents()
+		s.subShift = max(0.2*font.Size, bd-0.4*sd)
+	}
+	return s
+}
+
+func (b scriptBox) extents() (w, a, d vg.Length) {
+	w, a, d = b.base.extents()
+	var sw vg.Length
+	if b.sup != nil {
+		supw, supa, _ := b.sup.extents()
+		sw = supw
+		a = max(a, supa+b.supShift)
+	}
+	if b.sub != nil {
+		subw, _, subd := b.sub.extents()
+		sw = max(sw, subw)
+		d = max(d, subd+b.subShift)
+	}
+	return w + sw, a, d
+}
+
+func (b scriptBox) draw(c vg.Canvas, pt vg.Point) {
+	b.base.draw(c, pt)
+	w, _, _ := b.base.extents()
+	pt.X += w
+	if b.sup != nil {
+		b.sup.draw(c, vg.Point{X: pt.X, Y: pt.Y + b.supShift})
+	}
+	if b.sub != nil {
+		b.sub.draw(c, vg.Point{X: pt.X, Y: pt.Y - b.subShift})
+	}
+}
+
+// fracBox is a fraction, with the numerator
+// and denominator centered about a rule.
+type fracBox struct {
+	num, den box
+
+	// axis is the height of the rule above the
+	// baseline, thick is its thickness and gap
+	// is the space above and below it.
+	axis, thick, gap vg.Length
+}
+
+func newFracBox(font vg.Font, num, den box) fracBox {
+	return fracBox{
+		num:   num,
+		den:   den,
+		axis:  0.25 * font.Size,
+		thick: 0.05 * font.Size,
+		gap:   0.1 * font.Size,
+	}
+}
+
+func (b fracBox) extents() (w, a, d vg.Length) {
+	nw, na, nd := b.num.extents()
+	dw, da, dd := b.den.extents()
+	w = max(nw, dw) + 2*b.gap
+	a = b.axis + b.thick/2 + b.gap + nd + na
+	d = -b.axis + b.thick/2 + b.gap + da + dd
+	if d < 0 {
+		d = 0
+	}
+	return w, a, d
+}
+
+func (b fracBox) draw(c vg.Canvas, pt vg.Point) {
+	w, _, _ := b.extents()
+	nw, _, nd := b.num.extents()
+	dw, da, _ := b.den.extents()
+	b.num.draw(c, vg.Point{
+		X: pt.X + (w-nw)/2,
+		Y: pt.Y + b.axis + b.thick/2 + b.gap + nd,
+	})
+	b.den.draw(c, vg.Point{
+		X: pt.X + (w-dw)/2,
+		Y: pt.Y + b.axis - b.thick/2 - b.gap - da,
+	})
+	c.Fill(vg.Rectangle{
+		Min: vg.Point{X: pt.X + b.gap/2, Y: pt.Y + b.axis - b.thick/2},
+		Max: vg.Point{X: pt.X + w - b.gap/2, Y: pt.Y + b.axis + b.thick/2},
+	}.Path())
+}
+
+// sqrtBox is a square root, with a radical
+// sign and a rule over its body.
+type sqrtBox struct {
+	body box
+
+	// sign is the width of the radical sign, thick
+	// is the thickness of its lines and gap is the
+	// space between the rule and the body.
+	sign, thick, gap vg.Length
+}
+
+func newSqrtBox(font vg.Font, body box) sqrtBox {
+	return sqrtBox{
+		body:  body,
+		sign:  0.5 * font.Size,
+		thick: 0.05 * font.Size,
+		gap:   0.1 * font.Size,
+	}
+}
+
+func (b sqrtBox) extents() (w, a, d vg.Length) {
+	w, a, d = b.body.extents()
+	return w + b.sign + b.gap, a + b.gap + b.thick, d
+}
+
+func (b sqrtBox) draw(c vg.Canvas, pt vg.Point) {
+	w, a, d := b.body.extents()
+	top := pt.Y + a + b.gap + b.thick/2
+	var p vg.Path
+	p.Move(vg.Point{X: pt.X, Y: pt.Y + (a-d)/2})
+	p.Line(vg.Point{X: pt.X + 0.2*b.sign, Y: pt.Y + (a-d)/2 + b.thick})
+	p.Line(vg.Point{X: pt.X + 0.5*b.sign, Y: pt.Y - d})
+	p.Line(vg.Point{X: pt.X + b.sign, Y: top})
+	p.Line(vg.Point{X: pt.X + b.sign + w + b.gap, Y: top})
+	c.Push()
+	c.SetLineWidth(b.thick)
+	c.SetLineDash(nil, 0)
+	c.Stroke(p)
+	c.Pop()
+	b.body.draw(c, vg.Point{X: pt.X + b.sign, Y: pt.Y})
+}
+
+// hasMath returns whether the line is to be laid out as
+// math, containing math notation or escaped dollar signs
+// in a style that enables math.
+func (sty TextStyle) hasMath(line string) bool {
+	if !sty.Math {
+		return false
+	}
+	if strings.Contains(line, `\$`) {
+		return true
+	}
+	return strings.Count(line, "$") >= 2
+}
+
+// layoutLine returns the box for a line of text that may
+// contain math, drawn with the given font. Text outside the
+// math is shaped for display.
+func layoutLine(font vg.Font, line string) hbox {
+	var (
+		b    hbox
+		text []byte
+	)
+	flush := func() {
+		if len(text) != 0 {
+			b = append(b, textBox{font: font, text: shape(string(text))})
+			text = text[:0]
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		switch {
+		case strings.HasPrefix(line[i:], `\$`):
+			text = append(text, '$')
+			i++
+		case line[i] == '$':
+			end := mathEnd(line, i+1)
+			if end < 0 {
+				text = append(text, '$')
+				continue
+			}
+			flush()
+			p := mathParser{src: line[i+1 : end]}
+			b = append(b, p.parseList(font, false))
+			i = end
+		default:
+			text = append(text, line[i])
+		}
+	}
+	flush()
+	return b
+}
+
+// mathEnd returns the index of the unescaped dollar
+// sign closing math starting at i, or -1 if there
+// is none.
+func mathEnd(line string, i int) int {
+	for ; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '$':
+			return i
+		}
+	}
+	return -1
+}
+
+// mathParser parses math notation into boxes.
+type mathParser struct {
+	src string
+	pos int
+}
+
+// scriptScale is the scale of the font of scripts
+// and of the parts of fractions relative to the
+// surrounding font.
+const scriptScale = 0.7
+
+func scriptFont(font vg.Font) vg.Font {
+	font.Size *= scriptScale
+	return font
+}
+
+// parseList parses atoms up to the end of the source or,
+// if group is true, up to and including a closing brace.
+func (p *mathParser) parseList(font vg.Font, group bool) hbox {
+	var (
+		b hbox
+
+		// binary is whether an operator at the current
+		// position has a left operand.
+		binary bool
+	)
+	for p.pos < len(p.src) {
+		r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+		switch r {
+		case '}':
+			p.pos += n
+			if group {
+				return b
+			}
+			b = append(b, textBox{font: font, text: "}"})
+			binary = true
+			continue
+		case ' ', '\t':
+			p.pos += n
+			continue
+		}
+		a := p.parseAtom(font)
+		if a == nil {
+			continue
+		}
+		if op, ok := a.(opBox); ok {
+			if binary {
+				// Binary operators and relations are
+				// spaced from their operands.
+				sp := spaceBox(0.2 * font.Size)
+				b = append(b, sp, op.box, sp)
+				binary = false
+				continue
+			}
+			a = op.box
+		}
+		if _, ok := a.(spaceBox); !ok {
+			binary = !isOpening(a)
+		}
+		b = append(b, p.parseScripts(font, a))
+	}
+	return b
+}
+
+// opBox is a binary operator or relation.
+type opBox struct {
+	box
+}
+
+// parseScripts parses any superscript and
+// subscript following base.
+func (p *mathParser) parseScripts(font vg.Font, base box) box {
+	if op, ok := base.(opBox); ok {
+		base = op.box
+	}
+	var sup, sub box
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '^':
+			p.pos++
+			sup = p.parseArg(scriptFont(font))
+		case '_':
+			p.pos++
+			sub = p.parseArg(scriptFont(font))
+		default:
+			if sup == nil && sub == nil {
+				return base
+			}
+			return newScriptBox(font, base, sup, sub)
+		}
+	}
+	if sup == nil && sub == nil {
+		return base
+	}
+	return newScriptBox(font, base, sup, sub)
+}
+
+// parseArg parses a single atom or braced group
+// as the argument of a command or script.
+func (p *mathParser) parseArg(font vg.Font) box {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+	if p.pos == len(p.src) {
+		return hbox{}
+	}
+	a := p.parseAtom(font)
+	if op, ok := a.(opBox); ok {
+		return op.box
+	}
+	if a == nil {
+		return hbox{}
+	}
+	return a
+}
+
+// parseAtom parses a single character, command
+// or braced group.
+func (p *mathParser) parseAtom(font vg.Font) box {
+	r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += n
+	switch r {
+	case '{':
+		return p.parseList(font, true)
+	case '\\':
+		return p.parseCommand(font)
+	case '-':
+		return opBox{textBox{font: font, text: "−"}}
+	case '+', '=', '<', '>':
+		return opBox{textBox{font: font, text: string(r)}}
+	case '^', '_':
+		// A script without a base.
+		p.pos -= n
+		return p.parseScripts(font, hbox{})
+	}
+	return textBox{font: font, text: string(r)}
+}
+
+// parseCommand parses a command following a backslash.
+func (p *mathParser) parseCommand(font vg.Font) box {
+	if p.pos == len(p.src) {
+		return textBox{font: font, text: `\`}
+	}
+	start := p.pos
+	for p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		// A single character command.
+		r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.pos += n
+		switch r {
+		case ',':
+			return spaceBox(font.Size / 6)
+		case ':':
+			return spaceBox(font.Size * 2 / 9)
+		case ';':
+			return spaceBox(font.Size * 5 / 18)
+		case ' ':
+			return spaceBox(font.Width(" "))
+		case '!':
+			return spaceBox(-font.Size / 6)
+		}
+		return textBox{font: font, text: string(r)}
+	}
+	name := p.src[start:p.pos]
+	switch name {
+	case "frac":
+		num := p.parseArg(scriptFont(font))
+		den := p.parseArg(scriptFont(font))
+		return newFracBox(font, num, den)
+	case "sqrt":
+		return newSqrtBox(font, p.parseArg(font))
+	case "text", "mathrm":
+		return p.parseText(font)
+	case "quad":
+		return spaceBox(font.Size)
+	case "qquad":
+		return spaceBox(2 * font.Size)
+	}
+	if s, ok := mathSymbols[name]; ok {
+		return textBox{font: font, text: s}
+	}
+	if s, ok := mathOperators[name]; ok {
+		return opBox{textBox{font: font, text: s}}
+	}
+	// Unknown commands are drawn as written.
+	return textBox{font: font, text: `\` + name}
+}
+
+// parseText parses a braced argument as text,
+// keeping its spaces.
+func (p *mathParser) parseText(font vg.Font) box {
+	if p.pos == len(p.src) || p.src[p.pos] != '{' {
+		return hbox{}
+	}
+	end := strings.IndexByte(p.src[p.pos:], '}')
+	if end < 0 {
+		end = len(p.src) - p.pos
+	}
+	text := p.src[p.pos+1 : p.pos+end]
+	p.pos += end + 1
+	if p.pos > len(p.src) {
+		p.pos = len(p.src)
+	}
+	return textBox{font: font, text: text}
+}
+
+// isOpening returns whether b is an opening bracket,
+// after which an operator has no left operand.
+func isOpening(b box) bool {
+	t, ok := b.(textBox)
+	return ok && (t.text == "(" || t.text == "[" || t.text == "⟨")
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// mathSymbols maps the names of commands to
+// the symbols they draw.
+var mathSymbols = map[string]string{
+	"alpha":      "α",
+	"beta":       "β",
+	"gamma":      "γ",
+	"delta":      "δ",
+	"epsilon":    "ϵ",
+	"varepsilon": "ε",
+	"zeta":       "ζ",
+	"eta":        "η",
+	"theta":      "θ",
+	"vartheta":   "ϑ",
+	"iota":       "ι",
+	"kappa":      "κ",
+	"lambda":     "λ",
+	"mu":         "μ",
+	"nu":         "ν",
+	"xi":         "ξ",
+	"pi":         "π",
+	"rho":        "ρ",
+	"sigma":      "σ",
+	"tau":        "τ",
+	"upsilon":    "υ",
+	"phi":        "ϕ",
+	"varphi":     "φ",
+	"chi":        "χ",
+	"psi":        "ψ",
+	"omega":      "ω",
+	"Gamma":      "Γ",
+	"Delta":      "Δ",
+	"Theta":      "Θ",
+	"Lambda":     "Λ",
+	"Xi":         "Ξ",
+	"Pi":         "Π",
+	"Sigma":      "Σ",
+	"Upsilon":    "Υ",
+	"Phi":        "Φ",
+	"Psi":        "Ψ",
+	"Omega":      "Ω",
+
+	"infty":   "∞",
+	"partial": "∂",
+	"nabla":   "∇",
+	"sum":     "∑",
+	"prod":    "∏",
+	"int":     "∫",
+	"degree":  "°",
+	"circ":    "∘",
+	"prime":   "′",
+	"ldots":   "…",
+	"cdots":   "⋯",
+	"hbar":    "ℏ",
+	"ell":     "ℓ",
+	"AA":      "Å",
+	"langle":  "⟨",
+	"rangle":  "⟩",
+}
+
+// mathOperators maps the names of commands to the
+// binary operators and relations they draw.
+var mathOperators = map[string]string{
+	"times":      "×",
+	"cdot":       "·",
+	"div":        "÷",
+	"pm":         "±",
+	"mp":         "∓",
+	"leq":        "≤",
+	"le":         "≤",
+	"geq":        "≥",
+	"ge":         "≥",
+	"neq":        "≠",
+	"ne":         "≠",
+	"approx":     "≈",
+	"sim":        "∼",
+	"simeq":      "≃",
+	"equiv":      "≡",
+	"propto":     "∝",
+	"ll":         "≪",
+	"gg":         "≫",
+	"to":         "→",
+	"rightarrow": "→",
+	"leftarrow":  "←",
+	"in":         "∈",
+}
@@ -0,0 +1,146 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// drawnStrings returns the strings drawn by
+// the FillString actions recorded on c.
+func drawnStrings(c *recorder.Canvas) []*recorder.FillString {
+	var s []*recorder.FillString
+	for _, a := range c.Actions {
+		if fs, ok := a.(*recorder.FillString); ok {
+			s = append(s, fs)
+		}
+	}
+	return s
+}
+
+func TestMathText(t *testing.T) {
+	font, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		text string
+		want []string
+	}{
+		{text: "plain text", want: []string{"plain text"}},
+		{text: "cost ($)", want: []string{"cost ($)"}},
+		{text: `\$5 to \$10`, want: []string{"$5 to $10"}},
+		{text: `$\sigma^2$`, want: []string{"σ", "2"}},
+		{text: `$\times 10^{-3}$`, want: []string{"×", "1", "0", "−", "3"}},
+		{text: `$a - b$`, want: []string{"a", "−", "b"}},
+		{text: `width $w_{max}$ (m)`, want: []string{"width ", "w", "m", "a", "x", " (m)"}},
+		{text: `$\text{max value}$`, want: []string{"max value"}},
+		{text: `$\frac{1}{2}$`, want: []string{"1", "2"}},
+		{text: `$\unknown$`, want: []string{`\unknown`}},
+	} {
+		var r recorder.Canvas
+		c := NewCanvas(&r, 100, 100)
+		c.FillText(TextStyle{Font: font, Math: true}, vg.Point{}, test.text)
+		got := drawnStrings(&r)
+		if len(got) != len(test.want) {
+			t.Errorf("unexpected number of strings for %q: got:%d want:%d", test.text, len(got), len(test.want))
+			continue
+		}
+		for i, fs := range got {
+			if fs.String != test.want[i] {
+				t.Errorf("unexpected string %d for %q: got:%q want:%q", i, test.text, fs.String, test.want[i])
+			}
+		}
+	}
+}
+
+func TestMathTextDisabled(t *testing.T) {
+	font, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const text = `$5 to $10, $\sigma$`
+	var r recorder.Canvas
+	c := NewCanvas(&r, 100, 100)
+	sty := TextStyle{Font: font}
+	c.FillText(sty, vg.Point{}, text)
+	got := drawnStrings(&r)
+	if len(got) != 1 || got[0].String != text {
+		t.Errorf("text without Math not drawn unchanged: got:%v", got)
+	}
+	if got, want := sty.Width(text), font.Width(text); got != want {
+		t.Errorf("unexpected width: got:%v want:%v", got, want)
+	}
+}
+
+func TestMathTextScripts(t *testing.T) {
+	font, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var r recorder.Canvas
+	c := NewCanvas(&r, 100, 100)
+	c.FillText(TextStyle{Font: font, Math: true}, vg.Point{}, `$x^2_i$`)
+	got := drawnStrings(&r)
+	if len(got) != 3 {
+		t.Fatalf("unexpected number of strings: got:%d want:3", len(got))
+	}
+	x, sup, sub := got[0], got[1], got[2]
+	if sup.Size >= x.Size || sub.Size >= x.Size {
+		t.Errorf("scripts not smaller than base: base:%v sup:%v sub:%v", x.Size, sup.Size, sub.Size)
+	}
+	if sup.Point.Y <= x.Point.Y {
+		t.Errorf("superscript not raised: base:%v sup:%v", x.Point.Y, sup.Point.Y)
+	}
+	if sub.Point.Y >= x.Point.Y {
+		t.Errorf("subscript not lowered: base:%v sub:%v", x.Point.Y, sub.Point.Y)
+	}
+	if sup.Point.X != sub.Point.X || sup.Point.X <= x.Point.X {
+		t.Errorf("scripts not placed after base: base:%v sup:%v sub:%v", x.Point.X, sup.Point.X, sub.Point.X)
+	}
+}
+
+func TestMathTextExtents(t *testing.T) {
+	font, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sty := TextStyle{Font: font, Math: true}
+	if got, want := sty.Width(`$\alpha$`), font.Width("α"); got != want {
+		t.Errorf("unexpected width: got:%v want:%v", got, want)
+	}
+	if got, want := sty.Height(`$x$`), sty.Height("x"); got != want {
+		t.Errorf("unexpected height for plain math: got:%v want:%v", got, want)
+	}
+	if got, plain := sty.Height(`$\frac{a}{b}$`), sty.Height("x"); got <= plain {
+		t.Errorf("fraction height not greater than text height: got:%v text:%v", got, plain)
+	}
+}
+
+// typesetter is a vg.Canvas that typesets math.
+type typesetter struct {
+	recorder.Canvas
+}
+
+func (*typesetter) TypesetsMath() bool { return true }
+
+func TestMathTextTypesetter(t *testing.T) {
+	font, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var r typesetter
+	c := NewCanvas(&r, 100, 100)
+	const text = `$\sigma^2$ (m)`
+	c.FillText(TextStyle{Font: font, Math: true}, vg.Point{}, text)
+	got := drawnStrings(&r.Canvas)
+	if len(got) != 1 || got[0].String != text {
+		t.Errorf("text not passed through unchanged: got:%v", got)
+	}
+}
@@ -0,0 +1,350 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import "unicode"
+
+// shape returns the line of text, given in logical order, in the
+// form in which it should be passed to a vg.Canvas for drawing.
+// Arabic letters are replaced with their contextual presentation
+// forms and the text is reordered from logical to visual order
+// using a simplified form of the Unicode bidirectional algorithm.
+// Combining marks are kept with their base characters.
+//
+// Lines without right to left characters are returned unchanged.
+func shape(line string) string {
+	if !hasRTL(line) {
+		return line
+	}
+	rs := joinArabic([]rune(line))
+	return string(reorder(rs))
+}
+
+// hasRTL returns whether s contains any right to left
+// characters or Arabic digits.
+func hasRTL(s string) bool {
+	for _, r := range s {
+		if c := classify(r); c == bidiR || c == bidiAN {
+			return true
+		}
+	}
+	return false
+}
+
+// bidiClass is a simplified Unicode bidirectional
+// character type.
+type bidiClass int
+
+const (
+	bidiON  bidiClass = iota // Neutral, including whitespace and punctuation.
+	bidiL                    // Strong left to right.
+	bidiR                    // Strong right to left, including Arabic letters.
+	bidiEN                   // European number.
+	bidiAN                   // Arabic number.
+	bidiNSM                  // Non-spacing mark.
+)
+
+// classify returns the bidirectional class of r.
+func classify(r rune) bidiClass {
+	switch {
+	case unicode.Is(unicode.Mn, r):
+		return bidiNSM
+	case '0' <= r && r <= '9', 0x06f0 <= r && r <= 0x06f9:
+		return bidiEN
+	case 0x0660 <= r && r <= 0x0669:
+		return bidiAN
+	case 0x0590 <= r && r <= 0x08ff,
+		0xfb1d <= r && r <= 0xfdff,
+		0xfe70 <= r && r <= 0xfeff,
+		0x10800 <= r && r <= 0x10fff:
+		return bidiR
+	case unicode.IsLetter(r):
+		return bidiL
+	}
+	return bidiON
+}
+
+// cluster is a base character along with any
+// following combining marks.
+type cluster struct {
+	runes []rune
+	class bidiClass
+	level int
+}
+
+// reorder returns rs, in logical order, reordered into visual order.
+func reorder(rs []rune) []rune {
+	var cs []cluster
+	for i, r := range rs {
+		c := classify(r)
+		if c == bidiNSM && len(cs) != 0 {
+			cs[len(cs)-1].runes = append(cs[len(cs)-1].runes, r)
+			continue
+		}
+		if c == bidiNSM {
+			c = bidiON
+		}
+		cs = append(cs, cluster{runes: rs[i : i+1 : i+1], class: c})
+	}
+
+	// The paragraph direction is that of the first strong character.
+	para := bidiL
+	for _, c := range cs {
+		if c.class == bidiL || c.class == bidiR {
+			para = c.class
+			break
+		}
+	}
+
+	// European numbers preceded by left to right text are
+	// treated as left to right text.
+	prev := para
+	for i, c := range cs {
+		switch c.class {
+		case bidiL, bidiR:
+			prev = c.class
+		case bidiEN:
+			if prev == bidiL {
+				cs[i].class = bidiL
+			}
+		}
+	}
+
+	// Neutrals take the direction of the surrounding text if
+	// it is the same on both sides, and otherwise the direction
+	// of the paragraph. Numbers are treated as right to left.
+	strong := func(c bidiClass) bidiClass {
+		if c == bidiEN || c == bidiAN {
+			return bidiR
+		}
+		return c
+	}
+	for i := 0; i < len(cs); {
+		if cs[i].class != bidiON {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cs) && cs[j].class == bidiON {
+			j++
+		}
+		before, after := para, para
+		if i > 0 {
+			before = strong(cs[i-1].class)
+		}
+		if j < len(cs) {
+			after = strong(cs[j].class)
+		}
+		dir := para
+		if before == after {
+			dir = before
+		}
+		for k := i; k < j; k++ {
+			cs[k].class = dir
+		}
+		i = j
+	}
+
+	// Resolve the embedding level of each cluster.
+	base := 0
+	if para == bidiR {
+		base = 1
+	}
+	maxLevel := base
+	for i, c := range cs {
+		switch {
+		case base == 0 && c.class == bidiR:
+			cs[i].level = 1
+		case base == 0 && (c.class == bidiEN || c.class == bidiAN):
+			cs[i].level = 2
+		case base == 1 && c.class != bidiR:
+			cs[i].level = 2
+		default:
+			cs[i].level = base
+		}
+		if cs[i].level > maxLevel {
+			maxLevel = cs[i].level
+		}
+	}
+
+	// Trailing whitespace takes the paragraph level.
+	for i := len(cs) - 1; i >= 0 && unicode.IsSpace(cs[i].runes[0]); i-- {
+		cs[i].level = base
+	}
+
+	// Reverse each run of clusters at or above each
+	// level, from the highest level to the lowest odd
+	// level.
+	for lvl := maxLevel; lvl > 0; lvl-- {
+		for i := 0; i < len(cs); {
+			if cs[i].level < lvl {
+				i++
+				continue
+			}
+			j := i
+			for j < len(cs) && cs[j].level >= lvl {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				cs[a], cs[b] = cs[b], cs[a]
+			}
+			i = j
+		}
+	}
+
+	out := make([]rune, 0, len(rs))
+	for _, c := range cs {
+		if c.level%2 == 1 {
+			if m, ok := mirror[c.runes[0]]; ok {
+				out = append(out, m)
+				out = append(out, c.runes[1:]...)
+				continue
+			}
+		}
+		out = append(out, c.runes...)
+	}
+	return out
+}
+
+// mirror holds the mirrored forms of paired punctuation
+// drawn within right to left text.
+var mirror = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+// arabicForms holds the isolated, final, initial and medial
+// presentation forms of Arabic letters. Letters that only
+// join to the preceding letter have no initial or medial
+// forms.
+var arabicForms = map[rune][4]rune{
+	0x0621: {0xfe80, 0, 0, 0},
+	0x0622: {0xfe81, 0xfe82, 0, 0},
+	0x0623: {0xfe83, 0xfe84, 0, 0},
+	0x0624: {0xfe85, 0xfe86, 0, 0},
+	0x0625: {0xfe87, 0xfe88, 0, 0},
+	0x0626: {0xfe89, 0xfe8a, 0xfe8b, 0xfe8c},
+	0x0627: {0xfe8d, 0xfe8e, 0, 0},
+	0x0628: {0xfe8f, 0xfe90, 0xfe91, 0xfe92},
+	0x0629: {0xfe93, 0xfe94, 0, 0},
+	0x062a: {0xfe95, 0xfe96, 0xfe97, 0xfe98},
+	0x062b: {0xfe99, 0xfe9a, 0xfe9b, 0xfe9c},
+	0x062c: {0xfe9d, 0xfe9e, 0xfe9f, 0xfea0},
+	0x062d: {0xfea1, 0xfea2, 0xfea3, 0xfea4},
+	0x062e: {0xfea5, 0xfea6, 0xfea7, 0xfea8},
+	0x062f: {0xfea9, 0xfeaa, 0, 0},
+	0x0630: {0xfeab, 0xfeac, 0, 0},
+	0x0631: {0xfead, 0xfeae, 0, 0},
+	0x0632: {0xfeaf, 0xfeb0, 0, 0},
+	0x0633: {0xfeb1, 0xfeb2, 0xfeb3, 0xfeb4},
+	0x0634: {0xfeb5, 0xfeb6, 0xfeb7, 0xfeb8},
+	0x0635: {0xfeb9, 0xfeba, 0xfebb, 0xfebc},
+	0x0636: {0xfebd, 0xfebe, 0xfebf, 0xfec0},
+	0x0637: {0xfec1, 0xfec2, 0xfec3, 0xfec4},
+	0x0638: {0xfec5, 0xfec6, 0xfec7, 0xfec8},
+	0x0639: {0xfec9, 0xfeca, 0xfecb, 0xfecc},
+	0x063a: {0xfecd, 0xfece, 0xfecf, 0xfed0},
+	0x0641: {0xfed1, 0xfed2, 0xfed3, 0xfed4},
+	0x0642: {0xfed5, 0xfed6, 0xfed7, 0xfed8},
+	0x0643: {0xfed9, 0xfeda, 0xfedb, 0xfedc},
+	0x0644: {0xfedd, 0xfede, 0xfedf, 0xfee0},
+	0x0645: {0xfee1, 0xfee2, 0xfee3, 0xfee4},
+	0x0646: {0xfee5, 0xfee6, 0xfee7, 0xfee8},
+	0x0647: {0xfee9, 0xfeea, 0xfeeb, 0xfeec},
+	0x0648: {0xfeed, 0xfeee, 0, 0},
+	0x0649: {0xfeef, 0xfef0, 0xfbe8, 0xfbe9},
+	0x064a: {0xfef1, 0xfef2, 0xfef3, 0xfef4},
+}
+
+// lamAlef holds the isolated forms of the ligatures of lam
+// with the alef that follows it. The final forms follow
+// the isolated forms.
+var lamAlef = map[rune]rune{
+	0x0622: 0xfef5,
+	0x0623: 0xfef7,
+	0x0625: 0xfef9,
+	0x0627: 0xfefb,
+}
+
+const (
+	lam     = 0x0644
+	tatweel = 0x0640
+)
+
+// joinArabic returns rs, in logical order, with Arabic
+// letters replaced by their contextual forms.
+func joinArabic(rs []rune) []rune {
+	// joinsNext returns whether the character at i
+	// joins to the following character.
+	joinsNext := func(i int) bool {
+		if rs[i] == tatweel {
+			return true
+		}
+		f, ok := arabicForms[rs[i]]
+		return ok && f[2] != 0
+	}
+	// joinsPrev returns whether the character at i
+	// joins to the preceding character.
+	joinsPrev := func(i int) bool {
+		if rs[i] == tatweel {
+			return true
+		}
+		f, ok := arabicForms[rs[i]]
+		return ok && f[1] != 0
+	}
+	// neighbour returns the index of the nearest character
+	// from i in the direction d that is not a combining
+	// mark, or -1 if there is none.
+	neighbour := func(i, d int) int {
+		for i += d; 0 <= i && i < len(rs); i += d {
+			if !unicode.Is(unicode.Mn, rs[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	out := make([]rune, 0, len(rs))
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		f, ok := arabicForms[r]
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+		prev := neighbour(i, -1)
+		linked := prev >= 0 && joinsNext(prev) && joinsPrev(i)
+
+		if r == lam {
+			if next := neighbour(i, 1); next == i+1 {
+				if lig, ok := lamAlef[rs[next]]; ok {
+					if linked {
+						lig++
+					}
+					out = append(out, lig)
+					i = next
+					continue
+				}
+			}
+		}
+
+		next := neighbour(i, 1)
+		linksNext := next >= 0 && joinsNext(i) && joinsPrev(next)
+		switch {
+		case linked && linksNext:
+			out = append(out, f[3])
+		case linked:
+			out = append(out, f[1])
+		case linksNext:
+			out = append(out, f[2])
+		default:
+			out = append(out, f[0])
+		}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import "testing"
+
+var shapeTests = []struct {
+	name string
+	in   string
+	want string
+}{
+	{
+		name: "latin",
+		in:   "temperature (K)",
+		want: "temperature (K)",
+	},
+	{
+		name: "hebrew",
+		in:   "שלום",
+		want: "םולש",
+	},
+	{
+		name: "hebrew with number",
+		in:   "גובה 120 מטר",
+		want: "רטמ 120 הבוג",
+	},
+	{
+		name: "hebrew with parentheses",
+		in:   "זמן (שניות)",
+		want: "(תוינש) ןמז",
+	},
+	{
+		name: "hebrew with points",
+		in:   "שָׁלוֹם",
+		want: "םוֹלשָׁ",
+	},
+	{
+		name: "latin with embedded hebrew",
+		in:   "axis שלום label",
+		want: "axis םולש label",
+	},
+	{
+		name: "hebrew with embedded latin",
+		in:   "ציר x ערך",
+		want: "ךרע x ריצ",
+	},
+	{
+		name: "trailing space",
+		in:   "שלום ",
+		want: " םולש",
+	},
+	{
+		// كتب: kaf initial, teh medial, beh final.
+		name: "arabic",
+		in:   "كتب",
+		want: "ﺐﺘﻛ",
+	},
+	{
+		// سلام: seen initial, lam alef ligature final, meem isolated.
+		name: "arabic with ligature",
+		in:   "سلام",
+		want: "ﻡﻼﺳ",
+	},
+	{
+		// لا: lam alef ligature, isolated.
+		name: "arabic lam alef",
+		in:   "لا",
+		want: "ﻻ",
+	},
+	{
+		// بلا: beh initial, lam alef ligature final.
+		name: "arabic lam alef final",
+		in:   "بلا",
+		want: "ﻼﺑ",
+	},
+	{
+		// بِب: the kasra does not break the join.
+		name: "arabic with mark",
+		in:   "بِب",
+		want: "ﺐﺑِ",
+	},
+	{
+		name: "arabic digits",
+		in:   "عدد ١٢",
+		want: "١٢ ﺩﺪﻋ",
+	},
+}
+
+func TestShape(t *testing.T) {
+	for _, test := range shapeTests {
+		got := shape(test.in)
+		if got != test.want {
+			t.Errorf("unexpected result for %s: got:%q want:%q", test.name, got, test.want)
+		}
+	}
+}
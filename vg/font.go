@@ -36,6 +36,7 @@ var (
 	// FontMap maps Postscript/PDF font names to compatible
 	// free fonts (TrueType converted ghostscript fonts).
 	// Fonts that are not keys of this map are not supported.
+	// FontMap must not be changed while fonts are made.
 	FontMap = map[string]string{
 
 		// We use fonts from RedHat's Liberation project:
@@ -61,7 +62,13 @@ var (
 	// caches the associated *truetype.Font.
 	loadedFonts = make(map[string]*truetype.Font)
 
-	// FontLock protects access to the loadedFonts map.
+	// fontBytes is indexed by a font name and it
+	// holds the font data of fonts added using
+	// AddFontData.
+	fontBytes = make(map[string][]byte)
+
+	// FontLock protects access to the loadedFonts
+	// and fontBytes maps.
 	fontLock sync.RWMutex
 )
 
@@ -101,6 +108,9 @@ type Font struct {
 // file.  The font file name is name mapped by FontMap with the
 // .ttf extension.  For example, the font file for the font name
 // Courier is LiberationMono-Regular.ttf.
+//
+// MakeFont may be called concurrently. All fonts of the
+// same name share the font data loaded by the first call.
 func MakeFont(name string, size Length) (font Font, err error) {
 	font.Size = size
 	font.name = name
@@ -184,6 +194,50 @@ func AddFont(name string, font *truetype.Font) {
 	fontLock.Unlock()
 }
 
+// AddFontData parses the given TrueType font data and associates
+// the font with the given name, so that it can be used by MakeFont
+// in addition to the fonts in the FontMap. OpenType fonts are
+// supported if they use TrueType outlines. The data is retained
+// so that backends that are able to may embed the font in their
+// output: vgsvg embeds the whole font file, while vgpdf, which
+// only supports the standard PDF fonts, refuses to write text in
+// such fonts.
+func AddFontData(name string, data []byte) error {
+	font, err := freetype.ParseFont(data)
+	if err != nil {
+		return errors.New("Failed to parse font data: " + err.Error())
+	}
+	fontLock.Lock()
+	loadedFonts[name] = font
+	fontBytes[name] = data
+	fontLock.Unlock()
+	return nil
+}
+
+// AddFontFile is like AddFontData, but reads the
+// font data from the named file.
+func AddFontFile(name, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return AddFontData(name, data)
+}
+
+// FontData returns the TrueType font data for the given font
+// name, either as added by AddFontData or as located for a font
+// in the FontMap. It returns an error if the font data is not
+// available, for example if the font was added with AddFont.
+func FontData(name string) ([]byte, error) {
+	fontLock.RLock()
+	data, ok := fontBytes[name]
+	fontLock.RUnlock()
+	if ok {
+		return data, nil
+	}
+	return fontData(name)
+}
+
 // getFont returns the truetype.Font for the given font name or an error.
 func getFont(name string) (*truetype.Font, error) {
 	fontLock.RLock()
@@ -201,7 +255,13 @@ func getFont(name string) (*truetype.Font, error) {
 	font, err := freetype.ParseFont(bytes)
 	if err == nil {
 		fontLock.Lock()
-		loadedFonts[name] = font
+		// Keep the font loaded by another
+		// goroutine in the meantime, if any.
+		if f, ok := loadedFonts[name]; ok {
+			font = f
+		} else {
+			loadedFonts[name] = font
+		}
 		fontLock.Unlock()
 	} else {
 		err = errors.New("Failed to parse font file: " + err.Error())
@@ -0,0 +1,144 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"image/png"
+	"reflect"
+
+	"github.com/gonum/plot/vg"
+)
+
+// actions returns new actions of each type, by name.
+var actions = map[string]func() Action{
+	"SetLineWidth": func() Action { return &SetLineWidth{} },
+	"SetLineDash":  func() Action { return &SetLineDash{} },
+	"SetColor":     func() Action { return &SetColor{} },
+	"Rotate":       func() Action { return &Rotate{} },
+	"Translate":    func() Action { return &Translate{} },
+	"Scale":        func() Action { return &Scale{} },
+	"Push":         func() Action { return &Push{} },
+	"Pop":          func() Action { return &Pop{} },
+	"Stroke":       func() Action { return &Stroke{} },
+	"Fill":         func() Action { return &Fill{} },
+	"FillString":   func() Action { return &FillString{} },
+	"DrawImage":    func() Action { return &DrawImage{} },
+	"Clip":         func() Action { return &Clip{} },
+	"BeginGroup":   func() Action { return &BeginGroup{} },
+	"EndGroup":     func() Action { return &EndGroup{} },
+	"Comment":      func() Action { return &Comment{} },
+}
+
+// jsonAction is the JSON encoding of an action.
+type jsonAction struct {
+	Op   string
+	Args json.RawMessage `json:",omitempty"`
+}
+
+// jsonColor is the JSON encoding of the
+// color of a SetColor action.
+type jsonColor struct {
+	Color *struct{ R, G, B, A uint32 }
+}
+
+// jsonImage is the JSON encoding of
+// the arguments of a DrawImage action.
+type jsonImage struct {
+	Rectangle vg.Rectangle
+	PNG       []byte
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding
+// the actions recorded by the canvas as a JSON array of objects
+// holding the name of the method of each action and its
+// arguments, such as
+//
+//	{"Op":"Rotate","Args":{"Angle":0.72}}
+//
+// so that the actions may be stored or compared, and replayed
+// later on any canvas. Colors are encoded by their 16-bit alpha
+// premultiplied components, and images as PNG. The caller
+// locations of the actions are not encoded.
+func (c *Canvas) MarshalJSON() ([]byte, error) {
+	enc := make([]jsonAction, len(c.Actions))
+	for i, a := range c.Actions {
+		var args interface{} = a
+		switch a := a.(type) {
+		case *SetColor:
+			var col jsonColor
+			if a.Color != nil {
+				col.Color = &struct{ R, G, B, A uint32 }{}
+				col.Color.R, col.Color.G, col.Color.B, col.Color.A = a.Color.RGBA()
+			}
+			args = col
+		case *DrawImage:
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, a.Image); err != nil {
+				return nil, fmt.Errorf("recorder: error encoding image to PNG: %v", err)
+			}
+			args = jsonImage{Rectangle: a.Rectangle, PNG: buf.Bytes()}
+		}
+		b, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+		enc[i].Op = reflect.TypeOf(a).Elem().Name()
+		if string(b) != "{}" {
+			enc[i].Args = b
+		}
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface,
+// replacing the actions of the canvas with those decoded from
+// the JSON encoding returned by MarshalJSON.
+func (c *Canvas) UnmarshalJSON(b []byte) error {
+	var enc []jsonAction
+	if err := json.Unmarshal(b, &enc); err != nil {
+		return err
+	}
+	acts := make([]Action, len(enc))
+	for i, e := range enc {
+		newAction, ok := actions[e.Op]
+		if !ok {
+			return fmt.Errorf("recorder: unknown action %q", e.Op)
+		}
+		a := newAction()
+		if e.Args != nil {
+			var err error
+			switch a := a.(type) {
+			case *SetColor:
+				var col jsonColor
+				err = json.Unmarshal(e.Args, &col)
+				if col.Color != nil {
+					a.Color = color.RGBA64{
+						R: uint16(col.Color.R), G: uint16(col.Color.G),
+						B: uint16(col.Color.B), A: uint16(col.Color.A),
+					}
+				}
+			case *DrawImage:
+				var img jsonImage
+				if err = json.Unmarshal(e.Args, &img); err != nil {
+					break
+				}
+				a.Rectangle = img.Rectangle
+				a.Image, err = png.Decode(bytes.NewReader(img.PNG))
+			default:
+				err = json.Unmarshal(e.Args, a)
+			}
+			if err != nil {
+				return fmt.Errorf("recorder: error decoding action %d: %v", i, err)
+			}
+		}
+		acts[i] = a
+	}
+	c.Actions = acts
+	return nil
+}
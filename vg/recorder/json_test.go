@@ -0,0 +1,67 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package recorder
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+)
+
+func TestJSON(t *testing.T) {
+	var rec Canvas
+	rec.Groups = true
+	rec.Comment("Start")
+	rec.BeginGroup("data", "plotter line")
+	rec.Push()
+	rec.SetLineWidth(2)
+	rec.SetLineDash([]vg.Length{2, 5}, 1)
+	rec.SetColor(color.NRGBA{R: 0x65, G: 0x23, B: 0xf2, A: 0x80})
+	rec.SetColor(nil)
+	rec.Translate(vg.Point{X: 3, Y: 4})
+	rec.Scale(1, 2)
+	rec.Rotate(0.72)
+	rec.Clip(vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: 3, Y: 4}}, {Type: vg.ArcComp, Pos: vg.Point{X: 1, Y: 1}, Radius: 2, Start: 0.5, Angle: 1.5}})
+	rec.Stroke(vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: 3, Y: 4}}, {Type: vg.LineComp, Pos: vg.Point{X: 0.1, Y: 1e-7}}})
+	rec.Fill(vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: 3, Y: 4}}, {Type: vg.CloseComp}})
+	rec.Actions = append(rec.Actions, &FillString{Font: "Times-Roman", Size: 12, Point: vg.Point{X: 0, Y: 10}, String: "Text"})
+	rec.DrawImage(vg.Rectangle{Min: vg.Point{X: 0, Y: 0}, Max: vg.Point{X: 10, Y: 10}}, img)
+	rec.Pop()
+	rec.EndGroup()
+
+	b, err := json.Marshal(&rec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got Canvas
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Actions) != len(rec.Actions) {
+		t.Fatalf("unexpected number of actions decoded: got:%d want:%d", len(got.Actions), len(rec.Actions))
+	}
+	for i, a := range rec.Actions {
+		want := a.Call()
+		if c, ok := a.(*SetColor); ok && c.Color != nil {
+			// Colors are decoded as color.RGBA64.
+			r, g, b, a := c.Color.RGBA()
+			want = (&SetColor{Color: color.RGBA64{R: uint16(r), G: uint16(g), B: uint16(b), A: uint16(a)}}).Call()
+		}
+		if got := got.Actions[i].Call(); got != want {
+			t.Errorf("unexpected action %d:\n\tgot: %s\n\twant: %s", i, got, want)
+		}
+	}
+
+	var replay Canvas
+	if err := got.ReplayOn(&replay); err != nil {
+		t.Errorf("unexpected error replaying decoded actions: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`[{"Op":"Explode"}]`), &got); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
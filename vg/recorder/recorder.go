@@ -30,6 +30,11 @@ type Canvas struct {
 	// This includes source filename and line number.
 	KeepCaller bool
 
+	// Groups indicates whether the Canvas will record
+	// the groups begun and ended by the BeginGroup and
+	// EndGroup methods of the vg.Grouper interface.
+	Groups bool
+
 	// c holds a backing vg.Canvas. If c is non-nil
 	// then method calls to the Canvas will be
 	// reflected to c.
@@ -444,6 +449,100 @@ func (a *DrawImage) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// Clip corresponds to the vg.Clipper.Clip method.
+type Clip struct {
+	Path vg.Path
+
+	l callerLocation
+}
+
+// Clip implements the Clip method of the vg.Clipper interface.
+func (c *Canvas) Clip(path vg.Path) {
+	c.append(&Clip{Path: append(vg.Path(nil), path...)})
+}
+
+// Call returns the method call that generated the action.
+func (a *Clip) Call() string {
+	return fmt.Sprintf("%sClip(%#v)", a.l, a.Path)
+}
+
+// ApplyTo applies the action to the given vg.Canvas if
+// it is a vg.Clipper. Otherwise ApplyTo does nothing.
+func (a *Clip) ApplyTo(c vg.Canvas) {
+	if cl, ok := c.(vg.Clipper); ok {
+		cl.Clip(a.Path)
+	}
+}
+
+func (a *Clip) callerLocation() *callerLocation {
+	return &a.l
+}
+
+var _ vg.Grouper = (*Canvas)(nil)
+
+// BeginGroup corresponds to the vg.Grouper.BeginGroup method.
+type BeginGroup struct {
+	ID, Class string
+
+	l callerLocation
+}
+
+// BeginGroup implements the BeginGroup method of the vg.Grouper
+// interface. The group is recorded only if Groups is true.
+func (c *Canvas) BeginGroup(id, class string) {
+	if c.Groups {
+		c.append(&BeginGroup{ID: id, Class: class})
+	}
+}
+
+// Call returns the method call that generated the action.
+func (a *BeginGroup) Call() string {
+	return fmt.Sprintf("%sBeginGroup(%q, %q)", a.l, a.ID, a.Class)
+}
+
+// ApplyTo applies the action to the given vg.Canvas if
+// it is a vg.Grouper. Otherwise ApplyTo does nothing.
+func (a *BeginGroup) ApplyTo(c vg.Canvas) {
+	if g, ok := c.(vg.Grouper); ok {
+		g.BeginGroup(a.ID, a.Class)
+	}
+}
+
+func (a *BeginGroup) callerLocation() *callerLocation {
+	return &a.l
+}
+
+// EndGroup corresponds to the vg.Grouper.EndGroup method.
+type EndGroup struct {
+	l callerLocation
+}
+
+// EndGroup implements the EndGroup method of the vg.Grouper
+// interface. The end of the group is recorded only if Groups
+// is true.
+func (c *Canvas) EndGroup() {
+	if c.Groups {
+		c.append(&EndGroup{})
+	}
+}
+
+// Call returns the method call that generated the action.
+func (a *EndGroup) Call() string {
+	return fmt.Sprintf("%sEndGroup()", a.l)
+}
+
+// ApplyTo applies the action to the given vg.Canvas if
+// it is a vg.Grouper. Otherwise ApplyTo does nothing.
+func (a *EndGroup) ApplyTo(c vg.Canvas) {
+	if g, ok := c.(vg.Grouper); ok {
+		g.EndGroup()
+	}
+}
+
+func (a *EndGroup) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // Commenter defines types that can record comments.
 type Commenter interface {
 	Comment(string)
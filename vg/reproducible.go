@@ -0,0 +1,39 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Reproducible specifies whether back-ends should produce
+// byte-identical output for identical drawing operations.
+// When Reproducible is true, back-ends write a fixed
+// timestamp instead of the current time and derive
+// generated names from their content rather than from
+// the clock.
+//
+// Reproducible defaults to true if the SOURCE_DATE_EPOCH
+// environment variable is set.
+var Reproducible = os.Getenv("SOURCE_DATE_EPOCH") != ""
+
+// Now returns the time that back-ends should record as the
+// creation time of their output. If Reproducible is false,
+// Now returns the current time. Otherwise it returns the
+// time given in seconds since the Unix epoch by the
+// SOURCE_DATE_EPOCH environment variable or, if that is
+// not set or is invalid, the Unix epoch itself.
+func Now() time.Time {
+	if !Reproducible {
+		return time.Now()
+	}
+	sec, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64)
+	if err != nil {
+		sec = 0
+	}
+	return time.Unix(sec, 0).UTC()
+}
@@ -93,6 +93,64 @@ type CanvasWriterTo interface {
 	io.WriterTo
 }
 
+// Grouper wraps the BeginGroup and EndGroup methods.
+// Canvases that implement Grouper can annotate the
+// elements drawn between calls to BeginGroup and the
+// matching EndGroup, for example so that they can be
+// restyled after the canvas has been written.
+type Grouper interface {
+	// BeginGroup starts a group of drawing operations
+	// with the given id and space separated classes.
+	// Either may be the empty string. Groups must be
+	// properly nested with calls to Push and Pop.
+	BeginGroup(id, class string)
+
+	// EndGroup ends the most recently started group.
+	EndGroup()
+}
+
+// Blitter wraps the BlitRect method. Raster canvases
+// implement Blitter so that image-like plotters can
+// write pixels directly into the backing image rather
+// than filling a path for each element.
+type Blitter interface {
+	// BlitRect returns the image backing the canvas and
+	// the rectangle of its pixels covered by rect in the
+	// current canvas coordinates. The returned rectangle
+	// is not clipped to the bounds of the image.
+	// If pixels can not be written directly, for example
+	// because the current transform includes a rotation,
+	// ok is false.
+	BlitRect(rect Rectangle) (img *image.RGBA, r image.Rectangle, ok bool)
+}
+
+// Clipper wraps the Clip method. Canvases that implement
+// Clipper can restrict drawing to a region, for example
+// so that plotters do not draw outside the axes of a plot.
+// Drawing on canvases that do not implement Clipper, such
+// as those of vgpdf, is not clipped. A vgimg canvas created
+// with UseImageWithContext implements Clipper but does not
+// clip.
+type Clipper interface {
+	// Clip intersects the clipping region with the area
+	// enclosed by the path, in the current coordinates.
+	// Nothing is drawn outside the clipping region. The
+	// clipping region is restored by Pop to its value at
+	// the matching Push.
+	Clip(Path)
+}
+
+// MathTypesetter wraps the TypesetsMath method. Canvases
+// whose output is typeset by TeX implement MathTypesetter
+// so that math notation in text is passed to FillString
+// unchanged rather than being laid out by the draw package.
+type MathTypesetter interface {
+	// TypesetsMath returns whether math notation in
+	// text passed to FillString is typeset by the
+	// canvas.
+	TypesetsMath() bool
+}
+
 // Initialize sets all of the canvas's values to their
 // initial values.
 func Initialize(c Canvas) {
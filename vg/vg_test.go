@@ -165,3 +165,39 @@ func TestParseLength(t *testing.T) {
 		}
 	}
 }
+
+func TestAddFontData(t *testing.T) {
+	data, err := vg.FontData("Helvetica")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const name = "TestAddFontData-Sans"
+	if _, err := vg.MakeFont(name, 12); err == nil {
+		t.Fatalf("expected error making font before it was added")
+	}
+	if err := vg.AddFontData(name, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	font, err := vg.MakeFont(name, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	helv, err := vg.MakeFont("Helvetica", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := font.Width("gonum"), helv.Width("gonum"); got != want {
+		t.Errorf("unexpected text width: got:%v want:%v", got, want)
+	}
+	got, err := vg.FontData(name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("unexpected font data")
+	}
+
+	if err := vg.AddFontData("TestAddFontData-Invalid", []byte("not a font")); err == nil {
+		t.Errorf("expected error adding invalid font data")
+	}
+}
@@ -59,7 +59,7 @@ func NewTitle(w, h vg.Length, title string) *Canvas {
 	c.buf.WriteString(fmt.Sprintf("%%%%BoundingBox: 0 0 %.*g %.*g\n",
 		pr, w.Dots(DPI),
 		pr, h.Dots(DPI)))
-	c.buf.WriteString(fmt.Sprintf("%%%%CreationDate: %s\n", time.Now()))
+	c.buf.WriteString(fmt.Sprintf("%%%%CreationDate: %s\n", vg.Now().Format(time.RFC1123Z)))
 	c.buf.WriteString("%%Orientation: Portrait\n")
 	c.buf.WriteString("%%EndComments\n")
 	c.buf.WriteString("\n")
@@ -152,6 +152,12 @@ func (e *Canvas) Fill(path vg.Path) {
 	e.buf.WriteString("fill\n")
 }
 
+// Clip implements the vg.Clipper interface.
+func (e *Canvas) Clip(path vg.Path) {
+	e.trace(path)
+	e.buf.WriteString("clip newpath\n")
+}
+
 func (e *Canvas) trace(path vg.Path) {
 	e.buf.WriteString("newpath\n")
 	for _, comp := range path {
@@ -0,0 +1,271 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgimg
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+	"strings"
+	"time"
+)
+
+// Animation is a sequence of frames drawn on image canvases,
+// which may be written as an animated GIF or PNG (APNG) image.
+type Animation struct {
+	// Delay is the time for which each frame is shown.
+	Delay time.Duration
+
+	// Plays is the number of times the animation is
+	// played. If it is zero, the animation is repeated
+	// indefinitely.
+	Plays int
+
+	// Palette is the palette to which the frames are
+	// quantized when written as a GIF image. If it is
+	// nil, the Plan 9 palette of 256 colors is used.
+	Palette color.Palette
+
+	// Dither specifies whether the frames written as a
+	// GIF image are dithered, using Floyd-Steinberg error
+	// diffusion, rather than quantized to the nearest
+	// colors of the palette. Dithering smooths gradients,
+	// such as those of heat maps, but blurs thin lines.
+	Dither bool
+
+	frames []*image.NRGBA
+}
+
+// NewAnimation returns an empty animation
+// whose frames are each shown for delay.
+func NewAnimation(delay time.Duration) *Animation {
+	return &Animation{Delay: delay}
+}
+
+// Add adds a copy of the image of the canvas as the next
+// frame of the animation. It returns an error if the size
+// of the image does not match that of the previous frames.
+func (a *Animation) Add(c *Canvas) error {
+	b := c.img.Bounds()
+	if len(a.frames) > 0 && a.frames[0].Bounds().Size() != b.Size() {
+		return fmt.Errorf("vgimg: frame size %v does not match animation size %v", b.Size(), a.frames[0].Bounds().Size())
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(img, img.Bounds(), c.img, b.Min, draw.Src)
+	a.frames = append(a.frames, img)
+	return nil
+}
+
+// Len returns the number of frames of the animation.
+func (a *Animation) Len() int {
+	return len(a.frames)
+}
+
+// WriterTo returns an io.WriterTo that writes the animation
+// in the given format, "gif" or "png" or "apng", with an
+// optional leading dot.
+func (a *Animation) WriterTo(format string) (io.WriterTo, error) {
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "gif":
+		return GifAnimation{Animation: a}, nil
+	case "png", "apng":
+		return PngAnimation{Animation: a}, nil
+	default:
+		return nil, fmt.Errorf("unsupported animation format: %q", format)
+	}
+}
+
+// A GifAnimation is an animation with a WriteTo
+// method that writes an animated GIF image.
+type GifAnimation struct {
+	*Animation
+}
+
+// WriteTo implements the io.WriterTo interface, writing an animated
+// GIF image. GIF images do not support translucency, so the frames
+// should be drawn on an opaque background.
+func (a GifAnimation) WriteTo(w io.Writer) (int64, error) {
+	wc := writerCounter{Writer: w}
+	if len(a.frames) == 0 {
+		return 0, errors.New("vgimg: animation has no frames")
+	}
+	pal := a.Palette
+	if pal == nil {
+		pal = palette.Plan9
+	}
+	var drawer draw.Drawer = draw.Src
+	if a.Dither {
+		drawer = draw.FloydSteinberg
+	}
+	g := gif.GIF{
+		Image:     make([]*image.Paletted, len(a.frames)),
+		Delay:     make([]int, len(a.frames)),
+		LoopCount: a.loopCount(),
+	}
+	delay := int(a.Delay / (10 * time.Millisecond))
+	for i, f := range a.frames {
+		g.Image[i] = image.NewPaletted(f.Bounds(), pal)
+		drawer.Draw(g.Image[i], f.Bounds(), f, image.ZP)
+		g.Delay[i] = delay
+	}
+	b := bufio.NewWriter(&wc)
+	if err := gif.EncodeAll(b, &g); err != nil {
+		return wc.n, err
+	}
+	err := b.Flush()
+	return wc.n, err
+}
+
+// loopCount returns the GIF loop count of the animation,
+// which is the number of times it is repeated after it
+// is first played, or -1 if it is played once.
+func (a *Animation) loopCount() int {
+	switch {
+	case a.Plays <= 0:
+		return 0
+	case a.Plays == 1:
+		return -1
+	default:
+		return a.Plays - 1
+	}
+}
+
+// A PngAnimation is an animation with a WriteTo method
+// that writes an animated PNG (APNG) image. Viewers that
+// do not support APNG show the first frame.
+type PngAnimation struct {
+	*Animation
+}
+
+// WriteTo implements the io.WriterTo interface,
+// writing an animated PNG image.
+func (a PngAnimation) WriteTo(w io.Writer) (int64, error) {
+	wc := writerCounter{Writer: w}
+	if len(a.frames) == 0 {
+		return 0, errors.New("vgimg: animation has no frames")
+	}
+	e := apngEncoder{w: bufio.NewWriter(&wc)}
+	size := a.frames[0].Bounds().Size()
+
+	e.w.WriteString("\x89PNG\r\n\x1a\n")
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(size.X))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(size.Y))
+	ihdr[8] = 8 // Bit depth.
+	ihdr[9] = 6 // Color type of 8-bit RGBA.
+	e.chunk("IHDR", ihdr)
+
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(a.frames)))
+	binary.BigEndian.PutUint32(actl[4:], uint32(a.Plays))
+	e.chunk("acTL", actl)
+
+	// The delay is given as a fraction of a second,
+	// in milliseconds if it fits, else in centiseconds.
+	num, den := a.Delay/time.Millisecond, uint16(1000)
+	if num > 0xffff {
+		num, den = a.Delay/(10*time.Millisecond), 100
+		if num > 0xffff {
+			num = 0xffff
+		}
+	}
+	for i, f := range a.frames {
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], e.seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(size.X))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(size.Y))
+		// The frame is drawn at the origin, replacing the
+		// previous frame, so the offsets, the disposal and
+		// the blending are all zero.
+		binary.BigEndian.PutUint16(fctl[20:], uint16(num))
+		binary.BigEndian.PutUint16(fctl[22:], den)
+		e.seq++
+		e.chunk("fcTL", fctl)
+
+		data, err := apngData(f)
+		if err != nil {
+			return wc.n, err
+		}
+		if i == 0 {
+			// The first frame is the default image.
+			e.chunk("IDAT", data)
+			continue
+		}
+		fdat := make([]byte, 4+len(data))
+		binary.BigEndian.PutUint32(fdat, e.seq)
+		copy(fdat[4:], data)
+		e.seq++
+		e.chunk("fdAT", fdat)
+	}
+	e.chunk("IEND", nil)
+	if e.err != nil {
+		return wc.n, e.err
+	}
+	err := e.w.Flush()
+	return wc.n, err
+}
+
+// apngEncoder writes the chunks of an animated PNG image.
+type apngEncoder struct {
+	w   *bufio.Writer
+	err error
+
+	// seq is the sequence number of
+	// the next fcTL or fdAT chunk.
+	seq uint32
+}
+
+// chunk writes a chunk of the given type and data.
+func (e *apngEncoder) chunk(typ string, data []byte) {
+	if e.err != nil {
+		return
+	}
+	var head [8]byte
+	binary.BigEndian.PutUint32(head[:4], uint32(len(data)))
+	copy(head[4:], typ)
+	crc := crc32.NewIEEE()
+	crc.Write(head[4:])
+	crc.Write(data)
+	var tail [4]byte
+	binary.BigEndian.PutUint32(tail[:], crc.Sum32())
+	for _, b := range [][]byte{head[:], data, tail[:]} {
+		if _, e.err = e.w.Write(b); e.err != nil {
+			return
+		}
+	}
+}
+
+// apngData returns the compressed image data of a frame,
+// with each row of pixels preceded by the filter type
+// of none. All of the frames are written as RGBA so
+// that they share the header of the image.
+func apngData(img *image.NRGBA) ([]byte, error) {
+	var buf bytes.Buffer
+	z := zlib.NewWriter(&buf)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		if _, err := z.Write([]byte{0}); err != nil {
+			return nil, err
+		}
+		i := img.PixOffset(b.Min.X, y)
+		if _, err := z.Write(img.Pix[i : i+4*b.Dx()]); err != nil {
+			return nil, err
+		}
+	}
+	if err := z.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -16,7 +16,10 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
+	"sync"
 
+	"github.com/golang/freetype/raster"
 	"golang.org/x/image/tiff"
 
 	"github.com/llgcode/draw2d"
@@ -38,6 +41,29 @@ type Canvas struct {
 
 	// width is the current line width.
 	width vg.Length
+
+	// bg is the color the canvas is filled with
+	// when it is created.
+	bg color.Color
+
+	// painter paints the spans rasterized by the
+	// graphic context, within the clipping region.
+	// It is nil if the graphic context was given by
+	// UseImageWithContext, and clipping is then not
+	// supported.
+	painter *clipPainter
+
+	// clip is the stack of clipping rectangles in
+	// pixels, matching the Push and Pop calls.
+	clip []image.Rectangle
+
+	// aliased specifies whether anti-aliasing is
+	// turned off.
+	aliased bool
+
+	// snap specifies whether the points of lines
+	// are snapped to the pixel grid.
+	snap bool
 }
 
 const (
@@ -57,10 +83,11 @@ func New(w, h vg.Length) *Canvas {
 }
 
 // NewWith returns a new image canvas created according to the specified
-// options. The currently accepted options are UseWH,
-// UseDPI, UseImage, and UseImageWithContext.
-// Each of the options specifies the size of the canvas (UseWH, UseImage),
-// the resolution of the canvas (UseDPI), or both (useImageWithContext).
+// options. The currently accepted options are UseWH, UsePixels,
+// UseDPI, UseImage, UseImageWithContext and UseBackgroundColor.
+// Each of the options specifies the size of the canvas (UseWH, UsePixels,
+// UseImage), the resolution of the canvas (UseDPI), or both
+// (useImageWithContext). UseBackgroundColor may be used with any of them.
 // If size or resolution are not specified, defaults are used.
 // It panics if size and resolution are overspecified (i.e., too many options are
 // passed).
@@ -95,13 +122,28 @@ func NewWith(o ...option) *Canvas {
 	}
 	if c.gc == nil {
 		h := float64(c.img.Bounds().Max.Y - c.img.Bounds().Min.Y)
-		c.gc = draw2dimg.NewGraphicContext(c.img)
+		var p draw2dimg.Painter
+		if rgba, ok := c.img.(*image.RGBA); ok {
+			p = raster.NewRGBAPainter(rgba)
+		} else {
+			p = &imagePainter{img: c.img}
+		}
+		c.painter = &clipPainter{
+			Painter: p,
+			clip:    c.img.Bounds(),
+			aliased: c.aliased,
+		}
+		c.gc = draw2dimg.NewGraphicContextWithPainter(c.img, c.painter)
 		c.gc.SetDPI(c.dpi)
 		c.gc.Scale(1, -1)
 		c.gc.Translate(0, -h)
 	}
-	draw.Draw(c.img, c.img.Bounds(), image.White, image.ZP, draw.Src)
+	if c.bg == nil {
+		c.bg = color.White
+	}
+	draw.Draw(c.img, c.img.Bounds(), image.NewUniform(c.bg), image.ZP, draw.Src)
 	c.color = []color.Color{color.Black}
+	c.clip = []image.Rectangle{c.img.Bounds()}
 	vg.Initialize(c)
 	return c
 }
@@ -128,6 +170,94 @@ func UseWH(w, h vg.Length) option {
 	}
 }
 
+// UsePixels specifies the width and height of the canvas
+// in pixels. The size of the canvas in vg lengths is
+// determined by the resolution of the canvas, so that
+// UsePixels(1920, 1080) with UseDPI(192) produces a
+// 10in by 5.625in canvas.
+func UsePixels(w, h int) option {
+	if w <= 0 || h <= 0 {
+		panic("w and h must both be > 0.")
+	}
+	return func(c *Canvas) uint32 {
+		c.img = image.NewRGBA(image.Rect(0, 0, w, h))
+		return setsSize
+	}
+}
+
+// UseBackgroundColor specifies the color the canvas is
+// filled with when it is created. The default is white.
+// Using color.Transparent, along with a nil or transparent
+// plot.Plot BackgroundColor, produces an image with a
+// transparent background when written in a format that
+// supports transparency, such as PNG or TIFF.
+func UseBackgroundColor(clr color.Color) option {
+	return func(c *Canvas) uint32 {
+		c.bg = clr
+		return 0
+	}
+}
+
+// UseAntialiasing specifies whether the canvas anti-aliases
+// the edges of lines, fills and text, which it does by
+// default. Without anti-aliasing, the pixels covered by at
+// least half of their area are painted with the full color,
+// giving hard edges, as some printers and image processing
+// pipelines expect. Anti-aliasing can not be turned off on a
+// canvas created with UseImageWithContext.
+func UseAntialiasing(on bool) option {
+	return func(c *Canvas) uint32 {
+		c.aliased = !on
+		return 0
+	}
+}
+
+// UseSubpixel specifies whether the points of lines and
+// fills are positioned between pixels, as they are by
+// default. Otherwise they are snapped to the pixel grid:
+// to the centers of pixels for lines an odd number of
+// pixels wide, and to their corners for other lines and
+// fills, so that thin horizontal and vertical lines, such
+// as the gridlines of a plot, are drawn crisply rather
+// than blurred over two rows of pixels. Arcs and text
+// are not snapped.
+func UseSubpixel(on bool) option {
+	return func(c *Canvas) uint32 {
+		c.snap = !on
+		return 0
+	}
+}
+
+// Quality is a preset of the rendering quality
+// options of a canvas.
+type Quality int
+
+const (
+	// SmoothQuality anti-aliases and positions
+	// lines between pixels. It is the default.
+	SmoothQuality Quality = iota
+
+	// CrispQuality anti-aliases and snaps lines
+	// to the pixel grid, drawing horizontal and
+	// vertical lines crisply.
+	CrispQuality
+
+	// AliasedQuality snaps lines to the pixel
+	// grid and turns off anti-aliasing, drawing
+	// only fully painted pixels.
+	AliasedQuality
+)
+
+// UseQuality sets the rendering quality options of a canvas
+// to the given preset, as by UseAntialiasing and UseSubpixel.
+func UseQuality(q Quality) option {
+	return func(c *Canvas) uint32 {
+		c.aliased = q == AliasedQuality
+		c.snap = q == CrispQuality || q == AliasedQuality
+		return 0
+	}
+}
+
 // UseDPI sets the dots per inch of a canvas. It should only be
 // used as an option argument when initializing a new canvas.
 func UseDPI(dpi int) option {
@@ -154,7 +284,9 @@ func UseImage(img draw.Image) option {
 // UseImageWithContext specifies both an image
 // and a graphic context to create the canvas from.
 // The minimum point of the given image
-// should probably be 0,0.
+// should probably be 0,0. The canvas does
+// not control how the graphic context paints,
+// so drawing on it is not clipped.
 func UseImageWithContext(img draw.Image, gc draw2d.GraphicContext) option {
 	return func(c *Canvas) uint32 {
 		c.img = img
@@ -204,36 +336,140 @@ func (c *Canvas) Scale(x, y float64) {
 
 func (c *Canvas) Push() {
 	c.color = append(c.color, c.color[len(c.color)-1])
+	c.clip = append(c.clip, c.clip[len(c.clip)-1])
 	c.gc.Save()
 }
 
 func (c *Canvas) Pop() {
 	c.color = c.color[:len(c.color)-1]
+	c.clip = c.clip[:len(c.clip)-1]
+	if c.painter != nil {
+		c.painter.clip = c.clip[len(c.clip)-1]
+	}
 	c.gc.Restore()
 }
 
+// Clip implements the vg.Clipper interface. The
+// clipping region is intersected with the bounding
+// box of the path in the image, so paths that are not
+// rectangles aligned with the image are clipped to
+// their bounds. Clip does nothing if the canvas was
+// created with UseImageWithContext, and images drawn
+// with DrawImage are only clipped if the backing image
+// has a SubImage method returning a draw.Image, as the
+// standard library images do.
+func (c *Canvas) Clip(p vg.Path) {
+	if c.painter == nil || len(p) == 0 {
+		return
+	}
+	m := c.gc.GetMatrixTransform()
+	dpi := c.DPI()
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	add := func(pt vg.Point) {
+		x, y := m.TransformPoint(pt.X.Dots(dpi), pt.Y.Dots(dpi))
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp, vg.LineComp:
+			add(comp.Pos)
+		case vg.ArcComp:
+			r := vg.Point{X: comp.Radius, Y: comp.Radius}
+			add(comp.Pos.Sub(r))
+			add(comp.Pos.Add(r))
+		}
+	}
+	// Keep the pixels partly covered by the path.
+	r := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+	top := &c.clip[len(c.clip)-1]
+	*top = top.Intersect(r)
+	c.painter.clip = *top
+}
+
+// clipPainter is a draw2dimg.Painter that only
+// paints spans within a clipping rectangle.
+type clipPainter struct {
+	draw2dimg.Painter
+	clip  image.Rectangle
+	spans []raster.Span
+
+	// aliased specifies whether spans are painted
+	// without anti-aliasing.
+	aliased bool
+}
+
+// Paint implements the raster.Painter interface.
+func (p *clipPainter) Paint(ss []raster.Span, done bool) {
+	p.spans = p.spans[:0]
+	for _, s := range ss {
+		if s.Y < p.clip.Min.Y || s.Y >= p.clip.Max.Y {
+			continue
+		}
+		if s.X0 < p.clip.Min.X {
+			s.X0 = p.clip.Min.X
+		}
+		if s.X1 > p.clip.Max.X {
+			s.X1 = p.clip.Max.X
+		}
+		if p.aliased {
+			if s.Alpha < 0x8000 {
+				continue
+			}
+			s.Alpha = 0xffff
+		}
+		if s.X0 < s.X1 {
+			p.spans = append(p.spans, s)
+		}
+	}
+	p.Painter.Paint(p.spans, done)
+}
+
+// imagePainter is a draw2dimg.Painter that paints
+// spans onto any draw.Image, for images that are
+// not an *image.RGBA.
+type imagePainter struct {
+	img   draw.Image
+	color color.Color
+}
+
+// SetColor implements the draw2dimg.Painter interface.
+func (p *imagePainter) SetColor(c color.Color) {
+	p.color = c
+}
+
+// Paint implements the raster.Painter interface.
+func (p *imagePainter) Paint(ss []raster.Span, done bool) {
+	src := image.NewUniform(p.color)
+	for _, s := range ss {
+		mask := image.NewUniform(color.Alpha16{A: uint16(s.Alpha)})
+		draw.DrawMask(p.img, image.Rect(s.X0, s.Y, s.X1, s.Y+1), src, image.ZP, mask, image.ZP, draw.Over)
+	}
+}
+
 func (c *Canvas) Stroke(p vg.Path) {
 	if c.width <= 0 {
 		return
 	}
-	c.outline(p)
+	c.outline(p, true)
 	c.gc.Stroke()
 }
 
 func (c *Canvas) Fill(p vg.Path) {
-	c.outline(p)
+	c.outline(p, false)
 	c.gc.Fill()
 }
 
-func (c *Canvas) outline(p vg.Path) {
+func (c *Canvas) outline(p vg.Path, stroke bool) {
 	c.gc.BeginPath()
 	for _, comp := range p {
 		switch comp.Type {
 		case vg.MoveComp:
-			c.gc.MoveTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
+			c.gc.MoveTo(c.point(comp.Pos, stroke))
 
 		case vg.LineComp:
-			c.gc.LineTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()))
+			c.gc.LineTo(c.point(comp.Pos, stroke))
 
 		case vg.ArcComp:
 			c.gc.ArcTo(comp.Pos.X.Dots(c.DPI()), comp.Pos.Y.Dots(c.DPI()),
@@ -249,6 +485,25 @@ func (c *Canvas) outline(p vg.Path) {
 	}
 }
 
+// point returns the point pt in dots, snapped to the pixel
+// grid if the canvas snaps lines: to the centers of pixels
+// for a stroke an odd number of pixels wide, and to their
+// corners otherwise.
+func (c *Canvas) point(pt vg.Point, stroke bool) (x, y float64) {
+	x, y = pt.X.Dots(c.DPI()), pt.Y.Dots(c.DPI())
+	if !c.snap {
+		return x, y
+	}
+	m := c.gc.GetMatrixTransform()
+	px, py := m.TransformPoint(x, y)
+	if stroke && round(c.width.Dots(c.DPI())*m.GetScale())%2 == 1 {
+		px, py = math.Floor(px)+0.5, math.Floor(py)+0.5
+	} else {
+		px, py = float64(round(px)), float64(round(py))
+	}
+	return m.InverseTransformPoint(px, py)
+}
+
 func (c *Canvas) DPI() float64 {
 	return float64(c.gc.GetDPI())
 }
@@ -259,8 +514,19 @@ func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 
 	data, ok := fontMap[font.Name()]
 	if !ok {
-		panic(fmt.Sprintf("Font name %s is unknown", font.Name()))
+		if font.Font() == nil {
+			panic(fmt.Sprintf("Font name %s is unknown", font.Name()))
+		}
+		// Fonts added to the vg package are
+		// registered with draw2d by name.
+		data = draw2d.FontData{Name: font.Name()}
 	}
+
+	// draw2d caches fonts and glyphs in package
+	// variables, so text is drawn by one canvas
+	// at a time.
+	textLock.Lock()
+	defer textLock.Unlock()
 	if !registeredFont[font.Name()] {
 		draw2d.RegisterFont(data, font.Font())
 		registeredFont[font.Name()] = true
@@ -289,11 +555,66 @@ func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 	c.gc.Scale(1, -1)
 	c.gc.Translate(xmin, -ymin-height)
 	c.gc.Scale(width/dx, height/dy)
-	c.gc.DrawImage(img)
+	if dst, ok := c.clipped(); ok {
+		// Draw into the clipping rectangle of the image.
+		draw2dimg.DrawImage(img, dst, c.gc.GetMatrixTransform(), draw.Over, draw2dimg.BilinearFilter)
+	} else {
+		c.gc.DrawImage(img)
+	}
 	c.gc.Restore()
 }
 
+// clipped returns the part of the image within the clipping
+// rectangle, if the canvas is clipped and the image is able
+// to make a drawable sub-image.
+func (c *Canvas) clipped() (draw.Image, bool) {
+	if c.painter == nil || c.clip[len(c.clip)-1] == c.img.Bounds() {
+		return nil, false
+	}
+	img, ok := c.img.(interface {
+		SubImage(image.Rectangle) image.Image
+	})
+	if !ok {
+		return nil, false
+	}
+	dst, ok := img.SubImage(c.clip[len(c.clip)-1]).(draw.Image)
+	return dst, ok
+}
+
+// BlitRect implements the vg.Blitter interface. Pixels
+// can only be written directly when the backing image is an
+// *image.RGBA and the current transform is a translation
+// and scaling that preserves the orientation of the image.
+func (c *Canvas) BlitRect(rect vg.Rectangle) (img *image.RGBA, r image.Rectangle, ok bool) {
+	img, ok = c.img.(*image.RGBA)
+	if !ok {
+		return nil, image.Rectangle{}, false
+	}
+	m := c.gc.GetMatrixTransform()
+	if m[1] != 0 || m[2] != 0 || m[0] <= 0 || m[3] >= 0 {
+		return nil, image.Rectangle{}, false
+	}
+	dpi := c.DPI()
+	x0, y0 := m.TransformPoint(rect.Min.X.Dots(dpi), rect.Max.Y.Dots(dpi))
+	x1, y1 := m.TransformPoint(rect.Max.X.Dots(dpi), rect.Min.Y.Dots(dpi))
+	r = image.Rect(round(x0), round(y0), round(x1), round(y1))
+	if c.painter != nil {
+		// Limit writes to the clipping rectangle.
+		img = img.SubImage(c.clip[len(c.clip)-1]).(*image.RGBA)
+	}
+	return img, r, true
+}
+
+// round returns x rounded to the nearest integer.
+func round(x float64) int {
+	return int(math.Floor(x + 0.5))
+}
+
 var (
+	// textLock guards registeredFont and the
+	// font and glyph caches of draw2d.
+	textLock sync.Mutex
+
 	// RegisteredFont contains the set of font names
 	// that have already been registered with draw2d.
 	registeredFont = map[string]bool{}
@@ -2,11 +2,16 @@ package vgimg_test
 
 import (
 	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/plotter"
@@ -48,3 +53,221 @@ func TestIssue179(t *testing.T) {
 		t.Error("Image mismatch")
 	}
 }
+
+func TestTransparentBackground(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.BackgroundColor = nil
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	c := vgimg.NewWith(
+		vgimg.UsePixels(300, 200),
+		vgimg.UseDPI(150),
+		vgimg.UseBackgroundColor(color.Transparent),
+	)
+	if w, h := c.Size(); w != 2*vg.Inch || h != 4*vg.Inch/3 {
+		t.Errorf("unexpected canvas size: got:%v×%v want:%v×%v", w, h, 2*vg.Inch, 4*vg.Inch/3)
+	}
+	p.Draw(draw.New(c))
+
+	var buf bytes.Buffer
+	if _, err := (vgimg.PngCanvas{Canvas: c}).WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 300 || b.Dy() != 200 {
+		t.Errorf("unexpected image size: got:%v×%v want:300×200", b.Dx(), b.Dy())
+	}
+	if _, _, _, a := img.At(150, 5).RGBA(); a != 0 {
+		t.Errorf("expected transparent background, got alpha %#x", a)
+	}
+	var opaque bool
+	for x := 0; x < 300 && !opaque; x++ {
+		for y := 0; y < 200; y++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0 {
+				opaque = true
+				break
+			}
+		}
+	}
+	if !opaque {
+		t.Error("expected plot to be drawn")
+	}
+}
+
+func TestClip(t *testing.T) {
+	for _, img := range []settable{
+		image.NewRGBA(image.Rect(0, 0, 100, 100)),
+		image.NewNRGBA(image.Rect(0, 0, 100, 100)),
+	} {
+		testClip(t, img)
+	}
+}
+
+// settable is an image/draw.Image.
+type settable interface {
+	image.Image
+	Set(x, y int, c color.Color)
+}
+
+func testClip(t *testing.T, img settable) {
+	c := vgimg.NewWith(vgimg.UseImage(img), vgimg.UseDPI(72))
+	full := vg.Rectangle{Max: vg.Point{X: 100, Y: 100}}
+	clip := vg.Rectangle{Min: vg.Point{X: 20, Y: 20}, Max: vg.Point{X: 60, Y: 60}}
+
+	c.Push()
+	c.Clip(clip.Path())
+	c.SetColor(color.Black)
+	c.Fill(full.Path())
+	c.Pop()
+
+	c.Fill(vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}.Path())
+
+	for _, test := range []struct {
+		x, y  int
+		black bool
+	}{
+		{x: 40, y: 60, black: true},
+		{x: 21, y: 41, black: true},
+		{x: 10, y: 60, black: false},
+		{x: 70, y: 60, black: false},
+		{x: 40, y: 10, black: false},
+		{x: 40, y: 90, black: false},
+
+		// Drawing after Pop is not clipped.
+		{x: 5, y: 95, black: true},
+	} {
+		r, _, _, _ := img.At(test.x, test.y).RGBA()
+		if black := r == 0; black != test.black {
+			t.Errorf("unexpected pixel at (%d, %d) of %T: got black:%t want black:%t", test.x, test.y, img, black, test.black)
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(l)
+
+	a := vgimg.NewAnimation(250 * time.Millisecond)
+	a.Plays = 2
+	err = plot.Animate(a, vg.Inch, vg.Inch, 3, func(i int) (*plot.Plot, error) {
+		p.Update(func() {
+			err = l.Append(plotter.XYs{{X: float64(i + 2), Y: float64(i)}})
+		})
+		return p, err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Len() != 3 {
+		t.Fatalf("unexpected number of frames: got:%d want:3", a.Len())
+	}
+	if err := a.Add(vgimg.New(2*vg.Inch, vg.Inch)); err == nil {
+		t.Error("expected error for mismatched frame size")
+	}
+
+	var buf bytes.Buffer
+	if _, err := (vgimg.GifAnimation{Animation: a}).WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Image) != 3 || g.Delay[2] != 25 || g.LoopCount != 1 {
+		t.Errorf("unexpected GIF: got %d frames, delay %d and loop count %d want 3 frames, delay 25 and loop count 1",
+			len(g.Image), g.Delay[2], g.LoopCount)
+	}
+
+	w, err := a.WriterTo("apng")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf.Reset()
+	if _, err := w.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+	for _, chunk := range []struct {
+		typ  string
+		want int
+	}{
+		{typ: "acTL", want: 1},
+		{typ: "fcTL", want: 3},
+		{typ: "IDAT", want: 1},
+		{typ: "fdAT", want: 2},
+	} {
+		if got := bytes.Count(data, []byte(chunk.typ)); got != chunk.want {
+			t.Errorf("unexpected number of %s chunks: got:%d want:%d", chunk.typ, got, chunk.want)
+		}
+	}
+
+	// The first frame is the default image,
+	// decoded by PNG decoders.
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 96 || b.Dy() != 96 {
+		t.Errorf("unexpected image size: got:%v×%v want:96×96", b.Dx(), b.Dy())
+	}
+}
+
+func TestQuality(t *testing.T) {
+	for _, test := range []struct {
+		q vgimg.Quality
+		// crisp is whether the horizontal line
+		// covers a single row of pixels.
+		crisp bool
+		// aliased is whether all of the pixels
+		// are black or white.
+		aliased bool
+	}{
+		{q: vgimg.SmoothQuality},
+		{q: vgimg.CrispQuality, crisp: true},
+		{q: vgimg.AliasedQuality, crisp: true, aliased: true},
+	} {
+		img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+		c := vgimg.NewWith(vgimg.UseImage(img), vgimg.UseDPI(72), vgimg.UseQuality(test.q))
+		c.SetLineWidth(1)
+		c.Stroke(vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: 10, Y: 50.3}}, {Type: vg.LineComp, Pos: vg.Point{X: 90, Y: 50.3}}})
+		c.Stroke(vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: 10, Y: 10}}, {Type: vg.LineComp, Pos: vg.Point{X: 90, Y: 37}}})
+
+		var rows int
+		for y := 40; y < 60; y++ {
+			if r, _, _, _ := img.At(50, y).RGBA(); r != 0xffff {
+				rows++
+			}
+		}
+		if crisp := rows == 1; crisp != test.crisp {
+			t.Errorf("unexpected crispness for quality %d: line covers %d rows", test.q, rows)
+		}
+		var gray int
+		for x := 0; x < 100; x++ {
+			for y := 0; y < 100; y++ {
+				if r, _, _, _ := img.At(x, y).RGBA(); r != 0 && r != 0xffff {
+					gray++
+				}
+			}
+		}
+		if aliased := gray == 0; aliased != test.aliased {
+			t.Errorf("unexpected anti-aliasing for quality %d: %d gray pixels", test.q, gray)
+		}
+	}
+}
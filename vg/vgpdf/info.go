@@ -0,0 +1,143 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Info is the metadata of a PDF document, written
+// to its document information dictionary.
+type Info struct {
+	Title, Author, Subject string
+
+	// Keywords holds the keywords
+	// of the document.
+	Keywords []string
+
+	// Creator is the name of the application
+	// that created the document.
+	Creator string
+
+	// CreationDate is the time at which the document
+	// was created. It is omitted if it is zero.
+	CreationDate time.Time
+}
+
+// isZero returns whether the information is empty.
+func (i Info) isZero() bool {
+	return i.Title == "" && i.Author == "" && i.Subject == "" && len(i.Keywords) == 0 &&
+		i.Creator == "" && i.CreationDate.IsZero()
+}
+
+// dict returns the information dictionary of i.
+func (i Info) dict() string {
+	var buf bytes.Buffer
+	buf.WriteString("<<")
+	for _, e := range []struct{ key, val string }{
+		{"Title", i.Title},
+		{"Author", i.Author},
+		{"Subject", i.Subject},
+		{"Keywords", strings.Join(i.Keywords, ", ")},
+		{"Creator", i.Creator},
+		{"Producer", "gonum/plot"},
+	} {
+		if e.val != "" {
+			fmt.Fprintf(&buf, " /%s %s", e.key, pdfString(e.val))
+		}
+	}
+	if !i.CreationDate.IsZero() {
+		fmt.Fprintf(&buf, " /CreationDate %s", pdfString(pdfDate(i.CreationDate)))
+	}
+	buf.WriteString(" >>")
+	return buf.String()
+}
+
+// pdfString returns s as a PDF string, a literal string if
+// it is printable ASCII, or a hexadecimal UTF-16BE string
+// otherwise.
+func pdfString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r < ' ' || r > '~' {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return "(" + strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s) + ")"
+	}
+	var buf bytes.Buffer
+	buf.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&buf, "%04X", u)
+	}
+	buf.WriteString(">")
+	return buf.String()
+}
+
+// pdfDate returns t in the format of PDF dates.
+func pdfDate(t time.Time) string {
+	d := "D:" + t.Format("20060102150405")
+	_, offset := t.Zone()
+	if offset == 0 {
+		return d + "Z"
+	}
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%s%c%02d'%02d'", d, sign, offset/3600, offset/60%60)
+}
+
+var (
+	startxrefPattern = regexp.MustCompile(`startxref\s+(\d+)\s+%%EOF\s*$`)
+	sizePattern      = regexp.MustCompile(`/Size\s+(\d+)`)
+	rootPattern      = regexp.MustCompile(`/Root\s+\d+\s+\d+\s+R`)
+)
+
+// appendInfo returns the PDF document doc with the information
+// dictionary of info appended to it as an incremental update,
+// which leaves the original document unchanged.
+func appendInfo(doc []byte, info Info) ([]byte, error) {
+	m := startxrefPattern.FindSubmatch(doc)
+	if m == nil {
+		return nil, errors.New("vgpdf: no cross-reference table to add metadata to")
+	}
+	prev := string(m[1])
+	t := bytes.LastIndex(doc, []byte("trailer"))
+	if t < 0 {
+		return nil, errors.New("vgpdf: no trailer to add metadata to")
+	}
+	trailer := doc[t:]
+	size := sizePattern.FindSubmatch(trailer)
+	root := rootPattern.Find(trailer)
+	if size == nil || root == nil {
+		return nil, errors.New("vgpdf: invalid trailer")
+	}
+	n, err := strconv.Atoi(string(size[1]))
+	if err != nil {
+		return nil, fmt.Errorf("vgpdf: invalid trailer: %v", err)
+	}
+
+	buf := bytes.NewBuffer(append([]byte(nil), doc...))
+	if !bytes.HasSuffix(doc, []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	obj := buf.Len()
+	fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", n, info.dict())
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n%d 1\n%010d 00000 n \n", n, obj)
+	fmt.Fprintf(buf, "trailer\n<< /Size %d %s /Info %d 0 R /Prev %s >>\n", n+1, root, n, prev)
+	fmt.Fprintf(buf, "startxref\n%d\n%%%%EOF\n", xref)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const doc = `%PDF-1.4
+1 0 obj
+<< /Type /Catalog /Pages 2 0 R >>
+endobj
+2 0 obj
+<< /Type /Pages /Kids [] /Count 0 >>
+endobj
+xref
+0 3
+0000000000 65535 f 
+0000000009 00000 n 
+0000000058 00000 n 
+trailer
+<< /Size 3 /Root 1 0 R >>
+startxref
+110
+%%EOF
+`
+
+func TestAppendInfo(t *testing.T) {
+	info := Info{
+		Title:        "Résumé (draft)",
+		Author:       `A. \ Author`,
+		Keywords:     []string{"plot", "archive"},
+		CreationDate: time.Date(2016, 3, 4, 5, 6, 7, 0, time.FixedZone("", -(7*60+30)*60)),
+	}
+	got, err := appendInfo([]byte(doc), info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(got, []byte(doc)) {
+		t.Error("original document changed")
+	}
+	update := string(got[len(doc):])
+	want := `3 0 obj
+<< /Title <FEFF005200E900730075006D00E900200028006400720061006600740029> /Author (A. \\ Author) /Keywords (plot, archive) /Producer (gonum/plot) /CreationDate (D:20160304050607-07'30') >>
+endobj
+xref
+3 1
+`
+	if !strings.HasPrefix(update, want) {
+		t.Errorf("unexpected update:\ngot:\n%s\nwant prefix:\n%s", update, want)
+	}
+	if !strings.Contains(update, "trailer\n<< /Size 4 /Root 1 0 R /Info 3 0 R /Prev 110 >>\n") {
+		t.Errorf("unexpected trailer of update:\n%s", update)
+	}
+
+	// The cross-reference table of the
+	// update is at its startxref offset.
+	i := strings.LastIndex(update, "startxref\n")
+	xref, err := strconv.Atoi(strings.Fields(update[i:])[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(got[xref:], []byte("xref\n3 1\n")) {
+		t.Errorf("startxref does not point to the cross-reference table: %q", got[xref:])
+	}
+	// The information dictionary is at
+	// its offset in the table.
+	obj, err := strconv.Atoi(strings.Fields(string(got[xref:]))[3])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(got[obj:], []byte("3 0 obj\n")) {
+		t.Errorf("cross-reference table does not point to the information dictionary: %q", got[obj:])
+	}
+
+	if _, err := appendInfo([]byte("%PDF-1.4\n"), info); err == nil {
+		t.Error("expected error for document without trailer")
+	}
+}
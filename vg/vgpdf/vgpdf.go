@@ -4,10 +4,23 @@
 
 // Package vgpdf implements the vg.Canvas interface
 // using gopdf (bitbucket.org/zombiezen/gopdf/pdf).
+//
+// gopdf is only able to use the standard PDF fonts, the
+// fonts of the vg.FontMap, and does not embed them. Fonts
+// added using vg.AddFontData or vg.AddFontFile cannot be
+// written to PDF: text drawn in them is left out and
+// WriteTo returns an error.
+//
+// Documents are written with the metadata of the Info of
+// the canvas, but without a choice of compression, which
+// is left to gopdf. As fonts cannot be embedded, vgpdf
+// cannot write PDF/A documents: archival copies must be
+// converted by other tools, such as Ghostscript.
 package vgpdf
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"image"
 	"image/color"
@@ -24,10 +37,18 @@ const DPI = 72
 // Canvas implements the vg.Canvas interface,
 // drawing to a PDF.
 type Canvas struct {
+	// Info is the metadata of the document,
+	// written by WriteTo if it is not empty.
+	Info Info
+
 	doc         *pdf.Document
 	w, h        vg.Length
 	page        *pdf.Canvas
 	lineVisible bool
+
+	// err is the first error met while
+	// drawing, returned by WriteTo.
+	err error
 }
 
 // New creates a new PDF Canvas.
@@ -47,6 +68,15 @@ func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
 
+// NextPage ends the current page and starts a new page
+// of the same size, on which later drawing takes place.
+// The context of the canvas is reset to its initial state.
+func (c *Canvas) NextPage() {
+	c.page.Close()
+	c.page = c.doc.NewPage(unit(c.w), unit(c.h))
+	vg.Initialize(c)
+}
+
 func (c *Canvas) SetLineWidth(w vg.Length) {
 	c.page.SetLineWidth(unit(w))
 	c.lineVisible = w > 0
@@ -95,9 +125,19 @@ func (c *Canvas) Fill(p vg.Path) {
 	c.page.Fill(pdfPath(c, p))
 }
 
+// FillString implements the vg.Canvas.FillString method.
+// Text in fonts that are not in the vg.FontMap is not
+// drawn, and makes WriteTo return an error.
 func (c *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
+	name := fnt.Name()
+	if _, ok := vg.FontMap[name]; !ok {
+		if c.err == nil {
+			c.err = fmt.Errorf("vgpdf: font %s is not a standard PDF font and cannot be embedded", name)
+		}
+		return
+	}
 	t := new(pdf.Text)
-	t.SetFont(fnt.Name(), unit(fnt.Size))
+	t.SetFont(name, unit(fnt.Size))
 	t.NextLineOffset(unit(pt.X), unit(pt.Y))
 	t.Text(str)
 	c.page.DrawText(t)
@@ -227,9 +267,28 @@ func (w *writerCounter) Write(p []byte) (int, error) {
 // WriteTo writes the Canvas to an io.Writer.
 // After calling Write, the canvas is closed
 // and may no longer be used for drawing.
+// The Info of the canvas, if not empty, is
+// appended to the document as an incremental
+// update. Nothing is written if text was drawn
+// in a font that is not a standard PDF font.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	c.page.Close()
+	if c.err != nil {
+		return 0, c.err
+	}
 	wc := writerCounter{Writer: w}
+	if !c.Info.isZero() {
+		var buf bytes.Buffer
+		if err := c.doc.Encode(&buf); err != nil {
+			return 0, err
+		}
+		doc, err := appendInfo(buf.Bytes(), c.Info)
+		if err != nil {
+			return 0, err
+		}
+		_, err = wc.Write(doc)
+		return wc.n, err
+	}
 	b := bufio.NewWriter(&wc)
 	if err := c.doc.Encode(b); err != nil {
 		return wc.n, err
@@ -0,0 +1,39 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgpdf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgpdf"
+)
+
+func TestFillStringCustomFont(t *testing.T) {
+	data, err := vg.FontData("Times-Roman")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const name = "TestFillStringCustomFont-Serif"
+	if err := vg.AddFontData(name, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	font, err := vg.MakeFont(name, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := vgpdf.New(vg.Inch, vg.Inch)
+	c.FillString(font, vg.Point{}, "a")
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err == nil {
+		t.Error("expected error for font that is not a standard PDF font")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected output for font that is not a standard PDF font: %d bytes", buf.Len())
+	}
+}
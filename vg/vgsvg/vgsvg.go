@@ -10,12 +10,14 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/base64"
+	"encoding/xml"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
 	"io"
 	"math"
+	"strings"
 
 	svgo "github.com/ajstarks/svgo"
 	"github.com/gonum/plot/vg"
@@ -30,11 +32,42 @@ const DPI = 90
 const pr = 5
 
 type Canvas struct {
+	// Style is CSS emitted in a <style> element at the
+	// start of the SVG document when the canvas is written.
+	// Elements can be selected using the ids and classes
+	// given to BeginGroup. If Style is empty, no <style>
+	// element is written.
+	Style string
+
+	// IDPrefix is prepended to the ids of the clip
+	// paths and groups of the canvas, so that several
+	// SVG documents can be embedded in the same HTML
+	// page without their ids clashing. Selectors in
+	// Style must use the prefixed ids.
+	IDPrefix string
+
 	svg  *svgo.SVG
 	w, h vg.Length
 	buf  *bytes.Buffer
 	ht   float64
 	stk  []context
+
+	// hdr is the length of the SVG header in buf,
+	// after which the style element is written.
+	hdr int
+
+	// groups is the number of groups started
+	// by BeginGroup that have not been ended.
+	groups int
+
+	// fonts holds the names of fonts that are not
+	// in the fontMap, in order of first use. These
+	// fonts are embedded when the canvas is written.
+	fonts []string
+
+	// clips is the number of clip paths written,
+	// used to give each of them a unique id.
+	clips int
 }
 
 type context struct {
@@ -66,6 +99,7 @@ func New(w, h vg.Length) *Canvas {
 		pr, w/vg.Inch,
 		pr, h/vg.Inch,
 	)
+	c.hdr = buf.Len()
 
 	// Swap the origin to the bottom left.
 	// This must be matched with a </g> when saving,
@@ -126,6 +160,54 @@ func (c *Canvas) Pop() {
 	c.stk = c.stk[:len(c.stk)-1]
 }
 
+// Clip implements the vg.Clipper interface. The elements
+// drawn until the matching Pop are written within a <g>
+// element clipped to the path.
+func (c *Canvas) Clip(path vg.Path) {
+	c.clips++
+	id := fmt.Sprintf("%sclip%d", c.IDPrefix, c.clips)
+	fmt.Fprintf(c.buf, "<clipPath id=\"%s\">\n", id)
+	c.svg.Path(c.pathData(path))
+	c.buf.WriteString("</clipPath>\n")
+	fmt.Fprintf(c.buf, "<g clip-path=\"url(#%s)\">\n", id)
+	c.cur().gEnds++
+}
+
+// BeginGroup implements the vg.Grouper.BeginGroup method.
+// The elements drawn until the matching call to EndGroup
+// are written within a <g> element with the given id, after
+// the IDPrefix of the canvas, and class attributes.
+func (c *Canvas) BeginGroup(id, class string) {
+	if id != "" {
+		id = c.IDPrefix + id
+	}
+	c.buf.WriteString("<g")
+	writeAttr(c.buf, "id", id)
+	writeAttr(c.buf, "class", class)
+	c.buf.WriteString(">\n")
+	c.groups++
+}
+
+// EndGroup implements the vg.Grouper.EndGroup method.
+func (c *Canvas) EndGroup() {
+	if c.groups == 0 {
+		panic("vgsvg: EndGroup called without matching BeginGroup")
+	}
+	c.svg.Gend()
+	c.groups--
+}
+
+// writeAttr writes the named attribute with the escaped
+// value to buf. Empty values are not written.
+func writeAttr(buf *bytes.Buffer, name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(buf, ` %s="`, name)
+	xml.EscapeText(buf, []byte(value))
+	buf.WriteString(`"`)
+}
+
 func (c *Canvas) Stroke(path vg.Path) {
 	if c.cur().lineWidth.Dots(DPI) <= 0 {
 		return
@@ -248,7 +330,7 @@ func large(a float64) int {
 func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 	fontStr, ok := fontMap[font.Name()]
 	if !ok {
-		panic(fmt.Sprintf("Unknown font: %s", font.Name()))
+		fontStr = c.embedFont(font)
 	}
 	sty := style(fontStr,
 		elm("font-size", "medium", "%.*gpt", pr, font.Size.Points()),
@@ -260,6 +342,63 @@ func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
 		pr, pt.X.Dots(DPI), pr, -pt.Y.Dots(DPI), sty, str)
 }
 
+// embedFont returns the style string for a font that is not in
+// the fontMap, recording that it must be embedded in the output.
+// It panics if the data for the font is not available.
+func (c *Canvas) embedFont(font vg.Font) string {
+	name := font.Name()
+	var found bool
+	for _, f := range c.fonts {
+		if f == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		if _, err := vg.FontData(name); err != nil {
+			panic(fmt.Sprintf("Unknown font: %s", name))
+		}
+		c.fonts = append(c.fonts, name)
+	}
+	return fmt.Sprintf("font-family:'%s'", cssString(name))
+}
+
+// writeFonts writes a <style> element containing a @font-face
+// rule, with the font data embedded, for each font in c.fonts.
+func (c *Canvas) writeFonts(w io.Writer) (int64, error) {
+	if len(c.fonts) == 0 {
+		return 0, nil
+	}
+	buf := new(bytes.Buffer)
+	buf.WriteString("<style type=\"text/css\"><![CDATA[\n")
+	for _, name := range c.fonts {
+		data, err := vg.FontData(name)
+		if err != nil {
+			return 0, err
+		}
+		fmt.Fprintf(buf, "@font-face {\n\tfont-family: \"%s\";\n\tsrc: url(data:font/ttf;base64,%s) format(\"truetype\");\n}\n",
+			cssString(name), base64.StdEncoding.EncodeToString(data))
+	}
+	buf.WriteString("]]></style>\n")
+	return buf.WriteTo(w)
+}
+
+// cssString returns s escaped for use in a quoted CSS string.
+// Quotes, backslashes, control characters and the characters
+// special to XML are written as CSS escapes, so the string
+// may also be used in an attribute or CDATA section.
+func cssString(s string) string {
+	var buf bytes.Buffer
+	for _, r := range s {
+		if r < ' ' || r == 0x7f || strings.ContainsRune(`'"\&<>]`, r) {
+			fmt.Fprintf(&buf, "\\%x ", r)
+			continue
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
 // DrawImage implements the vg.Canvas.DrawImage method.
 func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
 	buf := new(bytes.Buffer)
@@ -311,7 +450,26 @@ var (
 // WriteTo writes the canvas to an io.Writer.
 func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	b := bufio.NewWriter(w)
-	n, err := c.buf.WriteTo(b)
+	data := c.buf.Bytes()
+	m, err := b.Write(data[:c.hdr])
+	n := int64(m)
+	if err != nil {
+		return n, err
+	}
+	fn, err := c.writeFonts(b)
+	n += fn
+	if err != nil {
+		return n, err
+	}
+	if c.Style != "" {
+		m, err = fmt.Fprint(b, "<style type=\"text/css\"><![CDATA[\n", c.Style, "\n]]></style>\n")
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err = b.Write(data[c.hdr:])
+	n += int64(m)
 	if err != nil {
 		return n, err
 	}
@@ -320,14 +478,14 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 	// so that the Canvas is not closed and can be
 	// used again if needed.
 	for i := 0; i < c.nEnds(); i++ {
-		m, err := fmt.Fprintln(b, "</g>")
+		m, err = fmt.Fprintln(b, "</g>")
 		n += int64(m)
 		if err != nil {
 			return n, err
 		}
 	}
 
-	m, err := fmt.Fprintln(b, "</svg>")
+	m, err = fmt.Fprintln(b, "</svg>")
 	n += int64(m)
 	if err != nil {
 		return n, err
@@ -339,7 +497,7 @@ func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
 // nEnds returns the number of group ends
 // needed before the SVG is saved.
 func (c *Canvas) nEnds() int {
-	n := 1 // close the transform that moves the origin
+	n := 1 + c.groups // close the transform that moves the origin and any open groups
 	for _, ctx := range c.stk {
 		n += ctx.gEnds
 	}
@@ -0,0 +1,183 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgsvg_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgsvg"
+)
+
+func TestGroups(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Title.Text = "groups"
+	l, err := plotter.NewLine(plotter.XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(plotter.NewGrid(), l)
+	p.Legend.Add("line", l)
+
+	c := vgsvg.New(4*vg.Inch, 4*vg.Inch)
+	c.Style = ".grid path { stroke: red; }"
+	p.Draw(draw.New(c))
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+
+	for _, want := range []string{
+		`<style type="text/css"><![CDATA[` + "\n" + c.Style + "\n]]></style>",
+		`<g id="title" class="title">`,
+		`<g id="x-axis" class="axis">`,
+		`<g id="y-axis" class="axis">`,
+		`<g id="plotter-0" class="plotter grid">`,
+		`<g id="plotter-1" class="plotter line">`,
+		`<g id="legend" class="legend">`,
+		`<g id="legend-entry-0" class="legend-entry">`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG output does not contain %q", want)
+		}
+	}
+	if strings.Index(svg, "<style") > strings.Index(svg, "<g") {
+		t.Errorf("style element not written before first group")
+	}
+	if open, close := strings.Count(svg, "<g"), strings.Count(svg, "</g>"); open != close {
+		t.Errorf("unbalanced groups: %d opened, %d closed", open, close)
+	}
+}
+
+func TestIDPrefix(t *testing.T) {
+	c := vgsvg.New(vg.Inch, vg.Inch)
+	c.IDPrefix = "fig1-"
+	c.BeginGroup("legend", "legend")
+	c.Push()
+	c.Clip(vg.Rectangle{Max: vg.Point{X: 1, Y: 1}}.Path())
+	c.Pop()
+	c.EndGroup()
+	c.BeginGroup("", "unnamed")
+	c.EndGroup()
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	for _, want := range []string{
+		`<g id="fig1-legend" class="legend">`,
+		`<clipPath id="fig1-clip1">`,
+		`<g class="unnamed">`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG output does not contain %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestGroupEscaping(t *testing.T) {
+	c := vgsvg.New(vg.Inch, vg.Inch)
+	c.BeginGroup(`a"b`, "c<d")
+	c.EndGroup()
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<g id="a&#34;b" class="c&lt;d">`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("SVG output does not contain %q:\n%s", want, buf.String())
+	}
+}
+
+func TestEmbedFont(t *testing.T) {
+	data, err := vg.FontData("Times-Roman")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const name = "TestEmbedFont-Serif"
+	if err := vg.AddFontData(name, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	font, err := vg.MakeFont(name, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := vgsvg.New(vg.Inch, vg.Inch)
+	c.FillString(font, vg.Point{}, "a")
+	c.FillString(font, vg.Point{}, "b")
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	src := "src: url(data:font/ttf;base64," + base64.StdEncoding.EncodeToString(data) + ")"
+	if n := strings.Count(svg, src); n != 1 {
+		t.Errorf("unexpected number of embedded fonts: got:%d want:1", n)
+	}
+	if !strings.Contains(svg, `font-family: "`+name+`";`) {
+		t.Errorf("SVG output does not contain @font-face rule for %s", name)
+	}
+	if !strings.Contains(svg, "font-family:'"+name+"'") {
+		t.Errorf("SVG output does not use font %s", name)
+	}
+}
+
+func TestEmbedFontName(t *testing.T) {
+	data, err := vg.FontData("Times-Roman")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const name = `TestEmbedFont'"<Serif>`
+	if err := vg.AddFontData(name, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	font, err := vg.MakeFont(name, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := vgsvg.New(vg.Inch, vg.Inch)
+	c.FillString(font, vg.Point{}, "a")
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := buf.String()
+	const escaped = `TestEmbedFont\27 \22 \3c Serif\3e `
+	if !strings.Contains(svg, `font-family: "`+escaped+`";`) {
+		t.Errorf("SVG output does not contain escaped @font-face rule:\n%s", svg)
+	}
+	if !strings.Contains(svg, "font-family:'"+escaped+"'") {
+		t.Errorf("SVG output does not use escaped font name:\n%s", svg)
+	}
+	d := xml.NewDecoder(&buf)
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG output: %v", err)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package vgterm implements the vg.Canvas interface by rendering
+// to a terminal using Unicode braille characters and ANSI colors.
+//
+// Paths and images are rasterized using the vgimg backend at a
+// resolution of two by four dots per character cell, and each cell
+// is then written as the braille character representing the dots
+// that differ from the background. Text is not rasterized; it is
+// written directly into the character grid.
+package vgterm
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"math"
+	"unicode/utf8"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+const (
+	// CellWidth and CellHeight are the nominal
+	// dimensions of a terminal character cell.
+	CellWidth  = vg.Inch / 10
+	CellHeight = vg.Inch / 5
+
+	// DPI is the resolution of the dot raster
+	// underlying the character cells.
+	DPI = 20
+
+	// dotsX and dotsY are the number of braille
+	// dots in each character cell.
+	dotsX = 2
+	dotsY = 4
+)
+
+// brailleBase is the code point of the empty braille pattern.
+const brailleBase = 0x2800
+
+// brailleBits holds the bit set in a braille pattern
+// for the dot at column x and row y of a cell, where
+// row 0 is the top of the cell.
+var brailleBits = [dotsY][dotsX]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Canvas implements the vg.Canvas interface,
+// drawing to a grid of terminal character cells.
+type Canvas struct {
+	*vgimg.Canvas
+
+	// ANSI specifies whether ANSI 24-bit color escape
+	// sequences are emitted when the canvas is written.
+	ANSI bool
+
+	// bg is the background color, that of
+	// the dots that are considered unset.
+	bg color.Color
+
+	img        *image.RGBA
+	cols, rows int
+	text       []cell
+
+	// stk is the stack of transforms and
+	// colors matching the Push and Pop calls.
+	stk []state
+}
+
+// cell is a character written into the grid by FillString.
+type cell struct {
+	r   rune
+	clr color.Color
+}
+
+type state struct {
+	m   affine
+	clr color.Color
+}
+
+// New returns a new terminal canvas with the given
+// number of character columns and rows.
+func New(cols, rows int) *Canvas {
+	if cols <= 0 || rows <= 0 {
+		panic("vgterm: cols and rows must both be > 0")
+	}
+	img := image.NewRGBA(image.Rect(0, 0, cols*dotsX, rows*dotsY))
+	c := &Canvas{
+		ANSI: true,
+		bg:   color.White,
+		img:  img,
+		cols: cols,
+		rows: rows,
+		text: make([]cell, cols*rows),
+		stk:  []state{{m: identity, clr: color.Black}},
+	}
+	c.Canvas = vgimg.NewWith(vgimg.UseImage(img), vgimg.UseDPI(DPI))
+	return c
+}
+
+// SetBackground fills the canvas with the color clr, erasing
+// anything drawn on it, and sets it as the background color:
+// dots of that color are written as unset. The background
+// color of a new canvas is white, which is also used if clr
+// is nil.
+func (c *Canvas) SetBackground(clr color.Color) {
+	if clr == nil {
+		clr = color.White
+	}
+	c.bg = clr
+	draw.Draw(c.img, c.img.Bounds(), image.NewUniform(clr), image.ZP, draw.Src)
+	for i := range c.text {
+		c.text[i] = cell{}
+	}
+}
+
+// Size returns the size of the canvas.
+func (c *Canvas) Size() (w, h vg.Length) {
+	return vg.Length(c.cols) * CellWidth, vg.Length(c.rows) * CellHeight
+}
+
+// Image returns the dot raster underlying the canvas.
+func (c *Canvas) Image() *image.RGBA {
+	return c.img
+}
+
+func (c *Canvas) cur() *state {
+	return &c.stk[len(c.stk)-1]
+}
+
+// SetColor implements the vg.Canvas.SetColor method.
+func (c *Canvas) SetColor(clr color.Color) {
+	if clr == nil {
+		clr = color.Black
+	}
+	c.cur().clr = clr
+	c.Canvas.SetColor(clr)
+}
+
+// Rotate implements the vg.Canvas.Rotate method.
+func (c *Canvas) Rotate(t float64) {
+	c.cur().m = c.cur().m.mul(rotation(t))
+	c.Canvas.Rotate(t)
+}
+
+// Translate implements the vg.Canvas.Translate method.
+func (c *Canvas) Translate(pt vg.Point) {
+	c.cur().m = c.cur().m.mul(translation(pt.X.Points(), pt.Y.Points()))
+	c.Canvas.Translate(pt)
+}
+
+// Scale implements the vg.Canvas.Scale method.
+func (c *Canvas) Scale(x, y float64) {
+	c.cur().m = c.cur().m.mul(scaling(x, y))
+	c.Canvas.Scale(x, y)
+}
+
+// Push implements the vg.Canvas.Push method.
+func (c *Canvas) Push() {
+	c.stk = append(c.stk, *c.cur())
+	c.Canvas.Push()
+}
+
+// Pop implements the vg.Canvas.Pop method.
+func (c *Canvas) Pop() {
+	c.stk = c.stk[:len(c.stk)-1]
+	c.Canvas.Pop()
+}
+
+// FillString implements the vg.Canvas.FillString method.
+// The text is written into the character grid starting at
+// the cell containing pt, rather than being rasterized.
+// Text that has been rotated by more than 45° is written
+// vertically, from bottom to top.
+func (c *Canvas) FillString(font vg.Font, pt vg.Point, str string) {
+	m := c.cur().m
+	// Use a point within the body of the glyphs
+	// rather than on the baseline to pick the row.
+	x, y := m.apply(pt.X.Points(), pt.Y.Points()+font.Size.Points()/3)
+	dx, dy := m.dir(1, 0)
+
+	col := int(math.Floor(x / CellWidth.Points()))
+	row := c.rows - 1 - int(math.Floor(y/CellHeight.Points()))
+	stepCol, stepRow := 1, 0
+	if math.Abs(dy) > math.Abs(dx) {
+		stepCol, stepRow = 0, -1
+		if dy < 0 {
+			stepRow = 1
+		}
+	}
+	for _, r := range str {
+		if col >= 0 && col < c.cols && row >= 0 && row < c.rows {
+			c.text[row*c.cols+col] = cell{r: r, clr: c.cur().clr}
+		}
+		col += stepCol
+		row += stepRow
+	}
+}
+
+// WriteTo writes the canvas to w as lines of text.
+func (c *Canvas) WriteTo(w io.Writer) (int64, error) {
+	wc := writerCounter{Writer: w}
+	b := bufio.NewWriter(&wc)
+	bg := c.bg
+	var buf [utf8.UTFMax]byte
+	for row := 0; row < c.rows; row++ {
+		var last color.Color
+		for col := 0; col < c.cols; col++ {
+			r, clr := c.cellAt(col, row, bg)
+			if c.ANSI && clr != nil && !sameColor(clr, last) {
+				cr, cg, cb, _ := clr.RGBA()
+				fmt.Fprintf(b, "\x1b[38;2;%d;%d;%dm", cr>>8, cg>>8, cb>>8)
+				last = clr
+			}
+			n := utf8.EncodeRune(buf[:], r)
+			b.Write(buf[:n])
+		}
+		if c.ANSI && last != nil {
+			b.WriteString("\x1b[0m")
+		}
+		b.WriteByte('\n')
+	}
+	err := b.Flush()
+	return wc.n, err
+}
+
+// cellAt returns the character and color for the cell at
+// the given column and row.
+func (c *Canvas) cellAt(col, row int, bg color.Color) (rune, color.Color) {
+	if t := c.text[row*c.cols+col]; t.r != 0 {
+		return t.r, t.clr
+	}
+	var (
+		pattern    rune
+		n          uint32
+		sr, sg, sb uint32
+	)
+	for y := 0; y < dotsY; y++ {
+		for x := 0; x < dotsX; x++ {
+			px := c.img.At(col*dotsX+x, row*dotsY+y)
+			if !isSet(px, bg) {
+				continue
+			}
+			pattern |= brailleBits[y][x]
+			r, g, b, _ := px.RGBA()
+			sr += r
+			sg += g
+			sb += b
+			n++
+		}
+	}
+	if n == 0 {
+		return ' ', nil
+	}
+	clr := color.RGBA64{R: uint16(sr / n), G: uint16(sg / n), B: uint16(sb / n), A: math.MaxUint16}
+	return brailleBase + pattern, clr
+}
+
+// isSet returns whether the dot color px is
+// distinguishable from the background color.
+func isSet(px, bg color.Color) bool {
+	const threshold = 0x2000
+	r0, g0, b0, _ := px.RGBA()
+	r1, g1, b1, _ := bg.RGBA()
+	return absDiff(r0, r1) > threshold || absDiff(g0, g1) > threshold || absDiff(b0, b1) > threshold
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	r0, g0, b0, a0 := a.RGBA()
+	r1, g1, b1, a1 := b.RGBA()
+	return r0>>8 == r1>>8 && g0>>8 == g1>>8 && b0>>8 == b1>>8 && a0>>8 == a1>>8
+}
+
+// affine is a 2D affine transform [a c e; b d f].
+type affine struct {
+	a, b, c, d, e, f float64
+}
+
+var identity = affine{a: 1, d: 1}
+
+func rotation(t float64) affine {
+	sin, cos := math.Sincos(t)
+	return affine{a: cos, b: sin, c: -sin, d: cos}
+}
+
+func translation(x, y float64) affine {
+	return affine{a: 1, d: 1, e: x, f: y}
+}
+
+func scaling(x, y float64) affine {
+	return affine{a: x, d: y}
+}
+
+// mul returns the transform that applies n and then m.
+func (m affine) mul(n affine) affine {
+	return affine{
+		a: m.a*n.a + m.c*n.b,
+		b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d,
+		d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e,
+		f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
+
+// apply returns the transformed point.
+func (m affine) apply(x, y float64) (float64, float64) {
+	return m.a*x + m.c*y + m.e, m.b*x + m.d*y + m.f
+}
+
+// dir returns the transformed direction vector.
+func (m affine) dir(x, y float64) (float64, float64) {
+	return m.a*x + m.c*y, m.b*x + m.d*y
+}
+
+// writerCounter implements the io.Writer interface, and counts
+// the total number of bytes written.
+type writerCounter struct {
+	io.Writer
+	n int64
+}
+
+func (w *writerCounter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
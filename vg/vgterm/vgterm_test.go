@@ -0,0 +1,121 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vgterm_test
+
+import (
+	"bytes"
+	"image/color"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/plotter"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgterm"
+)
+
+func ExampleCanvas() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "sin(x)"
+	f := plotter.NewFunction(math.Sin)
+	f.Samples = 200
+	p.Add(f)
+	p.X.Min, p.X.Max = 0, 2*math.Pi
+	p.Y.Min, p.Y.Max = -1, 1
+
+	c := vgterm.New(80, 24)
+	p.Draw(draw.New(c))
+	if _, err := c.WriteTo(os.Stdout); err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCanvas(t *testing.T) {
+	c := vgterm.New(4, 2)
+	c.ANSI = false
+
+	// Fill the left half of the top row of cells.
+	c.SetColor(color.Black)
+	w, h := c.Size()
+	c.Fill(vg.Rectangle{
+		Min: vg.Point{X: 0, Y: h / 2},
+		Max: vg.Point{X: w / 2, Y: h},
+	}.Path())
+
+	font, err := vg.MakeFont("Courier", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.FillString(font, vg.Point{X: w / 2, Y: 0}, "ab")
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	want := "⣿⣿  \n  ab\n"
+	if got != want {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestCanvasBackground(t *testing.T) {
+	c := vgterm.New(4, 1)
+	c.ANSI = false
+	c.SetBackground(color.Black)
+
+	// Fill the left half of the cells in white.
+	c.SetColor(color.White)
+	w, h := c.Size()
+	c.Fill(vg.Rectangle{Max: vg.Point{X: w / 2, Y: h}}.Path())
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := buf.String(), "⣿⣿  \n"; got != want {
+		t.Errorf("unexpected output:\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestCanvasRotatedText(t *testing.T) {
+	c := vgterm.New(3, 4)
+	c.ANSI = false
+
+	font, err := vg.MakeFont("Courier", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, h := c.Size()
+	c.Push()
+	c.Rotate(math.Pi / 2)
+	// In the rotated frame, x runs up the canvas
+	// and y runs towards the left.
+	c.FillString(font, vg.Point{X: 1, Y: -vgterm.CellWidth * 1.5}, "up")
+	c.Pop()
+	c.FillString(font, vg.Point{X: 2 * vgterm.CellWidth, Y: h - vgterm.CellHeight}, "r")
+
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	want := []string{"  r", "   ", " p ", " u "}
+	if len(lines) != len(want) {
+		t.Fatalf("unexpected number of lines: got:%d want:%d", len(lines), len(want))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("unexpected line %d: got:%q want:%q", i, lines[i], want[i])
+		}
+	}
+}
@@ -12,13 +12,14 @@ package vgtex
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha1"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
 	"io"
+	"io/ioutil"
 	"math"
-	"os"
 	"strings"
 	"time"
 
@@ -161,6 +162,13 @@ func (c *Canvas) Fill(p vg.Path) {
 	c.wtex("")
 }
 
+// Clip implements the vg.Clipper interface.
+func (c *Canvas) Clip(p vg.Path) {
+	c.wpath(p)
+	c.wtex(`\pgfusepath{clip}`)
+	c.wtex("")
+}
+
 // FillString implements the vg.Canvas.FillString method.
 func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 	c.wcolor()
@@ -168,20 +176,32 @@ func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 	c.wtex(`\pgftext[base,at={\pgfpoint{%gpt}{%gpt}}]{%s}`, pt.X, pt.Y, text)
 }
 
+// TypesetsMath implements the vg.MathTypesetter interface.
+// Math notation in text is typeset by LaTeX.
+func (c *Canvas) TypesetsMath() bool {
+	return true
+}
+
 // DrawImage implements the vg.Canvas.DrawImage method.
 // DrawImage will first save the image inside a PNG file and have the
 // generated LaTeX reference that file.
-// The file name will be "gonum-pgf-image-<canvas-id>-<time.Now()>.png
+// The file name will be "gonum-pgf-image-<canvas-id>-<time.Now()>.png",
+// or "gonum-pgf-image-<sha1-of-png>.png" if vg.Reproducible is true.
 func (c *Canvas) DrawImage(rect vg.Rectangle, img image.Image) {
-	fname := fmt.Sprintf("gonum-pgf-image-%v-%v.png", c.id, time.Now().UnixNano())
-	f, err := os.Create(fname)
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
 	if err != nil {
-		panic(err)
+		panic(fmt.Errorf("vgtex: error encoding image to PNG: %v", err))
+	}
+	var fname string
+	if vg.Reproducible {
+		fname = fmt.Sprintf("gonum-pgf-image-%x.png", sha1.Sum(buf.Bytes()))
+	} else {
+		fname = fmt.Sprintf("gonum-pgf-image-%v-%v.png", c.id, time.Now().UnixNano())
 	}
-	defer f.Close()
-	err = png.Encode(f, img)
+	err = ioutil.WriteFile(fname, buf.Bytes(), 0644)
 	if err != nil {
-		panic(fmt.Errorf("vgtex: error encoding image to PNG: %v", err))
+		panic(err)
 	}
 
 	var (
@@ -6,6 +6,7 @@ package vgtex
 
 import (
 	"bytes"
+	"image"
 	"io/ioutil"
 	"log"
 	"os"
@@ -31,6 +32,7 @@ func Example() {
 	p.Title.Text = `A scatter plot: $\sqrt{\frac{e^{3i\pi}}{2\cos 3\pi}}$`
 	p.X.Label.Text = `$x = \eta$`
 	p.Y.Label.Text = `$y$ is some $\Phi$`
+	p.Title.Math, p.X.Label.Math, p.Y.Label.Math = true, true, true
 
 	c := NewDocument(5*vg.Centimeter, 5*vg.Centimeter)
 	p.Draw(draw.New(c))
@@ -65,3 +67,43 @@ func TestTexCanvas(t *testing.T) {
 		t.Fatalf("test file [%s] and ref file [%s] differ", fname, refname)
 	}
 }
+
+func TestReproducibleImage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vgtex-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	defer func(r bool) { vg.Reproducible = r }(vg.Reproducible)
+	vg.Reproducible = true
+
+	img := image.NewGray(image.Rect(0, 0, 4, 4))
+	var outs [2]bytes.Buffer
+	for i := range outs {
+		c := New(5*vg.Centimeter, 5*vg.Centimeter)
+		c.DrawImage(vg.Rectangle{Max: vg.Point{X: 1, Y: 1}}, img)
+		if _, err := c.WriteTo(&outs[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !bytes.Equal(outs[0].Bytes(), outs[1].Bytes()) {
+		t.Errorf("output is not reproducible:\n%s\n%s", outs[0].Bytes(), outs[1].Bytes())
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("unexpected number of image files: got:%d want:1", len(files))
+	}
+}
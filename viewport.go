@@ -0,0 +1,158 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// A Viewport is a window onto the data of a plot, the ranges of
+// its axes, that may be panned and zoomed within or beyond the
+// full extent of the data, as by the user of an interactive
+// application embedding the plot.
+//
+// The operations of a Viewport set the ranges of the axes of
+// the plot, so that its tick marks are recomputed, and the plot
+// may be drawn again to show the new window. They are done along
+// the scales of the axes, so that zooming a log axis, for example,
+// keeps the tick marks evenly spaced. The methods of a Viewport
+// may be called concurrently with Draw.
+type Viewport struct {
+	p *Plot
+
+	// xmin, xmax, ymin and ymax are the
+	// extent of the data of the plot.
+	xmin, xmax, ymin, ymax float64
+}
+
+// NewViewport returns a Viewport of the plot p whose full
+// extent is the current range of the axes of p. Plotters must
+// be added to p before the Viewport is made, since adding
+// plotters extends the ranges of the axes.
+func NewViewport(p *Plot) *Viewport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.X.sanitizeRange()
+	p.Y.sanitizeRange()
+	return &Viewport{p: p, xmin: p.X.Min, xmax: p.X.Max, ymin: p.Y.Min, ymax: p.Y.Max}
+}
+
+// Extent returns the full extent of the data of the plot.
+func (v *Viewport) Extent() (xmin, xmax, ymin, ymax float64) {
+	return v.xmin, v.xmax, v.ymin, v.ymax
+}
+
+// Window returns the current window, the ranges of the axes.
+func (v *Viewport) Window() (xmin, xmax, ymin, ymax float64) {
+	v.p.mu.Lock()
+	defer v.p.mu.Unlock()
+	return v.p.X.Min, v.p.X.Max, v.p.Y.Min, v.p.Y.Max
+}
+
+// SetWindow sets the window to the given ranges.
+func (v *Viewport) SetWindow(xmin, xmax, ymin, ymax float64) {
+	v.p.mu.Lock()
+	defer v.p.mu.Unlock()
+	v.p.X.Min, v.p.X.Max = xmin, xmax
+	v.p.Y.Min, v.p.Y.Max = ymin, ymax
+}
+
+// Reset sets the window to the full extent of the data.
+func (v *Viewport) Reset() {
+	v.SetWindow(v.xmin, v.xmax, v.ymin, v.ymax)
+}
+
+// Pan moves the window by the fractions dx and dy of its width
+// and height. Positive fractions move the window towards the
+// maxima of the axes, so that the data move towards their minima.
+func (v *Viewport) Pan(dx, dy float64) {
+	v.p.mu.Lock()
+	defer v.p.mu.Unlock()
+	v.pan(dx, dy)
+}
+
+// Zoom zooms the window in by the factors fx and fy along the X
+// and Y axes, about the point at the fractions cx and cy of its
+// width and height, which stays in place. Factors greater than
+// one zoom in, showing less of the data, and factors less than one
+// zoom out. A factor of one leaves the axis unchanged. The factors
+// must be positive.
+func (v *Viewport) Zoom(fx, fy, cx, cy float64) {
+	v.p.mu.Lock()
+	defer v.p.mu.Unlock()
+	v.zoom(fx, fy, cx, cy)
+}
+
+// PanBy moves the window so that the data under the point from
+// of the canvas c, on which the plot is drawn, moves to the
+// point to, as when the plot is dragged by a pointer.
+func (v *Viewport) PanBy(c draw.Canvas, from, to vg.Point) {
+	v.p.syncLinks()
+	v.p.mu.Lock()
+	defer v.p.mu.Unlock()
+	dc := v.p.dataCanvas(c)
+	fx, fy := fraction(dc, from)
+	tx, ty := fraction(dc, to)
+	v.pan(fx-tx, fy-ty)
+}
+
+// ZoomAt zooms the window in by the given factor along both
+// axes about the point pt of the canvas c, on which the plot is
+// drawn, as when the plot is zoomed by a pointer wheel.
+func (v *Viewport) ZoomAt(c draw.Canvas, pt vg.Point, factor float64) {
+	v.p.syncLinks()
+	v.p.mu.Lock()
+	defer v.p.mu.Unlock()
+	cx, cy := fraction(v.p.dataCanvas(c), pt)
+	v.zoom(factor, factor, cx, cy)
+}
+
+// pan and zoom implement Pan and Zoom.
+// They must be called with v.p.mu held.
+func (v *Viewport) pan(dx, dy float64) {
+	v.p.X.window(dx, dx+1)
+	v.p.Y.window(dy, dy+1)
+}
+
+func (v *Viewport) zoom(fx, fy, cx, cy float64) {
+	v.p.X.window(cx-cx/fx, cx+(1-cx)/fx)
+	v.p.Y.window(cy-cy/fy, cy+(1-cy)/fy)
+}
+
+// fraction returns the location of the point pt as fractions
+// of the width and the height of the data canvas dc.
+func fraction(dc draw.Canvas, pt vg.Point) (x, y float64) {
+	size := dc.Size()
+	return float64((pt.X - dc.Min.X) / size.X), float64((pt.Y - dc.Min.Y) / size.Y)
+}
+
+// window sets the range of the axis to that from the fraction
+// lo of the range to the fraction hi, along the scale of the axis.
+func (a *Axis) window(lo, hi float64) {
+	a.Min, a.Max = a.Value(lo), a.Value(hi)
+}
+
+// scaleTransform returns the Transform along which the
+// Normalizer s is linear. Normalizers that are not known
+// are taken to be linear.
+func scaleTransform(s Normalizer) Transform {
+	switch s := s.(type) {
+	case LogScale:
+		return FuncTransform{Func: math.Log, InverseFunc: math.Exp}
+	case LogitScale:
+		return FuncTransform{Func: logit, InverseFunc: expit}
+	case TransformScale:
+		return s.Transform
+	}
+	return FuncTransform{Func: identity, InverseFunc: identity}
+}
+
+func identity(x float64) float64 { return x }
+
+// expit is the inverse of logit.
+func expit(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
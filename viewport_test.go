@@ -0,0 +1,86 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+func TestViewport(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(rangeData{xmin: 0, xmax: 10, ymin: 1, ymax: 1000})
+	p.Y.Scale = LogScale{}
+	v := NewViewport(p)
+
+	const tol = 1e-9
+	check := func(op string, wantXMin, wantXMax, wantYMin, wantYMax float64) {
+		xmin, xmax, ymin, ymax := v.Window()
+		if math.Abs(xmin-wantXMin) > tol || math.Abs(xmax-wantXMax) > tol ||
+			math.Abs(ymin-wantYMin) > tol*wantYMin || math.Abs(ymax-wantYMax) > tol*wantYMax {
+			t.Errorf("unexpected window after %s: got:[%v, %v]×[%v, %v] want:[%v, %v]×[%v, %v]",
+				op, xmin, xmax, ymin, ymax, wantXMin, wantXMax, wantYMin, wantYMax)
+		}
+	}
+
+	v.Pan(0.5, 1.0/3)
+	check("pan", 5, 15, 10, 10000)
+	v.Reset()
+	check("reset", 0, 10, 1, 1000)
+	v.Zoom(2, 3, 0, 0.5)
+	check("zoom", 0, 5, 10, 100)
+	v.Zoom(0.5, 1, 1, 0)
+	check("zoom out", -5, 5, 10, 100)
+
+	v.SetWindow(0, 10, 1, 1000)
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	dc := p.DataCanvas(c)
+	mid := vg.Point{X: (dc.Min.X + dc.Max.X) / 2, Y: (dc.Min.Y + dc.Max.Y) / 2}
+	v.PanBy(c, mid, dc.Max)
+	check("pan by", -5, 5, math.Sqrt(1000)/1000, math.Sqrt(1000))
+	v.SetWindow(0, 10, 1, 1000)
+	v.ZoomAt(c, dc.Min, 10)
+	check("zoom at", 0, 1, 1, math.Pow(1000, 0.1))
+
+	if xmin, xmax, ymin, ymax := v.Extent(); xmin != 0 || xmax != 10 || ymin != 1 || ymax != 1000 {
+		t.Errorf("unexpected extent: got:[%v, %v]×[%v, %v] want:[0, 10]×[1, 1000]", xmin, xmax, ymin, ymax)
+	}
+}
+
+// TestViewportConcurrent checks that a plot may be panned and
+// zoomed by pointer while it is drawn. It is most useful with -race.
+func TestViewportConcurrent(t *testing.T) {
+	p, err := New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(rangeData{xmin: 0, xmax: 10, ymin: 0, ymax: 10})
+	v := NewViewport(p)
+	c := draw.NewCanvas(new(recorder.Canvas), 300, 200)
+	dc := p.DataCanvas(c)
+	mid := vg.Point{X: (dc.Min.X + dc.Max.X) / 2, Y: (dc.Min.Y + dc.Max.Y) / 2}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			v.PanBy(c, mid, mid.Add(vg.Point{X: 1, Y: 1}))
+			v.ZoomAt(c, mid, 1.01)
+			p.HitBoxes(c)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		v.Pan(0.01, 0.01)
+		p.Draw(c)
+	}
+	<-done
+}